@@ -1,6 +1,7 @@
 package hdf5
 
 import (
+	"encoding/binary"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -174,6 +175,33 @@ func TestLoadChildren_V0WithBigEndian(t *testing.T) {
 	require.Equal(t, 24, len(children), "be_data.h5 should have 24 children")
 }
 
+// TestRead_V0BigEndianData verifies that big-endian datasets in a v0 file
+// decode to the same values as their little-endian twins. v0 superblocks
+// have no byte order field; data byte order comes from each datatype.
+func TestRead_V0BigEndianData(t *testing.T) {
+	t.Parallel()
+
+	f, err := Open("testdata/reference/be_data.h5")
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	for _, name := range []string{"Array", "Deflate_float_data", "Fletcher_float_data", "Shuffle_float_data"} {
+		be := findDatasetByPath(t, f, "/"+name+"_be")
+		le := findDatasetByPath(t, f, "/"+name+"_le")
+
+		dt, err := be.Dtype()
+		require.NoError(t, err)
+		require.Equal(t, binary.BigEndian, dt.GetByteOrder(), name)
+
+		beData, err := be.Read()
+		require.NoError(t, err, name)
+		leData, err := le.Read()
+		require.NoError(t, err, name)
+		require.Len(t, beData, 7*6, name)
+		require.Equal(t, leData, beData, name)
+	}
+}
+
 // TestLoadChildren_V0WithDeflate verifies loadChildren correctly
 // identifies Dataset objects in v0 files with compressed data.
 func TestLoadChildren_V0WithDeflate(t *testing.T) {
@@ -0,0 +1,118 @@
+package hdf5
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/scigolib/hdf5/internal/core"
+)
+
+// Well-known attribute names shared by PyTables, NetCDF-4 and NWB.
+const (
+	attrTitle = "TITLE"
+	attrClass = "CLASS"
+)
+
+// SetTitle sets the TITLE attribute of the dataset.
+//
+// The title is stored as a fixed-length, null-terminated string with the
+// ASCII character set, or UTF-8 if it has non-ASCII characters, which is the
+// form PyTables and NetCDF-4 write.
+//
+// Example:
+//
+//	ds.SetTitle("Temperature at 2 m")
+func (ds *DatasetWriter) SetTitle(title string) error {
+	return ds.writeConventionString(attrTitle, title)
+}
+
+// SetClass sets the CLASS attribute of the dataset (e.g. "TABLE", "ARRAY",
+// or "DIMENSION_SCALE"), stored like SetTitle.
+func (ds *DatasetWriter) SetClass(class string) error {
+	return ds.writeConventionString(attrClass, class)
+}
+
+// SetTitle sets the TITLE attribute of the group. See DatasetWriter.SetTitle.
+func (g *GroupWriter) SetTitle(title string) error {
+	return g.writeConventionString(attrTitle, title)
+}
+
+// SetClass sets the CLASS attribute of the group (e.g. "GROUP").
+// See DatasetWriter.SetClass.
+func (g *GroupWriter) SetClass(class string) error {
+	return g.writeConventionString(attrClass, class)
+}
+
+// Title returns the TITLE attribute of the dataset.
+// Fixed-length and variable-length string attributes are both accepted.
+func (d *Dataset) Title() (string, error) {
+	return conventionString(d.Attributes, attrTitle)
+}
+
+// Class returns the CLASS attribute of the dataset.
+func (d *Dataset) Class() (string, error) {
+	return conventionString(d.Attributes, attrClass)
+}
+
+// Title returns the TITLE attribute of the group.
+func (g *Group) Title() (string, error) {
+	return conventionString(g.Attributes, attrTitle)
+}
+
+// Class returns the CLASS attribute of the group.
+func (g *Group) Class() (string, error) {
+	return conventionString(g.Attributes, attrClass)
+}
+
+func (ds *DatasetWriter) writeConventionString(name, value string) error {
+	if err := validateConventionString(name, value); err != nil {
+		return err
+	}
+	return ds.WriteAttribute(name, value)
+}
+
+func (g *GroupWriter) writeConventionString(name, value string) error {
+	if err := validateConventionString(name, value); err != nil {
+		return err
+	}
+	return g.WriteAttribute(name, value)
+}
+
+// validateConventionString rejects values that cannot be stored as
+// null-terminated strings.
+func validateConventionString(name, value string) error {
+	if strings.IndexByte(value, 0) >= 0 {
+		return fmt.Errorf("%s attribute %q contains a null byte", name, value)
+	}
+	return nil
+}
+
+// conventionString reads a string attribute by name. A one-element string
+// array, as some writers store, is accepted as well.
+func conventionString(attributes func() ([]*core.Attribute, error), name string) (string, error) {
+	attrs, err := attributes()
+	if err != nil {
+		return "", err
+	}
+
+	for _, attr := range attrs {
+		if attr.Name != name {
+			continue
+		}
+		value, err := attr.ReadValue()
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s attribute: %w", name, err)
+		}
+		switch v := value.(type) {
+		case string:
+			return v, nil
+		case []string:
+			if len(v) == 1 {
+				return v[0], nil
+			}
+		}
+		return "", fmt.Errorf("%s attribute is not a single string (got %T)", name, value)
+	}
+
+	return "", fmt.Errorf("attribute %q not found", name)
+}
@@ -0,0 +1,66 @@
+package hdf5
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/scigolib/hdf5/internal/core"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConventionAttributes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "conventions.h5")
+	fw, err := CreateForWrite(path, CreateTruncate)
+	require.NoError(t, err)
+
+	ds, err := fw.CreateDataset("/table", Float64, []uint64{3})
+	require.NoError(t, err)
+	require.NoError(t, ds.SetTitle("Température à 2 m"))
+	require.NoError(t, ds.SetClass("TABLE"))
+	require.ErrorContains(t, ds.SetTitle("bad\x00title"), "null byte")
+
+	grp, err := fw.CreateGroup("/group")
+	require.NoError(t, err)
+	require.NoError(t, grp.SetClass("GROUP"))
+	require.NoError(t, grp.WriteAttribute("TITLE", []string{"stored as vlen"}))
+	require.NoError(t, fw.Close())
+
+	f, err := Open(path)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	table := findDatasetByPath(t, f, "/table")
+	title, err := table.Title()
+	require.NoError(t, err)
+	require.Equal(t, "Température à 2 m", title)
+	class, err := table.Class()
+	require.NoError(t, err)
+	require.Equal(t, "TABLE", class)
+
+	attrs, err := table.Attributes()
+	require.NoError(t, err)
+	charsets := make(map[string]uint8)
+	for _, attr := range attrs {
+		require.Equal(t, core.StringPadNullTerm, attr.Datatype.GetStringPadding(), attr.Name)
+		charsets[attr.Name] = attr.Datatype.GetStringCharset()
+	}
+	require.Equal(t, map[string]uint8{"TITLE": core.CharsetUTF8, "CLASS": core.CharsetASCII}, charsets)
+
+	var group *Group
+	f.Walk(func(p string, obj Object) {
+		if g, ok := obj.(*Group); ok && strings.TrimSuffix(p, "/") == "/group" {
+			group = g
+		}
+	})
+	require.NotNil(t, group)
+	class, err = group.Class()
+	require.NoError(t, err)
+	require.Equal(t, "GROUP", class)
+	title, err = group.Title()
+	require.NoError(t, err)
+	require.Equal(t, "stored as vlen", title)
+
+	_, err = f.Root().Title()
+	require.ErrorContains(t, err, "not found")
+}
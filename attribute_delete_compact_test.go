@@ -154,3 +154,77 @@ func TestAttributeDeletion_Compact(t *testing.T) {
 		t.Errorf("Failed to close file: %v", err)
 	}
 }
+
+// TestAttributeDeletion_CompactContinuation deletes an attribute that lives in
+// an object header continuation chunk and checks that neither the remaining
+// attributes nor a neighbouring dataset are damaged.
+func TestAttributeDeletion_CompactContinuation(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "attr_delete_continuation.h5")
+
+	fw, err := hdf5.CreateForWrite(testFile, hdf5.CreateTruncate)
+	if err != nil {
+		t.Fatalf("Failed to create file: %v", err)
+	}
+
+	ds, err := fw.CreateDataset("/data", hdf5.Int32, []uint64{4})
+	if err != nil {
+		t.Fatalf("Failed to create dataset: %v", err)
+	}
+	neighbour, err := fw.CreateDataset("/neighbour", hdf5.Int32, []uint64{4})
+	if err != nil {
+		t.Fatalf("Failed to create neighbour dataset: %v", err)
+	}
+	if err := neighbour.Write([]int32{1, 2, 3, 4}); err != nil {
+		t.Fatalf("Failed to write neighbour: %v", err)
+	}
+
+	// Enough payload to overflow the initial header allocation.
+	names := []string{"note0", "note1", "note2", "note3", "note4", "note5"}
+	for _, name := range names {
+		value := name + ": ................................................"
+		if err := ds.WriteAttribute(name, value); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	if err := ds.DeleteAttribute("note5"); err != nil {
+		t.Fatalf("Failed to delete note5: %v", err)
+	}
+	if err := fw.Close(); err != nil {
+		t.Fatalf("Failed to close file: %v", err)
+	}
+
+	f, err := hdf5.Open(testFile)
+	if err != nil {
+		t.Fatalf("Failed to open file: %v", err)
+	}
+	defer f.Close()
+
+	datasets := make(map[string]*hdf5.Dataset)
+	f.Walk(func(path string, obj hdf5.Object) {
+		if d, ok := obj.(*hdf5.Dataset); ok {
+			datasets[path] = d
+		}
+	})
+
+	got, err := datasets["/data"].ListAttributes()
+	if err != nil {
+		t.Fatalf("Failed to list attributes: %v", err)
+	}
+	if len(got) != len(names)-1 {
+		t.Fatalf("Expected %d attributes, got %v", len(names)-1, got)
+	}
+	for _, name := range got {
+		if name == "note5" {
+			t.Error("Deleted attribute 'note5' still exists")
+		}
+	}
+
+	values, err := datasets["/neighbour"].Read()
+	if err != nil {
+		t.Fatalf("Failed to read neighbour: %v", err)
+	}
+	if len(values) != 4 || values[3] != 4 {
+		t.Errorf("Neighbour data corrupted: %v", values)
+	}
+}
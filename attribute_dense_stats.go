@@ -0,0 +1,74 @@
+package hdf5
+
+import (
+	"fmt"
+
+	"github.com/scigolib/hdf5/internal/core"
+	"github.com/scigolib/hdf5/internal/structures"
+)
+
+// DenseStats describes the dense attribute storage of an object: the fractal
+// heap holding the attribute messages and the B-tree v2 indexing them by name.
+type DenseStats struct {
+	HeapManagedSpace      uint64 // Total managed space of the heap, in bytes
+	HeapAllocatedSpace    uint64 // Managed space allocated to heap blocks, in bytes
+	HeapFreeSpace         uint64 // Free space in heap blocks, in bytes
+	HeapManagedObjects    uint64 // Number of objects (attribute messages) in the heap
+	BTreeRecords          uint64 // Number of name index records
+	BTreeNodes            uint64 // Number of name index nodes
+	FractalHeapAddress    uint64 // Address of the fractal heap header
+	BTreeNameIndexAddress uint64 // Address of the name index B-tree header
+}
+
+// DenseAttributeStats returns the state of the dataset's dense attribute
+// storage, for diagnostics and tests. Heap and B-tree counts should agree
+// with the number of attributes; a mismatch points to corrupted storage.
+//
+// Returns:
+//   - DenseStats: Heap and B-tree statistics
+//   - error: If the dataset does not use dense attribute storage or it cannot be read
+//
+// Example:
+//
+//	stats, err := ds.DenseAttributeStats()
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("%d attributes, %d bytes free\n", stats.BTreeRecords, stats.HeapFreeSpace)
+func (ds *DatasetWriter) DenseAttributeStats() (DenseStats, error) {
+	sb := ds.fileWriter.file.Superblock()
+	reader := ds.fileWriter.writer.Reader()
+
+	attrInfo := ds.denseAttrInfo
+	if attrInfo == nil {
+		oh, err := core.ReadObjectHeader(reader, ds.address, sb)
+		if err != nil {
+			return DenseStats{}, fmt.Errorf("failed to read object header: %w", err)
+		}
+		if attrInfo, err = findAttributeInfo(oh, sb); err != nil {
+			return DenseStats{}, err
+		}
+	}
+
+	heap := structures.NewWritableFractalHeap(64 * 1024) // Match size from dense attribute writer
+	if err := heap.LoadFromFile(reader, attrInfo.FractalHeapAddr, sb); err != nil {
+		return DenseStats{}, fmt.Errorf("failed to load fractal heap: %w", err)
+	}
+
+	btree := structures.NewWritableBTreeV2(4096) // Match size from dense attribute writer
+	if err := btree.LoadFromFile(reader, attrInfo.BTreeNameIndexAddr, sb); err != nil {
+		return DenseStats{}, fmt.Errorf("failed to load B-tree: %w", err)
+	}
+	records, nodes := btree.Stats()
+
+	return DenseStats{
+		HeapManagedSpace:      heap.Header.ManagedSpaceSize,
+		HeapAllocatedSpace:    heap.Header.AllocatedManagedSpace,
+		HeapFreeSpace:         heap.Header.FreeSpace,
+		HeapManagedObjects:    heap.Header.NumManagedObjects,
+		BTreeRecords:          records,
+		BTreeNodes:            nodes,
+		FractalHeapAddress:    attrInfo.FractalHeapAddr,
+		BTreeNameIndexAddress: attrInfo.BTreeNameIndexAddr,
+	}, nil
+}
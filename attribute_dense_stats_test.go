@@ -0,0 +1,52 @@
+package hdf5
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDenseAttributeStats(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dense_stats.h5")
+	fw, err := CreateForWrite(path, CreateTruncate)
+	require.NoError(t, err)
+
+	ds, err := fw.CreateDataset("/data", Int32, []uint64{4})
+	require.NoError(t, err)
+
+	require.NoError(t, ds.WriteAttribute("compact", int32(1)))
+	_, err = ds.DenseAttributeStats()
+	require.ErrorContains(t, err, "dense storage not initialized")
+
+	for i := 0; i < 11; i++ {
+		require.NoError(t, ds.WriteAttribute(fmt.Sprintf("attr_%02d", i), int32(i)))
+	}
+
+	stats, err := ds.DenseAttributeStats()
+	require.NoError(t, err)
+	require.Equal(t, uint64(12), stats.BTreeRecords)
+	require.Equal(t, uint64(12), stats.HeapManagedObjects)
+	require.Equal(t, uint64(1), stats.BTreeNodes)
+	require.NotZero(t, stats.HeapManagedSpace)
+	require.LessOrEqual(t, stats.HeapFreeSpace, stats.HeapManagedSpace)
+	require.NotZero(t, stats.FractalHeapAddress)
+	require.NotZero(t, stats.BTreeNameIndexAddress)
+
+	require.NoError(t, ds.DeleteAttribute("attr_00"))
+	stats, err = ds.DenseAttributeStats()
+	require.NoError(t, err)
+	require.Equal(t, uint64(11), stats.BTreeRecords)
+	require.NoError(t, fw.Close())
+
+	fw, err = OpenForWrite(path, OpenReadWrite)
+	require.NoError(t, err)
+	defer func() { _ = fw.Close() }()
+	reopened, err := fw.OpenDataset("/data")
+	require.NoError(t, err)
+
+	stats, err = reopened.DenseAttributeStats()
+	require.NoError(t, err)
+	require.Equal(t, uint64(11), stats.BTreeRecords)
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/scigolib/hdf5"
@@ -857,3 +858,136 @@ func TestAttributeDeletion_DenseNotFound(t *testing.T) {
 
 	_ = fw.Close()
 }
+
+// TestAttributeModification_DenseGrowString tests growing a string attribute
+// in dense storage, then again after reopening the file.
+//
+// Reference: H5Adense.c - H5A__dense_write().
+func TestAttributeModification_DenseGrowString(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "attr_dense_grow.h5")
+
+	fw, err := hdf5.CreateForWrite(testFile, hdf5.CreateTruncate)
+	if err != nil {
+		t.Fatalf("Failed to create file: %v", err)
+	}
+	ds, err := fw.CreateDataset("/data", hdf5.Int32, []uint64{5})
+	if err != nil {
+		t.Fatalf("Failed to create dataset: %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		if err := ds.WriteAttribute(fmt.Sprintf("padding_%d", i), int32(i)); err != nil {
+			t.Fatalf("Failed to write padding attribute %d: %v", i, err)
+		}
+	}
+	if err := ds.WriteAttribute("name", "A"); err != nil {
+		t.Fatalf("Failed to write short string: %v", err)
+	}
+	if err := ds.WriteAttribute("name", "AAAAAAAA"); err != nil {
+		t.Fatalf("Failed to grow string: %v", err)
+	}
+	if err := fw.Close(); err != nil {
+		t.Fatalf("Failed to close file: %v", err)
+	}
+	if got := readDenseStringAttr(t, testFile, "name"); got != "AAAAAAAA" {
+		t.Errorf("name = %q, want %q", got, "AAAAAAAA")
+	}
+
+	fw, err = hdf5.OpenForWrite(testFile, hdf5.OpenReadWrite)
+	if err != nil {
+		t.Fatalf("Failed to reopen file: %v", err)
+	}
+	dsw, err := fw.OpenDataset("/data")
+	if err != nil {
+		t.Fatalf("Failed to open dataset: %v", err)
+	}
+	if err := dsw.WriteAttribute("name", "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA"); err != nil {
+		t.Fatalf("Failed to grow string after reopen: %v", err)
+	}
+	if err := fw.Close(); err != nil {
+		t.Fatalf("Failed to close file: %v", err)
+	}
+	if got := readDenseStringAttr(t, testFile, "name"); got != "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA" {
+		t.Errorf("name after reopen = %q", got)
+	}
+}
+
+// TestAttributeModification_DenseRepeatedResize resizes a dense attribute
+// until the space of the replaced values fills the heap, which must then be
+// compacted without losing the other attributes.
+func TestAttributeModification_DenseRepeatedResize(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "attr_dense_resize.h5")
+
+	fw, err := hdf5.CreateForWrite(testFile, hdf5.CreateTruncate)
+	if err != nil {
+		t.Fatalf("Failed to create file: %v", err)
+	}
+	ds, err := fw.CreateDataset("/data", hdf5.Int32, []uint64{5})
+	if err != nil {
+		t.Fatalf("Failed to create dataset: %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		if err := ds.WriteAttribute(fmt.Sprintf("padding_%d", i), int32(i)); err != nil {
+			t.Fatalf("Failed to write padding attribute %d: %v", i, err)
+		}
+	}
+	// About 80 KB of values in total, more than the 64 KB heap block.
+	var last string
+	for n := 1; n <= 400; n++ {
+		last = strings.Repeat("x", n)
+		if err := ds.WriteAttribute("log", last); err != nil {
+			t.Fatalf("Failed to write %d-byte value: %v", n, err)
+		}
+	}
+	if err := fw.Close(); err != nil {
+		t.Fatalf("Failed to close file: %v", err)
+	}
+
+	if got := readDenseStringAttr(t, testFile, "log"); got != last {
+		t.Errorf("log has %d bytes, want %d", len(got), len(last))
+	}
+
+	attrs, err := datasetsByPath(t, testFile)["/data"].Attributes()
+	if err != nil {
+		t.Fatalf("Failed to read attributes: %v", err)
+	}
+	if len(attrs) != 11 {
+		t.Fatalf("Expected 11 attributes, got %d", len(attrs))
+	}
+	for _, attr := range attrs {
+		if !strings.HasPrefix(attr.Name, "padding_") {
+			continue
+		}
+		value, err := attr.ReadValue()
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", attr.Name, err)
+		}
+		if want := attr.Name[len("padding_"):]; fmt.Sprint(value) != want {
+			t.Errorf("%s = %v, want %s", attr.Name, value, want)
+		}
+	}
+}
+
+// readDenseStringAttr reads a string attribute of the /data dataset.
+func readDenseStringAttr(t *testing.T, path, name string) string {
+	t.Helper()
+	attrs, err := datasetsByPath(t, path)["/data"].Attributes()
+	if err != nil {
+		t.Fatalf("Failed to read attributes: %v", err)
+	}
+	for _, attr := range attrs {
+		if attr.Name != name {
+			continue
+		}
+		value, err := attr.ReadValue()
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", name, err)
+		}
+		s, ok := value.(string)
+		if !ok {
+			t.Fatalf("%s is %T, want string", name, value)
+		}
+		return s
+	}
+	t.Fatalf("Attribute %q not found", name)
+	return ""
+}
@@ -0,0 +1,106 @@
+package hdf5
+
+import (
+	"fmt"
+
+	"github.com/scigolib/hdf5/internal/core"
+)
+
+// Attributes returns all attributes attached to the named datatype.
+// Files may use committed datatypes to carry metadata shared by the
+// datasets that use them.
+func (n *NamedDatatype) Attributes() ([]*core.Attribute, error) {
+	return n.file.objectAttributes(n.address)
+}
+
+// ListAttributes returns the names of all attributes attached to the named
+// datatype.
+func (n *NamedDatatype) ListAttributes() ([]string, error) {
+	attrs, err := n.Attributes()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(attrs))
+	for i, attr := range attrs {
+		names[i] = attr.Name
+	}
+	return names, nil
+}
+
+// ReadAttribute reads a single attribute of the named datatype by name.
+func (n *NamedDatatype) ReadAttribute(name string) (interface{}, error) {
+	return readAttributeByName(n.Attributes, name)
+}
+
+// ReadAttributes returns the attributes of the object at path, whether it is
+// a group, a dataset or a named datatype. Paths are resolved like Exists.
+//
+// Example:
+//
+//	attrs, err := f.ReadAttributes("/types/sample_t")
+//	for _, a := range attrs {
+//	    v, _ := a.ReadValue()
+//	    fmt.Println(a.Name, v)
+//	}
+func (f *File) ReadAttributes(path string) ([]*core.Attribute, error) {
+	obj, err := f.lookup(path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch o := obj.(type) {
+	case *Group:
+		return o.Attributes()
+	case *Dataset:
+		attrs, err := o.Attributes()
+		if err != nil || attrs != nil {
+			return attrs, err
+		}
+		return []*core.Attribute{}, nil
+	case *NamedDatatype:
+		return o.Attributes()
+	default:
+		return f.objectAttributes(obj.Address())
+	}
+}
+
+// ReadAttribute reads the attribute name of the object at path, whether it is
+// a group, a dataset or a named datatype.
+//
+// Example:
+//
+//	units, err := f.ReadAttribute("/data", "units")
+func (f *File) ReadAttribute(path, name string) (interface{}, error) {
+	return readAttributeByName(func() ([]*core.Attribute, error) {
+		return f.ReadAttributes(path)
+	}, name)
+}
+
+// objectAttributes reads the attributes of the object header at address,
+// compact or dense, whatever kind of object it belongs to.
+func (f *File) objectAttributes(address uint64) ([]*core.Attribute, error) {
+	header, err := core.ReadObjectHeader(f.reader, address, f.sb)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object header: %w", err)
+	}
+	if header.Attributes == nil {
+		return []*core.Attribute{}, nil
+	}
+	return header.Attributes, nil
+}
+
+// readAttributeByName returns the value of the attribute name from the list
+// returned by attributes.
+func readAttributeByName(attributes func() ([]*core.Attribute, error), name string) (interface{}, error) {
+	attrs, err := attributes()
+	if err != nil {
+		return nil, err
+	}
+	for _, attr := range attrs {
+		if attr.Name == name {
+			return attr.ReadValue()
+		}
+	}
+	return nil, fmt.Errorf("attribute %q not found", name)
+}
@@ -0,0 +1,149 @@
+package hdf5
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/scigolib/hdf5/internal/core"
+	"github.com/stretchr/testify/require"
+)
+
+// TestReadAttributes_AnyObject verifies that attributes are read the same way
+// from a named datatype, a dataset and a group. In tnamed_dtype_attr.h5 the
+// committed datatype /Datatype carries an attribute of its own.
+func TestReadAttributes_AnyObject(t *testing.T) {
+	f, err := Open("testdata/hdf5_official/tnamed_dtype_attr.h5")
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	obj, err := f.lookup("/Datatype")
+	require.NoError(t, err)
+	named, ok := obj.(*NamedDatatype)
+	require.True(t, ok)
+
+	names, err := named.ListAttributes()
+	require.NoError(t, err)
+	require.Equal(t, []string{"Attribute"}, names)
+	value, err := named.ReadAttribute("Attribute")
+	require.NoError(t, err)
+	require.Equal(t, int32(8), value)
+
+	for _, path := range []string{"/Datatype", "/Link_to_Datatype", "/Dataset", "/g1"} {
+		attrs, err := f.ReadAttributes(path)
+		require.NoError(t, err, path)
+		require.Len(t, attrs, 1, path)
+
+		value, err := f.ReadAttribute(path, "Attribute")
+		require.NoError(t, err, path)
+		require.Equal(t, int32(8), value, path)
+	}
+
+	attrs, err := f.ReadAttributes("/")
+	require.NoError(t, err)
+	require.Empty(t, attrs)
+
+	_, err = f.ReadAttribute("/Datatype", "missing")
+	require.ErrorContains(t, err, "not found")
+	_, err = f.ReadAttributes("/missing")
+	require.Error(t, err)
+}
+
+// TestReadAttribute_FixedStringReferenceFile reads the fixed-length UTF-8
+// "unit" attributes of flux.h5, which were not written by this package.
+func TestReadAttribute_FixedStringReferenceFile(t *testing.T) {
+	f, err := Open("testdata/reference/flux.h5")
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	attrs, err := f.ReadAttributes("/group1/flux/value")
+	require.NoError(t, err)
+	require.Len(t, attrs, 1)
+	require.True(t, attrs[0].Datatype.IsFixedString())
+	require.Equal(t, core.CharsetUTF8, attrs[0].Datatype.GetStringCharset())
+
+	unit, err := f.ReadAttribute("/group1/flux/value", "unit")
+	require.NoError(t, err)
+	require.Equal(t, "particles/s", unit)
+
+	unit, err = f.ReadAttribute("/group1/flux/time/value", "unit")
+	require.NoError(t, err)
+	require.Equal(t, "s", unit)
+}
+
+func TestAttributeCount(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "attr_count.h5")
+
+	fw, err := CreateForWrite(filename, CreateTruncate)
+	require.NoError(t, err)
+	_, err = fw.CreateDataset("/none", Int32, []uint64{1})
+	require.NoError(t, err)
+	compact, err := fw.CreateDataset("/compact", Int32, []uint64{1})
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, compact.WriteAttribute(fmt.Sprintf("a%d", i), int32(i)))
+	}
+	dense, err := fw.CreateDataset("/dense", Int32, []uint64{1})
+	require.NoError(t, err)
+	for i := 0; i < 20; i++ {
+		require.NoError(t, dense.WriteAttribute(fmt.Sprintf("attr_%02d", i), int32(i)))
+	}
+	grp, err := fw.CreateGroup("/grp")
+	require.NoError(t, err)
+	require.NoError(t, grp.WriteAttribute("title", "run"))
+	require.NoError(t, grp.WriteAttribute("id", int64(4)))
+	require.NoError(t, fw.Close())
+
+	f, err := Open(filename)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	for path, want := range map[string]int{"/none": 0, "/compact": 3, "/dense": 20} {
+		ds := findDatasetByPath(t, f, path)
+		n, err := ds.AttributeCount()
+		require.NoError(t, err, path)
+		require.Equal(t, want, n, path)
+		attrs, err := ds.Attributes()
+		require.NoError(t, err, path)
+		require.Len(t, attrs, n, path)
+	}
+
+	obj, err := f.lookup("/grp")
+	require.NoError(t, err)
+	g, _ := obj.AsGroup()
+	n, err := g.AttributeCount()
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+}
+
+func TestReadAttribute_RankZeroScalars(t *testing.T) {
+	// Both attributes have rank-0 dataspaces: a version 1 dataspace message in
+	// with_attributes.h5 and a version 2 one in the netCDF-4 file.
+	f, err := Open("testdata/with_attributes.h5")
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	attrs, err := f.ReadAttributes("/")
+	require.NoError(t, err)
+	var pi *core.Attribute
+	for _, a := range attrs {
+		if a.Name == "pi" {
+			pi = a
+		}
+	}
+	require.NotNil(t, pi)
+	require.True(t, pi.Dataspace.IsScalar())
+
+	value, err := f.ReadAttribute("/", "pi")
+	require.NoError(t, err)
+	require.IsType(t, float64(0), value)
+	require.InDelta(t, 3.14159, value, 1e-5)
+
+	nc, err := Open("testdata/dense_links.h5")
+	require.NoError(t, err)
+	defer func() { _ = nc.Close() }()
+
+	dimid, err := nc.ReadAttribute("/x", "_Netcdf4Dimid")
+	require.NoError(t, err)
+	require.IsType(t, int32(0), dimid)
+}
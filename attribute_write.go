@@ -2,9 +2,12 @@ package hdf5
 
 import (
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"math"
 	"reflect"
+	"strings"
+	"unicode/utf8"
 	"unsafe"
 
 	"github.com/scigolib/hdf5/internal/core"
@@ -12,29 +15,47 @@ import (
 	"github.com/scigolib/hdf5/internal/writer"
 )
 
-// Attribute storage threshold.
+// Attribute storage thresholds (defaults for WithMaxCompactAttributes and
+// WithMinDenseAttributes).
 const (
 	// MaxCompactAttributes is the threshold for transitioning to dense storage.
-	// When an object has 8+ attributes, dense storage (Fractal Heap + B-tree)
-	// is more efficient than compact storage (object header messages).
+	// When an object has more than 8 attributes, dense storage (Fractal Heap +
+	// B-tree) is more efficient than compact storage (object header messages).
 	MaxCompactAttributes = 8
+
+	// MinDenseAttributes is the threshold for transitioning back to compact
+	// storage: a dense object left with fewer than 6 attributes after a
+	// deletion moves them back into its object header.
+	MinDenseAttributes = 6
 )
 
 // WriteAttribute writes an attribute to a dataset.
 //
-// Storage strategy (automatic):
-//   - 0-7 attributes: Compact storage (object header messages)
-//   - 8+ attributes: Dense storage (Fractal Heap + B-tree v2)
+// Storage strategy (automatic, thresholds set by WithMaxCompactAttributes):
+//   - Up to 8 attributes: Compact storage (object header messages)
+//   - 9+ attributes: Dense storage (Fractal Heap + B-tree v2)
 //
 // Supported value types:
 //   - Scalars: int8, int16, int32, int64, uint8, uint16, uint32, uint64, float32, float64
 //   - Arrays: []int32, []float64, etc. (1D arrays only)
 //   - Strings: string (fixed-length, converted to byte array)
 //   - String arrays: []string (variable-length strings via Global Heap)
+//   - Structs: MyStruct or []MyStruct (compound type, see below)
+//   - Empty slices: []int32{}, []float64{}, []MyStruct{}, etc. (NULL dataspace)
+//
+// An empty slice is stored with the datatype of its elements and a NULL
+// dataspace, which holds no data; ReadValue returns it as an empty slice.
+// Empty []string values are rejected.
+//
+// Struct values are stored with a compound datatype whose members are the
+// exported fields in declaration order, packed without padding. The `hdf5`
+// struct tag renames a member and `hdf5:"-"` skips a field. Fields may be
+// sized integers, float32/float64, strings (stored fixed-length, sized to the
+// longest value) or nested structs.
 //
 // Parameters:
-//   - name: Attribute name (ASCII, no null bytes)
-//   - value: Attribute value (Go scalar, slice, or string)
+//   - name: Attribute name (non-empty ASCII without null bytes; UTF-8 with WithUTF8AttributeNames)
+//   - value: Attribute value (Go scalar, slice, string or struct)
 //
 // Returns:
 //   - error: If attribute cannot be written
@@ -46,9 +67,9 @@ const (
 //	ds.WriteAttribute("sensor_id", int32(42))
 //	ds.WriteAttribute("calibration", []float64{1.0, 0.0})
 //	ds.WriteAttribute("topics", []string{"camera", "lidar", "imu"})
+//	ds.WriteAttribute("channels", []Channel{{ID: 1, Gain: 2.5, Label: "ch1"}})
 //
 // Limitations:
-//   - No compound types
 //   - Attributes cannot be modified after creation (write-once)
 //   - No attribute deletion
 func (ds *DatasetWriter) WriteAttribute(name string, value interface{}) error {
@@ -64,8 +85,10 @@ func (ds *DatasetWriter) WriteAttribute(name string, value interface{}) error {
 // DeleteAttribute removes an attribute by name from the dataset.
 //
 // This method supports both compact and dense attribute storage:
-// - Compact storage (0-7 attributes): Removes message from object header
-// - Dense storage (8+ attributes): Removes from B-tree and fractal heap
+//   - Compact storage: Removes message from object header
+//   - Dense storage: Removes from B-tree and fractal heap, moving the remaining
+//     attributes back to compact storage once fewer than min dense remain
+//     (WithMinDenseAttributes)
 //
 // Parameters:
 //   - name: Attribute name to delete
@@ -77,13 +100,40 @@ func (ds *DatasetWriter) WriteAttribute(name string, value interface{}) error {
 func (ds *DatasetWriter) DeleteAttribute(name string) error {
 	// For datasets opened with OpenForWrite, use cached object header and dense attr info
 	if ds.objectHeader != nil {
-		return deleteAttributeWithCachedHeader(ds.fileWriter, ds.address, ds.objectHeader, ds.denseAttrInfo, name)
+		err := deleteAttributeWithCachedHeader(ds.fileWriter, ds.address, ds.objectHeader, ds.denseAttrInfo, name)
+		if err != nil {
+			return err
+		}
+		// The deletion may have moved messages between chunks or moved the
+		// attributes back to compact storage.
+		return ds.refreshAttributeInfo()
 	}
 
 	// For datasets created in this session, read object header fresh
 	return deleteAttribute(ds.fileWriter, ds.address, name)
 }
 
+// refreshAttributeInfo re-reads the cached object header and dense attribute
+// info of a dataset opened with OpenDataset.
+func (ds *DatasetWriter) refreshAttributeInfo() error {
+	sb := ds.fileWriter.file.Superblock()
+	oh, err := core.ReadObjectHeader(ds.fileWriter.writer.Reader(), ds.address, sb)
+	if err != nil {
+		return fmt.Errorf("failed to re-read object header: %w", err)
+	}
+	ds.objectHeader = oh
+	ds.denseAttrInfo = nil
+	for _, msg := range oh.Messages {
+		if msg.Type == core.MsgAttributeInfo {
+			ds.denseAttrInfo, err = core.ParseAttributeInfoMessage(msg.Data, sb)
+			if err != nil {
+				return fmt.Errorf("failed to parse attribute info: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
 // RebalanceAttributeBTree manually triggers B-tree rebalancing for this dataset's dense attribute storage.
 //
 // Use this when:
@@ -91,13 +141,9 @@ func (ds *DatasetWriter) DeleteAttribute(name string) error {
 //   - More efficient than RebalanceAllBTrees() for targeted optimization
 //   - After batch deletions with rebalancing disabled
 //
-// Performance (for current MVP with single-leaf B-trees):
-//   - Instant (< 1ms) - no-op for single-leaf trees
-//
-// Future (when multi-level B-trees implemented):
-//   - Small (<1000 attrs): <10ms
-//   - Medium (1000-10000 attrs): 10-100ms
-//   - Large (10000+ attrs): 100ms-1s
+// Performance:
+//   - Instant (< 1ms): name index B-trees are laid out with evenly filled
+//     nodes every time they are written, so there is nothing to rebalance
 //
 // Returns:
 //   - error: if dataset doesn't use dense storage or rebalancing fails
@@ -137,8 +183,7 @@ func (ds *DatasetWriter) RebalanceAttributeBTree() error {
 			return fmt.Errorf("failed to rebalance B-tree: %w", err)
 		}
 
-		// For MVP: RebalanceAll() is a no-op (single-leaf trees are already optimal)
-		// Future: If tree was modified, write it back to disk here
+		// RebalanceAll() is a no-op: written trees are already balanced.
 
 		return nil
 	}
@@ -180,29 +225,38 @@ func (ds *DatasetWriter) RebalanceAttributeBTree() error {
 		return fmt.Errorf("failed to rebalance B-tree: %w", err)
 	}
 
-	// For MVP: RebalanceAll() is a no-op
-	// Future: Write modified tree back to disk
+	// RebalanceAll() is a no-op: written trees are already balanced.
 
 	return nil
 }
 
 // writeAttribute is the internal implementation for writing attributes.
 //
-// Storage strategy:
-// - 0-7 attributes: Compact storage (object header messages)
-// - 8+ attributes: Dense storage (Fractal Heap + B-tree v2)
+// Storage strategy (see FileWriter.attributePhaseChange for the thresholds):
+// - Up to max compact attributes: Compact storage (object header messages)
+// - More: Dense storage (Fractal Heap + B-tree v2)
 //
 // Automatic transition:
-// - When adding the 8th attribute, all attributes are migrated to dense storage
+// - When adding an attribute beyond max compact, all attributes are migrated to dense storage
 // - Compact attribute messages are removed from object header
 // - Attribute Info Message is added to object header
 //
-// For MVP:
-// - Transition is one-way (compact → dense only, no dense → compact)
-// - No attribute deletion support
+// Deleting attributes below min dense moves them back (see transitionToCompactAttributes).
 //
 // Reference: H5Aint.c - H5A__dense_create().
 func writeAttribute(fw *FileWriter, objectAddr uint64, name string, value interface{}) error {
+	// Infer datatype and encode attribute (handles []string via Global Heap).
+	attr, err := encodeAttribute(fw, name, value)
+	if err != nil {
+		return err
+	}
+
+	return writeEncodedAttribute(fw, objectAddr, attr)
+}
+
+// writeEncodedAttribute adds or replaces an encoded attribute, choosing the
+// storage as described for writeAttribute.
+func writeEncodedAttribute(fw *FileWriter, objectAddr uint64, attr *core.Attribute) error {
 	// Get superblock
 	sb := fw.file.Superblock()
 
@@ -231,86 +285,137 @@ func writeAttribute(fw *FileWriter, objectAddr uint64, name string, value interf
 	}
 	// Total compact attributes includes both main and continuation.
 	totalCompactCount := compactCount + continuationAttrCount
+	maxCompact, _ := fw.attributePhaseChange(oh)
 
 	// Determine storage strategy
 	if hasDenseStorage {
 		// Already using dense storage → add to dense
-		return writeDenseAttribute(fw, objectAddr, oh, name, value, sb)
+		return writeDenseAttribute(fw, objectAddr, oh, attr, sb)
 	}
 
-	if totalCompactCount < MaxCompactAttributes {
+	if totalCompactCount < maxCompact {
 		// Still compact -> add compact attribute.
-		return writeCompactAttribute(fw, objectAddr, oh, name, value, sb)
+		return writeCompactAttribute(fw, objectAddr, oh, attr, sb)
 	}
 
 	// Transition needed -> migrate to dense.
-	return transitionToDenseAttributes(fw, objectAddr, oh, name, value, sb)
+	return transitionToDenseAttributes(fw, objectAddr, oh, []*core.Attribute{attr}, sb)
 }
 
-// writeCompactAttribute writes attribute to object header (compact storage).
-//
-// Implements OHDR bounds checking and continuation chunks (OCHK) per H5Oalloc.c:
-//   - If the modified OHDR fits within the original allocation, rewrite in place.
-//   - If it overflows, move the new attribute to a continuation chunk (OCHK)
-//     and add a small continuation message (type 0x0010) to the main OHDR.
-//
-// This prevents corruption of adjacent structures when attributes are added.
-func writeCompactAttribute(fw *FileWriter, objectAddr uint64, oh *core.ObjectHeader,
-	name string, value interface{}, sb *core.Superblock) error {
-	// 1. Infer datatype and encode attribute (handles []string via Global Heap).
+// encodeAttribute infers the datatype of value and encodes it as an attribute.
+func encodeAttribute(fw *FileWriter, name string, value interface{}) (*core.Attribute, error) {
+	if err := validateAttributeName(name, fw.config != nil && fw.config.UTF8AttributeNames); err != nil {
+		return nil, err
+	}
+
 	datatype, dataspace, data, err := inferAndEncodeAttributeValue(fw, value)
 	if err != nil {
-		return fmt.Errorf("failed to infer/encode attribute: %w", err)
+		return nil, fmt.Errorf("failed to infer/encode attribute: %w", err)
 	}
 
-	attr := &core.Attribute{
+	return &core.Attribute{
 		Name:      name,
 		Datatype:  datatype,
 		Dataspace: dataspace,
 		Data:      data,
+	}, nil
+}
+
+// validateAttributeName checks that name can be stored as an attribute name:
+// it must be non-empty and free of null bytes, which terminate names in the
+// attribute message, and ASCII unless allowUTF8 is set, in which case it must
+// be valid UTF-8.
+//
+// Reference: H5A.c - H5Acreate2() (name checks), H5Oattr.c - H5O__attr_encode().
+func validateAttributeName(name string, allowUTF8 bool) error {
+	if name == "" {
+		return fmt.Errorf("attribute name cannot be empty")
+	}
+	if strings.IndexByte(name, 0) >= 0 {
+		return fmt.Errorf("attribute name %q contains a null byte", name)
 	}
+	for i := 0; i < len(name); i++ {
+		if name[i] < utf8.RuneSelf {
+			continue
+		}
+		if !allowUTF8 {
+			return fmt.Errorf("attribute name %q is not ASCII (use WithUTF8AttributeNames to allow UTF-8)", name)
+		}
+		if !utf8.ValidString(name) {
+			return fmt.Errorf("attribute name %q is not valid UTF-8", name)
+		}
+		break
+	}
+	return nil
+}
+
+// stringCharset returns the HDF5 character set for s: UTF-8 if it has any
+// non-ASCII byte, ASCII otherwise.
+func stringCharset(s string) uint8 {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return core.CharsetUTF8
+		}
+	}
+	return core.CharsetASCII
+}
 
-	// 2. Check if attribute exists (for upsert semantics).
+// writeCompactAttribute writes attribute to object header (compact storage).
+//
+// Implements OHDR bounds checking and continuation chunks (OCHK) per H5Oalloc.c:
+//   - If the modified OHDR fits within the original allocation, rewrite in place.
+//   - If it overflows, move the new attribute to a continuation chunk (OCHK)
+//     and add a small continuation message (type 0x0010) to the main OHDR.
+//
+// This prevents corruption of adjacent structures when attributes are added.
+func writeCompactAttribute(fw *FileWriter, objectAddr uint64, oh *core.ObjectHeader,
+	attr *core.Attribute, sb *core.Superblock) error {
+	// 1. Check if attribute exists (for upsert semantics).
 	existingIndex := -1
 	for i, msg := range oh.Messages {
 		if msg.Type == core.MsgAttribute {
 			existingAttr, parseErr := core.ParseAttributeMessage(msg.Data, sb.Endianness)
-			if parseErr == nil && existingAttr.Name == name {
+			if parseErr == nil && existingAttr.Name == attr.Name {
 				existingIndex = i
 				break
 			}
 		}
 	}
 
-	// 3. Encode attribute message.
-	attrMsg, err := core.EncodeAttributeFromStruct(attr, sb)
+	// 2. Encode attribute message.
+	attrMsg, err := fw.encodeAttributeMessage(attr, sb)
 	if err != nil {
 		return fmt.Errorf("failed to encode attribute message: %w", err)
 	}
 
-	// 4. Upsert: replace if exists.
+	// 3. Upsert: replace if exists.
 	if existingIndex >= 0 {
 		oh.Messages[existingIndex].Data = attrMsg
+		// The attribute may live in a continuation chunk, which must be
+		// rewritten rather than folded into the main chunk.
+		if hasContinuationMessages(oh) {
+			return rewriteWithContinuationChunk(fw, objectAddr, oh, sb)
+		}
 		return writeOHDRWithBoundsCheck(fw, objectAddr, oh, sb)
 	}
 
-	// 5. Remove null padding messages and continuation-sourced messages.
+	// 4. Remove null padding messages and continuation-sourced messages.
 	// Null messages (type 0) are used as padding and can be safely removed.
 	// Messages from OCHK continuation blocks should not be rewritten into the main OHDR.
 	oh.Messages = filterMainChunkMessages(oh.Messages)
 
-	// 6. Add new attribute message.
+	// 5. Add new attribute message.
 	if err := core.AddMessageToObjectHeader(oh, core.MsgAttribute, attrMsg); err != nil {
 		return fmt.Errorf("failed to add message to header: %w", err)
 	}
 
-	// 7. Bounds check: does the modified OHDR fit in its allocation?
+	// 6. Bounds check: does the modified OHDR fit in its allocation?
 	allocSize := fw.lookupHeaderAllocSize(objectAddr)
 	newSize := core.ObjectHeaderSizeFromParsed(oh)
 
 	if allocSize > 0 && newSize > allocSize {
 		// Overflow: the new attribute doesn't fit. Use a continuation chunk.
-		return writeAttributeViaContinuation(fw, objectAddr, oh, attrMsg, name, value, sb, allocSize)
+		return writeAttributeViaContinuation(fw, objectAddr, oh, attrMsg, attr, sb, allocSize)
 	}
 
 	// Fits in allocation (or allocation unknown for legacy files).
@@ -327,13 +432,7 @@ func writeOHDRWithBoundsCheck(fw *FileWriter, objectAddr uint64, oh *core.Object
 	// Update allocator if the object header grew beyond currently tracked EOF.
 	newHeaderSize := core.ObjectHeaderSizeFromParsed(oh)
 	objectHeaderEnd := objectAddr + newHeaderSize
-	allocator := fw.writer.Allocator()
-	if allocator.EndOfFile() < objectHeaderEnd {
-		bytesToAdvance := objectHeaderEnd - allocator.EndOfFile()
-		if _, allocErr := allocator.Allocate(bytesToAdvance); allocErr != nil {
-			return fmt.Errorf("failed to advance allocator past grown object header: %w", allocErr)
-		}
-	}
+	fw.writer.Allocator().AdvanceEndOfFile(objectHeaderEnd)
 
 	return nil
 }
@@ -346,18 +445,47 @@ func writeOHDRWithBoundsCheck(fw *FileWriter, objectAddr uint64, oh *core.Object
 //  4. Rewrites the main OHDR (which now has the small continuation message instead
 //     of the large attribute message, so it should fit).
 //
-// If even the continuation message doesn't fit, fall back to dense storage transition.
+// If even the continuation message doesn't fit, the attributes still in the main
+// OHDR are moved into the OCHK as well, so that objects reach the configured
+// compact attribute limit before going dense. Only when the main OHDR has no
+// attributes left to move does it fall back to dense storage transition.
 func writeAttributeViaContinuation(fw *FileWriter, objectAddr uint64, oh *core.ObjectHeader,
-	attrMsg []byte, name string, value interface{}, sb *core.Superblock, allocSize uint64) error {
+	attrMsg []byte, attr *core.Attribute, sb *core.Superblock, allocSize uint64) error {
 	// Remove the last message (the attribute we just added that caused overflow).
 	lastIdx := len(oh.Messages) - 1
 	oh.Messages = oh.Messages[:lastIdx]
 
-	// Write the attribute to an OCHK continuation block.
 	ochkMessages := []core.MessageWriter{
 		{Type: core.MsgAttribute, Data: attrMsg},
 	}
-	ochkSize := core.ContinuationChunkSizeV2(ochkMessages)
+
+	if !fitsWithContinuation(oh, sb, allocSize) {
+		var moved []core.MessageWriter
+		kept := make([]*core.HeaderMessage, 0, len(oh.Messages))
+		for _, msg := range oh.Messages {
+			if msg.Type == core.MsgAttribute {
+				moved = append(moved, core.MessageWriter{
+					Type: msg.Type, Data: msg.Data, Flags: msg.Flags, CreationIndex: msg.CreationIndex,
+				})
+				continue
+			}
+			kept = append(kept, msg)
+		}
+		oh.Messages = kept
+		if len(moved) == 0 || !fitsWithContinuation(oh, sb, allocSize) {
+			if fw.earliest() {
+				// Dense storage is not available: gather all attributes
+				// in one continuation chunk instead.
+				return gatherAttributesInContinuation(fw, objectAddr, attr, attrMsg, sb, allocSize)
+			}
+			// Even the continuation message doesn't fit -- fall back to dense.
+			return transitionToDenseAttributes(fw, objectAddr, oh, []*core.Attribute{attr}, sb)
+		}
+		ochkMessages = append(moved, ochkMessages...)
+	}
+
+	// Write the attributes to an OCHK continuation block.
+	ochkSize := core.ContinuationChunkSize(oh.Version, oh.Flags, ochkMessages)
 
 	allocator := fw.writer.Allocator()
 	ochkAddr, err := allocator.Allocate(ochkSize)
@@ -365,7 +493,7 @@ func writeAttributeViaContinuation(fw *FileWriter, objectAddr uint64, oh *core.O
 		return fmt.Errorf("failed to allocate OCHK continuation block: %w", err)
 	}
 
-	if _, err := core.WriteContinuationChunkV2(fw.writer, ochkAddr, ochkMessages); err != nil {
+	if _, err := core.WriteContinuationChunk(fw.writer, ochkAddr, oh.Version, oh.Flags, ochkMessages); err != nil {
 		return fmt.Errorf("failed to write OCHK continuation block: %w", err)
 	}
 
@@ -375,19 +503,56 @@ func writeAttributeViaContinuation(fw *FileWriter, objectAddr uint64, oh *core.O
 		return fmt.Errorf("failed to add continuation message: %w", err)
 	}
 
-	// Check if the OHDR with continuation message fits.
-	newSize := core.ObjectHeaderSizeFromParsed(oh)
-	if newSize > allocSize {
-		// Even the continuation message doesn't fit -- fall back to dense.
-		// Remove the continuation message we just added.
-		oh.Messages = oh.Messages[:len(oh.Messages)-1]
-		return transitionToDenseAttributes(fw, objectAddr, oh, name, value, sb)
-	}
-
 	// Rewrite the main OHDR (now with continuation message instead of attribute).
 	return writeOHDRWithBoundsCheck(fw, objectAddr, oh, sb)
 }
 
+// gatherAttributesInContinuation adds an attribute to an object header whose
+// main chunk is full of attributes and continuation messages, for writers that
+// cannot use dense storage: all attributes, the new one included, are moved to
+// a single new continuation chunk. The old continuation chunks become dead
+// space.
+func gatherAttributesInContinuation(fw *FileWriter, objectAddr uint64, attr *core.Attribute,
+	attrMsg []byte, sb *core.Superblock, allocSize uint64) error {
+	oh, err := core.ReadObjectHeader(fw.writer.Reader(), objectAddr, sb)
+	if err != nil {
+		return fmt.Errorf("failed to re-read object header: %w", err)
+	}
+
+	var main []*core.HeaderMessage
+	for _, msg := range oh.Messages {
+		switch {
+		case msg.Type == core.MsgAttribute:
+			msg.FromContinuation = true
+		case !msg.FromContinuation && msg.Type != core.MsgNil && msg.Type != core.MsgContinuation:
+			main = append(main, msg)
+		}
+	}
+	if !fitsWithContinuation(&core.ObjectHeader{Version: oh.Version, Flags: oh.Flags, Messages: main}, sb, allocSize) {
+		return fmt.Errorf("attribute %q does not fit in the object header", attr.Name)
+	}
+
+	oh.Messages = append(oh.Messages, &core.HeaderMessage{
+		Type:             core.MsgAttribute,
+		Data:             attrMsg,
+		FromContinuation: true,
+	})
+	return rewriteWithContinuationChunk(fw, objectAddr, oh, sb)
+}
+
+// fitsWithContinuation reports whether the main OHDR still fits in allocSize
+// after adding one continuation message.
+func fitsWithContinuation(oh *core.ObjectHeader, sb *core.Superblock, allocSize uint64) bool {
+	placeholder := &core.HeaderMessage{
+		Type: core.MsgContinuation,
+		Data: core.EncodeContinuationMessage(0, 0, sb),
+	}
+	oh.Messages = append(oh.Messages, placeholder)
+	size := core.ObjectHeaderSizeFromParsed(oh)
+	oh.Messages = oh.Messages[:len(oh.Messages)-1]
+	return size <= allocSize
+}
+
 // filterMainChunkMessages removes null padding messages and messages that
 // originated from OCHK continuation blocks. This ensures that when rewriting
 // the main OHDR, we only include messages that belong in the main chunk.
@@ -427,9 +592,14 @@ func writeAttributeWithCachedHeader(fw *FileWriter, objectAddr uint64, oh *core.
 	denseAttrInfo *core.AttributeInfoMessage, name string, value interface{}) error {
 	sb := fw.file.Superblock()
 
+	attr, err := encodeAttribute(fw, name, value)
+	if err != nil {
+		return err
+	}
+
 	// If dense storage info is available, use it directly
 	if denseAttrInfo != nil {
-		return writeDenseAttributeWithInfo(fw, objectAddr, oh, denseAttrInfo, name, value, sb)
+		return writeDenseAttributes(fw, denseAttrInfo, []*core.Attribute{attr}, sb)
 	}
 
 	// No dense storage yet - re-read OHDR to get accurate message count
@@ -447,104 +617,138 @@ func writeAttributeWithCachedHeader(fw *FileWriter, objectAddr uint64, oh *core.
 		}
 		if msg.Type == core.MsgAttributeInfo {
 			// Dense storage was set up by a previous transition -- use it directly.
-			return writeDenseAttribute(fw, objectAddr, freshOH, name, value, sb)
+			return writeDenseAttribute(fw, objectAddr, freshOH, attr, sb)
 		}
 	}
 
-	if compactCount < MaxCompactAttributes {
-		return writeCompactAttribute(fw, objectAddr, freshOH, name, value, sb)
+	if maxCompact, _ := fw.attributePhaseChange(freshOH); compactCount < maxCompact {
+		return writeCompactAttribute(fw, objectAddr, freshOH, attr, sb)
 	}
 
-	return transitionToDenseAttributes(fw, objectAddr, freshOH, name, value, sb)
+	return transitionToDenseAttributes(fw, objectAddr, freshOH, []*core.Attribute{attr}, sb)
 }
 
-// writeDenseAttributeWithInfo writes or modifies attribute in existing dense storage.
+// writeDenseAttributes writes or modifies attributes in existing dense storage.
 //
-// This implements upsert semantics for dense attributes:
-// - If attribute exists → modify it (Phase 2: Dense modification)
-// - If attribute doesn't exist → create it (Phase 3: Dense RMW)
+// The fractal heap and name index B-tree are loaded once, every attribute is
+// upserted (modified if the name exists, inserted otherwise), and both
+// structures are written back in place once.
 //
-// This is similar to writeDenseAttribute but uses the cached AttributeInfoMessage
-// instead of searching for it in the object header.
-func writeDenseAttributeWithInfo(fw *FileWriter, _ uint64, _ *core.ObjectHeader,
-	attrInfo *core.AttributeInfoMessage, name string, value interface{}, sb *core.Superblock) error {
+// Reference: H5Adense.c - H5A__dense_insert(), H5A__dense_write().
+func writeDenseAttributes(fw *FileWriter, attrInfo *core.AttributeInfoMessage,
+	attrs []*core.Attribute, sb *core.Superblock) error {
 	// Load existing fractal heap from file
-	heap := structures.NewWritableFractalHeap(64 * 1024)
+	heap := structures.NewWritableFractalHeap(64 * 1024) // Match size from dense attribute writer
 	err := heap.LoadFromFile(fw.writer.Reader(), attrInfo.FractalHeapAddr, sb)
 	if err != nil {
 		return fmt.Errorf("failed to load fractal heap: %w", err)
 	}
 
 	// Load existing B-tree v2 from file
-	btree := structures.NewWritableBTreeV2(4096)
+	btree := structures.NewWritableBTreeV2(4096) // Match size from dense attribute writer
 	err = btree.LoadFromFile(fw.writer.Reader(), attrInfo.BTreeNameIndexAddr, sb)
 	if err != nil {
 		return fmt.Errorf("failed to load B-tree: %w", err)
 	}
 
-	// Prepare new attribute (handles []string via Global Heap).
-	datatype, dataspace, data, err := inferAndEncodeAttributeValue(fw, value)
+	for _, attr := range attrs {
+		if err := upsertDenseAttribute(heap, btree, attr, sb); err != nil {
+			return err
+		}
+	}
+
+	// Write updated structures back to file (IN-PLACE using WriteAt)
+	// NOTE: WriteAt() writes to the addresses where structures were loaded from
+	// This is true Read-Modify-Write - no new allocations!
+	err = heap.WriteAt(fw.writer, sb)
 	if err != nil {
-		return fmt.Errorf("failed to infer/encode attribute: %w", err)
+		return fmt.Errorf("failed to write updated heap: %w", err)
 	}
 
-	attr := &core.Attribute{
-		Name:      name,
-		Datatype:  datatype,
-		Dataspace: dataspace,
-		Data:      data,
+	err = btree.WriteAt(fw.writer, fw.writer.Allocator(), sb)
+	if err != nil {
+		return fmt.Errorf("failed to write updated B-tree: %w", err)
 	}
 
+	return nil
+}
+
+// upsertDenseAttribute modifies the attribute if the B-tree has its name and
+// inserts it into the heap and B-tree otherwise. The structures are only
+// updated in memory.
+//
+// Replaced values leave unused space in the heap. When the heap has no room
+// left, it is compacted and the upsert retried.
+func upsertDenseAttribute(heap *structures.WritableFractalHeap, btree *structures.WritableBTreeV2,
+	attr *core.Attribute, sb *core.Superblock) error {
 	// Encode attribute message
 	attrMsg, err := core.EncodeAttributeFromStruct(attr, sb)
 	if err != nil {
 		return fmt.Errorf("failed to encode attribute: %w", err)
 	}
 
-	// Check if attribute already exists (upsert semantics)
-	_, exists := btree.SearchRecord(name)
-
-	if exists { //nolint:nestif // Clear upsert logic
-		// Modify existing attribute (Phase 2)
-		// Set the encoded data in attr for ModifyDenseAttribute
-		attr.Data = attrMsg
-		err = core.ModifyDenseAttribute(heap, btree, name, attr)
-		if err != nil {
-			return fmt.Errorf("failed to modify existing dense attribute: %w", err)
+	err = storeDenseAttribute(heap, btree, attr, attrMsg)
+	if errors.Is(err, structures.ErrHeapFull) {
+		if err := compactDenseAttributeHeap(heap, btree); err != nil {
+			return err
 		}
-	} else {
-		// Create new attribute (Phase 3 - original RMW code)
+		err = storeDenseAttribute(heap, btree, attr, attrMsg)
+	}
+	return err
+}
 
-		// Insert into fractal heap
-		heapIDBytes, insertErr := heap.InsertObject(attrMsg)
-		if insertErr != nil {
-			return fmt.Errorf("failed to insert into heap: %w", insertErr)
+// storeDenseAttribute stores an encoded attribute message in the heap and
+// B-tree, replacing the attribute of the same name if there is one.
+func storeDenseAttribute(heap *structures.WritableFractalHeap, btree *structures.WritableBTreeV2,
+	attr *core.Attribute, attrMsg []byte) error {
+	// Check if attribute already exists (upsert semantics)
+	if _, exists := btree.SearchRecord(attr.Name); exists {
+		// ModifyDenseAttribute expects the encoded message in Data.
+		modified := *attr
+		modified.Data = attrMsg
+		if err := core.ModifyDenseAttribute(heap, btree, attr.Name, &modified); err != nil {
+			return fmt.Errorf("failed to modify existing dense attribute: %w", err)
 		}
+		return nil
+	}
 
-		// Convert heap ID to uint64 for B-tree
-		if len(heapIDBytes) != 8 {
-			return fmt.Errorf("unexpected heap ID length: %d bytes", len(heapIDBytes))
-		}
-		heapID := binary.LittleEndian.Uint64(heapIDBytes)
+	// Insert into fractal heap
+	heapIDBytes, err := heap.InsertObject(attrMsg)
+	if err != nil {
+		return fmt.Errorf("failed to insert into heap: %w", err)
+	}
 
-		// Insert into B-tree
-		err = btree.InsertRecord(name, heapID)
-		if err != nil {
-			return fmt.Errorf("failed to insert into B-tree: %w", err)
-		}
+	// Convert heap ID to uint64 for B-tree
+	if len(heapIDBytes) != 8 {
+		return fmt.Errorf("unexpected heap ID length: %d bytes", len(heapIDBytes))
 	}
+	heapID := binary.LittleEndian.Uint64(heapIDBytes)
 
-	// Write updated structures back to file (IN-PLACE using WriteAt)
-	err = heap.WriteAt(fw.writer, sb)
-	if err != nil {
-		return fmt.Errorf("failed to write updated heap: %w", err)
+	// Insert into B-tree
+	if err := btree.InsertRecord(attr.Name, heapID); err != nil {
+		return fmt.Errorf("failed to insert into B-tree: %w", err)
 	}
+	return nil
+}
 
-	err = btree.WriteAt(fw.writer, sb)
+// compactDenseAttributeHeap reclaims the heap space of replaced and deleted
+// attributes: the attributes indexed by the B-tree are packed at the start
+// of the heap and their records updated.
+func compactDenseAttributeHeap(heap *structures.WritableFractalHeap, btree *structures.WritableBTreeV2) error {
+	records := btree.GetRecords()
+	ids := make([][]byte, len(records))
+	for i, record := range records {
+		ids[i] = append(record.HeapID[:], 0)
+	}
+	newIDs, err := heap.Compact(ids)
 	if err != nil {
-		return fmt.Errorf("failed to write updated B-tree: %w", err)
+		return fmt.Errorf("failed to compact attribute heap: %w", err)
+	}
+	for i, id := range newIDs {
+		if err := btree.SetRecordHeapID(i, id); err != nil {
+			return fmt.Errorf("failed to update B-tree record: %w", err)
+		}
 	}
-
 	return nil
 }
 
@@ -577,7 +781,11 @@ func deleteAttribute(fw *FileWriter, objectAddr uint64, name string) error {
 
 	if hasDenseStorage {
 		// Dense storage → delete from B-tree and heap
-		return deleteDenseAttributeFromHeader(fw, objectAddr, oh, name, sb)
+		if err := deleteDenseAttributeFromHeader(fw, objectAddr, oh, name, sb); err != nil {
+			return err
+		}
+		_, err := transitionToCompactAttributes(fw, objectAddr, sb)
+		return err
 	}
 
 	// Compact storage → delete from object header
@@ -608,7 +816,11 @@ func deleteAttributeWithCachedHeader(fw *FileWriter, objectAddr uint64, oh *core
 
 		// Delete from heap and B-tree
 		// Note: Attribute count is implicit in B-tree record count, no explicit field to update
-		return deleteDenseAttributeImpl(fw, denseAttrInfo, name, sb)
+		if err := deleteDenseAttributeImpl(fw, denseAttrInfo, name, sb); err != nil {
+			return err
+		}
+		_, err := transitionToCompactAttributes(fw, objectAddr, sb)
+		return err
 	}
 
 	// No dense storage - delete from compact
@@ -643,6 +855,15 @@ func deleteCompactAttributeFromHeader(fw *FileWriter, objectAddr uint64, oh *cor
 	// Remove message (direct removal - clean approach)
 	oh.Messages = append(oh.Messages[:msgIndex], oh.Messages[msgIndex+1:]...)
 
+	// Headers with continuation chunks must keep those messages out of the
+	// main chunk, or the rewritten header would outgrow its allocation.
+	if hasContinuationMessages(oh) {
+		if err := rewriteWithContinuationChunk(fw, objectAddr, oh, sb); err != nil {
+			return fmt.Errorf("failed to write object header after deletion: %w", err)
+		}
+		return nil
+	}
+
 	// Write back object header to disk
 	err := core.WriteObjectHeader(fw.writer, objectAddr, oh, sb)
 	if err != nil {
@@ -652,6 +873,62 @@ func deleteCompactAttributeFromHeader(fw *FileWriter, objectAddr uint64, oh *cor
 	return nil
 }
 
+// hasContinuationMessages reports whether a parsed header spans OCHK
+// continuation blocks.
+func hasContinuationMessages(oh *core.ObjectHeader) bool {
+	for _, msg := range oh.Messages {
+		if msg.FromContinuation || msg.Type == core.MsgContinuation {
+			return true
+		}
+	}
+	return false
+}
+
+// rewriteWithContinuationChunk rewrites a v2 object header whose messages span
+// continuation blocks: main-chunk messages are written back in place, and the
+// remaining continuation messages are gathered into a single new OCHK block
+// referenced by one continuation message. The old OCHK blocks become dead space.
+//
+// Reference: H5Oalloc.c - H5O__alloc_chunk(), H5O__chunk_delete().
+func rewriteWithContinuationChunk(fw *FileWriter, objectAddr uint64, oh *core.ObjectHeader, sb *core.Superblock) error {
+	mainMessages := make([]*core.HeaderMessage, 0, len(oh.Messages))
+	var ochkMessages []core.MessageWriter
+	for _, msg := range oh.Messages {
+		switch {
+		case msg.Type == core.MsgNil || msg.Type == core.MsgContinuation:
+			// Padding and old continuation pointers are rebuilt below.
+		case msg.FromContinuation:
+			ochkMessages = append(ochkMessages, core.MessageWriter{
+				Type:          msg.Type,
+				Data:          msg.Data,
+				Flags:         msg.Flags,
+				CreationIndex: msg.CreationIndex,
+			})
+		default:
+			mainMessages = append(mainMessages, msg)
+		}
+	}
+	oh.Messages = mainMessages
+
+	if len(ochkMessages) > 0 {
+		ochkSize := core.ContinuationChunkSize(oh.Version, oh.Flags, ochkMessages)
+		ochkAddr, err := fw.writer.Allocator().Allocate(ochkSize)
+		if err != nil {
+			return fmt.Errorf("failed to allocate OCHK continuation block: %w", err)
+		}
+		if _, err := core.WriteContinuationChunk(fw.writer, ochkAddr, oh.Version, oh.Flags, ochkMessages); err != nil {
+			return fmt.Errorf("failed to write OCHK continuation block: %w", err)
+		}
+
+		contMsgData := core.EncodeContinuationMessage(ochkAddr, ochkSize, sb)
+		if err := core.AddMessageToObjectHeader(oh, core.MsgContinuation, contMsgData); err != nil {
+			return fmt.Errorf("failed to add continuation message: %w", err)
+		}
+	}
+
+	return writeOHDRWithBoundsCheck(fw, objectAddr, oh, sb)
+}
+
 // deleteDenseAttributeFromHeader deletes attribute from dense storage by reading Attribute Info from header.
 func deleteDenseAttributeFromHeader(fw *FileWriter, _ uint64, oh *core.ObjectHeader, name string, sb *core.Superblock) error {
 	// Find Attribute Info Message
@@ -710,7 +987,7 @@ func deleteDenseAttributeImpl(fw *FileWriter, attrInfo *core.AttributeInfoMessag
 	}
 
 	// Write updated B-tree back to file
-	err = btree.WriteAt(fw.writer, sb)
+	err = btree.WriteAt(fw.writer, fw.writer.Allocator(), sb)
 	if err != nil {
 		return fmt.Errorf("failed to write updated B-tree: %w", err)
 	}
@@ -725,119 +1002,144 @@ func deleteDenseAttributeImpl(fw *FileWriter, attrInfo *core.AttributeInfoMessag
 //
 // Process:
 // 1. Find Attribute Info Message in object header
-// 2. Load existing WritableFractalHeap from file
-// 3. Load existing WritableBTreeV2 from file
-// 4. Add new attribute to loaded structures
-// 5. Write updated heap and B-tree back to file (overwrite existing)
+// 2. Load existing WritableFractalHeap and WritableBTreeV2 from file
+// 3. Add new attribute to loaded structures
+// 4. Write updated heap and B-tree back to file (overwrite existing)
 //
 // This enables adding attributes to datasets that already have dense storage
 // (i.e., files that were created, closed, and reopened).
 //
 // Reference: H5Adense.c - H5A__dense_insert().
-//
-//nolint:gocyclo,cyclop // Complex RMW logic with multiple verification steps
 func writeDenseAttribute(fw *FileWriter, _ uint64, oh *core.ObjectHeader,
-	name string, value interface{}, sb *core.Superblock) error {
-	// Step 1: Find Attribute Info Message
-	var attrInfo *core.AttributeInfoMessage
+	attr *core.Attribute, sb *core.Superblock) error {
+	attrInfo, err := findAttributeInfo(oh, sb)
+	if err != nil {
+		return err
+	}
+
+	return writeDenseAttributes(fw, attrInfo, []*core.Attribute{attr}, sb)
+}
+
+// findAttributeInfo returns the Attribute Info Message of an object header
+// using dense attribute storage.
+func findAttributeInfo(oh *core.ObjectHeader, sb *core.Superblock) (*core.AttributeInfoMessage, error) {
 	for _, msg := range oh.Messages {
 		if msg.Type == core.MsgAttributeInfo {
-			// Parse the message data
-			parsed, err := core.ParseAttributeInfoMessage(msg.Data, sb)
+			attrInfo, err := core.ParseAttributeInfoMessage(msg.Data, sb)
 			if err != nil {
-				return fmt.Errorf("failed to parse attribute info message: %w", err)
+				return nil, fmt.Errorf("failed to parse attribute info message: %w", err)
 			}
-			attrInfo = parsed
-			break
+			return attrInfo, nil
 		}
 	}
+	return nil, fmt.Errorf("attribute info message not found (dense storage not initialized)")
+}
 
-	if attrInfo == nil {
-		return fmt.Errorf("attribute info message not found (dense storage not initialized)")
+// attributePhaseChange returns the attribute storage thresholds for the
+// object with header oh: the values recorded in the header when it stores
+// them (OHDRAttrStorePhaseChange), otherwise the file writer's configuration.
+//
+// Reference: H5Oattribute.c - H5O__attr_create(), H5O__attr_remove().
+func (fw *FileWriter) attributePhaseChange(oh *core.ObjectHeader) (maxCompact, minDense int) {
+	if oh != nil && oh.Version == 2 && oh.Flags&core.OHDRAttrStorePhaseChange != 0 {
+		return int(oh.MaxCompactAttributes), int(oh.MinDenseAttributes)
 	}
-
-	// Step 2: Load existing fractal heap from file
-	heap := structures.NewWritableFractalHeap(64 * 1024) // Match size from dense attribute writer
-	err := heap.LoadFromFile(fw.writer.Reader(), attrInfo.FractalHeapAddr, sb)
-	if err != nil {
-		return fmt.Errorf("failed to load fractal heap: %w", err)
+	if fw.config == nil {
+		return MaxCompactAttributes, MinDenseAttributes
 	}
+	return fw.config.MaxCompactAttributes, fw.config.MinDenseAttributes
+}
 
-	// Step 3: Load existing B-tree v2 from file
-	btree := structures.NewWritableBTreeV2(4096) // Match size from dense attribute writer
-	err = btree.LoadFromFile(fw.writer.Reader(), attrInfo.BTreeNameIndexAddr, sb)
-	if err != nil {
-		return fmt.Errorf("failed to load B-tree: %w", err)
-	}
+// stampAttributePhaseChange records non-default attribute storage thresholds
+// in a new object header, so other writers apply the same thresholds. Like
+// stampTimes, it must be called before the header is sized.
+//
+// Reference: H5Ocache.c - H5O__cache_serialize() (H5O_HDR_ATTR_STORE_PHASE_CHANGE).
+func (fw *FileWriter) stampAttributePhaseChange(ohw *core.ObjectHeaderWriter) {
+	if fw.config == nil || ohw.Version != 2 {
+		return
+	}
+	maxCompact, minDense := fw.config.MaxCompactAttributes, fw.config.MinDenseAttributes
+	if maxCompact == MaxCompactAttributes && minDense == MinDenseAttributes {
+		return
+	}
+	ohw.Flags |= core.OHDRAttrStorePhaseChange
+	ohw.MaxCompactAttributes = uint16(maxCompact) //nolint:gosec // G115: validated by validateAttributePhaseChange
+	ohw.MinDenseAttributes = uint16(minDense)     //nolint:gosec // G115: validated by validateAttributePhaseChange
+}
 
-	// Step 4: Prepare new attribute (handles []string via Global Heap).
-	datatype, dataspace, data, err := inferAndEncodeAttributeValue(fw, value)
+// transitionToCompactAttributes moves the attributes of an object in dense
+// storage back into its object header once fewer than min dense attributes
+// remain. It reports whether the object was converted.
+//
+// Attributes that do not fit in the header's allocation (for objects not
+// created by this writer, its current size) are written to a continuation
+// chunk. The conversion is skipped, leaving the attributes dense, when the
+// header already has continuation chunks. The dense structures are left in
+// place as unused file space.
+//
+// Reference: H5Oattribute.c - H5O__attr_remove() (H5A__dense_convert_to_compact).
+func transitionToCompactAttributes(fw *FileWriter, objectAddr uint64, sb *core.Superblock) (bool, error) {
+	oh, err := core.ReadObjectHeader(fw.writer.Reader(), objectAddr, sb)
 	if err != nil {
-		return fmt.Errorf("failed to infer/encode attribute: %w", err)
+		return false, fmt.Errorf("failed to re-read object header: %w", err)
 	}
 
-	attr := &core.Attribute{
-		Name:      name,
-		Datatype:  datatype,
-		Dataspace: dataspace,
-		Data:      data,
+	var kept []*core.HeaderMessage
+	for _, msg := range oh.Messages {
+		if msg.Type == core.MsgContinuation || msg.FromContinuation {
+			return false, nil
+		}
+		if msg.Type == core.MsgAttributeInfo || msg.Type == core.MsgNil {
+			continue
+		}
+		kept = append(kept, msg)
+	}
+	if len(kept) == len(oh.Messages) {
+		return false, nil // Not in dense storage.
 	}
 
-	// Encode attribute message
-	attrMsg, err := core.EncodeAttributeFromStruct(attr, sb)
+	attrs, err := core.ParseAttributesFromMessages(fw.writer.Reader(), oh.Messages, sb)
 	if err != nil {
-		return fmt.Errorf("failed to encode attribute: %w", err)
+		return false, fmt.Errorf("failed to read dense attributes: %w", err)
+	}
+	if _, minDense := fw.attributePhaseChange(oh); len(attrs) >= minDense {
+		return false, nil
 	}
 
-	// Check if attribute already exists (upsert semantics)
-	_, exists := btree.SearchRecord(name)
+	limit := fw.lookupHeaderAllocSize(objectAddr)
+	if limit == 0 {
+		limit = core.ObjectHeaderSizeFromParsed(oh)
+	}
 
-	if exists { //nolint:nestif // Clear upsert logic
-		// Modify existing attribute (Phase 2)
-		attr.Data = attrMsg
-		err = core.ModifyDenseAttribute(heap, btree, name, attr)
+	oh.Messages = kept
+	for _, attr := range attrs {
+		attrMsg, err := fw.encodeAttributeMessage(attr, sb)
 		if err != nil {
-			return fmt.Errorf("failed to modify existing dense attribute: %w", err)
+			return false, fmt.Errorf("failed to encode attribute %q: %w", attr.Name, err)
 		}
-	} else {
-		// Create new attribute (Phase 3 - original code)
-
-		// Insert into fractal heap
-		heapIDBytes, insertErr := heap.InsertObject(attrMsg)
-		if insertErr != nil {
-			return fmt.Errorf("failed to insert into heap: %w", insertErr)
+		if err := core.AddMessageToObjectHeader(oh, core.MsgAttribute, attrMsg); err != nil {
+			return false, fmt.Errorf("failed to add message to header: %w", err)
 		}
+	}
 
-		// Convert heap ID to uint64 for B-tree
-		if len(heapIDBytes) != 8 {
-			return fmt.Errorf("unexpected heap ID length: %d bytes", len(heapIDBytes))
+	if core.ObjectHeaderSizeFromParsed(oh) > limit {
+		// Keep the attributes in a continuation chunk instead. Its
+		// continuation message is no larger than the Attribute Info message
+		// it replaces, so the main chunk still fits.
+		for _, msg := range oh.Messages[len(kept):] {
+			msg.FromContinuation = true
 		}
-		heapID := binary.LittleEndian.Uint64(heapIDBytes)
-
-		// Insert into B-tree
-		err = btree.InsertRecord(name, heapID)
-		if err != nil {
-			return fmt.Errorf("failed to insert into B-tree: %w", err)
+		if err := rewriteWithContinuationChunk(fw, objectAddr, oh, sb); err != nil {
+			return false, err
 		}
+		return true, nil
 	}
 
-	// Step 5: Write updated structures back to file (IN-PLACE using WriteAt)
-	// NOTE: WriteAt() writes to the addresses where structures were loaded from
-	// This is true Read-Modify-Write - no new allocations!
-
-	// Write heap in-place at loaded address
-	err = heap.WriteAt(fw.writer, sb)
-	if err != nil {
-		return fmt.Errorf("failed to write updated heap: %w", err)
-	}
-
-	// Write B-tree in-place at loaded address
-	err = btree.WriteAt(fw.writer, sb)
-	if err != nil {
-		return fmt.Errorf("failed to write updated B-tree: %w", err)
+	if err := writeOHDRWithBoundsCheck(fw, objectAddr, oh, sb); err != nil {
+		return false, err
 	}
-
-	return nil
+	return true, nil
 }
 
 // transitionToDenseAttributes migrates all compact attributes to dense storage.
@@ -846,7 +1148,7 @@ func writeDenseAttribute(fw *FileWriter, _ uint64, oh *core.ObjectHeader,
 // 1. Read all compact attributes from object header
 // 2. Create DenseAttributeWriter
 // 3. Add all existing attributes to dense storage
-// 4. Add new attribute to dense storage
+// 4. Add the new attributes to dense storage (replacing same-named ones)
 // 5. Write dense storage (heap + B-tree)
 // 6. Get Attribute Info Message
 // 7. Remove all compact attribute messages from object header
@@ -857,7 +1159,7 @@ func writeDenseAttribute(fw *FileWriter, _ uint64, oh *core.ObjectHeader,
 //
 //nolint:gocognit,gocyclo,cyclop,funlen // Complex but necessary business logic for compact-to-dense transition
 func transitionToDenseAttributes(fw *FileWriter, objectAddr uint64, _ *core.ObjectHeader,
-	name string, value interface{}, sb *core.Superblock) error {
+	newAttrs []*core.Attribute, sb *core.Superblock) error {
 	// 1. Re-read the OHDR from disk to get ALL messages, including continuation-sourced ones.
 	// This is necessary because the caller may have filtered out continuation messages.
 	reader := fw.writer.Reader()
@@ -877,33 +1179,25 @@ func transitionToDenseAttributes(fw *FileWriter, objectAddr uint64, _ *core.Obje
 		}
 	}
 
-	// 2. Infer datatype and encode new attribute (handles []string via Global Heap).
-	datatype, dataspace, data, err := inferAndEncodeAttributeValue(fw, value)
-	if err != nil {
-		return fmt.Errorf("failed to infer/encode attribute: %w", err)
-	}
-
-	newAttr := &core.Attribute{
-		Name:      name,
-		Datatype:  datatype,
-		Dataspace: dataspace,
-		Data:      data,
+	// 2. Index the new attributes by name for upserts.
+	pending := make(map[string]*core.Attribute, len(newAttrs))
+	for _, attr := range newAttrs {
+		pending[attr.Name] = attr
 	}
 
 	// 3. Create DenseAttributeWriter
 	daw := writer.NewDenseAttributeWriter(objectAddr)
 
-	// 4. Add all existing attributes, replacing any that match the new attribute name
-	// (upsert semantics: if the new attribute already exists in compact storage, replace it).
-	replaced := false
+	// 4. Add all existing attributes, replacing any that match a new attribute name
+	// (upsert semantics: if a new attribute already exists in compact storage, replace it).
 	for _, attr := range compactAttrs {
-		if attr.Name == name {
+		if newAttr, ok := pending[attr.Name]; ok {
 			// Replace existing attribute with the new value.
 			err = daw.AddAttribute(newAttr, sb)
 			if err != nil {
 				return fmt.Errorf("failed to add replaced attribute: %w", err)
 			}
-			replaced = true
+			delete(pending, attr.Name)
 		} else {
 			err = daw.AddAttribute(attr, sb)
 			if err != nil {
@@ -912,9 +1206,12 @@ func transitionToDenseAttributes(fw *FileWriter, objectAddr uint64, _ *core.Obje
 		}
 	}
 
-	// 5. Add new attribute (only if it wasn't already replacing an existing one).
-	if !replaced {
-		err = daw.AddAttribute(newAttr, sb)
+	// 5. Add the remaining new attributes, in order.
+	for _, attr := range newAttrs {
+		if _, ok := pending[attr.Name]; !ok {
+			continue
+		}
+		err = daw.AddAttribute(attr, sb)
 		if err != nil {
 			return fmt.Errorf("failed to add new attribute: %w", err)
 		}
@@ -943,17 +1240,7 @@ func transitionToDenseAttributes(fw *FileWriter, objectAddr uint64, _ *core.Obje
 
 	// 7. Calculate object header size (without AttrInfo message yet)
 	// to determine where dense storage should be allocated
-	ohWriter := &core.ObjectHeaderWriter{
-		Version:  oh.Version,
-		Flags:    oh.Flags,
-		Messages: make([]core.MessageWriter, len(oh.Messages)),
-	}
-	for i, msg := range oh.Messages {
-		ohWriter.Messages[i] = core.MessageWriter{
-			Type: msg.Type,
-			Data: msg.Data,
-		}
-	}
+	ohWriter := core.NewObjectHeaderWriterFromParsed(oh)
 
 	// Add temporary AttrInfo message to calculate size
 	// Use REAL size (2 + offsetSize*2) even though addresses are unknown
@@ -978,13 +1265,7 @@ func transitionToDenseAttributes(fw *FileWriter, objectAddr uint64, _ *core.Obje
 
 	// 8. Update allocator to ensure dense storage allocated AFTER object header
 	allocator := fw.writer.Allocator()
-	if allocator.EndOfFile() < objectHeaderEnd {
-		bytesToAdvance := objectHeaderEnd - allocator.EndOfFile()
-		_, err = allocator.Allocate(bytesToAdvance)
-		if err != nil {
-			return fmt.Errorf("failed to advance allocator past object header: %w", err)
-		}
-	}
+	allocator.AdvanceEndOfFile(objectHeaderEnd)
 
 	// 9. Write dense storage - allocator will place it AFTER object header
 	attrInfo, err := daw.WriteToFile(fw.writer, allocator, sb)
@@ -1025,6 +1306,24 @@ func transitionToDenseAttributes(fw *FileWriter, objectAddr uint64, _ *core.Obje
 // For []string values, this uses the Global Heap via prepareVLenStringAttribute.
 // For all other types, it delegates to inferDatatypeFromValue + encodeAttributeValue.
 func inferAndEncodeAttributeValue(fw *FileWriter, value interface{}) (*core.DatatypeMessage, *core.DataspaceMessage, []byte, error) {
+	// Pre-encoded values (e.g., dimension scale reference lists) are stored as-is.
+	if raw, ok := value.(*rawAttributeValue); ok {
+		return raw.datatype, raw.dataspace, raw.data, nil
+	}
+
+	// Region references are global heap IDs already written by MakeRegionRef.
+	switch refs := value.(type) {
+	case RegionRef:
+		return regionRefAttribute([]RegionRef{refs}, []uint64{1})
+	case []RegionRef:
+		return regionRefAttribute(refs, []uint64{uint64(len(refs))})
+	}
+
+	// Empty numeric and struct slices are stored with a NULL dataspace.
+	if v := reflect.ValueOf(value); v.Kind() == reflect.Slice && v.Len() == 0 && v.Type().Elem().Kind() != reflect.String {
+		return inferNullAttribute(v)
+	}
+
 	// Handle []string specially — requires Global Heap I/O.
 	if strs, ok := value.([]string); ok {
 		if len(strs) == 0 {
@@ -1047,6 +1346,31 @@ func inferAndEncodeAttributeValue(fw *FileWriter, value interface{}) (*core.Data
 	return datatype, dataspace, data, nil
 }
 
+// inferNullAttribute returns the datatype of the elements of the empty slice
+// v and a NULL dataspace, which has no elements and so no data.
+func inferNullAttribute(v reflect.Value) (*core.DatatypeMessage, *core.DataspaceMessage, []byte, error) {
+	datatype, _, err := inferSlice(reflect.MakeSlice(v.Type(), 1, 1))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return datatype, &core.DataspaceMessage{Type: core.DataspaceNull}, nil, nil
+}
+
+// regionRefAttribute returns the region reference datatype, a dataspace of
+// dims (NULL if refs is empty) and the encoded references.
+func regionRefAttribute(refs []RegionRef, dims []uint64) (*core.DatatypeMessage, *core.DataspaceMessage, []byte, error) {
+	dt := &core.DatatypeMessage{
+		Class:         core.DatatypeReference,
+		Version:       1,
+		Size:          12,
+		ClassBitField: 0x01, // Region reference
+	}
+	if len(refs) == 0 {
+		return dt, &core.DataspaceMessage{Type: core.DataspaceNull}, nil, nil
+	}
+	return dt, &core.DataspaceMessage{Dimensions: dims}, encodeRegionRefs(refs), nil
+}
+
 // ensureGlobalHeapWriter lazily initializes the global heap writer on a FileWriter.
 // This is needed because OpenForWrite() does not initialize it (only CreateForWrite does).
 func ensureGlobalHeapWriter(fw *FileWriter) {
@@ -1107,12 +1431,19 @@ func prepareVLenStringAttribute(fw *FileWriter, strings []string) (*core.Datatyp
 	}
 
 	// Outer type: DatatypeVarLen, version=1, size=16 (heap ID size).
-	// ClassBitField: type=1 (string) in bits 0-3, padding=0 in bits 4-7, charset=0 (ASCII) in bits 8-11.
+	// ClassBitField: type=1 (string) in bits 0-3, padding=0 in bits 4-7, charset in bits 8-11.
+	charset := core.CharsetASCII
+	for _, s := range strings {
+		if stringCharset(s) == core.CharsetUTF8 {
+			charset = core.CharsetUTF8
+			break
+		}
+	}
 	dt := &core.DatatypeMessage{
 		Class:         core.DatatypeVarLen,
 		Version:       1,
 		Size:          16,
-		ClassBitField: 0x01, // Type=1 (string), padding=0, charset=0 (ASCII)
+		ClassBitField: 0x01 | uint32(charset)<<8, // Type=1 (string), padding=0, ASCII or UTF-8
 		Properties:    baseTypeMsg,
 	}
 
@@ -1136,14 +1467,16 @@ func inferDatatypeFromValue(value interface{}) (*core.DatatypeMessage, *core.Dat
 	}
 
 	switch v.Kind() {
-	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		return inferSignedInt(v)
-	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		return inferUnsignedInt(v)
 	case reflect.Float32, reflect.Float64:
 		return inferFloat(v)
 	case reflect.String:
 		return inferString(v)
+	case reflect.Struct:
+		return inferStruct(v)
 	case reflect.Slice:
 		return inferSlice(v)
 	default:
@@ -1161,7 +1494,7 @@ func inferSignedInt(v reflect.Value) (*core.DatatypeMessage, *core.DataspaceMess
 		size = 2
 	case reflect.Int32:
 		size = 4
-	case reflect.Int64:
+	case reflect.Int64, reflect.Int: // int is stored as int64 whatever the platform width
 		size = 8
 	default:
 		return nil, nil, fmt.Errorf("not a signed integer type")
@@ -1191,7 +1524,7 @@ func inferUnsignedInt(v reflect.Value) (*core.DatatypeMessage, *core.DataspaceMe
 		size = 2
 	case reflect.Uint32:
 		size = 4
-	case reflect.Uint64:
+	case reflect.Uint64, reflect.Uint: // uint is stored as uint64 whatever the platform width
 		size = 8
 	default:
 		return nil, nil, fmt.Errorf("not an unsigned integer type")
@@ -1245,7 +1578,7 @@ func inferString(v reflect.Value) (*core.DatatypeMessage, *core.DataspaceMessage
 	dt := &core.DatatypeMessage{
 		Class:         core.DatatypeString,
 		Size:          size,
-		ClassBitField: 0, // ASCII, null-terminated
+		ClassBitField: uint32(stringCharset(str)) << 4, // Null-terminated, ASCII or UTF-8
 	}
 
 	ds := &core.DataspaceMessage{
@@ -1283,14 +1616,20 @@ func inferSlice(v reflect.Value) (*core.DatatypeMessage, *core.DataspaceMessage,
 		dt = &core.DatatypeMessage{Class: core.DatatypeFixed, Size: 4, ClassBitField: 0x08}
 	case reflect.Uint32:
 		dt = &core.DatatypeMessage{Class: core.DatatypeFixed, Size: 4, ClassBitField: 0}
-	case reflect.Int64:
+	case reflect.Int64, reflect.Int:
 		dt = &core.DatatypeMessage{Class: core.DatatypeFixed, Size: 8, ClassBitField: 0x08}
-	case reflect.Uint64:
+	case reflect.Uint64, reflect.Uint:
 		dt = &core.DatatypeMessage{Class: core.DatatypeFixed, Size: 8, ClassBitField: 0}
 	case reflect.Float32:
 		dt = &core.DatatypeMessage{Class: core.DatatypeFloat, Size: 4, ClassBitField: 0}
 	case reflect.Float64:
 		dt = &core.DatatypeMessage{Class: core.DatatypeFloat, Size: 8, ClassBitField: 0}
+	case reflect.Struct:
+		var err error
+		dt, err = inferCompound(v.Type().Elem(), structRecords(v))
+		if err != nil {
+			return nil, nil, err
+		}
 	default:
 		return nil, nil, fmt.Errorf("unsupported slice element type: %s", elemKind)
 	}
@@ -1318,7 +1657,7 @@ func encodeAttributeValue(value interface{}) ([]byte, error) {
 		buf := make([]byte, 4)
 		binary.LittleEndian.PutUint32(buf, uint32(v.Int())) //nolint:gosec // Safe: validated data type
 		return buf, nil
-	case reflect.Int64:
+	case reflect.Int64, reflect.Int:
 		buf := make([]byte, 8)
 		binary.LittleEndian.PutUint64(buf, uint64(v.Int())) //nolint:gosec // Safe: validated data type
 		return buf, nil
@@ -1332,7 +1671,7 @@ func encodeAttributeValue(value interface{}) ([]byte, error) {
 		buf := make([]byte, 4)
 		binary.LittleEndian.PutUint32(buf, uint32(v.Uint())) //nolint:gosec // Safe: validated data type
 		return buf, nil
-	case reflect.Uint64:
+	case reflect.Uint64, reflect.Uint:
 		buf := make([]byte, 8)
 		binary.LittleEndian.PutUint64(buf, v.Uint())
 		return buf, nil
@@ -1352,6 +1691,12 @@ func encodeAttributeValue(value interface{}) ([]byte, error) {
 		copy(buf, str)
 		buf[len(str)] = 0 // Null terminator
 		return buf, nil
+	case reflect.Struct:
+		dt, err := inferCompound(v.Type(), []reflect.Value{v})
+		if err != nil {
+			return nil, err
+		}
+		return encodeStructRecords([]reflect.Value{v}, dt)
 	case reflect.Slice:
 		return encodeSliceValue(v)
 	default:
@@ -1403,13 +1748,13 @@ func encodeSliceValue(v reflect.Value) ([]byte, error) {
 			binary.LittleEndian.PutUint32(buf[i*4:], uint32(v.Index(i).Uint())) //nolint:gosec // Safe: validated data type
 		}
 		return buf, nil
-	case reflect.Int64:
+	case reflect.Int64, reflect.Int:
 		buf := make([]byte, length*8)
 		for i := 0; i < length; i++ {
 			binary.LittleEndian.PutUint64(buf[i*8:], uint64(v.Index(i).Int())) //nolint:gosec // Safe: validated data type
 		}
 		return buf, nil
-	case reflect.Uint64:
+	case reflect.Uint64, reflect.Uint:
 		buf := make([]byte, length*8)
 		for i := 0; i < length; i++ {
 			binary.LittleEndian.PutUint64(buf[i*8:], v.Index(i).Uint())
@@ -1429,6 +1774,13 @@ func encodeSliceValue(v reflect.Value) ([]byte, error) {
 			binary.LittleEndian.PutUint64(buf[i*8:], bits)
 		}
 		return buf, nil
+	case reflect.Struct:
+		records := structRecords(v)
+		dt, err := inferCompound(v.Type().Elem(), records)
+		if err != nil {
+			return nil, err
+		}
+		return encodeStructRecords(records, dt)
 	default:
 		return nil, fmt.Errorf("unsupported slice element type: %s", elemKind)
 	}
@@ -0,0 +1,117 @@
+package hdf5
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/scigolib/hdf5/internal/core"
+)
+
+// WriteAttributes writes several attributes to the dataset at once.
+//
+// The result is the same as calling WriteAttribute for each entry in name
+// order, but the storage is updated once for the whole batch: the object
+// header is read once, dense storage (fractal heap and B-tree) is loaded and
+// written back once, and a batch that takes the dataset past the compact
+// attribute limit (WithMaxCompactAttributes) moves all attributes to dense
+// storage in a single transition. Prefer it to WriteAttribute in loops that
+// add many attributes.
+//
+// Existing attributes with the same names are replaced. Values support the
+// same types as WriteAttribute. All values are encoded before any attribute
+// is written, so an unsupported value leaves the attributes unchanged.
+//
+// The B-tree indexing dense attributes grows as needed. Attributes added to
+// dense storage that already exists must fit in its 64 KiB fractal heap
+// block, about a thousand small attributes; past that, the write fails.
+//
+// Parameters:
+//   - attrs: Attribute values by name
+//
+// Returns:
+//   - error: If an attribute cannot be encoded or written
+//
+// Example:
+//
+//	err := ds.WriteAttributes(map[string]interface{}{
+//	    "units":     "Celsius",
+//	    "sensor_id": int32(42),
+//	    "range":     []float64{-40, 125},
+//	})
+func (ds *DatasetWriter) WriteAttributes(attrs map[string]interface{}) error {
+	return writeAttributes(ds.fileWriter, ds.address, ds.denseAttrInfo, attrs)
+}
+
+// writeAttributes encodes attrs in name order and writes them with one
+// storage update. denseAttrInfo is the cached Attribute Info Message of
+// datasets opened with OpenForWrite (nil otherwise).
+//
+// Reference: H5Adense.c - H5A__dense_insert(), H5Aint.c - H5A__dense_create().
+func writeAttributes(fw *FileWriter, objectAddr uint64, denseAttrInfo *core.AttributeInfoMessage,
+	attrs map[string]interface{}) error {
+	if len(attrs) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(attrs))
+	for name := range attrs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	encoded := make([]*core.Attribute, 0, len(names))
+	for _, name := range names {
+		attr, err := encodeAttribute(fw, name, attrs[name])
+		if err != nil {
+			return fmt.Errorf("attribute %q: %w", name, err)
+		}
+		encoded = append(encoded, attr)
+	}
+
+	sb := fw.file.Superblock()
+	if denseAttrInfo != nil {
+		return writeDenseAttributes(fw, denseAttrInfo, encoded, sb)
+	}
+
+	oh, err := core.ReadObjectHeader(fw.writer.Reader(), objectAddr, sb)
+	if err != nil {
+		return fmt.Errorf("failed to read object header: %w", err)
+	}
+
+	existing := make(map[string]bool)
+	for _, msg := range oh.Messages {
+		switch msg.Type {
+		case core.MsgAttributeInfo:
+			attrInfo, err := core.ParseAttributeInfoMessage(msg.Data, sb)
+			if err != nil {
+				return fmt.Errorf("failed to parse attribute info message: %w", err)
+			}
+			return writeDenseAttributes(fw, attrInfo, encoded, sb)
+		case core.MsgAttribute:
+			attr, err := core.ParseAttributeMessage(msg.Data, sb.Endianness)
+			if err != nil {
+				return fmt.Errorf("failed to parse existing attribute: %w", err)
+			}
+			existing[attr.Name] = true
+		}
+	}
+
+	total := len(existing)
+	for _, attr := range encoded {
+		if !existing[attr.Name] {
+			total++
+		}
+	}
+	if maxCompact, _ := fw.attributePhaseChange(oh); total > maxCompact {
+		return transitionToDenseAttributes(fw, objectAddr, oh, encoded, sb)
+	}
+
+	// The final set stays compact: at most max compact header
+	// rewrites, each handling continuation chunks as a single write does.
+	for _, attr := range encoded {
+		if err := writeEncodedAttribute(fw, objectAddr, attr); err != nil {
+			return fmt.Errorf("attribute %q: %w", attr.Name, err)
+		}
+	}
+	return nil
+}
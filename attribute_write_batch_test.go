@@ -0,0 +1,170 @@
+package hdf5
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// int32Attrs returns n int32 attributes named prefix0..prefixN-1 with value base+i.
+func int32Attrs(prefix string, n, base int) map[string]interface{} {
+	attrs := make(map[string]interface{}, n)
+	for i := 0; i < n; i++ {
+		attrs[fmt.Sprintf("%s%d", prefix, i)] = int32(base + i)
+	}
+	return attrs
+}
+
+// requireAttributes checks the attribute names and int32 values of /data.
+func requireAttributes(t *testing.T, path string, want map[string]int32) {
+	t.Helper()
+
+	f, err := Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	ds := findDatasetByPath(t, f, "/data")
+	names, err := ds.ListAttributes()
+	require.NoError(t, err)
+
+	wantNames := make([]string, 0, len(want))
+	for name := range want {
+		wantNames = append(wantNames, name)
+	}
+	sort.Strings(wantNames)
+	sort.Strings(names)
+	require.Equal(t, wantNames, names)
+
+	for name, value := range want {
+		got, err := ds.ReadAttributeAsInt32(name)
+		require.NoError(t, err, name)
+		require.Equal(t, value, got, name)
+	}
+}
+
+func TestWriteAttributes(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing int // Attributes written one by one before the batch
+		batch    int
+	}{
+		{"compact", 2, 4},
+		{"compact to dense", 3, 20},
+		{"dense", 12, 30},
+		{"beyond one B-tree leaf", 400, 600},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "batch.h5")
+
+			fw, err := CreateForWrite(path, CreateTruncate)
+			require.NoError(t, err)
+			ds, err := fw.CreateDataset("/data", Int32, []uint64{4})
+			require.NoError(t, err)
+
+			want := make(map[string]int32)
+			for i := 0; i < tt.existing; i++ {
+				name := fmt.Sprintf("old%d", i)
+				require.NoError(t, ds.WriteAttribute(name, int32(i)))
+				want[name] = int32(i)
+			}
+
+			// The batch adds new attributes and replaces old0.
+			batch := int32Attrs("new", tt.batch, 100)
+			batch["old0"] = int32(-1)
+			require.NoError(t, ds.WriteAttributes(batch))
+			for name, value := range batch {
+				want[name] = value.(int32)
+			}
+			require.NoError(t, fw.Close())
+
+			requireAttributes(t, path, want)
+		})
+	}
+}
+
+func TestWriteAttributes_OpenForWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "batch_rmw.h5")
+
+	fw, err := CreateForWrite(path, CreateTruncate)
+	require.NoError(t, err)
+	ds, err := fw.CreateDataset("/data", Int32, []uint64{4})
+	require.NoError(t, err)
+	require.NoError(t, ds.WriteAttributes(int32Attrs("a", 10, 0)))
+	require.NoError(t, fw.Close())
+
+	fw, err = OpenForWrite(path, OpenReadWrite)
+	require.NoError(t, err)
+	ds, err = fw.OpenDataset("/data")
+	require.NoError(t, err)
+	require.NoError(t, ds.WriteAttributes(int32Attrs("b", 5, 50)))
+	require.NoError(t, fw.Close())
+
+	want := make(map[string]int32)
+	for i := 0; i < 10; i++ {
+		want[fmt.Sprintf("a%d", i)] = int32(i)
+	}
+	for i := 0; i < 5; i++ {
+		want[fmt.Sprintf("b%d", i)] = int32(50 + i)
+	}
+	requireAttributes(t, path, want)
+}
+
+func TestWriteAttributes_InvalidValue(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "batch_invalid.h5")
+
+	fw, err := CreateForWrite(path, CreateTruncate)
+	require.NoError(t, err)
+	ds, err := fw.CreateDataset("/data", Int32, []uint64{4})
+	require.NoError(t, err)
+	require.NoError(t, ds.WriteAttribute("keep", int32(7)))
+
+	err = ds.WriteAttributes(map[string]interface{}{
+		"good": int32(1),
+		"bad":  map[string]int{"x": 1},
+	})
+	require.ErrorContains(t, err, `attribute "bad"`)
+	require.NoError(t, ds.WriteAttributes(nil))
+	require.NoError(t, fw.Close())
+
+	requireAttributes(t, path, map[string]int32{"keep": 7})
+}
+
+// benchmarkAttributeCount needs a multi-level B-tree v2 name index.
+const benchmarkAttributeCount = 1000
+
+// BenchmarkWriteAttribute_Individual adds attributes with one WriteAttribute call each.
+func BenchmarkWriteAttribute_Individual(b *testing.B) {
+	attrs := int32Attrs("attr", benchmarkAttributeCount, 0)
+	dir := b.TempDir()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		fw, err := CreateForWrite(filepath.Join(dir, "individual.h5"), CreateTruncate)
+		require.NoError(b, err)
+		ds, err := fw.CreateDataset("/data", Int32, []uint64{4})
+		require.NoError(b, err)
+		for name, value := range attrs {
+			require.NoError(b, ds.WriteAttribute(name, value))
+		}
+		require.NoError(b, fw.Close())
+	}
+}
+
+// BenchmarkWriteAttributes_Batch adds the same attributes with one WriteAttributes call.
+func BenchmarkWriteAttributes_Batch(b *testing.B) {
+	attrs := int32Attrs("attr", benchmarkAttributeCount, 0)
+	dir := b.TempDir()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		fw, err := CreateForWrite(filepath.Join(dir, "batch.h5"), CreateTruncate)
+		require.NoError(b, err)
+		ds, err := fw.CreateDataset("/data", Int32, []uint64{4})
+		require.NoError(b, err)
+		require.NoError(b, ds.WriteAttributes(attrs))
+		require.NoError(b, fw.Close())
+	}
+}
@@ -0,0 +1,208 @@
+package hdf5
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/scigolib/hdf5/internal/core"
+)
+
+// compoundField maps an exported struct field to a compound member.
+type compoundField struct {
+	index int    // Field index in the struct
+	name  string // Member name
+}
+
+// compoundFields lists the struct fields stored as compound members, in
+// declaration order. Unexported fields are skipped; the `hdf5` struct tag
+// renames a member, and `hdf5:"-"` omits the field.
+func compoundFields(t reflect.Type) []compoundField {
+	fields := make([]compoundField, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		name := f.Name
+		if tag, ok := f.Tag.Lookup("hdf5"); ok {
+			if tag == "-" {
+				continue
+			}
+			if tag != "" {
+				name = tag
+			}
+		}
+		fields = append(fields, compoundField{index: i, name: name})
+	}
+	return fields
+}
+
+// inferCompound builds a compound datatype for a struct type.
+//
+// Members are packed in field order without alignment padding, matching the
+// layout h5py and the C library produce for packed structs. String fields
+// become fixed-length null-terminated strings sized to the longest value found
+// in records, so the whole attribute is needed to infer the type.
+//
+// Supported field types: int8-int64, uint8-uint64, float32, float64, string
+// and nested structs.
+//
+// Reference: H5Tcompound.c - H5Tinsert(), H5Tpack().
+func inferCompound(t reflect.Type, records []reflect.Value) (*core.DatatypeMessage, error) {
+	fields := compoundFields(t)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("unsupported struct %s: no exported fields", t)
+	}
+
+	defs := make([]core.CompoundFieldDef, 0, len(fields))
+	offset := uint32(0)
+	for _, field := range fields {
+		values := make([]reflect.Value, len(records))
+		for i, rec := range records {
+			values[i] = rec.Field(field.index)
+		}
+
+		memberType, err := compoundMemberType(t.Field(field.index).Type, values)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.name, err)
+		}
+
+		defs = append(defs, core.CompoundFieldDef{Name: field.name, Offset: offset, Type: memberType})
+		offset += memberType.Size
+	}
+
+	return core.CreateCompoundTypeFromFields(defs)
+}
+
+// compoundMemberType returns the datatype of one compound member.
+func compoundMemberType(t reflect.Type, values []reflect.Value) (*core.DatatypeMessage, error) {
+	switch t.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		//nolint:gosec // G115: Go integer sizes are 1-8 bytes
+		return numericMemberType(core.DatatypeFixed, uint32(t.Size()), 0x08)
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		//nolint:gosec // G115: Go integer sizes are 1-8 bytes
+		return numericMemberType(core.DatatypeFixed, uint32(t.Size()), 0)
+	case reflect.Int: // Stored as int64 whatever the platform width
+		return numericMemberType(core.DatatypeFixed, 8, 0x08)
+	case reflect.Uint: // Stored as uint64 whatever the platform width
+		return numericMemberType(core.DatatypeFixed, 8, 0)
+	case reflect.Float32, reflect.Float64:
+		//nolint:gosec // G115: Go float sizes are 4 or 8 bytes
+		return numericMemberType(core.DatatypeFloat, uint32(t.Size()), 0)
+	case reflect.String:
+		longest := 0
+		for _, v := range values {
+			longest = max(longest, v.Len())
+		}
+		// String members carry no properties (null-terminated ASCII).
+		return &core.DatatypeMessage{
+			Class:   core.DatatypeString,
+			Version: 1,
+			Size:    uint32(longest + 1), //nolint:gosec // G115: string length fits in uint32
+		}, nil
+	case reflect.Struct:
+		return inferCompound(t, values)
+	default:
+		return nil, fmt.Errorf("unsupported compound member type: %s", t)
+	}
+}
+
+// numericMemberType returns a fully described numeric datatype. Compound
+// members are encoded inline with their properties, so the properties are
+// taken from the regular numeric encoder.
+func numericMemberType(class core.DatatypeClass, size, bitField uint32) (*core.DatatypeMessage, error) {
+	encoded, err := core.EncodeDatatypeMessage(&core.DatatypeMessage{Class: class, Size: size, ClassBitField: bitField})
+	if err != nil {
+		return nil, err
+	}
+	return core.ParseDatatypeMessage(encoded)
+}
+
+// inferStruct infers the compound datatype of a scalar struct value.
+func inferStruct(v reflect.Value) (*core.DatatypeMessage, *core.DataspaceMessage, error) {
+	dt, err := inferCompound(v.Type(), []reflect.Value{v})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ds := &core.DataspaceMessage{
+		Dimensions: []uint64{1}, // Scalar
+		MaxDims:    nil,
+	}
+
+	return dt, ds, nil
+}
+
+// structRecords returns the elements of a struct slice as records.
+func structRecords(v reflect.Value) []reflect.Value {
+	records := make([]reflect.Value, v.Len())
+	for i := range records {
+		records[i] = v.Index(i)
+	}
+	return records
+}
+
+// encodeStructRecords serializes records with the member layout of dt.
+func encodeStructRecords(records []reflect.Value, dt *core.DatatypeMessage) ([]byte, error) {
+	compound, err := core.ParseCompoundType(dt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse compound type: %w", err)
+	}
+
+	buf := make([]byte, len(records)*int(dt.Size))
+	for i, rec := range records {
+		if err := encodeCompoundRecord(buf[i*int(dt.Size):], rec, compound); err != nil {
+			return nil, fmt.Errorf("record %d: %w", i, err)
+		}
+	}
+	return buf, nil
+}
+
+// encodeCompoundRecord writes one struct value into buf at the member offsets.
+func encodeCompoundRecord(buf []byte, v reflect.Value, compound *core.CompoundType) error {
+	fields := compoundFields(v.Type())
+	if len(fields) != len(compound.Members) {
+		return fmt.Errorf("struct %s does not match compound type (%d fields, %d members)",
+			v.Type(), len(fields), len(compound.Members))
+	}
+
+	for i, member := range compound.Members {
+		f := v.Field(fields[i].index)
+		dst := buf[member.Offset : member.Offset+member.Type.Size]
+
+		switch f.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			putUint(dst, uint64(f.Int())) //nolint:gosec // G115: two's complement bit pattern
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			putUint(dst, f.Uint())
+		case reflect.Float32:
+			binary.LittleEndian.PutUint32(dst, math.Float32bits(float32(f.Float())))
+		case reflect.Float64:
+			binary.LittleEndian.PutUint64(dst, math.Float64bits(f.Float()))
+		case reflect.String:
+			copy(dst, f.String()) // Remaining bytes stay zero (null terminator)
+		case reflect.Struct:
+			nested, err := core.ParseCompoundType(member.Type)
+			if err != nil {
+				return fmt.Errorf("member %s: %w", member.Name, err)
+			}
+			if err := encodeCompoundRecord(dst, f, nested); err != nil {
+				return fmt.Errorf("member %s: %w", member.Name, err)
+			}
+		default:
+			return fmt.Errorf("member %s: unsupported type %s", member.Name, f.Type())
+		}
+	}
+	return nil
+}
+
+// putUint stores the low len(dst) bytes of v in little-endian order.
+func putUint(dst []byte, v uint64) {
+	for i := range dst {
+		dst[i] = byte(v >> (8 * i))
+	}
+}
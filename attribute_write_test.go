@@ -2,6 +2,7 @@ package hdf5
 
 import (
 	"fmt"
+	"path/filepath"
 	"testing"
 
 	"github.com/scigolib/hdf5/internal/core"
@@ -44,6 +45,22 @@ func TestInferDatatypeFromValue(t *testing.T) {
 			wantDimensions: []uint64{1},
 			wantErr:        false,
 		},
+		{
+			name:           "int scalar (stored as int64)",
+			value:          42,
+			wantClass:      core.DatatypeFixed,
+			wantSize:       8,
+			wantDimensions: []uint64{1},
+			wantErr:        false,
+		},
+		{
+			name:           "uint scalar (stored as uint64)",
+			value:          uint(7),
+			wantClass:      core.DatatypeFixed,
+			wantSize:       8,
+			wantDimensions: []uint64{1},
+			wantErr:        false,
+		},
 		{
 			name:           "float32 scalar",
 			value:          float32(3.14),
@@ -150,6 +167,25 @@ func TestEncodeAttributeValue(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:    "int scalar",
+			value:   -2,
+			wantLen: 8,
+			validate: func(t *testing.T, data []byte) {
+				assert.Equal(t, []byte{0xFE, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}, data)
+			},
+			wantErr: false,
+		},
+		{
+			name:    "uint array",
+			value:   []uint{1, 2},
+			wantLen: 16,
+			validate: func(t *testing.T, data []byte) {
+				assert.Equal(t, []byte{0x01, 0, 0, 0, 0, 0, 0, 0}, data[0:8])
+				assert.Equal(t, []byte{0x02, 0, 0, 0, 0, 0, 0, 0}, data[8:16])
+			},
+			wantErr: false,
+		},
 		{
 			name:    "float64 scalar",
 			value:   float64(1.0),
@@ -240,6 +276,47 @@ func TestWriteAttribute_Int32(t *testing.T) {
 	// when Dataset() method is implemented for read-mode files
 }
 
+// TestWriteAttribute_GoInt tests that plain int and uint values are written
+// as 64-bit integers.
+func TestWriteAttribute_GoInt(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "attr_int.h5")
+
+	fw, err := CreateForWrite(filename, CreateTruncate)
+	require.NoError(t, err)
+	ds, err := fw.CreateDataset("/data", Float64, []uint64{2})
+	require.NoError(t, err)
+	require.NoError(t, ds.Write([]float64{1, 2}))
+	require.NoError(t, ds.WriteAttribute("count", 42))
+	require.NoError(t, ds.WriteAttribute("offsets", []int{-1, 0, 1}))
+	require.NoError(t, ds.WriteAttribute("mask", uint(0xFF)))
+	type sample struct {
+		Index int
+		Value float64
+	}
+	require.NoError(t, ds.WriteAttribute("first", sample{Index: 3, Value: 0.5}))
+	require.NoError(t, fw.Close())
+
+	f, err := Open(filename)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	count, err := f.ReadAttribute("/data", "count")
+	require.NoError(t, err)
+	require.Equal(t, int64(42), count)
+
+	offsets, err := f.ReadAttribute("/data", "offsets")
+	require.NoError(t, err)
+	require.Equal(t, []int64{-1, 0, 1}, offsets)
+
+	mask, err := f.ReadAttribute("/data", "mask")
+	require.NoError(t, err)
+	require.EqualValues(t, 0xFF, mask)
+
+	first, err := f.ReadAttribute("/data", "first")
+	require.NoError(t, err)
+	require.Equal(t, core.CompoundValue{"Index": int64(3), "Value": 0.5}, first)
+}
+
 // Test round-trip: encode value → decode with existing parser.
 func TestAttributeEncoding_RoundTrip(t *testing.T) {
 	tests := []struct {
@@ -301,3 +378,256 @@ func BenchmarkEncodeAttributeValue(b *testing.B) {
 		})
 	}
 }
+
+// channelPosition is nested inside channelDescriptor to exercise nested compounds.
+type channelPosition struct {
+	X, Y float32
+}
+
+// channelDescriptor is a typical structured metadata record.
+type channelDescriptor struct {
+	ID       int32
+	Gain     float64
+	Label    string `hdf5:"label"`
+	Flags    uint16
+	Position channelPosition
+	internal int    //nolint:unused // Unexported fields are not stored
+	Skipped  string `hdf5:"-"`
+}
+
+func TestWriteAttribute_Compound(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "compound_attr.h5")
+
+	fw, err := CreateForWrite(path, CreateTruncate)
+	require.NoError(t, err)
+
+	ds, err := fw.CreateDataset("/signal", Float64, []uint64{4})
+	require.NoError(t, err)
+	require.NoError(t, ds.Write([]float64{1, 2, 3, 4}))
+
+	channels := []channelDescriptor{
+		{ID: 1, Gain: 2.5, Label: "left", Flags: 3, Position: channelPosition{X: 1, Y: -1}, Skipped: "x"},
+		{ID: 2, Gain: 0.5, Label: "right-front", Flags: 65535, Position: channelPosition{X: 2.5, Y: 0}},
+	}
+	require.NoError(t, ds.WriteAttribute("channels", channels))
+	require.NoError(t, ds.WriteAttribute("reference", channelPosition{X: 0.25, Y: 4}))
+	require.NoError(t, fw.Close())
+
+	f, err := Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var signal *Dataset
+	f.Walk(func(p string, obj Object) {
+		if d, ok := obj.(*Dataset); ok && p == "/signal" {
+			signal = d
+		}
+	})
+	require.NotNil(t, signal)
+
+	value, err := signal.ReadAttribute("channels")
+	require.NoError(t, err)
+	records, ok := value.([]core.CompoundValue)
+	require.True(t, ok, "expected []core.CompoundValue, got %T", value)
+	require.Len(t, records, 2)
+
+	assert.Equal(t, int32(1), records[0]["ID"])
+	assert.Equal(t, 2.5, records[0]["Gain"])
+	assert.Equal(t, "left", records[0]["label"])
+	assert.Equal(t, uint16(3), records[0]["Flags"])
+	assert.Equal(t, core.CompoundValue{"X": float32(1), "Y": float32(-1)}, records[0]["Position"])
+	assert.NotContains(t, records[0], "Skipped")
+	assert.NotContains(t, records[0], "internal")
+
+	assert.Equal(t, "right-front", records[1]["label"])
+	assert.Equal(t, uint16(65535), records[1]["Flags"])
+
+	value, err = signal.ReadAttribute("reference")
+	require.NoError(t, err)
+	assert.Equal(t, core.CompoundValue{"X": float32(0.25), "Y": float32(4)}, value)
+}
+
+func TestInferCompound_Layout(t *testing.T) {
+	records := []channelDescriptor{{Label: "abc"}, {Label: "abcdefg"}}
+	dt, ds, err := inferDatatypeFromValue(records)
+	require.NoError(t, err)
+	assert.Equal(t, core.DatatypeCompound, dt.Class)
+	assert.Equal(t, []uint64{2}, ds.Dimensions)
+
+	compound, err := core.ParseCompoundType(dt)
+	require.NoError(t, err)
+
+	// int32 + float64 + string(8) + uint16 + {float32, float32}, packed.
+	assert.Equal(t, uint32(4+8+8+2+8), dt.Size)
+	names := make([]string, len(compound.Members))
+	offsets := make([]uint32, len(compound.Members))
+	for i, m := range compound.Members {
+		names[i] = m.Name
+		offsets[i] = m.Offset
+	}
+	assert.Equal(t, []string{"ID", "Gain", "label", "Flags", "Position"}, names)
+	assert.Equal(t, []uint32{0, 4, 12, 20, 22}, offsets)
+
+	_, _, err = inferDatatypeFromValue([]struct{ C complex128 }{{}})
+	assert.ErrorContains(t, err, "unsupported compound member type")
+}
+
+func TestWriteAttribute_NameValidation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "attr_names.h5")
+	fw, err := CreateForWrite(path, CreateTruncate)
+	require.NoError(t, err)
+
+	ds, err := fw.CreateDataset("/data", Int32, []uint64{4})
+	require.NoError(t, err)
+
+	require.ErrorContains(t, ds.WriteAttribute("", int32(1)), "empty")
+	require.ErrorContains(t, ds.WriteAttribute("units\x00hidden", int32(1)), "null byte")
+	require.ErrorContains(t, ds.WriteAttribute("température", int32(1)), "not ASCII")
+	require.ErrorContains(t, ds.WriteAttributes(map[string]interface{}{"ok": int32(1), "bad\x00": int32(2)}), "null byte")
+
+	require.NoError(t, fw.Close())
+
+	f, err := Open(path)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	names, err := findDatasetByPath(t, f, "/data").ListAttributes()
+	require.NoError(t, err)
+	require.Empty(t, names, "rejected names must not be written")
+}
+
+func TestWriteAttribute_UTF8Name(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "attr_utf8.h5")
+	fw, err := CreateForWrite(path, CreateTruncate, WithUTF8AttributeNames())
+	require.NoError(t, err)
+
+	ds, err := fw.CreateDataset("/data", Int32, []uint64{4})
+	require.NoError(t, err)
+	require.NoError(t, ds.WriteAttribute("température", int32(21)))
+	require.NoError(t, ds.WriteAttribute("units", "°C"))
+	require.ErrorContains(t, ds.WriteAttribute("bad\xff", int32(1)), "not valid UTF-8")
+	require.NoError(t, fw.Close())
+
+	f, err := Open(path)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	attrs, err := findDatasetByPath(t, f, "/data").Attributes()
+	require.NoError(t, err)
+	charsets := make(map[string]uint8)
+	for _, attr := range attrs {
+		charsets[attr.Name] = attr.NameCharset
+	}
+	require.Equal(t, map[string]uint8{"température": core.CharsetUTF8, "units": core.CharsetASCII}, charsets)
+
+	value, err := findDatasetByPath(t, f, "/data").ReadAttribute("température")
+	require.NoError(t, err)
+	require.Equal(t, int32(21), value)
+}
+
+// hasDenseAttributes reports whether the object header at addr has an
+// Attribute Info message.
+func hasDenseAttributes(t *testing.T, fw *FileWriter, addr uint64) (*core.ObjectHeader, bool) {
+	t.Helper()
+	oh, err := core.ReadObjectHeader(fw.writer.Reader(), addr, fw.file.Superblock())
+	require.NoError(t, err)
+	for _, msg := range oh.Messages {
+		if msg.Type == core.MsgAttributeInfo {
+			return oh, true
+		}
+	}
+	return oh, false
+}
+
+func TestWriteAttribute_PhaseChange(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       []interface{}
+		count      int
+		wantDense  bool
+		wantStored bool
+	}{
+		{"default compact", nil, MaxCompactAttributes, false, false},
+		{"default dense", nil, MaxCompactAttributes + 1, true, false},
+		{"raised limit", []interface{}{WithMaxCompactAttributes(20)}, 15, false, true},
+		{"lowered limit", []interface{}{WithMaxCompactAttributes(2), WithMinDenseAttributes(1)}, 3, true, true},
+		{"always dense", []interface{}{WithMaxCompactAttributes(0), WithMinDenseAttributes(0)}, 1, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "phase.h5")
+			fw, err := CreateForWrite(path, CreateTruncate, tt.opts...)
+			require.NoError(t, err)
+			ds, err := fw.CreateDataset("/data", Int32, []uint64{1})
+			require.NoError(t, err)
+			for i := 0; i < tt.count; i++ {
+				require.NoError(t, ds.WriteAttribute(fmt.Sprintf("attr_%02d", i), int32(i)))
+			}
+
+			oh, dense := hasDenseAttributes(t, fw, ds.address)
+			require.Equal(t, tt.wantDense, dense)
+			require.Equal(t, tt.wantStored, oh.Flags&core.OHDRAttrStorePhaseChange != 0)
+			require.NoError(t, fw.Close())
+
+			f, err := Open(path)
+			require.NoError(t, err)
+			defer func() { _ = f.Close() }()
+			attrs, err := findDatasetByPath(t, f, "/data").Attributes()
+			require.NoError(t, err)
+			require.Len(t, attrs, tt.count)
+		})
+	}
+}
+
+func TestDeleteAttribute_DenseToCompact(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "phase_delete.h5")
+	fw, err := CreateForWrite(path, CreateTruncate, WithMaxCompactAttributes(4), WithMinDenseAttributes(3))
+	require.NoError(t, err)
+	ds, err := fw.CreateDataset("/data", Int32, []uint64{1})
+	require.NoError(t, err)
+	for i := 0; i < 5; i++ {
+		require.NoError(t, ds.WriteAttribute(fmt.Sprintf("attr_%d", i), int32(i)))
+	}
+	_, dense := hasDenseAttributes(t, fw, ds.address)
+	require.True(t, dense)
+
+	require.NoError(t, ds.DeleteAttribute("attr_0"))
+	require.NoError(t, ds.DeleteAttribute("attr_1"))
+	_, dense = hasDenseAttributes(t, fw, ds.address)
+	require.True(t, dense, "3 attributes is not below min dense")
+	require.NoError(t, fw.Close())
+
+	// Reopen: the thresholds come from the object header.
+	fw, err = OpenForWrite(path, OpenReadWrite)
+	require.NoError(t, err)
+	ow, err := fw.OpenDataset("/data")
+	require.NoError(t, err)
+	require.NoError(t, ow.DeleteAttribute("attr_2"))
+	_, dense = hasDenseAttributes(t, fw, ow.address)
+	require.False(t, dense)
+	require.Nil(t, ow.denseAttrInfo)
+	require.NoError(t, ow.WriteAttribute("attr_5", int32(5)))
+	require.NoError(t, fw.Close())
+
+	f, err := Open(path)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+	attrs, err := findDatasetByPath(t, f, "/data").Attributes()
+	require.NoError(t, err)
+	names := make([]string, 0, len(attrs))
+	for _, attr := range attrs {
+		names = append(names, attr.Name)
+	}
+	require.ElementsMatch(t, []string{"attr_3", "attr_4", "attr_5"}, names)
+}
+
+func TestAttributePhaseChange_Validation(t *testing.T) {
+	dir := t.TempDir()
+	_, err := CreateForWrite(filepath.Join(dir, "a.h5"), CreateTruncate, WithMaxCompactAttributes(70000))
+	require.ErrorContains(t, err, "out of range")
+	_, err = CreateForWrite(filepath.Join(dir, "b.h5"), CreateTruncate, WithMaxCompactAttributes(4))
+	require.ErrorContains(t, err, "exceeds max compact")
+	_, err = CreateForWrite(filepath.Join(dir, "c.h5"), CreateTruncate, WithMinDenseAttributes(-1))
+	require.ErrorContains(t, err, "negative")
+}
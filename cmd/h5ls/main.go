@@ -0,0 +1,294 @@
+// Package main provides an h5ls-style listing of HDF5 file contents.
+//
+// The output follows the layout of the HDF5 C tools' h5ls so the two can be
+// diffed: one line per object with the name padded to 24 columns, followed by
+// the object kind and, for datasets, the dataspace dimensions.
+//
+// Usage:
+//
+//	h5ls [-r] [-v] <file.h5>...
+//
+// With -r the whole hierarchy is listed using absolute paths; otherwise only
+// the members of the root group are listed. With -v each dataset is followed
+// by its modification time, chunk dimensions, filter pipeline and datatype,
+// formatted as h5ls -v prints them. Location, link count, attribute and
+// storage lines of h5ls -v are not produced.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/scigolib/hdf5"
+	"github.com/scigolib/hdf5/internal/core"
+)
+
+// nameWidth is the column width h5ls pads object names to.
+const nameWidth = 24
+
+// options holds the command-line switches.
+type options struct {
+	recursive bool
+	verbose   bool
+}
+
+// entry is a single object found while walking the file.
+type entry struct {
+	path string
+	obj  hdf5.Object
+}
+
+func main() {
+	var opts options
+	flag.BoolVar(&opts.recursive, "r", false, "List all groups recursively")
+	flag.BoolVar(&opts.verbose, "v", false, "Show chunking, filters and datatypes")
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) < 1 {
+		fmt.Println("Usage: h5ls [flags] <file.h5>...")
+		fmt.Println("Flags:")
+		flag.PrintDefaults()
+		os.Exit(1)
+	}
+
+	status := 0
+	for _, path := range args {
+		if err := run(os.Stdout, path, opts); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+			status = 1
+		}
+	}
+	os.Exit(status)
+}
+
+// run lists the contents of the file at path to w.
+func run(w io.Writer, path string, opts options) error {
+	f, err := hdf5.Open(path)
+	if err != nil {
+		return fmt.Errorf("unable to open file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	if opts.verbose {
+		fmt.Fprintf(w, "Opened %q with sec2 driver.\n", path)
+	}
+
+	entries := collect(f, opts.recursive)
+
+	// Datasets reachable through several hard links are listed in full once.
+	seen := make(map[uint64]string)
+	for _, e := range entries {
+		name := e.path
+		if !opts.recursive {
+			name = strings.TrimPrefix(name, "/")
+		}
+
+		if ds, ok := e.obj.(*hdf5.Dataset); ok {
+			if first, dup := seen[ds.Address()]; dup {
+				fmt.Fprintf(w, "%s Dataset, same as %s\n", padName(name), first)
+				continue
+			}
+			seen[ds.Address()] = name
+		}
+
+		listObject(w, f, name, e.obj, opts)
+	}
+	return nil
+}
+
+// collect returns the objects to list, ordered by name the way h5ls sorts them.
+func collect(f *hdf5.File, recursive bool) []entry {
+	var entries []entry
+	f.Walk(func(path string, obj hdf5.Object) {
+		path = strings.TrimSuffix(path, "/")
+		if path == "" {
+			if recursive {
+				entries = append(entries, entry{path: "/", obj: obj})
+			}
+			return
+		}
+		if !recursive && strings.Count(path, "/") > 1 {
+			return
+		}
+		entries = append(entries, entry{path: path, obj: obj})
+	})
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return comparePaths(entries[i].path, entries[j].path) < 0
+	})
+	return entries
+}
+
+// comparePaths orders paths component by component, so that a group's
+// members directly follow the group itself.
+func comparePaths(a, b string) int {
+	pa := strings.Split(a, "/")
+	pb := strings.Split(b, "/")
+	for i := 0; i < len(pa) && i < len(pb); i++ {
+		if c := strings.Compare(pa[i], pb[i]); c != 0 {
+			return c
+		}
+	}
+	return len(pa) - len(pb)
+}
+
+// padName pads an object name to the h5ls name column.
+func padName(name string) string {
+	return fmt.Sprintf("%-*s", nameWidth, name)
+}
+
+// listObject prints the summary line of one object and, in verbose mode, its details.
+func listObject(w io.Writer, f *hdf5.File, name string, obj hdf5.Object, opts options) {
+	switch o := obj.(type) {
+	case *hdf5.Group:
+		fmt.Fprintf(w, "%s Group\n", padName(name))
+		if opts.verbose {
+			printModTime(w, o.ModTime)
+		}
+	case *hdf5.Dataset:
+		listDataset(w, name, o, opts)
+	case *hdf5.NamedDatatype:
+		fmt.Fprintf(w, "%s Type\n", padName(name))
+		if opts.verbose {
+			fmt.Fprintf(w, "    %-10s %s\n", "Type:", typeString(o.Datatype(), 15))
+		}
+	default:
+		fmt.Fprintf(w, "%s Unknown\n", padName(name))
+	}
+}
+
+// listDataset prints a dataset line such as "Dataset {10/Inf, 20}".
+func listDataset(w io.Writer, name string, ds *hdf5.Dataset, opts options) {
+	space, err := ds.Dataspace()
+	if err != nil {
+		fmt.Fprintf(w, "%s Dataset {?}\n", padName(name))
+		fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+		return
+	}
+	fmt.Fprintf(w, "%s Dataset %s\n", padName(name), formatDataspace(space, opts.verbose))
+
+	if !opts.verbose {
+		return
+	}
+
+	printModTime(w, ds.ModTime)
+
+	dtype, err := ds.Dtype()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+		return
+	}
+
+	layout, err := ds.Layout()
+	if err == nil && layout.IsChunked() {
+		fmt.Fprintf(w, "    %-10s %s\n", "Chunks:", formatChunks(layout, dtype, len(space.Dimensions)))
+	}
+
+	filters, err := ds.Filters()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+	}
+	for i, flt := range filters {
+		fmt.Fprintf(w, "    %-10s %s\n", fmt.Sprintf("Filter-%d:", i), formatFilter(flt))
+	}
+
+	fmt.Fprintf(w, "    %-10s %s\n", "Type:", typeString(dtype, 15))
+}
+
+// formatDataspace renders dimensions as h5ls does: current size, followed by
+// "/max" when the maximum differs (always in verbose mode) or "/Inf" when the
+// dimension is unlimited.
+func formatDataspace(space *core.DataspaceMessage, verbose bool) string {
+	switch space.Type {
+	case core.DataspaceScalar:
+		return "{SCALAR}"
+	case core.DataspaceNull:
+		return "{NULL}"
+	}
+
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for i, dim := range space.Dimensions {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "%d", dim)
+
+		maxDim := dim
+		if i < len(space.MaxDims) {
+			maxDim = space.MaxDims[i]
+		}
+		switch {
+		case maxDim == hdf5.Unlimited:
+			sb.WriteString("/Inf")
+		case maxDim != dim || verbose:
+			fmt.Fprintf(&sb, "/%d", maxDim)
+		}
+	}
+	sb.WriteByte('}')
+	return sb.String()
+}
+
+// formatChunks renders the chunk dimensions and the size of one chunk in bytes.
+func formatChunks(layout *core.DataLayoutMessage, dtype *core.DatatypeMessage, rank int) string {
+	chunk := layout.ChunkSize
+	// Version 3 layouts store the element size as an extra trailing dimension.
+	if len(chunk) == rank+1 {
+		chunk = chunk[:rank]
+	}
+
+	total := uint64(dtype.Size)
+	dims := make([]string, len(chunk))
+	for i, dim := range chunk {
+		dims[i] = fmt.Sprintf("%d", dim)
+		total *= dim
+	}
+	return fmt.Sprintf("{%s} %d bytes", strings.Join(dims, ", "), total)
+}
+
+// filterNames are the names the HDF5 library registers for its built-in filters.
+var filterNames = map[core.FilterID]string{
+	core.FilterDeflate:     "deflate",
+	core.FilterShuffle:     "shuffle",
+	core.FilterFletcher:    "fletcher32",
+	core.FilterSZIP:        "szip",
+	core.FilterNBit:        "nbit",
+	core.FilterScaleOffset: "scaleoffset",
+}
+
+// formatFilter renders a filter as "deflate-1 OPT {6}".
+func formatFilter(flt core.Filter) string {
+	name := strings.TrimRight(flt.Name, "\x00")
+	if name == "" {
+		name = filterNames[flt.ID]
+	}
+	if name == "" {
+		name = "method"
+	}
+
+	optional := ""
+	if flt.Optional() {
+		optional = "OPT"
+	}
+
+	values := make([]string, len(flt.ClientData))
+	for i, v := range flt.ClientData {
+		values[i] = fmt.Sprintf("%d", v)
+	}
+	return fmt.Sprintf("%s-%d %s {%s}", name, flt.ID, optional, strings.Join(values, ", "))
+}
+
+// printModTime prints the "Modified:" line when the object records a modification time.
+func printModTime(w io.Writer, modTime func() (time.Time, bool)) {
+	t, ok := modTime()
+	if !ok {
+		return
+	}
+	fmt.Fprintf(w, "    %-10s %s\n", "Modified:", t.Local().Format("2006-01-02 15:04:05 MST"))
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/scigolib/hdf5"
+	"github.com/stretchr/testify/require"
+)
+
+// writeListingFile creates a small file with nested groups, a resizable
+// chunked dataset and a compressed dataset.
+func writeListingFile(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "listing.h5")
+
+	fw, err := hdf5.CreateForWrite(path, hdf5.CreateTruncate)
+	require.NoError(t, err)
+
+	_, err = fw.CreateGroup("/grp")
+	require.NoError(t, err)
+	_, err = fw.CreateGroup("/grp-b")
+	require.NoError(t, err)
+
+	temp, err := fw.CreateDataset("/grp/temperature", hdf5.Float64, []uint64{4, 3})
+	require.NoError(t, err)
+	require.NoError(t, temp.Write(make([]float64, 12)))
+
+	counts, err := fw.CreateDataset("/counts", hdf5.Int32, []uint64{10},
+		hdf5.WithChunkDims([]uint64{5}), hdf5.WithMaxDims([]uint64{hdf5.Unlimited}),
+		hdf5.WithGZIPCompression(6))
+	require.NoError(t, err)
+	require.NoError(t, counts.Write(make([]int32, 10)))
+
+	require.NoError(t, fw.Close())
+	return path
+}
+
+func TestRun_Recursive(t *testing.T) {
+	path := writeListingFile(t)
+
+	var out bytes.Buffer
+	require.NoError(t, run(&out, path, options{recursive: true}))
+
+	expected := "" +
+		"/                        Group\n" +
+		"/counts                  Dataset {10/Inf}\n" +
+		"/grp                     Group\n" +
+		"/grp/temperature         Dataset {4, 3}\n" +
+		"/grp-b                   Group\n"
+	require.Equal(t, expected, out.String())
+}
+
+func TestRun_RootOnly(t *testing.T) {
+	path := writeListingFile(t)
+
+	var out bytes.Buffer
+	require.NoError(t, run(&out, path, options{}))
+
+	expected := "" +
+		"counts                   Dataset {10/Inf}\n" +
+		"grp                      Group\n" +
+		"grp-b                    Group\n"
+	require.Equal(t, expected, out.String())
+}
+
+func TestRun_Verbose(t *testing.T) {
+	path := writeListingFile(t)
+
+	var out bytes.Buffer
+	require.NoError(t, run(&out, path, options{recursive: true, verbose: true}))
+
+	require.Contains(t, out.String(), "/counts                  Dataset {10/Inf}\n"+
+		"    Chunks:    {5} 20 bytes\n"+
+		"    Filter-0:  deflate-1  {6}\n"+
+		"    Type:      native int\n")
+	require.Contains(t, out.String(), "/grp/temperature         Dataset {4/4, 3/3}\n"+
+		"    Type:      native double\n")
+}
+
+func TestRun_MissingFile(t *testing.T) {
+	var out bytes.Buffer
+	require.Error(t, run(&out, filepath.Join(t.TempDir(), "missing.h5"), options{}))
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"github.com/scigolib/hdf5/internal/core"
+)
+
+// nativeIntNames are the names h5ls uses for little-endian integers that match
+// a native C type on a 64-bit Linux host, indexed by size and signedness.
+var nativeIntNames = map[uint32][2]string{
+	1: {"native unsigned char", "native signed char"},
+	2: {"native unsigned short", "native short"},
+	4: {"native unsigned int", "native int"},
+	8: {"native unsigned long", "native long"},
+}
+
+// typeString describes a datatype the way h5ls prints it. The indent is the
+// column at which nested member lines of compound types are aligned.
+func typeString(dt *core.DatatypeMessage, indent int) string {
+	switch dt.Class {
+	case core.DatatypeFixed:
+		return intTypeString(dt)
+	case core.DatatypeFloat:
+		return floatTypeString(dt)
+	case core.DatatypeString:
+		return stringTypeString(dt, fmt.Sprintf("%d-byte", dt.Size))
+	case core.DatatypeVarLen:
+		if dt.IsVariableString() {
+			return stringTypeString(dt, "variable-length")
+		}
+		base, err := core.ParseDatatypeMessage(dt.Properties)
+		if err != nil {
+			return "variable length of unknown type"
+		}
+		return fmt.Sprintf("variable length of\n%*s%s", indent+4, "", typeString(base, indent+4))
+	case core.DatatypeCompound:
+		return compoundTypeString(dt, indent)
+	case core.DatatypeBitfield:
+		return fmt.Sprintf("%d-bit%s bitfield", 8*dt.Size, orderSuffix(dt))
+	case core.DatatypeOpaque:
+		return fmt.Sprintf("%d-byte opaque type", dt.Size)
+	case core.DatatypeReference:
+		if dt.ClassBitField&0x0F == 1 {
+			return "dataset region reference"
+		}
+		return "object reference"
+	default:
+		return fmt.Sprintf("%d-byte class-%d", dt.Size, dt.Class)
+	}
+}
+
+// intTypeString renders integers as "native int" or "32-bit big-endian unsigned integer".
+func intTypeString(dt *core.DatatypeMessage) string {
+	signed := dt.IsSignedFixedPoint()
+	precision := typePrecision(dt)
+
+	if names, ok := nativeIntNames[dt.Size]; ok && precision == 8*dt.Size &&
+		(dt.Size == 1 || dt.GetByteOrder() == binary.LittleEndian) {
+		if signed {
+			return names[1]
+		}
+		return names[0]
+	}
+
+	sign := " unsigned"
+	if signed {
+		sign = ""
+	}
+	s := fmt.Sprintf("%d-bit%s%s integer", 8*dt.Size, orderSuffix(dt), sign)
+	if precision != 8*dt.Size {
+		s += fmt.Sprintf(" %d-bit precision", precision)
+	}
+	return s
+}
+
+// floatTypeString renders IEEE floats as "native double" or "IEEE 32-bit big-endian float".
+func floatTypeString(dt *core.DatatypeMessage) string {
+	if dt.GetByteOrder() == binary.LittleEndian {
+		switch dt.Size {
+		case 4:
+			return "native float"
+		case 8:
+			return "native double"
+		}
+	}
+	return fmt.Sprintf("IEEE %d-bit%s float", 8*dt.Size, orderSuffix(dt))
+}
+
+// stringTypeString renders strings as "10-byte null-terminated ASCII string".
+func stringTypeString(dt *core.DatatypeMessage, length string) string {
+	pad := "unknown padding"
+	switch dt.GetStringPadding() {
+	case core.StringPadNullTerm:
+		pad = "null-terminated"
+	case core.StringPadNullPad:
+		pad = "null-padded"
+	case core.StringPadSpacePad:
+		pad = "space-padded"
+	}
+
+	cset := "unknown character set"
+	switch dt.GetStringCharset() {
+	case core.CharsetASCII:
+		cset = "ASCII"
+	case core.CharsetUTF8:
+		cset = "UTF-8"
+	}
+	return fmt.Sprintf("%s %s %s string", length, pad, cset)
+}
+
+// compoundTypeString renders a compound as a "struct {...} N bytes" block with
+// one line per member giving its quoted name, byte offset and type.
+func compoundTypeString(dt *core.DatatypeMessage, indent int) string {
+	compound, err := core.ParseCompoundType(dt)
+	if err != nil {
+		return fmt.Sprintf("%d-byte class-%d", dt.Size, dt.Class)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("struct {")
+	for _, m := range compound.Members {
+		quoted := fmt.Sprintf("%q", m.Name)
+		fmt.Fprintf(&sb, "\n%*s%s", indent+4, "", quoted)
+		fmt.Fprintf(&sb, "%*s +%-4d %s", max(0, 16-len(quoted)), "", m.Offset, typeString(m.Type, indent+4))
+	}
+
+	plural := "s"
+	if dt.Size == 1 {
+		plural = ""
+	}
+	fmt.Fprintf(&sb, "\n%*s} %d byte%s", indent, "", dt.Size, plural)
+	return sb.String()
+}
+
+// orderSuffix returns " little-endian" or " big-endian" for multi-byte types.
+func orderSuffix(dt *core.DatatypeMessage) string {
+	if dt.Size <= 1 {
+		return ""
+	}
+	if dt.GetByteOrder() == binary.BigEndian {
+		return " big-endian"
+	}
+	return " little-endian"
+}
+
+// typePrecision returns the number of significant bits of an integer type.
+func typePrecision(dt *core.DatatypeMessage) uint32 {
+	if len(dt.Properties) < 4 {
+		return 8 * dt.Size
+	}
+	return uint32(binary.LittleEndian.Uint16(dt.Properties[2:4]))
+}
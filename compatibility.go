@@ -0,0 +1,143 @@
+package hdf5
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/scigolib/hdf5/internal/core"
+)
+
+// Compatibility selects the file format versions a FileWriter may use, and
+// with them the oldest HDF5 libraries able to read its files.
+type Compatibility uint8
+
+const (
+	// DefaultCompatibility uses the formats this package writes by default:
+	// version 2 object headers (HDF5 1.8+) with the configured superblock.
+	DefaultCompatibility Compatibility = iota
+
+	// Earliest uses the oldest format of every structure, like
+	// libver='earliest' in h5py or H5F_LIBVER_EARLIEST in the C library, so
+	// that files open in HDF5 1.6 and 1.8 and tools built on them:
+	//   - superblock version 0
+	//   - version 1 object headers, with version 1 continuation chunks
+	//   - symbol table groups with version 1 B-trees and local heaps
+	//   - version 1 attribute messages, always stored in the object header
+	//   - version 1 compound datatypes, version 2 fill value messages
+	//
+	// Features that need newer formats fail with an error instead:
+	// link-format (modern or dense) groups, soft and external links, null
+	// dataspaces, UTF-8 attribute names and compound members of array type.
+	Earliest
+)
+
+// String returns the name of the compatibility mode.
+func (c Compatibility) String() string {
+	switch c {
+	case DefaultCompatibility:
+		return "default"
+	case Earliest:
+		return "earliest"
+	default:
+		return fmt.Sprintf("Compatibility(%d)", uint8(c))
+	}
+}
+
+// WithCompatibility selects the file format versions used by the writer.
+//
+// Earliest overrides WithSuperblockVersion (forcing version 0) and the
+// attribute phase change: attributes are never moved to dense storage, which
+// HDF5 1.6 cannot read. It cannot be combined with WithModernGroups or
+// WithUTF8AttributeNames.
+//
+// For files opened with OpenForWrite, Earliest applies to the objects and
+// attributes added; the superblock of the file is kept.
+//
+// Default: DefaultCompatibility
+//
+// Example:
+//
+//	fw, err := hdf5.CreateForWrite("legacy.h5", hdf5.CreateTruncate,
+//	    hdf5.WithCompatibility(hdf5.Earliest))
+//
+// Reference: H5Fpublic.h - H5F_LIBVER_EARLIEST, H5Pfapl.c - H5Pset_libver_bounds().
+func WithCompatibility(c Compatibility) WriteOption {
+	return func(cfg *FileWriteConfig) {
+		cfg.Compatibility = c
+	}
+}
+
+// applyCompatibility adjusts the configuration to the compatibility mode, once
+// all options are applied.
+func applyCompatibility(cfg *FileWriteConfig) error {
+	switch cfg.Compatibility {
+	case DefaultCompatibility:
+		return nil
+	case Earliest:
+	default:
+		return fmt.Errorf("invalid compatibility mode: %d", cfg.Compatibility)
+	}
+
+	if cfg.ModernGroups {
+		return fmt.Errorf("WithModernGroups cannot be combined with WithCompatibility(Earliest)")
+	}
+	if cfg.UTF8AttributeNames {
+		return fmt.Errorf("WithUTF8AttributeNames cannot be combined with WithCompatibility(Earliest)")
+	}
+	cfg.SuperblockVersion = core.Version0
+	cfg.MaxCompactAttributes = math.MaxUint16
+	return nil
+}
+
+// earliest reports whether the writer uses the oldest formats.
+func (fw *FileWriter) earliest() bool {
+	return fw.config != nil && fw.config.Compatibility == Earliest
+}
+
+// objectHeaderVersion returns the version of new object headers.
+func (fw *FileWriter) objectHeaderVersion() uint8 {
+	if fw.earliest() {
+		return 1
+	}
+	return 2
+}
+
+// requireNewFormat returns an error if the writer is limited to the oldest
+// formats, which cannot store feature.
+func (fw *FileWriter) requireNewFormat(feature string) error {
+	if fw.earliest() {
+		return fmt.Errorf("%s requires HDF5 1.8 file format, not available with WithCompatibility(Earliest)", feature)
+	}
+	return nil
+}
+
+// compatibleDatatype returns dt in the oldest encoding when the writer uses
+// the oldest formats, and dt itself otherwise.
+func (fw *FileWriter) compatibleDatatype(dt *core.DatatypeMessage) (*core.DatatypeMessage, error) {
+	if !fw.earliest() {
+		return dt, nil
+	}
+	return core.CompoundTypeV1(dt)
+}
+
+// encodeAttributeMessage encodes attr for the object header: version 1 when
+// the writer uses the oldest formats, version 3 otherwise.
+func (fw *FileWriter) encodeAttributeMessage(attr *core.Attribute, sb *core.Superblock) ([]byte, error) {
+	if !fw.earliest() {
+		return core.EncodeAttributeFromStruct(attr, sb)
+	}
+	datatype, err := fw.compatibleDatatype(attr.Datatype)
+	if err != nil {
+		return nil, fmt.Errorf("attribute %q: %w", attr.Name, err)
+	}
+	return core.EncodeAttributeMessageV1(attr.Name, datatype, attr.Dataspace, attr.Data)
+}
+
+// encodeFillValueMessage encodes a Fill Value message: version 2 when the
+// writer uses the oldest formats, version 3 otherwise.
+func (fw *FileWriter) encodeFillValueMessage(allocTime, fillTime uint8, value []byte) ([]byte, error) {
+	if fw.earliest() {
+		return core.EncodeFillValueMessageV2(allocTime, fillTime, value)
+	}
+	return core.EncodeFillValueMessage(allocTime, fillTime, value)
+}
@@ -0,0 +1,244 @@
+package hdf5
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/scigolib/hdf5/internal/core"
+	"github.com/stretchr/testify/require"
+)
+
+// writeEarliestFile writes a file with WithCompatibility(Earliest) holding a
+// group, contiguous, chunked and compound datasets, and enough attributes on
+// one dataset to need a continuation chunk. It returns the file path.
+func writeEarliestFile(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "earliest.h5")
+
+	fw, err := CreateForWrite(path, CreateTruncate, WithCompatibility(Earliest), WithTrackTimes(true))
+	require.NoError(t, err)
+
+	g, err := fw.CreateGroup("/group")
+	require.NoError(t, err)
+	require.NoError(t, g.WriteAttribute("title", "earliest"))
+
+	ds, err := fw.CreateDataset("/group/contiguous", Float64, []uint64{4})
+	require.NoError(t, err)
+	require.NoError(t, ds.Write([]float64{1, 2, 3, 4}))
+	for i := 0; i < 20; i++ {
+		require.NoError(t, ds.WriteAttribute(fmt.Sprintf("attribute_%02d", i), int32(i)))
+	}
+
+	chunked, err := fw.CreateDataset("/chunked", Int32, []uint64{100},
+		WithChunkDims([]uint64{10}), WithGZIPCompression(6), WithAllocTime(AllocTimeEarly))
+	require.NoError(t, err)
+	values := make([]int32, 100)
+	for i := range values {
+		values[i] = int32(i)
+	}
+	require.NoError(t, chunked.Write(values))
+
+	int32Type, err := core.CreateBasicDatatypeMessage(core.DatatypeFixed, 4)
+	require.NoError(t, err)
+	float64Type, err := core.CreateBasicDatatypeMessage(core.DatatypeFloat, 8)
+	require.NoError(t, err)
+	compoundType, err := core.CreateCompoundTypeFromFields([]core.CompoundFieldDef{
+		{Name: "id", Offset: 0, Type: int32Type},
+		{Name: "value", Offset: 4, Type: float64Type},
+	})
+	require.NoError(t, err)
+	compound, err := fw.CreateCompoundDataset("/compound", compoundType, []uint64{1})
+	require.NoError(t, err)
+	require.NoError(t, compound.WriteRaw([]byte{7, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0xF8, 0x3F}))
+
+	require.NoError(t, fw.Close())
+	return path
+}
+
+// TestCompatibilityEarliest_Formats checks that every structure written in
+// earliest mode uses its oldest format.
+func TestCompatibilityEarliest_Formats(t *testing.T) {
+	path := writeEarliestFile(t)
+
+	f, err := Open(path)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	require.Equal(t, uint8(core.Version0), f.SuperblockVersion())
+
+	for _, p := range []string{"/", "/group", "/group/contiguous", "/chunked", "/compound"} {
+		obj, err := f.lookup(p)
+		require.NoError(t, err, p)
+		oh, err := core.ReadObjectHeader(f.reader, obj.Address(), f.sb)
+		require.NoError(t, err, p)
+		require.Equal(t, uint8(1), oh.Version, "%s: object header version", p)
+
+		for _, msg := range oh.Messages {
+			switch msg.Type {
+			case core.MsgAttribute:
+				require.Equal(t, byte(1), msg.Data[0], "%s: attribute message version", p)
+			case core.MsgFillValue:
+				require.Equal(t, byte(2), msg.Data[0], "%s: fill value message version", p)
+			case core.MsgAttributeInfo, core.MsgLinkInfo, core.MsgLinkMessage:
+				t.Errorf("%s: unexpected message type %d", p, msg.Type)
+			}
+		}
+	}
+
+	// The attributes did not fit the header's allocation.
+	ds := findDatasetByPath(t, f, "/group/contiguous")
+	oh, err := core.ReadObjectHeader(f.reader, ds.Address(), f.sb)
+	require.NoError(t, err)
+	fromContinuation := 0
+	for _, msg := range oh.Messages {
+		if msg.FromContinuation {
+			fromContinuation++
+		}
+	}
+	require.NotZero(t, fromContinuation, "expected a continuation chunk")
+
+	info, err := findDatasetByPath(t, f, "/compound").readInfo()
+	require.NoError(t, err)
+	require.Equal(t, uint8(1), info.Datatype.Version, "compound datatype version")
+}
+
+// TestCompatibilityEarliest_RoundTrip reads back the data and attributes
+// written in earliest mode.
+func TestCompatibilityEarliest_RoundTrip(t *testing.T) {
+	path := writeEarliestFile(t)
+
+	f, err := Open(path)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	ds := findDatasetByPath(t, f, "/group/contiguous")
+	data, err := ds.Read()
+	require.NoError(t, err)
+	require.Equal(t, []float64{1, 2, 3, 4}, data)
+
+	attrs, err := ds.Attributes()
+	require.NoError(t, err)
+	require.Len(t, attrs, 20)
+	for i := 0; i < 20; i++ {
+		v, err := ds.ReadAttribute(fmt.Sprintf("attribute_%02d", i))
+		require.NoError(t, err)
+		require.Equal(t, int32(i), v)
+	}
+
+	title, err := f.ReadAttribute("/group", "title")
+	require.NoError(t, err)
+	require.Equal(t, "earliest", title)
+
+	chunked, err := findDatasetByPath(t, f, "/chunked").Read()
+	require.NoError(t, err)
+	require.Len(t, chunked, 100)
+	require.Equal(t, float64(99), chunked[99])
+
+	records, err := findDatasetByPath(t, f, "/compound").ReadCompound()
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	require.Equal(t, int32(7), records[0]["id"])
+	require.Equal(t, 1.5, records[0]["value"])
+
+	header, err := core.ReadObjectHeader(f.reader, ds.Address(), f.sb)
+	require.NoError(t, err)
+	_, ok := header.ModTime()
+	require.True(t, ok, "modification time message")
+}
+
+// TestCompatibilityEarliest_Reopen adds attributes to an earliest-mode file
+// reopened for writing.
+func TestCompatibilityEarliest_Reopen(t *testing.T) {
+	path := writeEarliestFile(t)
+
+	fw, err := OpenForWrite(path, OpenReadWrite, WithCompatibility(Earliest))
+	require.NoError(t, err)
+	ds, err := fw.OpenDataset("/chunked")
+	require.NoError(t, err)
+	for i := 0; i < 12; i++ {
+		require.NoError(t, ds.WriteAttribute(fmt.Sprintf("extra_%02d", i), float64(i)))
+	}
+	require.NoError(t, fw.Close())
+
+	f, err := Open(path)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	chunked := findDatasetByPath(t, f, "/chunked")
+	attrs, err := chunked.Attributes()
+	require.NoError(t, err)
+	require.Len(t, attrs, 12)
+	v, err := chunked.ReadAttribute("extra_11")
+	require.NoError(t, err)
+	require.Equal(t, float64(11), v)
+
+	oh, err := core.ReadObjectHeader(f.reader, chunked.Address(), f.sb)
+	require.NoError(t, err)
+	require.Equal(t, uint8(1), oh.Version)
+}
+
+// TestCompatibilityEarliest_Rejected checks that features needing the HDF5
+// 1.8 format fail in earliest mode.
+func TestCompatibilityEarliest_Rejected(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := CreateForWrite(filepath.Join(dir, "modern.h5"), CreateTruncate,
+		WithCompatibility(Earliest), WithModernGroups())
+	require.ErrorContains(t, err, "WithModernGroups")
+
+	_, err = CreateForWrite(filepath.Join(dir, "utf8.h5"), CreateTruncate,
+		WithCompatibility(Earliest), WithUTF8AttributeNames())
+	require.ErrorContains(t, err, "WithUTF8AttributeNames")
+
+	fw, err := CreateForWrite(filepath.Join(dir, "links.h5"), CreateTruncate, WithCompatibility(Earliest))
+	require.NoError(t, err)
+	defer func() { _ = fw.Close() }()
+
+	require.ErrorContains(t, fw.CreateSoftLink("/soft", "/target"), "WithCompatibility(Earliest)")
+	require.ErrorContains(t, fw.CreateExternalLink("/external", "other.h5", "/data"), "WithCompatibility(Earliest)")
+	_, err = fw.CreateNullDataset("/null", Float64)
+	require.ErrorContains(t, err, "WithCompatibility(Earliest)")
+}
+
+// TestCompatibilityEarliest_H5dump validates the file with h5dump.
+// This test is skipped if h5dump is not available.
+func TestCompatibilityEarliest_H5dump(t *testing.T) {
+	h5dump, err := exec.LookPath("h5dump")
+	if err != nil {
+		t.Skip("h5dump not available")
+	}
+
+	path := writeEarliestFile(t)
+	out, err := exec.Command(h5dump, path).CombinedOutput()
+	require.NoError(t, err, "h5dump failed: %s", out)
+	require.Contains(t, string(out), "attribute_19")
+}
+
+// TestCompatibilityEarliest_H5py reads the file with h5py.
+// This test is skipped if python3 or h5py is not available.
+func TestCompatibilityEarliest_H5py(t *testing.T) {
+	python, err := exec.LookPath("python3")
+	if err != nil {
+		t.Skip("python3 not available")
+	}
+	if err := exec.Command(python, "-c", "import h5py").Run(); err != nil {
+		t.Skip("h5py not available")
+	}
+
+	path := writeEarliestFile(t)
+
+	const script = `
+import sys, h5py
+with h5py.File(sys.argv[1], "r") as f:
+    ds = f["group/contiguous"]
+    assert list(ds[...]) == [1, 2, 3, 4]
+    assert len(ds.attrs) == 20
+    assert int(f["chunked"][99]) == 99
+    assert f["group"].attrs["title"] in (b"earliest", "earliest")
+print("ok")
+`
+	out, err := exec.Command(python, "-c", script, path).CombinedOutput()
+	require.NoError(t, err, "h5py failed: %s", out)
+}
@@ -0,0 +1,84 @@
+package hdf5
+
+import (
+	"testing"
+
+	"github.com/scigolib/hdf5/internal/core"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRead_V4ChunkIndexes reads datasets indexed by the HDF5 1.10 chunk
+// indexes. h5fc_ext_none.h5 stores the same 4x6 int32 values 0..23 under
+// each index type.
+func TestRead_V4ChunkIndexes(t *testing.T) {
+	t.Parallel()
+
+	f, err := Open("testdata/hdf5_official/h5fc_ext_none.h5")
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	want := make([]float64, 24)
+	for i := range want {
+		want[i] = float64(i)
+	}
+
+	tests := []struct {
+		path  string
+		index core.ChunkIndexType
+	}{
+		{"/DSET_FA", core.ChunkIndexFixedArray},
+		{"/DSET_EA", core.ChunkIndexExtensibleArray},
+		{"/DSET_NONE", core.ChunkIndexImplicit},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			ds := findDatasetByPath(t, f, tt.path)
+
+			layout, err := ds.Layout()
+			require.NoError(t, err)
+			require.Equal(t, tt.index, layout.ChunkIndex)
+
+			data, err := ds.Read()
+			require.NoError(t, err)
+			require.Equal(t, want, data)
+
+			// Hyperslab reads locate chunks through the same index.
+			part, err := ds.ReadSlice([]uint64{2, 3}, []uint64{2, 3})
+			require.NoError(t, err)
+			require.Equal(t, []float64{15, 16, 17, 21, 22, 23}, part)
+		})
+	}
+}
+
+// TestRead_V4ChunkIndexNoData reads datasets whose array index was never
+// allocated: every element has the default fill value.
+func TestRead_V4ChunkIndexNoData(t *testing.T) {
+	t.Parallel()
+
+	f, err := Open("testdata/hdf5_official/h5fc_ext_none.h5")
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	for _, path := range []string{"/GROUP/DSET_NDATA_FA", "/GROUP/DSET_NDATA_EA"} {
+		data, err := findDatasetByPath(t, f, path).Read()
+		require.NoError(t, err, path)
+		require.Equal(t, make([]float64, 24), data, path)
+	}
+}
+
+// TestRead_FixedArrayFiltered reads a deflate-compressed dataset indexed by a
+// fixed array with filtered chunk elements.
+func TestRead_FixedArrayFiltered(t *testing.T) {
+	t.Parallel()
+
+	f, err := Open("testdata/hdf5_official/h5stat_idx.h5")
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	plain, err := findDatasetByPath(t, f, "/dset").Read()
+	require.NoError(t, err)
+	filtered, err := findDatasetByPath(t, f, "/dset_filter").Read()
+	require.NoError(t, err)
+	require.Equal(t, plain, filtered)
+	require.NotEmpty(t, filtered)
+}
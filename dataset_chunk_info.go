@@ -0,0 +1,96 @@
+package hdf5
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/scigolib/hdf5/internal/core"
+)
+
+// ChunkStat describes one allocated chunk of a chunked dataset, as returned
+// by Dataset.ChunkInfo.
+type ChunkStat struct {
+	Scaled  []uint64 // Chunk coordinates in chunk units
+	Offset  []uint64 // Coordinates of the chunk's first element
+	Address uint64   // File address of the stored chunk
+
+	StoredBytes  uint64 // Bytes stored in the file, after filters
+	LogicalBytes uint64 // Bytes of the full chunk before filters
+
+	// FilterMask has bit i set if filter i of the pipeline was skipped for
+	// this chunk, e.g. because compression did not make it smaller.
+	FilterMask uint32
+}
+
+// CompressionRatio returns LogicalBytes / StoredBytes, or 0 for an empty
+// chunk. Values near or below 1 mean the filters gained nothing.
+func (c *ChunkStat) CompressionRatio() float64 {
+	if c.StoredBytes == 0 {
+		return 0
+	}
+	return float64(c.LogicalBytes) / float64(c.StoredBytes)
+}
+
+// ChunkInfo returns the allocated chunks of a chunked dataset with their
+// position, file address and stored and logical sizes, in index order.
+// Chunks that were never written are omitted. No chunk data is read.
+//
+// Use it to tune chunk shapes and filters: chunks that compress poorly, or
+// much worse than their neighbours, stand out by their CompressionRatio.
+//
+// Edge chunks that extend past the dataset are stored whole, so their
+// LogicalBytes is the full chunk size.
+//
+// Example:
+//
+//	stats, err := ds.ChunkInfo()
+//	for _, c := range stats {
+//	    if c.CompressionRatio() < 1.1 {
+//	        fmt.Printf("chunk %v: %d -> %d bytes\n", c.Scaled, c.LogicalBytes, c.StoredBytes)
+//	    }
+//	}
+func (d *Dataset) ChunkInfo() ([]ChunkStat, error) {
+	info, err := d.readInfo()
+	if err != nil {
+		return nil, err
+	}
+	return d.chunkStats(info)
+}
+
+// chunkStats walks the chunk index of the dataset described by info.
+func (d *Dataset) chunkStats(info *core.DatasetInfo) ([]ChunkStat, error) {
+	layout := info.Layout
+	if !layout.IsChunked() {
+		return nil, errors.New("ChunkInfo only supports chunked datasets")
+	}
+
+	chunks, err := core.CollectChunks(d.file.reader, layout, info.Dataspace, d.file.sb)
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect chunks: %w", err)
+	}
+
+	// The last chunk dimension is the element size.
+	ndims := len(layout.ChunkSize) - 1
+	logical := uint64(1)
+	for _, dim := range layout.ChunkSize {
+		logical *= dim
+	}
+
+	stats := make([]ChunkStat, len(chunks))
+	for i, chunk := range chunks {
+		scaled := append([]uint64(nil), chunk.Key.Scaled[:ndims]...)
+		offset := make([]uint64, ndims)
+		for dim := range offset {
+			offset[dim] = scaled[dim] * layout.ChunkSize[dim]
+		}
+		stats[i] = ChunkStat{
+			Scaled:       scaled,
+			Offset:       offset,
+			Address:      chunk.Address,
+			StoredBytes:  uint64(chunk.Key.Nbytes),
+			LogicalBytes: logical,
+			FilterMask:   chunk.Key.FilterMask,
+		}
+	}
+	return stats, nil
+}
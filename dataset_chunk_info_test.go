@@ -0,0 +1,69 @@
+package hdf5
+
+import (
+	"math/rand"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestDataset_ChunkInfo verifies per-chunk positions and sizes of a
+// compressed 2D dataset whose first row of chunks compresses well and the
+// rest does not.
+func TestDataset_ChunkInfo(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "chunk_info.h5")
+
+	rng := rand.New(rand.NewSource(1))
+	data := make([]float64, 10*7)
+	for i := 4 * 7; i < len(data); i++ {
+		data[i] = rng.Float64()
+	}
+
+	fw, err := CreateForWrite(filename, CreateTruncate)
+	require.NoError(t, err)
+	ds, err := fw.CreateDataset("/grid", Float64, []uint64{10, 7},
+		WithChunkDims([]uint64{4, 4}), WithGZIPCompression(6))
+	require.NoError(t, err)
+	require.NoError(t, ds.Write(data))
+	plain, err := fw.CreateDataset("/plain", Int32, []uint64{4})
+	require.NoError(t, err)
+	require.NoError(t, plain.Write([]int32{1, 2, 3, 4}))
+	require.NoError(t, fw.Close())
+
+	f, err := Open(filename)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	stats, err := findDatasetByPath(t, f, "/grid").ChunkInfo()
+	require.NoError(t, err)
+	require.Len(t, stats, 6) // 3 x 2 chunks
+
+	seen := make(map[[2]uint64]bool)
+	var total uint64
+	for _, c := range stats {
+		require.Len(t, c.Scaled, 2)
+		seen[[2]uint64{c.Scaled[0], c.Scaled[1]}] = true
+		require.Equal(t, []uint64{c.Scaled[0] * 4, c.Scaled[1] * 4}, c.Offset)
+		require.Equal(t, uint64(4*4*8), c.LogicalBytes)
+		require.NotZero(t, c.Address)
+		require.NotZero(t, c.StoredBytes)
+		require.Zero(t, c.FilterMask)
+		total += c.StoredBytes
+
+		// Chunks of the all-zero first row compress far better.
+		if c.Scaled[0] == 0 {
+			require.Greater(t, c.CompressionRatio(), 4.0, "chunk %v", c.Scaled)
+		} else if c.Offset[1] == 0 {
+			require.Less(t, c.CompressionRatio(), 2.0, "chunk %v", c.Scaled)
+		}
+	}
+	require.Len(t, seen, 6)
+
+	details, err := findDatasetByPath(t, f, "/grid").InfoDetailed()
+	require.NoError(t, err)
+	require.Equal(t, total, details.StoredBytes)
+
+	_, err = findDatasetByPath(t, f, "/plain").ChunkInfo()
+	require.ErrorContains(t, err, "only supports chunked datasets")
+}
@@ -0,0 +1,364 @@
+package hdf5
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/scigolib/hdf5/internal/core"
+	"github.com/scigolib/hdf5/internal/writer"
+)
+
+// CreationProps collects the storage settings of a dataset (layout, chunk
+// shape, maximum dimensions, filters, allocation and fill values) so they can
+// be checked together before anything is written. It plays the role of an
+// HDF5 dataset creation property list (H5P_DATASET_CREATE).
+//
+// Methods return the receiver so calls can be chained. Filters are placed in
+// a fixed order whatever the call order: shuffle first, then Deflate and
+// custom filters in call order, then the Fletcher32 checksum last, so the
+// checksum covers the bytes actually stored.
+//
+// Example:
+//
+//	props := hdf5.NewCreationProps().
+//	    Chunk(100, 200).
+//	    MaxDims(hdf5.Unlimited, 200).
+//	    Deflate(6).
+//	    Shuffle().
+//	    FillValue(math.NaN())
+//	ds, err := fw.CreateDataset("/data", hdf5.Float64, []uint64{1000, 200},
+//	    hdf5.WithCreationProps(props))
+type CreationProps struct {
+	chunkDims   []uint64
+	maxDims     []uint64
+	compact     bool
+	shuffle     bool
+	deflate     int // GZIP level, 0 = no compression
+	strategy    DeflateStrategy
+	strategySet bool
+	filters     []customFilterSpec
+	fletcher32  bool
+	allocTime   AllocTime
+	fillTime    FillTime
+	fillTimeSet bool
+	fillValue   interface{}
+}
+
+// customFilterSpec is a filter added with CreationProps.Filter.
+type customFilterSpec struct {
+	id         uint16
+	clientData []uint32
+}
+
+// NewCreationProps returns an empty set of creation properties: contiguous
+// layout, no filters and the default fill value.
+func NewCreationProps() *CreationProps {
+	return &CreationProps{}
+}
+
+// Chunk selects chunked layout with the given chunk dimensions, one per
+// dataset dimension.
+func (p *CreationProps) Chunk(dims ...uint64) *CreationProps {
+	p.chunkDims = dims
+	return p
+}
+
+// MaxDims sets the maximum dimensions of a resizable dataset. Use Unlimited
+// for dimensions without a limit. Requires Chunk, unless the maximum
+// dimensions equal the dataset dimensions.
+func (p *CreationProps) MaxDims(dims ...uint64) *CreationProps {
+	p.maxDims = dims
+	return p
+}
+
+// Compact selects compact layout: the data is stored in the object header.
+// It cannot be combined with chunking, filters or MaxDims.
+func (p *CreationProps) Compact() *CreationProps {
+	p.compact = true
+	return p
+}
+
+// Shuffle adds the byte shuffle filter. It always runs before compression.
+// Requires Chunk.
+func (p *CreationProps) Shuffle() *CreationProps {
+	p.shuffle = true
+	return p
+}
+
+// Deflate adds GZIP compression with the given level (1-9). Requires Chunk.
+func (p *CreationProps) Deflate(level int) *CreationProps {
+	p.deflate = level
+	return p
+}
+
+// DeflateStrategy sets the encoding strategy of Deflate (see
+// WithDeflateStrategy). Requires Deflate.
+func (p *CreationProps) DeflateStrategy(strategy DeflateStrategy) *CreationProps {
+	p.strategy = strategy
+	p.strategySet = true
+	return p
+}
+
+// Filter adds a filter registered with RegisterFilter. Requires Chunk.
+func (p *CreationProps) Filter(id uint16, clientData ...uint32) *CreationProps {
+	p.filters = append(p.filters, customFilterSpec{id: id, clientData: clientData})
+	return p
+}
+
+// Fletcher32 adds the Fletcher32 checksum filter. It always runs last.
+// Requires Chunk.
+func (p *CreationProps) Fletcher32() *CreationProps {
+	p.fletcher32 = true
+	return p
+}
+
+// AllocTime records the space allocation time (see WithAllocTime).
+// Requires Chunk.
+func (p *CreationProps) AllocTime(mode AllocTime) *CreationProps {
+	p.allocTime = mode
+	return p
+}
+
+// FillTime records the fill value write time (see WithFillTime).
+// Requires Chunk.
+func (p *CreationProps) FillTime(mode FillTime) *CreationProps {
+	p.fillTime = mode
+	p.fillTimeSet = true
+	return p
+}
+
+// FillValue sets the value of elements that were never written, recorded in
+// the dataset's Fill Value message. value must be a Go scalar matching the
+// dataset datatype (for example float64 for Float64, int16 for Int16), or a
+// []byte holding exactly one element. Requires Chunk.
+func (p *CreationProps) FillValue(value interface{}) *CreationProps {
+	p.fillValue = value
+	return p
+}
+
+// Validate checks the properties against a dataset of the given datatype and
+// dimensions without creating anything. CreateDataset runs the same checks
+// before writing. opts supplies datatype options such as WithStringSize.
+//
+// Returns:
+//   - error: The first invalid setting or combination, or nil
+func (p *CreationProps) Validate(dtype Datatype, dims []uint64, opts ...DatasetOption) error {
+	if err := validateDimensions(dims); err != nil {
+		return err
+	}
+	config := &datasetConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	_, err := p.resolve(dtype, dims, config)
+	return err
+}
+
+// WithCreationProps applies a CreationProps to the dataset. The properties
+// are validated as a whole before any data is written to the file.
+//
+// It replaces the individual layout and filter options (WithChunkDims,
+// WithAutoClampChunks, WithMaxDims, WithCompactLayout, WithGZIPCompression,
+// WithCompression, WithDeflateStrategy, WithShuffle, WithFletcher32,
+// WithFilter, WithAllocTime and WithFillTime); combining it with any of them
+// is an error. Datatype options such as WithStringSize are still given
+// separately.
+//
+// Example:
+//
+//	props := hdf5.NewCreationProps().Chunk(100).Shuffle().Deflate(6)
+//	ds, err := fw.CreateDataset("/data", hdf5.Int32, []uint64{1000},
+//	    hdf5.WithCreationProps(props))
+func WithCreationProps(props *CreationProps) DatasetOption {
+	return func(cfg *datasetConfig) {
+		cfg.props = props
+	}
+}
+
+// applyCreationProps validates config.props and copies them into config.
+// It fails if layout or filter options were also given individually.
+func applyCreationProps(dtype Datatype, dims []uint64, config *datasetConfig) error {
+	if config.props == nil {
+		return nil
+	}
+	if len(config.chunkDims) > 0 || len(config.maxDims) > 0 || config.pipeline != nil ||
+		config.enableShuffle || config.autoChunk || config.writeFillInfo || config.compact ||
+		config.deflateStrategySet || config.clampChunks {
+		return fmt.Errorf("WithCreationProps cannot be combined with individual layout or filter options")
+	}
+
+	fillValue, err := config.props.resolve(dtype, dims, config)
+	if err != nil {
+		return fmt.Errorf("invalid creation properties: %w", err)
+	}
+
+	p := config.props
+	config.chunkDims = p.chunkDims
+	config.maxDims = p.maxDims
+	config.compact = p.compact
+	config.enableShuffle = p.shuffle
+	if p.deflate != 0 || len(p.filters) > 0 || p.fletcher32 {
+		config.pipeline = writer.NewFilterPipeline()
+		if p.deflate != 0 {
+			gz := writer.NewGZIPFilter(p.deflate)
+			gz.SetStrategy(writer.DeflateStrategy(p.strategy))
+			config.pipeline.AddFilter(gz)
+		}
+		for _, f := range p.filters {
+			config.pipeline.AddFilter(writer.NewCustomFilter(writer.FilterID(f.id), f.clientData))
+		}
+		if p.fletcher32 {
+			config.pipeline.AddFilter(writer.NewFletcher32Filter())
+		}
+	}
+	if p.allocTime != AllocTimeDefault || p.fillTimeSet || fillValue != nil {
+		config.writeFillInfo = true
+		config.allocTime = p.allocTime
+		config.fillTime = FillTimeIfSet
+		if p.fillTimeSet {
+			config.fillTime = p.fillTime
+		}
+		config.fillValue = fillValue
+	}
+	return nil
+}
+
+// resolve checks the properties for a dataset of dtype and dims and returns
+// the encoded fill value (nil if none was set).
+//
+//nolint:gocognit,gocyclo,cyclop // One check per property combination
+func (p *CreationProps) resolve(dtype Datatype, dims []uint64, config *datasetConfig) ([]byte, error) {
+	chunked := len(p.chunkDims) > 0
+	filtered := p.shuffle || p.deflate != 0 || len(p.filters) > 0 || p.fletcher32
+	fillInfo := p.allocTime != AllocTimeDefault || p.fillTimeSet || p.fillValue != nil
+
+	if p.compact {
+		switch {
+		case chunked:
+			return nil, fmt.Errorf("compact layout cannot be combined with chunked layout")
+		case filtered:
+			return nil, fmt.Errorf("compact layout cannot be combined with filters")
+		case len(p.maxDims) > 0:
+			return nil, fmt.Errorf("compact layout cannot be combined with maxDims")
+		}
+	}
+
+	if len(p.maxDims) > 0 {
+		if len(p.maxDims) != len(dims) {
+			return nil, fmt.Errorf("maxDims length (%d) must match dims length (%d)",
+				len(p.maxDims), len(dims))
+		}
+		for i, m := range p.maxDims {
+			if m != Unlimited && m < dims[i] {
+				return nil, fmt.Errorf("maxDims[%d] (%d) must be >= dims[%d] (%d)", i, m, i, dims[i])
+			}
+		}
+		if !chunked && !slices.Equal(p.maxDims, dims) {
+			return nil, fmt.Errorf("maxDims require chunked layout (use Chunk) unless equal to dims")
+		}
+	}
+
+	if chunked {
+		if err := checkChunkDims(p.chunkDims, dims, p.maxDims); err != nil {
+			return nil, err
+		}
+	}
+
+	if filtered && !chunked {
+		return nil, fmt.Errorf("filters require chunked layout (use Chunk)")
+	}
+	if p.deflate != 0 && (p.deflate < 1 || p.deflate > 9) {
+		return nil, fmt.Errorf("deflate level %d out of range 1-9", p.deflate)
+	}
+	if p.strategySet {
+		if p.deflate == 0 {
+			return nil, fmt.Errorf("deflate strategy requires Deflate")
+		}
+		if p.strategy < DeflateStrategyDefault || p.strategy > DeflateStrategyStored {
+			return nil, fmt.Errorf("invalid deflate strategy: %d", p.strategy)
+		}
+	}
+	for _, f := range p.filters {
+		reg, ok := core.LookupFilter(core.FilterID(f.id))
+		if !ok {
+			return nil, fmt.Errorf("filter %d is not registered", f.id)
+		}
+		if reg.Encode == nil {
+			return nil, fmt.Errorf("filter %d (%s) has no encode function", f.id, reg.Name)
+		}
+	}
+
+	if fillInfo && !chunked {
+		return nil, fmt.Errorf("allocation time, fill time and fill value require chunked layout (use Chunk)")
+	}
+	if p.allocTime > AllocTimeIncremental {
+		return nil, fmt.Errorf("invalid allocation time: %d", p.allocTime)
+	}
+	if p.fillTimeSet && p.fillTime > FillTimeIfSet {
+		return nil, fmt.Errorf("invalid fill time: %d", p.fillTime)
+	}
+
+	dtInfo, err := getDatatypeInfo(dtype, config)
+	if err != nil {
+		return nil, fmt.Errorf("invalid datatype: %w", err)
+	}
+	if p.fillValue == nil {
+		return nil, nil
+	}
+	return encodeFillValue(p.fillValue, dtInfo)
+}
+
+// encodeFillValue encodes a fill value as one little-endian element of the
+// datatype described by info, checking that the Go type matches it.
+func encodeFillValue(value interface{}, info *datatypeInfo) ([]byte, error) {
+	if raw, ok := value.([]byte); ok {
+		if len(raw) != int(info.size) {
+			return nil, fmt.Errorf("fill value has %d bytes, datatype size is %d", len(raw), info.size)
+		}
+		return raw, nil
+	}
+
+	var buf []byte
+	var class core.DatatypeClass
+	signed := false
+	switch v := value.(type) {
+	case int8:
+		buf, class, signed = []byte{byte(v)}, core.DatatypeFixed, true
+	case uint8:
+		buf, class = []byte{v}, core.DatatypeFixed
+	case int16:
+		buf, class, signed = binary.LittleEndian.AppendUint16(nil, uint16(v)), core.DatatypeFixed, true //nolint:gosec // G115: two's complement
+	case uint16:
+		buf, class = binary.LittleEndian.AppendUint16(nil, v), core.DatatypeFixed
+	case int32:
+		buf, class, signed = binary.LittleEndian.AppendUint32(nil, uint32(v)), core.DatatypeFixed, true //nolint:gosec // G115: two's complement
+	case uint32:
+		buf, class = binary.LittleEndian.AppendUint32(nil, v), core.DatatypeFixed
+	case int64:
+		buf, class, signed = binary.LittleEndian.AppendUint64(nil, uint64(v)), core.DatatypeFixed, true //nolint:gosec // G115: two's complement
+	case uint64:
+		buf, class = binary.LittleEndian.AppendUint64(nil, v), core.DatatypeFixed
+	case float32:
+		buf, class = binary.LittleEndian.AppendUint32(nil, math.Float32bits(v)), core.DatatypeFloat
+	case float64:
+		buf, class = binary.LittleEndian.AppendUint64(nil, math.Float64bits(v)), core.DatatypeFloat
+	default:
+		return nil, fmt.Errorf("unsupported fill value type %T", value)
+	}
+
+	// Enums store their base integer type.
+	dtClass := info.class
+	if dtClass == core.DatatypeEnum {
+		dtClass = core.DatatypeFixed
+	}
+	if class != dtClass || uint32(len(buf)) != info.size { //nolint:gosec // G115: at most 8 bytes
+		return nil, fmt.Errorf("fill value of type %T does not match datatype (class %d, size %d)",
+			value, info.class, info.size)
+	}
+	if class == core.DatatypeFixed && info.class == core.DatatypeFixed && signed != (info.classBitField&0x08 != 0) {
+		return nil, fmt.Errorf("fill value of type %T does not match datatype signedness", value)
+	}
+	return buf, nil
+}
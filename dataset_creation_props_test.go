@@ -0,0 +1,119 @@
+package hdf5
+
+import (
+	"encoding/binary"
+	"math"
+	"path/filepath"
+	"testing"
+
+	"github.com/scigolib/hdf5/internal/core"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCreationProps_Write verifies that chunking, filters, maxDims and the
+// fill value of a CreationProps reach the file, with shuffle placed first.
+func TestCreationProps_Write(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "props.h5")
+
+	data := make([]float64, 200)
+	for i := range data {
+		data[i] = float64(i % 17)
+	}
+
+	fw, err := CreateForWrite(filename, CreateTruncate)
+	require.NoError(t, err)
+	props := NewCreationProps().
+		Chunk(50).
+		MaxDims(Unlimited).
+		Fletcher32().
+		Deflate(6).
+		Shuffle().
+		FillValue(math.NaN())
+	ds, err := fw.CreateDataset("/data", Float64, []uint64{200}, WithCreationProps(props))
+	require.NoError(t, err)
+	require.NoError(t, ds.Write(data))
+	require.NoError(t, fw.Close())
+
+	f, err := Open(filename)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	rd := findDatasetByPath(t, f, "/data")
+	values, err := rd.Read()
+	require.NoError(t, err)
+	require.Equal(t, data, values)
+
+	header, err := core.ReadObjectHeader(f.reader, rd.Address(), f.sb)
+	require.NoError(t, err)
+	var fill *core.FillValueMessage
+	var pipeline *core.FilterPipelineMessage
+	for _, msg := range header.Messages {
+		switch msg.Type {
+		case core.MsgFillValue:
+			fill, err = core.ParseFillValueMessage(msg.Data)
+			require.NoError(t, err)
+		case core.MsgFilterPipeline:
+			pipeline, err = core.ParseFilterPipelineMessage(msg.Data)
+			require.NoError(t, err)
+		}
+	}
+	require.NotNil(t, fill)
+	require.Equal(t, core.FillTimeIfSet, fill.FillTime)
+	require.Len(t, fill.Value, 8)
+	require.True(t, math.IsNaN(math.Float64frombits(binary.LittleEndian.Uint64(fill.Value))))
+
+	require.NotNil(t, pipeline)
+	require.Len(t, pipeline.Filters, 3)
+	require.Equal(t, core.FilterShuffle, pipeline.Filters[0].ID)
+	require.Equal(t, core.FilterDeflate, pipeline.Filters[1].ID)
+	require.Equal(t, core.FilterFletcher, pipeline.Filters[2].ID)
+}
+
+// TestCreationProps_Validate verifies that invalid combinations are rejected
+// before anything is written.
+func TestCreationProps_Validate(t *testing.T) {
+	dims := []uint64{100}
+	tests := []struct {
+		name  string
+		props *CreationProps
+		want  string
+	}{
+		{"compression without chunking", NewCreationProps().Deflate(6), "filters require chunked layout"},
+		{"shuffle without chunking", NewCreationProps().Shuffle(), "filters require chunked layout"},
+		{"maxDims without chunking", NewCreationProps().MaxDims(Unlimited), "maxDims require chunked layout"},
+		{"compact and chunked", NewCreationProps().Compact().Chunk(10), "compact layout cannot be combined"},
+		{"chunk rank", NewCreationProps().Chunk(10, 10), "must match dataset dimensions"},
+		{"zero chunk", NewCreationProps().Chunk(0), "cannot be zero"},
+		{"deflate level", NewCreationProps().Chunk(10).Deflate(12), "out of range"},
+		{"unregistered filter", NewCreationProps().Chunk(10).Filter(40999), "not registered"},
+		{"fill type", NewCreationProps().Chunk(10).FillValue(int32(-1)), "does not match datatype"},
+		{"fill without chunking", NewCreationProps().FillValue(1.5), "require chunked layout"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.ErrorContains(t, tt.props.Validate(Float64, dims), tt.want)
+		})
+	}
+
+	require.NoError(t, NewCreationProps().Chunk(10).Shuffle().Deflate(1).FillValue(2.5).Validate(Float64, dims))
+	require.NoError(t, NewCreationProps().Chunk(10).FillValue(int32(-1)).Validate(Int32, dims))
+	require.ErrorContains(t, NewCreationProps().Chunk(10).FillValue(uint32(1)).Validate(Int32, dims), "signedness")
+	require.NoError(t, NewCreationProps().Compact().Validate(Int32, dims))
+
+	// Nothing is created when validation fails or options conflict.
+	filename := filepath.Join(t.TempDir(), "invalid.h5")
+	fw, err := CreateForWrite(filename, CreateTruncate)
+	require.NoError(t, err)
+	_, err = fw.CreateDataset("/bad", Float64, dims, WithCreationProps(NewCreationProps().Deflate(6)))
+	require.ErrorContains(t, err, "invalid creation properties")
+	_, err = fw.CreateDataset("/mixed", Float64, dims,
+		WithChunkDims([]uint64{10}), WithCreationProps(NewCreationProps().Chunk(10)))
+	require.ErrorContains(t, err, "cannot be combined")
+	require.NoError(t, fw.Close())
+
+	f, err := Open(filename)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+	require.False(t, f.Exists("/bad"))
+	require.False(t, f.Exists("/mixed"))
+}
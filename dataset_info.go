@@ -0,0 +1,146 @@
+package hdf5
+
+import (
+	"fmt"
+
+	"github.com/scigolib/hdf5/internal/core"
+)
+
+// Shape returns the current dimensions of the dataset.
+//
+// Scalar and null datasets return an empty slice; use Dataspace to tell them
+// apart. The dataset values are not read.
+//
+// Example:
+//
+//	shape, err := ds.Shape()
+//	fmt.Println(shape) // [100 20]
+func (d *Dataset) Shape() ([]uint64, error) {
+	space, err := d.Dataspace()
+	if err != nil {
+		return nil, err
+	}
+
+	if space.Type != core.DataspaceSimple {
+		return []uint64{}, nil
+	}
+
+	shape := make([]uint64, len(space.Dimensions))
+	copy(shape, space.Dimensions)
+	return shape, nil
+}
+
+// MaxDims returns the maximum dimensions of the dataset, with Unlimited for
+// dimensions that can grow without bound. When the file stores no maximum
+// dimensions they equal the current dimensions.
+//
+// Scalar and null datasets return an empty slice, as for Shape.
+//
+// Example:
+//
+//	maxDims, err := ds.MaxDims()
+//	growable := maxDims[0] == hdf5.Unlimited
+func (d *Dataset) MaxDims() ([]uint64, error) {
+	space, err := d.Dataspace()
+	if err != nil {
+		return nil, err
+	}
+
+	if space.Type != core.DataspaceSimple {
+		return []uint64{}, nil
+	}
+
+	maxDims := make([]uint64, len(space.MaxDims))
+	copy(maxDims, space.MaxDims)
+	return maxDims, nil
+}
+
+// Dataspace returns the dataset's dataspace message.
+//
+// The message carries the dataspace type (scalar, simple or null), the current
+// dimensions and, for resizable datasets, the maximum dimensions where
+// Unlimited marks an unbounded dimension. When no maximum dimensions are
+// stored they equal the current dimensions.
+func (d *Dataset) Dataspace() (*core.DataspaceMessage, error) {
+	info, err := d.readInfo()
+	if err != nil {
+		return nil, err
+	}
+	return info.Dataspace, nil
+}
+
+// Dtype returns the dataset's datatype message.
+//
+// Example:
+//
+//	dt, err := ds.Dtype()
+//	if dt.IsFloat64() { ... }
+func (d *Dataset) Dtype() (*core.DatatypeMessage, error) {
+	info, err := d.readInfo()
+	if err != nil {
+		return nil, err
+	}
+	return info.Datatype, nil
+}
+
+// Layout returns the dataset's storage layout message (compact, contiguous or
+// chunked, with chunk dimensions for chunked datasets).
+func (d *Dataset) Layout() (*core.DataLayoutMessage, error) {
+	info, err := d.readInfo()
+	if err != nil {
+		return nil, err
+	}
+	return info.Layout, nil
+}
+
+// Filters returns the dataset's filter pipeline in the order the filters were
+// applied on write, or nil when the dataset is not filtered.
+//
+// Filter.Supported tells whether this package can decode a filter. Reading
+// data that needs an unsupported filter fails with *UnsupportedFilterError,
+// unless the filter is optional (Filter.Optional), in which case it is skipped.
+//
+// Example:
+//
+//	filters, err := ds.Filters()
+//	for _, flt := range filters {
+//	    if !flt.Supported() && !flt.Optional() {
+//	        return fmt.Errorf("cannot read %s: filter %d", ds.Path(), flt.ID)
+//	    }
+//	}
+func (d *Dataset) Filters() ([]core.Filter, error) {
+	header, err := core.ReadObjectHeader(d.file.reader, d.address, d.file.sb)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dataset header: %w", err)
+	}
+
+	for _, msg := range header.Messages {
+		if msg.Type != core.MsgFilterPipeline {
+			continue
+		}
+		pipeline, err := core.ParseFilterPipelineMessage(msg.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse filter pipeline: %w", err)
+		}
+		return pipeline.Filters, nil
+	}
+	return nil, nil
+}
+
+// readInfo parses the datatype, dataspace and layout messages of the dataset.
+func (d *Dataset) readInfo() (*core.DatasetInfo, error) {
+	header, err := core.ReadObjectHeader(d.file.reader, d.address, d.file.sb)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dataset header: %w", err)
+	}
+
+	info, err := core.ReadDatasetInfo(header, d.file.sb)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dataset metadata: %w", err)
+	}
+
+	if info.Dataspace.Type == core.DataspaceSimple && info.Dataspace.MaxDims == nil {
+		info.Dataspace.MaxDims = append([]uint64(nil), info.Dataspace.Dimensions...)
+	}
+	return info, nil
+}
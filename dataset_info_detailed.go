@@ -0,0 +1,143 @@
+package hdf5
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"github.com/scigolib/hdf5/internal/core"
+)
+
+// DatasetDetails describes how a dataset is stored, as returned by
+// Dataset.InfoDetailed.
+type DatasetDetails struct {
+	Datatype  *core.DatatypeMessage
+	Dataspace *core.DataspaceMessage
+	Layout    core.DataLayoutClass
+
+	ChunkDims  []uint64            // Chunk dimensions (chunked layout only)
+	ChunkIndex core.ChunkIndexType // Chunk index structure (chunked layout only)
+	Chunks     int                 // Number of allocated chunks (chunked layout only)
+
+	Filters []string // Filter names, in the order applied on write
+
+	// ByteOrder of the data elements, or nil for datatypes without one
+	// (strings, compounds, references, ...).
+	ByteOrder binary.ByteOrder
+
+	LogicalBytes uint64 // Size of all elements in memory
+	StoredBytes  uint64 // Bytes of raw data allocated in the file
+}
+
+// CompressionRatio returns LogicalBytes / StoredBytes, or 0 when no data is
+// stored. Values above 1 mean the filters save space.
+func (d *DatasetDetails) CompressionRatio() float64 {
+	if d.StoredBytes == 0 {
+		return 0
+	}
+	return float64(d.LogicalBytes) / float64(d.StoredBytes)
+}
+
+// String returns a one-line summary of the details.
+func (d *DatasetDetails) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Dataset: %s, %s, %s", d.Datatype, d.Dataspace, layoutClassName(d.Layout))
+	if d.Layout == core.LayoutChunked {
+		fmt.Fprintf(&b, " %v (index=%s, chunks=%d)", d.ChunkDims, d.ChunkIndex, d.Chunks)
+	}
+	if len(d.Filters) > 0 {
+		fmt.Fprintf(&b, ", filters=[%s]", strings.Join(d.Filters, ", "))
+	}
+	switch d.ByteOrder {
+	case binary.LittleEndian:
+		b.WriteString(", little-endian")
+	case binary.BigEndian:
+		b.WriteString(", big-endian")
+	}
+	fmt.Fprintf(&b, ", %d bytes stored for %d logical bytes", d.StoredBytes, d.LogicalBytes)
+	if d.StoredBytes > 0 {
+		fmt.Fprintf(&b, " (ratio %.2f)", d.CompressionRatio())
+	}
+	return b.String()
+}
+
+// InfoDetailed returns the storage details of the dataset: layout, chunk
+// dimensions and index, filters, byte order, and the bytes stored in the
+// file against the logical size of the data. Use it to find out why a
+// dataset is large or slow to read. No data is read, but the chunk index of
+// chunked datasets is walked to sum the chunk sizes; ChunkInfo lists them.
+//
+// Example:
+//
+//	details, err := ds.InfoDetailed()
+//	fmt.Println(details) // Dataset: float (8 bytes), 1D array [1000000], chunked [65536] ...
+//	fmt.Printf("compression ratio: %.1f\n", details.CompressionRatio())
+func (d *Dataset) InfoDetailed() (*DatasetDetails, error) {
+	info, err := d.readInfo()
+	if err != nil {
+		return nil, err
+	}
+	filters, err := d.Filters()
+	if err != nil {
+		return nil, err
+	}
+
+	details := &DatasetDetails{
+		Datatype:     info.Datatype,
+		Dataspace:    info.Dataspace,
+		Layout:       info.Layout.Class,
+		ByteOrder:    datatypeByteOrder(info.Datatype),
+		LogicalBytes: info.Dataspace.TotalElements() * uint64(info.Datatype.Size),
+	}
+	for _, flt := range filters {
+		details.Filters = append(details.Filters, flt.DisplayName())
+	}
+
+	layout := info.Layout
+	switch layout.Class {
+	case core.LayoutCompact:
+		details.StoredBytes = uint64(len(layout.CompactData))
+	case core.LayoutContiguous:
+		if layout.DataAddress != undefinedAddress {
+			details.StoredBytes = layout.DataSize
+		}
+	case core.LayoutChunked:
+		details.ChunkDims = append([]uint64(nil), layout.ChunkSize[:len(layout.ChunkSize)-1]...)
+		details.ChunkIndex = layout.ChunkIndex
+		chunks, err := d.chunkStats(info)
+		if err != nil {
+			return nil, err
+		}
+		details.Chunks = len(chunks)
+		for _, chunk := range chunks {
+			details.StoredBytes += chunk.StoredBytes
+		}
+	}
+	return details, nil
+}
+
+// datatypeByteOrder returns the byte order of numeric datatypes, or nil.
+func datatypeByteOrder(dt *core.DatatypeMessage) binary.ByteOrder {
+	switch dt.Class {
+	case core.DatatypeFixed, core.DatatypeFloat, core.DatatypeBitfield, core.DatatypeTime:
+		return dt.GetByteOrder()
+	default:
+		return nil
+	}
+}
+
+// layoutClassName returns the name of a storage layout class.
+func layoutClassName(class core.DataLayoutClass) string {
+	switch class {
+	case core.LayoutCompact:
+		return "compact"
+	case core.LayoutContiguous:
+		return "contiguous"
+	case core.LayoutChunked:
+		return "chunked"
+	case core.LayoutVirtual:
+		return "virtual"
+	default:
+		return fmt.Sprintf("layout class %d", class)
+	}
+}
@@ -0,0 +1,67 @@
+package hdf5
+
+import (
+	"encoding/binary"
+	"path/filepath"
+	"testing"
+
+	"github.com/scigolib/hdf5/internal/core"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDataset_InfoDetailed verifies layout, chunking, filter and storage
+// details of written datasets.
+func TestDataset_InfoDetailed(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "details.h5")
+
+	data := make([]float64, 1000)
+	fw, err := CreateForWrite(filename, CreateTruncate)
+	require.NoError(t, err)
+	chunked, err := fw.CreateDataset("/chunked", Float64, []uint64{1000},
+		WithChunkDims([]uint64{250}), WithShuffle(), WithGZIPCompression(6))
+	require.NoError(t, err)
+	require.NoError(t, chunked.Write(data))
+	plain, err := fw.CreateDataset("/plain", Int32, []uint64{10})
+	require.NoError(t, err)
+	require.NoError(t, plain.Write(make([]int32, 10)))
+	require.NoError(t, fw.Close())
+
+	f, err := Open(filename)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	details, err := findDatasetByPath(t, f, "/chunked").InfoDetailed()
+	require.NoError(t, err)
+	require.Equal(t, core.LayoutChunked, details.Layout)
+	require.Equal(t, []uint64{250}, details.ChunkDims)
+	require.Equal(t, 4, details.Chunks)
+	require.Equal(t, []string{"shuffle", "deflate"}, details.Filters)
+	require.Equal(t, binary.LittleEndian, details.ByteOrder)
+	require.Equal(t, uint64(8000), details.LogicalBytes)
+	require.Positive(t, details.StoredBytes)
+	require.Greater(t, details.CompressionRatio(), 10.0) // All zeros compress well
+	require.Contains(t, details.String(), "filters=[shuffle, deflate]")
+
+	details, err = findDatasetByPath(t, f, "/plain").InfoDetailed()
+	require.NoError(t, err)
+	require.Equal(t, core.LayoutContiguous, details.Layout)
+	require.Nil(t, details.ChunkDims)
+	require.Empty(t, details.Filters)
+	require.Equal(t, uint64(40), details.LogicalBytes)
+	require.Equal(t, uint64(40), details.StoredBytes)
+	require.InDelta(t, 1.0, details.CompressionRatio(), 1e-9)
+}
+
+// TestDataset_InfoDetailed_BigEndian verifies the byte order of big-endian
+// data in a file written by the C library.
+func TestDataset_InfoDetailed_BigEndian(t *testing.T) {
+	f, err := Open("testdata/reference/be_data.h5")
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	details, err := findDatasetByPath(t, f, "/Deflate_float_data_be").InfoDetailed()
+	require.NoError(t, err)
+	require.Equal(t, binary.BigEndian, details.ByteOrder)
+	require.Equal(t, []string{"deflate"}, details.Filters)
+	require.Contains(t, details.String(), "big-endian")
+}
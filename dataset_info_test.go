@@ -0,0 +1,184 @@
+package hdf5
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/scigolib/hdf5/internal/core"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatasetShapeDtypeLayout(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "info.h5")
+
+	fw, err := CreateForWrite(path, CreateTruncate)
+	require.NoError(t, err)
+
+	fixed, err := fw.CreateDataset("/fixed", Float64, []uint64{2, 3})
+	require.NoError(t, err)
+	require.NoError(t, fixed.Write([]float64{1, 2, 3, 4, 5, 6}))
+
+	growing, err := fw.CreateDataset("/growing", Int32, []uint64{8},
+		WithChunkDims([]uint64{4}), WithMaxDims([]uint64{Unlimited}))
+	require.NoError(t, err)
+	require.NoError(t, growing.Write(make([]int32, 8)))
+	require.NoError(t, fw.Close())
+
+	f, err := Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	datasets := make(map[string]*Dataset)
+	f.Walk(func(p string, obj Object) {
+		if ds, ok := obj.(*Dataset); ok {
+			datasets[p] = ds
+		}
+	})
+
+	shape, err := datasets["/fixed"].Shape()
+	require.NoError(t, err)
+	require.Equal(t, []uint64{2, 3}, shape)
+
+	space, err := datasets["/fixed"].Dataspace()
+	require.NoError(t, err)
+	require.Equal(t, []uint64{2, 3}, space.MaxDims)
+
+	dtype, err := datasets["/fixed"].Dtype()
+	require.NoError(t, err)
+	require.True(t, dtype.IsFloat64())
+
+	layout, err := datasets["/fixed"].Layout()
+	require.NoError(t, err)
+	require.True(t, layout.IsContiguous())
+
+	space, err = datasets["/growing"].Dataspace()
+	require.NoError(t, err)
+	require.Equal(t, []uint64{8}, space.Dimensions)
+	require.Equal(t, []uint64{Unlimited}, space.MaxDims)
+
+	dtype, err = datasets["/growing"].Dtype()
+	require.NoError(t, err)
+	require.True(t, dtype.IsSignedFixedPoint())
+	require.Equal(t, uint32(4), dtype.Size)
+
+	layout, err = datasets["/growing"].Layout()
+	require.NoError(t, err)
+	require.True(t, layout.IsChunked())
+	require.Equal(t, uint64(4), layout.ChunkSize[0])
+}
+
+func TestDatasetMaxDims(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "maxdims.h5")
+
+	fw, err := CreateForWrite(path, CreateTruncate)
+	require.NoError(t, err)
+
+	declared, err := fw.CreateDataset("/declared", Float64, []uint64{2, 3}, WithMaxDims([]uint64{2, 3}))
+	require.NoError(t, err)
+	require.NoError(t, declared.Write([]float64{1, 2, 3, 4, 5, 6}))
+	require.ErrorContains(t, declared.Resize([]uint64{2, 2}), "requires chunked layout")
+
+	_, err = fw.CreateDataset("/plain", Float64, []uint64{4})
+	require.NoError(t, err)
+	_, err = fw.CreateDataset("/growing", Int32, []uint64{8},
+		WithChunkDims([]uint64{4}), WithMaxDims([]uint64{Unlimited}))
+	require.NoError(t, err)
+
+	_, err = fw.CreateDataset("/larger", Float64, []uint64{4}, WithMaxDims([]uint64{8}))
+	require.ErrorContains(t, err, "require chunked layout")
+	_, err = fw.CreateDataset("/compact", Float64, []uint64{4},
+		WithCompactLayout(), WithMaxDims([]uint64{4}))
+	require.ErrorContains(t, err, "compact layout cannot be combined with maxDims")
+	_, err = fw.CreateDataset("/props", Float64, []uint64{4},
+		WithCreationProps(NewCreationProps().MaxDims(4)))
+	require.NoError(t, err)
+	require.NoError(t, fw.Close())
+
+	f, err := Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	tests := map[string][]uint64{
+		"/declared": {2, 3},
+		"/plain":    {4},
+		"/growing":  {Unlimited},
+		"/props":    {4},
+	}
+	for name, want := range tests {
+		maxDims, err := findDatasetByPath(t, f, name).MaxDims()
+		require.NoError(t, err)
+		require.Equal(t, want, maxDims, name)
+	}
+
+	// The declared maximum dimensions are stored, not derived from dims.
+	ds := findDatasetByPath(t, f, "/declared")
+	header, err := core.ReadObjectHeader(f.reader, ds.Address(), f.sb)
+	require.NoError(t, err)
+	info, err := core.ReadDatasetInfo(header, f.sb)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{2, 3}, info.Dataspace.MaxDims)
+
+	ds = findDatasetByPath(t, f, "/plain")
+	header, err = core.ReadObjectHeader(f.reader, ds.Address(), f.sb)
+	require.NoError(t, err)
+	info, err = core.ReadDatasetInfo(header, f.sb)
+	require.NoError(t, err)
+	require.Nil(t, info.Dataspace.MaxDims)
+}
+
+func TestDatasetShape_NullDataspace(t *testing.T) {
+	f, err := Open("testdata/c-library-corpus/edge-cases/tnullspace.h5")
+	require.NoError(t, err)
+	defer f.Close()
+
+	var ds *Dataset
+	f.Walk(func(p string, obj Object) {
+		if d, ok := obj.(*Dataset); ok && p == "/dset" {
+			ds = d
+		}
+	})
+	require.NotNil(t, ds)
+
+	space, err := ds.Dataspace()
+	require.NoError(t, err)
+	require.Equal(t, core.DataspaceNull, space.Type)
+
+	shape, err := ds.Shape()
+	require.NoError(t, err)
+	require.Empty(t, shape)
+
+	maxDims, err := ds.MaxDims()
+	require.NoError(t, err)
+	require.Empty(t, maxDims)
+}
+
+func TestDatasetFilters(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "filters.h5")
+
+	fw, err := CreateForWrite(path, CreateTruncate)
+	require.NoError(t, err)
+	_, err = fw.CreateDataset("/plain", Int32, []uint64{4})
+	require.NoError(t, err)
+	_, err = fw.CreateDataset("/packed", Int32, []uint64{100},
+		WithChunkDims([]uint64{10}), WithShuffle(), WithGZIPCompression(5))
+	require.NoError(t, err)
+	require.NoError(t, fw.Close())
+
+	f, err := Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	filters, err := findDatasetByPath(t, f, "/plain").Filters()
+	require.NoError(t, err)
+	require.Nil(t, filters)
+
+	filters, err = findDatasetByPath(t, f, "/packed").Filters()
+	require.NoError(t, err)
+	require.Len(t, filters, 2)
+	require.Equal(t, core.FilterShuffle, filters[0].ID)
+	require.Equal(t, core.FilterDeflate, filters[1].ID)
+	require.Equal(t, []uint32{5}, filters[1].ClientData)
+	for _, flt := range filters {
+		require.True(t, flt.Supported())
+	}
+}
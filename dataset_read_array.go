@@ -0,0 +1,121 @@
+package hdf5
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/scigolib/hdf5/internal/core"
+)
+
+// ReadArray reads a dataset with an array datatype (created with
+// WithArrayDims) and returns one slice per dataset element. Array values with
+// several dimensions are flattened in row-major order; use ArrayDims to get
+// their shape.
+//
+// The result type follows the array base type:
+//   - [][]int8, [][]int16, [][]int32, [][]int64 for signed integers
+//   - [][]uint8, [][]uint16, [][]uint32, [][]uint64 for unsigned integers
+//   - [][]float32, [][]float64 for floats
+//
+// Returns:
+//   - interface{}: One array value per dataset element
+//   - error: If the dataset is not an array dataset or its base type is not supported
+//
+// Example:
+//
+//	data, err := ds.ReadArray()
+//	vectors := data.([][]float64) // e.g. [N][3] positions
+func (d *Dataset) ReadArray() (interface{}, error) {
+	header, err := core.ReadObjectHeader(d.file.reader, d.address, d.file.sb)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object header: %w", err)
+	}
+	messages, err := extractHyperslabMessages(header)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := parseHyperslabMessages(messages, d.file.sb)
+	if err != nil {
+		return nil, err
+	}
+
+	arr, err := core.ParseArrayType(parsed.datatype)
+	if err != nil {
+		return nil, err
+	}
+
+	n := parsed.dataspace.TotalElements()
+	buf := make([]byte, n*uint64(parsed.datatype.Size))
+	if _, _, err := core.ReadDatasetRawInto(d.file.reader, header, d.file.sb, buf, d.file.readOptions()...); err != nil {
+		return nil, err
+	}
+
+	return decodeArrayValues(buf, n, arr)
+}
+
+// ArrayDims returns the dimensions of each array value of an array dataset.
+func (d *Dataset) ArrayDims() ([]uint64, error) {
+	dt, err := d.Dtype()
+	if err != nil {
+		return nil, err
+	}
+	arr, err := core.ParseArrayType(dt)
+	if err != nil {
+		return nil, err
+	}
+	return arr.Dims, nil
+}
+
+// decodeArrayValues converts the raw bytes of n array values to Go slices.
+func decodeArrayValues(raw []byte, n uint64, arr *core.ArrayType) (interface{}, error) {
+	base := arr.Base
+	order := base.GetByteOrder()
+	inner := int(arr.Len()) //nolint:gosec // G115: array size bounded by the 32-bit datatype size
+
+	switch {
+	case base.Class == core.DatatypeFixed && base.IsSignedFixedPoint():
+		switch base.Size {
+		case 1:
+			return splitArray(raw, n, inner, 1, func(b []byte) int8 { return int8(b[0]) }), nil //nolint:gosec // G115: two's complement
+		case 2:
+			return splitArray(raw, n, inner, 2, func(b []byte) int16 { return int16(order.Uint16(b)) }), nil //nolint:gosec // G115: two's complement
+		case 4:
+			return splitArray(raw, n, inner, 4, func(b []byte) int32 { return int32(order.Uint32(b)) }), nil //nolint:gosec // G115: two's complement
+		case 8:
+			return splitArray(raw, n, inner, 8, func(b []byte) int64 { return int64(order.Uint64(b)) }), nil //nolint:gosec // G115: two's complement
+		}
+	case base.Class == core.DatatypeFixed:
+		switch base.Size {
+		case 1:
+			return splitArray(raw, n, inner, 1, func(b []byte) uint8 { return b[0] }), nil
+		case 2:
+			return splitArray(raw, n, inner, 2, order.Uint16), nil
+		case 4:
+			return splitArray(raw, n, inner, 4, order.Uint32), nil
+		case 8:
+			return splitArray(raw, n, inner, 8, order.Uint64), nil
+		}
+	case base.Class == core.DatatypeFloat:
+		switch base.Size {
+		case 4:
+			return splitArray(raw, n, inner, 4, func(b []byte) float32 { return math.Float32frombits(order.Uint32(b)) }), nil
+		case 8:
+			return splitArray(raw, n, inner, 8, func(b []byte) float64 { return math.Float64frombits(order.Uint64(b)) }), nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported array base type: %s", base)
+}
+
+// splitArray decodes n array values of inner elements of size bytes each.
+// All values share one backing slice.
+func splitArray[T any](raw []byte, n uint64, inner, size int, decode func([]byte) T) [][]T {
+	flat := make([]T, int(n)*inner) //nolint:gosec // G115: element count bounded by the buffer size
+	for i := range flat {
+		flat[i] = decode(raw[i*size:])
+	}
+	values := make([][]T, n)
+	for i := range values {
+		values[i] = flat[i*inner : (i+1)*inner : (i+1)*inner]
+	}
+	return values
+}
@@ -0,0 +1,51 @@
+package hdf5
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataset_ReadArray(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "arrays.h5")
+
+	fw, err := CreateForWrite(filename, CreateTruncate)
+	require.NoError(t, err)
+	vectors, err := fw.CreateDataset("/vectors", ArrayFloat64, []uint64{2}, WithArrayDims([]uint64{3}))
+	require.NoError(t, err)
+	require.NoError(t, vectors.Write([]float64{1, 2, 3, 4.5, 5.5, 6.5}))
+	matrices, err := fw.CreateDataset("/matrices", ArrayInt32, []uint64{3}, WithArrayDims([]uint64{2, 2}))
+	require.NoError(t, err)
+	require.NoError(t, matrices.Write([]int32{1, 2, 3, 4, -5, -6, -7, -8, 9, 10, 11, 12}))
+	small, err := fw.CreateDataset("/small", ArrayUint16, []uint64{2}, WithArrayDims([]uint64{2}))
+	require.NoError(t, err)
+	require.NoError(t, small.Write([]uint16{1, 65535, 3, 4}))
+	plain, err := fw.CreateDataset("/plain", Float64, []uint64{2})
+	require.NoError(t, err)
+	require.NoError(t, plain.Write([]float64{1, 2}))
+	require.NoError(t, fw.Close())
+
+	f, err := Open(filename)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	got, err := findDatasetByPath(t, f, "/vectors").ReadArray()
+	require.NoError(t, err)
+	require.Equal(t, [][]float64{{1, 2, 3}, {4.5, 5.5, 6.5}}, got)
+
+	ds := findDatasetByPath(t, f, "/matrices")
+	got, err = ds.ReadArray()
+	require.NoError(t, err)
+	require.Equal(t, [][]int32{{1, 2, 3, 4}, {-5, -6, -7, -8}, {9, 10, 11, 12}}, got)
+	dims, err := ds.ArrayDims()
+	require.NoError(t, err)
+	require.Equal(t, []uint64{2, 2}, dims)
+
+	got, err = findDatasetByPath(t, f, "/small").ReadArray()
+	require.NoError(t, err)
+	require.Equal(t, [][]uint16{{1, 65535}, {3, 4}}, got)
+
+	_, err = findDatasetByPath(t, f, "/plain").ReadArray()
+	require.ErrorContains(t, err, "not an array datatype")
+}
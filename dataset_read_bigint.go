@@ -0,0 +1,32 @@
+package hdf5
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/scigolib/hdf5/internal/core"
+)
+
+// ReadBigInts reads an integer dataset of any width as big.Int values.
+// Unlike Read, which converts to float64 and supports up to 8-byte integers,
+// it is exact and also decodes 16-byte (int128/uint128) and other wide
+// fixed-point types.
+//
+// Returns:
+//   - []*big.Int: One value per element
+//   - error: If the dataset is not an integer dataset
+//
+// Example:
+//
+//	values, err := ds.ReadBigInts()
+//	fmt.Println(values[0].String())
+func (d *Dataset) ReadBigInts() ([]*big.Int, error) {
+	raw, _, dt, err := d.ReadRaw()
+	if err != nil {
+		return nil, err
+	}
+	if dt.Class != core.DatatypeFixed {
+		return nil, fmt.Errorf("dataset is not an integer dataset: %s", dt.Message)
+	}
+	return core.DecodeBigInts(raw, dt.Message, uint64(len(raw))/uint64(dt.Size))
+}
@@ -0,0 +1,45 @@
+package hdf5
+
+import (
+	"math"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataset_ReadBigInts(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "bigint.h5")
+
+	fw, err := CreateForWrite(filename, CreateTruncate)
+	require.NoError(t, err)
+	signed, err := fw.CreateDataset("/signed", Int64, []uint64{3})
+	require.NoError(t, err)
+	require.NoError(t, signed.Write([]int64{math.MinInt64, -1, 1<<53 + 1}))
+	unsigned, err := fw.CreateDataset("/unsigned", Uint64, []uint64{2})
+	require.NoError(t, err)
+	require.NoError(t, unsigned.Write([]uint64{math.MaxUint64, 7}))
+	floats, err := fw.CreateDataset("/floats", Float64, []uint64{1})
+	require.NoError(t, err)
+	require.NoError(t, floats.Write([]float64{1.5}))
+	require.NoError(t, fw.Close())
+
+	f, err := Open(filename)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	values, err := findDatasetByPath(t, f, "/signed").ReadBigInts()
+	require.NoError(t, err)
+	require.Len(t, values, 3)
+	require.Equal(t, "-9223372036854775808", values[0].String())
+	require.Equal(t, "-1", values[1].String())
+	require.Equal(t, "9007199254740993", values[2].String()) // Not representable as float64.
+
+	values, err = findDatasetByPath(t, f, "/unsigned").ReadBigInts()
+	require.NoError(t, err)
+	require.Equal(t, "18446744073709551615", values[0].String())
+	require.Equal(t, "7", values[1].String())
+
+	_, err = findDatasetByPath(t, f, "/floats").ReadBigInts()
+	require.ErrorContains(t, err, "not an integer dataset")
+}
@@ -0,0 +1,73 @@
+package hdf5
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestRead_CompactLayout reads the whole "compact" dataset of tfilters.h5,
+// 20x10 int32 values 0..199 stored in its object header by the C library.
+func TestRead_CompactLayout(t *testing.T) {
+	f, err := Open("testdata/hdf5_official/tfilters.h5")
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	ds := findDatasetByPath(t, f, "/compact")
+	layout, err := ds.Layout()
+	require.NoError(t, err)
+	require.True(t, layout.IsCompact())
+	require.Equal(t, uint64(800), layout.DataSize)
+
+	want := make([]float64, 200)
+	for i := range want {
+		want[i] = float64(i)
+	}
+	values, err := ds.Read()
+	require.NoError(t, err)
+	require.Equal(t, want, values)
+
+	into := make([]float64, 200)
+	n, err := ds.ReadInto(into)
+	require.NoError(t, err)
+	require.Equal(t, 200, n)
+	require.Equal(t, want, into)
+}
+
+// TestRead_CompactLayout_MaxSize reads compact datasets as large as an
+// object header message allows, in version 1 and version 2 object headers.
+func TestRead_CompactLayout_MaxSize(t *testing.T) {
+	const n = 8191 // 65528 bytes of float64, the most that fits.
+
+	for _, version := range []uint8{0, 2} {
+		filename := filepath.Join(t.TempDir(), "compact_max.h5")
+		fw, err := CreateForWrite(filename, CreateTruncate, WithSuperblockVersion(version))
+		require.NoError(t, err)
+		ds, err := fw.CreateDataset("/data", Float64, []uint64{n}, WithCompactLayout())
+		require.NoError(t, err)
+		want := make([]float64, n)
+		for i := range want {
+			want[i] = float64(i) * 0.5
+		}
+		require.NoError(t, ds.Write(want))
+		require.NoError(t, fw.Close())
+
+		f, err := Open(filename)
+		require.NoError(t, err)
+		got := findDatasetByPath(t, f, "/data")
+		layout, err := got.Layout()
+		require.NoError(t, err)
+		require.True(t, layout.IsCompact())
+		require.Equal(t, uint64(n*8), layout.DataSize)
+
+		values, err := got.Read()
+		require.NoError(t, err)
+		require.Equal(t, want, values)
+
+		tail, err := got.ReadSlice([]uint64{n - 3}, []uint64{3})
+		require.NoError(t, err)
+		require.Equal(t, want[n-3:], tail)
+		require.NoError(t, f.Close())
+	}
+}
@@ -0,0 +1,45 @@
+package hdf5
+
+import (
+	"testing"
+
+	"github.com/scigolib/hdf5/internal/core"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRead_ShuffleFletcherDeflateOfficial reads /dset_all of
+// h5repack_filters.h5, written by the C library with a shuffle, fletcher32
+// and deflate pipeline. Each decoder needs its own client data: shuffle its
+// element size, deflate its level.
+func TestRead_ShuffleFletcherDeflateOfficial(t *testing.T) {
+	f, err := Open("testdata/hdf5_official/h5repack_filters.h5")
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	ds := findDatasetByPath(t, f, "/dset_all")
+	oh, err := core.ReadObjectHeader(f.reader, ds.Address(), f.sb)
+	require.NoError(t, err)
+	var pipeline *core.FilterPipelineMessage
+	for _, msg := range oh.Messages {
+		if msg.Type == core.MsgFilterPipeline {
+			pipeline, err = core.ParseFilterPipelineMessage(msg.Data)
+			require.NoError(t, err)
+		}
+	}
+	require.NotNil(t, pipeline)
+	require.Len(t, pipeline.Filters, 3)
+	require.Equal(t, core.FilterShuffle, pipeline.Filters[0].ID)
+	require.Equal(t, []uint32{4}, pipeline.Filters[0].ClientData)
+	require.Equal(t, core.FilterFletcher, pipeline.Filters[1].ID)
+	require.Equal(t, core.FilterDeflate, pipeline.Filters[2].ID)
+	require.Equal(t, []uint32{9}, pipeline.Filters[2].ClientData)
+
+	all, err := ds.Read()
+	require.NoError(t, err)
+	plain, err := findDatasetByPath(t, f, "/dset_deflate").Read()
+	require.NoError(t, err)
+	require.Equal(t, plain, all)
+	for i, v := range all {
+		require.Equal(t, float64(i), v)
+	}
+}
@@ -1,6 +1,7 @@
 package hdf5
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
@@ -34,11 +35,24 @@ import (
 //	    Stride: []uint64{2, 2},     // Every 2nd element
 //	    Block:  []uint64{1, 1},     // Each block is 1x1
 //	}
+//
+// Example 3 - Rows bottom-up (image stored top-down, 480 rows):
+//
+//	sel := &HyperslabSelection{
+//	    Start:   []uint64{479, 0},
+//	    Count:   []uint64{480, 640},
+//	    Reverse: []bool{true, false},
+//	}
+//
+// A reversed dimension walks downward: Start is the highest coordinate
+// selected, block k covers [Start-k*Stride-(Block-1), Start-k*Stride], and
+// values are returned in descending coordinate order along that dimension.
 type HyperslabSelection struct {
-	Start  []uint64
-	Count  []uint64
-	Stride []uint64 // nil means all 1s (contiguous selection)
-	Block  []uint64 // nil means all 1s (single element blocks)
+	Start   []uint64
+	Count   []uint64
+	Stride  []uint64 // nil means all 1s (contiguous selection)
+	Block   []uint64 // nil means all 1s (single element blocks)
+	Reverse []bool   // nil means no reversed dimensions
 }
 
 // ReadSlice reads a rectangular block from the dataset using simple start/count parameters.
@@ -60,7 +74,7 @@ type HyperslabSelection struct {
 //   - error: Error if selection is invalid or reading fails
 func (d *Dataset) ReadSlice(start, count []uint64) (interface{}, error) {
 	// Read object header to get dataset metadata
-	header, err := core.ReadObjectHeader(d.file.osFile, d.address, d.file.sb)
+	header, err := core.ReadObjectHeader(d.file.reader, d.address, d.file.sb)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read object header: %w", err)
 	}
@@ -138,7 +152,7 @@ func (d *Dataset) ReadSlice(start, count []uint64) (interface{}, error) {
 //   - error: Error if selection is invalid or reading fails
 func (d *Dataset) ReadHyperslab(selection *HyperslabSelection) (interface{}, error) {
 	// Read object header to get dataset metadata
-	header, err := core.ReadObjectHeader(d.file.osFile, d.address, d.file.sb)
+	header, err := core.ReadObjectHeader(d.file.reader, d.address, d.file.sb)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read object header: %w", err)
 	}
@@ -166,7 +180,17 @@ func (d *Dataset) ReadHyperslab(selection *HyperslabSelection) (interface{}, err
 		return nil, fmt.Errorf("invalid selection: %w", err)
 	}
 
-	return d.readHyperslab(selection, header)
+	if !selection.reversed() {
+		return d.readHyperslab(selection, header)
+	}
+
+	// Read the same elements in ascending order, then flip the result.
+	forward := selection.forward()
+	data, err := d.readHyperslab(forward, header)
+	if err != nil {
+		return nil, err
+	}
+	return reverseHyperslabData(data, forward, selection.Reverse)
 }
 
 // validateHyperslabSelection validates a hyperslab selection against dataset dimensions.
@@ -182,8 +206,13 @@ func validateHyperslabSelection(sel *HyperslabSelection, dims []uint64) error {
 	// Fill in defaults for nil Stride and Block
 	fillHyperslabDefaults(sel, ndims)
 
+	// Reversed dimensions extend downward from Start
+	if err := validateReverseBounds(sel, dims); err != nil {
+		return err
+	}
+
 	// Validate bounds for each dimension
-	return validateHyperslabBounds(sel, dims)
+	return validateHyperslabBounds(sel.forward(), dims)
 }
 
 // validateSelectionDimensions checks that selection arrays match dataset dimensionality.
@@ -204,6 +233,10 @@ func validateSelectionDimensions(sel *HyperslabSelection, ndims int) error {
 		return fmt.Errorf("block dimensions (%d) != dataset dimensions (%d)",
 			len(sel.Block), ndims)
 	}
+	if sel.Reverse != nil && len(sel.Reverse) != ndims {
+		return fmt.Errorf("reverse dimensions (%d) != dataset dimensions (%d)",
+			len(sel.Reverse), ndims)
+	}
 	return nil
 }
 
@@ -283,6 +316,9 @@ func (d *Dataset) readHyperslab(selection *HyperslabSelection, header *core.Obje
 	if err != nil {
 		return nil, err
 	}
+	if parsedMsgs.filterPipeline != nil {
+		parsedMsgs.filterPipeline.SkipChecksums = d.file.skipChecksums
+	}
 
 	// Dispatch to appropriate layout reader
 	return d.dispatchHyperslabReader(selection, parsedMsgs)
@@ -484,7 +520,7 @@ func (d *Dataset) readContiguousOptimized(
 		fileOffset := layout.DataAddress + startOffset
 
 		//nolint:gosec // G115: HDF5 addresses fit in int64 for io.ReaderAt interface
-		_, err := d.file.osFile.ReadAt(rawData, int64(fileOffset))
+		_, err := d.file.reader.ReadAt(rawData, int64(fileOffset))
 		if err != nil {
 			return nil, fmt.Errorf("failed to read 1D contiguous data: %w", err)
 		}
@@ -504,7 +540,7 @@ func (d *Dataset) readContiguousOptimized(
 	fileOffset := layout.DataAddress + startByteOffset
 
 	//nolint:gosec // G115: HDF5 addresses fit in int64 for io.ReaderAt interface
-	_, err := d.file.osFile.ReadAt(outputData, int64(fileOffset))
+	_, err := d.file.reader.ReadAt(outputData, int64(fileOffset))
 	if err != nil {
 		return nil, fmt.Errorf("failed to read contiguous data: %w", err)
 	}
@@ -560,7 +596,7 @@ func (d *Dataset) readContiguousRowByRow(
 	fileOffset := layout.DataAddress + startOffset
 
 	//nolint:gosec // G115: HDF5 addresses fit in int64 for io.ReaderAt interface
-	_, err := d.file.osFile.ReadAt(rawData, int64(fileOffset))
+	_, err := d.file.reader.ReadAt(rawData, int64(fileOffset))
 	if err != nil {
 		return nil, fmt.Errorf("failed to read bounding box: %w", err)
 	}
@@ -619,7 +655,7 @@ func (d *Dataset) readContiguous2DOptimized(
 
 					// Read single element
 					//nolint:gosec // G115: HDF5 addresses fit in int64 for io.ReaderAt interface
-					_, err := d.file.osFile.ReadAt(
+					_, err := d.file.reader.ReadAt(
 						outputData[outputIdx*elementSize:(outputIdx+1)*elementSize],
 						int64(byteOffset),
 					)
@@ -666,21 +702,9 @@ func (d *Dataset) readHyperslabChunked(
 		return []float64{}, nil
 	}
 
-	// Parse B-tree to get chunk addresses
-	btreeNode, err := core.ParseBTreeV1Node(
-		d.file.osFile,
-		layout.DataAddress,
-		d.file.sb.OffsetSize,
-		len(chunkDims),
-		chunkDims,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse chunk B-tree: %w", err)
-	}
-
 	// Build chunk index (scaled coordinates -> file address)
 	chunkIndex := make(map[string]chunkIndexEntry)
-	allChunks, err := btreeNode.CollectAllChunks(d.file.osFile, d.file.sb.OffsetSize, chunkDims)
+	allChunks, err := core.CollectChunks(d.file.reader, layout, dataspace, d.file.sb)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get chunk index: %w", err)
 	}
@@ -688,8 +712,9 @@ func (d *Dataset) readHyperslabChunked(
 	for _, chunk := range allChunks {
 		key := chunkCoordsToKey(chunk.Key.Scaled[:len(dims)])
 		chunkIndex[key] = chunkIndexEntry{
-			address: chunk.Address,
-			nbytes:  uint64(chunk.Key.Nbytes),
+			address:    chunk.Address,
+			nbytes:     uint64(chunk.Key.Nbytes),
+			filterMask: chunk.Key.FilterMask,
 		}
 	}
 
@@ -719,8 +744,9 @@ func (d *Dataset) readHyperslabChunked(
 
 // chunkIndexEntry stores chunk location information.
 type chunkIndexEntry struct {
-	address uint64
-	nbytes  uint64
+	address    uint64
+	nbytes     uint64
+	filterMask uint32
 }
 
 // findOverlappingChunks identifies all chunks that overlap with the hyperslab selection.
@@ -830,15 +856,21 @@ func (d *Dataset) extractFromChunk(
 	// Read chunk data (use nbytes from index)
 	chunkData := make([]byte, chunkInfo.nbytes)
 	//nolint:gosec // G115: HDF5 addresses fit in int64 for io.ReaderAt interface
-	_, err := d.file.osFile.ReadAt(chunkData, int64(chunkInfo.address))
+	_, err := d.file.reader.ReadAt(chunkData, int64(chunkInfo.address))
 	if err != nil {
 		return fmt.Errorf("failed to read chunk data: %w", err)
 	}
 
 	// Decompress if needed (using existing FilterPipelineMessage.ApplyFilters)
 	if filterPipeline != nil {
-		chunkData, err = filterPipeline.ApplyFilters(chunkData)
+		chunkData, err = filterPipeline.ApplyFiltersMasked(chunkData, chunkInfo.filterMask)
 		if err != nil {
+			var csErr *core.ChecksumError
+			if errors.As(err, &csErr) {
+				csErr.Chunk = append([]uint64(nil), chunkCoord...)
+				csErr.Address = chunkInfo.address
+				return csErr
+			}
 			return fmt.Errorf("failed to apply filters: %w", err)
 		}
 	}
@@ -0,0 +1,109 @@
+package hdf5
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// reversed reports whether any dimension of the selection is reversed.
+func (sel *HyperslabSelection) reversed() bool {
+	for _, r := range sel.Reverse {
+		if r {
+			return true
+		}
+	}
+	return false
+}
+
+// forward returns the ascending selection covering the same elements. For
+// each reversed dimension Start is moved down to the lowest coordinate.
+// Selections without reversed dimensions are returned unchanged.
+//
+// Stride and Block must already be filled in (see fillHyperslabDefaults).
+func (sel *HyperslabSelection) forward() *HyperslabSelection {
+	if !sel.reversed() {
+		return sel
+	}
+
+	start := make([]uint64, len(sel.Start))
+	copy(start, sel.Start)
+	for i, r := range sel.Reverse {
+		if r && sel.Count[i] > 0 && sel.Block[i] > 0 {
+			start[i] -= (sel.Count[i]-1)*sel.Stride[i] + sel.Block[i] - 1
+		}
+	}
+	return &HyperslabSelection{
+		Start:  start,
+		Count:  sel.Count,
+		Stride: sel.Stride,
+		Block:  sel.Block,
+	}
+}
+
+// validateReverseBounds checks that every reversed dimension stays within
+// [0, dims[i]) while walking down from Start. Zero counts, strides and
+// blocks are left to validateDimensionBounds.
+func validateReverseBounds(sel *HyperslabSelection, dims []uint64) error {
+	for i, r := range sel.Reverse {
+		if !r || sel.Count[i] == 0 || sel.Stride[i] == 0 || sel.Block[i] == 0 {
+			continue
+		}
+		if sel.Start[i] >= dims[i] {
+			return fmt.Errorf("reversed selection out of bounds in dimension %d: start=%d >= size=%d",
+				i, sel.Start[i], dims[i])
+		}
+		// Span below Start: (count-1)*stride + block - 1, checked for overflow.
+		if sel.Count[i]-1 > (math.MaxUint64-sel.Block[i])/sel.Stride[i] {
+			return fmt.Errorf("reversed selection overflows in dimension %d", i)
+		}
+		span := (sel.Count[i]-1)*sel.Stride[i] + sel.Block[i] - 1
+		if span > sel.Start[i] {
+			return fmt.Errorf("reversed selection out of bounds in dimension %d: "+
+				"start=%d - ((count-1)*stride + block - 1) < 0",
+				i, sel.Start[i])
+		}
+	}
+	return nil
+}
+
+// reverseHyperslabData flips data, the row-major result of the ascending
+// selection sel, along the dimensions marked in reverse.
+func reverseHyperslabData(data interface{}, sel *HyperslabSelection, reverse []bool) (interface{}, error) {
+	src := reflect.ValueOf(data)
+	if src.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("cannot reverse hyperslab data of type %T", data)
+	}
+
+	shape := make([]uint64, len(sel.Count))
+	for i := range shape {
+		shape[i] = sel.Count[i] * sel.Block[i]
+	}
+	if total := calculateHyperslabOutputSize(sel); uint64(src.Len()) != total { //nolint:gosec // G115: slice length is non-negative
+		return nil, fmt.Errorf("hyperslab data has %d elements, expected %d", src.Len(), total)
+	}
+
+	dst := reflect.MakeSlice(src.Type(), src.Len(), src.Len())
+	coord := make([]uint64, len(shape))
+	for n := 0; n < src.Len(); n++ {
+		// Destination index of the element at coord in the source.
+		idx := uint64(0)
+		for i, c := range coord {
+			if reverse[i] {
+				c = shape[i] - 1 - c
+			}
+			idx = idx*shape[i] + c
+		}
+		dst.Index(int(idx)).Set(src.Index(n)) //nolint:gosec // G115: idx < slice length
+
+		// Advance coord in row-major order.
+		for i := len(coord) - 1; i >= 0; i-- {
+			coord[i]++
+			if coord[i] < shape[i] {
+				break
+			}
+			coord[i] = 0
+		}
+	}
+	return dst.Interface(), nil
+}
@@ -0,0 +1,105 @@
+package hdf5
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadHyperslab_Reverse(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "reverse.h5")
+
+	// 4x5 grid with value row*10 + col.
+	grid := make([]float64, 20)
+	for i := range grid {
+		grid[i] = float64(i/5*10 + i%5)
+	}
+
+	fw, err := CreateForWrite(filename, CreateTruncate)
+	require.NoError(t, err)
+	contiguous, err := fw.CreateDataset("/contiguous", Float64, []uint64{4, 5})
+	require.NoError(t, err)
+	require.NoError(t, contiguous.Write(grid))
+	chunked, err := fw.CreateDataset("/chunked", Float64, []uint64{4, 5}, WithChunkDims([]uint64{2, 5}))
+	require.NoError(t, err)
+	require.NoError(t, chunked.Write(grid))
+	require.NoError(t, fw.Close())
+
+	f, err := Open(filename)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	tests := []struct {
+		name string
+		sel  HyperslabSelection
+		want []float64
+	}{
+		{
+			name: "rows bottom-up",
+			sel:  HyperslabSelection{Start: []uint64{3, 0}, Count: []uint64{4, 5}, Reverse: []bool{true, false}},
+			want: []float64{30, 31, 32, 33, 34, 20, 21, 22, 23, 24, 10, 11, 12, 13, 14, 0, 1, 2, 3, 4},
+		},
+		{
+			name: "both axes",
+			sel:  HyperslabSelection{Start: []uint64{1, 3}, Count: []uint64{2, 3}, Reverse: []bool{true, true}},
+			want: []float64{13, 12, 11, 3, 2, 1},
+		},
+		{
+			name: "strided columns right to left",
+			sel: HyperslabSelection{
+				Start: []uint64{2, 4}, Count: []uint64{1, 3}, Stride: []uint64{1, 2},
+				Reverse: []bool{false, true},
+			},
+			want: []float64{24, 22, 20},
+		},
+		{
+			name: "reversed blocks",
+			sel: HyperslabSelection{
+				Start: []uint64{0, 4}, Count: []uint64{1, 2}, Stride: []uint64{1, 3}, Block: []uint64{1, 2},
+				Reverse: []bool{false, true},
+			},
+			want: []float64{4, 3, 1, 0},
+		},
+	}
+
+	for _, path := range []string{"/contiguous", "/chunked"} {
+		ds := findDatasetByPath(t, f, path)
+		for _, tt := range tests {
+			t.Run(path+"/"+tt.name, func(t *testing.T) {
+				sel := tt.sel
+				got, err := ds.ReadHyperslab(&sel)
+				require.NoError(t, err)
+				require.Equal(t, tt.want, got)
+			})
+		}
+	}
+}
+
+func TestReadHyperslab_ReverseBounds(t *testing.T) {
+	dims := []uint64{10}
+	tests := []struct {
+		name    string
+		sel     HyperslabSelection
+		wantErr string
+	}{
+		{"start past end", HyperslabSelection{Start: []uint64{10}, Count: []uint64{1}, Reverse: []bool{true}}, "start=10 >= size=10"},
+		{"walks below zero", HyperslabSelection{Start: []uint64{3}, Count: []uint64{5}, Reverse: []bool{true}}, "out of bounds"},
+		{"stride below zero", HyperslabSelection{Start: []uint64{9}, Count: []uint64{5}, Stride: []uint64{3}, Reverse: []bool{true}}, "out of bounds"},
+		{"rank mismatch", HyperslabSelection{Start: []uint64{9}, Count: []uint64{4}, Reverse: []bool{true, false}}, "reverse dimensions"},
+		{"whole range", HyperslabSelection{Start: []uint64{9}, Count: []uint64{10}, Reverse: []bool{true}}, ""},
+		{"strided to zero", HyperslabSelection{Start: []uint64{9}, Count: []uint64{4}, Stride: []uint64{3}, Block: []uint64{1}, Reverse: []bool{true}}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sel := tt.sel
+			err := validateHyperslabSelection(&sel, dims)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
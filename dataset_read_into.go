@@ -0,0 +1,177 @@
+package hdf5
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/scigolib/hdf5/internal/core"
+)
+
+// ReadIntoBuffer reads the raw element bytes of the dataset into dst instead
+// of allocating a new slice, so a buffer can be reused across many reads.
+//
+// The bytes are the stored element bytes, in the byte order of the file's
+// datatype (see Dtype); no conversion is applied. Compact, contiguous and
+// chunked layouts are supported. Missing chunks and unallocated contiguous
+// storage read as zeros.
+//
+// Parameters:
+//   - dst: Destination buffer, at least (number of elements × element size) bytes
+//
+// Returns:
+//   - int: Number of elements written to dst
+//   - error: If dst is too small or the dataset cannot be read
+//
+// Example:
+//
+//	buf := make([]byte, 1<<20)
+//	for _, ds := range datasets {
+//	    n, err := ds.ReadIntoBuffer(buf)
+//	    if err != nil {
+//	        log.Fatal(err)
+//	    }
+//	    process(buf[:n*elemSize])
+//	}
+func (d *Dataset) ReadIntoBuffer(dst []byte) (int, error) {
+	header, err := core.ReadObjectHeader(d.file.reader, d.address, d.file.sb)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read object header: %w", err)
+	}
+
+	n, _, err := core.ReadDatasetRawInto(d.file.reader, header, d.file.sb, dst, d.file.readOptions()...)
+	if err != nil {
+		return 0, err
+	}
+	return int(n), nil //nolint:gosec // G115: element count is bounded by len(dst)
+}
+
+// DatatypeInfo describes the stored datatype of a dataset, for decoding the
+// bytes returned by ReadRaw.
+type DatatypeInfo struct {
+	Class      core.DatatypeClass    // Datatype class (core.DatatypeFixed, core.DatatypeFloat, ...).
+	Size       uint32                // Element size in bytes.
+	ByteOrder  binary.ByteOrder      // Byte order; meaningful for numeric classes.
+	BitField   uint32                // Class bit field (24 bits of class-specific flags).
+	Properties []byte                // Undecoded class properties.
+	Message    *core.DatatypeMessage // The parsed datatype message.
+}
+
+// ReadRaw reads the stored element bytes of the dataset with its dimensions
+// and datatype, without decoding them. It works for every datatype class,
+// including ones the typed readers cannot convert (e.g. 16-byte integers or
+// classes this package does not decode), so callers can decode such data
+// themselves.
+//
+// Elements are in row-major order and in the file's byte order. For
+// variable-length types the bytes are the stored global heap references.
+//
+// Returns:
+//   - []byte: Number of elements × element size bytes
+//   - []uint64: Dataset dimensions (empty for scalar and null datasets)
+//   - *DatatypeInfo: Stored datatype
+//   - error: If the dataset cannot be read
+//
+// Example:
+//
+//	raw, dims, dt, err := ds.ReadRaw()
+//	for i := 0; i < len(raw); i += int(dt.Size) {
+//	    decode(raw[i:i+int(dt.Size)], dt.ByteOrder)
+//	}
+func (d *Dataset) ReadRaw() ([]byte, []uint64, *DatatypeInfo, error) {
+	header, err := core.ReadObjectHeader(d.file.reader, d.address, d.file.sb)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to read object header: %w", err)
+	}
+	messages, err := extractHyperslabMessages(header)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	parsed, err := parseHyperslabMessages(messages, d.file.sb)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	dt := parsed.datatype
+	raw := make([]byte, parsed.dataspace.TotalElements()*uint64(dt.Size))
+	if len(raw) > 0 {
+		if _, _, err := core.ReadDatasetRawInto(d.file.reader, header, d.file.sb, raw, d.file.readOptions()...); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	dims := []uint64{}
+	if parsed.dataspace.Type == core.DataspaceSimple {
+		dims = append(dims, parsed.dataspace.Dimensions...)
+	}
+	info := &DatatypeInfo{
+		Class:      dt.Class,
+		Size:       dt.Size,
+		ByteOrder:  dt.GetByteOrder(),
+		BitField:   dt.ClassBitField,
+		Properties: dt.Properties,
+		Message:    dt,
+	}
+	return raw, dims, info, nil
+}
+
+// ReadInto reads the dataset values into dst, converting them to float64 like
+// Read, but without allocating the result slice.
+//
+// The raw element bytes are staged in a temporary buffer before conversion;
+// use ReadIntoBuffer to avoid that allocation too.
+//
+// Parameters:
+//   - dst: Destination slice, at least as long as the number of elements
+//
+// Returns:
+//   - int: Number of elements written to dst
+//   - error: If dst is too small or the datatype cannot be converted to float64
+//
+// Example:
+//
+//	values := make([]float64, 1024)
+//	n, err := ds.ReadInto(values)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	sum := 0.0
+//	for _, v := range values[:n] {
+//	    sum += v
+//	}
+func (d *Dataset) ReadInto(dst []float64) (int, error) {
+	header, err := core.ReadObjectHeader(d.file.reader, d.address, d.file.sb)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read object header: %w", err)
+	}
+
+	messages, err := extractHyperslabMessages(header)
+	if err != nil {
+		return 0, err
+	}
+	datatype, err := core.ParseDatatypeMessage(messages.datatype.Data)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse datatype: %w", err)
+	}
+	if datatype.IsString() || datatype.IsVariableString() {
+		return 0, fmt.Errorf("cannot convert %s to float64: use ReadStrings for string datasets", datatype)
+	}
+	dataspace, err := core.ParseDataspaceMessage(messages.dataspace.Data)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse dataspace: %w", err)
+	}
+
+	total := dataspace.TotalElements()
+	if uint64(len(dst)) < total {
+		return 0, fmt.Errorf("buffer too small: need %d elements, have %d", total, len(dst))
+	}
+
+	raw := make([]byte, total*uint64(datatype.Size))
+	n, _, err := core.ReadDatasetRawInto(d.file.reader, header, d.file.sb, raw, d.file.readOptions()...)
+	if err != nil {
+		return 0, err
+	}
+	if err := core.ConvertToFloat64Into(dst, raw, datatype, n); err != nil {
+		return 0, err
+	}
+	return int(n), nil //nolint:gosec // G115: element count is bounded by len(dst)
+}
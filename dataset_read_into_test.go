@@ -0,0 +1,116 @@
+package hdf5
+
+import (
+	"bytes"
+	"encoding/binary"
+	"path/filepath"
+	"testing"
+
+	"github.com/scigolib/hdf5/internal/core"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatasetReadInto(t *testing.T) {
+	values := make([]int32, 600)
+	for i := range values {
+		values[i] = int32(i*3 - 500)
+	}
+	var want bytes.Buffer
+	require.NoError(t, binary.Write(&want, binary.LittleEndian, values))
+
+	tests := []struct {
+		name string
+		dims []uint64
+		opts []DatasetOption
+	}{
+		{"contiguous", []uint64{600}, nil},
+		{"chunked", []uint64{600}, []DatasetOption{WithChunkDims([]uint64{256})}},
+		{"chunked 2-D gzip", []uint64{20, 30}, []DatasetOption{WithChunkDims([]uint64{10, 6}), WithGZIPCompression(6)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "into.h5")
+			fw, err := CreateForWrite(path, CreateTruncate)
+			require.NoError(t, err)
+			dw, err := fw.CreateDataset("/data", Int32, tt.dims, tt.opts...)
+			require.NoError(t, err)
+			require.NoError(t, dw.Write(values))
+			require.NoError(t, fw.Close())
+
+			f, err := Open(path)
+			require.NoError(t, err)
+			defer func() { _ = f.Close() }()
+			ds := findDatasetByPath(t, f, "/data")
+
+			// Reuse a dirty, oversized buffer across reads.
+			buf := bytes.Repeat([]byte{0xAA}, want.Len()+16)
+			for range 2 {
+				n, err := ds.ReadIntoBuffer(buf)
+				require.NoError(t, err)
+				require.Equal(t, len(values), n)
+				require.Equal(t, want.Bytes(), buf[:n*4])
+			}
+
+			raw, dims, dt, err := ds.ReadRaw()
+			require.NoError(t, err)
+			require.Equal(t, want.Bytes(), raw)
+			require.Equal(t, tt.dims, dims)
+			require.Equal(t, core.DatatypeFixed, dt.Class)
+			require.Equal(t, uint32(4), dt.Size)
+			require.Equal(t, binary.ByteOrder(binary.LittleEndian), dt.ByteOrder)
+			require.NotZero(t, dt.BitField&0x08) // Signed.
+
+			floats := make([]float64, len(values)+3)
+			n, err := ds.ReadInto(floats)
+			require.NoError(t, err)
+			require.Equal(t, len(values), n)
+			for i, v := range values {
+				require.Equal(t, float64(v), floats[i])
+			}
+		})
+	}
+}
+
+func TestDatasetReadInto_BufferTooSmall(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "small.h5")
+	fw, err := CreateForWrite(path, CreateTruncate)
+	require.NoError(t, err)
+	dw, err := fw.CreateDataset("/data", Float64, []uint64{10})
+	require.NoError(t, err)
+	require.NoError(t, dw.Write(make([]float64, 10)))
+	require.NoError(t, fw.Close())
+
+	f, err := Open(path)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+	ds := findDatasetByPath(t, f, "/data")
+
+	_, err = ds.ReadIntoBuffer(make([]byte, 79))
+	require.ErrorContains(t, err, "buffer too small")
+	_, err = ds.ReadInto(make([]float64, 9))
+	require.ErrorContains(t, err, "buffer too small")
+}
+
+func TestDataset_ReadRaw_Opaque(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "raw_opaque.h5")
+	fw, err := CreateForWrite(path, CreateTruncate)
+	require.NoError(t, err)
+	dw, err := fw.CreateDataset("/blobs", Opaque, []uint64{2, 2}, WithOpaqueTag("sensor frame", 3))
+	require.NoError(t, err)
+	want := []byte("abcdefghijkl")
+	require.NoError(t, dw.WriteRaw(want))
+	require.NoError(t, fw.Close())
+
+	f, err := Open(path)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	raw, dims, dt, err := findDatasetByPath(t, f, "/blobs").ReadRaw()
+	require.NoError(t, err)
+	require.Equal(t, want, raw)
+	require.Equal(t, []uint64{2, 2}, dims)
+	require.Equal(t, core.DatatypeOpaque, dt.Class)
+	require.Equal(t, uint32(3), dt.Size)
+	require.Contains(t, string(dt.Properties), "sensor frame")
+}
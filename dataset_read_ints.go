@@ -0,0 +1,62 @@
+package hdf5
+
+import (
+	"fmt"
+
+	"github.com/scigolib/hdf5/internal/core"
+)
+
+// ReadInts reads an integer dataset as int64 values, decoding the stored
+// fixed-point type directly. Unlike Read, which converts through float64 and
+// rounds integers above 2^53, it is exact, so it suits IDs and counters.
+//
+// Integers of 1 to 8 bytes are supported, in either byte order. Unsigned
+// values above math.MaxInt64 are an error; use ReadUints for them, or
+// ReadBigInts for wider types.
+//
+// Returns:
+//   - []int64: One value per element
+//   - error: If the dataset is not an integer dataset or a value does not fit
+//
+// Example:
+//
+//	ids, err := ds.ReadInts()
+//	fmt.Println(ids[0])
+func (d *Dataset) ReadInts() ([]int64, error) {
+	raw, dt, err := d.readIntegerRaw()
+	if err != nil {
+		return nil, err
+	}
+	return core.DecodeInt64s(raw, dt.Message, uint64(len(raw))/uint64(dt.Size))
+}
+
+// ReadUints reads an integer dataset as uint64 values, decoding the stored
+// fixed-point type directly and exactly, like ReadInts. Negative values of
+// signed datasets are an error.
+//
+// Returns:
+//   - []uint64: One value per element
+//   - error: If the dataset is not an integer dataset or a value is negative
+//
+// Example:
+//
+//	counters, err := ds.ReadUints()
+func (d *Dataset) ReadUints() ([]uint64, error) {
+	raw, dt, err := d.readIntegerRaw()
+	if err != nil {
+		return nil, err
+	}
+	return core.DecodeUint64s(raw, dt.Message, uint64(len(raw))/uint64(dt.Size))
+}
+
+// readIntegerRaw reads the raw bytes of an integer dataset.
+func (d *Dataset) readIntegerRaw() ([]byte, *DatatypeInfo, error) {
+	raw, _, dt, err := d.ReadRaw()
+	if err != nil {
+		return nil, nil, err
+	}
+	if dt.Class != core.DatatypeFixed {
+		return nil, nil, fmt.Errorf("dataset is not an integer dataset: %s", dt.Message)
+	}
+	return raw, dt, nil
+}
@@ -0,0 +1,54 @@
+package hdf5
+
+import (
+	"math"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataset_ReadIntsAndUints(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "ints.h5")
+
+	fw, err := CreateForWrite(filename, CreateTruncate)
+	require.NoError(t, err)
+	signed, err := fw.CreateDataset("/signed", Int64, []uint64{3})
+	require.NoError(t, err)
+	require.NoError(t, signed.Write([]int64{math.MinInt64, -1, 1<<53 + 1}))
+	small, err := fw.CreateDataset("/small", Int16, []uint64{2})
+	require.NoError(t, err)
+	require.NoError(t, small.Write([]int16{-300, 300}))
+	unsigned, err := fw.CreateDataset("/unsigned", Uint64, []uint64{2})
+	require.NoError(t, err)
+	require.NoError(t, unsigned.Write([]uint64{math.MaxUint64, 1<<60 + 3}))
+	floats, err := fw.CreateDataset("/floats", Float64, []uint64{1})
+	require.NoError(t, err)
+	require.NoError(t, floats.Write([]float64{1.5}))
+	require.NoError(t, fw.Close())
+
+	f, err := Open(filename)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	ints, err := findDatasetByPath(t, f, "/signed").ReadInts()
+	require.NoError(t, err)
+	require.Equal(t, []int64{math.MinInt64, -1, 1<<53 + 1}, ints) // 2^53+1 is not a float64.
+
+	ints, err = findDatasetByPath(t, f, "/small").ReadInts()
+	require.NoError(t, err)
+	require.Equal(t, []int64{-300, 300}, ints)
+
+	uints, err := findDatasetByPath(t, f, "/unsigned").ReadUints()
+	require.NoError(t, err)
+	require.Equal(t, []uint64{math.MaxUint64, 1<<60 + 3}, uints)
+
+	_, err = findDatasetByPath(t, f, "/unsigned").ReadInts()
+	require.ErrorContains(t, err, "overflows int64")
+	_, err = findDatasetByPath(t, f, "/small").ReadUints()
+	require.ErrorContains(t, err, "negative value -300")
+	_, err = findDatasetByPath(t, f, "/floats").ReadInts()
+	require.ErrorContains(t, err, "not an integer dataset")
+	_, err = findDatasetByPath(t, f, "/floats").ReadUints()
+	require.ErrorContains(t, err, "not an integer dataset")
+}
@@ -0,0 +1,88 @@
+package hdf5
+
+import (
+	"fmt"
+)
+
+// ReadReshaped reads the whole dataset and returns its values together with
+// the dataset dimensions, so callers can index the flat result in row-major
+// order: element [i, j] of a 2-D dataset is at data[i*shape[1]+j].
+//
+// The type of the returned data follows the dataset datatype:
+//   - []string for fixed- and variable-length string datasets (see ReadStrings)
+//   - []core.CompoundValue for compound datasets (see ReadCompound)
+//   - []float64 for numeric datasets (see Read)
+//
+// Scalar datasets return an empty shape and a single value.
+//
+// Returns:
+//   - interface{}: Flat values in row-major order
+//   - []uint64: Dataset dimensions
+//   - error: If the dataset cannot be read
+//
+// Example:
+//
+//	data, shape, err := ds.ReadReshaped()
+//	values := data.([]float64)
+//	fmt.Println(values[1*shape[1]+2]) // element [1, 2]
+func (d *Dataset) ReadReshaped() (interface{}, []uint64, error) {
+	shape, err := d.Shape()
+	if err != nil {
+		return nil, nil, err
+	}
+	dt, err := d.Dtype()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var data interface{}
+	switch {
+	case dt.IsString() || dt.IsVariableString():
+		data, err = d.ReadStrings()
+	case dt.IsCompound():
+		data, err = d.ReadCompound()
+	default:
+		data, err = d.Read()
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, shape, nil
+}
+
+// Read2D reads a two-dimensional numeric dataset as a matrix of float64
+// values, one slice per row. The rows share a single backing array.
+//
+// Returns:
+//   - [][]float64: Rows of the dataset
+//   - error: If the dataset is not two-dimensional or cannot be read
+//
+// Example:
+//
+//	m, err := ds.Read2D()
+//	fmt.Println(m[1][2]) // element [1, 2]
+func (d *Dataset) Read2D() ([][]float64, error) {
+	shape, err := d.Shape()
+	if err != nil {
+		return nil, err
+	}
+	if len(shape) != 2 {
+		return nil, fmt.Errorf("Read2D requires a 2-D dataset, dataset has %d dimensions", len(shape))
+	}
+
+	flat, err := d.Read()
+	if err != nil {
+		return nil, err
+	}
+	rows, cols := shape[0], shape[1]
+	if uint64(len(flat)) != rows*cols {
+		return nil, fmt.Errorf("read %d values, expected %d for shape %v", len(flat), rows*cols, shape)
+	}
+
+	matrix := make([][]float64, rows)
+	for i := range matrix {
+		start := uint64(i) * cols //nolint:gosec // G115: row index is non-negative
+		matrix[i] = flat[start : start+cols : start+cols]
+	}
+	return matrix, nil
+}
@@ -0,0 +1,46 @@
+package hdf5
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataset_Read2D_ReadReshaped(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "reshape.h5")
+
+	fw, err := CreateForWrite(filename, CreateTruncate)
+	require.NoError(t, err)
+	grid, err := fw.CreateDataset("/grid", Float64, []uint64{2, 3})
+	require.NoError(t, err)
+	require.NoError(t, grid.Write([]float64{1, 2, 3, 4, 5, 6}))
+	line, err := fw.CreateDataset("/line", Int32, []uint64{4})
+	require.NoError(t, err)
+	require.NoError(t, line.Write([]int32{7, 8, 9, 10}))
+	names, err := fw.CreateDataset("/names", String, []uint64{2}, WithStringSize(8))
+	require.NoError(t, err)
+	require.NoError(t, names.Write([]string{"alpha", "beta"}))
+	require.NoError(t, fw.Close())
+
+	f, err := Open(filename)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	m, err := findDatasetByPath(t, f, "/grid").Read2D()
+	require.NoError(t, err)
+	require.Equal(t, [][]float64{{1, 2, 3}, {4, 5, 6}}, m)
+
+	_, err = findDatasetByPath(t, f, "/line").Read2D()
+	require.ErrorContains(t, err, "requires a 2-D dataset")
+
+	data, shape, err := findDatasetByPath(t, f, "/grid").ReadReshaped()
+	require.NoError(t, err)
+	require.Equal(t, []uint64{2, 3}, shape)
+	require.Equal(t, []float64{1, 2, 3, 4, 5, 6}, data)
+
+	data, shape, err = findDatasetByPath(t, f, "/names").ReadReshaped()
+	require.NoError(t, err)
+	require.Equal(t, []uint64{2}, shape)
+	require.Equal(t, []string{"alpha", "beta"}, data)
+}
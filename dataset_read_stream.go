@@ -0,0 +1,181 @@
+package hdf5
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/scigolib/hdf5/internal/core"
+)
+
+// NewReader returns a reader that streams the raw element bytes of a
+// one-dimensional (or scalar) dataset in element order.
+//
+// The bytes are the stored element bytes, in the byte order of the file's
+// datatype (see Dtype); no conversion is applied. Chunked datasets are read
+// and decompressed one chunk at a time as the reader advances, so only one
+// chunk is held in memory. Chunks that were never written, and contiguous
+// storage that was never allocated, read as zeros.
+//
+// Returns:
+//   - io.Reader: Sequential reader over the dataset bytes
+//   - error: If the dataset has more than one dimension or its layout is not supported
+//
+// Example:
+//
+//	r, err := ds.NewReader()
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	_, err = io.Copy(out, r)
+func (d *Dataset) NewReader() (io.Reader, error) {
+	header, err := core.ReadObjectHeader(d.file.reader, d.address, d.file.sb)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object header: %w", err)
+	}
+
+	messages, err := extractHyperslabMessages(header)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := parseHyperslabMessages(messages, d.file.sb)
+	if err != nil {
+		return nil, err
+	}
+	if len(parsed.dataspace.Dimensions) > 1 {
+		return nil, fmt.Errorf("NewReader supports 1-D datasets only, dataset has %d dimensions",
+			len(parsed.dataspace.Dimensions))
+	}
+
+	size := parsed.dataspace.TotalElements() * uint64(parsed.datatype.Size)
+	layout := parsed.layout
+
+	switch {
+	case layout.IsCompact():
+		data := layout.CompactData
+		if uint64(len(data)) > size {
+			data = data[:size]
+		}
+		return bytes.NewReader(data), nil
+
+	case layout.IsContiguous():
+		if layout.DataAddress == undefinedAddress {
+			return io.LimitReader(zeroReader{}, int64(size)), nil //nolint:gosec // G115: dataset sizes fit in int64
+		}
+		//nolint:gosec // G115: HDF5 addresses and sizes fit in int64 for io.ReaderAt interface
+		return io.NewSectionReader(d.file.reader, int64(layout.DataAddress), int64(size)), nil
+
+	case layout.IsChunked():
+		chunks, err := core.CollectChunks(d.file.reader, layout, parsed.dataspace, d.file.sb)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get chunk index: %w", err)
+		}
+		if parsed.filterPipeline != nil {
+			parsed.filterPipeline.SkipChecksums = d.file.skipChecksums
+		}
+
+		index := make(map[uint64]core.ChunkEntry, len(chunks))
+		for _, chunk := range chunks {
+			index[chunk.Key.Scaled[0]] = chunk
+		}
+		return &chunkStreamReader{
+			dataset:        d,
+			index:          index,
+			filterPipeline: parsed.filterPipeline,
+			chunkSize:      layout.ChunkSize[0] * uint64(parsed.datatype.Size),
+			size:           size,
+			current:        -1,
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported layout class: %d", layout.Class)
+	}
+}
+
+// chunkStreamReader streams a 1-D chunked dataset, loading and decompressing
+// one chunk at a time.
+type chunkStreamReader struct {
+	dataset        *Dataset
+	index          map[uint64]core.ChunkEntry // Allocated chunks by chunk number.
+	filterPipeline *core.FilterPipelineMessage
+	chunkSize      uint64 // Unfiltered chunk size in bytes.
+	size           uint64 // Dataset size in bytes.
+	pos            uint64 // Offset of the next byte to return.
+	current        int64  // Chunk number held in buf, or -1.
+	buf            []byte
+}
+
+// Read implements io.Reader.
+func (s *chunkStreamReader) Read(p []byte) (int, error) {
+	if s.pos >= s.size {
+		return 0, io.EOF
+	}
+
+	n := 0
+	for n < len(p) && s.pos < s.size {
+		chunk := s.pos / s.chunkSize
+		if err := s.load(chunk); err != nil {
+			return n, err
+		}
+
+		start := s.pos - chunk*s.chunkSize
+		end := min(s.chunkSize, s.size-chunk*s.chunkSize)
+		copied := copy(p[n:], s.buf[start:end])
+		n += copied
+		s.pos += uint64(copied)
+	}
+	return n, nil
+}
+
+// load reads and decompresses the given chunk into buf unless it is already
+// loaded. Missing chunks are zero-filled.
+func (s *chunkStreamReader) load(chunk uint64) error {
+	if s.current == int64(chunk) { //nolint:gosec // G115: chunk count fits in int64
+		return nil
+	}
+
+	entry, ok := s.index[chunk]
+	if !ok {
+		s.buf = make([]byte, s.chunkSize)
+		s.current = int64(chunk) //nolint:gosec // G115: chunk count fits in int64
+		return nil
+	}
+
+	data := make([]byte, entry.Key.Nbytes)
+	//nolint:gosec // G115: HDF5 addresses fit in int64 for io.ReaderAt interface
+	if _, err := s.dataset.file.reader.ReadAt(data, int64(entry.Address)); err != nil {
+		return fmt.Errorf("failed to read chunk %d: %w", chunk, err)
+	}
+
+	if s.filterPipeline != nil {
+		var err error
+		data, err = s.filterPipeline.ApplyFiltersMasked(data, entry.Key.FilterMask)
+		if err != nil {
+			var csErr *core.ChecksumError
+			if errors.As(err, &csErr) {
+				csErr.Chunk = []uint64{chunk}
+				csErr.Address = entry.Address
+				return csErr
+			}
+			return fmt.Errorf("failed to apply filters to chunk %d: %w", chunk, err)
+		}
+	}
+	// The last chunk only needs to cover the end of the dataset.
+	if need := min(s.chunkSize, s.size-chunk*s.chunkSize); uint64(len(data)) < need {
+		return fmt.Errorf("chunk %d too small: %d bytes, expected %d", chunk, len(data), need)
+	}
+
+	s.buf = data
+	s.current = int64(chunk) //nolint:gosec // G115: chunk count fits in int64
+	return nil
+}
+
+// zeroReader reads an endless stream of zero bytes.
+type zeroReader struct{}
+
+// Read implements io.Reader.
+func (zeroReader) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
@@ -0,0 +1,75 @@
+package hdf5
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"path/filepath"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatasetNewReader(t *testing.T) {
+	values := make([]int32, 2500)
+	for i := range values {
+		values[i] = int32(i*7 - 1000)
+	}
+	var want bytes.Buffer
+	require.NoError(t, binary.Write(&want, binary.LittleEndian, values))
+
+	tests := []struct {
+		name string
+		opts []DatasetOption
+	}{
+		{"contiguous", nil},
+		{"chunked", []DatasetOption{WithChunkDims([]uint64{1000})}},
+		{"chunked shuffle gzip", []DatasetOption{WithChunkDims([]uint64{1000}), WithShuffle(), WithGZIPCompression(6)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "stream.h5")
+			fw, err := CreateForWrite(path, CreateTruncate)
+			require.NoError(t, err)
+			dw, err := fw.CreateDataset("/data", Int32, []uint64{uint64(len(values))}, tt.opts...)
+			require.NoError(t, err)
+			require.NoError(t, dw.Write(values))
+			require.NoError(t, fw.Close())
+
+			f, err := Open(path)
+			require.NoError(t, err)
+			defer func() { _ = f.Close() }()
+			ds := findDatasetByPath(t, f, "/data")
+
+			r, err := ds.NewReader()
+			require.NoError(t, err)
+			var got bytes.Buffer
+			_, err = io.Copy(&got, r)
+			require.NoError(t, err)
+			require.Equal(t, want.Bytes(), got.Bytes())
+
+			r, err = ds.NewReader()
+			require.NoError(t, err)
+			require.NoError(t, iotest.TestReader(r, want.Bytes()))
+		})
+	}
+}
+
+func TestDatasetNewReader_MultiDimensional(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stream2d.h5")
+	fw, err := CreateForWrite(path, CreateTruncate)
+	require.NoError(t, err)
+	dw, err := fw.CreateDataset("/matrix", Float64, []uint64{2, 3})
+	require.NoError(t, err)
+	require.NoError(t, dw.Write([]float64{1, 2, 3, 4, 5, 6}))
+	require.NoError(t, fw.Close())
+
+	f, err := Open(path)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	_, err = findDatasetByPath(t, f, "/matrix").NewReader()
+	require.ErrorContains(t, err, "1-D")
+}
@@ -0,0 +1,134 @@
+package hdf5
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/scigolib/hdf5/internal/core"
+)
+
+// ReadStruct reads a compound dataset into dst, which must be a pointer to a
+// slice of structs. The slice is replaced with one struct per dataset element.
+//
+// Struct fields are matched to compound members by name, using the same rules
+// as compound attribute writes: the `hdf5` struct tag renames a member,
+// `hdf5:"-"` skips the field and unexported fields are ignored. Members
+// without a matching field are ignored; a field without a matching member is
+// an error.
+//
+// Member types map to fields as follows:
+//   - Numeric members to any integer or float field (values are converted)
+//   - String members to string fields
+//   - Nested compound members to struct fields
+//   - Array members to Go arrays of the same length, or to slices; arrays
+//     with several dimensions are flattened in row-major order
+//
+// Variable-length sequence members are not supported yet.
+//
+// Example:
+//
+//	type Hit struct {
+//		ID    int32      `hdf5:"id"`
+//		Pos   [3]float64 `hdf5:"pos"`
+//		Track struct {
+//			Charge int8
+//		} `hdf5:"track"`
+//	}
+//	var hits []Hit
+//	err := ds.ReadStruct(&hits)
+func (d *Dataset) ReadStruct(dst interface{}) error {
+	ptr := reflect.ValueOf(dst)
+	if ptr.Kind() != reflect.Pointer || ptr.IsNil() {
+		return errors.New("ReadStruct requires a non-nil pointer to a slice of structs")
+	}
+	slice := ptr.Elem()
+	if slice.Kind() != reflect.Slice || slice.Type().Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("ReadStruct requires a pointer to a slice of structs, got %s", ptr.Type())
+	}
+
+	values, err := d.ReadCompound()
+	if err != nil {
+		return err
+	}
+
+	out := reflect.MakeSlice(slice.Type(), len(values), len(values))
+	for i, value := range values {
+		if err := assignCompound(out.Index(i), value); err != nil {
+			return fmt.Errorf("element %d: %w", i, err)
+		}
+	}
+	slice.Set(out)
+	return nil
+}
+
+// assignCompound stores one compound value into the struct v.
+func assignCompound(v reflect.Value, value core.CompoundValue) error {
+	for _, field := range compoundFields(v.Type()) {
+		member, ok := value[field.name]
+		if !ok {
+			return fmt.Errorf("no compound member %q for field %s.%s",
+				field.name, v.Type(), v.Type().Field(field.index).Name)
+		}
+		if err := assignMember(v.Field(field.index), member); err != nil {
+			return fmt.Errorf("member %s: %w", field.name, err)
+		}
+	}
+	return nil
+}
+
+// assignMember stores one decoded member value into the field v.
+func assignMember(v reflect.Value, member interface{}) error {
+	switch m := member.(type) {
+	case core.CompoundValue:
+		if v.Kind() != reflect.Struct {
+			return fmt.Errorf("cannot store compound in %s", v.Type())
+		}
+		return assignCompound(v, m)
+
+	case []interface{}:
+		switch v.Kind() {
+		case reflect.Array:
+			if v.Len() != len(m) {
+				return fmt.Errorf("cannot store %d array elements in %s", len(m), v.Type())
+			}
+		case reflect.Slice:
+			v.Set(reflect.MakeSlice(v.Type(), len(m), len(m)))
+		default:
+			return fmt.Errorf("cannot store array in %s", v.Type())
+		}
+		for i, elem := range m {
+			if err := assignMember(v.Index(i), elem); err != nil {
+				return fmt.Errorf("element %d: %w", i, err)
+			}
+		}
+		return nil
+
+	case string:
+		if v.Kind() != reflect.String {
+			return fmt.Errorf("cannot store string in %s", v.Type())
+		}
+		v.SetString(m)
+		return nil
+
+	default:
+		mv := reflect.ValueOf(member)
+		if !isNumericKind(mv.Kind()) || !isNumericKind(v.Kind()) {
+			return fmt.Errorf("cannot store %T in %s", member, v.Type())
+		}
+		v.Set(mv.Convert(v.Type()))
+		return nil
+	}
+}
+
+// isNumericKind reports whether k is an integer or float kind.
+func isNumericKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,103 @@
+package hdf5
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestReadStruct_NestedArrays reads a compound with array members and
+// nested compounds written by the HDF5 library.
+func TestReadStruct_NestedArrays(t *testing.T) {
+	type deepNested struct {
+		Short  [10]int16   `hdf5:"deep_nested_short"`
+		Double [10]float64 `hdf5:"deep_nested_double"`
+	}
+	type record struct {
+		A      [4]uint32  `hdf5:"a"`
+		B      []int32    `hdf5:"b"`
+		C      [8]float32 `hdf5:"c"`
+		Nested struct {
+			Double  float64   `hdf5:"nested_double"`
+			Strings [4]string `hdf5:"nested_string_array"`
+		} `hdf5:"nested_compound"`
+		Multiple struct {
+			B struct {
+				Deep deepNested `hdf5:"deep_nested_compound"`
+			} `hdf5:"further_nested_compoundB"`
+		} `hdf5:"multiple_nested_compound"`
+	}
+
+	f, err := Open("testdata/hdf5_official/tcompound_complex2.h5")
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	ds := findDatasetByPath(t, f, "/CompoundComplex1D")
+
+	var records []record
+	require.NoError(t, ds.ReadStruct(&records))
+	require.Len(t, records, 32)
+
+	r := records[1]
+	require.Equal(t, [4]uint32{10, 11, 12, 13}, r.A)
+	require.Equal(t, []int32{-10, -9, -8, -7, -6, -5}, r.B)
+	require.Equal(t, float32(10), r.C[0])
+	require.Equal(t, [4]string{"String test", "String test", "String test", "String test"}, records[0].Nested.Strings)
+	require.Equal(t, int16(9), records[0].Multiple.B.Deep.Short[9])
+	require.Equal(t, float64(9), records[0].Multiple.B.Deep.Double[9])
+}
+
+// TestReadStruct_ArrayMembers reads a compound of integer and float arrays
+// stored with a version 2 compound datatype.
+func TestReadStruct_ArrayMembers(t *testing.T) {
+	type record struct {
+		U8  [8]uint8   `hdf5:"DU08BITS"`
+		I16 [16]int16  `hdf5:"DS16BITS"`
+		I64 [64]int64  `hdf5:"DS64BITS"`
+		F64 [8]float64 `hdf5:"DummyDBL"`
+	}
+
+	f, err := Open("testdata/hdf5_official/tcmpdintarray.h5")
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	var records []record
+	require.NoError(t, findDatasetByPath(t, f, "/CompoundIntArray").ReadStruct(&records))
+	require.Len(t, records, 4)
+
+	r := records[0]
+	require.Equal(t, [8]uint8{255, 254, 252, 248, 240, 224, 192, 128}, r.U8)
+	require.Equal(t, int16(-32768), r.I16[15])
+	require.Equal(t, int64(-9223372036854775808), r.I64[63])
+	require.InDelta(t, 7.0001, r.F64[7], 1e-9)
+}
+
+// TestReadStruct_Errors tests destination and field mismatches.
+func TestReadStruct_Errors(t *testing.T) {
+	f, err := Open("testdata/hdf5_official/tcmpdintarray.h5")
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	ds := findDatasetByPath(t, f, "/CompoundIntArray")
+
+	var notPointer []struct{}
+	require.Error(t, ds.ReadStruct(notPointer))
+
+	var ints []int
+	require.ErrorContains(t, ds.ReadStruct(&ints), "slice of structs")
+
+	var missing []struct {
+		X int32 `hdf5:"missing"`
+	}
+	require.ErrorContains(t, ds.ReadStruct(&missing), `no compound member "missing"`)
+
+	var wrongLen []struct {
+		U8 [4]uint8 `hdf5:"DU08BITS"`
+	}
+	require.ErrorContains(t, ds.ReadStruct(&wrongLen), "cannot store 8 array elements")
+
+	var wrongKind []struct {
+		U8 string `hdf5:"DU08BITS"`
+	}
+	require.ErrorContains(t, ds.ReadStruct(&wrongKind), "cannot store array")
+}
@@ -4,6 +4,9 @@ import (
 	"encoding/binary"
 	"fmt"
 	"math"
+	"os"
+	"slices"
+	"sync"
 	"time"
 	"unsafe"
 
@@ -87,11 +90,11 @@ const (
 	// Reference datatypes - point to objects or dataset regions.
 
 	// ObjectReference represents reference to an object (group/dataset).
-	// Value type: ObjectRef (uint64 - 8-byte object address).
+	// Value type: ObjectRef (8-byte object address, see MakeObjectRef).
 	ObjectReference Datatype = 300
 
 	// RegionReference represents reference to a dataset region.
-	// Value type: RegionRef ([12]byte - 8-byte object addr + 4-byte region info).
+	// Value type: RegionRef (global heap ID of the region, see MakeRegionRef).
 	RegionReference Datatype = 301
 
 	// Opaque datatype - uninterpreted byte sequences with descriptive tag.
@@ -140,6 +143,14 @@ const (
 	// VLenUint8 represents variable-length uint8 sequences (byte arrays).
 	// Go type: [][]byte.
 	VLenUint8 Datatype = 507
+
+	// VLenStringList represents variable-length lists of variable-length
+	// strings: each element holds any number of strings of any length.
+	// Stored as a VLen sequence whose base type is a VLen string, so each
+	// element's heap object holds the heap IDs of its strings.
+	// Go type: [][]string (write), read back with Dataset.ReadStringLists.
+	// Example: [][]string{{"a", "b"}, {}, {"longer string"}}.
+	VLenStringList Datatype = 508
 )
 
 // Unlimited represents unlimited dimension size for resizable datasets.
@@ -201,10 +212,13 @@ func (h *stringTypeHandler) GetInfo(config *datasetConfig) (*datatypeInfo, error
 	if config.stringSize == 0 {
 		return nil, fmt.Errorf("string datatype requires size > 0 (use WithStringSize option)")
 	}
+	if config.stringPadding > StringSpacePad {
+		return nil, fmt.Errorf("invalid string padding: %d", config.stringPadding)
+	}
 	return &datatypeInfo{
 		class:         core.DatatypeString,
 		size:          config.stringSize,
-		classBitField: 0x00,
+		classBitField: uint32(config.stringPadding), // Bits 0-3: padding, bits 4-7: charset (ASCII)
 	}, nil
 }
 
@@ -448,8 +462,14 @@ func (h *vlenTypeHandler) EncodeDatatypeMessage(_ *datatypeInfo) ([]byte, error)
 		vlenType = 0x01 // String
 	}
 
-	// ClassBitField for VLen: type (1 byte) + padding (1 byte) + charset (2 bytes)
-	classBitField := uint32(vlenType) | (uint32(0x00) << 8) | (uint32(0x00) << 16) // UTF-8 charset
+	// ClassBitField for VLen: type in bits 0-3, padding in bits 4-7, charset
+	// in bits 8-11. Strings are declared UTF-8, as h5py does for str data:
+	// the elements are Go strings, and with an ASCII label some readers
+	// decode multibyte characters as Latin-1.
+	classBitField := uint32(vlenType)
+	if h.baseType == 0 {
+		classBitField |= uint32(core.CharsetUTF8) << 8
+	}
 
 	msg := &core.DatatypeMessage{
 		Class:         core.DatatypeVarLen,
@@ -528,6 +548,8 @@ func init() {
 		VLenUint32:  &vlenTypeHandler{Uint32},
 		VLenUint64:  &vlenTypeHandler{Uint64},
 		VLenUint8:   &vlenTypeHandler{Uint8},
+
+		VLenStringList: &vlenTypeHandler{VLenString},
 	}
 }
 
@@ -559,9 +581,11 @@ const snodEntrySize = 2*8 + 4 + 4 + 16 // 40
 // Format: 8-byte header + snodCapacity * snodEntrySize.
 const snodTotalSize = 8 + snodCapacity*snodEntrySize // 328
 
-// GroupMetadata stores metadata for a group (symbol table format).
+// GroupMetadata stores metadata for a group.
 // Used for tracking non-root groups to enable nested dataset creation.
+// For link-info groups (modern == true) only headerAddr and headerAllocSz are set.
 type GroupMetadata struct {
+	modern        bool   // Link-info (HDF5 1.8+) group: links live in the object header or dense storage
 	heapAddr      uint64 // Local heap address (stores link names)
 	stNodeAddr    uint64 // Symbol table node address (stores entries)
 	btreeAddr     uint64 // B-tree address (indexes symbol table)
@@ -576,6 +600,7 @@ type FileWriter struct {
 	writer   *writer.FileWriter
 	filename string
 	config   *FileWriteConfig // Configuration for write operations
+	created  bool             // File created by CreateForWrite (removed by Abort)
 
 	// Root group metadata for linking objects
 	rootGroupAddr     uint64 // Address of root group object header
@@ -589,6 +614,10 @@ type FileWriter struct {
 	// Example: "/mygroup" → {heapAddr, stNodeAddr, btreeAddr}
 	groups map[string]*GroupMetadata
 
+	// Original allocation sizes of dataset object headers created in this
+	// session, keyed by header address (see lookupHeaderAllocSize).
+	datasetHeaderAllocs map[uint64]uint64
+
 	// Global heap writer for variable-length data (vlen strings, ragged arrays)
 	globalHeapWriter *globalHeapWriter
 
@@ -597,6 +626,13 @@ type FileWriter struct {
 	lazyRebalancingConfig        *structures.LazyRebalancingConfig
 	incrementalRebalancingConfig *structures.IncrementalRebalancingConfig
 	smartRebalancingConfig       *SmartRebalancingConfig
+
+	// chunkMu serializes chunk allocation, chunk writes and chunk index
+	// updates, so chunks of a dataset can be written from several goroutines.
+	chunkMu sync.Mutex
+	// Chunked datasets whose index has not been written since their last
+	// WriteChunk; flushed on Close.
+	pendingChunkIndexes []*DatasetWriter
 }
 
 // lookupHeaderAllocSize returns the original allocation size for an object header
@@ -613,7 +649,18 @@ func (fw *FileWriter) lookupHeaderAllocSize(objectAddr uint64) uint64 {
 			return meta.headerAllocSz
 		}
 	}
-	return 0
+	// Check datasets.
+	return fw.datasetHeaderAllocs[objectAddr]
+}
+
+// trackDatasetHeader records the allocation size of a new dataset object
+// header, so attributes that outgrow it are moved to a continuation chunk
+// instead of overwriting whatever was allocated after the header.
+func (fw *FileWriter) trackDatasetHeader(addr, allocSize uint64) {
+	if fw.datasetHeaderAllocs == nil {
+		fw.datasetHeaderAllocs = make(map[uint64]uint64)
+	}
+	fw.datasetHeaderAllocs[addr] = allocSize
 }
 
 // Superblock version constants for file creation.
@@ -636,9 +683,26 @@ type WriteOption func(*FileWriteConfig)
 
 // FileWriteConfig holds configuration for file creation.
 type FileWriteConfig struct {
-	SuperblockVersion uint8 // HDF5 superblock version (0, 2, or 3)
-	BTreeRebalancing  bool  // Enable B-tree rebalancing after deletions (default: true)
-}
+	SuperblockVersion    uint8             // HDF5 superblock version (0, 2, or 3)
+	BTreeRebalancing     bool              // Enable B-tree rebalancing after deletions (default: true)
+	LocalHeapInitialSize uint64            // Initial data segment size of group name heaps (default: 4096)
+	ModernGroups         bool              // Create new groups in link-info format (default: false, symbol table)
+	TrackTimes           bool              // Record creation/modification times in new object headers (default: false)
+	AlignThreshold       uint64            // Minimum size of aligned allocations (default: 1)
+	Alignment            uint64            // Address multiple for allocations of AlignThreshold bytes or more (default: 1, no alignment)
+	UTF8AttributeNames   bool              // Accept non-ASCII (UTF-8) attribute names (default: false, ASCII only)
+	MaxCompactAttributes int               // Most attributes kept in the object header before moving to dense storage (default: 8)
+	MinDenseAttributes   int               // Fewest attributes kept in dense storage before moving back to compact (default: 6)
+	UserBlockSize        uint64            // Bytes reserved before the superblock (default: 0, no user block)
+	MetadataBlockSize    uint64            // Size of blocks small metadata allocations are aggregated in (default: 0, none)
+	Compatibility        Compatibility     // File format versions used for new structures (default: DefaultCompatibility)
+	DeterministicLayout  bool              // Byte-reproducible output, no object times (default: false)
+	FileMetadata         map[string]string // Key/value pairs stored in the superblock extension (default: none)
+}
+
+// defaultLocalHeapSize is the initial local heap data segment size for new groups.
+// 4096 bytes supports ~300+ typical names before the heap has to grow.
+const defaultLocalHeapSize = 4096
 
 // WithSuperblockVersion sets the HDF5 superblock version.
 //
@@ -649,6 +713,9 @@ type FileWriteConfig struct {
 //
 // Default: SuperblockV2 (modern format)
 //
+// SuperblockV0 alone still writes version 2 object headers, which need
+// HDF5 1.8; WithCompatibility(Earliest) uses the oldest format throughout.
+//
 // Example for maximum compatibility:
 //
 //	fw, err := hdf5.CreateForWrite("file.h5", hdf5.CreateTruncate,
@@ -691,6 +758,276 @@ func WithBTreeRebalancing(enable bool) WriteOption {
 	}
 }
 
+// WithLocalHeapInitialSize sets the initial size of the local heap that stores
+// link names for each symbol-table group (including the root group).
+//
+// A heap that fills up is grown automatically: a heap twice the size (or larger,
+// if needed) is allocated at a fresh address, existing names are copied over,
+// and the group's Symbol Table message is updated to point at it. A larger
+// initial size avoids these relocations for groups known to hold many children.
+//
+// Default: 4096 bytes. Values below 16 are rounded up to 16.
+//
+// Example - group with thousands of children:
+//
+//	fw, err := hdf5.CreateForWrite("data.h5", hdf5.CreateTruncate,
+//	    hdf5.WithLocalHeapInitialSize(64*1024))
+func WithLocalHeapInitialSize(size uint64) WriteOption {
+	return func(cfg *FileWriteConfig) {
+		cfg.LocalHeapInitialSize = size
+	}
+}
+
+// WithModernGroups makes CreateGroup create new-style (HDF5 1.8+) groups.
+//
+// Modern groups carry a Link Info and a Group Info message instead of a Symbol
+// Table message. Links are stored compactly as Link messages in the group's object
+// header; once a group holds more than 8 links (the HDF5 default max_compact),
+// they are migrated to dense storage (fractal heap + B-tree v2 name index).
+//
+// The root group keeps its symbol-table format, so files remain readable by
+// tools that expect it, while groups created beneath it use the new layout.
+//
+// Default: false (symbol-table groups, readable by HDF5 1.6 and later)
+//
+// Example:
+//
+//	fw, err := hdf5.CreateForWrite("data.h5", hdf5.CreateTruncate,
+//	    hdf5.WithModernGroups())
+//	group, _ := fw.CreateGroup("/results") // Link Info + Group Info
+func WithModernGroups() WriteOption {
+	return func(cfg *FileWriteConfig) {
+		cfg.ModernGroups = true
+	}
+}
+
+// WithTrackTimes makes new datasets and groups record their access,
+// modification, change and creation (birth) times, like H5Pset_obj_track_times.
+//
+// The times are stored in the version 2 object header prefix, set to the moment
+// the object is created, and read back with Dataset.ModTime and Group.ModTime.
+// The root group, created together with the file, is not stamped.
+//
+// Default: false (no times stored, matching files written by this library so far)
+//
+// Example:
+//
+//	fw, err := hdf5.CreateForWrite("data.h5", hdf5.CreateTruncate,
+//	    hdf5.WithTrackTimes(true))
+func WithTrackTimes(enable bool) WriteOption {
+	return func(cfg *FileWriteConfig) {
+		cfg.TrackTimes = enable
+	}
+}
+
+// WithAlignment aligns file space allocations of at least threshold bytes to
+// a multiple of alignment, like H5Pset_alignment. Use it to place chunks and
+// contiguous dataset data on filesystem block or stripe boundaries, which
+// speeds up sequential reads on some filesystems at the cost of padding.
+//
+// Smaller allocations, such as object headers, are not aligned and may fill
+// the gaps left by alignment. The superblock and root group, laid out when the
+// file is created, are not aligned.
+//
+// Default: threshold 1, alignment 1 (no alignment)
+//
+// Example:
+//
+//	// Align every block of 64 KiB or more to 4 KiB boundaries
+//	fw, err := hdf5.CreateForWrite("data.h5", hdf5.CreateTruncate,
+//	    hdf5.WithAlignment(64*1024, 4096))
+func WithAlignment(threshold, alignment uint64) WriteOption {
+	return func(cfg *FileWriteConfig) {
+		cfg.AlignThreshold = threshold
+		cfg.Alignment = alignment
+	}
+}
+
+// WithMetadataBlockSize makes small metadata allocations (object headers,
+// local and fractal heaps, B-tree nodes, ...) share blocks of size bytes,
+// like H5Pset_meta_block_size. Without it, metadata is placed wherever the
+// file ends at the time, interleaved with dataset data; with it, the
+// metadata of many consecutive objects ends up side by side, which makes
+// writing and later reading metadata-heavy files (thousands of small
+// datasets, groups or attributes) more sequential.
+//
+// Raw data (contiguous storage, chunks, variable-length data) and metadata
+// allocations of size bytes or more are placed as usual. The unused end of
+// the last block is given back when the file is closed.
+//
+// Default: 0 (no aggregation)
+//
+// Example:
+//
+//	fw, err := hdf5.CreateForWrite("data.h5", hdf5.CreateTruncate,
+//	    hdf5.WithMetadataBlockSize(64*1024))
+//
+// Reference: H5Pfapl.c - H5Pset_meta_block_size().
+func WithMetadataBlockSize(size uint64) WriteOption {
+	return func(cfg *FileWriteConfig) {
+		cfg.MetadataBlockSize = size
+	}
+}
+
+// WithUTF8AttributeNames allows attribute names with non-ASCII characters.
+// Names must then be valid UTF-8, and are stored with the UTF-8 character
+// set so other HDF5 tools decode them correctly.
+//
+// Default: false (attribute names must be ASCII)
+//
+// Example:
+//
+//	fw, err := hdf5.CreateForWrite("data.h5", hdf5.CreateTruncate,
+//	    hdf5.WithUTF8AttributeNames())
+//	ds.WriteAttribute("température", "°C")
+func WithUTF8AttributeNames() WriteOption {
+	return func(cfg *FileWriteConfig) {
+		cfg.UTF8AttributeNames = true
+	}
+}
+
+// WithMaxCompactAttributes sets how many attributes an object keeps in its
+// object header (compact storage) before they are moved to dense storage
+// (fractal heap + B-tree v2 name index).
+//
+// Compact attributes are cheaper to read and write and need no extra
+// structures, but every one of them lives in the object header: headers with
+// many attributes grow continuation chunks and are slower to scan. Dense
+// storage costs two extra structures per object but scales to any number of
+// attributes with indexed lookup by name. An attribute that does not fit in
+// the object header moves the object to dense storage regardless of n.
+//
+// n = 0 stores every attribute densely. n must not exceed 65535, the largest
+// value an object header can record. Non-default values are stored in the
+// headers of new datasets and groups, so the HDF5 library applies the same
+// thresholds when it modifies them.
+//
+// Default: 8 (HDF5 default)
+//
+// Example:
+//
+//	fw, err := hdf5.CreateForWrite("data.h5", hdf5.CreateTruncate,
+//	    hdf5.WithMaxCompactAttributes(32))
+//
+// Reference: H5Pocpl.c - H5Pset_attr_phase_change().
+func WithMaxCompactAttributes(n int) WriteOption {
+	return func(cfg *FileWriteConfig) {
+		cfg.MaxCompactAttributes = n
+	}
+}
+
+// WithMinDenseAttributes sets the attribute count below which an object in
+// dense storage moves its attributes back into the object header when an
+// attribute is deleted. It must not exceed WithMaxCompactAttributes; keeping
+// it lower leaves a gap that stops objects whose attribute count hovers
+// around the threshold from converting back and forth.
+//
+// Default: 6 (HDF5 default)
+//
+// Example:
+//
+//	fw, err := hdf5.CreateForWrite("data.h5", hdf5.CreateTruncate,
+//	    hdf5.WithMaxCompactAttributes(32), hdf5.WithMinDenseAttributes(24))
+//
+// Reference: H5Pocpl.c - H5Pset_attr_phase_change().
+func WithMinDenseAttributes(n int) WriteOption {
+	return func(cfg *FileWriteConfig) {
+		cfg.MinDenseAttributes = n
+	}
+}
+
+// WithUserBlock reserves a user block of size bytes at the start of the file,
+// like H5Pset_userblock. The HDF5 data starts after it and all addresses are
+// relative to the superblock, so the first size bytes are free for an
+// application header (e.g. a text banner or another format's magic number).
+// The user block is zero-filled; write its content directly to the file
+// after Close.
+//
+// size must be 0 (no user block) or a power of two of at least 512.
+//
+// Default: 0
+//
+// Example:
+//
+//	fw, err := hdf5.CreateForWrite("data.h5", hdf5.CreateTruncate,
+//	    hdf5.WithUserBlock(512))
+//
+// Reference: H5Pfcpl.c - H5Pset_userblock().
+func WithUserBlock(size uint64) WriteOption {
+	return func(cfg *FileWriteConfig) {
+		cfg.UserBlockSize = size
+	}
+}
+
+// validateUserBlock checks the user block size: 0 or a power of two >= 512.
+//
+// Reference: H5Pfcpl.c - H5Pset_userblock().
+func validateUserBlock(cfg *FileWriteConfig) error {
+	size := cfg.UserBlockSize
+	if size != 0 && (size < 512 || size&(size-1) != 0) {
+		return fmt.Errorf("user block size %d must be 0 or a power of two of at least 512", size)
+	}
+	return nil
+}
+
+// validateAttributePhaseChange checks the attribute storage thresholds. The
+// object header stores both as 16-bit values.
+//
+// Reference: H5Pocpl.c - H5Pset_attr_phase_change().
+func validateAttributePhaseChange(cfg *FileWriteConfig) error {
+	if cfg.MaxCompactAttributes < 0 || cfg.MaxCompactAttributes > math.MaxUint16 {
+		return fmt.Errorf("max compact attributes %d out of range [0, %d]", cfg.MaxCompactAttributes, math.MaxUint16)
+	}
+	if cfg.MinDenseAttributes < 0 {
+		return fmt.Errorf("min dense attributes %d must not be negative", cfg.MinDenseAttributes)
+	}
+	if cfg.MinDenseAttributes > cfg.MaxCompactAttributes {
+		return fmt.Errorf("min dense attributes %d exceeds max compact attributes %d",
+			cfg.MinDenseAttributes, cfg.MaxCompactAttributes)
+	}
+	return nil
+}
+
+// applyAlignment validates the alignment options and configures the
+// allocator with them and the metadata block size.
+//
+// Reference: H5Pfapl.c - H5Pset_alignment().
+func applyAlignment(fw *writer.FileWriter, cfg *FileWriteConfig) error {
+	if cfg.Alignment == 0 {
+		return fmt.Errorf("alignment must be positive")
+	}
+	fw.Allocator().SetAlignment(cfg.AlignThreshold, cfg.Alignment)
+	fw.Allocator().SetMetadataBlockSize(cfg.MetadataBlockSize)
+	return nil
+}
+
+// stampTimes records the current time in a new object header when time
+// tracking is enabled. It must be called before the header is sized, since
+// stored times add 16 bytes to the header prefix.
+//
+// Reference: H5Ocache.c - H5O__cache_serialize() (H5O_HDR_STORE_TIMES).
+func (fw *FileWriter) stampTimes(ohw *core.ObjectHeaderWriter) {
+	if fw.config == nil || !fw.config.TrackTimes {
+		return
+	}
+	if ohw.Version == 1 {
+		// Version 1 headers have no time fields; the modification time
+		// goes in a message instead.
+		ohw.Messages = append(ohw.Messages, core.MessageWriter{
+			Type: core.MsgModTime,
+			Data: core.EncodeModTimeMessage(time.Now()),
+		})
+		return
+	}
+	//nolint:gosec // G115: HDF5 stores 32-bit timestamps
+	now := uint32(time.Now().Unix())
+	ohw.Flags |= core.OHDRStoreTimes
+	ohw.AccessTime = now
+	ohw.ModificationTime = now
+	ohw.ChangeTime = now
+	ohw.BirthTime = now
+}
+
 // CreateForWrite creates a new HDF5 file for writing.
 // Unlike Create(), this keeps the file open in write mode.
 //
@@ -716,10 +1053,31 @@ func WithBTreeRebalancing(enable bool) WriteOption {
 //	fw, err := hdf5.CreateForWrite("data.h5", hdf5.CreateTruncate,
 //	    hdf5.WithSuperblockVersion(core.Version0))
 func CreateForWrite(filename string, mode CreateMode, opts ...interface{}) (*FileWriter, error) {
+	fw, err := createFileWriter(filename, opts, func(superblockSize uint64) (*writer.FileWriter, error) {
+		// Map CreateMode to writer.CreateMode and create basic writer
+		return initializeFileWriter(filename, mode, superblockSize)
+	})
+	if err != nil {
+		return nil, err
+	}
+	fw.created = true
+	return fw, nil
+}
+
+// createFileWriter applies opts, creates the backing writer with newWriter and
+// lays out the superblock and root group. It is shared by CreateForWrite and
+// CreateInMemory.
+func createFileWriter(filename string, opts []interface{},
+	newWriter func(superblockSize uint64) (*writer.FileWriter, error)) (*FileWriter, error) {
 	// Apply default configuration
 	cfg := &FileWriteConfig{
-		SuperblockVersion: core.Version2, // Modern format by default
-		BTreeRebalancing:  true,          // C library default behavior
+		SuperblockVersion:    core.Version2, // Modern format by default
+		BTreeRebalancing:     true,          // C library default behavior
+		LocalHeapInitialSize: defaultLocalHeapSize,
+		AlignThreshold:       1,
+		Alignment:            1,
+		MaxCompactAttributes: MaxCompactAttributes,
+		MinDenseAttributes:   MinDenseAttributes,
 	}
 
 	// Temporary FileWriter for applying FileWriterOptions
@@ -739,14 +1097,27 @@ func CreateForWrite(filename string, mode CreateMode, opts ...interface{}) (*Fil
 		}
 	}
 
+	if err := applyCompatibility(cfg); err != nil {
+		return nil, err
+	}
+	applyDeterministicLayout(cfg)
+	if err := validateAttributePhaseChange(cfg); err != nil {
+		return nil, err
+	}
+	if err := validateUserBlock(cfg); err != nil {
+		return nil, err
+	}
+	if err := validateFileMetadata(cfg); err != nil {
+		return nil, err
+	}
+
 	// Calculate superblock size based on version
 	superblockSize := uint64(48) // v2/v3
 	if cfg.SuperblockVersion == core.Version0 {
 		superblockSize = 96 // v0 is larger
 	}
 
-	// Map CreateMode to writer.CreateMode and create basic writer
-	fw, err := initializeFileWriter(filename, mode, superblockSize)
+	fw, err := newWriter(superblockSize)
 	if err != nil {
 		return nil, err
 	}
@@ -759,8 +1130,22 @@ func CreateForWrite(filename string, mode CreateMode, opts ...interface{}) (*Fil
 		}
 	}()
 
+	// Everything below, superblock included, is placed after the user block.
+	fw.SetBaseAddress(cfg.UserBlockSize)
+
 	// Create root group with Symbol Table structure
-	rootInfo, err := createRootGroupStructure(fw, cfg.SuperblockVersion)
+	rootInfo, err := createRootGroupStructure(fw, cfg.SuperblockVersion, cfg.LocalHeapInitialSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// Alignment applies from here on: the root group layout above is fixed
+	// for superblock v0.
+	if err := applyAlignment(fw, cfg); err != nil {
+		return nil, err
+	}
+
+	extensionAddr, err := writeFileMetadata(fw, cfg)
 	if err != nil {
 		return nil, err
 	}
@@ -770,10 +1155,10 @@ func CreateForWrite(filename string, mode CreateMode, opts ...interface{}) (*Fil
 		Version:        cfg.SuperblockVersion, // Use configured version
 		OffsetSize:     8,
 		LengthSize:     8,
-		BaseAddress:    0,
+		BaseAddress:    cfg.UserBlockSize,
 		RootGroup:      rootInfo.groupAddr,
 		Endianness:     binary.LittleEndian,
-		SuperExtension: 0,
+		SuperExtension: extensionAddr,
 		DriverInfo:     0,
 		// V0-specific cached addresses (required for h5dump compatibility)
 		RootBTreeAddr: rootInfo.btreeAddr,
@@ -914,6 +1299,21 @@ func (fw *FileWriter) CreateDataset(name string, dtype Datatype, dims []uint64,
 	for _, opt := range opts {
 		opt(config)
 	}
+	if err := applyCreationProps(dtype, dims, config); err != nil {
+		return nil, err
+	}
+	if err := applyDeflateStrategy(config); err != nil {
+		return nil, err
+	}
+
+	// Pick a chunk shape for WithCompression when none was given
+	if config.autoChunk && len(config.chunkDims) == 0 {
+		dtInfo, err := getDatatypeInfo(dtype, config)
+		if err != nil {
+			return nil, fmt.Errorf("invalid datatype: %w", err)
+		}
+		config.chunkDims = autoChunkDims(dims, config.maxDims, uint64(dtInfo.size))
+	}
 
 	// Validate maxDims if specified
 	if len(config.maxDims) > 0 {
@@ -932,10 +1332,32 @@ func (fw *FileWriter) CreateDataset(name string, dtype Datatype, dims []uint64,
 			}
 		}
 
-		// Require chunked layout for resizable datasets
-		if len(config.chunkDims) == 0 {
+		// Only chunked datasets can grow; other layouts may still declare
+		// maxDims equal to dims.
+		if len(config.chunkDims) == 0 && !slices.Equal(config.maxDims, dims) {
 			return nil, fmt.Errorf("resizable datasets (with maxDims) require chunked layout (use WithChunkDims)")
 		}
+		if config.compact {
+			return nil, fmt.Errorf("compact layout cannot be combined with maxDims")
+		}
+	}
+
+	if config.compact && len(config.chunkDims) > 0 {
+		return nil, fmt.Errorf("compact layout cannot be combined with chunked layout")
+	}
+	if config.writeFillInfo && len(config.chunkDims) == 0 {
+		return nil, fmt.Errorf("allocation and fill times require chunked layout (use WithChunkDims)")
+	}
+	if config.btreeV2Index && len(config.chunkDims) == 0 {
+		return nil, fmt.Errorf("v2 B-tree chunk index requires chunked layout (use WithChunkDims)")
+	}
+	if len(config.externalFiles) > 0 {
+		if err := validateExternalStorage(config); err != nil {
+			return nil, err
+		}
+	}
+	if err := validatePreallocate(config); err != nil {
+		return nil, err
 	}
 
 	// Check if chunked layout requested
@@ -952,11 +1374,29 @@ func (fw *FileWriter) CreateDataset(name string, dtype Datatype, dims []uint64,
 	// Calculate total data size
 	totalElements := calculateTotalElements(dims)
 	dataSize := totalElements * uint64(dtInfo.size)
-
-	// Allocate space for dataset data
-	dataAddress, err := fw.writer.Allocate(dataSize)
-	if err != nil {
-		return nil, fmt.Errorf("failed to allocate space for data: %w", err)
+	if config.compact && dataSize > core.MaxCompactDataSize {
+		return nil, fmt.Errorf("dataset size %d bytes exceeds compact layout maximum %d bytes",
+			dataSize, core.MaxCompactDataSize)
+	}
+
+	// Allocate space for dataset data (compact data lives in the object
+	// header, external data in other files)
+	var dataAddress uint64
+	var externalData []byte
+	if len(config.externalFiles) > 0 {
+		if err := externalCapacityCheck(config.externalFiles, dataSize); err != nil {
+			return nil, err
+		}
+		externalData, err = fw.writeExternalFileList(config.externalFiles)
+		if err != nil {
+			return nil, err
+		}
+		dataAddress = undefinedAddress
+	} else if !config.compact && config.layoutOrder == LayoutDataFirst {
+		dataAddress, err = fw.allocateContiguous(dataSize, config)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Encode datatype message using handler (simplified from complex switch)
@@ -967,34 +1407,47 @@ func (fw *FileWriter) CreateDataset(name string, dtype Datatype, dims []uint64,
 	}
 
 	// Create dataspace message
-	dataspaceData, err := core.EncodeDataspaceMessage(dims, nil)
+	dataspaceData, err := core.EncodeDataspaceMessage(dims, config.maxDims)
 	if err != nil {
 		return nil, fmt.Errorf("failed to encode dataspace: %w", err)
 	}
 
 	// Create layout message
-	layoutData, err := core.EncodeLayoutMessage(
-		core.LayoutContiguous,
-		dataSize,
-		dataAddress,
-		fw.file.sb,
-		nil, // No chunk dimensions for contiguous layout
-		0,   // No element size for contiguous layout
-	)
+	var layoutData []byte
+	if config.compact {
+		layoutData, err = core.EncodeCompactLayoutMessage(make([]byte, dataSize))
+	} else {
+		layoutData, err = core.EncodeLayoutMessage(
+			core.LayoutContiguous,
+			dataSize,
+			dataAddress,
+			fw.file.sb,
+			nil, // No chunk dimensions for contiguous layout
+			0,   // No element size for contiguous layout
+		)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to encode layout: %w", err)
 	}
 
 	// Create object header with messages
 	ohw := &core.ObjectHeaderWriter{
-		Version: 2,
-		Flags:   0, // Minimal flags
+		Version:  fw.objectHeaderVersion(),
+		Flags:    0, // Minimal flags
+		RefCount: 1,
 		Messages: []core.MessageWriter{
 			{Type: core.MsgDatatype, Data: datatypeData},
 			{Type: core.MsgDataspace, Data: dataspaceData},
 			{Type: core.MsgDataLayout, Data: layoutData},
 		},
 	}
+	if externalData != nil {
+		// The External Data Files message precedes the layout, as in files
+		// written by the C library.
+		ohw.Messages = slices.Insert(ohw.Messages, 2, core.MessageWriter{Type: core.MsgExternalFiles, Data: externalData})
+	}
+	fw.stampTimes(ohw)
+	fw.stampAttributePhaseChange(ohw)
 
 	// Pre-allocate OHDR with padding for future attributes.
 	ohw.PadToSize(core.MinOHDRAllocSize)
@@ -1010,6 +1463,18 @@ func (fw *FileWriter) CreateDataset(name string, dtype Datatype, dims []uint64,
 		return nil, fmt.Errorf("failed to allocate space for object header: %w", err)
 	}
 
+	// With LayoutMetadataFirst the data is placed after the header, and the
+	// layout message is completed once its address is known.
+	if !config.compact && externalData == nil && config.layoutOrder == LayoutMetadataFirst {
+		dataAddress, err = fw.allocateContiguous(dataSize, config)
+		if err != nil {
+			return nil, err
+		}
+		if err := fw.setContiguousAddress(ohw, dataSize, dataAddress); err != nil {
+			return nil, err
+		}
+	}
+
 	// Write object header
 	writtenSize, err := ohw.WriteTo(fw.writer, headerAddress)
 	if err != nil {
@@ -1019,6 +1484,14 @@ func (fw *FileWriter) CreateDataset(name string, dtype Datatype, dims []uint64,
 	if writtenSize != headerSize {
 		return nil, fmt.Errorf("header size mismatch: expected %d, wrote %d", headerSize, writtenSize)
 	}
+	fw.trackDatasetHeader(headerAddress, headerSize)
+
+	if config.compact {
+		dataAddress, err = fw.compactDataAddress(headerAddress)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	// Link dataset to parent group's symbol table
 	// Parse path to get parent and dataset name
@@ -1034,19 +1507,26 @@ func (fw *FileWriter) CreateDataset(name string, dtype Datatype, dims []uint64,
 	if dtInfo.baseType != nil {
 		// For array/enum, use base type for data writing
 		dsMsgForWriter = &core.DatatypeMessage{
-			Class:   dtInfo.baseType.class,
-			Version: 1,
-			Size:    dtInfo.baseType.size,
+			Class:         dtInfo.baseType.class,
+			Version:       1,
+			Size:          dtInfo.baseType.size,
+			ClassBitField: dtInfo.baseType.classBitField,
 		}
 	} else {
 		// For simple types, use the datatype itself
 		dsMsgForWriter = &core.DatatypeMessage{
-			Class:   dtInfo.class,
-			Version: 1,
-			Size:    dtInfo.size,
+			Class:         dtInfo.class,
+			Version:       1,
+			Size:          dtInfo.size,
+			ClassBitField: dtInfo.classBitField,
 		}
 	}
 
+	enumType, err := parseEnumDatatype(datatypeData)
+	if err != nil {
+		return nil, err
+	}
+
 	dsw := &DatasetWriter{
 		fileWriter:  fw,
 		name:        name,
@@ -1055,6 +1535,9 @@ func (fw *FileWriter) CreateDataset(name string, dtype Datatype, dims []uint64,
 		dataSize:    dataSize,
 		dtype:       dsMsgForWriter,
 		dims:        dims,
+		enumType:    enumType,
+		isCompact:   config.compact,
+		external:    config.externalFiles,
 	}
 
 	return dsw, nil
@@ -1109,12 +1592,19 @@ func (fw *FileWriter) CreateCompoundDataset(name string, compoundType *core.Data
 	if compoundType.Class != core.DatatypeCompound {
 		return nil, fmt.Errorf("datatype must be compound (class=%d), got class=%d", core.DatatypeCompound, compoundType.Class)
 	}
+	compoundType, err := fw.compatibleDatatype(compoundType)
+	if err != nil {
+		return nil, err
+	}
 
 	// Apply options
 	config := &datasetConfig{}
 	for _, opt := range opts {
 		opt(config)
 	}
+	if config.props != nil {
+		return nil, fmt.Errorf("WithCreationProps is not supported for compound datasets")
+	}
 
 	// Check if chunked layout requested
 	if len(config.chunkDims) > 0 {
@@ -1128,9 +1618,12 @@ func (fw *FileWriter) CreateCompoundDataset(name string, compoundType *core.Data
 	dataSize := totalElements * uint64(compoundType.Size)
 
 	// Allocate space for dataset data
-	dataAddress, err := fw.writer.Allocate(dataSize)
-	if err != nil {
-		return nil, fmt.Errorf("failed to allocate space for data: %w", err)
+	var dataAddress uint64
+	if config.layoutOrder == LayoutDataFirst {
+		dataAddress, err = fw.allocateContiguous(dataSize, config)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Encode datatype message (compound type is already encoded in DatatypeMessage)
@@ -1161,14 +1654,17 @@ func (fw *FileWriter) CreateCompoundDataset(name string, compoundType *core.Data
 
 	// Create object header writer
 	ohw := &core.ObjectHeaderWriter{
-		Version: 2,
-		Flags:   0, // Minimal flags
+		Version:  fw.objectHeaderVersion(),
+		Flags:    0, // Minimal flags
+		RefCount: 1,
 		Messages: []core.MessageWriter{
 			{Type: core.MsgDatatype, Data: datatypeData},
 			{Type: core.MsgDataspace, Data: dataspaceData},
 			{Type: core.MsgDataLayout, Data: layoutData},
 		},
 	}
+	fw.stampTimes(ohw)
+	fw.stampAttributePhaseChange(ohw)
 
 	// Pre-allocate OHDR with padding for future attributes.
 	ohw.PadToSize(core.MinOHDRAllocSize)
@@ -1184,6 +1680,15 @@ func (fw *FileWriter) CreateCompoundDataset(name string, compoundType *core.Data
 	if err != nil {
 		return nil, fmt.Errorf("failed to allocate space for object header: %w", err)
 	}
+	if config.layoutOrder == LayoutMetadataFirst {
+		dataAddress, err = fw.allocateContiguous(dataSize, config)
+		if err != nil {
+			return nil, err
+		}
+		if err := fw.setContiguousAddress(ohw, dataSize, dataAddress); err != nil {
+			return nil, err
+		}
+	}
 
 	// Write object header
 	writtenSize, err := ohw.WriteTo(fw.writer, headerAddress)
@@ -1194,6 +1699,7 @@ func (fw *FileWriter) CreateCompoundDataset(name string, compoundType *core.Data
 	if writtenSize != headerSize {
 		return nil, fmt.Errorf("header size mismatch: expected %d, wrote %d", headerSize, writtenSize)
 	}
+	fw.trackDatasetHeader(headerAddress, headerSize)
 
 	// Link dataset to parent group's symbol table
 	parent, datasetName := parsePath(name)
@@ -1219,8 +1725,8 @@ func (fw *FileWriter) CreateCompoundDataset(name string, compoundType *core.Data
 // calculateObjectHeaderSize calculates the size of an object header before writing.
 // This is needed for pre-allocation.
 func calculateObjectHeaderSize(ohw *core.ObjectHeaderWriter) (uint64, error) {
-	if ohw.Version != 2 {
-		return 0, fmt.Errorf("only object header version 2 supported")
+	if ohw.Version != 1 && ohw.Version != 2 {
+		return 0, fmt.Errorf("unsupported object header version: %d", ohw.Version)
 	}
 
 	// Use the ObjectHeaderWriter's own Size() method which correctly handles
@@ -1246,13 +1752,46 @@ type DatasetWriter struct {
 	// layoutBTreeOffset is the file offset where the B-tree address is stored
 	// in the layout message. Used to update the address after writing chunks.
 	layoutBTreeOffset uint64
-	// headerSize is the on-disk size of the V2 object header (used to recompute
-	// Jenkins checksum after patching the B-tree address in chunked datasets).
-	headerSize uint64
+
+	btreeV2Index  bool                           // Chunks are indexed by a v2 B-tree (WithChunkIndexBTreeV2)
+	btreeV2Writer *structures.ChunkBTreeV2Writer // Writer of the v2 B-tree index, rewriting it in place
+	chunkBytes    uint64                         // Unfiltered size of one chunk, including array element sizes
 
 	// For RMW scenarios (files opened with OpenForWrite)
 	objectHeader  *core.ObjectHeader         // Full object header (for attribute operations)
 	denseAttrInfo *core.AttributeInfoMessage // Dense attribute storage info (nil if no dense storage)
+
+	// Chunks written so far, keyed by chunk coordinate (chunked datasets).
+	// Guarded by fileWriter.chunkMu.
+	chunks          map[string]chunkRecord
+	chunkIndexDirty bool // Chunks were written since the index was last flushed
+	writeWorkers    int  // Goroutines used to filter chunks in Write
+
+	enumType *core.EnumType // Name/value mapping of enum datasets created in this session
+
+	// isCompact is set for compact datasets created in this session; their
+	// data (at dataAddress) lies inside the object header.
+	isCompact bool
+
+	// isNull is set for datasets created with CreateNullDataset, which hold
+	// no data.
+	isNull bool
+
+	// external lists the segments holding the raw data of datasets created
+	// with WithExternalStorage in this session.
+	external []ExternalFile
+
+	// appendMu serializes Append calls on this dataset.
+	appendMu sync.Mutex
+}
+
+// ChunkDims returns the chunk dimensions of a chunked dataset, including
+// those chosen by WithCompression, or nil for contiguous datasets.
+func (dw *DatasetWriter) ChunkDims() []uint64 {
+	if !dw.isChunked {
+		return nil
+	}
+	return append([]uint64(nil), dw.chunkDims...)
 }
 
 // Write writes data to the dataset.
@@ -1280,34 +1819,31 @@ type DatasetWriter struct {
 //	// Flatten row-major: [[1,2,3,4], [5,6,7,8], [9,10,11,12]]
 //	ds2.Write([]float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12})
 func (dw *DatasetWriter) Write(data interface{}) error {
+	return dw.write(data, nil)
+}
+
+// write implements Write and WriteWithProgress. progress, if not nil, is
+// called with the number of bytes written by each step.
+func (dw *DatasetWriter) write(data interface{}, progress func(n uint64)) error {
+	if dw.isNull {
+		return fmt.Errorf("dataset %s has a NULL dataspace and holds no data", dw.name)
+	}
+
 	// Handle variable-length data separately (uses global heap)
 	if dw.dtype.Class == core.DatatypeVarLen {
-		return dw.writeVLen(data)
+		if err := dw.writeVLen(data); err != nil {
+			return err
+		}
+		if progress != nil {
+			progress(dw.dataSize)
+		}
+		return nil
 	}
 
 	// Convert data to bytes based on datatype
-	var buf []byte
-	var err error
-
-	switch dw.dtype.Class {
-	case core.DatatypeFixed:
-		buf, err = encodeFixedPointData(data, dw.dtype.Size, dw.dataSize)
-	case core.DatatypeFloat:
-		buf, err = encodeFloatData(data, dw.dtype.Size, dw.dataSize)
-	case core.DatatypeString:
-		buf, err = encodeStringData(data, dw.dtype.Size, dw.dataSize)
-	case core.DatatypeReference:
-		// References are fixed-size types (8 or 12 bytes)
-		buf, err = encodeFixedPointData(data, dw.dtype.Size, dw.dataSize)
-	case core.DatatypeOpaque:
-		// Opaque data is raw bytes
-		buf, err = encodeOpaqueData(data, dw.dataSize)
-	default:
-		return fmt.Errorf("unsupported datatype class for writing: %d", dw.dtype.Class)
-	}
-
+	buf, err := dw.encodeData(data, dw.dataSize)
 	if err != nil {
-		return fmt.Errorf("failed to encode data: %w", err)
+		return err
 	}
 
 	// Verify size matches
@@ -1317,17 +1853,60 @@ func (dw *DatasetWriter) Write(data interface{}) error {
 
 	// Handle chunked vs contiguous layout
 	if dw.isChunked {
-		return dw.writeChunkedData(buf)
+		return dw.writeChunkedData(buf, progress)
 	}
 
-	// Write data to file (contiguous layout)
-	if err := dw.fileWriter.writer.WriteAtAddress(buf, dw.dataAddress); err != nil {
+	// Write data to file (contiguous or compact layout)
+	if err := dw.writeContiguousProgress(buf, progress); err != nil {
 		return fmt.Errorf("failed to write data: %w", err)
 	}
 
 	return nil
 }
 
+// encodeData converts data to the dataset's element bytes, expecting
+// expectedSize bytes in total.
+func (dw *DatasetWriter) encodeData(data interface{}, expectedSize uint64) ([]byte, error) {
+	var buf []byte
+	var err error
+
+	switch dw.dtype.Class {
+	case core.DatatypeFixed:
+		buf, err = encodeFixedPointData(data, dw.dtype.Size, expectedSize)
+	case core.DatatypeFloat:
+		buf, err = encodeFloatData(data, dw.dtype.Size, expectedSize)
+	case core.DatatypeString:
+		buf, err = encodeStringData(data, dw.dtype.Size, dw.dtype.GetStringPadding(), expectedSize)
+	case core.DatatypeReference:
+		// References are fixed-size types (8 or 12 bytes)
+		if refs, ok := data.([]RegionRef); ok {
+			buf = encodeRegionRefs(refs)
+			if uint64(len(buf)) != expectedSize {
+				return nil, fmt.Errorf("data size mismatch: expected %d bytes, got %d bytes", expectedSize, len(buf))
+			}
+			break
+		}
+		if refs, ok := data.([]ObjectRef); ok {
+			addrs := make([]uint64, len(refs))
+			for i, ref := range refs {
+				addrs[i] = uint64(ref)
+			}
+			data = addrs
+		}
+		buf, err = encodeFixedPointData(data, dw.dtype.Size, expectedSize)
+	case core.DatatypeOpaque:
+		// Opaque data is raw bytes
+		buf, err = encodeOpaqueData(data, expectedSize)
+	default:
+		return nil, fmt.Errorf("unsupported datatype class for writing: %d", dw.dtype.Class)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode data: %w", err)
+	}
+	return buf, nil
+}
+
 // WriteRaw writes raw bytes directly to the dataset without type conversion.
 // This is useful for advanced use cases like compound datatypes where the user
 // has already prepared the binary representation.
@@ -1351,11 +1930,11 @@ func (dw *DatasetWriter) WriteRaw(data []byte) error {
 
 	// Handle chunked vs contiguous layout
 	if dw.isChunked {
-		return dw.writeChunkedData(data)
+		return dw.writeChunkedData(data, nil)
 	}
 
-	// Write raw data to file (contiguous layout)
-	if err := dw.fileWriter.writer.WriteAtAddress(data, dw.dataAddress); err != nil {
+	// Write raw data to file (contiguous or compact layout)
+	if err := dw.writeContiguous(data); err != nil {
 		return fmt.Errorf("failed to write raw data: %w", err)
 	}
 
@@ -1534,8 +2113,34 @@ func (dw *DatasetWriter) writeVLen(data interface{}) error {
 			heapIDs[i] = heapID
 		}
 
+	case [][]string:
+		// Variable-length lists of variable-length strings (VLenStringList):
+		// each string goes to the heap first, then the list of their heap IDs.
+		if uint64(len(v)) != elemCount {
+			return fmt.Errorf("data length %d doesn't match dataset size %d", len(v), elemCount)
+		}
+
+		for i, list := range v {
+			seqBytes := make([]byte, 0, len(list)*16)
+			for j, str := range list {
+				strID, err := dw.fileWriter.globalHeapWriter.WriteToGlobalHeap([]byte(str))
+				if err != nil {
+					return fmt.Errorf("write string %d of list %d to heap: %w", j, i, err)
+				}
+				strID.SeqLen = uint32(len(str)) //nolint:gosec // G115: string length fits in uint32
+				seqBytes = append(seqBytes, strID.Encode()...)
+			}
+
+			heapID, err := dw.fileWriter.globalHeapWriter.WriteToGlobalHeap(seqBytes)
+			if err != nil {
+				return fmt.Errorf("write string list %d to heap: %w", i, err)
+			}
+			heapID.SeqLen = uint32(len(list)) //nolint:gosec // G115: list length fits in uint32
+			heapIDs[i] = heapID
+		}
+
 	default:
-		return fmt.Errorf("unsupported vlen data type: %T (expected []string or [][]numeric)", data)
+		return fmt.Errorf("unsupported vlen data type: %T (expected []string, [][]string or [][]numeric)", data)
 	}
 
 	// Encode heap IDs to bytes (16 bytes each: 4 seq_len + 8 addr + 4 index)
@@ -1548,11 +2153,11 @@ func (dw *DatasetWriter) writeVLen(data interface{}) error {
 	// Write heap IDs to dataset (contiguous or chunked)
 	if dw.isChunked {
 		// Write via chunk coordinator
-		return dw.writeChunkedData(heapIDData)
+		return dw.writeChunkedData(heapIDData, nil)
 	}
 
 	// Contiguous layout - write directly
-	if err := dw.fileWriter.writer.WriteAtAddress(heapIDData, dw.dataAddress); err != nil {
+	if err := dw.writeContiguous(heapIDData); err != nil {
 		return fmt.Errorf("write heap IDs: %w", err)
 	}
 
@@ -1840,8 +2445,12 @@ func encodeFloatData(data interface{}, elemSize uint32, expectedSize uint64) ([]
 	return buf, nil
 }
 
-// encodeStringData encodes string data to bytes (fixed-length).
-func encodeStringData(data interface{}, elemSize uint32, expectedSize uint64) ([]byte, error) {
+// encodeStringData encodes string data to bytes (fixed-length), padding each
+// string according to padding (see StringPadding). Strings that do not fit
+// are truncated to the string size.
+//
+// Reference: H5Tconv.c - H5T__conv_s_s().
+func encodeStringData(data interface{}, elemSize uint32, padding uint8, expectedSize uint64) ([]byte, error) {
 	v, ok := data.([]string)
 	if !ok {
 		return nil, fmt.Errorf("expected []string, got %T", data)
@@ -1855,20 +2464,15 @@ func encodeStringData(data interface{}, elemSize uint32, expectedSize uint64) ([
 	}
 
 	buf := make([]byte, expectedSize)
-	offset := 0
-
-	for _, str := range v {
-		// Copy string, null-terminate or truncate
-		strBytes := []byte(str)
-		if len(strBytes) >= int(elemSize) {
-			// Truncate if too long
-			copy(buf[offset:offset+int(elemSize)], strBytes[:elemSize])
-		} else {
-			// Copy and null-terminate
-			copy(buf[offset:], strBytes)
-			// Remaining bytes are already zero (null-terminated)
+	for i, str := range v {
+		slot := buf[i*int(elemSize) : (i+1)*int(elemSize)]
+		n := copy(slot, str)
+		if padding == core.StringPadSpacePad {
+			for j := n; j < len(slot); j++ {
+				slot[j] = ' '
+			}
 		}
-		offset += int(elemSize)
+		// Null-terminated and null-padded: remaining bytes are already zero.
 	}
 
 	return buf, nil
@@ -1894,7 +2498,10 @@ func encodeOpaqueData(data interface{}, expectedSize uint64) ([]byte, error) {
 // Close closes the dataset writer.
 // For MVP, this is a no-op (no per-dataset resources to release).
 func (dw *DatasetWriter) Close() error {
-	// No resources to release for MVP
+	// Chunks written with WriteChunk are indexed lazily.
+	if dw.isChunked {
+		return dw.flushChunkIndex()
+	}
 	return nil
 }
 
@@ -1904,6 +2511,7 @@ type DatasetOption func(*datasetConfig)
 // datasetConfig holds dataset creation options.
 type datasetConfig struct {
 	stringSize    uint32
+	stringPadding StringPadding          // For fixed-length string datatypes
 	arrayDims     []uint64               // For array datatypes
 	enumNames     []string               // For enum datatypes
 	enumValues    []int64                // For enum datatypes
@@ -1912,7 +2520,25 @@ type datasetConfig struct {
 	chunkDims     []uint64               // For chunked layout
 	pipeline      *writer.FilterPipeline // Filter pipeline for chunked datasets
 	enableShuffle bool                   // Add shuffle filter before compression
+	autoChunk     bool                   // Choose chunk dimensions when none are given
+	clampChunks   bool                   // Clamp chunk dimensions to the dataset shape
 	maxDims       []uint64               // Maximum dimensions (for resizable datasets)
+	writeWorkers  int                    // Goroutines filtering chunks in Write (0 = serial)
+	allocTime     AllocTime              // Space allocation time (chunked datasets)
+	fillTime      FillTime               // Fill value write time (chunked datasets)
+	writeFillInfo bool                   // Emit a Fill Value message (WithAllocTime/WithFillTime given)
+	compact       bool                   // Store data inline in the object header
+	fillValue     []byte                 // Encoded fill value (WithCreationProps)
+	props         *CreationProps         // Combined creation properties (WithCreationProps)
+
+	deflateStrategy    DeflateStrategy // GZIP encoding strategy (WithDeflateStrategy)
+	deflateStrategySet bool            // WithDeflateStrategy given
+
+	btreeV2Index bool // Index chunks with a v2 B-tree (WithChunkIndexBTreeV2)
+
+	externalFiles []ExternalFile // Raw data segments outside the file (WithExternalStorage)
+	preallocate   bool           // Zero-fill contiguous storage at creation (WithPreallocate)
+	layoutOrder   LayoutOrder    // Header/data allocation order (WithLayoutOrder)
 }
 
 // WithStringSize sets the fixed string size for String datasets.
@@ -1927,6 +2553,44 @@ func WithStringSize(size uint32) DatasetOption {
 	}
 }
 
+// StringPadding selects how fixed-length strings shorter than the string
+// size are stored, matching the HDF5 H5T_str_t values.
+type StringPadding uint8
+
+// String padding schemes for WithStringPadding.
+const (
+	// StringNullTerm stores a null terminator after the string (default).
+	// A string that fills the whole size is stored without one.
+	StringNullTerm = StringPadding(core.StringPadNullTerm)
+
+	// StringNullPad fills the remaining bytes with nulls. A string may use
+	// the full size, without a terminator.
+	StringNullPad = StringPadding(core.StringPadNullPad)
+
+	// StringSpacePad fills the remaining bytes with spaces, as Fortran
+	// CHARACTER variables do. Trailing spaces are removed on read.
+	StringSpacePad = StringPadding(core.StringPadSpacePad)
+)
+
+// WithStringPadding sets the padding scheme of a String dataset (or of the
+// string base type of an array dataset). The scheme is recorded in the
+// datatype, and ReadStrings strips the padding accordingly.
+//
+// Default: StringNullTerm
+//
+// Example:
+//
+//	// Fortran-compatible, space-padded 16-character strings
+//	ds, _ := fw.CreateDataset("/names", hdf5.String, []uint64{10},
+//	    hdf5.WithStringSize(16), hdf5.WithStringPadding(hdf5.StringSpacePad))
+//
+// Reference: H5Tpublic.h - H5T_str_t, H5Tconv.c - H5T__conv_s_s().
+func WithStringPadding(padding StringPadding) DatasetOption {
+	return func(cfg *datasetConfig) {
+		cfg.stringPadding = padding
+	}
+}
+
 // WithArrayDims sets the dimensions for Array datatypes.
 // This is required when creating an Array dataset.
 //
@@ -1985,7 +2649,9 @@ func WithOpaqueTag(tag string, size uint32) DatasetOption {
 
 // WithMaxDims sets maximum dimensions for resizable datasets.
 // Use hdf5.Unlimited (0xFFFFFFFFFFFFFFFF) for unlimited dimensions.
-// Requires chunked layout (use WithChunkDims).
+// Requires chunked layout (use WithChunkDims), except that a contiguous
+// dataset may declare maxDims equal to its dimensions; such a dataset
+// cannot be resized.
 //
 // The maxDims slice must have the same length as the dataset dimensions.
 // Each maxDim value must be >= the corresponding dimension, or Unlimited.
@@ -2011,6 +2677,11 @@ func WithMaxDims(maxDims []uint64) DatasetOption {
 // When specified, the dataset will use chunked layout instead of contiguous.
 //
 // Chunk dimensions must match dataset rank and be > 0 in all dimensions.
+// They may not exceed the dataset dimensions, or the maximum dimensions of a
+// resizable dataset (any size is allowed along unlimited dimensions); use
+// WithAutoClampChunks to reduce them instead of failing. Dimensions that do
+// not divide the dataset evenly leave partial edge chunks, which are stored
+// padded to the full chunk size and trimmed on read.
 // Chunks should be chosen for optimal I/O patterns (typical: 10KB-1MB per chunk).
 //
 // Example:
@@ -2023,6 +2694,23 @@ func WithChunkDims(dims []uint64) DatasetOption {
 	}
 }
 
+// WithAutoClampChunks reduces chunk dimensions given with WithChunkDims that
+// exceed the dataset dimensions (or the maximum dimensions of a resizable
+// dataset) to those dimensions, instead of failing dataset creation. The
+// chunk shape actually used is available from DatasetWriter.ChunkDims.
+//
+// Example:
+//
+//	// Chunks of 64x64 for a 10x1000 dataset become 10x64
+//	ds, _ := fw.CreateDataset("/data", hdf5.Float32, []uint64{10, 1000},
+//	    hdf5.WithChunkDims([]uint64{64, 64}),
+//	    hdf5.WithAutoClampChunks())
+func WithAutoClampChunks() DatasetOption {
+	return func(cfg *datasetConfig) {
+		cfg.clampChunks = true
+	}
+}
+
 // WithGZIPCompression enables GZIP compression with specified level (1-9).
 // This option is only valid for chunked datasets (requires WithChunkDims).
 //
@@ -2050,6 +2738,30 @@ func WithGZIPCompression(level int) DatasetOption {
 	}
 }
 
+// WithCompression enables GZIP compression with the given level (1-9) without
+// requiring a chunk shape. If WithChunkDims is not given, the dataset is
+// chunked automatically, with chunks of at most 64 KiB for datasets up to
+// 1 MiB and larger chunks, up to 1 MiB, for larger datasets; otherwise it
+// behaves like WithGZIPCompression. Unlimited dimensions (WithMaxDims) are
+// chunked toward the target size, so resizable datasets that start small
+// still get chunks of tens of kilobytes.
+//
+// The chosen chunk shape is available from DatasetWriter.ChunkDims, and from
+// Dataset.Layout when reading, so it can be recorded and passed explicitly
+// with WithChunkDims to reproduce the same layout.
+//
+// Example:
+//
+//	ds, _ := fw.CreateDataset("/signal", hdf5.Float64, []uint64{10_000_000},
+//	    hdf5.WithCompression(6))
+//	fmt.Println(ds.ChunkDims()) // [19532]
+func WithCompression(level int) DatasetOption {
+	return func(cfg *datasetConfig) {
+		cfg.autoChunk = true
+		WithGZIPCompression(level)(cfg)
+	}
+}
+
 // WithShuffle enables byte shuffle filter (improves compression).
 // This option is only valid for chunked datasets (requires WithChunkDims).
 //
@@ -2057,7 +2769,9 @@ func WithGZIPCompression(level int) DatasetOption {
 // improving compression ratios for numeric data (typically 2-10x better).
 //
 // Shuffle should be combined with compression (e.g., GZIP) to be effective.
-// It's automatically placed before compression in the filter pipeline.
+// It is always placed first in the filter pipeline, regardless of option
+// order, and records the datatype size as its parameter, as the HDF5 library
+// does.
 //
 // Best for:
 //   - Integer arrays with slowly changing values
@@ -2073,10 +2787,8 @@ func WithGZIPCompression(level int) DatasetOption {
 //	    hdf5.WithGZIPCompression(9))
 func WithShuffle() DatasetOption {
 	return func(cfg *datasetConfig) {
-		if cfg.pipeline == nil {
-			cfg.pipeline = writer.NewFilterPipeline()
-		}
-		// Shuffle will be inserted at the beginning of pipeline during dataset creation
+		// Shuffle is inserted at the start of the pipeline during dataset
+		// creation, once the datatype size is known.
 		cfg.enableShuffle = true
 	}
 }
@@ -2112,6 +2824,85 @@ func WithFletcher32() DatasetOption {
 	}
 }
 
+// AllocTime selects when storage for a dataset is allocated, matching the
+// HDF5 H5D_alloc_time_t values.
+type AllocTime uint8
+
+// Space allocation times for WithAllocTime.
+const (
+	// AllocTimeDefault uses the layout's default: incremental for chunked
+	// datasets.
+	AllocTimeDefault AllocTime = 0
+
+	// AllocTimeEarly allocates all storage when the dataset is created.
+	AllocTimeEarly = AllocTime(core.AllocTimeEarly)
+
+	// AllocTimeLate allocates storage when data is first written.
+	AllocTimeLate = AllocTime(core.AllocTimeLate)
+
+	// AllocTimeIncremental allocates each chunk when it is written.
+	AllocTimeIncremental = AllocTime(core.AllocTimeIncremental)
+)
+
+// FillTime selects when fill values are written to allocated storage,
+// matching the HDF5 H5D_fill_time_t values.
+type FillTime uint8
+
+// Fill value write times for WithFillTime.
+const (
+	// FillTimeAlloc writes fill values whenever storage is allocated.
+	FillTimeAlloc = FillTime(core.FillTimeAlloc)
+
+	// FillTimeNever never writes fill values; unwritten storage is undefined.
+	FillTimeNever = FillTime(core.FillTimeNever)
+
+	// FillTimeIfSet writes fill values only when one was defined (the HDF5
+	// default).
+	FillTimeIfSet = FillTime(core.FillTimeIfSet)
+)
+
+// WithAllocTime records the space allocation time of a chunked dataset in
+// its Fill Value message. Other HDF5 writers that extend the dataset (and
+// tools such as h5repack) use it to decide when to materialize chunks.
+// This library always allocates a chunk when it is written.
+//
+// Only valid for chunked datasets (requires WithChunkDims).
+//
+// Example:
+//
+//	ds, _ := fw.CreateDataset("/data", hdf5.Float64, []uint64{1000},
+//	    hdf5.WithChunkDims([]uint64{100}),
+//	    hdf5.WithAllocTime(hdf5.AllocTimeEarly))
+func WithAllocTime(mode AllocTime) DatasetOption {
+	return func(cfg *datasetConfig) {
+		cfg.allocTime = mode
+		if !cfg.writeFillInfo {
+			cfg.fillTime = FillTimeIfSet
+			cfg.writeFillInfo = true
+		}
+	}
+}
+
+// WithFillTime records the fill value write time of a chunked dataset in
+// its Fill Value message. The fill value itself is the default (zero).
+//
+// Only valid for chunked datasets (requires WithChunkDims).
+//
+// Default: FillTimeIfSet
+//
+// Example:
+//
+//	// Tell readers that unwritten chunks hold no meaningful values
+//	ds, _ := fw.CreateDataset("/data", hdf5.Float64, []uint64{1000},
+//	    hdf5.WithChunkDims([]uint64{100}),
+//	    hdf5.WithFillTime(hdf5.FillTimeNever))
+func WithFillTime(mode FillTime) DatasetOption {
+	return func(cfg *datasetConfig) {
+		cfg.fillTime = mode
+		cfg.writeFillInfo = true
+	}
+}
+
 // OpenMode specifies how to open an existing HDF5 file.
 type OpenMode int
 
@@ -2162,14 +2953,26 @@ const (
 func OpenForWrite(filename string, mode OpenMode, opts ...WriteOption) (*FileWriter, error) {
 	// Apply default configuration
 	cfg := &FileWriteConfig{
-		SuperblockVersion: core.Version2, // Will be overridden by file's actual version
-		BTreeRebalancing:  true,          // C library default behavior
+		SuperblockVersion:    core.Version2, // Will be overridden by file's actual version
+		BTreeRebalancing:     true,          // C library default behavior
+		LocalHeapInitialSize: defaultLocalHeapSize,
+		AlignThreshold:       1,
+		Alignment:            1,
+		MaxCompactAttributes: MaxCompactAttributes,
+		MinDenseAttributes:   MinDenseAttributes,
 	}
 
 	// Apply user options
 	for _, opt := range opts {
 		opt(cfg)
 	}
+	if err := applyCompatibility(cfg); err != nil {
+		return nil, err
+	}
+	applyDeterministicLayout(cfg)
+	if err := validateAttributePhaseChange(cfg); err != nil {
+		return nil, err
+	}
 
 	// Step 1: Open existing HDF5 file for reading (to load structure)
 	f, err := Open(filename)
@@ -2196,6 +2999,12 @@ func OpenForWrite(filename string, mode OpenMode, opts ...WriteOption) (*FileWri
 		_ = f.Close()
 		return nil, fmt.Errorf("failed to create writer: %w", err)
 	}
+	fw.SetBaseAddress(f.sb.BaseAddress)
+	if err := applyAlignment(fw, cfg); err != nil {
+		_ = fw.Close()
+		_ = f.Close()
+		return nil, err
+	}
 
 	// Step 3: Extract root group information from existing file
 	rootGroupAddr := f.sb.RootGroup
@@ -2355,6 +3164,8 @@ func (fw *FileWriter) OpenDataset(path string) (*DatasetWriter, error) {
 //
 // Best practice: Still call defer fw.StopIncrementalRebalancing() explicitly after
 // EnableIncrementalRebalancing() for clarity, but Close() provides a safety net.
+//
+// To give up on a file after a failed write instead of finishing it, use Abort.
 func (fw *FileWriter) Close() error {
 	if fw.writer == nil {
 		return nil
@@ -2367,6 +3178,14 @@ func (fw *FileWriter) Close() error {
 	// Future: Will stop all tracked BTrees automatically.
 	_ = fw.StopIncrementalRebalancing() // Ignore error - likely "not enabled" (MVP)
 
+	// Write the indexes of chunked datasets filled with WriteChunk.
+	for _, dw := range fw.pendingChunkIndexes {
+		if err := dw.flushChunkIndex(); err != nil {
+			return fmt.Errorf("failed to flush chunk index of %q: %w", dw.name, err)
+		}
+	}
+	fw.pendingChunkIndexes = nil
+
 	// Flush global heap before closing (for variable-length data)
 	if fw.globalHeapWriter != nil {
 		if err := fw.globalHeapWriter.Flush(); err != nil {
@@ -2380,6 +3199,8 @@ func (fw *FileWriter) Close() error {
 	// initial EOA. Without updating it, h5py/h5wasm/h5dump fail with
 	// "actual len exceeds EOA".
 	if fw.file != nil && fw.file.sb != nil {
+		// The unwritten rest of a metadata block must not count as file space.
+		fw.writer.Allocator().ReleaseMetadataBlock()
 		finalEOF := fw.writer.EndOfFile()
 		if err := fw.file.sb.WriteTo(fw.writer, finalEOF); err != nil {
 			return fmt.Errorf("failed to update superblock EOA: %w", err)
@@ -2409,6 +3230,56 @@ func (fw *FileWriter) Close() error {
 	return nil
 }
 
+// Abort closes the file without finishing it, for error paths after a failed
+// write. Unlike Close, it writes nothing more: pending chunk indexes, the
+// global heap and the final superblock are discarded and buffered writes are
+// not flushed.
+//
+// A file created by CreateForWrite (with CreateTruncate or CreateExclusive)
+// is removed, so no half-written file is left behind. A file opened with
+// OpenForWrite is kept as it is on disk, which may leave it inconsistent.
+//
+// The FileWriter cannot be used after Abort; calling Close or Abort again
+// does nothing.
+//
+// Example:
+//
+//	fw, err := hdf5.CreateForWrite("out.h5", hdf5.CreateTruncate)
+//	if err != nil {
+//	    return err
+//	}
+//	if err := writeResults(fw); err != nil {
+//	    _ = fw.Abort()
+//	    return err
+//	}
+//	return fw.Close()
+func (fw *FileWriter) Abort() error {
+	if fw.writer == nil {
+		return nil
+	}
+
+	_ = fw.StopIncrementalRebalancing() // Ignore error - likely "not enabled" (MVP)
+	fw.pendingChunkIndexes = nil
+
+	err := fw.writer.Close()
+	if err != nil {
+		err = fmt.Errorf("failed to close writer: %w", err)
+	}
+	if fw.file != nil {
+		if cerr := fw.file.Close(); cerr != nil && err == nil {
+			err = fmt.Errorf("failed to close read handle: %w", cerr)
+		}
+	}
+	fw.writer = nil
+
+	if fw.created && fw.filename != "" {
+		if rerr := os.Remove(fw.filename); rerr != nil && err == nil {
+			err = fmt.Errorf("failed to remove %s: %w", fw.filename, rerr)
+		}
+	}
+	return err
+}
+
 // DisableRebalancing temporarily disables B-tree rebalancing.
 //
 // Use this to improve performance during batch delete operations.
@@ -2775,22 +3646,21 @@ type rootGroupInfo struct {
 // Returns information about the created root group structure.
 // createRootGroupStructure creates the root group structures.
 // Dispatches to version-specific implementation based on superblock version.
-func createRootGroupStructure(fw *writer.FileWriter, superblockVersion uint8) (*rootGroupInfo, error) {
+func createRootGroupStructure(fw *writer.FileWriter, superblockVersion uint8, heapSize uint64) (*rootGroupInfo, error) {
 	if superblockVersion == core.Version0 {
-		return createRootGroupStructureV0(fw)
+		return createRootGroupStructureV0(fw, heapSize)
 	}
-	return createRootGroupStructureV2(fw)
+	return createRootGroupStructureV2(fw, heapSize)
 }
 
 // createRootGroupStructureV2 creates root group for modern format (v2/v3).
 // Order: Heap → B-tree → Object Header (v2 doesn't cache addresses in superblock).
-func createRootGroupStructureV2(fw *writer.FileWriter) (*rootGroupInfo, error) {
+func createRootGroupStructureV2(fw *writer.FileWriter, heapSize uint64) (*rootGroupInfo, error) {
 	const offsetSize = 8
 	const lengthSize = 8
 
 	// Create local heap for root group names.
-	// 4096 bytes supports ~300+ typical names.
-	rootHeap := structures.NewLocalHeap(4096)
+	rootHeap := structures.NewLocalHeap(heapSize)
 	rootHeapAddr, err := fw.Allocate(rootHeap.Size())
 	if err != nil {
 		return nil, fmt.Errorf("failed to allocate root heap: %w", err)
@@ -2834,7 +3704,7 @@ func createRootGroupStructureV2(fw *writer.FileWriter) (*rootGroupInfo, error) {
 // This matches the reference implementation where:
 // 1. H5O_create() creates object header first
 // 2. H5G__stab_create_components() creates B-tree, then heap.
-func createRootGroupStructureV0(fw *writer.FileWriter) (*rootGroupInfo, error) {
+func createRootGroupStructureV0(fw *writer.FileWriter, heapDataSize uint64) (*rootGroupInfo, error) {
 	const offsetSize = 8
 	const lengthSize = 8
 
@@ -2858,8 +3728,9 @@ func createRootGroupStructureV0(fw *writer.FileWriter) (*rootGroupInfo, error) {
 	// Per C reference (H5Gpkg.h:51): H5G_NODE_SIZEOF_HDR(f) + (2*K * H5G_SIZEOF_ENTRY_FILE(f)).
 	stNodeSize := uint64(snodTotalSize)
 
-	// Local heap size: 4096 bytes supports ~300+ typical names.
-	heapSize := uint64(4096)
+	// Local heap size (rounded the same way NewLocalHeap rounds it).
+	rootHeap := structures.NewLocalHeap(heapDataSize)
+	heapSize := rootHeap.DataSegmentSize
 
 	// Step 2: Calculate fixed addresses and reserve space via allocator.
 	// Superblock v0: 0x00-0x5F (96 bytes)
@@ -2899,7 +3770,6 @@ func createRootGroupStructureV0(fw *writer.FileWriter) (*rootGroupInfo, error) {
 	}
 
 	// 4. Write local heap (after symbol table node).
-	rootHeap := structures.NewLocalHeap(4096)
 	if err := rootHeap.WriteTo(fw, rootHeapAddr); err != nil {
 		return nil, fmt.Errorf("failed to write root heap: %w", err)
 	}
@@ -0,0 +1,148 @@
+package hdf5
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/scigolib/hdf5/internal/writer"
+)
+
+// Append adds rows to the end of a resizable chunked dataset: it grows the
+// first dimension by the number of rows in data and writes them. data holds
+// whole rows in row-major order (for a dataset of shape [n, 3], a multiple
+// of 3 values), using the same Go types as Write. Chunks that already hold
+// the last rows of the dataset are read back, merged with the new rows and
+// rewritten.
+//
+// Append is safe for concurrent use. Each call is atomic: its rows are
+// stored contiguously and no other Append to the dataset sees the dataset
+// half-grown. When several goroutines append at once, the order in which
+// their rows end up in the dataset is unspecified. Appends to different
+// datasets of the same file proceed in parallel, except for the short
+// chunk space allocation, which is shared by the whole file. Append must
+// not run concurrently with Write or Resize on the same dataset.
+//
+// The chunk index is written when the dataset or the file is closed.
+//
+// Example:
+//
+//	ds, _ := fw.CreateDataset("/samples", hdf5.Float64, []uint64{1, 3},
+//	    hdf5.WithChunkDims([]uint64{256, 3}),
+//	    hdf5.WithMaxDims([]uint64{hdf5.Unlimited, 3}))
+//	ds.Write([]float64{0, 0, 0})
+//	go func() { _ = ds.Append(readingsA) }()
+//	go func() { _ = ds.Append(readingsB) }()
+//
+// Reference: H5Dint.c - H5D__set_extent(), H5Dchunk.c - H5D__chunk_write().
+func (dw *DatasetWriter) Append(data interface{}) error {
+	dw.appendMu.Lock()
+	defer dw.appendMu.Unlock()
+
+	if !dw.isChunked || dw.chunkCoordinator == nil {
+		return fmt.Errorf("Append requires a chunked dataset")
+	}
+	if len(dw.maxDims) == 0 {
+		return fmt.Errorf("dataset not resizable (maxDims not set)")
+	}
+	value := reflect.ValueOf(data)
+	if value.Kind() != reflect.Slice {
+		return fmt.Errorf("expected a slice, got %T", data)
+	}
+
+	rowElems := calculateTotalElements(dw.dims[1:])
+	count := uint64(value.Len()) //nolint:gosec // G115: slice length is non-negative
+	if count%rowElems != 0 {
+		return fmt.Errorf("%d elements is not a whole number of rows of %d elements", count, rowElems)
+	}
+	if count == 0 {
+		return nil
+	}
+	buf, err := dw.encodeData(data, count*uint64(dw.dtype.Size))
+	if err != nil {
+		return err
+	}
+
+	oldRows := dw.dims[0]
+	newDims := slices.Clone(dw.dims)
+	newDims[0] += count / rowElems
+	if dw.maxDims[0] != Unlimited && newDims[0] > dw.maxDims[0] {
+		return fmt.Errorf("dimension 0 (%d) exceeds maxDims[0] (%d)", newDims[0], dw.maxDims[0])
+	}
+
+	// Chunks first, then the dataspace: if a chunk cannot be written, the
+	// dataset keeps its old shape.
+	cc, err := writer.NewChunkCoordinator(newDims, dw.chunkDims)
+	if err != nil {
+		return fmt.Errorf("update chunk coordinator: %w", err)
+	}
+	if err := dw.appendChunks(cc, buf, oldRows); err != nil {
+		return err
+	}
+	return dw.Resize(newDims)
+}
+
+// appendChunks writes the chunks of cc covering rows oldRows onwards, where
+// buf holds the new rows. Chunks that also cover earlier rows keep them.
+func (dw *DatasetWriter) appendChunks(cc *writer.ChunkCoordinator, buf []byte, oldRows uint64) error {
+	numChunks := cc.NumChunks()
+	perRow := calculateTotalElements(numChunks[1:])
+	chunkRows := dw.chunkDims[0]
+	chunkSize := uint64(dw.dtype.Size) * calculateTotalElements(dw.chunkDims)
+
+	for row := oldRows / chunkRows; row < numChunks[0]; row++ {
+		for k := uint64(0); k < perRow; k++ {
+			coord := cc.GetChunkCoordinate(row*perRow + k)
+
+			var chunk []byte
+			if row*chunkRows < oldRows {
+				existing, err := dw.readChunk(coord, chunkSize)
+				if err != nil {
+					return err
+				}
+				chunk = existing
+			} else {
+				chunk = make([]byte, chunkSize)
+			}
+			cc.MergeRows(chunk, coord, buf, oldRows, dw.dtype.Size)
+
+			filtered, err := dw.filterChunk(coord, chunk)
+			if err != nil {
+				return err
+			}
+			if err := dw.storeChunk(coord, filtered); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// readChunk returns the unfiltered data of a chunk written in this session,
+// or a zero-filled chunk if it has not been written.
+func (dw *DatasetWriter) readChunk(coord []uint64, chunkSize uint64) ([]byte, error) {
+	fw := dw.fileWriter
+	fw.chunkMu.Lock()
+	record, ok := dw.chunks[fmt.Sprint(coord)]
+	fw.chunkMu.Unlock()
+	if !ok {
+		return make([]byte, chunkSize), nil
+	}
+
+	data := make([]byte, record.size)
+	//nolint:gosec // G115: HDF5 addresses fit in int64 for io.ReaderAt interface
+	if _, err := fw.writer.Reader().ReadAt(data, int64(record.address)); err != nil {
+		return nil, fmt.Errorf("failed to read chunk %v: %w", coord, err)
+	}
+	if dw.pipeline != nil && !dw.pipeline.IsEmpty() {
+		unfiltered, err := dw.pipeline.Remove(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unfilter chunk %v: %w", coord, err)
+		}
+		data = unfiltered
+	}
+	if uint64(len(data)) != chunkSize {
+		return nil, fmt.Errorf("chunk %v has %d bytes, expected %d", coord, len(data), chunkSize)
+	}
+	return data, nil
+}
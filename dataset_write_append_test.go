@@ -0,0 +1,90 @@
+package hdf5
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatasetWriter_Append(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "append.h5")
+
+	fw, err := CreateForWrite(filename, CreateTruncate)
+	require.NoError(t, err)
+
+	ds, err := fw.CreateDataset("/samples", Float64, []uint64{1, 2},
+		WithChunkDims([]uint64{4, 2}), WithMaxDims([]uint64{Unlimited, 2}))
+	require.NoError(t, err)
+	require.NoError(t, ds.Write([]float64{-1, -1}))
+
+	// Rows of the second dataset span several chunks across dimension 1.
+	counts, err := fw.CreateDataset("/counts", Int32, []uint64{2, 3},
+		WithChunkDims([]uint64{3, 2}), WithMaxDims([]uint64{Unlimited, 3}), WithGZIPCompression(6))
+	require.NoError(t, err)
+	require.NoError(t, counts.Write([]int32{0, 1, 2, 3, 4, 5}))
+
+	const writers, rowsEach = 8, 5
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			rows := make([]float64, 0, 2*rowsEach)
+			for r := 0; r < rowsEach; r++ {
+				rows = append(rows, float64(w), float64(r))
+			}
+			require.NoError(t, ds.Append(rows))
+		}()
+		go func() {
+			defer wg.Done()
+			base := int32(6 + 3*w) //nolint:gosec // G115: small test values
+			require.NoError(t, counts.Append([]int32{base, base + 1, base + 2}))
+		}()
+	}
+	wg.Wait()
+
+	require.ErrorContains(t, ds.Append([]float64{1, 2, 3}), "not a whole number of rows")
+	flat, err := fw.CreateDataset("/flat", Float64, []uint64{4})
+	require.NoError(t, err)
+	require.ErrorContains(t, flat.Append([]float64{1}), "requires a chunked dataset")
+	require.NoError(t, fw.Close())
+
+	f, err := Open(filename)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	samples := findDatasetByPath(t, f, "/samples")
+	info, err := samples.Info()
+	require.NoError(t, err)
+	require.Contains(t, info, "[41 x 2]")
+	values, err := samples.Read()
+	require.NoError(t, err)
+	require.Len(t, values, 2*(1+writers*rowsEach))
+	require.Equal(t, []float64{-1, -1}, values[:2])
+
+	// Each Append is stored as one run of rows, in some order.
+	seen := map[float64]bool{}
+	for i := 2; i < len(values); i += 2 * rowsEach {
+		w := values[i]
+		require.False(t, seen[w], "rows of writer %v are split", w)
+		seen[w] = true
+		for r := 0; r < rowsEach; r++ {
+			require.Equal(t, []float64{w, float64(r)}, values[i+2*r:i+2*r+2])
+		}
+	}
+	require.Len(t, seen, writers)
+
+	ints, err := findDatasetByPath(t, f, "/counts").ReadInts()
+	require.NoError(t, err)
+	require.Len(t, ints, 3*(2+writers))
+	require.Equal(t, []int64{0, 1, 2, 3, 4, 5}, ints[:6])
+	rowsSeen := map[int64]bool{}
+	for i := 6; i < len(ints); i += 3 {
+		require.Equal(t, int64(0), ints[i]%3)
+		require.Equal(t, []int64{ints[i], ints[i] + 1, ints[i] + 2}, ints[i:i+3])
+		rowsSeen[ints[i]] = true
+	}
+	require.Len(t, rowsSeen, writers)
+}
@@ -0,0 +1,63 @@
+package hdf5
+
+import (
+	"fmt"
+
+	"github.com/scigolib/hdf5/internal/structures"
+)
+
+// WithChunkIndexBTreeV2 indexes the chunks of the dataset with a version 2
+// B-tree, the index HDF5 1.10+ gives chunked datasets with more than one
+// unlimited dimension. The layout message is then written in version 4,
+// which HDF5 1.8 and older cannot read; without this option chunks are
+// indexed by a version 1 B-tree, readable by every HDF5 version.
+//
+// The index is rewritten in place each time chunks are flushed (on Write,
+// on Close after WriteChunk), so Resize followed by writes to the new
+// chunks keeps it current without leaving stale copies in the file.
+//
+// Requires chunked layout. Not available with WithCompatibility(Earliest).
+//
+// Example:
+//
+//	ds, _ := fw.CreateDataset("/grid", hdf5.Float64, []uint64{100, 100},
+//	    hdf5.WithChunkDims([]uint64{50, 50}),
+//	    hdf5.WithMaxDims([]uint64{hdf5.Unlimited, hdf5.Unlimited}),
+//	    hdf5.WithChunkIndexBTreeV2())
+//	ds.Write(data)
+//	ds.Resize([]uint64{200, 100})
+//	ds.WriteChunk([]uint64{2, 0}, more)
+//
+// Reference: H5Dbtree2.c, H5Dlayout.c - H5D__layout_set_latest_indexing().
+func WithChunkIndexBTreeV2() DatasetOption {
+	return func(cfg *datasetConfig) {
+		cfg.btreeV2Index = true
+	}
+}
+
+// writeBTreeV2Index writes a v2 B-tree index of all chunks written so far
+// and returns the address of its header. The index written by the previous
+// flush is overwritten, so its header address does not change. The caller
+// holds fw.chunkMu.
+func (dw *DatasetWriter) writeBTreeV2Index() (uint64, error) {
+	fw := dw.fileWriter
+	if dw.btreeV2Writer == nil {
+		filtered := dw.pipeline != nil && !dw.pipeline.IsEmpty()
+		dw.btreeV2Writer = structures.NewChunkBTreeV2Writer(len(dw.dims), dw.chunkBytes, filtered)
+	} else {
+		dw.btreeV2Writer.Reset()
+	}
+
+	btreeWriter := dw.btreeV2Writer
+	for _, chunk := range dw.chunks {
+		if err := btreeWriter.AddChunk(chunk.coord, chunk.address, chunk.size, 0); err != nil {
+			return 0, fmt.Errorf("failed to add chunk %v to index: %w", chunk.coord, err)
+		}
+	}
+
+	addr, err := btreeWriter.WriteToFile(fw.writer, fw.writer.Allocator(), fw.file.sb)
+	if err != nil {
+		return 0, fmt.Errorf("failed to write v2 B-tree: %w", err)
+	}
+	return addr, nil
+}
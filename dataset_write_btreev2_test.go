@@ -0,0 +1,285 @@
+package hdf5
+
+import (
+	"encoding/binary"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/scigolib/hdf5/internal/core"
+	"github.com/stretchr/testify/require"
+)
+
+// writeBTreeV2GridFile writes a 4x6 int32 dataset indexed by a v2 B-tree,
+// grows it to 8x9 and fills the new chunks, as an appending writer would.
+// It returns the file path and the expected values.
+func writeBTreeV2GridFile(t *testing.T) (string, []int32) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "btreev2.h5")
+
+	fw, err := CreateForWrite(path, CreateTruncate)
+	require.NoError(t, err)
+	ds, err := fw.CreateDataset("/grid", Int32, []uint64{4, 6},
+		WithChunkDims([]uint64{2, 3}),
+		WithMaxDims([]uint64{Unlimited, Unlimited}),
+		WithChunkIndexBTreeV2())
+	require.NoError(t, err)
+
+	initial := make([]int32, 4*6)
+	for i := range initial {
+		initial[i] = int32(i)
+	}
+	require.NoError(t, ds.Write(initial))
+
+	// Grow both dimensions, then write the chunks of the new region.
+	require.NoError(t, ds.Resize([]uint64{8, 9}))
+	want := make([]int32, 8*9)
+	for row := 0; row < 8; row++ {
+		for col := 0; col < 9; col++ {
+			if row < 4 && col < 6 {
+				want[row*9+col] = int32(row*6 + col)
+			} else {
+				want[row*9+col] = int32(1000 + row*9 + col)
+			}
+		}
+	}
+	for cr := uint64(0); cr < 4; cr++ {
+		for cc := uint64(0); cc < 3; cc++ {
+			if cr < 2 && cc < 2 {
+				continue
+			}
+			chunk := make([]int32, 0, 6)
+			for row := cr * 2; row < cr*2+2; row++ {
+				chunk = append(chunk, want[row*9+cc*3:row*9+cc*3+3]...)
+			}
+			require.NoError(t, ds.WriteChunk([]uint64{cr, cc}, chunk))
+		}
+	}
+	require.NoError(t, fw.Close())
+	return path, want
+}
+
+// TestChunkIndexBTreeV2_ResizeAppend reads back a dataset grown with Resize
+// and WriteChunk, and checks that it is indexed by a v2 B-tree.
+func TestChunkIndexBTreeV2_ResizeAppend(t *testing.T) {
+	path, want := writeBTreeV2GridFile(t)
+
+	f, err := Open(path)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	ds := findDatasetByPath(t, f, "/grid")
+	layout, err := ds.Layout()
+	require.NoError(t, err)
+	require.Equal(t, uint8(4), layout.Version)
+	require.Equal(t, core.ChunkIndexBTreeV2, layout.ChunkIndex)
+	require.Equal(t, uint32(core.BTreeV2ChunkNodeSize), layout.BT2NodeSize)
+
+	data, err := ds.Read()
+	require.NoError(t, err)
+	require.Len(t, data, len(want))
+	for i, v := range want {
+		require.Equal(t, float64(v), data[i], "element %d", i)
+	}
+
+	stats, err := ds.ChunkInfo()
+	require.NoError(t, err)
+	require.Len(t, stats, 12)
+	require.Equal(t, []uint64{3, 2}, stats[len(stats)-1].Scaled)
+
+	part, err := ds.ReadSlice([]uint64{6, 7}, []uint64{2, 2})
+	require.NoError(t, err)
+	require.Equal(t, []float64{float64(want[6*9+7]), float64(want[6*9+8]), float64(want[7*9+7]), float64(want[7*9+8])}, part)
+}
+
+// TestChunkIndexBTreeV2_RepeatedResize alternates Resize and Write. Each
+// Write flushes a new index, which the next Resize must not point back to
+// the previous one.
+func TestChunkIndexBTreeV2_RepeatedResize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "btreev2_resize.h5")
+
+	fw, err := CreateForWrite(path, CreateTruncate)
+	require.NoError(t, err)
+	ds, err := fw.CreateDataset("/series", Float64, []uint64{4},
+		WithChunkDims([]uint64{4}),
+		WithMaxDims([]uint64{Unlimited}),
+		WithChunkIndexBTreeV2())
+	require.NoError(t, err)
+
+	var values []float64
+	for size := 4; size <= 16; size += 4 {
+		if size > 4 {
+			require.NoError(t, ds.Resize([]uint64{uint64(size)}))
+		}
+		for len(values) < size {
+			values = append(values, float64(len(values)))
+		}
+		require.NoError(t, ds.Write(values))
+	}
+	require.NoError(t, ds.Resize([]uint64{20}))
+	require.NoError(t, fw.Close())
+
+	f, err := Open(path)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	data, err := findDatasetByPath(t, f, "/series").Read()
+	require.NoError(t, err)
+	require.Equal(t, append(values, 0, 0, 0, 0), data)
+}
+
+// TestChunkIndexBTreeV2_RewrittenInPlace checks that each flush writes the
+// index over the previous one instead of leaving it behind.
+func TestChunkIndexBTreeV2_RewrittenInPlace(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "btreev2_inplace.h5")
+	const n = 2000
+
+	fw, err := CreateForWrite(path, CreateTruncate)
+	require.NoError(t, err)
+	ds, err := fw.CreateDataset("/values", Int16, []uint64{n},
+		WithChunkDims([]uint64{1}),
+		WithMaxDims([]uint64{Unlimited}),
+		WithChunkIndexBTreeV2())
+	require.NoError(t, err)
+
+	values := make([]int16, 2*n)
+	for i := range values {
+		values[i] = int16(i)
+	}
+	// Index nodes are the only allocations of the node size.
+	countNodes := func() int {
+		count := 0
+		for _, b := range fw.writer.Allocator().Blocks() {
+			if b.Size == core.BTreeV2ChunkNodeSize {
+				count++
+			}
+		}
+		return count
+	}
+
+	require.NoError(t, ds.Write(values[:n]))
+	headerAddr := ds.dataAddress
+	nodes := countNodes()
+	require.Greater(t, nodes, 1)
+
+	// Rewriting the same chunks neither moves nor grows the index.
+	require.NoError(t, ds.Write(values[:n]))
+	require.Equal(t, headerAddr, ds.dataAddress)
+	require.Equal(t, nodes, countNodes())
+
+	// New chunks add nodes, but the header stays where the layout points.
+	require.NoError(t, ds.Resize([]uint64{2 * n}))
+	require.NoError(t, ds.Write(values))
+	require.Equal(t, headerAddr, ds.dataAddress)
+	require.NoError(t, fw.Close())
+
+	f, err := Open(path)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	dset := findDatasetByPath(t, f, "/values")
+	layout, err := dset.Layout()
+	require.NoError(t, err)
+	require.Equal(t, headerAddr, layout.DataAddress)
+	data, err := dset.Read()
+	require.NoError(t, err)
+	require.Len(t, data, 2*n)
+	for i := range values {
+		require.Equal(t, float64(values[i]), data[i], "element %d", i)
+	}
+}
+
+// TestChunkIndexBTreeV2_Deep writes enough filtered chunks for a v2 B-tree
+// of depth 2, whose records sit in internal nodes as well as leaves.
+func TestChunkIndexBTreeV2_Deep(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "btreev2_deep.h5")
+	const n = 24000
+
+	fw, err := CreateForWrite(path, CreateTruncate)
+	require.NoError(t, err)
+	ds, err := fw.CreateDataset("/values", Int16, []uint64{n},
+		WithChunkDims([]uint64{1}),
+		WithMaxDims([]uint64{Unlimited}),
+		WithGZIPCompression(1),
+		WithChunkIndexBTreeV2())
+	require.NoError(t, err)
+	values := make([]int16, n)
+	for i := range values {
+		values[i] = int16(i)
+	}
+	require.NoError(t, ds.Write(values))
+	require.NoError(t, fw.Close())
+
+	f, err := Open(path)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	dset := findDatasetByPath(t, f, "/values")
+	layout, err := dset.Layout()
+	require.NoError(t, err)
+	header := make([]byte, 14)
+	_, err = f.reader.ReadAt(header, int64(layout.DataAddress))
+	require.NoError(t, err)
+	require.Equal(t, "BTHD", string(header[:4]))
+	require.Equal(t, byte(core.BTreeV2FilteredChunkRecord), header[5])
+	require.Equal(t, uint16(2), binary.LittleEndian.Uint16(header[12:]), "tree depth")
+
+	data, err := dset.Read()
+	require.NoError(t, err)
+	require.Len(t, data, n)
+	for i := range values {
+		require.Equal(t, float64(values[i]), data[i], "element %d", i)
+	}
+}
+
+// TestChunkIndexBTreeV2_Rejected checks the option's preconditions.
+func TestChunkIndexBTreeV2_Rejected(t *testing.T) {
+	dir := t.TempDir()
+
+	fw, err := CreateForWrite(filepath.Join(dir, "contiguous.h5"), CreateTruncate)
+	require.NoError(t, err)
+	_, err = fw.CreateDataset("/data", Float64, []uint64{10}, WithChunkIndexBTreeV2())
+	require.ErrorContains(t, err, "requires chunked layout")
+	require.NoError(t, fw.Close())
+
+	fw, err = CreateForWrite(filepath.Join(dir, "earliest.h5"), CreateTruncate, WithCompatibility(Earliest))
+	require.NoError(t, err)
+	_, err = fw.CreateDataset("/data", Float64, []uint64{10},
+		WithChunkDims([]uint64{5}), WithChunkIndexBTreeV2())
+	require.ErrorContains(t, err, "WithCompatibility(Earliest)")
+	require.NoError(t, fw.Close())
+}
+
+// TestChunkIndexBTreeV2_H5py reads the grown dataset with h5py.
+// This test is skipped if python3 or h5py is not available.
+func TestChunkIndexBTreeV2_H5py(t *testing.T) {
+	python, err := exec.LookPath("python3")
+	if err != nil {
+		t.Skip("python3 not available")
+	}
+	if err := exec.Command(python, "-c", "import h5py").Run(); err != nil {
+		t.Skip("h5py not available")
+	}
+
+	path, want := writeBTreeV2GridFile(t)
+
+	const script = `
+import sys, h5py
+with h5py.File(sys.argv[1], "r") as f:
+    ds = f["grid"]
+    assert ds.shape == (8, 9), ds.shape
+    assert ds.maxshape == (None, None), ds.maxshape
+    assert ds.id.get_num_chunks() == 12
+    print(",".join(str(int(v)) for v in ds[...].ravel()))
+`
+	out, err := exec.Command(python, "-c", script, path).CombinedOutput()
+	require.NoError(t, err, "h5py failed: %s", out)
+
+	expected := make([]string, len(want))
+	for i, v := range want {
+		expected[i] = strconv.Itoa(int(v))
+	}
+	require.Equal(t, strings.Join(expected, ",")+"\n", string(out))
+}
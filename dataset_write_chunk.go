@@ -0,0 +1,132 @@
+package hdf5
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// chunkRecord is a written chunk awaiting insertion into the chunk index.
+type chunkRecord struct {
+	coord   []uint64 // Chunk coordinate (chunk index per dimension)
+	address uint64   // File address of the (filtered) chunk
+	size    uint32   // Stored size in bytes
+}
+
+// WithParallelChunkWrites makes Write compress and filter the chunks of a
+// chunked dataset on several goroutines. Chunks are still stored in chunk
+// order, so the resulting file is identical to a serial write.
+//
+// A workers value of zero or less uses runtime.GOMAXPROCS(0) goroutines.
+// The option has no effect on contiguous datasets.
+//
+// Example:
+//
+//	ds, _ := fw.CreateDataset("/data", hdf5.Float64, []uint64{4096, 4096},
+//	    hdf5.WithChunkDims([]uint64{256, 256}),
+//	    hdf5.WithGZIPCompression(6),
+//	    hdf5.WithParallelChunkWrites(0))
+func WithParallelChunkWrites(workers int) DatasetOption {
+	return func(cfg *datasetConfig) {
+		if workers <= 0 {
+			workers = runtime.GOMAXPROCS(0)
+		}
+		cfg.writeWorkers = workers
+	}
+}
+
+// WriteChunk writes a single chunk of a chunked dataset.
+//
+// The data covers the part of the chunk that lies inside the dataset, in
+// row-major order: edge chunks hold fewer elements than the chunk
+// dimensions, and are stored zero-padded to the full chunk size. The chunk
+// is filtered (compressed) by the calling goroutine, so WriteChunk may be
+// called concurrently for disjoint chunks of one or more datasets of the
+// same file; only space allocation and the chunk index update are
+// serialized. Writing a chunk again replaces it.
+//
+// The chunk index is written when the dataset or the file is closed.
+//
+// Parameters:
+//   - coords: Chunk coordinate (chunk number along each dimension)
+//   - data: Chunk values, using the same Go types as Write
+//
+// Returns:
+//   - error: If the dataset is not chunked, coords are out of range, or the
+//     data does not match the chunk size
+//
+// Example:
+//
+//	var wg sync.WaitGroup
+//	for i := uint64(0); i < 8; i++ {
+//	    wg.Add(1)
+//	    go func() {
+//	        defer wg.Done()
+//	        _ = ds.WriteChunk([]uint64{i}, block(i))
+//	    }()
+//	}
+//	wg.Wait()
+func (dw *DatasetWriter) WriteChunk(coords []uint64, data interface{}) error {
+	if !dw.isChunked || dw.chunkCoordinator == nil {
+		return fmt.Errorf("WriteChunk requires a chunked dataset")
+	}
+
+	numChunks := dw.chunkCoordinator.NumChunks()
+	if len(coords) != len(numChunks) {
+		return fmt.Errorf("chunk coordinate rank %d does not match dataset rank %d", len(coords), len(numChunks))
+	}
+	for i, c := range coords {
+		if c >= numChunks[i] {
+			return fmt.Errorf("chunk coordinate %v out of range in dimension %d (%d chunks)", coords, i, numChunks[i])
+		}
+	}
+
+	expected := uint64(dw.dtype.Size)
+	for _, n := range dw.chunkCoordinator.GetChunkSize(coords) {
+		expected *= n
+	}
+	buf, err := dw.encodeData(data, expected)
+	if err != nil {
+		return err
+	}
+
+	buf = dw.chunkCoordinator.PadChunkData(buf, coords, dw.dtype.Size)
+
+	filtered, err := dw.filterChunk(coords, buf)
+	if err != nil {
+		return err
+	}
+	return dw.storeChunk(coords, filtered)
+}
+
+// parallelFor calls fn(i) for every i in [0, n) using up to workers
+// goroutines, and returns when all calls have finished.
+func parallelFor(n, workers uint64, fn func(i uint64)) {
+	if workers <= 1 || n <= 1 {
+		for i := uint64(0); i < n; i++ {
+			fn(i)
+		}
+		return
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	next := uint64(0)
+	for w := uint64(0); w < min(workers, n); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				mu.Lock()
+				i := next
+				next++
+				mu.Unlock()
+				if i >= n {
+					return
+				}
+				fn(i)
+			}
+		}()
+	}
+	wg.Wait()
+}
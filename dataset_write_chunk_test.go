@@ -0,0 +1,134 @@
+package hdf5
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// readDatasetFloat64 opens filename and reads the float64 dataset at path.
+func readDatasetFloat64(t *testing.T, filename, path string) []float64 {
+	t.Helper()
+	f, err := Open(filename)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	ds := findDatasetByPath(t, f, path)
+	values, err := ds.Read()
+	require.NoError(t, err)
+	return values
+}
+
+func TestWriteChunk_Concurrent(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "write_chunk.h5")
+
+	// 1003 elements in chunks of 100: the last chunk holds 3 elements.
+	const n, chunk = 1003, 100
+	expected := make([]float64, n)
+	for i := range expected {
+		expected[i] = float64(i%17) * 0.5
+	}
+
+	fw, err := CreateForWrite(filename, CreateTruncate)
+	require.NoError(t, err)
+	ds, err := fw.CreateDataset("/data", Float64, []uint64{n},
+		WithChunkDims([]uint64{chunk}), WithGZIPCompression(6))
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	errs := make([]error, (n+chunk-1)/chunk)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			start := i * chunk
+			errs[i] = ds.WriteChunk([]uint64{uint64(i)}, expected[start:min(start+chunk, n)]) //nolint:gosec // G115: test index
+		}()
+	}
+	wg.Wait()
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+	require.NoError(t, fw.Close())
+
+	require.Equal(t, expected, readDatasetFloat64(t, filename, "/data"))
+}
+
+func TestWriteChunk_2D_Rewrite(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "write_chunk_2d.h5")
+
+	fw, err := CreateForWrite(filename, CreateTruncate)
+	require.NoError(t, err)
+	ds, err := fw.CreateDataset("/grid", Float64, []uint64{4, 6}, WithChunkDims([]uint64{2, 3}))
+	require.NoError(t, err)
+
+	// Each chunk is filled with its chunk number; chunk [1,1] is written twice.
+	for r := uint64(0); r < 2; r++ {
+		for c := uint64(0); c < 2; c++ {
+			v := float64(r*2 + c)
+			require.NoError(t, ds.WriteChunk([]uint64{r, c}, []float64{v, v, v, v, v, v}))
+		}
+	}
+	require.NoError(t, ds.WriteChunk([]uint64{1, 1}, []float64{9, 9, 9, 9, 9, 9}))
+	require.NoError(t, ds.Close())
+	require.NoError(t, fw.Close())
+
+	require.Equal(t, []float64{
+		0, 0, 0, 1, 1, 1,
+		0, 0, 0, 1, 1, 1,
+		2, 2, 2, 9, 9, 9,
+		2, 2, 2, 9, 9, 9,
+	}, readDatasetFloat64(t, filename, "/grid"))
+}
+
+func TestWriteChunk_Errors(t *testing.T) {
+	fw, err := CreateForWrite(filepath.Join(t.TempDir(), "write_chunk_err.h5"), CreateTruncate)
+	require.NoError(t, err)
+	defer func() { _ = fw.Close() }()
+
+	contiguous, err := fw.CreateDataset("/contiguous", Float64, []uint64{10})
+	require.NoError(t, err)
+	require.ErrorContains(t, contiguous.WriteChunk([]uint64{0}, []float64{1}), "requires a chunked dataset")
+
+	ds, err := fw.CreateDataset("/chunked", Float64, []uint64{10}, WithChunkDims([]uint64{4}))
+	require.NoError(t, err)
+	require.ErrorContains(t, ds.WriteChunk([]uint64{0, 0}, []float64{1}), "rank")
+	require.ErrorContains(t, ds.WriteChunk([]uint64{3}, []float64{1, 2}), "out of range")
+	require.Error(t, ds.WriteChunk([]uint64{2}, []float64{1, 2, 3}), "edge chunk holds two elements")
+}
+
+func TestWithParallelChunkWrites(t *testing.T) {
+	dir := t.TempDir()
+	data := make([]float64, 50_000)
+	for i := range data {
+		data[i] = float64(i % 1000)
+	}
+
+	write := func(name string, opts ...DatasetOption) string {
+		filename := filepath.Join(dir, name)
+		fw, err := CreateForWrite(filename, CreateTruncate)
+		require.NoError(t, err)
+		opts = append(opts, WithChunkDims([]uint64{1024}), WithGZIPCompression(6), WithShuffle())
+		ds, err := fw.CreateDataset("/data", Float64, []uint64{uint64(len(data))}, opts...)
+		require.NoError(t, err)
+		require.NoError(t, ds.Write(data))
+		require.NoError(t, fw.Close())
+		return filename
+	}
+
+	serial := write("serial.h5")
+	parallel := write("parallel.h5", WithParallelChunkWrites(4))
+
+	require.Equal(t, data, readDatasetFloat64(t, parallel, "/data"))
+
+	// Chunks are stored in chunk order, so the files are identical.
+	a, err := os.ReadFile(serial)
+	require.NoError(t, err)
+	b, err := os.ReadFile(parallel)
+	require.NoError(t, err)
+	require.True(t, bytes.Equal(a, b), "parallel write should produce the same file")
+}
@@ -28,19 +28,11 @@ import (
 //nolint:gocognit,gocyclo,cyclop,funlen // Complex by nature: chunked dataset creation involves many steps
 func (fw *FileWriter) createChunkedDataset(name string, dtype Datatype, dims []uint64, config *datasetConfig) (*DatasetWriter, error) {
 	// 1. Validate chunk dimensions
-	if len(config.chunkDims) != len(dims) {
-		return nil, fmt.Errorf("chunk dimensions (%d) must match dataset dimensions (%d)",
-			len(config.chunkDims), len(dims))
+	if config.clampChunks && len(config.chunkDims) == len(dims) {
+		config.chunkDims = clampChunkDims(config.chunkDims, dims, config.maxDims)
 	}
-
-	for i, chunkDim := range config.chunkDims {
-		if chunkDim == 0 {
-			return nil, fmt.Errorf("chunk dimension %d cannot be zero", i)
-		}
-		if chunkDim > dims[i] {
-			return nil, fmt.Errorf("chunk dimension %d (%d) cannot exceed dataset dimension (%d)",
-				i, chunkDim, dims[i])
-		}
+	if err := checkChunkDims(config.chunkDims, dims, config.maxDims); err != nil {
+		return nil, err
 	}
 
 	// 2. Get datatype info
@@ -75,14 +67,27 @@ func (fw *FileWriter) createChunkedDataset(name string, dtype Datatype, dims []u
 	// 7. Create chunked layout message
 	// Per C reference (H5Dchunk.c:909-913), layout stores ndims+1 dimensions
 	// where the last dimension is the datatype element size.
-	layoutData, err := core.EncodeLayoutMessage(
-		core.LayoutChunked,
-		0,            // dataSize not used for chunked
-		btreeAddress, // B-tree address (0 for now)
-		fw.file.sb,
-		config.chunkDims,
-		dtInfo.size, // element size for trailing dimension
-	)
+	// The index address is at offset 3 of a version 3 layout message and
+	// ends a version 4 one.
+	var layoutData []byte
+	layoutAddrOffset := uint64(3)
+	if config.btreeV2Index {
+		if err := fw.requireNewFormat("v2 B-tree chunk index"); err != nil {
+			return nil, err
+		}
+		btreeAddress = undefinedAddress
+		layoutData, err = core.EncodeChunkedLayoutBTreeV2(config.chunkDims, dtInfo.size, btreeAddress, fw.file.sb)
+		layoutAddrOffset = uint64(len(layoutData)) - uint64(fw.file.sb.OffsetSize)
+	} else {
+		layoutData, err = core.EncodeLayoutMessage(
+			core.LayoutChunked,
+			0,            // dataSize not used for chunked
+			btreeAddress, // B-tree address (0 for now)
+			fw.file.sb,
+			config.chunkDims,
+			dtInfo.size, // element size for trailing dimension
+		)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to encode chunked layout: %w", err)
 	}
@@ -94,24 +99,28 @@ func (fw *FileWriter) createChunkedDataset(name string, dtype Datatype, dims []u
 			config.pipeline = writer.NewFilterPipeline()
 		}
 
-		// Add shuffle filter at beginning if requested
+		// Shuffle goes first, whatever the option order, so that compression
+		// and checksum filters see the shuffled bytes. Its client data is the
+		// full datatype size, which readers need to unshuffle.
+		// Reference: H5Zshuffle.c - H5Z__set_local_shuffle().
 		if config.enableShuffle {
-			// Element size from datatype
-			shuffleFilter := writer.NewShuffleFilter(dtInfo.size)
-			config.pipeline.AddFilterAtStart(shuffleFilter)
+			config.pipeline.AddFilterAtStart(writer.NewShuffleFilter(dtInfo.size))
 		}
 	}
 
 	// 9. Create object header with optional filter pipeline
 	ohw := &core.ObjectHeaderWriter{
-		Version: 2,
-		Flags:   0, // Minimal flags
+		Version:  fw.objectHeaderVersion(),
+		Flags:    0, // Minimal flags
+		RefCount: 1,
 		Messages: []core.MessageWriter{
 			{Type: core.MsgDatatype, Data: datatypeData},
 			{Type: core.MsgDataspace, Data: dataspaceData},
 			{Type: core.MsgDataLayout, Data: layoutData},
 		},
 	}
+	fw.stampTimes(ohw)
+	fw.stampAttributePhaseChange(ohw)
 
 	// Add filter pipeline message if present
 	if config.pipeline != nil && !config.pipeline.IsEmpty() {
@@ -126,6 +135,25 @@ func (fw *FileWriter) createChunkedDataset(name string, dtype Datatype, dims []u
 		})
 	}
 
+	// Add fill value message if allocation or fill time was set.
+	// The HDF5 default allocation time for chunked datasets is incremental.
+	// Reference: H5Pdcpl.c - H5P__set_layout().
+	if config.writeFillInfo {
+		allocTime := uint8(config.allocTime)
+		if config.allocTime == AllocTimeDefault {
+			allocTime = core.AllocTimeIncremental
+		}
+		fillData, err := fw.encodeFillValueMessage(allocTime, uint8(config.fillTime), config.fillValue)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode fill value message: %w", err)
+		}
+
+		ohw.Messages = append(ohw.Messages, core.MessageWriter{
+			Type: core.MsgFillValue,
+			Data: fillData,
+		})
+	}
+
 	// Pre-allocate OHDR with padding for future attributes.
 	ohw.PadToSize(core.MinOHDRAllocSize)
 
@@ -149,6 +177,7 @@ func (fw *FileWriter) createChunkedDataset(name string, dtype Datatype, dims []u
 	if writtenSize != headerSize {
 		return nil, fmt.Errorf("header size mismatch: expected %d, wrote %d", headerSize, writtenSize)
 	}
+	fw.trackDatasetHeader(headerAddress, headerSize)
 
 	// Calculate offset of B-tree address within the file.
 	// Object header v2 layout:
@@ -160,8 +189,7 @@ func (fw *FileWriter) createChunkedDataset(name string, dtype Datatype, dims []u
 	//     - Datatype: 4 + len(datatypeData)
 	//     - Dataspace: 4 + len(dataspaceData)
 	//     - Layout header: 4 bytes
-	//     - Layout data: version(1) + class(1) + dimensionality(1) + btreeAddress(offsetSize)
-	// The B-tree address is at offset 3 within layout message data.
+	//     - Layout data, with the B-tree address at layoutAddrOffset
 	layoutBTreeOffset := headerAddress +
 		4 + // OHDR
 		1 + // version
@@ -170,7 +198,15 @@ func (fw *FileWriter) createChunkedDataset(name string, dtype Datatype, dims []u
 		4 + uint64(len(datatypeData)) + // datatype message
 		4 + uint64(len(dataspaceData)) + // dataspace message
 		4 + // layout message header
-		3 // offset to btree address within layout data (version + class + dimensionality)
+		layoutAddrOffset
+	if ohw.Version == 1 {
+		// Object header v1: 16-byte prefix, then messages with 8-byte
+		// headers and data padded to 8 bytes.
+		layoutBTreeOffset = headerAddress + 16 +
+			8 + alignTo8(uint64(len(datatypeData))) +
+			8 + alignTo8(uint64(len(dataspaceData))) +
+			8 + layoutAddrOffset
+	}
 
 	// 9. Link to parent group
 	parent, datasetName := parsePath(name)
@@ -183,22 +219,29 @@ func (fw *FileWriter) createChunkedDataset(name string, dtype Datatype, dims []u
 	if dtInfo.baseType != nil {
 		// For array/enum, use base type for data writing
 		dsMsgForWriter = &core.DatatypeMessage{
-			Class:   dtInfo.baseType.class,
-			Version: 1,
-			Size:    dtInfo.baseType.size,
+			Class:         dtInfo.baseType.class,
+			Version:       1,
+			Size:          dtInfo.baseType.size,
+			ClassBitField: dtInfo.baseType.classBitField,
 		}
 	} else {
 		// For simple types, use the datatype itself
 		dsMsgForWriter = &core.DatatypeMessage{
-			Class:   dtInfo.class,
-			Version: 1,
-			Size:    dtInfo.size,
+			Class:         dtInfo.class,
+			Version:       1,
+			Size:          dtInfo.size,
+			ClassBitField: dtInfo.classBitField,
 		}
 	}
 
 	totalElements := calculateTotalElements(dims)
 	dataSize := totalElements * uint64(dtInfo.size)
 
+	enumType, err := parseEnumDatatype(datatypeData)
+	if err != nil {
+		return nil, err
+	}
+
 	return &DatasetWriter{
 		fileWriter:        fw,
 		name:              name,
@@ -213,7 +256,10 @@ func (fw *FileWriter) createChunkedDataset(name string, dtype Datatype, dims []u
 		chunkDims:         config.chunkDims,
 		pipeline:          config.pipeline, // Filter pipeline
 		layoutBTreeOffset: layoutBTreeOffset,
-		headerSize:        headerSize,
+		btreeV2Index:      config.btreeV2Index,
+		chunkBytes:        calculateTotalElements(config.chunkDims) * uint64(dtInfo.size),
+		writeWorkers:      config.writeWorkers,
+		enumType:          enumType,
 	}, nil
 }
 
@@ -221,18 +267,11 @@ func (fw *FileWriter) createChunkedDataset(name string, dtype Datatype, dims []u
 //
 // Implementation steps:
 // 1. Extract chunks using ChunkCoordinator
-// 2. Write each chunk to file
-// 3. Build B-tree index
-// 4. Write B-tree to file
+// 2. Filter chunks, in parallel when WithParallelChunkWrites is set
+// 3. Write each chunk to file, in chunk order, reporting progress
+// 4. Build and write the B-tree index
 // 5. Update object header with B-tree address
-//
-// For MVP (Phase 1):
-// - All chunks written at once (no partial writes)
-// - No compression
-// - Simple B-tree v1.
-//
-//nolint:gocognit,cyclop,gocyclo // Complex by nature: writing chunks + B-tree + updating layout + checksum recompute
-func (dw *DatasetWriter) writeChunkedData(buf []byte) error {
+func (dw *DatasetWriter) writeChunkedData(buf []byte, progress func(n uint64)) error {
 	if !dw.isChunked {
 		return fmt.Errorf("writeChunkedData called on non-chunked dataset")
 	}
@@ -242,65 +281,134 @@ func (dw *DatasetWriter) writeChunkedData(buf []byte) error {
 	}
 
 	elemSize := dw.dtype.Size
+	totalChunks := dw.chunkCoordinator.GetTotalChunks()
 
-	// 1. Create B-tree writer
-	// Per C reference (H5Dbtree.c:687-690), B-tree keys store byte offsets,
-	// so the writer needs chunk dimensions for the conversion.
-	dimensionality := len(dw.dims)
-	btreeWriter := structures.NewChunkBTreeWriter(dimensionality, dw.chunkDims, elemSize)
+	// Chunks are filtered in batches: in parallel within a batch, then
+	// stored in chunk order, so the file layout does not depend on scheduling
+	// and at most one batch of filtered chunks is held in memory.
+	workers := uint64(max(dw.writeWorkers, 1))
+	batchSize := workers * 4
+	filtered := make([][]byte, batchSize)
+	sizes := make([]int, batchSize)
+	errs := make([]error, batchSize)
+	for first := uint64(0); first < totalChunks; first += batchSize {
+		n := min(batchSize, totalChunks-first)
+		parallelFor(n, workers, func(k uint64) {
+			coord := dw.chunkCoordinator.GetChunkCoordinate(first + k)
+			chunkData := dw.chunkCoordinator.ExtractChunkData(buf, coord, elemSize)
+			// Progress counts dataset bytes, not the padding of edge chunks.
+			sizes[k] = int(calculateTotalElements(dw.chunkCoordinator.GetChunkSize(coord)) * uint64(elemSize)) //nolint:gosec // G115: chunk sizes are limited to 32 bits by HDF5
+			filtered[k], errs[k] = dw.filterChunk(coord, chunkData)
+		})
+		for k := uint64(0); k < n; k++ {
+			if errs[k] != nil {
+				return errs[k]
+			}
+			coord := dw.chunkCoordinator.GetChunkCoordinate(first + k)
+			if err := dw.storeChunk(coord, filtered[k]); err != nil {
+				return err
+			}
+			// Reported outside the chunk lock, from the calling goroutine.
+			if progress != nil {
+				progress(uint64(sizes[k]))
+			}
+		}
+	}
 
-	// 2. Process each chunk
-	totalChunks := dw.chunkCoordinator.GetTotalChunks()
+	return dw.flushChunkIndex()
+}
 
-	for i := uint64(0); i < totalChunks; i++ {
-		// Get chunk coordinate
-		coord := dw.chunkCoordinator.GetChunkCoordinate(i)
+// filterChunk applies the dataset's filter pipeline (if any) to a chunk.
+// It is safe for concurrent use.
+func (dw *DatasetWriter) filterChunk(coord []uint64, chunkData []byte) ([]byte, error) {
+	if dw.pipeline == nil || dw.pipeline.IsEmpty() {
+		return chunkData, nil
+	}
+	filtered, err := dw.pipeline.Apply(chunkData)
+	if err != nil {
+		return nil, fmt.Errorf("filter application failed for chunk %v: %w", coord, err)
+	}
+	return filtered, nil
+}
 
-		// Extract chunk data
-		chunkData := dw.chunkCoordinator.ExtractChunkData(buf, coord, elemSize)
+// storeChunk allocates space for a filtered chunk, writes it and records it
+// for the chunk index. It is safe for concurrent use: allocation, the write
+// and the index update are serialized by the file writer's chunk lock.
+func (dw *DatasetWriter) storeChunk(coord []uint64, chunkData []byte) error {
+	fw := dw.fileWriter
+	fw.chunkMu.Lock()
+	defer fw.chunkMu.Unlock()
 
-		// Apply filters to chunk (if pipeline configured)
-		if dw.pipeline != nil && !dw.pipeline.IsEmpty() {
-			filtered, err := dw.pipeline.Apply(chunkData)
-			if err != nil {
-				return fmt.Errorf("filter application failed for chunk %v: %w", coord, err)
-			}
-			chunkData = filtered
-		}
+	// Allocate space for chunk (filtered size may differ from original)
+	chunkAddr, err := fw.writer.AllocateRaw(uint64(len(chunkData)))
+	if err != nil {
+		return fmt.Errorf("failed to allocate chunk %v: %w", coord, err)
+	}
+	if err := fw.writer.WriteAtAddress(chunkData, chunkAddr); err != nil {
+		return fmt.Errorf("failed to write chunk %v: %w", coord, err)
+	}
 
-		// Allocate space for chunk (filtered size may differ from original)
-		chunkAddr, err := dw.fileWriter.writer.Allocate(uint64(len(chunkData)))
+	if dw.chunks == nil {
+		dw.chunks = make(map[string]chunkRecord)
+	}
+	dw.chunks[fmt.Sprint(coord)] = chunkRecord{
+		coord:   append([]uint64(nil), coord...),
+		address: chunkAddr,
+		size:    uint32(len(chunkData)), //nolint:gosec // G115: chunk size is validated and fits in uint32
+	}
+	if !dw.chunkIndexDirty {
+		dw.chunkIndexDirty = true
+		fw.pendingChunkIndexes = append(fw.pendingChunkIndexes, dw)
+	}
+	return nil
+}
+
+// flushChunkIndex writes the B-tree index (v1, or v2 with
+// WithChunkIndexBTreeV2) of all chunks written so far and points the layout
+// message at it. It does nothing when the index is already up to date.
+//
+// For MVP, a new index is written on every flush; the previous one is left
+// unreferenced in the file.
+func (dw *DatasetWriter) flushChunkIndex() error {
+	fw := dw.fileWriter
+	fw.chunkMu.Lock()
+	defer fw.chunkMu.Unlock()
+	if !dw.chunkIndexDirty {
+		return nil
+	}
+
+	var btreeAddr uint64
+	if dw.btreeV2Index {
+		addr, err := dw.writeBTreeV2Index()
 		if err != nil {
-			return fmt.Errorf("failed to allocate chunk %v: %w", coord, err)
+			return err
 		}
-
-		// Write chunk data (filtered)
-		if err := dw.fileWriter.writer.WriteAtAddress(chunkData, chunkAddr); err != nil {
-			return fmt.Errorf("failed to write chunk %v: %w", coord, err)
+		btreeAddr = addr
+	} else {
+		// Per C reference (H5Dbtree.c:687-690), B-tree keys store byte offsets,
+		// so the writer needs chunk dimensions for the conversion.
+		btreeWriter := structures.NewChunkBTreeWriter(len(dw.dims), dw.chunkDims, dw.dtype.Size)
+		for _, chunk := range dw.chunks {
+			if err := btreeWriter.AddChunkWithSize(chunk.coord, chunk.address, chunk.size); err != nil {
+				return fmt.Errorf("failed to add chunk %v to index: %w", chunk.coord, err)
+			}
 		}
 
-		// Add to B-tree index with chunk size
-		//nolint:gosec // G115: chunk size is validated and fits in uint32
-		if err := btreeWriter.AddChunkWithSize(coord, chunkAddr, uint32(len(chunkData))); err != nil {
-			return fmt.Errorf("failed to add chunk %v to index: %w", coord, err)
+		addr, err := btreeWriter.WriteToFile(fw.writer, fw.writer.Allocator())
+		if err != nil {
+			return fmt.Errorf("failed to write B-tree: %w", err)
 		}
+		btreeAddr = addr
 	}
-
-	// 3. Write B-tree
-	btreeAddr, err := btreeWriter.WriteToFile(dw.fileWriter.writer, dw.fileWriter.writer.Allocator())
-	if err != nil {
-		return fmt.Errorf("failed to write B-tree: %w", err)
-	}
-
-	// 4. Store B-tree address
 	dw.dataAddress = btreeAddr
+	dw.chunkIndexDirty = false
 
-	// 5. Update the B-tree address in the layout message (in the object header).
+	// Update the B-tree address in the layout message (in the object header).
 	// This ensures the file can be read correctly after closing.
 	if dw.layoutBTreeOffset > 0 {
 		// Write B-tree address at the calculated offset.
 		// The address is stored as offsetSize bytes (typically 8).
-		offsetSize := dw.fileWriter.file.sb.OffsetSize
+		offsetSize := fw.file.sb.OffsetSize
 		addrBuf := make([]byte, offsetSize)
 		switch offsetSize {
 		case 8:
@@ -310,27 +418,184 @@ func (dw *DatasetWriter) writeChunkedData(buf []byte) error {
 		default:
 			return fmt.Errorf("unsupported offset size: %d", offsetSize)
 		}
-		if err := dw.fileWriter.writer.WriteAtAddress(addrBuf, dw.layoutBTreeOffset); err != nil {
+		if err := fw.writer.WriteAtAddress(addrBuf, dw.layoutBTreeOffset); err != nil {
 			return fmt.Errorf("failed to update B-tree address in layout message: %w", err)
 		}
 
-		// Recompute V2 object header Jenkins checksum after patching the B-tree address.
-		// The checksum covers all bytes from OHDR signature through messages (excluding
-		// the 4-byte checksum itself). Without this, h5dump rejects the header with
-		// "incorrect metadata checksum after all read attempts".
-		checksumSize := uint64(4)
-		dataLen := dw.headerSize - checksumSize
-		ohdrBuf := make([]byte, dataLen)
-		if _, readErr := dw.fileWriter.writer.Reader().ReadAt(ohdrBuf, int64(dw.address)); readErr != nil { //nolint:gosec // G115: address within file bounds
-			return fmt.Errorf("failed to read object header for checksum: %w", readErr)
+		// Recompute the header checksum after patching the B-tree address.
+		if err := dw.updateHeaderChecksum(); err != nil {
+			return err
+		}
+
+		// A cached header (Resize, attributes) still holds the old address
+		// and would write it back.
+		if dw.objectHeader != nil {
+			if err := dw.refreshAttributeInfo(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Chunk sizes chosen by WithCompression.
+const (
+	autoChunkMinBytes  = 64 << 10 // Chunk size for datasets up to 1 MiB.
+	autoChunkMaxBytes  = 1 << 20  // Largest chunk size chosen.
+	autoChunkUnlimited = 1024     // Extent assumed for unlimited dimensions.
+)
+
+// autoChunkDims picks chunk dimensions for a dataset compressed without an
+// explicit chunk shape. Unlimited dimensions (see maxDims) count as at least
+// autoChunkUnlimited long, and empty dimensions as 1. The target chunk size
+// starts at autoChunkMinBytes and doubles for every tenfold increase of the
+// dataset size beyond 1 MiB, up to autoChunkMaxBytes.
+//
+// Unlimited dimensions are then doubled in turn while the chunk stays within
+// the target, as the dataset is expected to grow along them. Finally,
+// dimensions are halved in turn, slowest-varying first, until a chunk fits
+// the target, so chunks end up between half the target and the target.
+//
+// Reference: h5py _hl/filters.py - guess_chunk().
+func autoChunkDims(dims, maxDims []uint64, elemSize uint64) []uint64 {
+	chunk := append([]uint64(nil), dims...)
+	if elemSize == 0 {
+		elemSize = 1
+	}
+
+	var unlimited []int
+	for i := range chunk {
+		chunk[i] = max(chunk[i], 1)
+		if len(maxDims) == len(dims) && maxDims[i] == Unlimited {
+			chunk[i] = max(chunk[i], autoChunkUnlimited)
+			unlimited = append(unlimited, i)
+		}
+	}
+
+	target := uint64(autoChunkMinBytes)
+	for size := calculateTotalElements(chunk) * elemSize; size > 1<<20 && target < autoChunkMaxBytes; size /= 10 {
+		target *= 2
+	}
+
+	for j := 0; len(unlimited) > 0 && calculateTotalElements(chunk)*elemSize*2 <= target; j = (j + 1) % len(unlimited) {
+		chunk[unlimited[j]] *= 2
+	}
+
+	for i := 0; calculateTotalElements(chunk)*elemSize > target; i = (i + 1) % len(chunk) {
+		if calculateTotalElements(chunk) == 1 {
+			break
+		}
+		chunk[i] = (chunk[i] + 1) / 2
+	}
+	return chunk
+}
+
+// chunkDimLimit returns the largest chunk dimension allowed for dimension i:
+// the dataset dimension, or the maximum dimension of a resizable dataset
+// (0 for unlimited dimensions, which accept any chunk size).
+func chunkDimLimit(i int, dims, maxDims []uint64) uint64 {
+	if len(maxDims) == 0 {
+		return dims[i]
+	}
+	if maxDims[i] == Unlimited {
+		return 0
+	}
+	return maxDims[i]
+}
+
+// checkChunkDims validates chunk dimensions against the dataset shape. A
+// chunk may not be larger than the dataset in any dimension, except for
+// resizable datasets, whose chunks are bounded by the maximum dimensions.
+func checkChunkDims(chunkDims, dims, maxDims []uint64) error {
+	if len(chunkDims) != len(dims) {
+		return fmt.Errorf("chunk dimensions (%d) must match dataset dimensions (%d)",
+			len(chunkDims), len(dims))
+	}
+
+	for i, chunkDim := range chunkDims {
+		if chunkDim == 0 {
+			return fmt.Errorf("chunk dimension %d cannot be zero", i)
 		}
-		newChecksum := core.JenkinsChecksum(ohdrBuf)
-		var csumBuf [4]byte
-		binary.LittleEndian.PutUint32(csumBuf[:], newChecksum)
-		if err := dw.fileWriter.writer.WriteAtAddress(csumBuf[:], dw.address+dataLen); err != nil {
-			return fmt.Errorf("failed to write object header checksum: %w", err)
+		limit := chunkDimLimit(i, dims, maxDims)
+		switch {
+		case limit == 0 || chunkDim <= limit:
+		case len(maxDims) == 0:
+			return fmt.Errorf("chunk dimension %d (%d) cannot exceed dataset dimension (%d)",
+				i, chunkDim, limit)
+		default:
+			return fmt.Errorf("chunk dimension %d (%d) cannot exceed maximum dimension (%d)",
+				i, chunkDim, limit)
 		}
 	}
+	return nil
+}
 
+// clampChunkDims returns chunkDims with every dimension reduced to the limit
+// checked by checkChunkDims, for WithAutoClampChunks.
+func clampChunkDims(chunkDims, dims, maxDims []uint64) []uint64 {
+	clamped := append([]uint64(nil), chunkDims...)
+	for i := range clamped {
+		if limit := chunkDimLimit(i, dims, maxDims); limit != 0 && clamped[i] > limit {
+			clamped[i] = limit
+		}
+	}
+	return clamped
+}
+
+// updateHeaderChecksum recomputes the Jenkins checksum of the first chunk of
+// the dataset's V2 object header after bytes inside it were patched in place.
+//
+// The checksum covers all bytes from OHDR signature through messages (excluding
+// the 4-byte checksum itself). Without this, h5dump rejects the header with
+// "incorrect metadata checksum after all read attempts". The chunk size is
+// read from the header prefix, since attribute writes may have rewritten the
+// header since the dataset was created.
+//
+// Reference: H5Ocache.c - H5O__cache_serialize().
+func (dw *DatasetWriter) updateHeaderChecksum() error {
+	fw := dw.fileWriter
+	reader := fw.writer.Reader()
+
+	// Prefix: "OHDR"(4) + version(1) + flags(1) [+ times(16)] [+ phase change(4)] + chunk #0 size(1-8).
+	var prefix [34]byte
+	if _, err := reader.ReadAt(prefix[:6], int64(dw.address)); err != nil { //nolint:gosec // G115: address within file bounds
+		return fmt.Errorf("failed to read object header prefix: %w", err)
+	}
+	if prefix[0] == 1 {
+		return nil // Version 1 headers have no checksum.
+	}
+	if string(prefix[:4]) != "OHDR" {
+		return fmt.Errorf("object header at 0x%x is not a version 2 header", dw.address)
+	}
+	flags := prefix[5]
+	sizeOffset := uint64(6)
+	if flags&core.OHDRStoreTimes != 0 {
+		sizeOffset += 16
+	}
+	if flags&core.OHDRAttrStorePhaseChange != 0 {
+		sizeOffset += 4
+	}
+	sizeWidth := uint64(1) << (flags & core.OHDRChunk0SizeMask)
+	sizeBuf := prefix[sizeOffset : sizeOffset+sizeWidth]
+	if _, err := reader.ReadAt(sizeBuf, int64(dw.address+sizeOffset)); err != nil { //nolint:gosec // G115: address within file bounds
+		return fmt.Errorf("failed to read object header chunk size: %w", err)
+	}
+	var chunkSize uint64
+	for i := len(sizeBuf) - 1; i >= 0; i-- {
+		chunkSize = chunkSize<<8 | uint64(sizeBuf[i])
+	}
+
+	dataLen := sizeOffset + sizeWidth + chunkSize
+	ohdrBuf := make([]byte, dataLen)
+	if _, err := reader.ReadAt(ohdrBuf, int64(dw.address)); err != nil { //nolint:gosec // G115: address within file bounds
+		return fmt.Errorf("failed to read object header for checksum: %w", err)
+	}
+	newChecksum := core.JenkinsChecksum(ohdrBuf)
+	var csumBuf [4]byte
+	binary.LittleEndian.PutUint32(csumBuf[:], newChecksum)
+	if err := fw.writer.WriteAtAddress(csumBuf[:], dw.address+dataLen); err != nil {
+		return fmt.Errorf("failed to write object header checksum: %w", err)
+	}
 	return nil
 }
@@ -1,11 +1,16 @@
 package hdf5
 
 import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"io"
 	"math"
 	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/scigolib/hdf5/internal/core"
 	"github.com/stretchr/testify/require"
 )
 
@@ -462,3 +467,164 @@ func TestChunkedWrite_MultiLevel_RoundTrip(t *testing.T) {
 	})
 	require.True(t, found, "dataset /data not found")
 }
+
+// TestChunkedDataset_AllocAndFillTime verifies the Fill Value message written
+// for WithAllocTime and WithFillTime.
+func TestChunkedDataset_AllocAndFillTime(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "fill_time.h5")
+
+	fw, err := CreateForWrite(filename, CreateTruncate)
+	require.NoError(t, err)
+	early, err := fw.CreateDataset("/early", Float64, []uint64{20},
+		WithChunkDims([]uint64{5}), WithAllocTime(AllocTimeEarly), WithFillTime(FillTimeNever))
+	require.NoError(t, err)
+	require.NoError(t, early.Write(make([]float64, 20)))
+	_, err = fw.CreateDataset("/fill", Int32, []uint64{20},
+		WithChunkDims([]uint64{5}), WithFillTime(FillTimeAlloc))
+	require.NoError(t, err)
+	_, err = fw.CreateDataset("/plain", Int32, []uint64{20}, WithChunkDims([]uint64{5}))
+	require.NoError(t, err)
+	_, err = fw.CreateDataset("/contiguous", Int32, []uint64{20}, WithAllocTime(AllocTimeLate))
+	require.ErrorContains(t, err, "require chunked layout")
+	require.NoError(t, fw.Close())
+
+	f, err := Open(filename)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	fillMessage := func(path string) *core.FillValueMessage {
+		ds := findDatasetByPath(t, f, path)
+		header, err := core.ReadObjectHeader(f.reader, ds.Address(), f.sb)
+		require.NoError(t, err)
+		for _, msg := range header.Messages {
+			if msg.Type == core.MsgFillValue {
+				fill, err := core.ParseFillValueMessage(msg.Data)
+				require.NoError(t, err)
+				return fill
+			}
+		}
+		return nil
+	}
+
+	fill := fillMessage("/early")
+	require.NotNil(t, fill)
+	require.Equal(t, core.AllocTimeEarly, fill.AllocTime)
+	require.Equal(t, core.FillTimeNever, fill.FillTime)
+
+	fill = fillMessage("/fill")
+	require.NotNil(t, fill)
+	require.Equal(t, core.AllocTimeIncremental, fill.AllocTime, "chunked default")
+	require.Equal(t, core.FillTimeAlloc, fill.FillTime)
+
+	require.Nil(t, fillMessage("/plain"))
+
+	values, err := findDatasetByPath(t, f, "/early").Read()
+	require.NoError(t, err)
+	require.Len(t, values, 20)
+}
+
+// TestChunkedWrite_NonDivisible_GZIP writes a compressed dataset whose chunk
+// shape does not divide it, checks that edge chunks are stored zero-padded
+// to the full chunk size, and that reads trim them.
+func TestChunkedWrite_NonDivisible_GZIP(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "non_divisible.h5")
+
+	// 25x35 with 10x8 chunks: 3x5 chunks, the last row and column partial.
+	dims := []uint64{25, 35}
+	expected := make([]int32, 25*35)
+	for i := range expected {
+		expected[i] = int32(i + 1)
+	}
+
+	fw, err := CreateForWrite(filename, CreateTruncate)
+	require.NoError(t, err)
+	ds, err := fw.CreateDataset("/data", Int32, dims,
+		WithChunkDims([]uint64{10, 8}), WithGZIPCompression(6))
+	require.NoError(t, err)
+	require.NoError(t, ds.Write(expected))
+	require.NoError(t, fw.Close())
+
+	f, err := Open(filename)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+	rd := findDatasetByPath(t, f, "/data")
+
+	values, err := rd.Read()
+	require.NoError(t, err)
+	require.Len(t, values, len(expected))
+	for i, v := range values {
+		require.Equal(t, float64(expected[i]), v, "element %d", i)
+	}
+
+	// The bottom-right corner lies in the partial edge chunk (2, 4).
+	slice, err := rd.ReadSlice([]uint64{20, 30}, []uint64{5, 5})
+	require.NoError(t, err)
+	corner := slice.([]float64)
+	require.Len(t, corner, 25)
+	require.Equal(t, float64(expected[20*35+30]), corner[0])
+	require.Equal(t, float64(expected[24*35+34]), corner[24])
+
+	stats, err := rd.ChunkInfo()
+	require.NoError(t, err)
+	require.Len(t, stats, 15)
+
+	raw, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	for _, c := range stats {
+		if c.Offset[0] != 20 || c.Offset[1] != 32 {
+			continue
+		}
+		zr, err := zlib.NewReader(bytes.NewReader(raw[c.Address : c.Address+c.StoredBytes]))
+		require.NoError(t, err)
+		chunk, err := io.ReadAll(zr)
+		require.NoError(t, err)
+		require.Len(t, chunk, 10*8*4)
+
+		// Rows 20-24, columns 32-34 hold data; the rest is zero padding.
+		for row := 0; row < 10; row++ {
+			for col := 0; col < 8; col++ {
+				v := int32(binary.LittleEndian.Uint32(chunk[(row*8+col)*4:]))
+				if row < 5 && col < 3 {
+					require.Equal(t, expected[(20+row)*35+32+col], v)
+				} else {
+					require.Zero(t, v, "padding at row %d col %d", row, col)
+				}
+			}
+		}
+		return
+	}
+	t.Fatal("edge chunk (20, 32) not found")
+}
+
+// TestChunkedDataset_AutoClampChunks tests clamping chunk dimensions to the
+// dataset and maximum dimensions.
+func TestChunkedDataset_AutoClampChunks(t *testing.T) {
+	fw, err := CreateForWrite(filepath.Join(t.TempDir(), "clamp.h5"), CreateTruncate)
+	require.NoError(t, err)
+	defer func() { _ = fw.Close() }()
+
+	ds, err := fw.CreateDataset("/fixed", Float32, []uint64{10, 1000},
+		WithChunkDims([]uint64{64, 64}), WithAutoClampChunks())
+	require.NoError(t, err)
+	require.Equal(t, []uint64{10, 64}, ds.ChunkDims())
+
+	ds, err = fw.CreateDataset("/bounded", Float32, []uint64{10},
+		WithChunkDims([]uint64{100}), WithMaxDims([]uint64{50}), WithAutoClampChunks())
+	require.NoError(t, err)
+	require.Equal(t, []uint64{50}, ds.ChunkDims())
+
+	// Along unlimited dimensions chunks may be larger than the dataset.
+	ds, err = fw.CreateDataset("/unlimited", Float32, []uint64{10},
+		WithChunkDims([]uint64{100}), WithMaxDims([]uint64{Unlimited}), WithAutoClampChunks())
+	require.NoError(t, err)
+	require.Equal(t, []uint64{100}, ds.ChunkDims())
+	require.NoError(t, ds.Write(make([]float32, 10)))
+
+	_, err = fw.CreateDataset("/too_big", Float32, []uint64{10},
+		WithChunkDims([]uint64{100}), WithMaxDims([]uint64{50}))
+	require.ErrorContains(t, err, "chunk dimension 0 (100) cannot exceed maximum dimension (50)")
+
+	_, err = fw.CreateDataset("/props", Float32, []uint64{10},
+		WithCreationProps(NewCreationProps().Chunk(5)), WithAutoClampChunks())
+	require.ErrorContains(t, err, "cannot be combined")
+}
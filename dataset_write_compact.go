@@ -0,0 +1,75 @@
+package hdf5
+
+import (
+	"fmt"
+
+	"github.com/scigolib/hdf5/internal/core"
+)
+
+// WithCompactLayout stores the dataset's data inside its object header
+// instead of in a separate block. This saves space and a read for small
+// datasets, such as per-run metadata, and is the layout HDF5 recommends for
+// datasets of a few kilobytes.
+//
+// The data must fit in one object header message: at most 65531 bytes.
+// Compact datasets cannot be chunked or resized.
+//
+// Example:
+//
+//	ds, _ := fw.CreateDataset("/calibration", hdf5.Float64, []uint64{16},
+//	    hdf5.WithCompactLayout())
+func WithCompactLayout() DatasetOption {
+	return func(cfg *datasetConfig) {
+		cfg.compact = true
+	}
+}
+
+// compactDataAddress returns the file address of the raw data of the compact
+// layout message in the object header at headerAddress.
+func (fw *FileWriter) compactDataAddress(headerAddress uint64) (uint64, error) {
+	header, err := core.ReadObjectHeader(fw.writer.Reader(), headerAddress, fw.file.sb)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read object header: %w", err)
+	}
+
+	// V2 message header: type (1) + size (2) + flags (1) [+ creation index (2)].
+	// V1 message header: type (2) + size (2) + flags (1) + reserved (3).
+	msgHeaderSize := uint64(4)
+	switch {
+	case header.Version == 1:
+		msgHeaderSize = 8
+	case header.Flags&core.OHDRAttrCrtOrderTracked != 0:
+		msgHeaderSize = 6
+	}
+	for _, msg := range header.Messages {
+		if msg.Type == core.MsgDataLayout {
+			// Layout data: version (1) + class (1) + size (2) + raw data.
+			return msg.Offset + msgHeaderSize + 4, nil
+		}
+	}
+	return 0, fmt.Errorf("layout message not found in object header")
+}
+
+// writeContiguous writes the complete data of a contiguous or compact
+// dataset. External data goes to the dataset's external files. Compact
+// data lives in the object header, whose checksum is updated afterwards; it
+// is located again on every write because adding attributes may rewrite the
+// header.
+func (dw *DatasetWriter) writeContiguous(buf []byte) error {
+	if len(dw.external) > 0 {
+		return dw.writeExternal(buf)
+	}
+	if !dw.isCompact {
+		return dw.fileWriter.writer.WriteAtAddress(buf, dw.dataAddress)
+	}
+
+	addr, err := dw.fileWriter.compactDataAddress(dw.address)
+	if err != nil {
+		return err
+	}
+	dw.dataAddress = addr
+	if err := dw.fileWriter.writer.WriteAtAddress(buf, addr); err != nil {
+		return err
+	}
+	return dw.updateHeaderChecksum()
+}
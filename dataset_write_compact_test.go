@@ -0,0 +1,82 @@
+package hdf5
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/scigolib/hdf5/internal/core"
+	"github.com/stretchr/testify/require"
+)
+
+// requireHeaderChecksum checks the Jenkins checksum of the first chunk of the
+// V2 object header at addr.
+func requireHeaderChecksum(t *testing.T, filename string, addr uint64) {
+	t.Helper()
+	data, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	oh := data[addr:]
+	require.Equal(t, "OHDR", string(oh[:4]))
+
+	flags := oh[5]
+	prefix := 6
+	if flags&core.OHDRStoreTimes != 0 {
+		prefix += 16
+	}
+	if flags&core.OHDRAttrStorePhaseChange != 0 {
+		prefix += 4
+	}
+	width := 1 << (flags & core.OHDRChunk0SizeMask)
+	var size uint64
+	for i := width - 1; i >= 0; i-- {
+		size = size<<8 | uint64(oh[prefix+i])
+	}
+	end := uint64(prefix+width) + size //nolint:gosec // G115: test header sizes are small
+	require.Equal(t, binary.LittleEndian.Uint32(oh[end:]), core.JenkinsChecksum(oh[:end]))
+}
+
+func TestWithCompactLayout_RoundTrip(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "compact.h5")
+
+	fw, err := CreateForWrite(filename, CreateTruncate)
+	require.NoError(t, err)
+
+	grid, err := fw.CreateDataset("/grid", Float64, []uint64{2, 3}, WithCompactLayout())
+	require.NoError(t, err)
+	require.NoError(t, grid.Write([]float64{1.5, 2.5, 3.5, 4.5, 5.5, 6.5}))
+	require.NoError(t, grid.WriteAttribute("units", "m"))
+
+	// Attribute first, data second.
+	names, err := fw.CreateDataset("/names", String, []uint64{2}, WithStringSize(6), WithCompactLayout())
+	require.NoError(t, err)
+	require.NoError(t, names.WriteAttribute("kind", "label"))
+	require.NoError(t, names.Write([]string{"alpha", "beta"}))
+
+	_, err = fw.CreateDataset("/big", Float64, []uint64{10000}, WithCompactLayout())
+	require.ErrorContains(t, err, "exceeds compact layout maximum")
+	_, err = fw.CreateDataset("/chunked", Float64, []uint64{10}, WithCompactLayout(), WithChunkDims([]uint64{5}))
+	require.ErrorContains(t, err, "cannot be combined with chunked")
+	require.NoError(t, fw.Close())
+
+	f, err := Open(filename)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	ds := findDatasetByPath(t, f, "/grid")
+	layout, err := ds.Layout()
+	require.NoError(t, err)
+	require.True(t, layout.IsCompact())
+	values, err := ds.Read()
+	require.NoError(t, err)
+	require.Equal(t, []float64{1.5, 2.5, 3.5, 4.5, 5.5, 6.5}, values)
+	requireHeaderChecksum(t, filename, ds.Address())
+	requireHeaderChecksum(t, filename, findDatasetByPath(t, f, "/names").Address())
+	units, err := ds.ReadAttribute("units")
+	require.NoError(t, err)
+	require.Equal(t, "m", units)
+
+	strs, err := findDatasetByPath(t, f, "/names").ReadStrings()
+	require.NoError(t, err)
+	require.Equal(t, []string{"alpha", "beta"}, strs)
+}
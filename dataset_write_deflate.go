@@ -0,0 +1,76 @@
+package hdf5
+
+import (
+	"fmt"
+
+	"github.com/scigolib/hdf5/internal/writer"
+)
+
+// DeflateStrategy selects how GZIP compression encodes chunks, matching the
+// strategies of Go's compress/flate. Data written with any strategy is a
+// standard deflate stream that every HDF5 reader can decompress.
+type DeflateStrategy int
+
+// Deflate strategies for WithDeflateStrategy. compress/flate has no
+// equivalent of zlib's Z_FILTERED, so it is not offered.
+const (
+	// DeflateStrategyDefault searches for repeated sequences and
+	// Huffman-codes the result, as the compression level directs.
+	DeflateStrategyDefault = DeflateStrategy(writer.DeflateDefault)
+
+	// DeflateStrategyHuffmanOnly only Huffman-codes bytes, without searching
+	// for repeats. It is much faster and loses little on noisy data such as
+	// floating-point measurements, especially after WithShuffle.
+	DeflateStrategyHuffmanOnly = DeflateStrategy(writer.DeflateHuffmanOnly)
+
+	// DeflateStrategyStored stores data in uncompressed deflate blocks, for
+	// data that is already compressed (e.g. JPEG images in an Opaque
+	// dataset) but must use the deflate filter.
+	DeflateStrategyStored = DeflateStrategy(writer.DeflateStored)
+)
+
+// WithDeflateStrategy sets the encoding strategy of GZIP compression. It
+// requires WithGZIPCompression or WithCompression and may be given before or
+// after them; the compression level is still recorded in the file.
+//
+// Only encoding is affected: reading is unchanged.
+//
+// Example:
+//
+//	// Fast compression for noisy float data
+//	ds, _ := fw.CreateDataset("/signal", hdf5.Float64, []uint64{1_000_000},
+//	    hdf5.WithChunkDims([]uint64{65536}),
+//	    hdf5.WithShuffle(),
+//	    hdf5.WithGZIPCompression(6),
+//	    hdf5.WithDeflateStrategy(hdf5.DeflateStrategyHuffmanOnly))
+func WithDeflateStrategy(strategy DeflateStrategy) DatasetOption {
+	return func(cfg *datasetConfig) {
+		cfg.deflateStrategy = strategy
+		cfg.deflateStrategySet = true
+	}
+}
+
+// applyDeflateStrategy sets the strategy of WithDeflateStrategy on the GZIP
+// filters of the pipeline.
+func applyDeflateStrategy(config *datasetConfig) error {
+	if !config.deflateStrategySet {
+		return nil
+	}
+	if config.deflateStrategy < DeflateStrategyDefault || config.deflateStrategy > DeflateStrategyStored {
+		return fmt.Errorf("invalid deflate strategy: %d", config.deflateStrategy)
+	}
+
+	found := false
+	if config.pipeline != nil {
+		for _, f := range config.pipeline.Filters() {
+			if gz, ok := f.(*writer.GZIPFilter); ok {
+				gz.SetStrategy(writer.DeflateStrategy(config.deflateStrategy))
+				found = true
+			}
+		}
+	}
+	if !found {
+		return fmt.Errorf("deflate strategy requires GZIP compression (use WithGZIPCompression)")
+	}
+	return nil
+}
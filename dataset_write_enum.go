@@ -0,0 +1,91 @@
+package hdf5
+
+import (
+	"fmt"
+
+	"github.com/scigolib/hdf5/internal/core"
+)
+
+// WriteEnum writes an enum dataset by member name. Each name is mapped to
+// its value in the dataset's enum datatype and stored with the width and
+// byte order of the enum base type.
+//
+// Works for enum datasets created with WithEnumValues (contiguous or
+// chunked) and for contiguous enum datasets reopened with OpenForWrite +
+// OpenDataset.
+//
+// Parameters:
+//   - names: One member name per dataset element
+//
+// Returns:
+//   - error: If the dataset is not an enum, a name is not a member, or the
+//     number of names does not match the dataset size
+//
+// Example:
+//
+//	ds, _ := fw.CreateDataset("/colors", hdf5.EnumUint8, []uint64{3},
+//	    hdf5.WithEnumValues([]string{"RED", "GREEN", "BLUE"}, []int64{0, 1, 2}))
+//	err := ds.WriteEnum([]string{"BLUE", "RED", "BLUE"})
+func (dw *DatasetWriter) WriteEnum(names []string) error {
+	enum, err := dw.enumMapping()
+	if err != nil {
+		return err
+	}
+	contiguous, err := dw.isContiguous()
+	if err != nil {
+		return err
+	}
+	if !contiguous && !dw.isChunked {
+		return fmt.Errorf("WriteEnum does not support reopened chunked datasets")
+	}
+
+	size := uint64(enum.Base.Size)
+	if uint64(len(names))*size != dw.dataSize {
+		return fmt.Errorf("data length %d doesn't match dataset size %d", len(names), dw.dataSize/size)
+	}
+
+	values := make(map[string][]byte, len(enum.Names))
+	for i, name := range enum.Names {
+		values[name] = enum.Values[i]
+	}
+
+	buf := make([]byte, 0, dw.dataSize)
+	for i, name := range names {
+		value, ok := values[name]
+		if !ok {
+			return fmt.Errorf("element %d: %q is not a member of the enum", i, name)
+		}
+		buf = append(buf, value...)
+	}
+
+	return dw.WriteRaw(buf)
+}
+
+// enumMapping returns the enum datatype of the dataset.
+func (dw *DatasetWriter) enumMapping() (*core.EnumType, error) {
+	if dw.enumType != nil {
+		return dw.enumType, nil
+	}
+	// Datasets reopened with OpenDataset keep the full enum datatype.
+	if dw.dtype.Class == core.DatatypeEnum {
+		return core.ParseEnumType(dw.dtype)
+	}
+	return nil, fmt.Errorf("dataset %q is not an enum dataset", dw.name)
+}
+
+// parseEnumDatatype returns the enum mapping of an encoded datatype message,
+// or nil if the datatype is not an enum.
+func parseEnumDatatype(datatypeData []byte) (*core.EnumType, error) {
+	dt, err := core.ParseDatatypeMessage(datatypeData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse datatype: %w", err)
+	}
+	if dt.Class != core.DatatypeEnum {
+		return nil, nil //nolint:nilnil // nil means not an enum datatype
+	}
+	enum, err := core.ParseEnumType(dt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse enum datatype: %w", err)
+	}
+	return enum, nil
+}
@@ -0,0 +1,68 @@
+package hdf5
+
+import (
+	"encoding/binary"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// readRawBytes opens filename and returns the raw element bytes of path.
+func readRawBytes(t *testing.T, filename, path string, size int) []byte {
+	t.Helper()
+	f, err := Open(filename)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	buf := make([]byte, size)
+	_, err = findDatasetByPath(t, f, path).ReadIntoBuffer(buf)
+	require.NoError(t, err)
+	return buf
+}
+
+func TestWriteEnum(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "enum_write.h5")
+
+	fw, err := CreateForWrite(filename, CreateTruncate)
+	require.NoError(t, err)
+	levels, err := fw.CreateDataset("/levels", EnumInt16, []uint64{4},
+		WithEnumValues([]string{"LOW", "MID", "HIGH"}, []int64{-1, 300, 7}))
+	require.NoError(t, err)
+	require.NoError(t, levels.WriteEnum([]string{"HIGH", "LOW", "MID", "HIGH"}))
+
+	colors, err := fw.CreateDataset("/colors", EnumUint8, []uint64{5},
+		WithEnumValues([]string{"RED", "GREEN", "BLUE"}, []int64{10, 20, 30}),
+		WithChunkDims([]uint64{2}))
+	require.NoError(t, err)
+	require.NoError(t, colors.WriteEnum([]string{"BLUE", "RED", "GREEN", "GREEN", "RED"}))
+
+	require.ErrorContains(t, levels.WriteEnum([]string{"LOW", "MID", "NONE", "LOW"}), `"NONE" is not a member`)
+	require.ErrorContains(t, levels.WriteEnum([]string{"LOW"}), "doesn't match dataset size")
+
+	plain, err := fw.CreateDataset("/plain", Int16, []uint64{1})
+	require.NoError(t, err)
+	require.ErrorContains(t, plain.WriteEnum([]string{"LOW"}), "not an enum dataset")
+	require.NoError(t, fw.Close())
+
+	raw := readRawBytes(t, filename, "/levels", 8)
+	got := make([]int16, 4)
+	for i := range got {
+		got[i] = int16(binary.LittleEndian.Uint16(raw[i*2:])) //nolint:gosec // G115: test data
+	}
+	require.Equal(t, []int16{7, -1, 300, 7}, got)
+	require.Equal(t, []byte{30, 10, 20, 20, 10}, readRawBytes(t, filename, "/colors", 5))
+
+	// Reopened datasets map names through the stored enum datatype.
+	fw, err = OpenForWrite(filename, OpenReadWrite)
+	require.NoError(t, err)
+	ds, err := fw.OpenDataset("/levels")
+	require.NoError(t, err)
+	require.NoError(t, ds.WriteEnum([]string{"MID", "MID", "LOW", "HIGH"}))
+	ds, err = fw.OpenDataset("/colors")
+	require.NoError(t, err)
+	require.ErrorContains(t, ds.WriteEnum([]string{"RED", "RED", "BLUE", "BLUE", "GREEN"}), "reopened chunked")
+	require.NoError(t, fw.Close())
+
+	require.Equal(t, []byte{0x2C, 0x01, 0x2C, 0x01, 0xFF, 0xFF, 7, 0}, readRawBytes(t, filename, "/levels", 8))
+}
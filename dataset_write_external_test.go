@@ -0,0 +1,94 @@
+package hdf5
+
+import (
+	"encoding/binary"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/scigolib/hdf5/internal/core"
+	"github.com/scigolib/hdf5/internal/structures"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithExternalStorage(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "external.h5")
+	rawA := filepath.Join(dir, "a.raw")
+	rawB := filepath.Join(dir, "b.raw")
+
+	fw, err := CreateForWrite(filename, CreateTruncate)
+	require.NoError(t, err)
+	ds, err := fw.CreateDataset("/data", Float64, []uint64{6}, WithExternalStorage([]ExternalFile{
+		{Name: rawA, Offset: 16, Size: 32},
+		{Name: rawB, Offset: 0, Size: 64},
+	}))
+	require.NoError(t, err)
+	values := []float64{1, 2, 3, 4, 5, 6}
+	require.NoError(t, ds.Write(values))
+	require.NoError(t, fw.Close())
+
+	// The first four values go to a.raw after 16 bytes, the rest to b.raw.
+	a, err := os.ReadFile(rawA)
+	require.NoError(t, err)
+	require.Len(t, a, 48)
+	for i := 0; i < 4; i++ {
+		require.Equal(t, values[i], math.Float64frombits(binary.LittleEndian.Uint64(a[16+8*i:])))
+	}
+	b, err := os.ReadFile(rawB)
+	require.NoError(t, err)
+	require.Len(t, b, 16)
+	require.Equal(t, 5.0, math.Float64frombits(binary.LittleEndian.Uint64(b)))
+	require.Equal(t, 6.0, math.Float64frombits(binary.LittleEndian.Uint64(b[8:])))
+
+	f, err := Open(filename)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+	oh, err := core.ReadObjectHeader(f.reader, findDatasetByPath(t, f, "/data").Address(), f.sb)
+	require.NoError(t, err)
+
+	var efl *core.ExternalFileListMessage
+	for _, msg := range oh.Messages {
+		if msg.Type == core.MsgExternalFiles {
+			efl, err = core.ParseExternalFileListMessage(msg.Data, f.sb)
+			require.NoError(t, err)
+		}
+	}
+	require.NotNil(t, efl, "no External Data Files message")
+	require.Len(t, efl.Entries, 2)
+	require.Equal(t, uint64(16), efl.Entries[0].Offset)
+	require.Equal(t, uint64(64), efl.Entries[1].Size)
+
+	heap, err := structures.LoadLocalHeap(f.reader, efl.HeapAddress, f.sb)
+	require.NoError(t, err)
+	name, err := heap.GetString(efl.Entries[1].NameOffset)
+	require.NoError(t, err)
+	require.Equal(t, rawB, name)
+}
+
+func TestWithExternalStorage_Validation(t *testing.T) {
+	dir := t.TempDir()
+	fw, err := CreateForWrite(filepath.Join(dir, "invalid.h5"), CreateTruncate)
+	require.NoError(t, err)
+	defer func() { _ = fw.Close() }()
+
+	raw := filepath.Join(dir, "data.raw")
+	_, err = fw.CreateDataset("/small", Int32, []uint64{10},
+		WithExternalStorage([]ExternalFile{{Name: raw, Size: 39}}))
+	require.ErrorContains(t, err, "external storage capacity 39 bytes is less than dataset size 40 bytes")
+
+	_, err = fw.CreateDataset("/chunked", Int32, []uint64{10},
+		WithExternalStorage([]ExternalFile{{Name: raw, Size: 40}}),
+		WithChunkDims([]uint64{5}))
+	require.ErrorContains(t, err, "cannot be combined with chunked layout")
+
+	_, err = fw.CreateDataset("/compact", Int32, []uint64{10},
+		WithExternalStorage([]ExternalFile{{Name: raw, Size: 40}}),
+		WithCompactLayout())
+	require.ErrorContains(t, err, "cannot be combined with compact layout")
+
+	_, err = fw.CreateDataset("/unnamed", Int32, []uint64{10},
+		WithExternalStorage([]ExternalFile{{Size: 40}}))
+	require.ErrorContains(t, err, "empty name")
+}
@@ -1,9 +1,12 @@
 package hdf5
 
 import (
+	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/scigolib/hdf5/internal/core"
 	"github.com/stretchr/testify/require"
 )
 
@@ -92,6 +95,90 @@ func TestChunkedDatasetWithShuffleGZIP(t *testing.T) {
 		compressionRatio, uncompressedSize, compressedSize)
 }
 
+func TestChunkedDatasetShuffleGZIP_RoundTrip(t *testing.T) {
+	tmpFile := filepath.Join(t.TempDir(), "shuffle_roundtrip.h5")
+
+	file, err := CreateForWrite(tmpFile, CreateTruncate)
+	require.NoError(t, err)
+
+	// Chunk size does not divide the dataset, so the last chunk is partial.
+	ds, err := file.CreateDataset("/data", Float64, []uint64{2500},
+		WithChunkDims([]uint64{1000}),
+		WithShuffle(),
+		WithGZIPCompression(6))
+	require.NoError(t, err)
+
+	data := make([]float64, 2500)
+	for i := range data {
+		data[i] = 273.15 + float64(i)*0.125 - float64(i%7)*1e-9
+	}
+	require.NoError(t, ds.Write(data))
+	require.NoError(t, file.Close())
+
+	f, err := Open(tmpFile)
+	require.NoError(t, err)
+	defer f.Close()
+
+	readDs := findDatasetByPath(t, f, "/data")
+	got, err := readDs.Read()
+	require.NoError(t, err)
+	require.Equal(t, data, got)
+}
+
+// TestChunkedDatasetShufflePipelineMessage checks the stored pipeline: shuffle
+// comes first whatever the option order, carries the datatype size as client
+// data and is optional, as written by H5Pset_shuffle.
+func TestChunkedDatasetShufflePipelineMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		dtype    Datatype
+		elemSize uint32
+	}{
+		{"int16", Int16, 2},
+		{"float64", Float64, 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpFile := filepath.Join(t.TempDir(), "shuffle_pipeline.h5")
+
+			fw, err := CreateForWrite(tmpFile, CreateTruncate)
+			require.NoError(t, err)
+			_, err = fw.CreateDataset("/data", tt.dtype, []uint64{100},
+				WithChunkDims([]uint64{10}),
+				WithGZIPCompression(4),
+				WithFletcher32(),
+				WithShuffle())
+			require.NoError(t, err)
+			require.NoError(t, fw.Close())
+
+			f, err := Open(tmpFile)
+			require.NoError(t, err)
+			defer f.Close()
+
+			ds := findDatasetByPath(t, f, "/data")
+			header, err := core.ReadObjectHeader(f.Reader(), ds.Address(), f.Superblock())
+			require.NoError(t, err)
+
+			var pipeline *core.FilterPipelineMessage
+			for _, msg := range header.Messages {
+				if msg.Type == core.MsgFilterPipeline {
+					pipeline, err = core.ParseFilterPipelineMessage(msg.Data)
+					require.NoError(t, err)
+				}
+			}
+			require.NotNil(t, pipeline)
+			require.Len(t, pipeline.Filters, 3)
+
+			require.Equal(t, core.FilterShuffle, pipeline.Filters[0].ID)
+			require.Equal(t, []uint32{tt.elemSize}, pipeline.Filters[0].ClientData)
+			require.Equal(t, uint16(0x0001), pipeline.Filters[0].Flags&0x0001)
+			require.Equal(t, core.FilterDeflate, pipeline.Filters[1].ID)
+			require.Equal(t, core.FilterFletcher, pipeline.Filters[2].ID)
+		})
+	}
+}
+
 func TestChunkedDatasetWithFletcher32(t *testing.T) {
 	tmpFile := "test_fletcher.h5"
 	defer os.Remove(tmpFile)
@@ -129,6 +216,96 @@ func TestChunkedDatasetWithFletcher32(t *testing.T) {
 	t.Logf("File size with Fletcher32: %d bytes", info.Size())
 }
 
+func TestChunkedDatasetFletcher32_DetectsCorruption(t *testing.T) {
+	tmpFile := filepath.Join(t.TempDir(), "fletcher32_corrupt.h5")
+
+	data := make([]float64, 40)
+	for i := range data {
+		data[i] = float64(i) * 1.5
+	}
+
+	file, err := CreateForWrite(tmpFile, CreateTruncate)
+	require.NoError(t, err)
+	ds, err := file.CreateDataset("/data", Float64, []uint64{40},
+		WithChunkDims([]uint64{10}),
+		WithFletcher32())
+	require.NoError(t, err)
+	require.NoError(t, ds.Write(data))
+	require.NoError(t, file.Close())
+
+	// Intact file: checksums verify.
+	f, err := Open(tmpFile)
+	require.NoError(t, err)
+	values, err := findDatasetByPath(t, f, "/data").Read()
+	require.NoError(t, err)
+	require.Equal(t, data, values)
+
+	// Locate the third chunk (elements 20-29).
+	dataset := findDatasetByPath(t, f, "/data")
+	oh, err := core.ReadObjectHeader(f.reader, dataset.address, f.sb)
+	require.NoError(t, err)
+	var layout *core.DataLayoutMessage
+	for _, msg := range oh.Messages {
+		if msg.Type == core.MsgDataLayout {
+			layout, err = core.ParseDataLayoutMessage(msg.Data, f.sb)
+			require.NoError(t, err)
+		}
+	}
+	require.NotNil(t, layout)
+	node, err := core.ParseBTreeV1Node(f.reader, layout.DataAddress, f.sb.OffsetSize, len(layout.ChunkSize), layout.ChunkSize)
+	require.NoError(t, err)
+	chunks, err := node.CollectAllChunks(f.reader, f.sb.OffsetSize, layout.ChunkSize)
+	require.NoError(t, err)
+	var chunkAddr uint64
+	for _, c := range chunks {
+		if c.Key.Scaled[0] == 2 {
+			chunkAddr = c.Address
+		}
+	}
+	require.NotZero(t, chunkAddr)
+	require.NoError(t, f.Close())
+
+	// Flip one data byte in that chunk.
+	raw, err := os.OpenFile(tmpFile, os.O_RDWR, 0)
+	require.NoError(t, err)
+	b := make([]byte, 1)
+	_, err = raw.ReadAt(b, int64(chunkAddr)+3)
+	require.NoError(t, err)
+	b[0] ^= 0x40
+	_, err = raw.WriteAt(b, int64(chunkAddr)+3)
+	require.NoError(t, err)
+	require.NoError(t, raw.Close())
+
+	f, err = Open(tmpFile)
+	require.NoError(t, err)
+	_, err = findDatasetByPath(t, f, "/data").Read()
+	var csErr *ChecksumError
+	require.ErrorAs(t, err, &csErr)
+	require.Equal(t, []uint64{2}, csErr.Chunk)
+	require.Equal(t, chunkAddr, csErr.Address)
+	require.NotEqual(t, csErr.Stored, csErr.Computed)
+
+	// Hyperslab reads verify too.
+	_, err = findDatasetByPath(t, f, "/data").ReadSlice([]uint64{20}, []uint64{5})
+	require.ErrorAs(t, err, &csErr)
+	require.Equal(t, []uint64{2}, csErr.Chunk)
+
+	// Reads outside the damaged chunk are unaffected.
+	_, err = findDatasetByPath(t, f, "/data").ReadSlice([]uint64{0}, []uint64{10})
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	// Verification disabled: the corrupted values are returned.
+	f, err = Open(tmpFile, WithVerifyFilters(false))
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+	values, err = findDatasetByPath(t, f, "/data").Read()
+	require.NoError(t, err)
+	require.Len(t, values, 40)
+	require.Equal(t, data[:20], values[:20])
+	require.NotEqual(t, data[20], values[20])
+}
+
 func TestChunkedDatasetWithAllFilters(t *testing.T) {
 	tmpFile := "test_all_filters.h5"
 	defer os.Remove(tmpFile)
@@ -214,6 +391,47 @@ func TestChunkedDatasetGZIPLevels(t *testing.T) {
 	}
 }
 
+// TestChunkedDatasetDeflateStrategy verifies that every deflate strategy
+// reads back unchanged and that the option order does not matter.
+func TestChunkedDatasetDeflateStrategy(t *testing.T) {
+	dir := t.TempDir()
+
+	data := make([]int32, 1000)
+	expected := make([]float64, len(data))
+	for i := range data {
+		data[i] = int32(i % 50)
+		expected[i] = float64(data[i])
+	}
+
+	sizes := make(map[DeflateStrategy]int64)
+	for _, strategy := range []DeflateStrategy{DeflateStrategyDefault, DeflateStrategyHuffmanOnly, DeflateStrategyStored} {
+		filename := filepath.Join(dir, fmt.Sprintf("strategy%d.h5", strategy))
+		fw, err := CreateForWrite(filename, CreateTruncate)
+		require.NoError(t, err)
+		ds, err := fw.CreateDataset("/data", Int32, []uint64{1000},
+			WithDeflateStrategy(strategy),
+			WithChunkDims([]uint64{100}),
+			WithGZIPCompression(6))
+		require.NoError(t, err)
+		require.NoError(t, ds.Write(data))
+		require.NoError(t, fw.Close())
+
+		require.Equal(t, expected, readDatasetFloat64(t, filename, "/data"))
+		info, err := os.Stat(filename)
+		require.NoError(t, err)
+		sizes[strategy] = info.Size()
+	}
+	require.Less(t, sizes[DeflateStrategyDefault], sizes[DeflateStrategyHuffmanOnly])
+	require.Less(t, sizes[DeflateStrategyHuffmanOnly], sizes[DeflateStrategyStored])
+
+	fw, err := CreateForWrite(filepath.Join(dir, "nogzip.h5"), CreateTruncate)
+	require.NoError(t, err)
+	defer func() { _ = fw.Close() }()
+	_, err = fw.CreateDataset("/data", Int32, []uint64{1000},
+		WithChunkDims([]uint64{100}), WithDeflateStrategy(DeflateStrategyHuffmanOnly))
+	require.ErrorContains(t, err, "requires GZIP compression")
+}
+
 func TestChunkedDatasetShuffleImprovement(t *testing.T) {
 	// Compare GZIP alone vs Shuffle+GZIP
 	data := make([]int32, 10000)
@@ -641,3 +859,137 @@ func TestChunkedDatasetMixedValues(t *testing.T) {
 
 	t.Logf("Mixed values compression: %.2f:1", compressionRatio)
 }
+
+func TestAutoChunkDims(t *testing.T) {
+	tests := []struct {
+		name     string
+		dims     []uint64
+		elemSize uint64
+		want     []uint64
+	}{
+		{"small dataset is one chunk", []uint64{100}, 4, []uint64{100}},
+		{"1-D 4 MB", []uint64{1_000_000}, 4, []uint64{31250}},
+		{"1-D 80 MB", []uint64{10_000_000}, 8, []uint64{19532}},
+		{"2-D 8 MB", []uint64{1000, 1000}, 8, []uint64{125, 125}},
+		{"unlimited rows", []uint64{1, 3}, 8, []uint64{2048, 3}},
+		{"empty unlimited rows", []uint64{0, 100}, 4, []uint64{256, 50}},
+		{"two unlimited dims", []uint64{1, 1}, 4, []uint64{128, 256}},
+		{"fixed maxDims", []uint64{10, 10}, 8, []uint64{10, 10}},
+	}
+	maxDims := map[string][]uint64{
+		"unlimited rows":       {Unlimited, 3},
+		"empty unlimited rows": {Unlimited, 100},
+		"two unlimited dims":   {Unlimited, Unlimited},
+		"fixed maxDims":        {100, 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, autoChunkDims(tt.dims, maxDims[tt.name], tt.elemSize))
+		})
+	}
+}
+
+func TestWithCompression_AutoChunk(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "auto_chunk.h5")
+	fw, err := CreateForWrite(path, CreateTruncate)
+	require.NoError(t, err)
+
+	data := make([]float64, 100_000)
+	for i := range data {
+		data[i] = float64(i % 250)
+	}
+	ds, err := fw.CreateDataset("/auto", Float64, []uint64{uint64(len(data))}, WithCompression(6))
+	require.NoError(t, err)
+	require.Equal(t, []uint64{6250}, ds.ChunkDims())
+	require.NoError(t, ds.Write(data))
+
+	explicit, err := fw.CreateDataset("/explicit", Int32, []uint64{1000},
+		WithChunkDims([]uint64{250}), WithCompression(6))
+	require.NoError(t, err)
+	require.Equal(t, []uint64{250}, explicit.ChunkDims())
+
+	plain, err := fw.CreateDataset("/plain", Int32, []uint64{10})
+	require.NoError(t, err)
+	require.Nil(t, plain.ChunkDims())
+	require.NoError(t, fw.Close())
+
+	f, err := Open(path)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	rd := findDatasetByPath(t, f, "/auto")
+	layout, err := rd.Layout()
+	require.NoError(t, err)
+	require.True(t, layout.IsChunked())
+	require.Equal(t, uint64(6250), layout.ChunkSize[0])
+
+	filters, err := rd.Filters()
+	require.NoError(t, err)
+	require.Len(t, filters, 1)
+	require.Equal(t, core.FilterDeflate, filters[0].ID)
+
+	got, err := rd.Read()
+	require.NoError(t, err)
+	require.Equal(t, data, got)
+}
+
+func TestWithCompression_AutoChunkResizable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "auto_chunk_resizable.h5")
+	fw, err := CreateForWrite(path, CreateTruncate)
+	require.NoError(t, err)
+
+	ds, err := fw.CreateDataset("/rows", Float64, []uint64{1, 3},
+		WithMaxDims([]uint64{Unlimited, 3}), WithCompression(6))
+	require.NoError(t, err)
+	require.Equal(t, []uint64{2048, 3}, ds.ChunkDims())
+	require.NoError(t, ds.Write([]float64{1, 2, 3}))
+
+	data := make([]float64, 5000*3)
+	for i := range data {
+		data[i] = float64(i % 97)
+	}
+	require.NoError(t, ds.Resize([]uint64{5000, 3}))
+	require.NoError(t, ds.Write(data))
+	require.NoError(t, fw.Close())
+
+	f, err := Open(path)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	rd := findDatasetByPath(t, f, "/rows")
+	layout, err := rd.Layout()
+	require.NoError(t, err)
+	require.True(t, layout.IsChunked())
+	require.Equal(t, uint64(2048), layout.ChunkSize[0])
+	require.Equal(t, uint64(3), layout.ChunkSize[1])
+
+	got, err := rd.Read()
+	require.NoError(t, err)
+	require.Equal(t, data, got)
+}
+
+func TestOpen_WithMaxAllocation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "limit.h5")
+	fw, err := CreateForWrite(path, CreateTruncate)
+	require.NoError(t, err)
+	dw, err := fw.CreateDataset("/data", Float64, []uint64{1000},
+		WithChunkDims([]uint64{100}), WithGZIPCompression(6))
+	require.NoError(t, err)
+	require.NoError(t, dw.Write(make([]float64, 1000)))
+	require.NoError(t, fw.Close())
+
+	f, err := Open(path, WithMaxAllocation(4096))
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+	ds := findDatasetByPath(t, f, "/data")
+
+	_, err = ds.Read()
+	require.ErrorContains(t, err, "allocation limit")
+
+	f2, err := Open(path, WithMaxAllocation(8000))
+	require.NoError(t, err)
+	defer func() { _ = f2.Close() }()
+	values, err := findDatasetByPath(t, f2, "/data").Read()
+	require.NoError(t, err)
+	require.Len(t, values, 1000)
+}
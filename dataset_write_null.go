@@ -0,0 +1,118 @@
+package hdf5
+
+import (
+	"fmt"
+
+	"github.com/scigolib/hdf5/internal/core"
+)
+
+// CreateNullDataset creates a dataset with a NULL dataspace: it has a
+// datatype but no elements and no data. Such datasets are commonly used as
+// placeholders that carry only attributes.
+//
+// Only datatype options (WithStringSize, WithArrayDims, WithEnumValues, ...)
+// apply; layout and filter options are rejected. Write on the returned
+// DatasetWriter fails, but attributes can be written as usual.
+//
+// Reading the dataset returns an empty slice.
+//
+// Parameters:
+//   - name: Dataset path (e.g., "/placeholder" or "/group/placeholder")
+//   - dtype: Datatype of the (absent) elements
+//   - opts: Optional datatype configuration
+//
+// Returns:
+//   - *DatasetWriter: Writer for the dataset's attributes
+//   - error: If creation fails
+//
+// Example:
+//
+//	ds, _ := fw.CreateNullDataset("/calibration", hdf5.Float64)
+//	ds.WriteAttribute("status", "pending")
+//
+// Reference: H5S.c - H5Screate(H5S_NULL).
+func (fw *FileWriter) CreateNullDataset(name string, dtype Datatype, opts ...DatasetOption) (*DatasetWriter, error) {
+	if err := validateDatasetName(name); err != nil {
+		return nil, err
+	}
+	if err := fw.requireNewFormat("null dataspace"); err != nil {
+		return nil, err
+	}
+
+	config := &datasetConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	if len(config.chunkDims) > 0 || len(config.maxDims) > 0 || config.pipeline != nil ||
+		config.enableShuffle || config.autoChunk || config.compact || config.writeFillInfo ||
+		config.props != nil || config.deflateStrategySet || config.preallocate {
+		return nil, fmt.Errorf("null dataset %q accepts only datatype options", name)
+	}
+
+	dtInfo, err := getDatatypeInfo(dtype, config)
+	if err != nil {
+		return nil, fmt.Errorf("invalid datatype: %w", err)
+	}
+
+	handler := datatypeRegistry[dtype]
+	datatypeData, err := handler.EncodeDatatypeMessage(dtInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode datatype: %w", err)
+	}
+
+	// No storage is allocated: the layout is contiguous with size 0 at the
+	// undefined address, as the C library writes it.
+	layoutData, err := core.EncodeLayoutMessage(core.LayoutContiguous, 0, undefinedAddress, fw.file.sb, nil, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode layout: %w", err)
+	}
+
+	ohw := &core.ObjectHeaderWriter{
+		Version: 2,
+		Flags:   0,
+		Messages: []core.MessageWriter{
+			{Type: core.MsgDatatype, Data: datatypeData},
+			{Type: core.MsgDataspace, Data: core.EncodeNullDataspaceMessage()},
+			{Type: core.MsgDataLayout, Data: layoutData},
+		},
+	}
+	fw.stampTimes(ohw)
+	fw.stampAttributePhaseChange(ohw)
+	ohw.PadToSize(core.MinOHDRAllocSize)
+
+	headerSize, err := calculateObjectHeaderSize(ohw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate header size: %w", err)
+	}
+	headerAddress, err := fw.writer.Allocate(headerSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to allocate space for object header: %w", err)
+	}
+	writtenSize, err := ohw.WriteTo(fw.writer, headerAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write object header: %w", err)
+	}
+	if writtenSize != headerSize {
+		return nil, fmt.Errorf("header size mismatch: expected %d, wrote %d", headerSize, writtenSize)
+	}
+	fw.trackDatasetHeader(headerAddress, headerSize)
+
+	parent, datasetName := parsePath(name)
+	if err := fw.linkToParent(parent, datasetName, headerAddress); err != nil {
+		return nil, fmt.Errorf("failed to link dataset to parent: %w", err)
+	}
+
+	return &DatasetWriter{
+		fileWriter:  fw,
+		name:        name,
+		address:     headerAddress,
+		dataAddress: undefinedAddress,
+		dtype: &core.DatatypeMessage{
+			Class:         dtInfo.class,
+			Version:       1,
+			Size:          dtInfo.size,
+			ClassBitField: dtInfo.classBitField,
+		},
+		isNull: true,
+	}, nil
+}
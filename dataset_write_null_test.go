@@ -0,0 +1,87 @@
+package hdf5
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/scigolib/hdf5/internal/core"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCreateNullDataset verifies that a dataset with a NULL dataspace keeps
+// its datatype and attributes and reads back as an empty slice.
+func TestCreateNullDataset(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "null.h5")
+
+	fw, err := CreateForWrite(filename, CreateTruncate)
+	require.NoError(t, err)
+	ds, err := fw.CreateNullDataset("/placeholder", Int32)
+	require.NoError(t, err)
+	require.NoError(t, ds.WriteAttribute("status", "pending"))
+	require.ErrorContains(t, ds.Write([]int32{1}), "NULL dataspace")
+
+	_, err = fw.CreateNullDataset("/chunked", Float64, WithChunkDims([]uint64{4}))
+	require.ErrorContains(t, err, "only datatype options")
+	require.NoError(t, fw.Close())
+
+	f, err := Open(filename)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	rd := findDatasetByPath(t, f, "/placeholder")
+	dt, err := rd.Dtype()
+	require.NoError(t, err)
+	require.Equal(t, core.DatatypeFixed, dt.Class)
+	require.Equal(t, uint32(4), dt.Size)
+
+	shape, err := rd.Shape()
+	require.NoError(t, err)
+	require.Empty(t, shape)
+
+	values, err := rd.Read()
+	require.NoError(t, err)
+	require.Equal(t, []float64{}, values)
+
+	status, err := rd.ReadAttribute("status")
+	require.NoError(t, err)
+	require.Equal(t, "pending", status)
+}
+
+// TestWriteAttribute_Empty verifies that empty typed slices are written with
+// a NULL dataspace and read back as empty slices of the same type.
+func TestWriteAttribute_Empty(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "empty_attr.h5")
+
+	fw, err := CreateForWrite(filename, CreateTruncate)
+	require.NoError(t, err)
+	ds, err := fw.CreateDataset("/data", Float64, []uint64{2})
+	require.NoError(t, err)
+	require.NoError(t, ds.Write([]float64{1, 2}))
+	require.NoError(t, ds.WriteAttribute("no_ints", []int32{}))
+	require.NoError(t, ds.WriteAttribute("no_floats", []float64{}))
+	require.NoError(t, ds.WriteAttribute("no_longs", []int64{}))
+	require.Error(t, ds.WriteAttribute("no_strings", []string{}))
+	require.NoError(t, fw.Close())
+
+	f, err := Open(filename)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	rd := findDatasetByPath(t, f, "/data")
+	attrs, err := rd.Attributes()
+	require.NoError(t, err)
+	for _, attr := range attrs {
+		require.Equal(t, core.DataspaceNull, attr.Dataspace.Type, attr.Name)
+	}
+
+	want := map[string]interface{}{
+		"no_ints":   []int32{},
+		"no_floats": []float64{},
+		"no_longs":  []int64{},
+	}
+	for name, expected := range want {
+		value, err := rd.ReadAttribute(name)
+		require.NoError(t, err, name)
+		require.Equal(t, expected, value, name)
+	}
+}
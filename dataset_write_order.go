@@ -0,0 +1,80 @@
+package hdf5
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/scigolib/hdf5/internal/core"
+)
+
+// LayoutOrder selects whether the raw data of a contiguous dataset is placed
+// in the file before or after its object header.
+type LayoutOrder uint8
+
+// Storage allocation orders for WithLayoutOrder.
+const (
+	// LayoutDataFirst allocates the raw data before the object header (the
+	// default).
+	LayoutDataFirst LayoutOrder = iota
+
+	// LayoutMetadataFirst allocates the object header before the raw data,
+	// as the HDF5 C library does for contiguous datasets with late space
+	// allocation.
+	LayoutMetadataFirst
+)
+
+// WithLayoutOrder sets the order in which the object header and the raw
+// data of a contiguous dataset are allocated, and therefore their order in
+// the file. Use LayoutMetadataFirst to reproduce the byte layout of files
+// written by the C library, for example to diff output against known-good
+// reference files.
+//
+// Chunked datasets always place their chunks after the object header, as
+// chunks are allocated when written; compact and external datasets store no
+// raw data in the file, so the option has no effect on them.
+//
+// Default: LayoutDataFirst
+//
+// Example:
+//
+//	ds, _ := fw.CreateDataset("/data", hdf5.Float64, []uint64{1000},
+//	    hdf5.WithLayoutOrder(hdf5.LayoutMetadataFirst))
+//
+// Reference: H5Dint.c - H5D__create(), H5Dcontig.c - H5D__contig_alloc().
+func WithLayoutOrder(order LayoutOrder) DatasetOption {
+	return func(cfg *datasetConfig) {
+		cfg.layoutOrder = order
+	}
+}
+
+// allocateContiguous reserves the raw data of a contiguous dataset and
+// zero-fills it if WithPreallocate was given.
+func (fw *FileWriter) allocateContiguous(dataSize uint64, config *datasetConfig) (uint64, error) {
+	dataAddress, err := fw.writer.AllocateRaw(dataSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to allocate space for data: %w", err)
+	}
+	if config.preallocate {
+		if err := fw.zeroFill(dataAddress, dataSize); err != nil {
+			return 0, err
+		}
+	}
+	return dataAddress, nil
+}
+
+// setContiguousAddress points the contiguous layout message of ohw at
+// dataAddress. The message keeps its size, so the header size is unchanged.
+func (fw *FileWriter) setContiguousAddress(ohw *core.ObjectHeaderWriter, dataSize, dataAddress uint64) error {
+	layoutData, err := core.EncodeLayoutMessage(core.LayoutContiguous, dataSize, dataAddress, fw.file.sb, nil, 0)
+	if err != nil {
+		return fmt.Errorf("failed to encode layout: %w", err)
+	}
+	i := slices.IndexFunc(ohw.Messages, func(m core.MessageWriter) bool {
+		return m.Type == core.MsgDataLayout
+	})
+	if i < 0 {
+		return fmt.Errorf("layout message not found in object header")
+	}
+	ohw.Messages[i].Data = layoutData
+	return nil
+}
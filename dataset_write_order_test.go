@@ -0,0 +1,44 @@
+package hdf5
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithLayoutOrder(t *testing.T) {
+	for _, version := range []uint8{0, 2} {
+		filename := filepath.Join(t.TempDir(), "order.h5")
+
+		fw, err := CreateForWrite(filename, CreateTruncate, WithSuperblockVersion(version))
+		require.NoError(t, err)
+
+		dataFirst, err := fw.CreateDataset("/data_first", Float64, []uint64{4})
+		require.NoError(t, err)
+		require.Less(t, dataFirst.dataAddress, dataFirst.address)
+		require.NoError(t, dataFirst.Write([]float64{1, 2, 3, 4}))
+
+		metaFirst, err := fw.CreateDataset("/meta_first", Float64, []uint64{4},
+			WithLayoutOrder(LayoutMetadataFirst), WithPreallocate())
+		require.NoError(t, err)
+		require.Greater(t, metaFirst.dataAddress, metaFirst.address)
+		require.NoError(t, metaFirst.Write([]float64{5, 6, 7, 8}))
+
+		// Chunks follow the header whatever the option says.
+		_, err = fw.CreateDataset("/chunked", Float64, []uint64{4},
+			WithChunkDims([]uint64{2}), WithLayoutOrder(LayoutMetadataFirst))
+		require.NoError(t, err)
+		require.NoError(t, fw.Close())
+
+		f, err := Open(filename)
+		require.NoError(t, err)
+		values, err := findDatasetByPath(t, f, "/data_first").Read()
+		require.NoError(t, err)
+		require.Equal(t, []float64{1, 2, 3, 4}, values)
+		values, err = findDatasetByPath(t, f, "/meta_first").Read()
+		require.NoError(t, err)
+		require.Equal(t, []float64{5, 6, 7, 8}, values)
+		require.NoError(t, f.Close())
+	}
+}
@@ -0,0 +1,62 @@
+package hdf5
+
+import (
+	"fmt"
+)
+
+// preallocateBlockSize is the size of the zero buffer used to fill
+// preallocated storage.
+const preallocateBlockSize = 64 * 1024
+
+// WithPreallocate zero-fills the storage of a contiguous dataset when it is
+// created, instead of leaving it unwritten until Write. Without it, the
+// reserved region is a hole in the file until the data arrives; on file
+// systems that create sparse files, and for tools that copy or checksum the
+// file in the meantime, that region may not be what was expected. With it,
+// the file has its final layout as soon as CreateDataset returns, and
+// unwritten elements read as zero.
+//
+// The cost is the full dataset size written once more: a 1 GiB dataset
+// takes 1 GiB of disk space and a 1 GiB write at creation, before any data
+// is written to it.
+//
+// Only valid for contiguous datasets; chunked, compact and external storage
+// is rejected.
+//
+// Example:
+//
+//	ds, _ := fw.CreateDataset("/frames", hdf5.Uint16, []uint64{100, 1024, 1024},
+//	    hdf5.WithPreallocate())
+//
+// Reference: H5Dcontig.c - H5D__contig_fill().
+func WithPreallocate() DatasetOption {
+	return func(cfg *datasetConfig) {
+		cfg.preallocate = true
+	}
+}
+
+// validatePreallocate checks that WithPreallocate is used with the
+// contiguous layout.
+func validatePreallocate(config *datasetConfig) error {
+	if !config.preallocate {
+		return nil
+	}
+	if len(config.chunkDims) > 0 || config.compact || len(config.externalFiles) > 0 {
+		return fmt.Errorf("preallocation requires contiguous layout")
+	}
+	return nil
+}
+
+// zeroFill writes size zero bytes at address.
+func (fw *FileWriter) zeroFill(address, size uint64) error {
+	zeros := make([]byte, min(size, preallocateBlockSize))
+	for size > 0 {
+		n := min(size, uint64(len(zeros)))
+		if err := fw.writer.WriteAtAddress(zeros[:n], address); err != nil {
+			return fmt.Errorf("failed to preallocate dataset storage: %w", err)
+		}
+		address += n
+		size -= n
+	}
+	return nil
+}
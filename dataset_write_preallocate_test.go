@@ -0,0 +1,51 @@
+package hdf5
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithPreallocate(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "preallocate.h5")
+
+	fw, err := CreateForWrite(filename, CreateTruncate)
+	require.NoError(t, err)
+
+	// Larger than one zero block, and left unwritten.
+	ds, err := fw.CreateDataset("/frames", Float64, []uint64{100, 100}, WithPreallocate())
+	require.NoError(t, err)
+	start, size := ds.dataAddress, ds.dataSize
+
+	written, err := fw.CreateDataset("/values", Int32, []uint64{4}, WithPreallocate())
+	require.NoError(t, err)
+	require.NoError(t, written.Write([]int32{1, 2, 3, 4}))
+
+	_, err = fw.CreateDataset("/chunked", Float64, []uint64{10},
+		WithChunkDims([]uint64{5}), WithPreallocate())
+	require.ErrorContains(t, err, "requires contiguous layout")
+	_, err = fw.CreateDataset("/compact", Float64, []uint64{10},
+		WithCompactLayout(), WithPreallocate())
+	require.ErrorContains(t, err, "requires contiguous layout")
+	require.NoError(t, fw.Close())
+
+	data, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, uint64(len(data)), start+size)
+	require.Equal(t, make([]byte, size), data[start:start+size])
+
+	f, err := Open(filename)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	values, err := findDatasetByPath(t, f, "/frames").Read()
+	require.NoError(t, err)
+	require.Len(t, values, 10000)
+	require.Equal(t, make([]float64, 10000), values)
+
+	values, err = findDatasetByPath(t, f, "/values").Read()
+	require.NoError(t, err)
+	require.Equal(t, []float64{1, 2, 3, 4}, values)
+}
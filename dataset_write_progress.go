@@ -0,0 +1,58 @@
+package hdf5
+
+// progressBlockSize is the size of the pieces a contiguous dataset is
+// written in when progress is reported.
+const progressBlockSize = 4 << 20
+
+// WriteWithProgress writes data like Write and calls cb as the data is
+// written, for progress bars on large writes.
+//
+// bytesWritten counts the dataset's element bytes (before compression) stored
+// so far and total is the dataset's full size in bytes; the last call has
+// bytesWritten == total. Chunked datasets report after each chunk is stored,
+// contiguous datasets after each 4 MiB block. cb is called from the calling
+// goroutine, outside any lock, so it may do slow work such as printing.
+//
+// Parameters:
+//   - data: Data to write (see Write)
+//   - cb: Progress callback; nil behaves like Write
+//
+// Example:
+//
+//	err := ds.WriteWithProgress(values, func(written, total uint64) {
+//	    fmt.Printf("\r%3d%%", written*100/total)
+//	})
+func (dw *DatasetWriter) WriteWithProgress(data interface{}, cb func(bytesWritten, total uint64)) error {
+	if cb == nil {
+		return dw.Write(data)
+	}
+
+	var written uint64
+	return dw.write(data, func(n uint64) {
+		written += n
+		cb(written, dw.dataSize)
+	})
+}
+
+// writeContiguousProgress writes contiguous or compact data, in blocks when
+// progress is reported.
+func (dw *DatasetWriter) writeContiguousProgress(buf []byte, progress func(n uint64)) error {
+	if progress == nil || dw.isCompact || len(dw.external) > 0 {
+		if err := dw.writeContiguous(buf); err != nil {
+			return err
+		}
+		if progress != nil {
+			progress(uint64(len(buf)))
+		}
+		return nil
+	}
+
+	for off := 0; off < len(buf); off += progressBlockSize {
+		block := buf[off:min(off+progressBlockSize, len(buf))]
+		if err := dw.fileWriter.writer.WriteAtAddress(block, dw.dataAddress+uint64(off)); err != nil { //nolint:gosec // G115: off is non-negative
+			return err
+		}
+		progress(uint64(len(block)))
+	}
+	return nil
+}
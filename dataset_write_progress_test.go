@@ -0,0 +1,69 @@
+package hdf5
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatasetWriter_WriteWithProgress(t *testing.T) {
+	const n = 1_200_000 // 9.6 MB of float64: three contiguous blocks.
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = float64(i)
+	}
+
+	tests := []struct {
+		name  string
+		dims  []uint64
+		opts  []DatasetOption
+		calls int
+	}{
+		{"contiguous", []uint64{n}, nil, 3},
+		{"chunked gzip", []uint64{n}, []DatasetOption{WithChunkDims([]uint64{250_000}), WithGZIPCompression(1)}, 5},
+		{"compact", []uint64{16}, []DatasetOption{WithCompactLayout()}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), "progress.h5")
+			fw, err := CreateForWrite(filename, CreateTruncate)
+			require.NoError(t, err)
+			ds, err := fw.CreateDataset("/data", Float64, tt.dims, tt.opts...)
+			require.NoError(t, err)
+
+			data := values[:tt.dims[0]]
+			var reports [][2]uint64
+			require.NoError(t, ds.WriteWithProgress(data, func(written, total uint64) {
+				reports = append(reports, [2]uint64{written, total})
+			}))
+			require.NoError(t, fw.Close())
+
+			total := tt.dims[0] * 8
+			require.Len(t, reports, tt.calls)
+			for i, r := range reports {
+				require.Equal(t, total, r[1])
+				if i > 0 {
+					require.Greater(t, r[0], reports[i-1][0])
+				}
+			}
+			require.Equal(t, total, reports[len(reports)-1][0])
+
+			got := readDatasetFloat64(t, filename, "/data")
+			require.Equal(t, data, got)
+		})
+	}
+}
+
+func TestDatasetWriter_WriteWithProgress_NilCallback(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "progress_nil.h5")
+	fw, err := CreateForWrite(filename, CreateTruncate)
+	require.NoError(t, err)
+	ds, err := fw.CreateDataset("/data", Int32, []uint64{3})
+	require.NoError(t, err)
+	require.NoError(t, ds.WriteWithProgress([]int32{1, 2, 3}, nil))
+	require.NoError(t, fw.Close())
+
+	require.Equal(t, []float64{1, 2, 3}, readDatasetFloat64(t, filename, "/data"))
+}
@@ -0,0 +1,146 @@
+package hdf5
+
+import (
+	"encoding/binary"
+	"fmt"
+	"reflect"
+
+	"github.com/scigolib/hdf5/internal/core"
+)
+
+// WriteSlice overwrites a run of elements of a one-dimensional contiguous
+// dataset, starting at element start[0]. The number of elements written is
+// the length of data.
+//
+// For variable-length string datasets (data of type []string) the existing
+// global heap objects are updated in place: a new value that fits in the old
+// object's slot reuses it, while a longer value is stored as a new heap object
+// and the element's heap ID is rewritten. This works both for datasets created
+// in this session and for datasets reopened with OpenForWrite + OpenDataset.
+//
+// Other datatypes accept the same Go types as Write.
+//
+// Parameters:
+//   - start: Starting element (one value, for the single dimension)
+//   - data: Values to write ([]string for vlen strings)
+//
+// Returns:
+//   - error: If the dataset is not 1-D and contiguous, the slice is out of
+//     bounds, or the data cannot be encoded
+//
+// Example:
+//
+//	fw, _ := hdf5.OpenForWrite("names.h5", hdf5.OpenReadWrite)
+//	ds, _ := fw.OpenDataset("/names")
+//	err := ds.WriteSlice([]uint64{3}, []string{"replacement"})
+func (dw *DatasetWriter) WriteSlice(start []uint64, data interface{}) error {
+	if len(dw.dims) != 1 || len(start) != 1 {
+		return fmt.Errorf("WriteSlice supports 1-D datasets only (dataset rank %d, start rank %d)",
+			len(dw.dims), len(start))
+	}
+	contiguous, err := dw.isContiguous()
+	if err != nil {
+		return err
+	}
+	if !contiguous {
+		return fmt.Errorf("WriteSlice supports contiguous datasets only")
+	}
+	if dw.dataAddress == 0 || dw.dataAddress == undefinedAddress {
+		return fmt.Errorf("dataset %q has no allocated storage", dw.name)
+	}
+
+	value := reflect.ValueOf(data)
+	if value.Kind() != reflect.Slice {
+		return fmt.Errorf("expected a slice, got %T", data)
+	}
+	count := uint64(value.Len()) //nolint:gosec // G115: slice length is non-negative
+	if start[0] > dw.dims[0] || count > dw.dims[0]-start[0] {
+		return fmt.Errorf("slice [%d, %d) out of bounds for dimension size %d",
+			start[0], start[0]+count, dw.dims[0])
+	}
+	if count == 0 {
+		return nil
+	}
+
+	elemSize := uint64(dw.dtype.Size)
+	address := dw.dataAddress + start[0]*elemSize
+
+	if dw.dtype.Class == core.DatatypeVarLen {
+		strs, ok := data.([]string)
+		if !ok {
+			return fmt.Errorf("WriteSlice supports []string for variable-length datasets, got %T", data)
+		}
+		return dw.writeVLenStringSlice(address, strs)
+	}
+
+	buf, err := dw.encodeData(data, count*elemSize)
+	if err != nil {
+		return err
+	}
+
+	if err := dw.fileWriter.writer.WriteAtAddress(buf, address); err != nil {
+		return fmt.Errorf("failed to write data: %w", err)
+	}
+	return nil
+}
+
+// writeVLenStringSlice updates the heap objects of consecutive vlen string
+// elements starting at the file address of the first element's heap ID.
+func (dw *DatasetWriter) writeVLenStringSlice(address uint64, strs []string) error {
+	ghw := dw.fileWriter.globalHeapWriter
+	elemSize := uint64(dw.dtype.Size)
+	offsetSize := int(dw.fileWriter.file.Superblock().OffsetSize)
+
+	ids := make([]byte, uint64(len(strs))*elemSize)
+	//nolint:gosec // G115: HDF5 addresses fit in int64 for io.ReaderAt interface
+	if _, err := dw.fileWriter.writer.Reader().ReadAt(ids, int64(address)); err != nil {
+		return fmt.Errorf("failed to read heap IDs: %w", err)
+	}
+
+	for i, str := range strs {
+		raw := ids[uint64(i)*elemSize:]
+		ref, err := core.ParseGlobalHeapReference(raw[4:], offsetSize)
+		if err != nil {
+			return fmt.Errorf("element %d: %w", i, err)
+		}
+		old := HeapID{
+			CollectionAddress: ref.HeapAddress,
+			ObjectIndex:       uint16(ref.ObjectIndex), //nolint:gosec // G115: object indices are 16-bit on disk
+			SeqLen:            binary.LittleEndian.Uint32(raw),
+		}
+
+		id, err := ghw.UpdateObject(old, []byte(str))
+		if err != nil {
+			return fmt.Errorf("update string %d in heap: %w", i, err)
+		}
+		id.SeqLen = uint32(len(str)) //nolint:gosec // G115: string length fits in uint32
+		copy(raw[:elemSize], id.Encode())
+	}
+
+	if err := dw.fileWriter.writer.WriteAtAddress(ids, address); err != nil {
+		return fmt.Errorf("failed to write heap IDs: %w", err)
+	}
+	return nil
+}
+
+// isContiguous reports whether the dataset uses contiguous storage. Datasets
+// reopened with OpenDataset are checked against their layout message.
+func (dw *DatasetWriter) isContiguous() (bool, error) {
+	if dw.isChunked || dw.isCompact {
+		return false, nil
+	}
+	if dw.objectHeader == nil {
+		return true, nil
+	}
+	for _, msg := range dw.objectHeader.Messages {
+		if msg.Type != core.MsgDataLayout {
+			continue
+		}
+		layout, err := core.ParseDataLayoutMessage(msg.Data, dw.fileWriter.file.Superblock())
+		if err != nil {
+			return false, fmt.Errorf("failed to parse layout: %w", err)
+		}
+		return layout.IsContiguous(), nil
+	}
+	return false, fmt.Errorf("dataset %q has no layout message", dw.name)
+}
@@ -0,0 +1,110 @@
+package hdf5
+
+import (
+	"encoding/binary"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// readHeapID returns the heap ID stored for element i of a vlen dataset.
+func readHeapID(t *testing.T, dw *DatasetWriter, i uint64) HeapID {
+	t.Helper()
+	raw := make([]byte, 16)
+	//nolint:gosec // G115: test addresses fit in int64
+	_, err := dw.fileWriter.writer.Reader().ReadAt(raw, int64(dw.dataAddress+i*16))
+	require.NoError(t, err)
+	return HeapID{
+		SeqLen:            binary.LittleEndian.Uint32(raw[0:4]),
+		CollectionAddress: binary.LittleEndian.Uint64(raw[4:12]),
+		ObjectIndex:       uint16(binary.LittleEndian.Uint32(raw[12:16])),
+	}
+}
+
+func TestWriteSlice_VLenStrings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vlen_rmw.h5")
+	fw, err := CreateForWrite(path, CreateTruncate)
+	require.NoError(t, err)
+	dw, err := fw.CreateDataset("/names", VLenString, []uint64{4})
+	require.NoError(t, err)
+	require.NoError(t, dw.Write([]string{"alpha", "bravo-bravo", "charlie", "delta"}))
+	ints, err := fw.CreateDataset("/ints", Int32, []uint64{5})
+	require.NoError(t, err)
+	require.NoError(t, ints.Write([]int32{1, 2, 3, 4, 5}))
+	require.NoError(t, fw.Close())
+
+	fw, err = OpenForWrite(path, OpenReadWrite)
+	require.NoError(t, err)
+	dw, err = fw.OpenDataset("/names")
+	require.NoError(t, err)
+
+	// Shrinking reuses the heap object.
+	before := readHeapID(t, dw, 1)
+	require.NoError(t, dw.WriteSlice([]uint64{1}, []string{"b"}))
+	after := readHeapID(t, dw, 1)
+	require.Equal(t, before.CollectionAddress, after.CollectionAddress)
+	require.Equal(t, before.ObjectIndex, after.ObjectIndex)
+	require.Equal(t, uint32(1), after.SeqLen)
+
+	// Growing appends a new object and rewrites the heap ID.
+	long := strings.Repeat("x", 200)
+	before = readHeapID(t, dw, 2)
+	require.NoError(t, dw.WriteSlice([]uint64{2}, []string{long, "d"}))
+	after = readHeapID(t, dw, 2)
+	require.NotEqual(t, before, after)
+	require.Equal(t, uint32(200), after.SeqLen)
+
+	ints, err = fw.OpenDataset("/ints")
+	require.NoError(t, err)
+	require.NoError(t, ints.WriteSlice([]uint64{3}, []int32{40, 50}))
+	require.ErrorContains(t, ints.WriteSlice([]uint64{4}, []int32{1, 2}), "out of bounds")
+	require.NoError(t, fw.Close())
+
+	f, err := Open(path)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	got, err := findDatasetByPath(t, f, "/names").ReadStrings()
+	require.NoError(t, err)
+	require.Equal(t, []string{"alpha", "b", long, "d"}, got)
+
+	values, err := findDatasetByPath(t, f, "/ints").Read()
+	require.NoError(t, err)
+	require.Equal(t, []float64{1, 2, 3, 40, 50}, values)
+}
+
+// Heap objects still held in the unflushed collection are updated in memory.
+func TestWriteSlice_VLenSameSession(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vlen_session.h5")
+	fw, err := CreateForWrite(path, CreateTruncate)
+	require.NoError(t, err)
+	dw, err := fw.CreateDataset("/names", VLenString, []uint64{3})
+	require.NoError(t, err)
+	require.NoError(t, dw.Write([]string{"one", "two", "three"}))
+	require.NoError(t, dw.WriteSlice([]uint64{0}, []string{"uno", "a much longer second value"}))
+	require.NoError(t, fw.Close())
+
+	f, err := Open(path)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+	got, err := findDatasetByPath(t, f, "/names").ReadStrings()
+	require.NoError(t, err)
+	require.Equal(t, []string{"uno", "a much longer second value", "three"}, got)
+}
+
+func TestWriteSlice_Rejects(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "slice_reject.h5")
+	fw, err := CreateForWrite(path, CreateTruncate)
+	require.NoError(t, err)
+	defer func() { _ = fw.Close() }()
+
+	matrix, err := fw.CreateDataset("/matrix", Float64, []uint64{2, 2})
+	require.NoError(t, err)
+	require.ErrorContains(t, matrix.WriteSlice([]uint64{0, 0}, []float64{1}), "1-D")
+
+	chunked, err := fw.CreateDataset("/chunked", Float64, []uint64{8}, WithChunkDims([]uint64{4}))
+	require.NoError(t, err)
+	require.ErrorContains(t, chunked.WriteSlice([]uint64{0}, []float64{1}), "contiguous")
+}
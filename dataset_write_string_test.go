@@ -444,7 +444,7 @@ func TestEncodeStringData(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			buf, err := encodeStringData(tt.data, tt.elemSize, tt.expectedSize)
+			buf, err := encodeStringData(tt.data, tt.elemSize, core.StringPadNullTerm, tt.expectedSize)
 			if tt.wantErr {
 				require.Error(t, err)
 				if tt.errContains != "" {
@@ -460,7 +460,7 @@ func TestEncodeStringData(t *testing.T) {
 
 // TestEncodeStringData_NullPadding verifies that short strings are null-padded.
 func TestEncodeStringData_NullPadding(t *testing.T) {
-	buf, err := encodeStringData([]string{"hi"}, 5, 5)
+	buf, err := encodeStringData([]string{"hi"}, 5, core.StringPadNullTerm, 5)
 	require.NoError(t, err)
 	require.Len(t, buf, 5)
 
@@ -472,6 +472,71 @@ func TestEncodeStringData_NullPadding(t *testing.T) {
 	assert.Equal(t, byte(0), buf[4])
 }
 
+func TestEncodeStringData_Padding(t *testing.T) {
+	data := []string{"hi", "hello"}
+	tests := []struct {
+		padding uint8
+		want    string
+	}{
+		{core.StringPadNullTerm, "hi\x00\x00\x00hello"},
+		{core.StringPadNullPad, "hi\x00\x00\x00hello"},
+		{core.StringPadSpacePad, "hi   hello"},
+	}
+	for _, tt := range tests {
+		buf, err := encodeStringData(data, 5, tt.padding, 10)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, string(buf), "padding %d", tt.padding)
+	}
+}
+
+func TestWithStringPadding_RoundTrip(t *testing.T) {
+	values := []string{"x", "fortran", "exactly8"}
+	tests := []struct {
+		name    string
+		padding StringPadding
+		want    []string
+	}{
+		{"null-terminated", StringNullTerm, values},
+		{"null-padded", StringNullPad, values},
+		{"space-padded", StringSpacePad, values},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "padding.h5")
+			fw, err := CreateForWrite(path, CreateTruncate)
+			require.NoError(t, err)
+			opts := []DatasetOption{WithStringSize(8), WithStringPadding(tt.padding)}
+			ds, err := fw.CreateDataset("/contiguous", String, []uint64{3}, opts...)
+			require.NoError(t, err)
+			require.NoError(t, ds.Write(values))
+			ds, err = fw.CreateDataset("/chunked", String, []uint64{3}, append(opts, WithChunkDims([]uint64{2}))...)
+			require.NoError(t, err)
+			require.NoError(t, ds.Write(values))
+			require.NoError(t, fw.Close())
+
+			f, err := Open(path)
+			require.NoError(t, err)
+			defer func() { _ = f.Close() }()
+			for _, name := range []string{"/contiguous", "/chunked"} {
+				dset := findDatasetByPath(t, f, name)
+				got, err := dset.ReadStrings()
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got, name)
+
+				dtype, err := dset.Dtype()
+				require.NoError(t, err)
+				assert.Equal(t, uint8(tt.padding), dtype.GetStringPadding(), name)
+			}
+		})
+	}
+
+	fw, err := CreateForWrite(filepath.Join(t.TempDir(), "bad.h5"), CreateTruncate)
+	require.NoError(t, err)
+	defer func() { _ = fw.Close() }()
+	_, err = fw.CreateDataset("/bad", String, []uint64{1}, WithStringSize(4), WithStringPadding(7))
+	require.ErrorContains(t, err, "invalid string padding")
+}
+
 // TestWithStringSize tests the WithStringSize option function.
 func TestWithStringSize(t *testing.T) {
 	cfg := &datasetConfig{}
@@ -35,6 +35,40 @@ func TestCreateForWrite(t *testing.T) {
 	assert.Equal(t, uint8(2), f.SuperblockVersion())
 }
 
+func TestFileWriter_Abort(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	t.Run("created file is removed", func(t *testing.T) {
+		filename := filepath.Join(tmpDir, "aborted.h5")
+		fw, err := CreateForWrite(filename, CreateTruncate)
+		require.NoError(t, err)
+		ds, err := fw.CreateDataset("/data", Float64, []uint64{3})
+		require.NoError(t, err)
+		require.NoError(t, ds.Write([]float64{1, 2, 3}))
+
+		require.NoError(t, fw.Abort())
+		_, err = os.Stat(filename)
+		require.True(t, os.IsNotExist(err), "aborted file still exists: %v", err)
+
+		// The handle is finished: further Abort and Close calls do nothing.
+		require.NoError(t, fw.Abort())
+		require.NoError(t, fw.Close())
+	})
+
+	t.Run("opened file is kept", func(t *testing.T) {
+		filename := filepath.Join(tmpDir, "existing.h5")
+		fw, err := CreateForWrite(filename, CreateTruncate)
+		require.NoError(t, err)
+		require.NoError(t, fw.Close())
+
+		fw, err = OpenForWrite(filename, OpenReadWrite)
+		require.NoError(t, err)
+		require.NoError(t, fw.Abort())
+		_, err = os.Stat(filename)
+		require.NoError(t, err)
+	})
+}
+
 func TestCreateDataset_1D_Int32(t *testing.T) {
 	tmpDir := t.TempDir()
 	filename := filepath.Join(tmpDir, "test_dataset_1d_int32.h5")
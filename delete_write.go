@@ -86,7 +86,7 @@ func (fw *FileWriter) Delete(path string) error {
 	if newRefCount > 0 {
 		// Object still has other hard links pointing to it.
 		// Rewrite the object header with decremented refcount (no cascade delete).
-		if err := fw.writeRefCount(objectAddr, oh, sb); err != nil {
+		if err := writeObjectHeaderWithRefCount(fw, objectAddr, oh); err != nil {
 			return fmt.Errorf("delete %q: failed to update reference count: %w", path, err)
 		}
 		return nil
@@ -103,37 +103,153 @@ func (fw *FileWriter) Delete(path string) error {
 	return nil
 }
 
-// writeRefCount rewrites the object header with an updated reference count.
-// For V2 headers, this adds/updates a RefCount message.
-// For V1 headers, the refcount is part of the header prefix (not rewritten in MVP).
-func (fw *FileWriter) writeRefCount(addr uint64, oh *core.ObjectHeader, sb *core.Superblock) error {
-	if oh.Version != 2 {
-		// V1: RefCount is in the header prefix. The ObjectHeaderWriter handles this.
-		// We don't support rewriting V1 headers at this time (they are legacy format).
-		// V1 headers default to refcount=1 and are only used for backward compatibility.
-		return nil
+// DeleteGroup removes a group from the HDF5 file.
+//
+// Without recursive, the group must be empty; a non-empty group is rejected
+// before anything is modified. With recursive, every link in the group is
+// removed first (subgroups depth-first, datasets and links via Delete), then
+// the group itself is unlinked from its parent and its storage is freed.
+//
+// If other hard links still reference the group, only the link named by path
+// is removed and the group's contents are left in place, matching H5Ldelete.
+//
+// Parameters:
+//   - path: Absolute path to the group (e.g., "/results/run1")
+//   - recursive: Delete the group's contents first instead of rejecting a non-empty group
+//
+// Returns:
+//   - error: If the path is not a group, the group is non-empty and recursive
+//     is false, or deletion fails
+//
+// Example:
+//
+//	fw, _ := hdf5.OpenForWrite("data.h5", hdf5.OpenReadWrite)
+//	defer fw.Close()
+//	fw.DeleteGroup("/empty", false)      // Remove an empty group
+//	fw.DeleteGroup("/old_results", true) // Remove a group and everything in it
+//
+// Reference: H5Ldelete.c, H5G_obj_remove(), H5O_delete().
+func (fw *FileWriter) DeleteGroup(path string, recursive bool) error {
+	if path == "" {
+		return fmt.Errorf("delete group: path cannot be empty")
+	}
+	if !strings.HasPrefix(path, "/") {
+		return fmt.Errorf("delete group: path must start with '/' (got %q)", path)
+	}
+	path = strings.TrimSuffix(path, "/")
+	if path == "" {
+		return fmt.Errorf("delete group: cannot delete root group")
+	}
+
+	addr, err := fw.resolveObjectAddress(path)
+	if err != nil {
+		return fmt.Errorf("delete group %q: %w", path, err)
+	}
+
+	sb := fw.file.Superblock()
+	oh, err := core.ReadObjectHeader(fw.writer.Reader(), addr, sb)
+	if err != nil {
+		return fmt.Errorf("delete group %q: failed to read object header: %w", path, err)
+	}
+	if !isGroupHeader(oh) {
+		return fmt.Errorf("delete group %q: object is not a group", path)
+	}
+
+	links, err := fw.groupLinks(addr, oh)
+	if err != nil {
+		return fmt.Errorf("delete group %q: failed to list links: %w", path, err)
 	}
 
-	// V2: Update or add RefCount message (type 0x0016).
-	refCountData := make([]byte, 4)
-	sb.Endianness.PutUint32(refCountData, oh.ReferenceCount)
+	if len(links) > 0 {
+		if !recursive {
+			return fmt.Errorf("delete group %q: group is not empty (has %d links); use recursive deletion", path, len(links))
+		}
 
-	found := false
+		// Only empty the group when this is its last hard link; otherwise the
+		// contents remain reachable through the other links.
+		if oh.GetReferenceCount() <= 1 {
+			fw.trackGroup(path, addr, oh)
+			for _, link := range links {
+				if err := fw.deleteGroupMember(path+"/"+link.name, link.addr, sb); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return fw.Delete(path)
+}
+
+// deleteGroupMember removes one link of a group being deleted recursively.
+// Subgroups that are only reachable through this link are emptied first.
+func (fw *FileWriter) deleteGroupMember(path string, addr uint64, sb *core.Superblock) error {
+	if addr != undefinedAddress && addr != 0 {
+		oh, err := core.ReadObjectHeader(fw.writer.Reader(), addr, sb)
+		if err == nil && isGroupHeader(oh) {
+			return fw.DeleteGroup(path, true)
+		}
+	}
+	return fw.Delete(path)
+}
+
+// groupLinks lists every link of a group, including soft and external links
+// (reported with an undefined address). Use groupChildren for hard links only.
+func (fw *FileWriter) groupLinks(addr uint64, oh *core.ObjectHeader) ([]groupChild, error) {
 	for _, msg := range oh.Messages {
-		if msg.Type == core.MsgRefCount {
-			copy(msg.Data, refCountData)
-			found = true
-			break
+		if msg.Type != core.MsgLinkInfo {
+			continue
+		}
+		links, err := fw.readModernGroupLinks(oh)
+		if err != nil {
+			return nil, err
+		}
+		result := make([]groupChild, 0, len(links))
+		for _, link := range links {
+			childAddr := undefinedAddress
+			if link.IsHardLink() {
+				childAddr = link.ObjectAddress
+			}
+			result = append(result, groupChild{name: link.Name, addr: childAddr})
 		}
+		return result, nil
+	}
+	return fw.groupChildren(addr, oh)
+}
+
+// trackGroup registers metadata for a group that was not created in this
+// session (e.g., in a file opened with OpenForWrite), so its links can be
+// removed through unlinkFromParent.
+func (fw *FileWriter) trackGroup(path string, addr uint64, oh *core.ObjectHeader) {
+	if _, exists := fw.groups[path]; exists {
+		return
 	}
-	if !found {
-		// Add a RefCount message if one doesn't exist yet.
-		if err := core.AddMessageToObjectHeader(oh, core.MsgRefCount, refCountData); err != nil {
-			return fmt.Errorf("add refcount message: %w", err)
+
+	meta := &GroupMetadata{headerAddr: addr}
+	sb := fw.file.sb
+	osSize := int(sb.OffsetSize)
+	for _, msg := range oh.Messages {
+		switch msg.Type {
+		case core.MsgLinkInfo:
+			meta.modern = true
+		case core.MsgSymbolTable:
+			if len(msg.Data) >= 2*osSize {
+				meta.btreeAddr = readAddrFromBuf(msg.Data, osSize, sb.Endianness)
+				meta.heapAddr = readAddrFromBuf(msg.Data[osSize:], osSize, sb.Endianness)
+			}
 		}
 	}
+	fw.groups[path] = meta
+}
 
-	return core.WriteObjectHeader(fw.writer, addr, oh, sb)
+// isGroupHeader reports whether an object header describes a group, either
+// old-style (symbol table message) or new-style (link info message).
+func isGroupHeader(oh *core.ObjectHeader) bool {
+	for _, msg := range oh.Messages {
+		if msg.Type == core.MsgSymbolTable || msg.Type == core.MsgLinkInfo {
+			return true
+		}
+	}
+	return false
 }
 
 // cascadeDelete frees all storage associated with an object whose refcount has
@@ -162,6 +278,17 @@ func (fw *FileWriter) cascadeDelete(objectAddr uint64, oh *core.ObjectHeader, sb
 			if err := fw.freeGroupStructures(msg.Data, sb, allocator); err != nil {
 				return err
 			}
+
+		case core.MsgLinkInfo:
+			// Link-info group — reject non-empty groups. Empty dense storage
+			// (fractal heap + name index) is left as dead space.
+			links, err := fw.readModernGroupLinks(oh)
+			if err != nil {
+				return err
+			}
+			if len(links) > 0 {
+				return fmt.Errorf("cannot delete non-empty group (has %d children); delete children first", len(links))
+			}
 		}
 	}
 
@@ -478,3 +478,150 @@ func TestDelete_H5dump(t *testing.T) {
 	// For CI this is covered by the existing h5dump validation in the test suite.
 	// The file is created and should be readable by h5dump.
 }
+
+// ---------------------------------------------------------------------------
+// fw.DeleteGroup()
+// ---------------------------------------------------------------------------
+
+// walkPaths returns every path reported by Walk, excluding the root group.
+func walkPaths(t *testing.T, file string) []string {
+	t.Helper()
+
+	f, err := hdf5.Open(file)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	var paths []string
+	f.Walk(func(path string, _ hdf5.Object) {
+		if path != "/" {
+			paths = append(paths, path)
+		}
+	})
+	return paths
+}
+
+// populateDeleteGroupTree creates /keep plus a nested /a tree with datasets,
+// a subgroup, and a soft link.
+func populateDeleteGroupTree(t *testing.T, fw *hdf5.FileWriter) {
+	t.Helper()
+
+	keep, err := fw.CreateDataset("/keep", hdf5.Int32, []uint64{2})
+	require.NoError(t, err)
+	require.NoError(t, keep.Write([]int32{7, 8}))
+
+	_, err = fw.CreateGroup("/a")
+	require.NoError(t, err)
+	_, err = fw.CreateGroup("/a/b")
+	require.NoError(t, err)
+
+	for _, name := range []string{"/a/ds", "/a/b/ds"} {
+		ds, err := fw.CreateDataset(name, hdf5.Float64, []uint64{3})
+		require.NoError(t, err)
+		require.NoError(t, ds.Write([]float64{1, 2, 3}))
+	}
+
+	require.NoError(t, fw.CreateSoftLink("/a/alias", "/keep"))
+}
+
+func TestDeleteGroup_Recursive(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []interface{}
+	}{
+		{"symbol table groups", nil},
+		{"link info groups", []interface{}{hdf5.WithModernGroups()}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "delete_group_recursive.h5")
+
+			fw, err := hdf5.CreateForWrite(file, hdf5.CreateTruncate, tt.opts...)
+			require.NoError(t, err)
+			populateDeleteGroupTree(t, fw)
+
+			require.NoError(t, fw.DeleteGroup("/a", true))
+			require.NoError(t, fw.Close())
+
+			assert.Equal(t, []string{"/keep"}, walkPaths(t, file))
+		})
+	}
+}
+
+func TestDeleteGroup_NonRecursiveNonEmpty(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "delete_group_nonempty.h5")
+
+	fw, err := hdf5.CreateForWrite(file, hdf5.CreateTruncate)
+	require.NoError(t, err)
+	populateDeleteGroupTree(t, fw)
+
+	err = fw.DeleteGroup("/a", false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not empty")
+
+	// The failed call must leave the group and its contents intact.
+	require.NoError(t, fw.Close())
+	paths := walkPaths(t, file)
+	assert.Contains(t, paths, "/a/")
+	assert.Contains(t, paths, "/a/b/ds")
+	assert.Contains(t, paths, "/a/ds")
+}
+
+func TestDeleteGroup_Empty(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "delete_group_empty.h5")
+
+	fw, err := hdf5.CreateForWrite(file, hdf5.CreateTruncate)
+	require.NoError(t, err)
+
+	_, err = fw.CreateGroup("/empty")
+	require.NoError(t, err)
+	require.NoError(t, fw.DeleteGroup("/empty", false))
+	require.NoError(t, fw.Close())
+
+	assert.Empty(t, walkPaths(t, file))
+}
+
+func TestDeleteGroup_ReopenedFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "delete_group_reopen.h5")
+
+	fw, err := hdf5.CreateForWrite(file, hdf5.CreateTruncate)
+	require.NoError(t, err)
+	populateDeleteGroupTree(t, fw)
+	require.NoError(t, fw.Close())
+
+	fw, err = hdf5.OpenForWrite(file, hdf5.OpenReadWrite)
+	require.NoError(t, err)
+	require.NoError(t, fw.DeleteGroup("/a", true))
+	require.NoError(t, fw.Close())
+
+	assert.Equal(t, []string{"/keep"}, walkPaths(t, file))
+}
+
+func TestDeleteGroup_ErrorCases(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "delete_group_errors.h5")
+
+	fw, err := hdf5.CreateForWrite(file, hdf5.CreateTruncate)
+	require.NoError(t, err)
+	defer func() { _ = fw.Close() }()
+	populateDeleteGroupTree(t, fw)
+
+	tests := []struct {
+		name    string
+		path    string
+		wantErr string
+	}{
+		{"empty path", "", "cannot be empty"},
+		{"relative path", "a", "must start with"},
+		{"root group", "/", "root group"},
+		{"missing group", "/missing", "not found"},
+		{"dataset", "/keep", "not a group"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := fw.DeleteGroup(tt.path, true)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package hdf5
+
+// WithDeterministicLayout makes the file a function of the calls made on the
+// FileWriter alone, so that writing the same objects with the same options
+// twice produces byte-identical files. Use it for reproducible outputs, e.g.
+// content-addressed storage or files compared against golden copies.
+//
+// Files are laid out in the order objects are created and written, and
+// inputs without an order are processed sorted (the links passed to
+// CreateDenseGroup, attributes passed as maps), with or without this option.
+// Chunks filtered in parallel (WithParallelChunkWrites) are stored in chunk
+// order. This option disables what depends on the clock: object times are
+// not stored, overriding WithTrackTimes.
+//
+// Default: off
+//
+// Example:
+//
+//	fw, err := hdf5.CreateForWrite("results.h5", hdf5.CreateTruncate,
+//	    hdf5.WithDeterministicLayout())
+func WithDeterministicLayout() WriteOption {
+	return func(cfg *FileWriteConfig) {
+		cfg.DeterministicLayout = true
+	}
+}
+
+// applyDeterministicLayout adjusts the configuration for deterministic
+// output, once all options are applied.
+func applyDeterministicLayout(cfg *FileWriteConfig) {
+	if cfg.DeterministicLayout {
+		cfg.TrackTimes = false
+	}
+}
@@ -0,0 +1,63 @@
+package hdf5
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// writeDeterministicFile writes groups, dense link storage, dense
+// attributes and chunks filtered in parallel, with object times requested.
+func writeDeterministicFile(t *testing.T, path string) []byte {
+	t.Helper()
+	fw, err := CreateForWrite(path, CreateTruncate, WithDeterministicLayout(), WithTrackTimes(true))
+	require.NoError(t, err)
+
+	links := make(map[string]string)
+	for g := 0; g < 12; g++ {
+		name := fmt.Sprintf("/run%02d", g)
+		_, err := fw.CreateGroup(name)
+		require.NoError(t, err)
+
+		ds, err := fw.CreateDataset(name+"/samples", Float64, []uint64{64, 64},
+			WithChunkDims([]uint64{16, 16}),
+			WithGZIPCompression(5),
+			WithParallelChunkWrites(4))
+		require.NoError(t, err)
+		values := make([]float64, 64*64)
+		for i := range values {
+			values[i] = float64(g*i%97) / 7
+		}
+		require.NoError(t, ds.Write(values))
+		for a := 0; a < 12; a++ {
+			require.NoError(t, ds.WriteAttribute(fmt.Sprintf("param%02d", a), fmt.Sprint(a*g)))
+		}
+		links[fmt.Sprintf("link%02d", g)] = name + "/samples"
+	}
+	require.NoError(t, fw.CreateDenseGroup("/index", links))
+	require.NoError(t, fw.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return data
+}
+
+// TestDeterministicLayout writes the same objects twice and checks that the
+// files are byte-identical.
+func TestDeterministicLayout(t *testing.T) {
+	dir := t.TempDir()
+	first := writeDeterministicFile(t, filepath.Join(dir, "first.h5"))
+	second := writeDeterministicFile(t, filepath.Join(dir, "second.h5"))
+	require.Equal(t, len(first), len(second))
+	require.True(t, bytes.Equal(first, second), "files differ")
+
+	f, err := Open(filepath.Join(dir, "first.h5"))
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+	_, tracked := findDatasetByPath(t, f, "/run03/samples").ModTime()
+	require.False(t, tracked, "object times must not be stored")
+}
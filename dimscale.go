@@ -0,0 +1,162 @@
+package hdf5
+
+import (
+	"fmt"
+
+	"github.com/scigolib/hdf5/internal/core"
+)
+
+// Dimension scale attribute names and values.
+//
+// Reference: HDF5 Dimension Scale Specification, H5DS.c.
+const (
+	dimScaleClassAttr   = "CLASS"
+	dimScaleClassValue  = "DIMENSION_SCALE"
+	dimScaleNameAttr    = "NAME"
+	dimensionListAttr   = "DIMENSION_LIST"
+	dimScaleRefListAttr = "REFERENCE_LIST"
+)
+
+// DimScale is a dimension scale attached to one dimension of a dataset.
+//
+// Dimension scales are how NetCDF-4 and xarray store coordinate variables: the
+// scale dataset carries CLASS="DIMENSION_SCALE" and an optional NAME, and the
+// dataset it labels lists the scales of each dimension in its DIMENSION_LIST
+// attribute as object references.
+type DimScale struct {
+	Dim     int      // Dimension of the labelled dataset the scale is attached to
+	Name    string   // Value of the scale's NAME attribute ("" if not set)
+	Path    string   // Absolute path of the scale dataset
+	Dataset *Dataset // The scale dataset
+}
+
+// DimensionScales returns the dimension scales attached to the dataset, ordered
+// by dimension. A dimension may have several scales or none; a dataset without
+// a DIMENSION_LIST attribute has no scales and returns an empty slice.
+//
+// Returns:
+//   - []DimScale: Attached scales, one entry per (dimension, scale) pair
+//   - error: If DIMENSION_LIST cannot be read or references an unknown object
+//
+// Example:
+//
+//	scales, err := ds.DimensionScales()
+//	for _, s := range scales {
+//	    coords, _ := s.Dataset.Read()
+//	    fmt.Printf("dim %d: %s %v\n", s.Dim, s.Name, coords)
+//	}
+//
+// Reference: H5DS.c - H5DSiterate_scales(), H5DSget_scale_name().
+func (d *Dataset) DimensionScales() ([]DimScale, error) {
+	attrs, err := d.Attributes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read attributes: %w", err)
+	}
+
+	var dimList *core.Attribute
+	for _, attr := range attrs {
+		if attr.Name == dimensionListAttr {
+			dimList = attr
+			break
+		}
+	}
+	if dimList == nil {
+		return []DimScale{}, nil
+	}
+
+	sequences, err := dimList.ReadVarLenSequences()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", dimensionListAttr, err)
+	}
+
+	base, err := core.ParseDatatypeMessage(dimList.Datatype.Properties)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s base type: %w", dimensionListAttr, err)
+	}
+	refSize := int(d.file.sb.OffsetSize)
+	if base.Class != core.DatatypeReference || int(base.Size) != refSize {
+		return nil, fmt.Errorf("%s: unsupported reference type (class %d, size %d)",
+			dimensionListAttr, base.Class, base.Size)
+	}
+
+	paths := d.file.objectPaths()
+	scales := make([]DimScale, 0, len(sequences))
+	for dim, seq := range sequences {
+		for off := 0; off+refSize <= len(seq); off += refSize {
+			addr := readAddrFromBuf(seq[off:], refSize, d.file.sb.Endianness)
+			path, ok := paths[addr]
+			if !ok {
+				return nil, fmt.Errorf("%s: dimension %d references unknown object at 0x%X",
+					dimensionListAttr, dim, addr)
+			}
+
+			_, name := parsePath(path)
+			scale := &Dataset{file: d.file, name: name, path: path, address: addr}
+			scales = append(scales, DimScale{
+				Dim:     dim,
+				Name:    scale.dimScaleName(),
+				Path:    path,
+				Dataset: scale,
+			})
+		}
+	}
+
+	return scales, nil
+}
+
+// IsDimensionScale reports whether the dataset is a dimension scale, i.e. has a
+// CLASS attribute with the value "DIMENSION_SCALE".
+//
+// Reference: H5DS.c - H5DSis_scale().
+func (d *Dataset) IsDimensionScale() (bool, error) {
+	attrs, err := d.Attributes()
+	if err != nil {
+		return false, fmt.Errorf("failed to read attributes: %w", err)
+	}
+	for _, attr := range attrs {
+		if attr.Name != dimScaleClassAttr {
+			continue
+		}
+		value, err := attr.ReadValue()
+		if err != nil {
+			return false, nil //nolint:nilerr // A CLASS attribute that is not a string is not a scale marker
+		}
+		class, _ := value.(string)
+		return class == dimScaleClassValue, nil
+	}
+	return false, nil
+}
+
+// dimScaleName returns the NAME attribute of a scale dataset, or "" if unset.
+func (d *Dataset) dimScaleName() string {
+	value, err := d.ReadAttribute(dimScaleNameAttr)
+	if err != nil {
+		return ""
+	}
+	name, _ := value.(string)
+	return name
+}
+
+// objectPaths maps object header addresses to absolute paths. Objects reachable
+// through several hard links are reported under the first path visited.
+func (f *File) objectPaths() map[uint64]string {
+	paths := make(map[uint64]string)
+	f.Walk(func(path string, obj Object) {
+		var addr uint64
+		switch o := obj.(type) {
+		case *Dataset:
+			addr = o.address
+		case *Group:
+			addr = o.address
+			if path != "/" {
+				path = path[:len(path)-1] // Walk reports groups with a trailing "/"
+			}
+		default:
+			return
+		}
+		if _, seen := paths[addr]; !seen && addr != 0 {
+			paths[addr] = path
+		}
+	})
+	return paths
+}
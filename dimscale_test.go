@@ -0,0 +1,85 @@
+package hdf5_test
+
+import (
+	"testing"
+
+	"github.com/scigolib/hdf5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// datasetsByPath opens a file and returns its datasets keyed by path.
+func datasetsByPath(t *testing.T, file string) map[string]*hdf5.Dataset {
+	t.Helper()
+
+	f, err := hdf5.Open(file)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = f.Close() })
+
+	datasets := make(map[string]*hdf5.Dataset)
+	f.Walk(func(path string, obj hdf5.Object) {
+		if ds, ok := obj.(*hdf5.Dataset); ok {
+			datasets[path] = ds
+		}
+	})
+	return datasets
+}
+
+// TestDimensionScales_HDF5Tests reads the dimension scale files produced by the
+// HDF5 high-level library tests (test_ds.c), in both byte orders.
+func TestDimensionScales_HDF5Tests(t *testing.T) {
+	for _, file := range []string{
+		"testdata/hdf5_official/test_ds_le.h5",
+		"testdata/hdf5_official/test_ds_be.h5",
+	} {
+		t.Run(file, func(t *testing.T) {
+			datasets := datasetsByPath(t, file)
+
+			for _, path := range []string{"/dset_al", "/dset_al2"} {
+				scales, err := datasets[path].DimensionScales()
+				require.NoError(t, err)
+				require.Len(t, scales, 4)
+
+				for dim, s := range scales {
+					want := []string{"/ds_1_al", "/ds_2_al", "/ds_3_al", "/ds_4_al"}[dim]
+					assert.Equal(t, dim, s.Dim)
+					assert.Equal(t, want, s.Path)
+					assert.Equal(t, want, s.Name)
+					assert.Equal(t, datasets[want].Address(), s.Dataset.Address())
+
+					isScale, err := s.Dataset.IsDimensionScale()
+					require.NoError(t, err)
+					assert.True(t, isScale)
+				}
+			}
+
+			// A scale itself has no scales attached and is not labelled data.
+			scales, err := datasets["/ds_1_al"].DimensionScales()
+			require.NoError(t, err)
+			assert.Empty(t, scales)
+
+			isScale, err := datasets["/dset_al"].IsDimensionScale()
+			require.NoError(t, err)
+			assert.False(t, isScale)
+		})
+	}
+}
+
+// TestDimensionScales_NetCDF4 reads a netCDF-4 file where every variable is
+// attached to the shared dimension "x".
+func TestDimensionScales_NetCDF4(t *testing.T) {
+	datasets := datasetsByPath(t, "testdata/dense_links.h5")
+
+	scales, err := datasets["/v00"].DimensionScales()
+	require.NoError(t, err)
+	require.Len(t, scales, 1)
+	assert.Equal(t, 0, scales[0].Dim)
+	assert.Equal(t, "/x", scales[0].Path)
+	assert.Equal(t, "x", scales[0].Dataset.Name())
+	// netCDF-4 marks dimensions without a coordinate variable through NAME.
+	assert.Contains(t, scales[0].Name, "This is a netCDF dimension but not a netCDF variable")
+
+	isScale, err := datasets["/x"].IsDimensionScale()
+	require.NoError(t, err)
+	assert.True(t, isScale)
+}
@@ -0,0 +1,304 @@
+package hdf5
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/scigolib/hdf5/internal/core"
+)
+
+// rawAttributeValue is an attribute value whose datatype, dataspace and encoded
+// data are built by the caller, for attribute types that cannot be inferred
+// from a Go value (e.g. the reference-based dimension scale attributes).
+type rawAttributeValue struct {
+	datatype  *core.DatatypeMessage
+	dataspace *core.DataspaceMessage
+	data      []byte
+}
+
+// CreateDimensionScale creates a dataset and marks it as a dimension scale, the
+// HDF5 representation of a coordinate variable used by NetCDF-4 and xarray.
+//
+// The dataset gets a CLASS="DIMENSION_SCALE" attribute and, if name is not
+// empty, a NAME attribute holding the dimension name. Attach it to the
+// dimensions of other datasets with DatasetWriter.AttachScale.
+//
+// Parameters:
+//   - path: Dataset path (e.g., "/time")
+//   - dtype: Data type of the scale values
+//   - dims: Dimensions (usually one dimension matching the labelled axis)
+//   - name: Dimension name stored in the NAME attribute ("" to omit it)
+//   - opts: Dataset options, as for CreateDataset
+//
+// Returns:
+//   - *DatasetWriter: Handle for writing the scale values
+//   - error: If creation fails
+//
+// Example:
+//
+//	lat, _ := fw.CreateDimensionScale("/lat", hdf5.Float64, []uint64{180}, "lat")
+//	lat.Write(latitudes)
+//	temp, _ := fw.CreateDataset("/temperature", hdf5.Float32, []uint64{180, 360})
+//	temp.AttachScale(0, lat)
+//
+// Reference: H5DS.c - H5DSset_scale().
+func (fw *FileWriter) CreateDimensionScale(path string, dtype Datatype, dims []uint64, name string, opts ...DatasetOption) (*DatasetWriter, error) {
+	ds, err := fw.CreateDataset(path, dtype, dims, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ds.WriteAttribute(dimScaleClassAttr, dimScaleClassValue); err != nil {
+		return nil, fmt.Errorf("failed to write %s attribute: %w", dimScaleClassAttr, err)
+	}
+	if name != "" {
+		if err := ds.WriteAttribute(dimScaleNameAttr, name); err != nil {
+			return nil, fmt.Errorf("failed to write %s attribute: %w", dimScaleNameAttr, err)
+		}
+	}
+
+	return ds, nil
+}
+
+// AttachScale attaches a dimension scale to dimension dim of the dataset.
+//
+// The dataset's DIMENSION_LIST attribute (one list of object references per
+// dimension) and the scale's REFERENCE_LIST attribute (dataset reference and
+// dimension index pairs) are created or extended, so that the pair can be
+// navigated in both directions by HDF5 tools, NetCDF-4 and xarray. Attaching
+// a scale that is already attached to the same dimension is a no-op.
+//
+// Parameters:
+//   - dim: Dimension index of this dataset (0-based)
+//   - scale: Dataset created with CreateDimensionScale in the same file
+//
+// Returns:
+//   - error: If dim is out of range, scale is not a dimension scale, or
+//     the attributes cannot be written
+//
+// Reference: H5DS.c - H5DSattach_scale().
+func (ds *DatasetWriter) AttachScale(dim int, scale *DatasetWriter) error {
+	if scale == nil {
+		return fmt.Errorf("attach scale: scale is nil")
+	}
+	if scale.fileWriter != ds.fileWriter {
+		return fmt.Errorf("attach scale: scale %q belongs to a different file", scale.name)
+	}
+	if scale.address == ds.address {
+		return fmt.Errorf("attach scale: cannot attach dataset %q to itself", ds.name)
+	}
+	if dim < 0 || dim >= len(ds.dims) {
+		return fmt.Errorf("attach scale: dimension %d out of range for rank %d", dim, len(ds.dims))
+	}
+
+	fw := ds.fileWriter
+	sb := fw.file.Superblock()
+	reader := fw.writer.Reader()
+
+	scaleHeader, err := core.ReadObjectHeader(reader, scale.address, sb)
+	if err != nil {
+		return fmt.Errorf("attach scale: failed to read scale object header: %w", err)
+	}
+	if !isDimScaleHeader(scaleHeader) {
+		return fmt.Errorf("attach scale: %q is not a dimension scale (use CreateDimensionScale)", scale.name)
+	}
+
+	dsHeader, err := core.ReadObjectHeader(reader, ds.address, sb)
+	if err != nil {
+		return fmt.Errorf("attach scale: failed to read dataset object header: %w", err)
+	}
+
+	// DIMENSION_LIST: object references to the scales of each dimension.
+	dimLists := make([][]uint64, len(ds.dims))
+	dimListAttr := findAttribute(dsHeader, dimensionListAttr)
+	if dimListAttr != nil {
+		if dimLists, err = decodeDimensionList(dimListAttr, len(ds.dims), int(sb.OffsetSize), sb.Endianness); err != nil {
+			return fmt.Errorf("attach scale: %w", err)
+		}
+	}
+	for _, addr := range dimLists[dim] {
+		if addr == scale.address {
+			return nil // Already attached.
+		}
+	}
+	dimLists[dim] = append(dimLists[dim], scale.address)
+
+	dimListValue, err := encodeDimensionList(fw, dimLists)
+	if err != nil {
+		return fmt.Errorf("attach scale: %w", err)
+	}
+	if err := ds.replaceAttribute(dimensionListAttr, dimListAttr != nil, dimListValue); err != nil {
+		return fmt.Errorf("attach scale: failed to write %s: %w", dimensionListAttr, err)
+	}
+
+	// REFERENCE_LIST: back-references from the scale to the datasets using it.
+	refListAttr := findAttribute(scaleHeader, dimScaleRefListAttr)
+	var refData []byte
+	if refListAttr != nil {
+		refData = refListAttr.Data
+	}
+	refListValue, err := appendReferenceList(refData, ds.address, dim)
+	if err != nil {
+		return fmt.Errorf("attach scale: %w", err)
+	}
+	if err := scale.replaceAttribute(dimScaleRefListAttr, refListAttr != nil, refListValue); err != nil {
+		return fmt.Errorf("attach scale: failed to write %s: %w", dimScaleRefListAttr, err)
+	}
+
+	return nil
+}
+
+// replaceAttribute writes an attribute, deleting the previous version first.
+func (ds *DatasetWriter) replaceAttribute(name string, exists bool, value interface{}) error {
+	if exists {
+		if err := ds.DeleteAttribute(name); err != nil {
+			return err
+		}
+	}
+	return ds.WriteAttribute(name, value)
+}
+
+// findAttribute returns the attribute with the given name, or nil.
+func findAttribute(oh *core.ObjectHeader, name string) *core.Attribute {
+	for _, attr := range oh.Attributes {
+		if attr.Name == name {
+			return attr
+		}
+	}
+	return nil
+}
+
+// isDimScaleHeader reports whether an object header has CLASS="DIMENSION_SCALE".
+func isDimScaleHeader(oh *core.ObjectHeader) bool {
+	attr := findAttribute(oh, dimScaleClassAttr)
+	if attr == nil {
+		return false
+	}
+	value, err := attr.ReadValue()
+	if err != nil {
+		return false
+	}
+	class, _ := value.(string)
+	return class == dimScaleClassValue
+}
+
+// objectReferenceType returns the datatype of an object reference (hobj_ref_t),
+// which holds an object header address. Files written by this library use
+// 8-byte addresses.
+func objectReferenceType() *core.DatatypeMessage {
+	return &core.DatatypeMessage{
+		Class:   core.DatatypeReference,
+		Version: 1,
+		Size:    8,
+	}
+}
+
+// decodeDimensionList decodes the object references of an existing DIMENSION_LIST.
+func decodeDimensionList(attr *core.Attribute, rank, offsetSize int, order binary.ByteOrder) ([][]uint64, error) {
+	sequences, err := attr.ReadVarLenSequences()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read existing %s: %w", dimensionListAttr, err)
+	}
+	if len(sequences) != rank {
+		return nil, fmt.Errorf("existing %s has %d entries, dataset rank is %d", dimensionListAttr, len(sequences), rank)
+	}
+
+	lists := make([][]uint64, rank)
+	for dim, seq := range sequences {
+		for off := 0; off+offsetSize <= len(seq); off += offsetSize {
+			lists[dim] = append(lists[dim], readAddrFromBuf(seq[off:], offsetSize, order))
+		}
+	}
+	return lists, nil
+}
+
+// encodeDimensionList writes each dimension's reference list to the global heap
+// and builds the DIMENSION_LIST attribute: a variable-length sequence of object
+// references per dimension. Dimensions without scales get an empty sequence.
+//
+// Reference: H5DS.c - H5DSattach_scale() (H5Tvlen_create(H5T_STD_REF_OBJ)).
+func encodeDimensionList(fw *FileWriter, lists [][]uint64) (*rawAttributeValue, error) {
+	ensureGlobalHeapWriter(fw)
+
+	data := make([]byte, 0, len(lists)*16)
+	for dim, refs := range lists {
+		var heapID HeapID
+		if len(refs) > 0 {
+			seq := make([]byte, len(refs)*8)
+			for i, addr := range refs {
+				binary.LittleEndian.PutUint64(seq[i*8:], addr)
+			}
+			var err error
+			heapID, err = fw.globalHeapWriter.WriteToGlobalHeap(seq)
+			if err != nil {
+				return nil, fmt.Errorf("write dimension %d references to global heap: %w", dim, err)
+			}
+			heapID.SeqLen = uint32(len(refs)) //nolint:gosec // G115: scale count fits in uint32
+		}
+		data = append(data, heapID.Encode()...)
+	}
+	if err := fw.globalHeapWriter.Flush(); err != nil {
+		return nil, fmt.Errorf("flush global heap: %w", err)
+	}
+
+	baseType, err := core.EncodeDatatypeMessage(objectReferenceType())
+	if err != nil {
+		return nil, fmt.Errorf("encode reference type: %w", err)
+	}
+
+	return &rawAttributeValue{
+		datatype: &core.DatatypeMessage{
+			Class:      core.DatatypeVarLen,
+			Version:    1,
+			Size:       16,
+			Properties: baseType, // Type=0 (sequence) in the class bit field
+		},
+		dataspace: &core.DataspaceMessage{Dimensions: []uint64{uint64(len(lists))}},
+		data:      data,
+	}, nil
+}
+
+// referenceListEntrySize is the size of a REFERENCE_LIST element: an object
+// reference followed by an int dimension index, padded like the C struct
+// ds_list_t { hobj_ref_t ref; unsigned dim_idx; }.
+const referenceListEntrySize = 16
+
+// appendReferenceList adds a (dataset, dimension) entry to the REFERENCE_LIST
+// data of a dimension scale and returns the new attribute value.
+//
+// Reference: H5DS.c - H5DSattach_scale(), H5DS__get_REFLIST_type().
+func appendReferenceList(existing []byte, datasetAddr uint64, dim int) (*rawAttributeValue, error) {
+	if len(existing)%referenceListEntrySize != 0 {
+		return nil, fmt.Errorf("existing %s has unexpected size %d", dimScaleRefListAttr, len(existing))
+	}
+
+	entry := make([]byte, referenceListEntrySize)
+	binary.LittleEndian.PutUint64(entry[0:8], datasetAddr)
+	binary.LittleEndian.PutUint32(entry[8:12], uint32(dim)) //nolint:gosec // G115: dim validated against rank
+	data := append(append([]byte{}, existing...), entry...)
+
+	int32Type := &core.DatatypeMessage{
+		Class:         core.DatatypeFixed,
+		Version:       1,
+		Size:          4,
+		ClassBitField: 0x08,                // Little-endian, signed
+		Properties:    []byte{0, 0, 32, 0}, // Bit offset 0, precision 32
+	}
+	encoded, err := core.EncodeCompoundDatatypeV3(referenceListEntrySize, []core.CompoundFieldDef{
+		{Name: "dataset", Offset: 0, Type: objectReferenceType()},
+		{Name: "dimension", Offset: 8, Type: int32Type},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encode %s type: %w", dimScaleRefListAttr, err)
+	}
+	compound, err := core.ParseDatatypeMessage(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s type: %w", dimScaleRefListAttr, err)
+	}
+
+	return &rawAttributeValue{
+		datatype:  compound,
+		dataspace: &core.DataspaceMessage{Dimensions: []uint64{uint64(len(data) / referenceListEntrySize)}},
+		data:      data,
+	}, nil
+}
@@ -0,0 +1,114 @@
+package hdf5_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/scigolib/hdf5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachScale_RoundTrip(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "dimscales.h5")
+
+	fw, err := hdf5.CreateForWrite(file, hdf5.CreateTruncate)
+	require.NoError(t, err)
+
+	lat, err := fw.CreateDimensionScale("/lat", hdf5.Float64, []uint64{3}, "lat")
+	require.NoError(t, err)
+	require.NoError(t, lat.Write([]float64{-10, 0, 10}))
+
+	lon, err := fw.CreateDimensionScale("/lon", hdf5.Float64, []uint64{4}, "lon")
+	require.NoError(t, err)
+	require.NoError(t, lon.Write([]float64{0, 90, 180, 270}))
+
+	temp, err := fw.CreateDataset("/temperature", hdf5.Float64, []uint64{3, 4})
+	require.NoError(t, err)
+	require.NoError(t, temp.Write(make([]float64, 12)))
+	require.NoError(t, temp.WriteAttribute("units", "K"))
+
+	pressure, err := fw.CreateDataset("/pressure", hdf5.Float64, []uint64{3, 4})
+	require.NoError(t, err)
+	require.NoError(t, pressure.Write(make([]float64, 12)))
+
+	require.NoError(t, temp.AttachScale(0, lat))
+	require.NoError(t, temp.AttachScale(1, lon))
+	require.NoError(t, temp.AttachScale(1, lon)) // Already attached: no-op.
+	require.NoError(t, pressure.AttachScale(0, lat))
+	require.NoError(t, fw.Close())
+
+	datasets := datasetsByPath(t, file)
+
+	scales, err := datasets["/temperature"].DimensionScales()
+	require.NoError(t, err)
+	require.Len(t, scales, 2)
+	assert.Equal(t, hdf5.DimScale{Dim: 0, Name: "lat", Path: "/lat", Dataset: scales[0].Dataset}, scales[0])
+	assert.Equal(t, hdf5.DimScale{Dim: 1, Name: "lon", Path: "/lon", Dataset: scales[1].Dataset}, scales[1])
+
+	coords, err := scales[1].Dataset.Read()
+	require.NoError(t, err)
+	assert.Equal(t, []float64{0, 90, 180, 270}, coords)
+
+	// Existing attributes are kept alongside DIMENSION_LIST.
+	units, err := datasets["/temperature"].ReadAttribute("units")
+	require.NoError(t, err)
+	assert.Equal(t, "K", units)
+
+	scales, err = datasets["/pressure"].DimensionScales()
+	require.NoError(t, err)
+	require.Len(t, scales, 1)
+	assert.Equal(t, "/lat", scales[0].Path)
+
+	for path, wantRefs := range map[string]uint64{"/lat": 2, "/lon": 1} {
+		isScale, err := datasets[path].IsDimensionScale()
+		require.NoError(t, err)
+		assert.True(t, isScale, path)
+
+		attrs, err := datasets[path].Attributes()
+		require.NoError(t, err)
+		var refs uint64
+		for _, attr := range attrs {
+			if attr.Name == "REFERENCE_LIST" {
+				refs = attr.Dataspace.TotalElements()
+			}
+		}
+		assert.Equal(t, wantRefs, refs, "%s REFERENCE_LIST entries", path)
+	}
+}
+
+func TestAttachScale_Errors(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "dimscales_errors.h5")
+
+	fw, err := hdf5.CreateForWrite(file, hdf5.CreateTruncate)
+	require.NoError(t, err)
+	defer func() { _ = fw.Close() }()
+
+	scale, err := fw.CreateDimensionScale("/x", hdf5.Int32, []uint64{2}, "")
+	require.NoError(t, err)
+	data, err := fw.CreateDataset("/data", hdf5.Int32, []uint64{2})
+	require.NoError(t, err)
+	plain, err := fw.CreateDataset("/plain", hdf5.Int32, []uint64{2})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		dim     int
+		scale   *hdf5.DatasetWriter
+		wantErr string
+	}{
+		{"nil scale", 0, nil, "nil"},
+		{"negative dimension", -1, scale, "out of range"},
+		{"dimension beyond rank", 1, scale, "out of range"},
+		{"not a scale", 0, plain, "not a dimension scale"},
+		{"self", 0, data, "itself"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := data.AttachScale(tt.dim, tt.scale)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
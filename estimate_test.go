@@ -0,0 +1,95 @@
+package hdf5
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// estimateSpecs describes a file with contiguous and chunked datasets, one
+// of them in nested groups that the estimate must create.
+func estimateSpecs() []DatasetSpec {
+	return []DatasetSpec{
+		{Path: "/temperature", Dtype: Float64, Dims: []uint64{1000}},
+		{Path: "/sim/run1/grid", Dtype: Int32, Dims: []uint64{100, 60},
+			Options: []DatasetOption{WithChunkDims([]uint64{25, 16})}},
+		{Path: "/sim/run1/labels", Dtype: Uint8, Dims: []uint64{300},
+			Options: []DatasetOption{WithChunkDims([]uint64{64}), WithMaxDims([]uint64{Unlimited})}},
+	}
+}
+
+// TestEstimateSize_MatchesFile compares the estimate with the size of the
+// file actually written, for uncompressed datasets.
+func TestEstimateSize_MatchesFile(t *testing.T) {
+	estimate, err := EstimateSize(estimateSpecs())
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "estimate.h5")
+	fw, err := CreateForWrite(path, CreateTruncate)
+	require.NoError(t, err)
+	_, err = fw.CreateGroup("/sim")
+	require.NoError(t, err)
+	_, err = fw.CreateGroup("/sim/run1")
+	require.NoError(t, err)
+	for _, spec := range estimateSpecs() {
+		ds, err := fw.CreateDataset(spec.Path, spec.Dtype, spec.Dims, spec.Options...)
+		require.NoError(t, err)
+		n := calculateTotalElements(spec.Dims)
+		switch spec.Dtype {
+		case Float64:
+			require.NoError(t, ds.Write(make([]float64, n)))
+		case Int32:
+			require.NoError(t, ds.Write(make([]int32, n)))
+		case Uint8:
+			require.NoError(t, ds.Write(make([]uint8, n)))
+		}
+	}
+	require.NoError(t, fw.Close())
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, uint64(info.Size()), estimate)
+}
+
+// TestEstimateSize_Compressed checks that compressed datasets are counted
+// at their uncompressed size, an upper bound of the file size.
+func TestEstimateSize_Compressed(t *testing.T) {
+	spec := DatasetSpec{Path: "/data", Dtype: Float64, Dims: []uint64{10000},
+		Options: []DatasetOption{WithChunkDims([]uint64{1000}), WithGZIPCompression(6)}}
+	estimate, err := EstimateSize([]DatasetSpec{spec})
+	require.NoError(t, err)
+	require.Greater(t, estimate, uint64(10000*8))
+
+	path := filepath.Join(t.TempDir(), "compressed.h5")
+	fw, err := CreateForWrite(path, CreateTruncate)
+	require.NoError(t, err)
+	ds, err := fw.CreateDataset(spec.Path, spec.Dtype, spec.Dims, spec.Options...)
+	require.NoError(t, err)
+	require.NoError(t, ds.Write(make([]float64, 10000)))
+	require.NoError(t, fw.Close())
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Less(t, uint64(info.Size()), estimate)
+}
+
+// TestEstimateSize_Large estimates a dataset far larger than memory.
+func TestEstimateSize_Large(t *testing.T) {
+	const dims = 1 << 20
+	estimate, err := EstimateSize([]DatasetSpec{
+		{Path: "/huge", Dtype: Float64, Dims: []uint64{dims, dims}},
+	})
+	require.NoError(t, err)
+	require.Greater(t, estimate, uint64(dims*dims*8))
+	require.Less(t, estimate, uint64(dims*dims*8+1<<20))
+}
+
+// TestEstimateSize_InvalidSpec reports the dataset a spec error belongs to.
+func TestEstimateSize_InvalidSpec(t *testing.T) {
+	_, err := EstimateSize([]DatasetSpec{
+		{Path: "/bad", Dtype: Float64, Dims: []uint64{10}, Options: []DatasetOption{WithChunkDims([]uint64{20})}},
+	})
+	require.ErrorContains(t, err, `dataset "/bad"`)
+}
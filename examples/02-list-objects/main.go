@@ -17,17 +17,14 @@ func main() {
 	fmt.Println("Superblock version:", file.SuperblockVersion())
 	fmt.Println("\nObjects in file:")
 
-	file.Walk(func(path string, obj hdf5.Object) {
-		switch v := obj.(type) {
-		case *hdf5.Group:
-			fmt.Printf("  Group: %s (children: %d)\n", path, len(v.Children()))
-			for _, child := range v.Children() {
-				fmt.Printf("    - %s\n", child.Name())
+	file.Walk(func(_ string, obj hdf5.Object) {
+		if g, ok := obj.AsGroup(); ok {
+			fmt.Printf("  Group: %s (children: %d)\n", g.Path(), len(g.Children()))
+			for _, child := range g.Children() {
+				fmt.Printf("    - %s (%s)\n", child.Name(), child.Kind())
 			}
-		case *hdf5.Dataset:
-			fmt.Printf("  Dataset: %s\n", path)
-		default:
-			fmt.Printf("  Unknown: %s (type: %T)\n", path, obj)
+			return
 		}
+		fmt.Printf("  %s: %s\n", obj.Kind(), obj.Path())
 	})
 }
@@ -8,120 +8,472 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
+	"sync"
 
 	"github.com/scigolib/hdf5/internal/core"
 	"github.com/scigolib/hdf5/internal/utils"
 )
 
 // File represents an open HDF5 file with its metadata and root group.
+//
+// A File is safe for concurrent use by multiple goroutines for reading:
+// Walk, attribute reads and dataset reads only read the file structure
+// parsed by Open and use per-call buffers. With WithEager(false), each group
+// is parsed once, under a lock, by the first call that needs its children.
+// Close must not be called while reads are in progress. Files opened with
+// OpenReaderAt inherit the concurrency guarantees of the supplied
+// io.ReaderAt, which per its contract must allow parallel ReadAt calls.
 type File struct {
-	osFile        *os.File
+	reader        io.ReaderAt
+	closer        io.Closer // Closed by Close; nil when the caller owns the reader (OpenReaderAt)
 	sb            *core.Superblock
 	root          *Group
-	visitedBTrees map[uint64]bool // Track visited B-tree addresses to prevent cycles
+	visitedBTrees map[uint64]bool  // Track visited B-tree addresses to prevent cycles
+	skipChecksums bool             // Do not verify filter checksums (WithVerifyFilters(false))
+	maxAllocation uint64           // Read allocation limit in bytes, 0 = none (WithMaxAllocation)
+	size          uint64           // File size in bytes, for bounds checks on read
+	loading       []string         // Names of the objects being loaded by Open, for StructureError
+	loadingAddrs  []uint64         // Addresses of the groups enclosing the object being loaded
+	logger        func(ParseEvent) // Receives parse events while Open runs (WithLogger)
+	lazy          bool             // Groups load their children on first access (WithEager(false))
+	loadMu        sync.Mutex       // Serializes deferred group loads, which share the loading state
+}
+
+// OpenOption is a functional option for configuring how a file is read.
+type OpenOption func(*openConfig)
+
+// openConfig holds configuration for reading a file.
+type openConfig struct {
+	verifyFilters bool
+	maxAllocation uint64
+	family        *familyReader // Member files, set by OpenFamily
+	logger        func(ParseEvent)
+	lazy          bool // Defer group loading (WithEager(false))
+}
+
+// ChecksumError is returned when a chunk fails checksum verification (Fletcher32 filter).
+// Use errors.As to get the chunk coordinates, address and both checksum values.
+type ChecksumError = core.ChecksumError
+
+// UnsupportedFilterError is returned when reading a chunk that needs a
+// mandatory filter this package does not implement. Use Dataset.Filters to
+// check support before reading.
+type UnsupportedFilterError = core.UnsupportedFilterError
+
+// WithVerifyFilters enables or disables verification of filter checksums.
+//
+// When enabled (default), every chunk written with the Fletcher32 filter is
+// checked on read and a mismatch fails the read with a *ChecksumError naming
+// the chunk. When disabled, checksums are stripped without being checked,
+// which allows salvaging data from a damaged file.
+//
+// Example:
+//
+//	f, err := hdf5.Open("damaged.h5", hdf5.WithVerifyFilters(false))
+func WithVerifyFilters(verify bool) OpenOption {
+	return func(cfg *openConfig) {
+		cfg.verifyFilters = verify
+	}
+}
+
+// WithMaxAllocation caps the memory a single dataset read may allocate.
+// Reads whose data, or converted result, would need more than maxBytes fail
+// with an error instead of attempting the allocation. Zero (the default)
+// means no limit beyond the built-in sanity checks.
+//
+// Independently of this option, contiguous datasets whose size extends
+// beyond the end of the file are always rejected, so a malformed dataspace
+// claiming billions of elements cannot trigger a huge allocation. Use this
+// option to also bound chunked datasets, whose compressed data may
+// legitimately expand beyond the file size, when ingesting untrusted files.
+//
+// Example:
+//
+//	f, err := hdf5.Open("upload.h5", hdf5.WithMaxAllocation(256<<20))
+func WithMaxAllocation(maxBytes uint64) OpenOption {
+	return func(cfg *openConfig) {
+		cfg.maxAllocation = maxBytes
+	}
 }
 
 // Open opens an HDF5 file for reading and returns a File handle.
 // The file must be a valid HDF5 file with a supported format version.
-func Open(filename string) (*File, error) {
+//
+// The whole group hierarchy is loaded by Open, unless WithEager(false) is
+// given. A structure that cannot be parsed fails it with a *StructureError
+// naming the object and address.
+//
+// Options:
+//   - WithVerifyFilters: Verify chunk checksums on read (default: true)
+//   - WithMaxAllocation: Limit the memory a dataset read may allocate (default: no limit)
+//   - WithLogger: Receive a trace of the structures parsed while loading
+//   - WithEager: Load groups on first access instead of in Open (default: true)
+func Open(filename string, opts ...OpenOption) (*File, error) {
 	//nolint:gosec // G304: User-provided filename is intentional for HDF5 file library
 	f, err := os.Open(filename)
 	if err != nil {
 		return nil, utils.WrapError("file open failed", err)
 	}
 
-	// Verify HDF5 signature before reading superblock.
-	if !isHDF5File(f) {
-		_ = f.Close()
-		return nil, errors.New("not an HDF5 file")
-	}
-
 	// Get file size for address validation.
 	fi, err := f.Stat()
 	if err != nil {
 		_ = f.Close()
 		return nil, utils.WrapError("file stat failed", err)
 	}
-	fileSize := fi.Size()
 
-	sb, err := core.ReadSuperblock(f)
+	file, err := openReaderAt(f, fi.Size(), opts)
 	if err != nil {
 		_ = f.Close()
+		return nil, err
+	}
+	file.closer = f
+
+	return file, nil
+}
+
+// OpenReaderAt opens an HDF5 file backed by an arbitrary io.ReaderAt, such as
+// a bytes.Reader over an in-memory image or a range-request reader for object
+// storage. size is the total size of the file in bytes.
+//
+// The reader must remain usable for as long as the File is used. The caller
+// keeps ownership of r: File.Close does not close it.
+//
+// Options are the same as for Open.
+//
+// Example:
+//
+//	data, _ := os.ReadFile("data.h5")
+//	f, err := hdf5.OpenReaderAt(bytes.NewReader(data), int64(len(data)))
+func OpenReaderAt(r io.ReaderAt, size int64, opts ...OpenOption) (*File, error) {
+	if r == nil {
+		return nil, errors.New("reader is nil")
+	}
+	if size < 0 {
+		return nil, fmt.Errorf("invalid file size %d", size)
+	}
+	return openReaderAt(r, size, opts)
+}
+
+// openReaderAt reads the superblock and root group from r.
+func openReaderAt(r io.ReaderAt, size int64, opts []OpenOption) (*File, error) {
+	cfg := openConfig{verifyFilters: true}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	// Find the superblock: it follows the user block, if any. All other
+	// addresses in the file are relative to it, so read through a view that
+	// starts at the superblock.
+	base, err := core.LocateSuperblock(r, size)
+	if err != nil {
+		return nil, err
+	}
+	if base > 0 {
+		size -= int64(base)                           //nolint:gosec // G115: base is below size
+		r = io.NewSectionReader(r, int64(base), size) //nolint:gosec // G115: base is below size
+	}
+
+	sb, err := core.ReadSuperblock(r)
+	if err != nil {
 		return nil, utils.WrapError("superblock read failed", err)
 	}
+	// The stored base address may be stale (e.g. a user block prepended
+	// after creation); the superblock location is authoritative.
+	sb.BaseAddress = base
+
+	if err := cfg.checkDriver(r, sb); err != nil {
+		return nil, err
+	}
+	if cfg.family != nil && base == 0 {
+		size = cfg.family.size() // The member size may have changed.
+	}
 
 	file := &File{
-		osFile:        f,
+		reader:        r,
 		sb:            sb,
 		visitedBTrees: make(map[uint64]bool),
+		skipChecksums: !cfg.verifyFilters,
+		maxAllocation: cfg.maxAllocation,
+		size:          uint64(size), //nolint:gosec // G115: size is validated non-negative
+		logger:        cfg.logger,
+		lazy:          cfg.lazy,
 	}
+	file.trace("superblock", 0, "version %d, user block %d bytes, root group at 0x%X",
+		sb.Version, base, sb.RootGroup)
 
 	// Validate root group address.
 	//nolint:gosec // G115: File size is always positive, safe to convert int64 to uint64
-	if sb.RootGroup >= uint64(fileSize) {
-		_ = f.Close()
+	if sb.RootGroup >= uint64(size) {
 		return nil, fmt.Errorf("root group address %d beyond file size %d",
-			sb.RootGroup, fileSize)
+			sb.RootGroup, size)
+	}
+
+	if file.lazy {
+		if err := file.openLazyRoot(); err != nil {
+			return nil, utils.WrapError("root group load failed", err)
+		}
+		return file, nil
 	}
 
 	// For all versions, sb.RootGroup now contains the correct object header address.
+	file.loadingAddrs = []uint64{sb.RootGroup}
 	file.root, err = loadGroup(file, sb.RootGroup)
+	file.loadingAddrs = nil
 	if err != nil {
-		_ = f.Close()
 		return nil, utils.WrapError("root group load failed", err)
 	}
 
 	// Ensure root group always has name "/" (may be empty from object header)
 	file.root.name = "/"
+	assignPaths(file.root, "/")
+	file.logger = nil // Events are only reported while loading.
 
 	return file, nil
 }
 
-// isHDF5File verifies HDF5 file signature.
-func isHDF5File(r utils.ReaderAt) bool {
-	buf := utils.GetBuffer(8)
-	defer utils.ReleaseBuffer(buf)
-
-	if _, err := r.ReadAt(buf, 0); err != nil {
-		return false
-	}
-	return string(buf) == core.Signature
-}
-
 // Close closes the HDF5 file and releases associated resources.
-// It is safe to call Close multiple times.
+// It is safe to call Close multiple times. For files opened with
+// OpenReaderAt the underlying reader is left open.
+//
+// Reads made after Close fail with an error wrapping os.ErrClosed.
 func (f *File) Close() error {
-	if f.osFile == nil {
-		return nil // Already closed.
+	var err error
+	if f.closer != nil {
+		err = f.closer.Close()
 	}
-	err := f.osFile.Close()
-	f.osFile = nil // Prevent double close.
+	f.reader = closedReader{}
+	f.closer = nil // Prevent double close.
 	return err
 }
 
+// closedReader replaces the reader of a closed File.
+type closedReader struct{}
+
+// ReadAt implements io.ReaderAt; it always fails with os.ErrClosed.
+func (closedReader) ReadAt([]byte, int64) (int, error) {
+	return 0, os.ErrClosed
+}
+
+// readOptions returns the dataset read options implied by the open options.
+func (f *File) readOptions() []core.ReadOption {
+	opts := []core.ReadOption{core.WithFileSize(f.size)}
+	if f.skipChecksums {
+		opts = append(opts, core.WithoutChecksumVerification())
+	}
+	if f.maxAllocation > 0 {
+		opts = append(opts, core.WithMaxAllocation(f.maxAllocation))
+	}
+	return opts
+}
+
 // Root returns the root group of the HDF5 file.
 func (f *File) Root() *Group {
 	return f.root
 }
 
+// WalkOption is a functional option for configuring File.Walk.
+type WalkOption func(*walkConfig)
+
+// walkConfig holds configuration for File.Walk.
+type walkConfig struct {
+	visitOnce       bool
+	onAlias         func(path string, first Object)
+	followSoftLinks bool
+	maxDepth        int // Deepest level visited, 0 = unlimited
+	onLinkError     func(path string, err error)
+}
+
+// WithVisitOnce makes Walk visit each object only once, even when several
+// hard links lead to it. Objects are identified by their object header
+// address (see Object.Address). The first path reached in depth-first order
+// is visited; every later path to the same object is passed to onAlias
+// together with the object seen first (whose Path is the first path), and
+// the children of an aliased group are not walked again. onAlias may be nil.
+//
+// Example:
+//
+//	f.Walk(visit, hdf5.WithVisitOnce(func(path string, first hdf5.Object) {
+//	    fmt.Printf("%s is a hard link to %s\n", path, first.Path())
+//	}))
+func WithVisitOnce(onAlias func(path string, first Object)) WalkOption {
+	return func(cfg *walkConfig) {
+		cfg.visitOnce = true
+		cfg.onAlias = onAlias
+	}
+}
+
+// WithFollowSoftLinks makes Walk follow soft links: the object a soft link
+// points to is visited under the link's path, and a group is walked below
+// it. Soft links whose target does not exist, and chains of soft links that
+// lead back to themselves, are skipped and reported to WithLinkErrors.
+// External links are not followed.
+//
+// Example:
+//
+//	f.Walk(visit, hdf5.WithFollowSoftLinks(), hdf5.WithLinkErrors(func(path string, err error) {
+//	    log.Printf("skipping %s: %v", path, err)
+//	}))
+func WithFollowSoftLinks() WalkOption {
+	return func(cfg *walkConfig) {
+		cfg.followSoftLinks = true
+	}
+}
+
+// WithMaxDepth limits Walk to objects at most depth links below the root
+// group (the root group is at depth 0, its members at depth 1). It guards
+// against very deep or cyclic hierarchies in untrusted files; depth <= 0
+// means no limit.
+func WithMaxDepth(depth int) WalkOption {
+	return func(cfg *walkConfig) {
+		cfg.maxDepth = depth
+	}
+}
+
+// WithLinkErrors sets a function called for links Walk does not descend
+// into: links back to an enclosing group (*LinkCycleError) and, with
+// WithFollowSoftLinks, soft links that cannot be resolved. Without it such
+// links are skipped silently.
+func WithLinkErrors(fn func(path string, err error)) WalkOption {
+	return func(cfg *walkConfig) {
+		cfg.onLinkError = fn
+	}
+}
+
 // Walk traverses the entire file structure, calling fn for each object.
 // Objects are visited in depth-first order starting from the root group.
-func (f *File) Walk(fn func(path string, obj Object)) {
-	walkGroup(f.root, "/", fn)
+//
+// By default an object reachable through several hard links is visited once
+// per link; use WithVisitOnce to visit it once and report the other paths
+// as aliases.
+//
+// A link to a group that encloses it (a link cycle) is never descended
+// into: it is reported to WithLinkErrors as a *LinkCycleError instead of
+// being visited, so Walk always terminates.
+func (f *File) Walk(fn func(path string, obj Object), opts ...WalkOption) {
+	var cfg walkConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	w := &walker{file: f, fn: fn, cfg: cfg}
+	if cfg.visitOnce {
+		w.seen = make(map[uint64]Object)
+	}
+	w.walkGroup(f.root, "/")
+}
+
+// walker carries the state of one Walk call.
+type walker struct {
+	file *File
+	fn   func(string, Object)
+	cfg  walkConfig
+	seen map[uint64]Object // Objects visited so far by address; nil unless visiting once.
+
+	// Groups enclosing the object being visited, from the root group down.
+	ancestors []ancestorGroup
+}
+
+// ancestorGroup is a group on the path of the object being visited.
+type ancestorGroup struct {
+	address uint64
+	path    string
 }
 
-func walkGroup(g *Group, currentPath string, fn func(string, Object)) {
-	fn(currentPath, g)
+func (w *walker) walkGroup(g *Group, currentPath string) {
+	if !w.visit(currentPath, g) {
+		return
+	}
 
-	for _, child := range g.Children() {
-		childPath := currentPath + child.Name()
+	depth := len(w.ancestors) + 1
+	if w.cfg.maxDepth > 0 && depth > w.cfg.maxDepth {
+		return
+	}
+	w.ancestors = append(w.ancestors, ancestorGroup{address: g.Address(), path: strings.TrimSuffix(currentPath, "/")})
+	defer func() { w.ancestors = w.ancestors[:len(w.ancestors)-1] }()
+
+	for _, m := range w.members(g, currentPath) {
+		childPath := currentPath + m.name
+
+		childGroup, ok := m.obj.(*Group)
+		if !ok {
+			w.visit(childPath, m.obj)
+			continue
+		}
+		if target, cycle := w.enclosing(childGroup.Address()); cycle {
+			w.linkError(childPath, &LinkCycleError{Path: childPath, Target: target})
+			continue
+		}
+		w.walkGroup(childGroup, childPath+"/")
+	}
+}
+
+// walkMember is an object reached through a link of a walked group.
+type walkMember struct {
+	name string
+	obj  Object
+}
+
+// members returns the objects linked from g: its children, or with
+// WithFollowSoftLinks its hard links and the targets of its soft links.
+func (w *walker) members(g *Group, currentPath string) []walkMember {
+	if !w.cfg.followSoftLinks {
+		members := make([]walkMember, 0, len(g.Children()))
+		for _, child := range g.Children() {
+			members = append(members, walkMember{name: child.Name(), obj: child})
+		}
+		return members
+	}
+
+	groupPath := pathOrRoot(strings.TrimSuffix(currentPath, "/"))
+	resolved, err := w.file.groupMembers(g, groupPath, nil)
+	if err != nil {
+		w.linkError(groupPath, err)
+		return nil
+	}
+	members := make([]walkMember, 0, len(resolved))
+	for _, m := range resolved {
+		if m.err != nil {
+			w.linkError(currentPath+m.name, m.err)
+			continue
+		}
+		members = append(members, walkMember{name: m.name, obj: m.obj})
+	}
+	return members
+}
+
+// enclosing returns the path of the enclosing group at address, if any.
+func (w *walker) enclosing(address uint64) (string, bool) {
+	for _, a := range w.ancestors {
+		if a.address == address {
+			return pathOrRoot(a.path), true
+		}
+	}
+	return "", false
+}
+
+// linkError reports a link Walk does not descend into.
+func (w *walker) linkError(path string, err error) {
+	if w.cfg.onLinkError != nil {
+		w.cfg.onLinkError(path, err)
+	}
+}
 
-		if childGroup, ok := child.(*Group); ok {
-			walkGroup(childGroup, childPath+"/", fn)
-		} else {
-			fn(childPath, child)
+// visit calls fn for obj unless it was already visited under another path,
+// in which case the alias is reported instead. It reports whether fn was called.
+func (w *walker) visit(path string, obj Object) bool {
+	if w.seen != nil {
+		if first, ok := w.seen[obj.Address()]; ok {
+			if w.cfg.onAlias != nil {
+				w.cfg.onAlias(path, first)
+			}
+			return false
 		}
+		w.seen[obj.Address()] = obj
 	}
+	w.fn(path, obj)
+	return true
 }
 
 // SuperblockVersion returns the HDF5 superblock format version (0, 2, or 3).
@@ -135,8 +487,10 @@ func (f *File) Superblock() *core.Superblock {
 }
 
 // Reader returns the underlying file reader for low-level access.
+// Offsets are HDF5 addresses: for files with a user block they are relative
+// to the superblock (see Superblock.BaseAddress), not to the start of the file.
 func (f *File) Reader() io.ReaderAt {
-	return f.osFile
+	return f.reader
 }
 
 // readSignature reads 4 bytes at address and returns string.
@@ -0,0 +1,71 @@
+package hdf5
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/scigolib/hdf5/internal/core"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithAlignment(t *testing.T) {
+	const alignment = 4096
+	path := filepath.Join(t.TempDir(), "aligned.h5")
+
+	fw, err := CreateForWrite(path, CreateTruncate, WithAlignment(1024, alignment))
+	require.NoError(t, err)
+
+	contiguous := make([]float64, 500)
+	for i := range contiguous {
+		contiguous[i] = float64(i) / 3
+	}
+	ds, err := fw.CreateDataset("/contiguous", Float64, []uint64{500})
+	require.NoError(t, err)
+	require.NoError(t, ds.Write(contiguous))
+
+	chunked := make([]int32, 1200)
+	for i := range chunked {
+		chunked[i] = int32(i)
+	}
+	ds, err = fw.CreateDataset("/chunked", Int32, []uint64{1200}, WithChunkDims([]uint64{300}))
+	require.NoError(t, err)
+	require.NoError(t, ds.Write(chunked))
+	require.NoError(t, fw.Close())
+
+	f, err := Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	cont := findDatasetByPath(t, f, "/contiguous")
+	got, err := cont.Read()
+	require.NoError(t, err)
+	require.Equal(t, contiguous, got)
+
+	layout, err := cont.Layout()
+	require.NoError(t, err)
+	require.Zero(t, layout.DataAddress%alignment, "contiguous data at 0x%x", layout.DataAddress)
+
+	chunk := findDatasetByPath(t, f, "/chunked")
+	values, err := chunk.Read()
+	require.NoError(t, err)
+	require.Len(t, values, 1200)
+	require.Equal(t, float64(1199), values[1199])
+
+	layout, err = chunk.Layout()
+	require.NoError(t, err)
+	space, err := chunk.Dataspace()
+	require.NoError(t, err)
+	chunks, err := core.CollectChunks(f.Reader(), layout, space, f.Superblock())
+	require.NoError(t, err)
+	require.Len(t, chunks, 4)
+	for _, c := range chunks {
+		require.Zero(t, c.Address%alignment, "chunk %v at 0x%x", c.Key.Scaled, c.Address)
+	}
+}
+
+func TestWithAlignment_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "invalid.h5")
+
+	_, err := CreateForWrite(path, CreateTruncate, WithAlignment(1, 0))
+	require.ErrorContains(t, err, "alignment must be positive")
+}
@@ -0,0 +1,157 @@
+package hdf5
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestFile_ConcurrentReads reads different datasets of one open File from
+// eight goroutines. Run with -race to check that the read path shares no
+// mutable state.
+func TestFile_ConcurrentReads(t *testing.T) {
+	const workers = 8
+	path := filepath.Join(t.TempDir(), "concurrent.h5")
+
+	fw, err := CreateForWrite(path, CreateTruncate)
+	require.NoError(t, err)
+
+	expected := make([][]float64, workers)
+	for i := 0; i < workers; i++ {
+		values := make([]float64, 1000)
+		for j := range values {
+			values[j] = float64(i*10000 + j)
+		}
+		expected[i] = values
+
+		var opts []DatasetOption
+		if i%2 == 1 {
+			opts = append(opts, WithChunkDims([]uint64{128}), WithShuffle())
+		}
+		ds, err := fw.CreateDataset(fmt.Sprintf("/data%d", i), Float64, []uint64{1000}, opts...)
+		require.NoError(t, err)
+		require.NoError(t, ds.Write(values))
+		require.NoError(t, ds.WriteAttribute("index", int32(i)))
+	}
+
+	labels, err := fw.CreateDataset("/labels", String, []uint64{3}, WithStringSize(8))
+	require.NoError(t, err)
+	require.NoError(t, labels.Write([]string{"a", "b", "c"}))
+	require.NoError(t, fw.Close())
+
+	f, err := Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	datasets := make(map[string]*Dataset)
+	f.Walk(func(p string, obj Object) {
+		if ds, ok := obj.(*Dataset); ok {
+			datasets[p] = ds
+		}
+	})
+
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ds := datasets[fmt.Sprintf("/data%d", i)]
+			for iter := 0; iter < 20; iter++ {
+				values, err := ds.Read()
+				if err != nil {
+					errs <- err
+					return
+				}
+				if values[999] != expected[i][999] || values[0] != expected[i][0] {
+					errs <- fmt.Errorf("data%d: unexpected values %v...%v", i, values[0], values[999])
+					return
+				}
+
+				slice, err := ds.ReadSlice([]uint64{100}, []uint64{10})
+				if err != nil {
+					errs <- err
+					return
+				}
+				if got := slice.([]float64); got[0] != expected[i][100] {
+					errs <- fmt.Errorf("data%d: unexpected slice start %v", i, got[0])
+					return
+				}
+
+				if _, err := ds.ReadAttribute("index"); err != nil {
+					errs <- err
+					return
+				}
+				if _, err := datasets["/labels"].ReadStrings(); err != nil {
+					errs <- err
+					return
+				}
+
+				count := 0
+				f.Walk(func(string, Object) { count++ })
+				if count != workers+2 {
+					errs <- fmt.Errorf("walk visited %d objects", count)
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+}
+
+// TestFile_ConcurrentReads_Compressed decompresses chunks of a reference file
+// from several goroutines at once.
+func TestFile_ConcurrentReads_Compressed(t *testing.T) {
+	f, err := Open("testdata/gzip_test.h5")
+	require.NoError(t, err)
+	defer f.Close()
+
+	var datasets []*Dataset
+	f.Walk(func(_ string, obj Object) {
+		if ds, ok := obj.(*Dataset); ok {
+			datasets = append(datasets, ds)
+		}
+	})
+	require.NotEmpty(t, datasets)
+
+	want := make([][]float64, len(datasets))
+	for i, ds := range datasets {
+		want[i], err = ds.Read()
+		require.NoError(t, err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			i := w % len(datasets)
+			for iter := 0; iter < 10; iter++ {
+				got, err := datasets[i].Read()
+				if err != nil {
+					errs <- err
+					return
+				}
+				if len(got) != len(want[i]) || got[len(got)-1] != want[i][len(want[i])-1] {
+					errs <- fmt.Errorf("%s: data differs from sequential read", datasets[i].Name())
+					return
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+}
@@ -0,0 +1,189 @@
+package hdf5
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/scigolib/hdf5/internal/core"
+	"github.com/scigolib/hdf5/internal/utils"
+)
+
+// OpenFamily opens an HDF5 file written by the family driver, which splits
+// one logical file into member files of a fixed size (H5Pset_fapl_family,
+// h5py's driver="family"). pattern names the members with one integer verb,
+// e.g. "data-%d.h5" or "data%05d.h5", as given to the HDF5 library when the
+// file was created.
+//
+// Members are numbered from 0; all consecutive existing members are opened
+// and read as one file. The member size is taken from the driver
+// information stored in the superblock, or, for files without it, from the
+// size of the first member.
+//
+// Options are the same as for Open.
+//
+// Example:
+//
+//	// archive-00000.h5, archive-00001.h5, ...
+//	f, err := hdf5.OpenFamily("archive-%05d.h5")
+//	if err != nil {
+//	    return err
+//	}
+//	defer f.Close()
+//
+// Reference: H5FDfamily.c.
+func OpenFamily(pattern string, opts ...OpenOption) (*File, error) {
+	if first := fmt.Sprintf(pattern, 0); strings.Contains(first, "%!") || first == fmt.Sprintf(pattern, 1) {
+		return nil, fmt.Errorf("family pattern %q must contain an integer verb such as %%d", pattern)
+	}
+
+	fr := &familyReader{}
+	for i := 0; ; i++ {
+		name := fmt.Sprintf(pattern, i)
+		//nolint:gosec // G304: User-provided filename is intentional for HDF5 file library
+		member, err := os.Open(name)
+		if errors.Is(err, os.ErrNotExist) && i > 0 {
+			break
+		}
+		if err != nil {
+			_ = fr.Close()
+			return nil, utils.WrapError("family member open failed", err)
+		}
+		fi, err := member.Stat()
+		if err != nil {
+			_ = member.Close()
+			_ = fr.Close()
+			return nil, utils.WrapError("family member stat failed", err)
+		}
+		fr.members = append(fr.members, member)
+		fr.sizes = append(fr.sizes, fi.Size())
+	}
+	// All members but the last have the member size; the superblock then
+	// confirms it (see checkDriver).
+	if fr.sizes[0] == 0 {
+		_ = fr.Close()
+		return nil, fmt.Errorf("family member %q is empty", fmt.Sprintf(pattern, 0))
+	}
+	fr.memberSize = fr.sizes[0]
+
+	file, err := openReaderAt(fr, fr.size(), append(opts, func(cfg *openConfig) {
+		cfg.family = fr
+	}))
+	if err != nil {
+		_ = fr.Close()
+		return nil, err
+	}
+	file.closer = fr
+
+	return file, nil
+}
+
+// checkDriver checks that the file can be read with the driver it was
+// opened with: the default one, or the family driver for OpenFamily.
+func (cfg *openConfig) checkDriver(r io.ReaderAt, sb *core.Superblock) error {
+	info, err := core.ReadDriverInfo(r, sb)
+	if err != nil {
+		return err
+	}
+
+	var name string
+	if info != nil {
+		name = info.Name
+	}
+	switch {
+	case name == core.DriverFamily && cfg.family == nil:
+		memberSize, _ := info.FamilyMemberSize()
+		return fmt.Errorf("file was written by the family driver (members of %d bytes): open it with OpenFamily", memberSize)
+	case name == core.DriverFamily:
+		memberSize, err := info.FamilyMemberSize()
+		if err != nil {
+			return err
+		}
+		return cfg.family.setMemberSize(memberSize)
+	case cfg.family != nil && info != nil:
+		return fmt.Errorf("file was written by the %q driver, not the family driver", name)
+	case cfg.family != nil:
+		// Files written without driver information (by older libraries, or
+		// with the information suppressed) keep the member size of the
+		// first member, as the HDF5 library does.
+		return nil
+	case name == core.DriverMulti:
+		return errors.New("files written by the multi or split driver are not supported")
+	case info != nil:
+		return fmt.Errorf("unsupported file driver %q", name)
+	}
+	return nil
+}
+
+// familyReader reads the members of a family file as one file. Member i
+// holds the logical addresses [i*memberSize, (i+1)*memberSize); members
+// shorter than that read as zeros past their end, as in the HDF5 library.
+type familyReader struct {
+	members    []*os.File
+	sizes      []int64
+	memberSize int64
+}
+
+// setMemberSize sets the member size recorded in the superblock.
+func (fr *familyReader) setMemberSize(memberSize uint64) error {
+	if memberSize > 1<<62 {
+		return fmt.Errorf("family member size %d too large", memberSize)
+	}
+	size := int64(memberSize) //nolint:gosec // G115: checked above
+	for i, s := range fr.sizes {
+		if s > size {
+			return fmt.Errorf("family member %d is %d bytes, larger than the member size %d", i, s, size)
+		}
+	}
+	fr.memberSize = size
+	return nil
+}
+
+// size returns the size of the logical file.
+func (fr *familyReader) size() int64 {
+	last := len(fr.members) - 1
+	return int64(last)*fr.memberSize + fr.sizes[last]
+}
+
+// ReadAt reads from the members holding [off, off+len(p)).
+func (fr *familyReader) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, errors.New("negative offset")
+	}
+	total := fr.size()
+	n := 0
+	for n < len(p) {
+		pos := off + int64(n)
+		if pos >= total {
+			return n, io.EOF
+		}
+		i := pos / fr.memberSize
+		rel := pos % fr.memberSize
+		chunk := p[n : min(int64(len(p)-n), fr.memberSize-rel)+int64(n)]
+
+		// Zero-fill what lies beyond the member's end.
+		avail := max(fr.sizes[i]-rel, 0)
+		if avail < int64(len(chunk)) {
+			clear(chunk[avail:])
+		}
+		if avail > 0 {
+			if _, err := fr.members[i].ReadAt(chunk[:min(avail, int64(len(chunk)))], rel); err != nil {
+				return n, fmt.Errorf("family member %d: %w", i, err)
+			}
+		}
+		n += len(chunk)
+	}
+	return n, nil
+}
+
+// Close closes all members.
+func (fr *familyReader) Close() error {
+	var errs []error
+	for _, m := range fr.members {
+		errs = append(errs, m.Close())
+	}
+	fr.members = nil
+	return errors.Join(errs...)
+}
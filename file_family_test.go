@@ -0,0 +1,165 @@
+package hdf5
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/scigolib/hdf5/internal/core"
+	"github.com/stretchr/testify/require"
+)
+
+// writeFamilyFile writes a superblock v0 file, adds a family driver info
+// block as the HDF5 library does for family files, and splits it into
+// members of memberSize bytes named by pattern. It returns the values of
+// its /data dataset.
+func writeFamilyFile(t *testing.T, pattern string, memberSize int) []float64 {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "single.h5")
+
+	fw, err := CreateForWrite(path, CreateTruncate, WithSuperblockVersion(core.Version0))
+	require.NoError(t, err)
+	want := make([]float64, 500)
+	for i := range want {
+		want[i] = float64(i) * 0.5
+	}
+	ds, err := fw.CreateDataset("/data", Float64, []uint64{500})
+	require.NoError(t, err)
+	require.NoError(t, ds.Write(want))
+	require.NoError(t, fw.Close())
+
+	image, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	// Driver info block at the end of file: version, reserved, size, name,
+	// member size. Then point the superblock at it and move the EOF.
+	block := []byte{0, 0, 0, 0}
+	block = binary.LittleEndian.AppendUint32(block, 8)
+	block = append(block, core.DriverFamily...)
+	block = binary.LittleEndian.AppendUint64(block, uint64(memberSize))
+	binary.LittleEndian.PutUint64(image[48:], uint64(len(image)))
+	image = append(image, block...)
+	binary.LittleEndian.PutUint64(image[40:], uint64(len(image)))
+
+	for i := 0; i*memberSize < len(image); i++ {
+		member := image[i*memberSize : min((i+1)*memberSize, len(image))]
+		require.NoError(t, os.WriteFile(fmt.Sprintf(pattern, i), member, 0o600))
+	}
+	return want
+}
+
+// TestOpenFamily reads a dataset stored across several family members.
+func TestOpenFamily(t *testing.T) {
+	pattern := filepath.Join(t.TempDir(), "fam-%05d.h5")
+	want := writeFamilyFile(t, pattern, 1024)
+	require.FileExists(t, fmt.Sprintf(pattern, 3))
+
+	f, err := OpenFamily(pattern)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	data, err := findDatasetByPath(t, f, "/data").Read()
+	require.NoError(t, err)
+	require.Equal(t, want, data)
+}
+
+// TestOpenFamily_ShortMember reads a family whose middle member was
+// truncated: the missing bytes read as zeros.
+func TestOpenFamily_ShortMember(t *testing.T) {
+	pattern := filepath.Join(t.TempDir(), "fam-%d.h5")
+	want := writeFamilyFile(t, pattern, 4096)
+
+	// The dataset starts in member 0; drop the tail of member 1, which
+	// holds only raw data.
+	member := fmt.Sprintf(pattern, 1)
+	require.NoError(t, os.Truncate(member, 1024))
+
+	f, err := OpenFamily(pattern)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	data, err := findDatasetByPath(t, f, "/data").Read()
+	require.NoError(t, err)
+	require.Len(t, data, len(want))
+	require.Equal(t, want[len(want)-1], data[len(data)-1])
+	require.Contains(t, data, 0.0)
+}
+
+// TestOpenFamily_Errors checks that family files need OpenFamily and
+// other files need Open.
+func TestOpenFamily_Errors(t *testing.T) {
+	dir := t.TempDir()
+	pattern := filepath.Join(dir, "fam-%d.h5")
+	// One member, so that member 0 holds the driver info block, as in files
+	// written by the HDF5 library.
+	writeFamilyFile(t, pattern, 1<<16)
+
+	_, err := Open(fmt.Sprintf(pattern, 0))
+	require.ErrorContains(t, err, "open it with OpenFamily")
+
+	_, err = OpenFamily(filepath.Join(dir, "fam.h5"))
+	require.ErrorContains(t, err, "integer verb")
+
+	_, err = OpenFamily(filepath.Join(dir, "missing-%d.h5"))
+	require.Error(t, err)
+
+	_, err = OpenFamily("testdata/hdf5_official/tmulti-%d.h5")
+	require.Error(t, err)
+}
+
+// TestOpenFamily_Official reads family files written by the HDF5 library,
+// with and without driver information in the superblock.
+func TestOpenFamily_Official(t *testing.T) {
+	for _, pattern := range []string{
+		"testdata/hdf5_official/family_file%05d.h5",
+		"testdata/hdf5_official/tfamily%05d.h5",
+		"testdata/hdf5_official/family_v16-%06d.h5",
+	} {
+		t.Run(filepath.Base(pattern), func(t *testing.T) {
+			f, err := OpenFamily(pattern)
+			require.NoError(t, err)
+			defer func() { _ = f.Close() }()
+
+			var datasets int
+			f.Walk(func(_ string, obj Object) {
+				if _, ok := obj.(*Dataset); ok {
+					datasets++
+				}
+			})
+			require.Positive(t, datasets)
+		})
+	}
+}
+
+// TestOpenFamily_H5py reads a family file written by h5py.
+// This test is skipped if python3 or h5py is not available.
+func TestOpenFamily_H5py(t *testing.T) {
+	python, err := exec.LookPath("python3")
+	if err != nil {
+		t.Skip("python3 not available")
+	}
+	if err := exec.Command(python, "-c", "import h5py").Run(); err != nil {
+		t.Skip("h5py not available")
+	}
+
+	pattern := filepath.Join(t.TempDir(), "h5py-%d.h5")
+	const script = `
+import sys, h5py, numpy as np
+with h5py.File(sys.argv[1], "w", driver="family", memb_size=4096) as f:
+    f["values"] = np.arange(3000, dtype="<i4")
+`
+	out, err := exec.Command(python, "-c", script, pattern).CombinedOutput()
+	require.NoError(t, err, "h5py failed: %s", out)
+
+	f, err := OpenFamily(pattern)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	data, err := findDatasetByPath(t, f, "/values").Read()
+	require.NoError(t, err)
+	require.Len(t, data, 3000)
+	require.Equal(t, 2999.0, data[2999])
+}
@@ -0,0 +1,102 @@
+package hdf5
+
+import (
+	"strings"
+
+	"github.com/scigolib/hdf5/internal/core"
+)
+
+// WithEager selects whether Open loads the whole group hierarchy (true, the
+// default) or only checks the superblock and the root group header (false).
+//
+// With WithEager(false), a group's links are parsed, and the object headers
+// they point to are read, the first time its children are needed: by
+// Children, Err, Links, Info, path lookups or Walk. Each group is loaded
+// once. Opening a large file is then fast, and only the groups actually
+// visited are parsed. As the structure is parsed later, a damaged group
+// does not fail Open: it has no children and Err reports why.
+//
+// Example:
+//
+//	f, err := hdf5.Open("archive.h5", hdf5.WithEager(false))
+//	fmt.Println(f.SuperblockVersion()) // No group has been parsed yet.
+//	ds, err := f.Root().Dataset("summary")
+func WithEager(eager bool) OpenOption {
+	return func(cfg *openConfig) {
+		cfg.lazy = !eager
+	}
+}
+
+// openLazyRoot checks that the root group header can be read and sets the
+// root group up to load its children on first access.
+func (f *File) openLazyRoot() error {
+	address := f.sb.RootGroup
+	if readSignature(f.reader, address) != SignatureSNOD {
+		if _, err := core.ReadObjectHeader(f.reader, address, f.sb); err != nil {
+			return f.structureError("object header", address, err)
+		}
+	}
+	f.root = deferGroup(f, "/", address, func() (*Group, error) {
+		return loadGroup(f, address)
+	})
+	f.root.path = "/"
+	f.logger = nil // Events are only reported while Open runs.
+	return nil
+}
+
+// groupAt returns the group called name at address, built by load: right
+// away, or on first access of its children with WithEager(false). A
+// non-empty name replaces the one load gives the group.
+func (f *File) groupAt(name string, address uint64, load func() (*Group, error)) (*Group, error) {
+	if f.lazy {
+		return deferGroup(f, name, address, load), nil
+	}
+	group, err := load()
+	if err != nil {
+		return nil, err
+	}
+	if name != "" {
+		group.name = name
+	}
+	return group, nil
+}
+
+// deferGroup returns a group whose children, and errors, are taken from the
+// group built by load once they are first needed.
+func deferGroup(file *File, name string, address uint64, load func() (*Group, error)) *Group {
+	return &Group{file: file, name: name, address: address, load: load}
+}
+
+// ensureLoaded loads the children of a group deferred by WithEager(false).
+// The loading state of the file is shared, so loads are serialized; each
+// group is loaded once.
+func (g *Group) ensureLoaded() {
+	if g.load == nil {
+		return
+	}
+	g.loadOnce.Do(func() {
+		f := g.file
+		f.loadMu.Lock()
+		defer f.loadMu.Unlock()
+
+		// Load as Open would have: errors name the group path, and a hard
+		// link back to the group itself is not followed.
+		f.loading = strings.Split(strings.Trim(g.path, "/"), "/")
+		if g.path == "/" {
+			f.loading = nil
+		}
+		f.loadingAddrs = []uint64{g.address}
+		loaded, err := g.load()
+		f.loading, f.loadingAddrs = nil, nil
+		if err != nil {
+			g.err = err
+			return
+		}
+
+		g.children = loaded.children
+		g.err = loaded.err
+		g.symbolTable = loaded.symbolTable
+		g.localHeap = loaded.localHeap
+		assignPaths(g, g.path)
+	})
+}
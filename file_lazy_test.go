@@ -0,0 +1,123 @@
+package hdf5
+
+import (
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// walkPaths returns the paths visited by Walk, and the errors of the groups.
+func walkPaths(f *File) ([]string, map[string]string) {
+	var paths []string
+	errs := map[string]string{}
+	f.Walk(func(path string, obj Object) {
+		paths = append(paths, path)
+		if g, ok := obj.AsGroup(); ok && g.Err() != nil {
+			errs[path] = g.Err().Error()
+		}
+	})
+	return paths, errs
+}
+
+func TestOpen_WithEagerFalse_MatchesEager(t *testing.T) {
+	files := []string{
+		"testdata/v0.h5",
+		"testdata/v2.h5",
+		"testdata/v3.h5",
+		"testdata/with_groups.h5",
+		"testdata/reference/flux.h5",
+		"testdata/hdf5_official/h5repack_objs.h5",
+	}
+	for _, name := range files {
+		t.Run(name, func(t *testing.T) {
+			eager, err := Open(name)
+			require.NoError(t, err)
+			defer func() { _ = eager.Close() }()
+
+			lazy, err := Open(name, WithEager(false))
+			require.NoError(t, err)
+			defer func() { _ = lazy.Close() }()
+
+			require.Equal(t, eager.SuperblockVersion(), lazy.SuperblockVersion())
+			require.Nil(t, lazy.Root().children, "root children loaded by Open")
+
+			eagerPaths, eagerErrs := walkPaths(eager)
+			lazyPaths, lazyErrs := walkPaths(lazy)
+			require.Equal(t, eagerPaths, lazyPaths)
+			require.Equal(t, eagerErrs, lazyErrs)
+
+			for _, path := range eagerPaths {
+				obj, err := eager.lookup(path)
+				require.NoError(t, err, path)
+				lazyObj, err := lazy.lookup(path)
+				require.NoError(t, err, path)
+				require.Equal(t, obj.Path(), lazyObj.Path())
+				require.Equal(t, obj.Address(), lazyObj.Address())
+			}
+		})
+	}
+}
+
+func TestOpen_WithEagerFalse_LoadsOnAccess(t *testing.T) {
+	f, err := Open("testdata/reference/flux.h5", WithEager(false))
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	// Looking up a path loads only the groups along it.
+	obj, err := f.Root().Open("group1/flux")
+	require.NoError(t, err)
+	flux, ok := obj.AsGroup()
+	require.True(t, ok)
+	require.Equal(t, "/group1/flux", flux.Path())
+	require.Nil(t, flux.children)
+
+	links, err := flux.Links()
+	require.NoError(t, err)
+	require.Len(t, links, 3)
+	require.Len(t, flux.children, 3)
+	for _, child := range flux.Children() {
+		require.Equal(t, "/group1/flux/"+child.Name(), child.Path())
+	}
+
+	ds, err := flux.Dataset("dims")
+	require.NoError(t, err)
+	dims, err := ds.ReadStrings()
+	require.NoError(t, err)
+	require.NotEmpty(t, dims)
+}
+
+func TestOpen_WithEagerFalse_Concurrent(t *testing.T) {
+	f, err := Open("testdata/hdf5_official/h5repack_objs.h5", WithEager(false))
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	const workers = 8
+	counts := make([]int, workers)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			f.Walk(func(string, Object) { counts[i]++ })
+		}(i)
+	}
+	wg.Wait()
+	for _, n := range counts {
+		require.Equal(t, counts[0], n)
+	}
+	require.Greater(t, counts[0], 1)
+}
+
+func TestOpen_WithEagerFalse_Close(t *testing.T) {
+	f, err := Open("testdata/with_groups.h5", WithEager(false))
+	require.NoError(t, err)
+	require.NotZero(t, f.SuperblockVersion())
+	require.NoError(t, f.Close())
+	require.NoError(t, f.Close())
+
+	// Groups not loaded before Close stay empty.
+	require.Empty(t, f.Root().Children())
+	require.ErrorIs(t, f.Root().Err(), os.ErrClosed)
+}
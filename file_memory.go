@@ -0,0 +1,67 @@
+package hdf5
+
+import (
+	"github.com/scigolib/hdf5/internal/writer"
+)
+
+// MemFileWriter is a FileWriter whose file is held in memory instead of on disk.
+// All FileWriter methods are available; Bytes returns the finished file image.
+//
+// Useful for tests, and for generating files to serve over the network or
+// from environments with a read-only file system.
+type MemFileWriter struct {
+	*FileWriter
+	mem *writer.MemStorage
+}
+
+// CreateInMemory creates a new HDF5 file in memory.
+//
+// Parameters:
+//   - opts: Optional configuration, as for CreateForWrite (WithSuperblockVersion, etc.)
+//
+// Returns:
+//   - *MemFileWriter: Handle for writing; call Bytes to finish the file
+//   - error: If creation fails
+//
+// Example:
+//
+//	fw, err := hdf5.CreateInMemory()
+//	if err != nil {
+//	    return err
+//	}
+//	ds, _ := fw.CreateDataset("/temperature", hdf5.Float64, []uint64{3})
+//	_ = ds.Write([]float64{20.5, 21.0, 19.8})
+//	data, err := fw.Bytes()
+//	if err != nil {
+//	    return err
+//	}
+//	w.Header().Set("Content-Type", "application/x-hdf5")
+//	_, _ = w.Write(data)
+func CreateInMemory(opts ...interface{}) (*MemFileWriter, error) {
+	var mem *writer.MemStorage
+	fw, err := createFileWriter("", opts, func(superblockSize uint64) (*writer.FileWriter, error) {
+		w := writer.NewMemFileWriter(superblockSize)
+		mem, _ = w.Storage().(*writer.MemStorage)
+		return w, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &MemFileWriter{FileWriter: fw, mem: mem}, nil
+}
+
+// Bytes finishes the file, exactly as Close does, and returns its contents.
+// The writer cannot be used for writing afterwards; calling Bytes again
+// returns the same image.
+//
+// The returned slice can be read back with OpenReaderAt:
+//
+//	data, _ := fw.Bytes()
+//	f, err := hdf5.OpenReaderAt(bytes.NewReader(data), int64(len(data)))
+func (m *MemFileWriter) Bytes() ([]byte, error) {
+	if err := m.Close(); err != nil {
+		return nil, err
+	}
+	return m.mem.Bytes(), nil
+}
@@ -0,0 +1,79 @@
+package hdf5
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// populate writes the same content through any FileWriter.
+func populate(t *testing.T, fw *FileWriter) {
+	t.Helper()
+
+	ds, err := fw.CreateDataset("/temperature", Float64, []uint64{3})
+	require.NoError(t, err)
+	require.NoError(t, ds.Write([]float64{20.5, 21.0, 19.8}))
+	require.NoError(t, ds.WriteAttribute("units", "celsius"))
+
+	_, err = fw.CreateGroup("/raw")
+	require.NoError(t, err)
+	counts, err := fw.CreateDataset("/raw/counts", Int32, []uint64{100}, WithChunkDims([]uint64{25}), WithFletcher32())
+	require.NoError(t, err)
+	values := make([]int32, 100)
+	for i := range values {
+		values[i] = int32(i * i)
+	}
+	require.NoError(t, counts.Write(values))
+}
+
+func TestCreateInMemory(t *testing.T) {
+	for _, version := range []uint8{SuperblockV0, SuperblockV2} {
+		t.Run(fmt.Sprintf("superblock v%d", version), func(t *testing.T) {
+			mfw, err := CreateInMemory(WithSuperblockVersion(version))
+			require.NoError(t, err)
+			populate(t, mfw.FileWriter)
+
+			data, err := mfw.Bytes()
+			require.NoError(t, err)
+			again, err := mfw.Bytes()
+			require.NoError(t, err)
+			assert.Equal(t, data, again, "Bytes is idempotent")
+
+			// The image is identical to the same file written to disk.
+			path := filepath.Join(t.TempDir(), "disk.h5")
+			fw, err := CreateForWrite(path, CreateTruncate, WithSuperblockVersion(version))
+			require.NoError(t, err)
+			populate(t, fw)
+			require.NoError(t, fw.Close())
+			onDisk, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, onDisk, data)
+
+			f, err := OpenReaderAt(bytes.NewReader(data), int64(len(data)))
+			require.NoError(t, err)
+			defer func() { _ = f.Close() }()
+
+			temps, err := findDatasetByPath(t, f, "/temperature").Read()
+			require.NoError(t, err)
+			assert.Equal(t, []float64{20.5, 21.0, 19.8}, temps)
+			units, err := findDatasetByPath(t, f, "/temperature").ReadAttribute("units")
+			require.NoError(t, err)
+			assert.Equal(t, "celsius", units)
+
+			counts, err := findDatasetByPath(t, f, "/raw/counts").Read()
+			require.NoError(t, err)
+			require.Len(t, counts, 100)
+			assert.InDelta(t, 99*99, counts[99], 0)
+		})
+	}
+}
+
+func TestCreateInMemory_InvalidOption(t *testing.T) {
+	_, err := CreateInMemory("not an option")
+	require.Error(t, err)
+}
@@ -0,0 +1,68 @@
+package hdf5
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithMetadataBlockSize(t *testing.T) {
+	const blockSize = 64 * 1024
+	path := filepath.Join(t.TempDir(), "meta_block.h5")
+
+	fw, err := CreateForWrite(path, CreateTruncate, WithMetadataBlockSize(blockSize))
+	require.NoError(t, err)
+
+	const count = 50
+	for i := 0; i < count; i++ {
+		ds, err := fw.CreateDataset(fmt.Sprintf("/ds%02d", i), Float64, []uint64{100})
+		require.NoError(t, err)
+		data := make([]float64, 100)
+		for j := range data {
+			data[j] = float64(i*100 + j)
+		}
+		require.NoError(t, ds.Write(data))
+		require.NoError(t, ds.WriteAttribute("index", int32(i)))
+	}
+	require.NoError(t, fw.Close())
+
+	f, err := Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	// The object headers share one block and the data follows it, instead
+	// of alternating header, data, header, data.
+	var lowest, highest uint64 = ^uint64(0), 0
+	dataAddrs := make([]uint64, 0, count)
+	for i := 0; i < count; i++ {
+		ds := findDatasetByPath(t, f, fmt.Sprintf("/ds%02d", i))
+		lowest = min(lowest, ds.Address())
+		highest = max(highest, ds.Address())
+
+		values, err := ds.Read()
+		require.NoError(t, err)
+		require.Equal(t, float64(i*100+99), values[99])
+		index, err := f.ReadAttribute(fmt.Sprintf("/ds%02d", i), "index")
+		require.NoError(t, err)
+		require.Equal(t, int32(i), index)
+
+		layout, err := ds.Layout()
+		require.NoError(t, err)
+		dataAddrs = append(dataAddrs, layout.DataAddress)
+	}
+	require.Less(t, highest-lowest, uint64(blockSize))
+	for _, addr := range dataAddrs {
+		require.False(t, addr > lowest && addr < highest, "data at 0x%x among headers 0x%x-0x%x", addr, lowest, highest)
+	}
+
+	// The unused end of the last block is not part of the file: the
+	// end-of-file address of the version 2 superblock (at byte 28) is the
+	// file size.
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, uint64(len(raw)), binary.LittleEndian.Uint64(raw[28:36]))
+}
@@ -0,0 +1,152 @@
+package hdf5
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// writeMetadataFile writes a file with metadata in its superblock extension
+// and one dataset, /data = [1, 2, 3].
+func writeMetadataFile(t *testing.T) string {
+	t.Helper()
+	filename := filepath.Join(t.TempDir(), "metadata.h5")
+
+	fw, err := CreateForWrite(filename, CreateTruncate, WithFileMetadata(map[string]string{
+		"instrument": "LHC-7",
+		"pipeline":   "v2.3.1",
+		"empty":      "",
+	}))
+	require.NoError(t, err)
+	ds, err := fw.CreateDataset("/data", Int32, []uint64{3})
+	require.NoError(t, err)
+	require.NoError(t, ds.Write([]int32{1, 2, 3}))
+	require.NoError(t, fw.Close())
+	return filename
+}
+
+func TestWithFileMetadata(t *testing.T) {
+	f, err := Open(writeMetadataFile(t))
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	info, err := f.WriterInfo()
+	require.NoError(t, err)
+	require.True(t, info.HasExtension)
+	require.Equal(t, map[string]string{
+		"instrument": "LHC-7",
+		"pipeline":   "v2.3.1",
+		"empty":      "",
+	}, info.Metadata)
+	require.True(t, strings.HasPrefix(info.Library, modulePath), info.Library)
+
+	// The extension carries no creation settings, so the defaults apply.
+	require.Equal(t, 32, info.ChunkIndexK)
+	require.Equal(t, "H5F_FSPACE_STRATEGY_FSM_AGGR", info.FileSpaceStrategy)
+
+	// The rest of the file is unaffected.
+	data, err := findDatasetByPath(t, f, "/data").Read()
+	require.NoError(t, err)
+	require.Equal(t, []float64{1, 2, 3}, data)
+}
+
+// TestWithFileMetadata_H5dump checks that h5dump reads a file with a
+// metadata superblock extension and does not list the metadata as root
+// attributes. This test is skipped if h5dump is not available.
+func TestWithFileMetadata_H5dump(t *testing.T) {
+	h5dump, err := exec.LookPath("h5dump")
+	if err != nil {
+		t.Skip("h5dump not available")
+	}
+
+	path := writeMetadataFile(t)
+	out, err := exec.Command(h5dump, "-B", path).CombinedOutput()
+	require.NoError(t, err, "h5dump failed: %s", out)
+	require.Contains(t, string(out), `DATASET "data"`)
+	require.Contains(t, string(out), "SUPER_BLOCK")
+	require.NotContains(t, string(out), "instrument")
+}
+
+// TestWithFileMetadata_H5py reads a file with a metadata superblock
+// extension with h5py. This test is skipped if python3 or h5py is not
+// available.
+func TestWithFileMetadata_H5py(t *testing.T) {
+	python, err := exec.LookPath("python3")
+	if err != nil {
+		t.Skip("python3 not available")
+	}
+	if err := exec.Command(python, "-c", "import h5py").Run(); err != nil {
+		t.Skip("h5py not available")
+	}
+
+	path := writeMetadataFile(t)
+
+	const script = `
+import sys, h5py
+with h5py.File(sys.argv[1], "r") as f:
+    assert list(f["data"][...]) == [1, 2, 3]
+    assert "instrument" not in f.attrs
+print("ok")
+`
+	out, err := exec.Command(python, "-c", script, path).CombinedOutput()
+	require.NoError(t, err, "h5py failed: %s", out)
+}
+
+func TestWithFileMetadata_SurvivesReopen(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "metadata_reopen.h5")
+
+	fw, err := CreateForWrite(filename, CreateTruncate, WithFileMetadata(map[string]string{"site": "CERN"}))
+	require.NoError(t, err)
+	require.NoError(t, fw.Close())
+
+	fw, err = OpenForWrite(filename, OpenReadWrite)
+	require.NoError(t, err)
+	_, err = fw.CreateGroup("/later")
+	require.NoError(t, err)
+	require.NoError(t, fw.Close())
+
+	f, err := Open(filename)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	info, err := f.WriterInfo()
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"site": "CERN"}, info.Metadata)
+}
+
+func TestWithFileMetadata_Errors(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := CreateForWrite(filepath.Join(dir, "v0.h5"), CreateTruncate,
+		WithSuperblockVersion(SuperblockV0), WithFileMetadata(map[string]string{"a": "b"}))
+	require.ErrorContains(t, err, "superblock version 2")
+
+	_, err = CreateForWrite(filepath.Join(dir, "reserved.h5"), CreateTruncate,
+		WithFileMetadata(map[string]string{libraryAttr: "mine"}))
+	require.ErrorContains(t, err, "reserved")
+
+	_, err = CreateForWrite(filepath.Join(dir, "name.h5"), CreateTruncate,
+		WithFileMetadata(map[string]string{"": "b"}))
+	require.Error(t, err)
+}
+
+func TestWithFileMetadata_None(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "no_metadata.h5")
+
+	fw, err := CreateForWrite(filename, CreateTruncate)
+	require.NoError(t, err)
+	require.NoError(t, fw.Close())
+
+	f, err := Open(filename)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	info, err := f.WriterInfo()
+	require.NoError(t, err)
+	require.False(t, info.HasExtension)
+	require.Nil(t, info.Metadata)
+	require.Empty(t, info.Library)
+}
@@ -0,0 +1,197 @@
+package hdf5
+
+import (
+	"fmt"
+
+	"github.com/scigolib/hdf5/internal/core"
+)
+
+// FileStats describes space usage of a file opened for writing.
+//
+// Space freed during the session (deleted objects, relocated heaps, removed
+// attributes) is tracked by the allocator and reused by later allocations.
+// FreeBytes is therefore the space a repack could reclaim right now; free space
+// in a file as it was before OpenForWrite is not tracked and is not included.
+type FileStats struct {
+	EndOfFile      uint64 // End-of-file address (file size after Close)
+	AllocatedBytes uint64 // Bytes in use: EndOfFile minus FreeBytes
+	FreeBytes      uint64 // Freed bytes available for reuse (reclaimable by repacking)
+	FreeBlocks     int    // Number of free-space fragments
+
+	Objects             int // Objects reachable from the root group (including the root)
+	DenseAttributeHeaps int // Objects storing attributes densely (fractal heap + B-tree v2)
+	DenseLinkHeaps      int // Groups storing links densely (fractal heap + B-tree v2)
+}
+
+// Fragmentation returns the fraction of the file occupied by free space (0 to 1).
+func (s FileStats) Fragmentation() float64 {
+	if s.EndOfFile == 0 {
+		return 0
+	}
+	return float64(s.FreeBytes) / float64(s.EndOfFile)
+}
+
+// FileStats reports end-of-file, used and reclaimable space, and the number of
+// dense-storage structures in the file.
+//
+// Use it after many deletions to decide whether to run RebalanceAllBTrees or to
+// repack the file: a high Fragmentation() means a repack would shrink the file,
+// many dense heaps mean rebalancing has more B-trees to work on.
+//
+// Returns:
+//   - FileStats: Space usage snapshot
+//   - error: If the object hierarchy cannot be read
+//
+// Example:
+//
+//	stats, err := fw.FileStats()
+//	if err != nil {
+//	    return err
+//	}
+//	fmt.Printf("%d of %d bytes free (%.1f%%)\n",
+//	    stats.FreeBytes, stats.EndOfFile, 100*stats.Fragmentation())
+func (fw *FileWriter) FileStats() (FileStats, error) {
+	allocator := fw.writer.Allocator()
+
+	stats := FileStats{EndOfFile: allocator.EndOfFile()}
+	for _, block := range allocator.FreeBlocks() {
+		stats.FreeBytes += block.Size
+		stats.FreeBlocks++
+	}
+	stats.AllocatedBytes = stats.EndOfFile - stats.FreeBytes
+
+	err := fw.forEachObject(func(_ string, _ uint64, oh *core.ObjectHeader) error {
+		stats.Objects++
+		for _, msg := range oh.Messages {
+			switch msg.Type {
+			case core.MsgAttributeInfo:
+				// Objects that only track creation order keep their
+				// attributes compact and have no heap.
+				attrInfo, parseErr := core.ParseAttributeInfoMessage(msg.Data, fw.file.sb)
+				if parseErr == nil && attrInfo.FractalHeapAddr != 0 && attrInfo.FractalHeapAddr != undefinedAddress {
+					stats.DenseAttributeHeaps++
+				}
+			case core.MsgLinkInfo:
+				linkInfo, parseErr := core.ParseLinkInfoMessage(msg.Data, fw.file.sb)
+				if parseErr == nil && linkInfo.HasFractalHeap() {
+					stats.DenseLinkHeaps++
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return FileStats{}, fmt.Errorf("file stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// forEachObject visits every object reachable from the root group through hard
+// links, depth-first, calling fn with the object's path, header address and parsed
+// header. Objects reachable through several links are visited once.
+// Both symbol-table and link-info groups are traversed.
+func (fw *FileWriter) forEachObject(fn func(path string, addr uint64, oh *core.ObjectHeader) error) error {
+	visited := make(map[uint64]bool)
+
+	var visit func(path string, addr uint64) error
+	visit = func(path string, addr uint64) error {
+		if visited[addr] {
+			return nil
+		}
+		visited[addr] = true
+
+		oh, err := core.ReadObjectHeader(fw.writer.Reader(), addr, fw.file.sb)
+		if err != nil {
+			return fmt.Errorf("read object header of %q at 0x%X: %w", path, addr, err)
+		}
+		if err := fn(path, addr, oh); err != nil {
+			return err
+		}
+
+		children, err := fw.groupChildren(addr, oh)
+		if err != nil {
+			return fmt.Errorf("list children of %q: %w", path, err)
+		}
+		prefix := path
+		if prefix != "/" {
+			prefix += "/"
+		}
+		for _, child := range children {
+			if err := visit(prefix+child.name, child.addr); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return visit("/", fw.rootGroupAddr)
+}
+
+// groupChild is a named hard link from a group to an object header.
+type groupChild struct {
+	name string
+	addr uint64
+}
+
+// groupChildren lists the hard links of a group object, or nil for non-groups.
+func (fw *FileWriter) groupChildren(addr uint64, oh *core.ObjectHeader) ([]groupChild, error) {
+	for _, msg := range oh.Messages {
+		if msg.Type != core.MsgLinkInfo {
+			continue
+		}
+		links, err := fw.readModernGroupLinks(oh)
+		if err != nil {
+			return nil, err
+		}
+		children := make([]groupChild, 0, len(links))
+		for _, link := range links {
+			if link.IsHardLink() {
+				children = append(children, groupChild{name: link.Name, addr: link.ObjectAddress})
+			}
+		}
+		return children, nil
+	}
+
+	btreeAddr, heapAddr := uint64(0), uint64(0)
+	for _, msg := range oh.Messages {
+		if msg.Type == core.MsgSymbolTable && len(msg.Data) >= 2*int(fw.file.sb.OffsetSize) {
+			osSize := int(fw.file.sb.OffsetSize)
+			btreeAddr = readAddrFromBuf(msg.Data, osSize, fw.file.sb.Endianness)
+			heapAddr = readAddrFromBuf(msg.Data[osSize:], osSize, fw.file.sb.Endianness)
+			break
+		}
+	}
+	if btreeAddr == 0 && addr == fw.rootGroupAddr {
+		// Superblock v0: the root symbol table may only be cached in the superblock.
+		btreeAddr, heapAddr = fw.rootBTreeAddr, fw.rootHeapAddr
+	}
+	if btreeAddr == 0 || btreeAddr == undefinedAddress {
+		return nil, nil
+	}
+
+	_, snodAddrs, err := fw.readGroupBTree(btreeAddr)
+	if err != nil {
+		return nil, fmt.Errorf("read group B-tree: %w", err)
+	}
+	heap, err := fw.readLocalHeap(heapAddr)
+	if err != nil {
+		return nil, fmt.Errorf("read local heap: %w", err)
+	}
+
+	var children []groupChild
+	for _, snodAddr := range snodAddrs {
+		node, err := fw.readSymbolTableNode(snodAddr)
+		if err != nil {
+			return nil, fmt.Errorf("read symbol table node: %w", err)
+		}
+		for _, entry := range node.Entries {
+			name, err := heap.GetString(entry.LinkNameOffset)
+			if err != nil {
+				return nil, fmt.Errorf("read link name: %w", err)
+			}
+			children = append(children, groupChild{name: name, addr: entry.ObjectAddress})
+		}
+	}
+	return children, nil
+}
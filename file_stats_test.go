@@ -0,0 +1,126 @@
+package hdf5
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/scigolib/hdf5/internal/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileStats(t *testing.T) {
+	fw, err := CreateForWrite(filepath.Join(t.TempDir(), "stats.h5"), CreateTruncate, WithModernGroups())
+	require.NoError(t, err)
+	defer func() { _ = fw.Close() }()
+
+	stats, err := fw.FileStats()
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.Objects, "empty file has only the root group")
+	assert.Zero(t, stats.FreeBytes)
+	assert.Equal(t, stats.EndOfFile, stats.AllocatedBytes)
+
+	// Dataset with enough attributes to switch to dense attribute storage.
+	ds, err := fw.CreateDataset("/measurements", Float64, []uint64{100})
+	require.NoError(t, err)
+	require.NoError(t, ds.Write(make([]float64, 100)))
+	for i := 0; i < MaxCompactAttributes+2; i++ {
+		require.NoError(t, ds.WriteAttribute(fmt.Sprintf("attr_%02d", i), int32(i)))
+	}
+
+	// Group with enough links to switch to dense link storage.
+	_, err = fw.CreateGroup("/runs")
+	require.NoError(t, err)
+	for i := 0; i < denseGroupThreshold+2; i++ {
+		_, err := fw.CreateGroup(fmt.Sprintf("/runs/run_%02d", i))
+		require.NoError(t, err)
+	}
+
+	_, err = fw.CreateDataset("/tail", Int32, []uint64{4})
+	require.NoError(t, err)
+
+	stats, err = fw.FileStats()
+	require.NoError(t, err)
+	assert.Equal(t, 1+1+1+(denseGroupThreshold+2)+1, stats.Objects)
+	assert.Equal(t, 1, stats.DenseAttributeHeaps)
+	assert.Equal(t, 1, stats.DenseLinkHeaps)
+	assert.Equal(t, fw.writer.Allocator().EndOfFile(), stats.EndOfFile)
+
+	// Deleting an object in the middle of the file leaves reclaimable space.
+	require.NoError(t, fw.Delete("/measurements"))
+	after, err := fw.FileStats()
+	require.NoError(t, err)
+	assert.Positive(t, after.FreeBytes)
+	assert.Positive(t, after.FreeBlocks)
+	assert.Equal(t, after.EndOfFile-after.FreeBytes, after.AllocatedBytes)
+	assert.Equal(t, stats.Objects-1, after.Objects)
+	assert.Zero(t, after.DenseAttributeHeaps)
+	assert.Greater(t, after.Fragmentation(), 0.0)
+	assert.Less(t, after.Fragmentation(), 1.0)
+}
+
+// TestFileStats_CompactAttributeInfo checks that an Attribute Info message
+// without a fractal heap, as written for objects that track attribute
+// creation order, is not counted as dense attribute storage.
+func TestFileStats_CompactAttributeInfo(t *testing.T) {
+	fw, err := CreateForWrite(filepath.Join(t.TempDir(), "stats_ainfo.h5"), CreateTruncate, WithModernGroups())
+	require.NoError(t, err)
+	defer func() { _ = fw.Close() }()
+
+	// The last object in the file, so its header can grow in place.
+	ds, err := fw.CreateDataset("/ordered", Int32, []uint64{4})
+	require.NoError(t, err)
+	require.NoError(t, ds.WriteAttribute("units", "K"))
+
+	sb := fw.file.sb
+	oh, err := core.ReadObjectHeader(fw.writer.Reader(), ds.address, sb)
+	require.NoError(t, err)
+	infoMsg, err := core.EncodeAttributeInfoMessage(&core.AttributeInfoMessage{
+		Flags:              0x01, // Creation order tracked, not indexed.
+		FractalHeapAddr:    undefinedAddress,
+		BTreeNameIndexAddr: undefinedAddress,
+	}, sb)
+	require.NoError(t, err)
+	require.NoError(t, core.AddMessageToObjectHeader(oh, core.MsgAttributeInfo, infoMsg))
+	require.NoError(t, writeOHDRWithBoundsCheck(fw, ds.address, oh, sb))
+
+	stats, err := fw.FileStats()
+	require.NoError(t, err)
+	assert.Equal(t, 2, stats.Objects)
+	assert.Zero(t, stats.DenseAttributeHeaps)
+}
+
+func TestFileStats_SymbolTableGroups(t *testing.T) {
+	for _, version := range []uint8{SuperblockV0, SuperblockV2} {
+		t.Run(fmt.Sprintf("superblock v%d", version), func(t *testing.T) {
+			fw, err := CreateForWrite(filepath.Join(t.TempDir(), "stats_sym.h5"), CreateTruncate,
+				WithSuperblockVersion(version))
+			require.NoError(t, err)
+			defer func() { _ = fw.Close() }()
+
+			_, err = fw.CreateGroup("/a")
+			require.NoError(t, err)
+			_, err = fw.CreateGroup("/a/b")
+			require.NoError(t, err)
+			_, err = fw.CreateDataset("/a/b/data", Int32, []uint64{2})
+			require.NoError(t, err)
+
+			var paths []string
+			require.NoError(t, fw.forEachObject(func(path string, _ uint64, _ *core.ObjectHeader) error {
+				paths = append(paths, path)
+				return nil
+			}))
+			assert.Equal(t, []string{"/", "/a", "/a/b", "/a/b/data"}, paths)
+
+			stats, err := fw.FileStats()
+			require.NoError(t, err)
+			assert.Equal(t, 4, stats.Objects)
+			assert.Zero(t, stats.DenseLinkHeaps)
+		})
+	}
+}
+
+func TestFileStats_Fragmentation_Empty(t *testing.T) {
+	assert.Zero(t, FileStats{}.Fragmentation())
+}
@@ -1,6 +1,9 @@
 package hdf5
 
 import (
+	"bytes"
+	"io"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -77,6 +80,96 @@ func TestFileClose(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// TestFileClose_ReadAfterClose checks that reads after Close fail with
+// os.ErrClosed instead of panicking.
+func TestFileClose_ReadAfterClose(t *testing.T) {
+	for _, lazy := range []bool{false, true} {
+		f, err := Open("testdata/reference/flux.h5", WithEager(!lazy))
+		require.NoError(t, err)
+		obj, err := f.Root().Open("group1/flux")
+		require.NoError(t, err)
+		flux, ok := obj.AsGroup()
+		require.True(t, ok)
+		dims, err := flux.Dataset("dims")
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+
+		_, err = dims.Read()
+		require.ErrorIs(t, err, os.ErrClosed)
+		_, err = dims.ReadStrings()
+		require.ErrorIs(t, err, os.ErrClosed)
+		_, err = dims.Attributes()
+		require.ErrorIs(t, err, os.ErrClosed)
+		_, err = flux.Attributes()
+		require.ErrorIs(t, err, os.ErrClosed)
+		_, err = flux.Links()
+		require.ErrorIs(t, err, os.ErrClosed)
+	}
+}
+
+// countingReaderAt records how many ReadAt calls were made, standing in for
+// a remote (e.g. range-request) reader.
+type countingReaderAt struct {
+	r     io.ReaderAt
+	reads int
+}
+
+func (c *countingReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	c.reads++
+	return c.r.ReadAt(p, off)
+}
+
+// TestOpenReaderAt opens files from memory through a non-os.File reader.
+func TestOpenReaderAt(t *testing.T) {
+	for _, name := range []string{"testdata/with_groups.h5", "testdata/dense_links.h5"} {
+		t.Run(name, func(t *testing.T) {
+			data, err := os.ReadFile(name)
+			require.NoError(t, err)
+
+			fromDisk, err := Open(name)
+			require.NoError(t, err)
+			defer func() { _ = fromDisk.Close() }()
+
+			src := &countingReaderAt{r: bytes.NewReader(data)}
+			f, err := OpenReaderAt(src, int64(len(data)))
+			require.NoError(t, err)
+			require.Positive(t, src.reads)
+			require.Equal(t, fromDisk.SuperblockVersion(), f.SuperblockVersion())
+
+			var diskPaths, memPaths []string
+			fromDisk.Walk(func(p string, _ Object) { diskPaths = append(diskPaths, p) })
+			f.Walk(func(p string, obj Object) {
+				memPaths = append(memPaths, p)
+				if ds, ok := obj.(*Dataset); ok {
+					want, wantErr := findDatasetByPath(t, fromDisk, p).Read()
+					got, gotErr := ds.Read()
+					require.Equal(t, wantErr == nil, gotErr == nil, p)
+					require.Equal(t, want, got, p)
+				}
+			})
+			require.Equal(t, diskPaths, memPaths)
+
+			// Close leaves the caller's reader alone and is idempotent.
+			require.NoError(t, f.Close())
+			require.NoError(t, f.Close())
+		})
+	}
+}
+
+func TestOpenReaderAt_Invalid(t *testing.T) {
+	_, err := OpenReaderAt(nil, 0)
+	require.Error(t, err)
+
+	_, err = OpenReaderAt(bytes.NewReader([]byte("not an hdf5 file at all")), 23)
+	require.ErrorContains(t, err, "not an HDF5 file")
+
+	// A truncated image fails validation instead of reading past the end.
+	data, err := os.ReadFile("testdata/v2.h5")
+	require.NoError(t, err)
+	_, err = OpenReaderAt(bytes.NewReader(data[:64]), 64)
+	require.Error(t, err)
+}
+
 // TestWalk tests the Walk functionality for traversing file structure.
 func TestWalk(t *testing.T) {
 	file, err := Open("testdata/with_groups.h5")
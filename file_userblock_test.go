@@ -0,0 +1,121 @@
+package hdf5
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/scigolib/hdf5/internal/core"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithUserBlock(t *testing.T) {
+	for _, version := range []uint8{core.Version0, core.Version2} {
+		t.Run(fmt.Sprintf("v%d", version), func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "userblock.h5")
+			fw, err := CreateForWrite(path, CreateTruncate, WithUserBlock(1024), WithSuperblockVersion(version))
+			require.NoError(t, err)
+			_, err = fw.CreateGroup("/grp")
+			require.NoError(t, err)
+			ds, err := fw.CreateDataset("/grp/data", Float64, []uint64{4})
+			require.NoError(t, err)
+			require.NoError(t, ds.Write([]float64{1, 2, 3, 4}))
+			require.NoError(t, ds.WriteAttribute("units", "m"))
+			names, err := fw.CreateDataset("/names", VLenString, []uint64{2})
+			require.NoError(t, err)
+			require.NoError(t, names.Write([]string{"alpha", "beta"}))
+			require.NoError(t, fw.Close())
+
+			// The user block is left for the application.
+			raw, err := os.ReadFile(path)
+			require.NoError(t, err)
+			require.Equal(t, make([]byte, 1024), raw[:1024])
+			require.Equal(t, core.Signature, string(raw[1024:1032]))
+			banner := []byte("application header")
+			f, err := os.OpenFile(path, os.O_RDWR, 0)
+			require.NoError(t, err)
+			_, err = f.WriteAt(banner, 0)
+			require.NoError(t, err)
+			require.NoError(t, f.Close())
+
+			// Reopen, modify and read back.
+			fw, err = OpenForWrite(path, OpenReadWrite)
+			require.NoError(t, err)
+			extra, err := fw.CreateDataset("/extra", Int32, []uint64{3})
+			require.NoError(t, err)
+			require.NoError(t, extra.Write([]int32{7, 8, 9}))
+			require.NoError(t, fw.Close())
+
+			file, err := Open(path)
+			require.NoError(t, err)
+			defer func() { _ = file.Close() }()
+			require.Equal(t, uint64(1024), file.Superblock().BaseAddress)
+
+			values, err := findDatasetByPath(t, file, "/grp/data").Read()
+			require.NoError(t, err)
+			require.Equal(t, []float64{1, 2, 3, 4}, values)
+			units, err := findDatasetByPath(t, file, "/grp/data").ReadAttribute("units")
+			require.NoError(t, err)
+			require.Equal(t, "m", units)
+			strs, err := findDatasetByPath(t, file, "/names").ReadStrings()
+			require.NoError(t, err)
+			require.Equal(t, []string{"alpha", "beta"}, strs)
+			values, err = findDatasetByPath(t, file, "/extra").Read()
+			require.NoError(t, err)
+			require.Equal(t, []float64{7, 8, 9}, values)
+
+			raw, err = os.ReadFile(path)
+			require.NoError(t, err)
+			require.True(t, bytes.HasPrefix(raw, banner))
+		})
+	}
+}
+
+func TestWithUserBlock_InMemory(t *testing.T) {
+	fw, err := CreateInMemory(WithUserBlock(512))
+	require.NoError(t, err)
+	ds, err := fw.CreateDataset("/data", Int32, []uint64{3}, WithChunkDims([]uint64{2}))
+	require.NoError(t, err)
+	require.NoError(t, ds.Write([]int32{1, 2, 3}))
+	image, err := fw.Bytes()
+	require.NoError(t, err)
+	require.Equal(t, core.Signature, string(image[512:520]))
+
+	f, err := OpenReaderAt(bytes.NewReader(image), int64(len(image)))
+	require.NoError(t, err)
+	values, err := findDatasetByPath(t, f, "/data").Read()
+	require.NoError(t, err)
+	require.Equal(t, []float64{1, 2, 3}, values)
+}
+
+// A user block prepended to an existing file (as h5jam does) is found by
+// searching for the signature at power-of-two offsets.
+func TestOpen_PrependedUserBlock(t *testing.T) {
+	fw, err := CreateInMemory()
+	require.NoError(t, err)
+	ds, err := fw.CreateDataset("/data", Float64, []uint64{2})
+	require.NoError(t, err)
+	require.NoError(t, ds.Write([]float64{0.5, 1.5}))
+	image, err := fw.Bytes()
+	require.NoError(t, err)
+
+	withBlock := append(bytes.Repeat([]byte{'#'}, 2048), image...)
+	f, err := OpenReaderAt(bytes.NewReader(withBlock), int64(len(withBlock)))
+	require.NoError(t, err)
+	require.Equal(t, uint64(2048), f.Superblock().BaseAddress)
+	values, err := findDatasetByPath(t, f, "/data").Read()
+	require.NoError(t, err)
+	require.Equal(t, []float64{0.5, 1.5}, values)
+
+	_, err = OpenReaderAt(bytes.NewReader(withBlock[:2048]), 2048)
+	require.ErrorContains(t, err, "not an HDF5 file")
+}
+
+func TestWithUserBlock_Invalid(t *testing.T) {
+	for _, size := range []uint64{100, 511, 768} {
+		_, err := CreateInMemory(WithUserBlock(size))
+		require.ErrorContains(t, err, "power of two")
+	}
+}
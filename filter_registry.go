@@ -0,0 +1,62 @@
+package hdf5
+
+import (
+	"github.com/scigolib/hdf5/internal/core"
+	"github.com/scigolib/hdf5/internal/writer"
+)
+
+// FilterFunc transforms the bytes of one chunk for a custom filter.
+// clientData holds the filter parameters (cd_values) stored with the dataset.
+type FilterFunc func(data []byte, clientData []uint32) ([]byte, error)
+
+// RegisterFilter registers a third-party HDF5 filter, such as a compression
+// plugin from the HDF Group filter registry. Reads consult the registry
+// before failing with *UnsupportedFilterError, and WithFilter adds the filter
+// to datasets being written.
+//
+// Either function may be nil for a read-only (encode nil) or write-only
+// (decode nil) filter. Registering an ID again replaces the earlier filter.
+// The filters implemented by this package (deflate, shuffle, Fletcher32,
+// BZIP2, LZF, Zstd) cannot be replaced.
+//
+// Parameters:
+//   - id: HDF5 filter identifier (IDs 256-511 and above 32767 are for third parties)
+//   - name: Filter name stored in the pipeline message of written datasets
+//   - decode: Reverses the filter on read
+//   - encode: Applies the filter on write
+//
+// Returns:
+//   - error: If the ID is reserved or built in, or both functions are nil
+//
+// Example:
+//
+//	err := hdf5.RegisterFilter(32015, "zstd", zstdDecode, zstdEncode)
+func RegisterFilter(id uint16, name string, decode, encode FilterFunc) error {
+	return core.RegisterFilter(core.RegisteredFilter{
+		ID:     core.FilterID(id),
+		Name:   name,
+		Decode: core.FilterFunc(decode),
+		Encode: core.FilterFunc(encode),
+	})
+}
+
+// WithFilter adds a filter registered with RegisterFilter to the dataset's
+// filter pipeline, after any filters already added. clientData is stored
+// with the dataset and passed to the filter functions.
+// This option is only valid for chunked datasets (requires WithChunkDims).
+//
+// Writing fails if the filter is not registered or has no encode function.
+//
+// Example:
+//
+//	ds, _ := fw.CreateDataset("/data", hdf5.Float64, []uint64{1000},
+//	    hdf5.WithChunkDims([]uint64{100}),
+//	    hdf5.WithFilter(32015, 3))
+func WithFilter(id uint16, clientData ...uint32) DatasetOption {
+	return func(cfg *datasetConfig) {
+		if cfg.pipeline == nil {
+			cfg.pipeline = writer.NewFilterPipeline()
+		}
+		cfg.pipeline.AddFilter(writer.NewCustomFilter(writer.FilterID(id), clientData))
+	}
+}
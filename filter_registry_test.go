@@ -0,0 +1,59 @@
+package hdf5
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// reverseFilter reverses the chunk bytes; it is its own inverse.
+func reverseFilter(data []byte, _ []uint32) ([]byte, error) {
+	out := make([]byte, len(data))
+	for i, b := range data {
+		out[len(data)-1-i] = b
+	}
+	return out, nil
+}
+
+func TestRegisterFilter_RoundTrip(t *testing.T) {
+	const id = 40000
+	filename := filepath.Join(t.TempDir(), "custom_filter.h5")
+
+	fw, err := CreateForWrite(filename, CreateTruncate)
+	require.NoError(t, err)
+	ds, err := fw.CreateDataset("/data", Float64, []uint64{8},
+		WithChunkDims([]uint64{4}), WithFilter(id, 7))
+	require.NoError(t, err)
+	require.ErrorContains(t, ds.Write([]float64{1, 2, 3, 4, 5, 6, 7, 8}), "not registered")
+	require.NoError(t, fw.Close())
+
+	require.ErrorContains(t, RegisterFilter(1, "deflate", reverseFilter, reverseFilter), "built in")
+	require.NoError(t, RegisterFilter(id, "reverse", reverseFilter, reverseFilter))
+
+	fw, err = CreateForWrite(filename, CreateTruncate)
+	require.NoError(t, err)
+	ds, err = fw.CreateDataset("/data", Float64, []uint64{8},
+		WithChunkDims([]uint64{4}), WithFilter(id, 7))
+	require.NoError(t, err)
+	want := []float64{1, 2, 3, 4, 5, 6, 7, 8}
+	require.NoError(t, ds.Write(want))
+	require.NoError(t, fw.Close())
+
+	f, err := Open(filename)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	d := findDatasetByPath(t, f, "/data")
+	filters, err := d.Filters()
+	require.NoError(t, err)
+	require.Len(t, filters, 1)
+	require.Equal(t, uint16(id), uint16(filters[0].ID))
+	require.Equal(t, "reverse", filters[0].Name)
+	require.Equal(t, []uint32{7}, filters[0].ClientData)
+	require.True(t, filters[0].Supported())
+
+	got, err := d.Read()
+	require.NoError(t, err)
+	require.Equal(t, want, got)
+}
@@ -110,7 +110,7 @@ func (ghw *globalHeapWriter) createNewHeap(minObjectSize uint64) error {
 	}
 
 	// Allocate space in file
-	heapAddr, err := ghw.fileWriter.writer.Allocate(collectionSize)
+	heapAddr, err := ghw.fileWriter.writer.AllocateRaw(collectionSize)
 	if err != nil {
 		return fmt.Errorf("allocate heap space: %w", err)
 	}
@@ -135,7 +135,7 @@ func (ghw *globalHeapWriter) flushCurrentHeap() error {
 	}
 
 	// Encode heap collection to bytes
-	heapData := ghw.encodeHeapCollection()
+	heapData := ghw.currentHeap.encode()
 
 	// Write to file
 	//nolint:gosec // G115: HDF5 addresses fit in int64 for io.WriterAt interface
@@ -146,10 +146,10 @@ func (ghw *globalHeapWriter) flushCurrentHeap() error {
 	return nil
 }
 
-// encodeHeapCollection encodes the current heap collection to bytes.
+// encode encodes the heap collection to bytes.
 // Format follows HDF5 spec section 3.2.6.
-func (ghw *globalHeapWriter) encodeHeapCollection() []byte {
-	heap := ghw.currentHeap
+func (ghc *globalHeapCollectionBuilder) encode() []byte {
+	heap := ghc
 	buf := make([]byte, heap.size)
 
 	offset := 0
@@ -286,3 +286,136 @@ func alignTo8(size uint64) uint64 {
 func (ghw *globalHeapWriter) Flush() error {
 	return ghw.flushCurrentHeap()
 }
+
+// UpdateObject replaces the data of the heap object referenced by id and
+// returns the heap ID that now refers to the data.
+//
+// Data that fits in the object's existing 8-byte-aligned slot is written in
+// place and id is returned unchanged. Larger data is appended as a new object
+// (possibly in a new collection) and the old object is removed from its
+// collection; the caller must store the returned ID. A zero collection
+// address (a never-written element) always appends.
+//
+// Collections already flushed to disk are read back, modified, and rewritten
+// at their original address; the collection currently being filled is
+// modified in memory.
+//
+// Reference: H5HG.c - H5HG_modify(), H5HG_remove().
+func (ghw *globalHeapWriter) UpdateObject(id HeapID, data []byte) (HeapID, error) {
+	if id.CollectionAddress == 0 || id.CollectionAddress == undefinedAddress {
+		return ghw.WriteToGlobalHeap(data)
+	}
+
+	heap, onDisk, err := ghw.loadCollection(id.CollectionAddress)
+	if err != nil {
+		return HeapID{}, err
+	}
+
+	if heap.replaceObject(id.ObjectIndex, data) {
+		if onDisk {
+			if err := ghw.rewriteCollection(heap); err != nil {
+				return HeapID{}, err
+			}
+		}
+		return id, nil
+	}
+
+	if !heap.removeObject(id.ObjectIndex) {
+		return HeapID{}, fmt.Errorf("object %d not found in heap collection at 0x%X",
+			id.ObjectIndex, id.CollectionAddress)
+	}
+	if onDisk {
+		if err := ghw.rewriteCollection(heap); err != nil {
+			return HeapID{}, err
+		}
+	}
+	return ghw.WriteToGlobalHeap(data)
+}
+
+// loadCollection returns a builder for the collection at address. The
+// in-memory current heap is returned directly (onDisk false); any other
+// collection is read from the file.
+func (ghw *globalHeapWriter) loadCollection(address uint64) (*globalHeapCollectionBuilder, bool, error) {
+	if ghw.currentHeap != nil && ghw.currentHeap.address == address {
+		return ghw.currentHeap, false, nil
+	}
+
+	offsetSize := int(ghw.fileWriter.file.Superblock().OffsetSize)
+	collection, err := core.ReadGlobalHeapCollection(ghw.fileWriter.writer.Reader(), address, offsetSize)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read global heap collection at 0x%X: %w", address, err)
+	}
+	return newCollectionBuilderFrom(collection), true, nil
+}
+
+// rewriteCollection writes a modified on-disk collection back in place.
+func (ghw *globalHeapWriter) rewriteCollection(heap *globalHeapCollectionBuilder) error {
+	//nolint:gosec // G115: HDF5 addresses fit in int64 for io.WriterAt interface
+	if _, err := ghw.fileWriter.writer.WriteAt(heap.encode(), int64(heap.address)); err != nil {
+		return fmt.Errorf("rewrite heap collection at 0x%X: %w", heap.address, err)
+	}
+	return nil
+}
+
+// newCollectionBuilderFrom converts a collection read from disk into a builder
+// so it can be modified and re-encoded. Objects are packed in index order.
+func newCollectionBuilderFrom(collection *core.GlobalHeapCollection) *globalHeapCollectionBuilder {
+	heap := &globalHeapCollectionBuilder{
+		address:   collection.Address,
+		size:      collection.Size,
+		objects:   make([]*globalHeapObjectBuilder, 0, len(collection.Objects)),
+		nextIndex: 1,
+		usedSpace: 16, // Collection header
+	}
+	for _, obj := range collection.Objects {
+		index := uint16(obj.Index) //nolint:gosec // G115: object indices are 16-bit on disk
+		heap.objects = append(heap.objects, &globalHeapObjectBuilder{
+			index:    index,
+			refCount: obj.NRefs,
+			data:     obj.Data,
+		})
+		heap.usedSpace += 16 + alignTo8(uint64(len(obj.Data)))
+		if index >= heap.nextIndex {
+			heap.nextIndex = index + 1
+		}
+	}
+	heap.freeSpace = heap.size - heap.usedSpace
+	return heap
+}
+
+// replaceObject replaces the data of object index if the new data fits in the
+// object's current aligned slot. It reports whether the object was replaced.
+func (ghc *globalHeapCollectionBuilder) replaceObject(index uint16, data []byte) bool {
+	for _, obj := range ghc.objects {
+		if obj.index != index {
+			continue
+		}
+		oldSize := alignTo8(uint64(len(obj.data)))
+		newSize := alignTo8(uint64(len(data)))
+		if newSize > oldSize {
+			return false
+		}
+		obj.data = data
+		ghc.usedSpace -= oldSize - newSize
+		ghc.freeSpace += oldSize - newSize
+		return true
+	}
+	return false
+}
+
+// removeObject removes object index from the collection, returning its space
+// to the free-space marker. Remaining objects keep their indices, so heap IDs
+// referring to them stay valid. It reports whether the object was found.
+func (ghc *globalHeapCollectionBuilder) removeObject(index uint16) bool {
+	for i, obj := range ghc.objects {
+		if obj.index != index {
+			continue
+		}
+		size := 16 + alignTo8(uint64(len(obj.data)))
+		ghc.objects = append(ghc.objects[:i], ghc.objects[i+1:]...)
+		ghc.usedSpace -= size
+		ghc.freeSpace += size
+		return true
+	}
+	return false
+}
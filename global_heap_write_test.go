@@ -3,10 +3,15 @@ package hdf5
 import (
 	"bytes"
 	"encoding/binary"
+	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/scigolib/hdf5/internal/core"
+	"github.com/stretchr/testify/require"
 )
 
 // TestWriteToGlobalHeap tests writing a single object to global heap.
@@ -349,3 +354,46 @@ func TestHeapIDEncoding(t *testing.T) {
 		t.Errorf("Index encoding incorrect: %x", encoded[12:16])
 	}
 }
+
+// TestVLenReadsAcrossHeapCollections writes more variable-length data than
+// fits one global heap collection and reads it back through the dataset and
+// attribute readers, which must follow each heap ID to its own collection.
+func TestVLenReadsAcrossHeapCollections(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vlen_collections.h5")
+	fw, err := CreateForWrite(path, CreateTruncate)
+	require.NoError(t, err)
+
+	values := make([]string, 300)
+	for i := range values {
+		values[i] = fmt.Sprintf("%03d-%s", i, strings.Repeat("x", 60))
+	}
+	ds, err := fw.CreateDataset("/strings", VLenString, []uint64{uint64(len(values))})
+	require.NoError(t, err)
+	require.NoError(t, ds.Write(values))
+	require.NoError(t, ds.WriteAttribute("labels", values[:100]))
+	require.NoError(t, fw.Close())
+
+	f, err := Open(path)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+	rd := findDatasetByPath(t, f, "/strings")
+
+	// Each element is a 16-byte heap ID: length, collection address, index.
+	r, err := rd.NewReader()
+	require.NoError(t, err)
+	raw, err := io.ReadAll(r)
+	require.NoError(t, err)
+	collections := make(map[uint64]bool)
+	for i := 0; i+16 <= len(raw); i += 16 {
+		collections[binary.LittleEndian.Uint64(raw[i+4:])] = true
+	}
+	require.Greater(t, len(collections), 1, "data should span several heap collections")
+
+	got, err := rd.ReadStrings()
+	require.NoError(t, err)
+	require.Equal(t, values, got)
+
+	attr, err := rd.ReadAttribute("labels")
+	require.NoError(t, err)
+	require.Equal(t, values[:100], attr)
+}
@@ -3,6 +3,8 @@ package hdf5
 import (
 	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/scigolib/hdf5/internal/core"
 	"github.com/scigolib/hdf5/internal/structures"
@@ -14,15 +16,36 @@ const (
 	SignatureSNOD = "SNOD" // Symbol table node signature.
 )
 
-// Object represents any HDF5 object (Group or Dataset) that can be accessed in the file structure.
+// Object represents any HDF5 object (Group, Dataset or NamedDatatype) that can
+// be accessed in the file structure.
+//
+// Use Kind or the Is/As helpers to branch on the concrete type without type
+// assertions.
 type Object interface {
+	// Name returns the link name of the object ("/" for the root group).
 	Name() string
+	// Path returns the absolute path of the object, e.g. "/group/data".
+	Path() string
+	// Kind reports whether the object is a group, dataset or named datatype.
+	Kind() ObjectKind
+	// IsGroup reports whether the object is a group.
+	IsGroup() bool
+	// IsDataset reports whether the object is a dataset.
+	IsDataset() bool
+	// AsGroup returns the object as a *Group, or false if it is not a group.
+	AsGroup() (*Group, bool)
+	// AsDataset returns the object as a *Dataset, or false if it is not a dataset.
+	AsDataset() (*Dataset, bool)
+	// Address returns the object header address. Hard links to the same
+	// object share one address.
+	Address() uint64
 }
 
 // Dataset represents an HDF5 dataset containing multidimensional array data.
 type Dataset struct {
 	file    *File
 	name    string
+	path    string // Absolute path, assigned once the hierarchy is loaded.
 	address uint64 // Address of object header.
 }
 
@@ -32,6 +55,7 @@ type Dataset struct {
 type NamedDatatype struct {
 	file     *File
 	name     string
+	path     string                // Absolute path, assigned once the hierarchy is loaded.
 	address  uint64                // Address of object header.
 	datatype *core.DatatypeMessage // The stored datatype definition.
 }
@@ -58,13 +82,49 @@ func (d *Dataset) Address() uint64 {
 
 // Attributes returns all attributes attached to this dataset.
 func (d *Dataset) Attributes() ([]*core.Attribute, error) {
-	header, err := core.ReadObjectHeader(d.file.osFile, d.address, d.file.sb)
+	header, err := core.ReadObjectHeader(d.file.reader, d.address, d.file.sb)
 	if err != nil {
 		return nil, err
 	}
 	return header.Attributes, nil
 }
 
+// AttributeCount returns the number of attributes attached to this dataset
+// without reading them: only the object header and, for dense attribute
+// storage, the header of the attribute name index are read.
+//
+// Example:
+//
+//	n, err := ds.AttributeCount()
+//	fmt.Printf("%s (%d attributes)\n", ds.Name(), n)
+func (d *Dataset) AttributeCount() (int, error) {
+	return core.CountAttributes(d.file.reader, d.address, d.file.sb)
+}
+
+// ModTime returns the time the dataset was last modified, as recorded in its
+// object header. The boolean is false when the file does not track times for
+// this object (see WithTrackTimes), or the header cannot be read.
+func (d *Dataset) ModTime() (time.Time, bool) {
+	header, err := core.ReadObjectHeader(d.file.reader, d.address, d.file.sb)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return header.ModTime()
+}
+
+// CreationTime returns the time the dataset was created, as recorded in its
+// object header. The boolean is false when the file does not track times for
+// this object (see WithTrackTimes), when it has a version 1 object header,
+// which records only the modification time, or when the header cannot be
+// read.
+func (d *Dataset) CreationTime() (time.Time, bool) {
+	header, err := core.ReadObjectHeader(d.file.reader, d.address, d.file.sb)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return header.CreationTime()
+}
+
 // ListAttributes returns the names of all attributes attached to this dataset.
 func (d *Dataset) ListAttributes() ([]string, error) {
 	attrs, err := d.Attributes()
@@ -101,41 +161,50 @@ func (d *Dataset) ReadAttribute(name string) (interface{}, error) {
 // All values are converted to float64 for convenience.
 func (d *Dataset) Read() ([]float64, error) {
 	// Read object header for this dataset.
-	header, err := core.ReadObjectHeader(d.file.osFile, d.address, d.file.sb)
+	header, err := core.ReadObjectHeader(d.file.reader, d.address, d.file.sb)
 	if err != nil {
 		return nil, err
 	}
 
 	// Use the dataset reader to get values.
-	return core.ReadDatasetFloat64(d.file.osFile, header, d.file.sb)
+	return core.ReadDatasetFloat64(d.file.reader, header, d.file.sb, d.file.readOptions()...)
 }
 
 // ReadStrings reads string dataset values and returns them as string array.
-// Supports fixed-length strings (null-terminated, null-padded, space-padded).
-// Variable-length strings are not yet supported.
+// Fixed-length strings are split into their fixed-size slots and trimmed
+// according to the padding type of the datatype: at the first null byte for
+// null-terminated strings, and of trailing nulls or spaces for null-padded
+// and space-padded strings. Variable-length strings are read from the
+// global heap. Contiguous, compact and chunked layouts are supported.
+//
+// UTF-8 strings whose last character was cut off by the fixed width lose
+// that partial character. Strings labelled ASCII are returned as stored, as
+// writers such as h5py use that label for UTF-8 text too.
 func (d *Dataset) ReadStrings() ([]string, error) {
 	// Read object header for this dataset.
-	header, err := core.ReadObjectHeader(d.file.osFile, d.address, d.file.sb)
+	header, err := core.ReadObjectHeader(d.file.reader, d.address, d.file.sb)
 	if err != nil {
 		return nil, err
 	}
 
 	// Use the string dataset reader.
-	return core.ReadDatasetStrings(d.file.osFile, header, d.file.sb)
+	return core.ReadDatasetStrings(d.file.reader, header, d.file.sb, d.file.readOptions()...)
 }
 
 // ReadCompound reads compound dataset values and returns them as array of maps.
 // Each map represents one compound structure instance with field names as keys.
 // Supports nested compound types, numeric types, and fixed-length strings.
+// Array members are returned as []interface{} flattened in row-major order.
+// Use ReadStruct to read into Go structs.
 func (d *Dataset) ReadCompound() ([]core.CompoundValue, error) {
 	// Read object header for this dataset.
-	header, err := core.ReadObjectHeader(d.file.osFile, d.address, d.file.sb)
+	header, err := core.ReadObjectHeader(d.file.reader, d.address, d.file.sb)
 	if err != nil {
 		return nil, err
 	}
 
 	// Use the compound dataset reader.
-	return core.ReadDatasetCompound(d.file.osFile, header, d.file.sb)
+	return core.ReadDatasetCompound(d.file.reader, header, d.file.sb, d.file.readOptions()...)
 }
 
 // ReadVLenBytes reads a variable-length dataset and returns values as [][]byte.
@@ -147,18 +216,31 @@ func (d *Dataset) ReadCompound() ([]core.CompoundValue, error) {
 // to the base element type and byte order.
 func (d *Dataset) ReadVLenBytes() ([][]byte, error) {
 	// Read object header for this dataset.
-	header, err := core.ReadObjectHeader(d.file.osFile, d.address, d.file.sb)
+	header, err := core.ReadObjectHeader(d.file.reader, d.address, d.file.sb)
 	if err != nil {
 		return nil, err
 	}
 
 	// Use the variable-length dataset reader.
-	return core.ReadDatasetVLenBytes(d.file.osFile, header, d.file.sb)
+	return core.ReadDatasetVLenBytes(d.file.reader, header, d.file.sb, d.file.readOptions()...)
+}
+
+// ReadStringLists reads a dataset of variable-length string lists (written
+// as VLenStringList) and returns one []string per element. Empty lists are
+// returned as empty, non-nil slices.
+func (d *Dataset) ReadStringLists() ([][]string, error) {
+	header, err := core.ReadObjectHeader(d.file.reader, d.address, d.file.sb)
+	if err != nil {
+		return nil, err
+	}
+
+	return core.ReadDatasetVLenStringLists(d.file.reader, header, d.file.sb, d.file.readOptions()...)
 }
 
 // Info returns metadata about the dataset without reading actual values.
+// InfoDetailed adds chunking, filters, byte order and storage size.
 func (d *Dataset) Info() (string, error) {
-	header, err := core.ReadObjectHeader(d.file.osFile, d.address, d.file.sb)
+	header, err := core.ReadObjectHeader(d.file.reader, d.address, d.file.sb)
 	if err != nil {
 		return "", err
 	}
@@ -175,10 +257,15 @@ func (d *Dataset) Info() (string, error) {
 type Group struct {
 	file        *File
 	name        string
-	address     uint64 // Address of object header (0 if traditional/SNOD format)
+	path        string // Absolute path, assigned once the hierarchy is loaded.
+	address     uint64 // Address of object header.
 	children    []Object
+	err         error // Why some links are missing from children, see Err.
 	symbolTable *structures.SymbolTable
 	localHeap   *structures.LocalHeap
+
+	load     func() (*Group, error) // Loads the children on first access (WithEager(false)), nil if loaded
+	loadOnce sync.Once
 }
 
 // Name returns the group's name.
@@ -187,10 +274,37 @@ func (g *Group) Name() string {
 }
 
 // Children returns all child objects (groups and datasets) within this group.
+// Children whose object could not be loaded are left out and reported by
+// Err, so an empty result with a nil Err means the group has no children.
+// With WithEager(false), the first call loads the children.
 func (g *Group) Children() []Object {
+	g.ensureLoaded()
 	return g.children
 }
 
+// Err returns the errors for the links of the group whose object could not
+// be loaded and is therefore missing from Children, or nil if all were
+// loaded. Link storage that cannot be parsed at all makes Open fail, or with
+// WithEager(false), leaves the group without children and is reported here.
+//
+// Example:
+//
+//	for _, child := range group.Children() {
+//	    fmt.Println(child.Name())
+//	}
+//	if err := group.Err(); err != nil {
+//	    log.Printf("%s: some links skipped: %v", group.Name(), err)
+//	}
+func (g *Group) Err() error {
+	g.ensureLoaded()
+	return g.err
+}
+
+// skipLink records that the object of link name could not be loaded.
+func (g *Group) skipLink(name string, err error) {
+	g.err = errors.Join(g.err, fmt.Errorf("link %q: %w", name, err))
+}
+
 // Attributes returns all attributes attached to this group.
 // Note: For groups loaded via traditional format (SNOD), the address may be 0,
 // and attributes cannot be retrieved (traditional format doesn't have attributes).
@@ -201,7 +315,7 @@ func (g *Group) Attributes() ([]*core.Attribute, error) {
 	}
 
 	// Read object header to get attributes.
-	header, err := core.ReadObjectHeader(g.file.osFile, g.address, g.file.sb)
+	header, err := core.ReadObjectHeader(g.file.reader, g.address, g.file.sb)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read object header: %w", err)
 	}
@@ -214,13 +328,50 @@ func (g *Group) Attributes() ([]*core.Attribute, error) {
 	return header.Attributes, nil
 }
 
+// AttributeCount returns the number of attributes attached to this group
+// without reading them, like Dataset.AttributeCount.
+func (g *Group) AttributeCount() (int, error) {
+	// Traditional format groups (SNOD) don't support attributes.
+	if g.address == 0 {
+		return 0, nil
+	}
+	return core.CountAttributes(g.file.reader, g.address, g.file.sb)
+}
+
+// ModTime returns the time the group was last modified, as recorded in its
+// object header. The boolean is false when the file does not track times for
+// this group (see WithTrackTimes), or the group has no object header address.
+func (g *Group) ModTime() (time.Time, bool) {
+	if g.address == 0 {
+		return time.Time{}, false
+	}
+	header, err := core.ReadObjectHeader(g.file.reader, g.address, g.file.sb)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return header.ModTime()
+}
+
+// CreationTime returns the time the group was created, as recorded in its
+// object header, like Dataset.CreationTime.
+func (g *Group) CreationTime() (time.Time, bool) {
+	if g.address == 0 {
+		return time.Time{}, false
+	}
+	header, err := core.ReadObjectHeader(g.file.reader, g.address, g.file.sb)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return header.CreationTime()
+}
+
 func loadGroup(file *File, address uint64) (*Group, error) {
 	if address == 0 {
 		return nil, errors.New("invalid group address: 0")
 	}
 
 	// Check signature to determine group format.
-	sig := readSignature(file.osFile, address)
+	sig := readSignature(file.reader, address)
 
 	// SNOD always means traditional format.
 	if sig == SignatureSNOD {
@@ -233,12 +384,12 @@ func loadGroup(file *File, address uint64) (*Group, error) {
 }
 
 func loadModernGroup(file *File, address uint64) (*Group, error) {
-	r := file.osFile
+	r := file.reader
 	sb := file.sb
 
 	header, err := core.ReadObjectHeader(r, address, sb)
 	if err != nil {
-		return nil, utils.WrapError("object header read failed", err)
+		return nil, utils.WrapError("object header read failed", file.structureError("object header", address, err))
 	}
 
 	group := &Group{
@@ -246,6 +397,8 @@ func loadModernGroup(file *File, address uint64) (*Group, error) {
 		name:    header.Name,
 		address: address, // Store address for later Attributes() access
 	}
+	file.trace("object header", address, "version %d, %d messages, type %s",
+		header.Version, len(header.Messages), header.Type)
 
 	// Load children only for groups.
 	// Note: For v0 files, the root group may have ObjectTypeUnknown because
@@ -262,16 +415,19 @@ func loadModernGroup(file *File, address uint64) (*Group, error) {
 				// Parse the link message.
 				linkMsg, err := structures.ParseLinkMessage(msg.Data, sb)
 				if err != nil {
-					return nil, utils.WrapError("link message parse failed", err)
+					return nil, utils.WrapError("link message parse failed", file.structureError("link message", address, err))
 				}
 
 				// Process based on link type.
 				if linkMsg.IsHardLink() {
+					file.trace("link message", address, "hard link %q to 0x%X", linkMsg.Name, linkMsg.ObjectAddress)
 					// Load the object that this link points to.
 					child, err := loadObject(file, linkMsg.ObjectAddress, linkMsg.Name)
 					if err != nil {
-						// Log warning but continue with other links.
-						// Some links might point to objects we don't support yet.
+						// Continue with other links: some might point to
+						// objects we don't support yet. Err reports them.
+						file.traceError("link message", address, err, "link %q skipped", linkMsg.Name)
+						group.skipLink(linkMsg.Name, err)
 						continue
 					}
 					group.children = append(group.children, child)
@@ -280,6 +436,7 @@ func loadModernGroup(file *File, address uint64) (*Group, error) {
 					// Soft links are symbolic links within HDF5 file pointing to paths.
 					// Current implementation focuses on hard links (direct object references).
 					// Target version: v0.11.0-beta (write support phase)
+					file.trace("link message", address, "soft link %q not loaded", linkMsg.Name)
 					continue
 				}
 			}
@@ -298,29 +455,35 @@ func loadModernGroup(file *File, address uint64) (*Group, error) {
 				}
 				linkInfo, err := core.ParseLinkInfoMessage(msg.Data, sb)
 				if err != nil {
-					return nil, utils.WrapError("link info parse failed", err)
+					return nil, utils.WrapError("link info parse failed", file.structureError("link info message", address, err))
 				}
 				if !linkInfo.HasFractalHeap() || !linkInfo.HasNameBTree() {
+					file.trace("link info", address, "no dense link storage")
 					continue
 				}
-				heapObjects, err := core.ReadDenseHeapObjects(file.osFile,
+				heapObjects, err := core.ReadDenseHeapObjects(file.reader,
 					linkInfo.NameBTreeAddress,
 					linkInfo.FractalHeapAddress,
 					sb,
 				)
 				if err != nil {
-					return nil, utils.WrapError("dense link read failed", err)
+					return nil, utils.WrapError("dense link read failed",
+						file.structureError("dense link storage", linkInfo.FractalHeapAddress, err))
 				}
+				file.trace("dense link storage", linkInfo.FractalHeapAddress, "%d links, name index B-tree at 0x%X",
+					len(heapObjects), linkInfo.NameBTreeAddress)
 				for _, raw := range heapObjects {
 					linkMsg, err := structures.ParseLinkMessage(raw, sb)
 					if err != nil {
 						// Skip individual malformed records rather than
-						// failing the whole group — matches the compact-
-						// link branch's tolerance below.
+						// failing the whole group; Err reports them.
+						file.traceError("dense link storage", linkInfo.FractalHeapAddress, err, "malformed link skipped")
+						group.err = errors.Join(group.err, fmt.Errorf("malformed link in dense storage: %w", err))
 						continue
 					}
 					if linkMsg.IsSoftLink() {
 						// Soft links deferred — see compact-link branch.
+						file.trace("dense link storage", linkInfo.FractalHeapAddress, "soft link %q not loaded", linkMsg.Name)
 						continue
 					}
 					if !linkMsg.IsHardLink() {
@@ -328,6 +491,8 @@ func loadModernGroup(file *File, address uint64) (*Group, error) {
 					}
 					child, err := loadObject(file, linkMsg.ObjectAddress, linkMsg.Name)
 					if err != nil {
+						file.traceError("dense link storage", linkInfo.FractalHeapAddress, err, "link %q skipped", linkMsg.Name)
+						group.skipLink(linkMsg.Name, err)
 						continue
 					}
 					group.children = append(group.children, child)
@@ -345,15 +510,14 @@ func loadModernGroup(file *File, address uint64) (*Group, error) {
 					// Symbol table message data format:
 					// Bytes 0-7: B-tree address.
 					// Bytes 8-15: Local heap address.
-					if len(msg.Data) >= 16 {
-						btreeAddr := sb.Endianness.Uint64(msg.Data[0:8])
-						heapAddr := sb.Endianness.Uint64(msg.Data[8:16])
-
-						group.symbolTable = &structures.SymbolTable{
-							Version:      1,
-							BTreeAddress: btreeAddr,
-							HeapAddress:  heapAddr,
-						}
+					if len(msg.Data) < 16 {
+						return nil, file.structureError("symbol table message", address,
+							fmt.Errorf("message too short: %d bytes, need 16", len(msg.Data)))
+					}
+					group.symbolTable = &structures.SymbolTable{
+						Version:      1,
+						BTreeAddress: sb.Endianness.Uint64(msg.Data[0:8]),
+						HeapAddress:  sb.Endianness.Uint64(msg.Data[8:16]),
 					}
 				}
 			}
@@ -374,9 +538,18 @@ func loadModernGroup(file *File, address uint64) (*Group, error) {
 			}
 
 			if group.symbolTable != nil {
+				file.trace("symbol table", address, "B-tree at 0x%X, local heap at 0x%X",
+					group.symbolTable.BTreeAddress, group.symbolTable.HeapAddress)
 				if err := group.loadChildren(); err != nil {
 					return nil, utils.WrapError("load children failed", err)
 				}
+			} else if sb.Version == core.Version0 && address == sb.RootGroup {
+				// The root group of a version 0 file always has a symbol
+				// table; without one its links cannot be found.
+				return nil, file.structureError("object header", address,
+					errors.New("root group has no symbol table message and the superblock caches none"))
+			} else {
+				file.trace("object header", address, "no links, link info or symbol table: group has no children")
 			}
 		}
 	}
@@ -386,9 +559,9 @@ func loadModernGroup(file *File, address uint64) (*Group, error) {
 
 func loadTraditionalGroup(file *File, address uint64) (*Group, error) {
 	// Parse the Symbol Table Node (SNOD).
-	node, err := structures.ParseSymbolTableNode(file.osFile, address, file.sb)
+	node, err := structures.ParseSymbolTableNode(file.reader, address, file.sb)
 	if err != nil {
-		return nil, utils.WrapError("symbol table node parse failed", err)
+		return nil, utils.WrapError("symbol table node parse failed", file.structureError("symbol table node", address, err))
 	}
 
 	// For traditional format, we need the local heap address.
@@ -402,17 +575,18 @@ func loadTraditionalGroup(file *File, address uint64) (*Group, error) {
 	// TEMPORARY: Try to find heap address from root group's symbol table message.
 	// This is a workaround - proper solution would pass heap address explicitly.
 	var heap *structures.LocalHeap
+	var heapAddr uint64
 
 	// Read root object header to get heap address.
-	rootHeader, err := core.ReadObjectHeader(file.osFile, file.sb.RootGroup, file.sb)
+	rootHeader, err := core.ReadObjectHeader(file.reader, file.sb.RootGroup, file.sb)
 	if err == nil {
 		// Find symbol table message.
 		for _, msg := range rootHeader.Messages {
 			if msg.Type == core.MsgSymbolTable && len(msg.Data) >= 16 {
-				heapAddr := file.sb.Endianness.Uint64(msg.Data[8:16])
-				heap, err = structures.LoadLocalHeap(file.osFile, heapAddr, file.sb)
+				heapAddr = file.sb.Endianness.Uint64(msg.Data[8:16])
+				heap, err = structures.LoadLocalHeap(file.reader, heapAddr, file.sb)
 				if err != nil {
-					return nil, utils.WrapError("local heap load failed", err)
+					return nil, utils.WrapError("local heap load failed", file.structureError("local heap", heapAddr, err))
 				}
 				break
 			}
@@ -422,11 +596,13 @@ func loadTraditionalGroup(file *File, address uint64) (*Group, error) {
 	if heap == nil {
 		return nil, errors.New("could not find local heap for traditional group")
 	}
+	file.trace("symbol table node", address, "%d entries, local heap at 0x%X", len(node.Entries), heapAddr)
 
 	// Create group.
 	group := &Group{
 		file:      file,
 		name:      "/",
+		address:   address,
 		localHeap: heap,
 	}
 
@@ -435,12 +611,13 @@ func loadTraditionalGroup(file *File, address uint64) (*Group, error) {
 		// Skip soft links - they have CacheType=2 and ObjectAddress=HADDR_UNDEF.
 		// Following C library behavior: soft links are not resolved during file open.
 		if entry.IsSoftLink() {
+			file.trace("symbol table node", address, "soft link not loaded")
 			continue
 		}
 
 		linkName, err := heap.GetString(entry.LinkNameOffset)
 		if err != nil {
-			return nil, utils.WrapError("link name read failed", err)
+			return nil, utils.WrapError("link name read failed", file.structureError("local heap", heapAddr, err))
 		}
 
 		child, err := loadObject(file, entry.ObjectAddress, linkName)
@@ -464,32 +641,39 @@ func (g *Group) loadChildren() error {
 	btreeAddr := g.symbolTable.BTreeAddress
 	if g.file.visitedBTrees[btreeAddr] {
 		// Already visited this B-tree, no children to add (prevents cycle).
+		g.file.trace("B-tree", btreeAddr, "already visited by another group, children skipped")
 		return nil
 	}
 	g.file.visitedBTrees[btreeAddr] = true
 
-	heap, err := structures.LoadLocalHeap(g.file.osFile, g.symbolTable.HeapAddress, g.file.sb)
+	heap, err := structures.LoadLocalHeap(g.file.reader, g.symbolTable.HeapAddress, g.file.sb)
 	if err != nil {
-		return utils.WrapError("local heap load failed", err)
+		return utils.WrapError("local heap load failed",
+			g.file.structureError("local heap", g.symbolTable.HeapAddress, err))
 	}
 
 	// Detect B-tree format by reading signature.
-	btreeSig := readSignature(g.file.osFile, btreeAddr)
+	btreeSig := readSignature(g.file.reader, btreeAddr)
 
 	var entries []structures.BTreeEntry
 	switch btreeSig {
 	case "TREE": //nolint:goconst // HDF5 B-tree signature used across multiple packages
 		// v1 B-tree format (used in v0 files and some v1 files).
-		entries, err = structures.ReadGroupBTreeEntries(g.file.osFile, btreeAddr, g.file.sb)
+		entries, err = structures.ReadGroupBTreeEntries(g.file.reader, btreeAddr, g.file.sb)
 	case "BTRE":
 		// Modern B-tree format.
-		entries, err = structures.ReadBTreeEntries(g.file.osFile, btreeAddr, g.file.sb)
+		entries, err = structures.ReadBTreeEntries(g.file.reader, btreeAddr, g.file.sb)
 	default:
-		return fmt.Errorf("unknown B-tree signature: %q at address 0x%X", btreeSig, btreeAddr)
+		return g.file.structureError("B-tree", btreeAddr, fmt.Errorf("unknown B-tree signature: %q", btreeSig))
 	}
 
 	if err != nil {
-		return utils.WrapError("B-tree read failed", err)
+		return utils.WrapError("B-tree read failed", g.file.structureError("B-tree", btreeAddr, err))
+	}
+	if len(entries) == 0 {
+		g.file.trace("B-tree", btreeAddr, "%s B-tree has no entries: symbol table empty", btreeSig)
+	} else {
+		g.file.trace("B-tree", btreeAddr, "%s B-tree, %d entries", btreeSig, len(entries))
 	}
 
 	for _, entry := range entries {
@@ -498,20 +682,23 @@ func (g *Group) loadChildren() error {
 		// The target path is stored in local heap at CachedSoftLinkOffset.
 		// Like the C library, we don't resolve soft links during file open - only on explicit access.
 		if entry.IsSoftLink() {
+			g.file.trace("B-tree", btreeAddr, "soft link not loaded")
 			continue
 		}
 
 		// Check if this is an unnamed SNOD (offset 0 AND object is SNOD) - means we should inline its children.
 		// Note: offset 0 alone is NOT sufficient - it's a valid offset for the first string in the heap!
 		// We must verify the object at the address is actually a SNOD, not a regular object with name at offset 0.
-		sig := readSignature(g.file.osFile, entry.ObjectAddress)
+		sig := readSignature(g.file.reader, entry.ObjectAddress)
 		if entry.LinkNameOffset == 0 && sig == SignatureSNOD {
 			// This is an unnamed SNOD container - load its children directly.
-			node, err := structures.ParseSymbolTableNode(g.file.osFile, entry.ObjectAddress, g.file.sb)
+			node, err := structures.ParseSymbolTableNode(g.file.reader, entry.ObjectAddress, g.file.sb)
 			if err != nil {
-				return utils.WrapError("SNOD parse failed", err)
+				return utils.WrapError("SNOD parse failed", g.file.structureError("symbol table node", entry.ObjectAddress, err))
 			}
 
+			g.file.trace("symbol table node", entry.ObjectAddress, "unnamed node, %d entries inlined", len(node.Entries))
+
 			// Add each entry from the SNOD to this group.
 			for _, snodEntry := range node.Entries {
 				// Skip soft links in SNOD entries (same as above).
@@ -521,7 +708,8 @@ func (g *Group) loadChildren() error {
 
 				childName, err := heap.GetString(snodEntry.LinkNameOffset)
 				if err != nil {
-					return utils.WrapError("SNOD child name read failed", err)
+					return utils.WrapError("SNOD child name read failed",
+						g.file.structureError("local heap", g.symbolTable.HeapAddress, err))
 				}
 
 				// For nested groups with CacheType=1, pass cached symbol table addresses.
@@ -543,7 +731,7 @@ func (g *Group) loadChildren() error {
 
 		linkName, err := heap.GetString(entry.LinkNameOffset)
 		if err != nil {
-			return utils.WrapError("link name read failed", err)
+			return utils.WrapError("link name read failed", g.file.structureError("local heap", g.symbolTable.HeapAddress, err))
 		}
 
 		// For nested groups with CacheType=1 (H5G_CACHED_STAB), use cached symbol table addresses.
@@ -566,22 +754,45 @@ func (g *Group) loadChildren() error {
 }
 
 func loadObject(file *File, address uint64, name string) (Object, error) {
+	if file.isLoading(address) {
+		// A hard link back to an enclosing group. Loading it again would
+		// recurse forever, so it is loaded without children; Walk reports
+		// it as a link cycle.
+		return &Group{file: file, name: name, address: address}, nil
+	}
+	defer file.enterObject(name, address)()
+	return loadObjectAt(file, address, name)
+}
+
+// isLoading reports whether the object at address encloses the object being
+// loaded.
+func (f *File) isLoading(address uint64) bool {
+	for _, addr := range f.loadingAddrs {
+		if addr == address {
+			return true
+		}
+	}
+	return false
+}
+
+// loadObjectAt loads the object at address for loadObject.
+func loadObjectAt(file *File, address uint64, name string) (Object, error) {
 	// Check signature first - SNOD means traditional group format.
-	sig := readSignature(file.osFile, address)
+	sig := readSignature(file.reader, address)
 	if sig == SignatureSNOD {
 		// SNOD is a symbol table node - it might be:
 		// 1. A true group with multiple children.
 		// 2. A redirect node with single entry (v0 files).
 
-		node, err := structures.ParseSymbolTableNode(file.osFile, address, file.sb)
+		node, err := structures.ParseSymbolTableNode(file.reader, address, file.sb)
 		if err != nil {
-			return nil, err
+			return nil, file.structureError("symbol table node", address, err)
 		}
 
 		// If SNOD has single entry, it's likely a redirect - load the target directly.
 		if len(node.Entries) == 1 {
 			// Get heap from root to read the name.
-			rootHeader, err := core.ReadObjectHeader(file.osFile, file.sb.RootGroup, file.sb)
+			rootHeader, err := core.ReadObjectHeader(file.reader, file.sb.RootGroup, file.sb)
 			if err != nil {
 				return nil, err
 			}
@@ -590,7 +801,7 @@ func loadObject(file *File, address uint64, name string) (Object, error) {
 			for _, msg := range rootHeader.Messages {
 				if msg.Type == core.MsgSymbolTable && len(msg.Data) >= 16 {
 					heapAddr := file.sb.Endianness.Uint64(msg.Data[8:16])
-					heap, err = structures.LoadLocalHeap(file.osFile, heapAddr, file.sb)
+					heap, err = structures.LoadLocalHeap(file.reader, heapAddr, file.sb)
 					if err != nil {
 						return nil, err
 					}
@@ -603,40 +814,34 @@ func loadObject(file *File, address uint64, name string) (Object, error) {
 				linkName, err := heap.GetString(entry.LinkNameOffset)
 				if err == nil && linkName == name {
 					// This is a redirect node - load the target object directly.
-					return loadObject(file, entry.ObjectAddress, name)
+					return loadObjectAt(file, entry.ObjectAddress, name)
 				}
 			}
 		}
 
 		// Otherwise, treat as a real group.
-		group, err := loadTraditionalGroup(file, address)
-		if err != nil {
-			return nil, err
-		}
-		// Override name if provided.
-		if name != "" {
-			group.name = name
-		}
-		return group, nil
+		return file.groupAt(name, address, func() (*Group, error) {
+			return loadTraditionalGroup(file, address)
+		})
 	}
 
 	// Try reading object header (works for both v1 and v2).
-	header, err := core.ReadObjectHeader(file.osFile, address, file.sb)
+	header, err := core.ReadObjectHeader(file.reader, address, file.sb)
 	if err != nil {
-		return nil, err
+		return nil, file.structureError("object header", address, err)
+	}
+	if header.Type != core.ObjectTypeGroup {
+		// Groups report their header when loadGroup reads it.
+		file.trace("object header", address, "version %d, %d messages, type %s",
+			header.Version, len(header.Messages), header.Type)
 	}
 
 	switch header.Type {
 	case core.ObjectTypeGroup:
-		group, err := loadGroup(file, address)
-		if err != nil {
-			return nil, err
-		}
 		// Override name if provided (but keep stored address).
-		if name != "" {
-			group.name = name
-		}
-		return group, nil
+		return file.groupAt(name, address, func() (*Group, error) {
+			return loadGroup(file, address)
+		})
 	case core.ObjectTypeDataset:
 		return &Dataset{
 			file:    file,
@@ -651,7 +856,8 @@ func loadObject(file *File, address uint64, name string) (Object, error) {
 			if msg.Type == core.MsgDatatype {
 				dt, err := core.ParseDatatypeMessage(msg.Data)
 				if err != nil {
-					return nil, fmt.Errorf("failed to parse named datatype: %w", err)
+					return nil, fmt.Errorf("failed to parse named datatype: %w",
+						file.structureError("datatype message", address, err))
 				}
 				datatype = dt
 				break
@@ -667,11 +873,10 @@ func loadObject(file *File, address uint64, name string) (Object, error) {
 		// For v0 files, groups may have no messages and thus ObjectTypeUnknown.
 		// Try loading as a group first.
 		if file.sb.Version == core.Version0 {
-			group, err := loadGroup(file, address)
+			group, err := file.groupAt(name, address, func() (*Group, error) {
+				return loadGroup(file, address)
+			})
 			if err == nil {
-				if name != "" {
-					group.name = name
-				}
 				return group, nil
 			}
 			// If loading as group fails, fall through to error
@@ -686,21 +891,28 @@ func loadObject(file *File, address uint64, name string) (Object, error) {
 // This is used for v0 files where nested groups have their symbol table info cached
 // in the parent SNOD entry (CacheType=1, H5G_CACHED_STAB).
 func loadGroupWithCachedSymbolTable(file *File, address uint64, name string, btreeAddr, heapAddr uint64) (*Group, error) {
-	group := &Group{
-		file:    file,
-		name:    name,
-		address: address,
-		symbolTable: &structures.SymbolTable{
-			Version:      1,
-			BTreeAddress: btreeAddr,
-			HeapAddress:  heapAddr,
-		},
-	}
+	load := func() (*Group, error) {
+		group := &Group{
+			file:    file,
+			name:    name,
+			address: address,
+			symbolTable: &structures.SymbolTable{
+				Version:      1,
+				BTreeAddress: btreeAddr,
+				HeapAddress:  heapAddr,
+			},
+		}
 
-	// Load children using the cached symbol table addresses.
-	if err := group.loadChildren(); err != nil {
-		return nil, utils.WrapError("load children with cached symbol table failed", err)
+		// Load children using the cached symbol table addresses.
+		if err := group.loadChildren(); err != nil {
+			return nil, utils.WrapError("load children with cached symbol table failed", err)
+		}
+		return group, nil
+	}
+	if file.lazy {
+		return deferGroup(file, name, address, load), nil
 	}
 
-	return group, nil
+	defer file.enterObject(name, address)()
+	return load()
 }
@@ -1,7 +1,9 @@
 package hdf5
 
 import (
+	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -325,3 +327,49 @@ func TestGroupWriter_WriteAttribute_CompactToDense(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, attrs, 10, "expected 10 attributes (dense storage)")
 }
+
+// TestFileWriter_WriteRootAttribute tests file-level attributes through the
+// compact-to-dense transition, with root links (and local heap growth) in between.
+func TestFileWriter_WriteRootAttribute(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "root_attrs.h5")
+
+	fw, err := CreateForWrite(filename, CreateTruncate)
+	require.NoError(t, err)
+	require.Equal(t, "/", fw.Root().Path())
+	require.NoError(t, fw.WriteRootAttribute("Conventions", "CF-1.8"))
+	for i := 0; i < 40; i++ {
+		ds, err := fw.CreateDataset(fmt.Sprintf("/measurement_series_%03d", i), Int32, []uint64{2})
+		require.NoError(t, err)
+		require.NoError(t, ds.Write([]int32{1, 2}))
+	}
+	for i := 0; i < 12; i++ {
+		require.NoError(t, fw.Root().WriteAttribute(fmt.Sprintf("attr%02d", i), int32(i)))
+	}
+	require.NoError(t, fw.Close())
+
+	f, err := Open(filename)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	require.Len(t, f.Root().Children(), 40)
+	attrs, err := f.Root().Attributes()
+	require.NoError(t, err)
+	require.Len(t, attrs, 13)
+	values := make(map[string]interface{})
+	for _, attr := range attrs {
+		values[attr.Name], err = attr.ReadValue()
+		require.NoError(t, err)
+	}
+	require.Equal(t, "CF-1.8", values["Conventions"])
+	require.Equal(t, int32(11), values["attr11"])
+}
+
+func TestFileWriter_WriteRootAttribute_SuperblockV0(t *testing.T) {
+	fw, err := CreateForWrite(filepath.Join(t.TempDir(), "root_v0.h5"), CreateTruncate,
+		WithSuperblockVersion(SuperblockV0))
+	require.NoError(t, err)
+	defer func() { _ = fw.Close() }()
+
+	err = fw.WriteRootAttribute("Conventions", "CF-1.8")
+	require.ErrorContains(t, err, "superblock version 2 or later")
+}
@@ -0,0 +1,82 @@
+package hdf5
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestGroup_Children_Flux checks that the groups of flux.h5, which were once
+// read as empty, list their children.
+func TestGroup_Children_Flux(t *testing.T) {
+	f, err := Open("testdata/reference/flux.h5")
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	counts := map[string]int{}
+	f.Walk(func(path string, obj Object) {
+		if g, ok := obj.(*Group); ok {
+			require.NoError(t, g.Err(), path)
+			counts[path] = len(g.Children())
+		}
+	})
+	require.Equal(t, map[string]int{
+		"/":                  1,
+		"/group1/":           1,
+		"/group1/flux/":      3,
+		"/group1/flux/time/": 1,
+	}, counts)
+}
+
+func TestGroup_Err(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "group_err.h5")
+
+	fw, err := CreateForWrite(filename, CreateTruncate, WithModernGroups())
+	require.NoError(t, err)
+	_, err = fw.CreateGroup("/g")
+	require.NoError(t, err)
+	_, err = fw.CreateGroup("/empty")
+	require.NoError(t, err)
+	for _, name := range []string{"/g/a", "/g/b"} {
+		ds, err := fw.CreateDataset(name, Int32, []uint64{2})
+		require.NoError(t, err)
+		require.NoError(t, ds.Write([]int32{1, 2}))
+	}
+	require.NoError(t, fw.Close())
+
+	f, err := Open(filename)
+	require.NoError(t, err)
+	g, err := f.Root().Group("g")
+	require.NoError(t, err)
+	require.Len(t, g.Children(), 2)
+	require.NoError(t, g.Err())
+	b, err := g.Dataset("b")
+	require.NoError(t, err)
+	address := b.Address()
+	require.NoError(t, f.Close())
+
+	// Destroy the object header of /g/b.
+	file, err := os.OpenFile(filename, os.O_RDWR, 0)
+	require.NoError(t, err)
+	_, err = file.WriteAt([]byte{0xFF, 0xFF, 0xFF, 0xFF}, int64(address)) //nolint:gosec // G115: test file addresses are small
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	f, err = Open(filename)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	g, err = f.Root().Group("g")
+	require.NoError(t, err)
+	require.Len(t, g.Children(), 1)
+	require.Equal(t, "a", g.Children()[0].Name())
+	require.ErrorContains(t, g.Err(), `link "b"`)
+
+	// A group without links is empty, not broken.
+	empty, err := f.Root().Group("empty")
+	require.NoError(t, err)
+	require.Empty(t, empty.Children())
+	require.NoError(t, empty.Err())
+}
@@ -1,6 +1,7 @@
 package hdf5
 
 import (
+	"fmt"
 	"sort"
 	"testing"
 
@@ -15,15 +16,17 @@ import (
 // checksummed direct-block branch in attribute.go.
 // ---------------------------------------------------------------------------
 
-// TestDenseLinks_RootGroup opens testdata/dense_links.h5 and verifies all 16
-// dense links are read back through the fractal-heap path.
+// TestDenseLinks_RootGroup opens testdata/dense_links.h5 and verifies all 17
+// dense links (16 variables plus the "x" dimension scale, whose object header
+// ends in a gap after creation-order-tracked messages) are read back through
+// the fractal-heap path.
 func TestDenseLinks_RootGroup(t *testing.T) {
 	f, err := Open("testdata/dense_links.h5")
 	require.NoError(t, err)
 	defer func() { _ = f.Close() }()
 
 	children := f.Root().Children()
-	require.Len(t, children, 16, "root group should expose all 16 dense links")
+	require.Len(t, children, 17, "root group should expose all 17 dense links")
 
 	names := make([]string, len(children))
 	for i, c := range children {
@@ -33,7 +36,61 @@ func TestDenseLinks_RootGroup(t *testing.T) {
 
 	want := []string{
 		"v00", "v01", "v02", "v03", "v04", "v05", "v06", "v07",
-		"v08", "v09", "v10", "v11", "v12", "v13", "v14", "v15",
+		"v08", "v09", "v10", "v11", "v12", "v13", "v14", "v15", "x",
 	}
 	require.Equal(t, want, names)
 }
+
+// TestDenseLinks_IndirectHeap reads the root group of h5repack_objs.h5, whose
+// 40 links no longer fit in the root direct block of the fractal heap: the
+// root is an indirect block whose rows point at the direct blocks.
+func TestDenseLinks_IndirectHeap(t *testing.T) {
+	f, err := Open("testdata/hdf5_official/h5repack_objs.h5")
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	links, err := f.Root().Links()
+	require.NoError(t, err)
+	require.Len(t, links, 40)
+
+	kinds := map[LinkType]int{}
+	for _, l := range links {
+		kinds[l.Type]++
+	}
+	require.Equal(t, map[LinkType]int{LinkHard: 38, LinkSoft: 1, LinkExternal: 1}, kinds)
+
+	// Soft and external links are not loaded as children.
+	require.Len(t, f.Root().Children(), 38)
+	require.NoError(t, f.Root().Err())
+	_, err = f.Root().Dataset("compound3D")
+	require.NoError(t, err)
+}
+
+// TestDenseLinks_DeepBTree reads the root group of h5stat_newgrat.h5, which
+// has 35001 links (testdata/hdf5_official/ddl/h5stat_newgrat.ddl): the name
+// index is a v2 B-tree of depth 3 and the heap nests indirect blocks.
+func TestDenseLinks_DeepBTree(t *testing.T) {
+	if testing.Short() {
+		t.Skip("loads 35001 groups")
+	}
+	f, err := Open("testdata/hdf5_official/h5stat_newgrat.h5")
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	links, err := f.Root().Links()
+	require.NoError(t, err)
+	require.Len(t, links, 35001)
+
+	names := make(map[string]bool, len(links))
+	for _, l := range links {
+		names[l.Name] = true
+	}
+	require.Len(t, names, 35001, "link names should be distinct")
+	require.True(t, names["DATASET_NAME"])
+	for i := 1; i <= 35000; i++ {
+		require.True(t, names[fmt.Sprintf("GROUP%d", i)], "GROUP%d", i)
+	}
+
+	require.Len(t, f.Root().Children(), 35001)
+	require.NoError(t, f.Root().Err())
+}
@@ -0,0 +1,126 @@
+package hdf5
+
+import (
+	"fmt"
+
+	"github.com/scigolib/hdf5/internal/core"
+)
+
+// GroupStorage is how a group stores its links, matching the HDF5
+// H5G_storage_type_t values.
+type GroupStorage int
+
+// Link storage types reported by Group.Info.
+const (
+	// GroupStorageSymbolTable is the original format: a B-tree and a local
+	// heap (files written by HDF5 1.6 and earlier, or for compatibility).
+	GroupStorageSymbolTable GroupStorage = iota
+
+	// GroupStorageCompact keeps Link messages in the object header.
+	GroupStorageCompact
+
+	// GroupStorageDense keeps links in a fractal heap indexed by a v2 B-tree.
+	GroupStorageDense
+)
+
+// String returns the storage type name ("symbol table", "compact" or
+// "dense").
+func (s GroupStorage) String() string {
+	switch s {
+	case GroupStorageSymbolTable:
+		return "symbol table"
+	case GroupStorageCompact:
+		return "compact"
+	case GroupStorageDense:
+		return "dense"
+	default:
+		return "unknown"
+	}
+}
+
+// Group Info defaults used by the HDF5 library when the message does not
+// store the values (H5Gprivate.h - H5G_CRT_GINFO_*).
+const (
+	defaultMaxCompactLinks = 8
+	defaultMinDenseLinks   = 6
+	defaultEstLinks        = 4
+	defaultEstLinkNameLen  = 8
+)
+
+// GroupInfo describes how a group stores its links, from its Link Info and
+// Group Info messages. The estimates and thresholds are those the group was
+// created with; they are zero for symbol table groups, which have neither
+// message.
+type GroupInfo struct {
+	Storage GroupStorage // How the links are stored
+	Links   int          // Actual number of links
+
+	EstimatedLinks   int // Expected number of links, used to size the object header
+	EstimatedNameLen int // Expected link name length
+	MaxCompact       int // Links kept in the object header before switching to dense storage
+	MinDense         int // Links in dense storage below which the group switches back to compact
+
+	TrackCreationOrder bool  // Link creation order is recorded
+	IndexCreationOrder bool  // Link creation order is indexed
+	MaxCreationOrder   int64 // Highest creation order assigned so far (if tracked)
+}
+
+// Info returns the link storage type, the number of links and the link
+// storage settings of the group. Tools can use it to decide whether a group
+// is small enough to expand, without reading the children.
+//
+// Example:
+//
+//	info, _ := group.Info()
+//	if info.Links > 1000 {
+//	    fmt.Printf("%s: %d links (%s storage)\n", group.Name(), info.Links, info.Storage)
+//	}
+//
+// Reference: H5G.c - H5Gget_info(), H5Oginfo.c, H5Olinfo.c.
+func (g *Group) Info() (GroupInfo, error) {
+	links, err := g.Links()
+	if err != nil {
+		return GroupInfo{}, err
+	}
+	info := GroupInfo{Storage: GroupStorageSymbolTable, Links: len(links)}
+
+	// Root groups loaded from a symbol table node have no object header.
+	if g.symbolTable != nil || g.localHeap != nil {
+		return info, nil
+	}
+
+	header, err := core.ReadObjectHeader(g.file.reader, g.address, g.file.sb)
+	if err != nil {
+		return GroupInfo{}, fmt.Errorf("failed to read object header: %w", err)
+	}
+	for _, msg := range header.Messages {
+		switch msg.Type {
+		case core.MsgLinkInfo:
+			linkInfo, err := core.ParseLinkInfoMessage(msg.Data, g.file.sb)
+			if err != nil {
+				return GroupInfo{}, fmt.Errorf("failed to parse link info: %w", err)
+			}
+			info.Storage = GroupStorageCompact
+			if linkInfo.HasFractalHeap() && linkInfo.HasNameBTree() {
+				info.Storage = GroupStorageDense
+			}
+			info.TrackCreationOrder = linkInfo.HasCreationOrderTracking()
+			info.IndexCreationOrder = linkInfo.HasCreationOrderIndex()
+			info.MaxCreationOrder = linkInfo.MaxCreationOrder
+		case core.MsgGroupInfo:
+			groupInfo, err := core.ParseGroupInfoMessage(msg.Data)
+			if err != nil {
+				return GroupInfo{}, fmt.Errorf("failed to parse group info: %w", err)
+			}
+			info.MaxCompact, info.MinDense = defaultMaxCompactLinks, defaultMinDenseLinks
+			if groupInfo.Flags&core.GroupInfoStorePhaseChange != 0 {
+				info.MaxCompact, info.MinDense = int(groupInfo.MaxCompact), int(groupInfo.MinDense)
+			}
+			info.EstimatedLinks, info.EstimatedNameLen = defaultEstLinks, defaultEstLinkNameLen
+			if groupInfo.Flags&core.GroupInfoStoreEstimates != 0 {
+				info.EstimatedLinks, info.EstimatedNameLen = int(groupInfo.EstNumEntries), int(groupInfo.EstNameLen)
+			}
+		}
+	}
+	return info, nil
+}
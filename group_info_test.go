@@ -0,0 +1,62 @@
+package hdf5
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroup_Info(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "group_info.h5")
+
+	fw, err := CreateForWrite(filename, CreateTruncate, WithModernGroups())
+	require.NoError(t, err)
+	_, err = fw.CreateGroup("/runs")
+	require.NoError(t, err)
+	for _, name := range []string{"/runs/a", "/runs/b", "/runs/c"} {
+		ds, err := fw.CreateDataset(name, Int32, []uint64{1})
+		require.NoError(t, err)
+		require.NoError(t, ds.Write([]int32{1}))
+	}
+	require.NoError(t, fw.Close())
+
+	f, err := Open(filename)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	runs, err := f.Root().Group("runs")
+	require.NoError(t, err)
+	info, err := runs.Info()
+	require.NoError(t, err)
+	require.Equal(t, GroupStorageCompact, info.Storage)
+	require.Equal(t, 3, info.Links)
+	require.Equal(t, 8, info.MaxCompact)
+	require.Equal(t, 6, info.MinDense)
+	require.Equal(t, 4, info.EstimatedLinks)
+	require.Equal(t, 8, info.EstimatedNameLen)
+
+	// Symbol table groups have no Group Info message.
+	legacy, err := Open("testdata/v0.h5")
+	require.NoError(t, err)
+	defer func() { _ = legacy.Close() }()
+	info, err = legacy.Root().Info()
+	require.NoError(t, err)
+	require.Equal(t, GroupStorageSymbolTable, info.Storage)
+	require.Equal(t, len(legacy.Root().Children()), info.Links)
+	require.Zero(t, info.MaxCompact)
+
+	// The netCDF-4 root group stores its links densely and tracks their
+	// creation order.
+	nc, err := Open("testdata/dense_links.h5")
+	require.NoError(t, err)
+	defer func() { _ = nc.Close() }()
+	info, err = nc.Root().Info()
+	require.NoError(t, err)
+	require.Equal(t, GroupStorageDense, info.Storage)
+	require.Equal(t, 17, info.Links)
+	require.True(t, info.TrackCreationOrder)
+	require.True(t, info.IndexCreationOrder)
+	require.Equal(t, int64(17), info.MaxCreationOrder)
+	require.Equal(t, "dense", info.Storage.String())
+}
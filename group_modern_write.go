@@ -0,0 +1,418 @@
+package hdf5
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/scigolib/hdf5/internal/core"
+	"github.com/scigolib/hdf5/internal/structures"
+)
+
+// modernGroupHeaderSize is the minimum allocation for a link-info group's object header.
+// Compact links share the header with attributes, so it is padded to twice the
+// usual minimum: room for denseGroupThreshold short Link messages plus a few attributes.
+const modernGroupHeaderSize = 2 * core.MinOHDRAllocSize
+
+// errLinkExists is returned when a link with the same name already exists in a group.
+var errLinkExists = errors.New("link already exists")
+
+// createModernGroupHeader writes the object header of an empty link-info group.
+//
+// The header holds a Link Info message (no dense storage yet, so both heap and
+// name-index addresses are undefined) and a Group Info message with library
+// defaults, like H5G__obj_create_real() for groups created with the latest format.
+//
+// Returns (headerAddr, headerAllocSize, error).
+func (fw *FileWriter) createModernGroupHeader() (uint64, uint64, error) {
+	sb := fw.file.sb
+
+	linkInfoData, err := core.EncodeLinkInfoMessage(&core.LinkInfoMessage{
+		FractalHeapAddress: undefinedAddress,
+		NameBTreeAddress:   undefinedAddress,
+	}, sb)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to encode link info message: %w", err)
+	}
+	groupInfoData, err := core.EncodeGroupInfoMessage(&core.GroupInfoMessage{})
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to encode group info message: %w", err)
+	}
+
+	ohw := &core.ObjectHeaderWriter{
+		Version: 2,
+		Flags:   0,
+		Messages: []core.MessageWriter{
+			{Type: core.MsgLinkInfo, Data: linkInfoData},
+			{Type: core.MsgGroupInfo, Data: groupInfoData},
+		},
+	}
+	fw.stampTimes(ohw)
+	fw.stampAttributePhaseChange(ohw)
+	ohw.PadToSize(modernGroupHeaderSize)
+	headerSize := ohw.Size()
+
+	headerAddr, err := fw.writer.Allocate(headerSize)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to allocate object header: %w", err)
+	}
+
+	writtenSize, err := ohw.WriteTo(fw.writer, headerAddr)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to write object header: %w", err)
+	}
+	if writtenSize != headerSize {
+		return 0, 0, fmt.Errorf("header size mismatch: expected %d, wrote %d", headerSize, writtenSize)
+	}
+
+	return headerAddr, headerSize, nil
+}
+
+// findLinkInfo returns the parsed Link Info message of a group object header,
+// or an error if the header has none.
+func findLinkInfo(oh *core.ObjectHeader, sb *core.Superblock) (*core.LinkInfoMessage, error) {
+	for _, msg := range oh.Messages {
+		if msg.Type != core.MsgLinkInfo {
+			continue
+		}
+		linkInfo, err := core.ParseLinkInfoMessage(msg.Data, sb)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse link info message: %w", err)
+		}
+		return linkInfo, nil
+	}
+	return nil, errors.New("link info message not found")
+}
+
+// encodeHardLink encodes a hard Link message for a link-info group.
+func encodeHardLink(name string, targetAddr uint64, sb *core.Superblock) ([]byte, error) {
+	if len(name) > 255 {
+		return nil, fmt.Errorf("link name too long: %d bytes (max 255)", len(name))
+	}
+	value := make([]byte, sb.OffsetSize)
+	writeUint64Sized(value, targetAddr, int(sb.OffsetSize), sb.Endianness)
+
+	return core.EncodeLinkMessage(&core.LinkMessage{
+		Version:   1,
+		Flags:     0, // Hard link, 1-byte name length, ASCII
+		Type:      core.LinkTypeHard,
+		Name:      name,
+		LinkValue: value,
+	}, sb)
+}
+
+// writeUint64Sized writes value using size bytes in the given byte order.
+func writeUint64Sized(buf []byte, value uint64, size int, endianness binary.ByteOrder) {
+	switch size {
+	case 2:
+		endianness.PutUint16(buf, uint16(value)) //nolint:gosec // Safe: size limited to 2 bytes
+	case 4:
+		endianness.PutUint32(buf, uint32(value)) //nolint:gosec // Safe: size limited to 4 bytes
+	default:
+		endianness.PutUint64(buf, value)
+	}
+}
+
+// readModernGroupLinks returns all links of a link-info group, from compact Link
+// messages or from dense storage.
+func (fw *FileWriter) readModernGroupLinks(oh *core.ObjectHeader) ([]*structures.LinkMessage, error) {
+	sb := fw.file.sb
+
+	linkInfo, err := findLinkInfo(oh, sb)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw [][]byte
+	if linkInfo.HasFractalHeap() && linkInfo.HasNameBTree() {
+		raw, err = core.ReadDenseHeapObjects(fw.writer.Reader(), linkInfo.NameBTreeAddress, linkInfo.FractalHeapAddress, sb)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read dense links: %w", err)
+		}
+	} else {
+		for _, msg := range oh.Messages {
+			if msg.Type == core.MsgLinkMessage {
+				raw = append(raw, msg.Data)
+			}
+		}
+	}
+
+	links := make([]*structures.LinkMessage, 0, len(raw))
+	for _, data := range raw {
+		link, parseErr := structures.ParseLinkMessage(data, sb)
+		if parseErr != nil {
+			return nil, fmt.Errorf("failed to parse link message: %w", parseErr)
+		}
+		links = append(links, link)
+	}
+	return links, nil
+}
+
+// lookupModernLink resolves a link name in a link-info group to the target object address.
+func (fw *FileWriter) lookupModernLink(headerAddr uint64, name string) (uint64, error) {
+	oh, err := core.ReadObjectHeader(fw.writer.Reader(), headerAddr, fw.file.sb)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read group object header: %w", err)
+	}
+	links, err := fw.readModernGroupLinks(oh)
+	if err != nil {
+		return 0, err
+	}
+	for _, link := range links {
+		if link.Name == name {
+			return link.ObjectAddress, nil
+		}
+	}
+	return 0, fmt.Errorf("object not found: %s", name)
+}
+
+// linkToModernGroup adds a hard link to a link-info group.
+//
+// Like H5G__obj_insert(), links are stored compactly as Link messages in the group's
+// object header until the group would hold more than denseGroupThreshold links (or
+// the header allocation is exhausted); then all links are migrated to dense storage
+// (H5G__dense_create) and further links go directly into the fractal heap and name index.
+func (fw *FileWriter) linkToModernGroup(meta *GroupMetadata, childName string, childAddr uint64) error {
+	sb := fw.file.sb
+
+	oh, err := core.ReadObjectHeader(fw.writer.Reader(), meta.headerAddr, sb)
+	if err != nil {
+		return fmt.Errorf("failed to read group object header: %w", err)
+	}
+	linkInfo, err := findLinkInfo(oh, sb)
+	if err != nil {
+		return err
+	}
+
+	linkData, err := encodeHardLink(childName, childAddr, sb)
+	if err != nil {
+		return err
+	}
+
+	if linkInfo.HasFractalHeap() {
+		return fw.insertDenseLink(linkInfo, childName, linkData)
+	}
+
+	links, err := fw.readModernGroupLinks(oh)
+	if err != nil {
+		return err
+	}
+	for _, link := range links {
+		if link.Name == childName {
+			return fmt.Errorf("%w: %q", errLinkExists, childName)
+		}
+	}
+
+	if len(links) < denseGroupThreshold {
+		oh.Messages = filterMainChunkMessages(oh.Messages)
+		if err := core.AddMessageToObjectHeader(oh, core.MsgLinkMessage, linkData); err != nil {
+			return fmt.Errorf("failed to add link message: %w", err)
+		}
+		if core.ObjectHeaderSizeFromParsed(oh) <= meta.headerAllocSz {
+			return writeOHDRWithBoundsCheck(fw, meta.headerAddr, oh, sb)
+		}
+		// Header allocation exhausted: fall through to dense storage.
+	}
+
+	return fw.convertToDenseLinks(meta, linkData)
+}
+
+// insertDenseLink adds an encoded Link message to an existing dense link storage.
+//
+// Reference: H5Gdense.c - H5G__dense_insert().
+func (fw *FileWriter) insertDenseLink(linkInfo *core.LinkInfoMessage, name string, linkData []byte) error {
+	sb := fw.file.sb
+
+	heap := structures.NewWritableFractalHeap(64 * 1024)
+	if err := heap.LoadFromFile(fw.writer.Reader(), linkInfo.FractalHeapAddress, sb); err != nil {
+		return fmt.Errorf("failed to load link fractal heap: %w", err)
+	}
+	btree := structures.NewWritableBTreeV2(4096)
+	if err := btree.LoadFromFile(fw.writer.Reader(), linkInfo.NameBTreeAddress, sb); err != nil {
+		return fmt.Errorf("failed to load link name index: %w", err)
+	}
+
+	if btree.HasKey(name) {
+		return fmt.Errorf("%w: %q", errLinkExists, name)
+	}
+
+	if err := addLinkToDenseStorage(heap, btree, name, linkData); err != nil {
+		return err
+	}
+
+	if err := heap.WriteAt(fw.writer, sb); err != nil {
+		return fmt.Errorf("failed to write link fractal heap: %w", err)
+	}
+	if err := btree.WriteAt(fw.writer, fw.writer.Allocator(), sb); err != nil {
+		return fmt.Errorf("failed to write link name index: %w", err)
+	}
+	return nil
+}
+
+// addLinkToDenseStorage inserts a Link message into the fractal heap and indexes it by name.
+func addLinkToDenseStorage(heap *structures.WritableFractalHeap, btree *structures.WritableBTreeV2, name string, linkData []byte) error {
+	heapID, err := heap.InsertObject(linkData)
+	if err != nil {
+		return fmt.Errorf("failed to insert link %q into fractal heap: %w", name, err)
+	}
+	if len(heapID) != 8 {
+		return fmt.Errorf("unexpected heap ID length: %d bytes", len(heapID))
+	}
+	if err := btree.InsertRecord(name, binary.LittleEndian.Uint64(heapID)); err != nil {
+		return fmt.Errorf("failed to index link %q: %w", name, err)
+	}
+	return nil
+}
+
+// convertToDenseLinks migrates all compact links of a group (plus one new link)
+// to dense storage and rewrites the Link Info message with the new addresses.
+//
+// Reference: H5Gobj.c - H5G__obj_insert() compact-to-dense conversion.
+func (fw *FileWriter) convertToDenseLinks(meta *GroupMetadata, newLink []byte) error {
+	sb := fw.file.sb
+
+	// Re-read the header: the caller may have modified its copy.
+	oh, err := core.ReadObjectHeader(fw.writer.Reader(), meta.headerAddr, sb)
+	if err != nil {
+		return fmt.Errorf("failed to re-read group object header: %w", err)
+	}
+	linkInfo, err := findLinkInfo(oh, sb)
+	if err != nil {
+		return err
+	}
+
+	heap := structures.NewWritableFractalHeap(64 * 1024)
+	btree := structures.NewWritableBTreeV2(4096)
+
+	remaining := make([]*core.HeaderMessage, 0, len(oh.Messages))
+	for _, msg := range oh.Messages {
+		if msg.Type == core.MsgLinkMessage {
+			link, parseErr := structures.ParseLinkMessage(msg.Data, sb)
+			if parseErr != nil {
+				return fmt.Errorf("failed to parse link message: %w", parseErr)
+			}
+			if err := addLinkToDenseStorage(heap, btree, link.Name, msg.Data); err != nil {
+				return err
+			}
+			continue
+		}
+		if msg.Type == core.MsgNil || msg.FromContinuation {
+			continue
+		}
+		remaining = append(remaining, msg)
+	}
+
+	newParsed, err := structures.ParseLinkMessage(newLink, sb)
+	if err != nil {
+		return fmt.Errorf("failed to parse link message: %w", err)
+	}
+	if err := addLinkToDenseStorage(heap, btree, newParsed.Name, newLink); err != nil {
+		return err
+	}
+
+	allocator := fw.writer.Allocator()
+	heapAddr, err := heap.WriteToFile(fw.writer, allocator, sb)
+	if err != nil {
+		return fmt.Errorf("failed to write link fractal heap: %w", err)
+	}
+	btreeAddr, err := btree.WriteToFile(fw.writer, allocator, sb)
+	if err != nil {
+		return fmt.Errorf("failed to write link name index: %w", err)
+	}
+
+	linkInfo.FractalHeapAddress = heapAddr
+	linkInfo.NameBTreeAddress = btreeAddr
+	linkInfoData, err := core.EncodeLinkInfoMessage(linkInfo, sb)
+	if err != nil {
+		return fmt.Errorf("failed to encode link info message: %w", err)
+	}
+	for _, msg := range remaining {
+		if msg.Type == core.MsgLinkInfo {
+			msg.Data = linkInfoData
+		}
+	}
+	oh.Messages = remaining
+
+	if err := writeOHDRWithBoundsCheck(fw, meta.headerAddr, oh, sb); err != nil {
+		return err
+	}
+
+	// Dense storage was just created; later inserts load it back through the reader.
+	if err := fw.writer.Flush(); err != nil {
+		return fmt.Errorf("failed to flush after dense link conversion: %w", err)
+	}
+	return nil
+}
+
+// unlinkFromModernGroup removes a link from a link-info group.
+// Returns the address of the object the link pointed to.
+//
+// Reference: H5Gobj.c - H5G_obj_remove().
+func (fw *FileWriter) unlinkFromModernGroup(meta *GroupMetadata, childName string) (uint64, error) {
+	sb := fw.file.sb
+
+	oh, err := core.ReadObjectHeader(fw.writer.Reader(), meta.headerAddr, sb)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read group object header: %w", err)
+	}
+	linkInfo, err := findLinkInfo(oh, sb)
+	if err != nil {
+		return 0, err
+	}
+
+	if !linkInfo.HasFractalHeap() {
+		oh.Messages = filterMainChunkMessages(oh.Messages)
+		for i, msg := range oh.Messages {
+			if msg.Type != core.MsgLinkMessage {
+				continue
+			}
+			link, parseErr := structures.ParseLinkMessage(msg.Data, sb)
+			if parseErr != nil || link.Name != childName {
+				continue
+			}
+			oh.Messages = append(oh.Messages[:i], oh.Messages[i+1:]...)
+			if err := writeOHDRWithBoundsCheck(fw, meta.headerAddr, oh, sb); err != nil {
+				return 0, err
+			}
+			return link.ObjectAddress, nil
+		}
+		return 0, fmt.Errorf("child %q not found in group", childName)
+	}
+
+	heap := structures.NewWritableFractalHeap(64 * 1024)
+	if err := heap.LoadFromFile(fw.writer.Reader(), linkInfo.FractalHeapAddress, sb); err != nil {
+		return 0, fmt.Errorf("failed to load link fractal heap: %w", err)
+	}
+	btree := structures.NewWritableBTreeV2(4096)
+	if err := btree.LoadFromFile(fw.writer.Reader(), linkInfo.NameBTreeAddress, sb); err != nil {
+		return 0, fmt.Errorf("failed to load link name index: %w", err)
+	}
+
+	heapID, found := btree.SearchRecord(childName)
+	if !found {
+		return 0, fmt.Errorf("child %q not found in group", childName)
+	}
+	data, err := heap.GetObject(heapID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read link %q: %w", childName, err)
+	}
+	link, err := structures.ParseLinkMessage(data, sb)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse link %q: %w", childName, err)
+	}
+
+	if err := btree.DeleteRecord(childName); err != nil {
+		return 0, fmt.Errorf("failed to remove link %q from name index: %w", childName, err)
+	}
+	if err := heap.DeleteObject(heapID); err != nil {
+		return 0, fmt.Errorf("failed to remove link %q from fractal heap: %w", childName, err)
+	}
+	if err := heap.WriteAt(fw.writer, sb); err != nil {
+		return 0, fmt.Errorf("failed to write link fractal heap: %w", err)
+	}
+	if err := btree.WriteAt(fw.writer, fw.writer.Allocator(), sb); err != nil {
+		return 0, fmt.Errorf("failed to write link name index: %w", err)
+	}
+
+	return link.ObjectAddress, nil
+}
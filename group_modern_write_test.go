@@ -0,0 +1,235 @@
+package hdf5
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/scigolib/hdf5/internal/core"
+	"github.com/scigolib/hdf5/internal/structures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// modernGroupHeader reads the object header of the group at path in a reopened file.
+func modernGroupHeader(t *testing.T, f *File, path string) *core.ObjectHeader {
+	t.Helper()
+	var addr uint64
+	f.Walk(func(p string, obj Object) {
+		if g, ok := obj.(*Group); ok && p == path {
+			addr = g.address
+		}
+	})
+	require.NotZero(t, addr, "group %s not found", path)
+	oh, err := core.ReadObjectHeader(f.reader, addr, f.sb)
+	require.NoError(t, err)
+	return oh
+}
+
+// walkChildren returns the sorted paths of all objects directly below prefix.
+func walkChildren(f *File, prefix string) []string {
+	var paths []string
+	f.Walk(func(p string, _ Object) {
+		if len(p) > len(prefix) && p[:len(prefix)] == prefix {
+			paths = append(paths, p)
+		}
+	})
+	sort.Strings(paths)
+	return paths
+}
+
+func TestWithModernGroups_CompactLinks(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "modern_compact.h5")
+
+	fw, err := CreateForWrite(testFile, CreateTruncate, WithModernGroups())
+	require.NoError(t, err)
+
+	g, err := fw.CreateGroup("/results")
+	require.NoError(t, err)
+	require.NoError(t, g.WriteAttribute("units", "kelvin"))
+	_, err = fw.CreateGroup("/results/run1")
+	require.NoError(t, err)
+	_, err = fw.CreateGroup("/results/run2")
+	require.NoError(t, err)
+	ds, err := fw.CreateDataset("/results/temperature", Float64, []uint64{3})
+	require.NoError(t, err)
+	require.NoError(t, ds.Write([]float64{1.5, 2.5, 3.5}))
+	require.NoError(t, fw.Close())
+
+	f, err := Open(testFile)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	oh := modernGroupHeader(t, f, "/results/")
+	types := make(map[core.MessageType]int)
+	for _, msg := range oh.Messages {
+		types[msg.Type]++
+	}
+	assert.Equal(t, 1, types[core.MsgLinkInfo])
+	assert.Equal(t, 1, types[core.MsgGroupInfo])
+	assert.Equal(t, 3, types[core.MsgLinkMessage])
+	assert.Zero(t, types[core.MsgSymbolTable])
+
+	assert.Equal(t, []string{
+		"/results/run1/",
+		"/results/run2/",
+		"/results/temperature",
+	}, walkChildren(f, "/results/"))
+
+	var values []float64
+	f.Walk(func(p string, obj Object) {
+		if ds, ok := obj.(*Dataset); ok && p == "/results/temperature" {
+			values, err = ds.Read()
+		}
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []float64{1.5, 2.5, 3.5}, values)
+
+	var group *Group
+	f.Walk(func(p string, obj Object) {
+		if p == "/results/" {
+			group = obj.(*Group)
+		}
+	})
+	attrs, err := group.Attributes()
+	require.NoError(t, err)
+	require.Len(t, attrs, 1)
+	assert.Equal(t, "units", attrs[0].Name)
+}
+
+func TestWithModernGroups_DenseTransition(t *testing.T) {
+	const numChildren = 50
+	testFile := filepath.Join(t.TempDir(), "modern_dense.h5")
+
+	fw, err := CreateForWrite(testFile, CreateTruncate, WithModernGroups())
+	require.NoError(t, err)
+
+	_, err = fw.CreateGroup("/big")
+	require.NoError(t, err)
+	want := make([]string, 0, numChildren)
+	for i := 0; i < numChildren; i++ {
+		_, err := fw.CreateGroup(fmt.Sprintf("/big/child_%02d", i))
+		require.NoError(t, err, "child %d", i)
+		want = append(want, fmt.Sprintf("/big/child_%02d/", i))
+	}
+
+	// Lookups go through dense storage once the group has been converted.
+	_, err = fw.CreateDataset("/big/child_42/data", Int32, []uint64{2})
+	require.NoError(t, err)
+	require.NoError(t, fw.Close())
+
+	f, err := Open(testFile)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	oh := modernGroupHeader(t, f, "/big/")
+	linkInfo, err := findLinkInfo(oh, f.sb)
+	require.NoError(t, err)
+	assert.True(t, linkInfo.HasFractalHeap(), "group should use dense storage")
+	for _, msg := range oh.Messages {
+		assert.NotEqual(t, core.MsgLinkMessage, msg.Type, "no compact links after transition")
+	}
+
+	got := walkChildren(f, "/big/child_")
+	want = append(want, "/big/child_42/data")
+	sort.Strings(want)
+	assert.Equal(t, want, got)
+}
+
+func TestWithModernGroups_ManyLinks(t *testing.T) {
+	const numChildren = 1000 // Well beyond one 4 KiB name index leaf (371 links)
+	testFile := filepath.Join(t.TempDir(), "modern_many.h5")
+
+	fw, err := CreateForWrite(testFile, CreateTruncate, WithModernGroups())
+	require.NoError(t, err)
+	_, err = fw.CreateGroup("/big")
+	require.NoError(t, err)
+	want := make([]string, 0, numChildren)
+	for i := 0; i < numChildren; i++ {
+		path := fmt.Sprintf("/big/data_%04d", i)
+		ds, err := fw.CreateDataset(path, Int32, []uint64{1})
+		require.NoError(t, err, "child %d", i)
+		require.NoError(t, ds.Write([]int32{int32(i)}))
+		want = append(want, path)
+	}
+	require.NoError(t, fw.Close())
+
+	f, err := Open(testFile)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	oh := modernGroupHeader(t, f, "/big/")
+	linkInfo, err := findLinkInfo(oh, f.sb)
+	require.NoError(t, err)
+	btree := structures.NewWritableBTreeV2(0)
+	require.NoError(t, btree.LoadFromFile(f.reader, linkInfo.NameBTreeAddress, f.sb))
+	records, nodes := btree.Stats()
+	assert.Equal(t, uint64(numChildren), records)
+	assert.Greater(t, nodes, uint64(1), "name index should have several nodes")
+
+	assert.Equal(t, want, walkChildren(f, "/big/"))
+	values, err := findDatasetByPath(t, f, "/big/data_0777").Read()
+	require.NoError(t, err)
+	assert.Equal(t, []float64{777}, values)
+}
+
+func TestWithModernGroups_Delete(t *testing.T) {
+	for _, numChildren := range []int{3, 12} {
+		t.Run(fmt.Sprintf("%d children", numChildren), func(t *testing.T) {
+			testFile := filepath.Join(t.TempDir(), "modern_delete.h5")
+
+			fw, err := CreateForWrite(testFile, CreateTruncate, WithModernGroups())
+			require.NoError(t, err)
+
+			_, err = fw.CreateGroup("/g")
+			require.NoError(t, err)
+			for i := 0; i < numChildren; i++ {
+				_, err := fw.CreateGroup(fmt.Sprintf("/g/c%02d", i))
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, fw.Delete("/g/c01"))
+			require.Error(t, fw.Delete("/g/c01"))
+			require.NoError(t, fw.Close())
+
+			f, err := Open(testFile)
+			require.NoError(t, err)
+			defer func() { _ = f.Close() }()
+
+			got := walkChildren(f, "/g/")
+			assert.Len(t, got, numChildren-1)
+			assert.NotContains(t, got, "/g/c01/")
+		})
+	}
+}
+
+func TestWithModernGroups_DeleteNonEmpty(t *testing.T) {
+	fw, err := CreateForWrite(filepath.Join(t.TempDir(), "modern_nonempty.h5"), CreateTruncate, WithModernGroups())
+	require.NoError(t, err)
+	defer func() { _ = fw.Close() }()
+
+	_, err = fw.CreateGroup("/parent")
+	require.NoError(t, err)
+	_, err = fw.CreateGroup("/parent/child")
+	require.NoError(t, err)
+
+	err = fw.Delete("/parent")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "non-empty group")
+}
+
+func TestWithModernGroups_DuplicateLink(t *testing.T) {
+	fw, err := CreateForWrite(filepath.Join(t.TempDir(), "modern_dup.h5"), CreateTruncate, WithModernGroups())
+	require.NoError(t, err)
+	defer func() { _ = fw.Close() }()
+
+	_, err = fw.CreateGroup("/g")
+	require.NoError(t, err)
+	_, err = fw.CreateGroup("/g/a")
+	require.NoError(t, err)
+
+	meta := fw.groups["/g"]
+	err = fw.linkToModernGroup(meta, "a", meta.headerAddr)
+	require.ErrorIs(t, err, errLinkExists)
+}
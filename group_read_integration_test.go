@@ -136,6 +136,59 @@ func TestReadStrings_MixedLengths(t *testing.T) {
 	assert.Equal(t, "Go", result[2])
 }
 
+// TestReadStrings_Chunked reads fixed-length strings stored in chunks.
+func TestReadStrings_Chunked(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "read_strings_chunked.h5")
+
+	words := []string{"one", "two", "three", "four", "five", "six", "seven"}
+
+	fw, err := CreateForWrite(filename, CreateTruncate)
+	require.NoError(t, err)
+	ds, err := fw.CreateDataset("/words", String, []uint64{7}, WithStringSize(8), WithChunkDims([]uint64{3}))
+	require.NoError(t, err)
+	require.NoError(t, ds.Write(words))
+	require.NoError(t, fw.Close())
+
+	f, err := Open(filename)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	got, err := findDatasetByPath(t, f, "/words").ReadStrings()
+	require.NoError(t, err)
+	assert.Equal(t, words, got)
+
+	// Read() is numeric-only and points at ReadStrings.
+	_, err = findDatasetByPath(t, f, "/words").Read()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "use ReadStrings")
+}
+
+// TestReadStrings_ReferenceFiles reads string datasets written by the C library:
+// null-padded fixed-length strings and the variable-length strings of flux.h5.
+func TestReadStrings_ReferenceFiles(t *testing.T) {
+	f, err := Open("testdata/string_test.h5")
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	fixed, err := findDatasetByPath(t, f, "/fixed_strings").ReadStrings()
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, len(fixed), 3)
+	assert.Equal(t, []string{"hello", "world", "test"}, fixed[:3])
+
+	long, err := findDatasetByPath(t, f, "/long_strings").ReadStrings()
+	require.NoError(t, err)
+	require.NotEmpty(t, long)
+	assert.Equal(t, "This is a longer test string", long[0])
+
+	flux, err := Open("testdata/reference/flux.h5")
+	require.NoError(t, err)
+	defer func() { _ = flux.Close() }()
+
+	dims, err := findDatasetByPath(t, flux, "/group1/flux/dims").ReadStrings()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"time"}, dims)
+}
+
 // ---------------------------------------------------------------------------
 // ReadCompound tests
 // ---------------------------------------------------------------------------
@@ -46,19 +46,20 @@ type GroupWriter struct {
 
 // WriteAttribute writes an attribute to this group.
 //
-// Storage strategy (automatic):
-//   - 0-7 attributes: Compact storage (object header messages)
-//   - 8+ attributes: Dense storage (Fractal Heap + B-tree v2)
+// Storage strategy (automatic, thresholds set by WithMaxCompactAttributes):
+//   - Up to 8 attributes: Compact storage (object header messages)
+//   - 9+ attributes: Dense storage (Fractal Heap + B-tree v2)
 //
 // Supported value types:
 //   - Scalars: int8, int16, int32, int64, uint8, uint16, uint32, uint64, float32, float64
 //   - Arrays: []int32, []float64, etc. (1D arrays only)
 //   - Strings: string (fixed-length, converted to byte array)
 //   - String arrays: []string (variable-length strings via Global Heap)
+//   - Structs: MyStruct or []MyStruct (compound type, see DatasetWriter.WriteAttribute)
 //
 // Parameters:
 //   - name: Attribute name (ASCII, no null bytes)
-//   - value: Attribute value (Go scalar, slice, or string)
+//   - value: Attribute value (Go scalar, slice, string or struct)
 //
 // Returns:
 //   - error: If attribute cannot be written
@@ -72,10 +73,15 @@ type GroupWriter struct {
 //	group.WriteAttribute("topics", []string{"camera", "lidar", "imu"})
 //
 // Limitations:
-//   - No compound types
 //   - Attributes cannot be modified after creation (write-once)
 //   - No attribute deletion
 func (g *GroupWriter) WriteAttribute(name string, value interface{}) error {
+	if g.headerAddr == g.file.rootGroupAddr && g.file.file.sb.Version == core.Version0 {
+		// The v0 root group has a version 1 object header packed against
+		// its B-tree, with no room to grow.
+		return fmt.Errorf("root group attributes require superblock version 2 or later")
+	}
+
 	// Delegate to existing attribute writing infrastructure
 	// This reuses the same code path as DatasetWriter.WriteAttribute
 	return writeAttribute(g.file, g.headerAddr, name, value)
@@ -120,6 +126,36 @@ func (g *GroupWriter) Path() string {
 	return g.path
 }
 
+// Root returns a handle for the root group, for writing file-level
+// attributes such as a global "Conventions" attribute.
+//
+// Attributes use the same compact and dense storage as other groups. Files
+// created with superblock version 0 (WithSuperblockVersion) do not support
+// root group attributes.
+//
+// Example:
+//
+//	fw, _ := hdf5.CreateForWrite("data.h5", hdf5.CreateTruncate)
+//	fw.Root().WriteAttribute("Conventions", "CF-1.8")
+func (fw *FileWriter) Root() *GroupWriter {
+	return &GroupWriter{
+		path:       "/",
+		headerAddr: fw.rootGroupAddr,
+		file:       fw,
+	}
+}
+
+// WriteRootAttribute writes an attribute to the root group.
+// It is shorthand for fw.Root().WriteAttribute(name, value).
+//
+// Example:
+//
+//	fw.WriteRootAttribute("Conventions", "CF-1.8")
+//	fw.WriteRootAttribute("history", "created by acquisition v2.1")
+func (fw *FileWriter) WriteRootAttribute(name string, value interface{}) error {
+	return fw.Root().WriteAttribute(name, value)
+}
+
 // validateGroupPath validates group path is not empty, starts with '/', and is not root.
 func validateGroupPath(path string) error {
 	if path == "" {
@@ -134,13 +170,26 @@ func validateGroupPath(path string) error {
 	return nil
 }
 
+// groupBTreeK is the group B-tree internal node K (separate from GroupLeafNodeK).
+// Per C reference (H5Bprivate.h): HDF5_BTREE_SNODE_IK_DEF = 16, so each node has
+// up to 2K = 32 children.
+const groupBTreeK = 16
+
+// localHeapInitialSize returns the configured initial local heap size for new groups.
+func (fw *FileWriter) localHeapInitialSize() uint64 {
+	if fw.config == nil || fw.config.LocalHeapInitialSize == 0 {
+		return defaultLocalHeapSize
+	}
+	return fw.config.LocalHeapInitialSize
+}
+
 // createGroupStructures creates and writes the local heap, symbol table node, and B-tree for a group.
 // Returns (heapAddr, stNodeAddr, btreeAddr, error).
 func (fw *FileWriter) createGroupStructures() (uint64, uint64, uint64, error) {
 	offsetSize := int(fw.file.sb.OffsetSize)
 
-	// Create local heap (4096 bytes supports ~300+ typical names).
-	heap := structures.NewLocalHeap(4096)
+	// Create local heap for link names (grows on demand, see expandHeapAndAdd).
+	heap := structures.NewLocalHeap(fw.localHeapInitialSize())
 	heapAddr, err := fw.writer.Allocate(heap.Size())
 	if err != nil {
 		return 0, 0, 0, fmt.Errorf("failed to allocate heap: %w", err)
@@ -184,8 +233,9 @@ func (fw *FileWriter) createGroupStructures() (uint64, uint64, uint64, error) {
 // CreateGroup creates a new empty group in the HDF5 file.
 // Groups organize datasets and other groups in a hierarchical structure.
 //
-// This method creates an empty group using symbol table format (old HDF5 format).
-// For groups with many links, consider using CreateDenseGroup() or CreateGroupWithLinks().
+// By default this method creates an empty group using symbol table format (old HDF5 format).
+// Files created with WithModernGroups() get link-info groups instead, which switch
+// to dense link storage automatically once they grow past 8 links.
 //
 // Parameters:
 //   - path: Group path (must start with "/", e.g., "/data" or "/data/experiments")
@@ -208,9 +258,7 @@ func (fw *FileWriter) createGroupStructures() (uint64, uint64, uint64, error) {
 //	nested.WriteAttribute("MATLAB_class", "double")
 //
 // Limitations for MVP (v0.11.0-beta):
-//   - Only symbol table structure (no indexed groups)
 //   - No link creation time tracking
-//   - Maximum 32 entries per group (symbol table node capacity)
 //   - Parent group must exist (create parents first)
 func (fw *FileWriter) CreateGroup(path string) (*GroupWriter, error) {
 	// Validate path
@@ -228,10 +276,47 @@ func (fw *FileWriter) CreateGroup(path string) (*GroupWriter, error) {
 		}
 	}
 
+	var headerAddr uint64
+	if fw.config != nil && fw.config.ModernGroups {
+		addr, allocSz, err := fw.createModernGroupHeader()
+		if err != nil {
+			return nil, err
+		}
+		headerAddr = addr
+		fw.groups[path] = &GroupMetadata{
+			modern:        true,
+			headerAddr:    addr,
+			headerAllocSz: allocSz,
+		}
+	} else {
+		addr, err := fw.createSymbolTableGroup(path)
+		if err != nil {
+			return nil, err
+		}
+		headerAddr = addr
+	}
+
+	// Link to parent group
+	if err := fw.linkToParent(parent, name, headerAddr); err != nil {
+		return nil, fmt.Errorf("failed to link to parent: %w", err)
+	}
+
+	// Return GroupWriter handle
+	return &GroupWriter{
+		path:       path,
+		headerAddr: headerAddr,
+		file:       fw,
+	}, nil
+}
+
+// createSymbolTableGroup creates the heap, symbol table node, B-tree and object header
+// of an empty symbol-table group and records its metadata.
+// Returns the address of the group's object header.
+func (fw *FileWriter) createSymbolTableGroup(path string) (uint64, error) {
 	// Create group structures (heap, symbol table, B-tree)
 	heapAddr, stNodeAddr, btreeAddr, err := fw.createGroupStructures()
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
 
 	// Create object header for the group
@@ -239,12 +324,15 @@ func (fw *FileWriter) CreateGroup(path string) (*GroupWriter, error) {
 	stMsg := core.EncodeSymbolTableMessage(btreeAddr, heapAddr, int(fw.file.sb.OffsetSize), int(fw.file.sb.LengthSize))
 
 	ohw := &core.ObjectHeaderWriter{
-		Version: 2,
-		Flags:   0,
+		Version:  fw.objectHeaderVersion(),
+		Flags:    0,
+		RefCount: 1,
 		Messages: []core.MessageWriter{
 			{Type: core.MsgSymbolTable, Data: stMsg},
 		},
 	}
+	fw.stampTimes(ohw)
+	fw.stampAttributePhaseChange(ohw)
 
 	// Pre-allocate OHDR with padding to accommodate future attributes.
 	// This prevents corruption when attributes are added later.
@@ -253,17 +341,17 @@ func (fw *FileWriter) CreateGroup(path string) (*GroupWriter, error) {
 
 	headerAddr, err := fw.writer.Allocate(headerSize)
 	if err != nil {
-		return nil, fmt.Errorf("failed to allocate object header: %w", err)
+		return 0, fmt.Errorf("failed to allocate object header: %w", err)
 	}
 
 	// Write object header
 	writtenSize, err := ohw.WriteTo(fw.writer, headerAddr)
 	if err != nil {
-		return nil, fmt.Errorf("failed to write object header: %w", err)
+		return 0, fmt.Errorf("failed to write object header: %w", err)
 	}
 
 	if writtenSize != headerSize {
-		return nil, fmt.Errorf("header size mismatch: expected %d, wrote %d", headerSize, writtenSize)
+		return 0, fmt.Errorf("header size mismatch: expected %d, wrote %d", headerSize, writtenSize)
 	}
 
 	// Store group metadata for nested dataset linking
@@ -275,17 +363,7 @@ func (fw *FileWriter) CreateGroup(path string) (*GroupWriter, error) {
 		headerAllocSz: headerSize,
 	}
 
-	// Link to parent group
-	if err := fw.linkToParent(parent, name, headerAddr); err != nil {
-		return nil, fmt.Errorf("failed to link to parent: %w", err)
-	}
-
-	// Return GroupWriter handle
-	return &GroupWriter{
-		path:       path,
-		headerAddr: headerAddr,
-		file:       fw,
-	}, nil
+	return headerAddr, nil
 }
 
 // parsePath splits a path into parent directory and name.
@@ -337,6 +415,9 @@ func (fw *FileWriter) linkToParent(parentPath, childName string, childAddr uint6
 		if !exists {
 			return fmt.Errorf("parent group %q not found (create it first)", parentPath)
 		}
+		if meta.modern {
+			return fw.linkToModernGroup(meta, childName, childAddr)
+		}
 		heapAddr = meta.heapAddr
 		btreeAddr = meta.btreeAddr
 	}
@@ -357,10 +438,11 @@ func (fw *FileWriter) linkToParent(parentPath, childName string, childAddr uint6
 	}
 
 	// Step 3: Read ALL SNODs in this group (the B-tree may have multiple children after splits).
-	btreeNode, snodAddrs, err := fw.readGroupBTree(btreeAddr)
+	_, snodAddrs, innerAddrs, err := fw.readGroupBTreeNodes(btreeAddr)
 	if err != nil {
 		return fmt.Errorf("read group B-tree: %w", err)
 	}
+	prevSNODs := len(snodAddrs)
 
 	// Collect all entries from all SNODs, plus the new entry.
 	allEntries := make([]structures.SymbolTableEntry, 0, snodCapacity)
@@ -406,42 +488,12 @@ func (fw *FileWriter) linkToParent(parentPath, childName string, childAddr uint6
 	// Step 4: Rebuild and write B-tree FIRST (before SNODs).
 	// For v0 format with fixed addresses, the B-tree write must complete before SNOD writes
 	// to avoid overwriting SNOD data with B-tree zero padding.
-	const groupBTreeK = 16 // B-tree internal node K (separate from GroupLeafNodeK).
-	newBTree := structures.NewBTreeNodeV1(0, groupBTreeK)
-
-	for i := 0; i < numSNODs; i++ {
-		// Per C reference H5G__node_create (H5Gnode.c:306-309): initial keys are 0.
-		// Per H5G__node_cmp3 (H5Gnode.c:415): search_name <= left_key returns -1 (before).
-		// Per H5G__node_insert (H5Gnode.c:623): md_key = last entry of LEFT node.
-		//
-		// Key[0] = 0 (empty string sentinel)
-		// Key[i] for i>0 = last entry's name offset in SNOD[i-1] (the boundary between nodes)
-		// This ensures cmp3 returns 0 for names that belong in the RIGHT node.
-		var leftKey uint64
-		if i > 0 {
-			// Boundary key = last entry of the previous SNOD
-			prevEnd := i * snodCapacity
-			if prevEnd > len(allEntries) {
-				prevEnd = len(allEntries)
-			}
-			leftKey = allEntries[prevEnd-1].LinkNameOffset
-		}
-		if addErr := newBTree.AddKey(leftKey, snodAddrs[i]); addErr != nil {
-			return fmt.Errorf("add B-tree key for SNOD %d: %w", i, addErr)
-		}
-	}
-
-	// Add final right key (offset of last entry's name, i.e., the largest name).
-	lastEntry := allEntries[len(allEntries)-1]
-	newBTree.Keys = append(newBTree.Keys, lastEntry.LinkNameOffset)
-
-	// Write B-tree (rewrite in place -- B-tree was allocated with K=16 so has room for 32 children).
-	if err := newBTree.WriteAt(fw.writer, btreeAddr, offsetSize, groupBTreeK, fw.file.sb.Endianness); err != nil {
-		return fmt.Errorf("write B-tree: %w", err)
+	if err := fw.writeGroupBTree(btreeAddr, innerAddrs, allEntries, snodAddrs); err != nil {
+		return err
 	}
 
 	// Update parent's stNodeAddr to the first SNOD (it may have moved).
-	if len(btreeNode.ChildPointers) == 0 || snodAddrs[0] != btreeNode.ChildPointers[0] {
+	if prevSNODs == 0 {
 		fw.updateGroupStNodeAddr(parentPath, snodAddrs[0])
 	}
 
@@ -497,9 +549,57 @@ func (fw *FileWriter) resolveEntryName(entry structures.SymbolTableEntry, heap *
 	return name
 }
 
-// readGroupBTree reads the B-tree v1 node at the given address and extracts child SNOD addresses.
-// Returns the B-tree node, the list of SNOD addresses, and any error.
+// readGroupBTree reads a group's v1 B-tree and extracts the SNOD addresses it indexes.
+// Returns the root B-tree node, the list of SNOD addresses (in key order), and any error.
 func (fw *FileWriter) readGroupBTree(btreeAddr uint64) (*structures.BTreeNodeV1, []uint64, error) {
+	root, snodAddrs, _, err := fw.readGroupBTreeNodes(btreeAddr)
+	return root, snodAddrs, err
+}
+
+// readGroupBTreeNodes reads a group's v1 B-tree, descending into internal nodes.
+// Returns the root node, the SNOD addresses indexed by the leaves (in key order),
+// and the addresses of all non-root B-tree nodes (needed to free them on rebuild).
+func (fw *FileWriter) readGroupBTreeNodes(btreeAddr uint64) (*structures.BTreeNodeV1, []uint64, []uint64, error) {
+	root, err := fw.readGroupBTreeNode(btreeAddr)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var snodAddrs, innerAddrs []uint64
+	var walk func(node *structures.BTreeNodeV1) error
+	walk = func(node *structures.BTreeNodeV1) error {
+		for _, child := range node.ChildPointers {
+			if child == 0 || child == undefinedAddress {
+				continue
+			}
+			if node.NodeLevel == 0 {
+				snodAddrs = append(snodAddrs, child)
+				continue
+			}
+			childNode, childErr := fw.readGroupBTreeNode(child)
+			if childErr != nil {
+				return childErr
+			}
+			if childNode.NodeLevel != node.NodeLevel-1 {
+				return fmt.Errorf("group B-tree node at 0x%X has level %d, expected %d",
+					child, childNode.NodeLevel, node.NodeLevel-1)
+			}
+			innerAddrs = append(innerAddrs, child)
+			if walkErr := walk(childNode); walkErr != nil {
+				return walkErr
+			}
+		}
+		return nil
+	}
+	if err := walk(root); err != nil {
+		return nil, nil, nil, err
+	}
+
+	return root, snodAddrs, innerAddrs, nil
+}
+
+// readGroupBTreeNode reads a single B-tree v1 node of a group symbol table.
+func (fw *FileWriter) readGroupBTreeNode(btreeAddr uint64) (*structures.BTreeNodeV1, error) {
 	offsetSize := fw.file.sb.OffsetSize
 	endianness := fw.file.sb.Endianness
 
@@ -508,12 +608,12 @@ func (fw *FileWriter) readGroupBTree(btreeAddr uint64) (*structures.BTreeNodeV1,
 	header := make([]byte, headerSize)
 	//nolint:gosec // G115: HDF5 addresses fit in int64 for io.ReaderAt interface.
 	if _, err := fw.writer.ReadAt(header, int64(btreeAddr)); err != nil {
-		return nil, nil, fmt.Errorf("read B-tree header: %w", err)
+		return nil, fmt.Errorf("read B-tree header: %w", err)
 	}
 
 	sig := string(header[0:4])
 	if sig != "TREE" { //nolint:goconst // HDF5 B-tree signature used across multiple packages
-		return nil, nil, fmt.Errorf("invalid B-tree signature: %q", sig)
+		return nil, fmt.Errorf("invalid B-tree signature: %q", sig)
 	}
 
 	entriesUsed := endianness.Uint16(header[6:8])
@@ -525,7 +625,7 @@ func (fw *FileWriter) readGroupBTree(btreeAddr uint64) (*structures.BTreeNodeV1,
 	data := make([]byte, dataSize)
 	//nolint:gosec // G115: HDF5 addresses fit in int64 for io.ReaderAt interface.
 	if _, err := fw.writer.ReadAt(data, int64(btreeAddr)+int64(headerSize)); err != nil {
-		return nil, nil, fmt.Errorf("read B-tree data: %w", err)
+		return nil, fmt.Errorf("read B-tree data: %w", err)
 	}
 
 	node := &structures.BTreeNodeV1{
@@ -533,14 +633,13 @@ func (fw *FileWriter) readGroupBTree(btreeAddr uint64) (*structures.BTreeNodeV1,
 		NodeType:      header[4],
 		NodeLevel:     header[5],
 		EntriesUsed:   entriesUsed,
-		LeftSibling:   0xFFFFFFFFFFFFFFFF,
-		RightSibling:  0xFFFFFFFFFFFFFFFF,
+		LeftSibling:   readAddrFromBuf(header[8:], int(offsetSize), endianness),
+		RightSibling:  readAddrFromBuf(header[8+int(offsetSize):], int(offsetSize), endianness),
 		Keys:          make([]uint64, 0, entriesUsed+1),
 		ChildPointers: make([]uint64, 0, entriesUsed),
 	}
 
 	pos := 0
-	var snodAddrs []uint64
 	for i := uint16(0); i < entriesUsed; i++ {
 		key := readAddrFromBuf(data[pos:], int(offsetSize), endianness)
 		pos += int(offsetSize)
@@ -549,9 +648,6 @@ func (fw *FileWriter) readGroupBTree(btreeAddr uint64) (*structures.BTreeNodeV1,
 
 		node.Keys = append(node.Keys, key)
 		node.ChildPointers = append(node.ChildPointers, child)
-		if child != 0 && child != 0xFFFFFFFFFFFFFFFF {
-			snodAddrs = append(snodAddrs, child)
-		}
 	}
 	// Read final key.
 	if pos+int(offsetSize) <= len(data) {
@@ -559,7 +655,110 @@ func (fw *FileWriter) readGroupBTree(btreeAddr uint64) (*structures.BTreeNodeV1,
 		node.Keys = append(node.Keys, finalKey)
 	}
 
-	return node, snodAddrs, nil
+	return node, nil
+}
+
+// groupBTreeNodeSize returns the on-disk size of a group B-tree v1 node with K=groupBTreeK.
+// Header: 4 (sig) + 1 (type) + 1 (level) + 2 (entries) + 2*offsetSize (siblings),
+// followed by (2K+1) keys and 2K children.
+func groupBTreeNodeSize(offsetSize int) uint64 {
+	return uint64(8 + 2*offsetSize + (2*groupBTreeK+1)*offsetSize + 2*groupBTreeK*offsetSize) //nolint:gosec // Safe: small constant calculation
+}
+
+// writeGroupBTree rebuilds a group's v1 B-tree over the given SNODs.
+//
+// The root node is always written at btreeAddr so the Symbol Table message stays
+// valid. A single node indexes at most 2K SNODs (2K*2*GroupLeafNodeK = 256 links);
+// beyond that the tree grows internal levels bottom-up, the same way the chunk
+// index writer does (ChunkBTreeWriter.buildMultiLevelTree). Non-root nodes from
+// the previous layout (oldInner) are freed first so the allocator can reuse them.
+//
+// Keys follow H5G__node_cmp3 (H5Gnode.c): the left key of SNOD i is the name offset
+// of the last entry in SNOD i-1 (0 for the first SNOD), and the final right key is
+// the last entry overall. Internal nodes reuse the boundary keys of their subtrees.
+func (fw *FileWriter) writeGroupBTree(btreeAddr uint64, oldInner []uint64, entries []structures.SymbolTableEntry, snodAddrs []uint64) error {
+	if len(snodAddrs) == 0 {
+		return fmt.Errorf("group B-tree at 0x%X must index at least one SNOD", btreeAddr)
+	}
+	offsetSize := fw.file.sb.OffsetSize
+	nodeSize := groupBTreeNodeSize(int(offsetSize))
+
+	for _, addr := range oldInner {
+		_ = fw.writer.Allocator().Free(addr, nodeSize)
+	}
+
+	// bounds[i] is the left key of SNOD i; bounds[n] is the right key of the last SNOD.
+	n := len(snodAddrs)
+	bounds := make([]uint64, n+1)
+	for i := 1; i < n; i++ {
+		bounds[i] = entries[i*snodCapacity-1].LinkNameOffset
+	}
+	if len(entries) > 0 {
+		bounds[n] = entries[len(entries)-1].LinkNameOffset
+	}
+
+	// subtree is a child at the current level, covering SNODs [first, last).
+	type subtree struct {
+		addr        uint64
+		first, last int
+	}
+	level := make([]subtree, n)
+	for i, addr := range snodAddrs {
+		level[i] = subtree{addr: addr, first: i, last: i + 1}
+	}
+
+	const fanout = 2 * groupBTreeK
+	for nodeLevel := uint8(0); ; nodeLevel++ {
+		numNodes := (len(level) + fanout - 1) / fanout
+
+		// The topmost node always lives at btreeAddr.
+		addrs := []uint64{btreeAddr}
+		if numNodes > 1 {
+			addrs = make([]uint64, numNodes)
+			for i := range addrs {
+				addr, err := fw.writer.Allocate(nodeSize)
+				if err != nil {
+					return fmt.Errorf("allocate group B-tree node (level %d): %w", nodeLevel, err)
+				}
+				addrs[i] = addr
+			}
+		}
+
+		next := make([]subtree, numNodes)
+		for i := 0; i < numNodes; i++ {
+			end := (i + 1) * fanout
+			if end > len(level) {
+				end = len(level)
+			}
+			children := level[i*fanout : end]
+
+			node := structures.NewBTreeNodeV1(0, groupBTreeK)
+			node.NodeLevel = nodeLevel
+			if i > 0 {
+				node.LeftSibling = addrs[i-1]
+			}
+			if i < numNodes-1 {
+				node.RightSibling = addrs[i+1]
+			}
+			for _, c := range children {
+				if err := node.AddKey(bounds[c.first], c.addr); err != nil {
+					return fmt.Errorf("add group B-tree key (level %d): %w", nodeLevel, err)
+				}
+			}
+			lastChild := children[len(children)-1]
+			node.Keys = append(node.Keys, bounds[lastChild.last])
+
+			if err := node.WriteAt(fw.writer, addrs[i], offsetSize, groupBTreeK, fw.file.sb.Endianness); err != nil {
+				return fmt.Errorf("write group B-tree node (level %d): %w", nodeLevel, err)
+			}
+			next[i] = subtree{addr: addrs[i], first: children[0].first, last: lastChild.last}
+		}
+
+		if numNodes == 1 {
+			return nil
+		}
+		level = next
+	}
 }
 
 // readAddrFromBuf reads a variable-sized address from a byte buffer.
@@ -584,6 +783,10 @@ func readAddrFromBuf(data []byte, size int, endianness binary.ByteOrder) uint64
 func (fw *FileWriter) updateGroupHeapAddr(parentPath string, newHeapAddr uint64) error {
 	if parentPath == "" || parentPath == "/" {
 		fw.rootHeapAddr = newHeapAddr
+		// Superblock v0 caches the root heap address in the root symbol table entry.
+		if fw.file.sb.RootHeapAddr != 0 {
+			fw.file.sb.RootHeapAddr = newHeapAddr
+		}
 		// Rewrite root group's symbol table message.
 		return fw.rewriteSymbolTableMessage(fw.rootGroupAddr, fw.rootBTreeAddr, newHeapAddr)
 	}
@@ -592,20 +795,30 @@ func (fw *FileWriter) updateGroupHeapAddr(parentPath string, newHeapAddr uint64)
 		return fmt.Errorf("group %q not found", parentPath)
 	}
 	meta.heapAddr = newHeapAddr
-	return nil
+	return fw.rewriteSymbolTableMessage(meta.headerAddr, meta.btreeAddr, newHeapAddr)
 }
 
-// expandHeapAndAdd expands the local heap (doubles its size) and adds a string.
+// expandHeapAndAdd grows a full local heap and adds a string.
+// Like H5HL_insert, the heap is relocated: a heap at least twice the size is
+// allocated at a fresh address, existing strings are copied (offsets stay valid),
+// the group's Symbol Table message is updated, and the old heap space is freed.
 // Returns the new heap, new address, string offset, and any error.
-func (fw *FileWriter) expandHeapAndAdd(heap *structures.LocalHeap, _ uint64, parentPath, childName string) (*structures.LocalHeap, uint64, uint64, error) {
+func (fw *FileWriter) expandHeapAndAdd(heap *structures.LocalHeap, oldHeapAddr uint64, parentPath, childName string) (*structures.LocalHeap, uint64, uint64, error) {
 	newSize := heap.DataSegmentSize * 2
-	newHeap := structures.NewLocalHeap(newSize)
-	if err := newHeap.CopyStringsFrom(heap); err != nil {
-		return nil, 0, 0, fmt.Errorf("copy strings to new heap: %w", err)
-	}
-	nameOffset, err := newHeap.AddString(childName)
-	if err != nil {
-		return nil, 0, 0, fmt.Errorf("add string to expanded heap: %w", err)
+	var newHeap *structures.LocalHeap
+	var nameOffset uint64
+	for {
+		newHeap = structures.NewLocalHeap(newSize)
+		if err := newHeap.CopyStringsFrom(heap); err != nil {
+			return nil, 0, 0, fmt.Errorf("copy strings to new heap: %w", err)
+		}
+		offset, err := newHeap.AddString(childName)
+		if err == nil {
+			nameOffset = offset
+			break
+		}
+		// Name longer than the doubled free space: keep doubling.
+		newSize *= 2
 	}
 	newHeapAddr, err := fw.writer.Allocate(newHeap.Size())
 	if err != nil {
@@ -614,6 +827,7 @@ func (fw *FileWriter) expandHeapAndAdd(heap *structures.LocalHeap, _ uint64, par
 	if err := fw.updateGroupHeapAddr(parentPath, newHeapAddr); err != nil {
 		return nil, 0, 0, fmt.Errorf("update heap address: %w", err)
 	}
+	_ = fw.writer.Allocator().Free(oldHeapAddr, heap.Size())
 	return newHeap, newHeapAddr, nameOffset, nil
 }
 
@@ -730,6 +944,9 @@ func (fw *FileWriter) readSymbolTableNode(addr uint64) (*structures.SymbolTableN
 //
 // Reference: H5Gcreate.c - H5Gcreate2().
 func (fw *FileWriter) CreateDenseGroup(name string, links map[string]string) error {
+	if err := fw.requireNewFormat("dense group"); err != nil {
+		return err
+	}
 	// Validate name
 	if !strings.HasPrefix(name, "/") {
 		return fmt.Errorf("group name must start with /: %s", name)
@@ -738,8 +955,15 @@ func (fw *FileWriter) CreateDenseGroup(name string, links map[string]string) err
 	// Create DenseGroupWriter
 	dgw := writer.NewDenseGroupWriter(name)
 
-	// Add all links
-	for linkName, targetPath := range links {
+	// Add all links, in name order so that the file does not depend on map
+	// iteration order.
+	linkNames := make([]string, 0, len(links))
+	for linkName := range links {
+		linkNames = append(linkNames, linkName)
+	}
+	sort.Strings(linkNames)
+	for _, linkName := range linkNames {
+		targetPath := links[linkName]
 		// Resolve target path to object header address
 		targetAddr, err := fw.resolveObjectAddress(targetPath)
 		if err != nil {
@@ -804,6 +1028,13 @@ func (fw *FileWriter) resolveObjectAddress(path string) (uint64, error) {
 		if !exists {
 			return 0, fmt.Errorf("parent group %q not found", parent)
 		}
+		if meta.modern {
+			addr, err := fw.lookupModernLink(meta.headerAddr, name)
+			if err != nil {
+				return 0, fmt.Errorf("object not found: %s", path)
+			}
+			return addr, nil
+		}
 		btreeAddr = meta.btreeAddr
 		heapAddr = meta.heapAddr
 	}
@@ -866,6 +1097,9 @@ func (fw *FileWriter) unlinkFromParent(parentPath, childName string) (uint64, er
 		if !exists {
 			return 0, fmt.Errorf("parent group %q not found", parentPath)
 		}
+		if meta.modern {
+			return fw.unlinkFromModernGroup(meta, childName)
+		}
 		heapAddr = meta.heapAddr
 		btreeAddr = meta.btreeAddr
 	}
@@ -877,7 +1111,7 @@ func (fw *FileWriter) unlinkFromParent(parentPath, childName string) (uint64, er
 	}
 
 	// Step 2: Read ALL SNODs from B-tree.
-	_, snodAddrs, err := fw.readGroupBTree(btreeAddr)
+	_, snodAddrs, innerAddrs, err := fw.readGroupBTreeNodes(btreeAddr)
 	if err != nil {
 		return 0, fmt.Errorf("read group B-tree: %w", err)
 	}
@@ -929,43 +1163,17 @@ func (fw *FileWriter) unlinkFromParent(parentPath, childName string) (uint64, er
 		}
 		snodAddrs = append(snodAddrs, newAddr)
 	}
-	// Only use as many as needed.
+	// Only use as many as needed; release SNODs that are no longer referenced.
+	for _, addr := range snodAddrs[numSNODs:] {
+		_ = fw.writer.Allocator().Free(addr, snodTotalSize)
+	}
 	snodAddrs = snodAddrs[:numSNODs]
 
 	offsetSize := fw.file.sb.OffsetSize
 
-	// Rebuild B-tree.
-	const groupBTreeK = 16
-	newBTree := structures.NewBTreeNodeV1(0, groupBTreeK)
-
-	if len(allEntries) > 0 {
-		for i := 0; i < numSNODs; i++ {
-			var leftKey uint64
-			if i > 0 {
-				prevEnd := i * snodCapacity
-				if prevEnd > len(allEntries) {
-					prevEnd = len(allEntries)
-				}
-				leftKey = allEntries[prevEnd-1].LinkNameOffset
-			}
-			if addErr := newBTree.AddKey(leftKey, snodAddrs[i]); addErr != nil {
-				return 0, fmt.Errorf("add B-tree key for SNOD %d: %w", i, addErr)
-			}
-		}
-		// Final right key = last entry's name offset.
-		lastEntry := allEntries[len(allEntries)-1]
-		newBTree.Keys = append(newBTree.Keys, lastEntry.LinkNameOffset)
-	} else {
-		// Empty group: single SNOD with key 0.
-		if addErr := newBTree.AddKey(0, snodAddrs[0]); addErr != nil {
-			return 0, fmt.Errorf("add B-tree key for empty SNOD: %w", addErr)
-		}
-		newBTree.Keys = append(newBTree.Keys, uint64(0))
-	}
-
-	// Write B-tree.
-	if err := newBTree.WriteAt(fw.writer, btreeAddr, offsetSize, groupBTreeK, fw.file.sb.Endianness); err != nil {
-		return 0, fmt.Errorf("write B-tree: %w", err)
+	// Rebuild and write B-tree.
+	if err := fw.writeGroupBTree(btreeAddr, innerAddrs, allEntries, snodAddrs); err != nil {
+		return 0, err
 	}
 
 	// Write entries to SNODs.
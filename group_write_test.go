@@ -1,7 +1,10 @@
 package hdf5
 
 import (
+	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -189,3 +192,88 @@ func TestCreateGroup_BinaryFormat(t *testing.T) {
 	// 5. "OHDR" signature (object header for group)
 	require.Contains(t, string(data), "OHDR", "should contain object header")
 }
+
+// TestCreateGroup_ManyChildren stresses a single parent with 1000 child groups
+// plus parent attributes. This exceeds the capacity of both the default local
+// heap (forcing relocation) and a single group B-tree node (forcing a
+// multi-level tree).
+func TestCreateGroup_ManyChildren(t *testing.T) {
+	const numChildren = 1000
+
+	for _, tc := range []struct {
+		name string
+		opts []interface{}
+	}{
+		{name: "v2 superblock"},
+		{name: "v0 superblock", opts: []interface{}{WithSuperblockVersion(SuperblockV0)}},
+		{name: "small initial heap", opts: []interface{}{WithLocalHeapInitialSize(64)}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			testFile := filepath.Join(t.TempDir(), "many_children.h5")
+
+			fw, err := CreateForWrite(testFile, CreateTruncate, tc.opts...)
+			require.NoError(t, err)
+
+			parent, err := fw.CreateGroup("/parent")
+			require.NoError(t, err)
+			for i := 0; i < 10; i++ {
+				require.NoError(t, parent.WriteAttribute(fmt.Sprintf("attr_%02d", i), int32(i)))
+			}
+			for i := 0; i < numChildren; i++ {
+				_, err := fw.CreateGroup(fmt.Sprintf("/parent/child_%04d", i))
+				require.NoError(t, err, "child %d", i)
+			}
+			require.NoError(t, fw.Close())
+
+			f, err := Open(testFile)
+			require.NoError(t, err)
+			defer func() { _ = f.Close() }()
+
+			children := make(map[string]bool)
+			var parentGroup *Group
+			f.Walk(func(path string, obj Object) {
+				if path == "/parent/" {
+					parentGroup, _ = obj.(*Group)
+				}
+				if strings.HasPrefix(path, "/parent/child_") {
+					children[path] = true
+				}
+			})
+			require.Len(t, children, numChildren)
+			require.True(t, children["/parent/child_0000/"])
+			require.True(t, children["/parent/child_0999/"])
+
+			require.NotNil(t, parentGroup)
+			attrs, err := parentGroup.Attributes()
+			require.NoError(t, err)
+			require.Len(t, attrs, 10)
+		})
+	}
+}
+
+func TestWithLocalHeapInitialSize(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "heap_size.h5")
+
+	fw, err := CreateForWrite(testFile, CreateTruncate, WithLocalHeapInitialSize(32))
+	require.NoError(t, err)
+	require.Equal(t, uint64(32), fw.config.LocalHeapInitialSize)
+
+	// Names longer than the whole initial heap must still fit after growth.
+	longName := "/" + strings.Repeat("g", 200)
+	_, err = fw.CreateGroup(longName)
+	require.NoError(t, err)
+	_, err = fw.CreateGroup("/short")
+	require.NoError(t, err)
+	require.NoError(t, fw.Close())
+
+	f, err := Open(testFile)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	var found []string
+	f.Walk(func(path string, _ Object) {
+		found = append(found, path)
+	})
+	require.Contains(t, found, longName+"/")
+	require.Contains(t, found, "/short/")
+}
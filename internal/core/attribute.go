@@ -13,17 +13,28 @@ import (
 
 // Attribute represents an HDF5 attribute with metadata and data.
 type Attribute struct {
-	Name      string
-	Datatype  *DatatypeMessage
-	Dataspace *DataspaceMessage
-	Data      []byte
+	Name string
+	// NameCharset is the character set of Name (CharsetASCII or CharsetUTF8),
+	// stored in version 3 attribute messages.
+	NameCharset uint8
+	Datatype    *DatatypeMessage
+	Dataspace   *DataspaceMessage
+	Data        []byte
 
 	// For variable-length types, we need access to the file reader
 	// to resolve Global Heap references.
 	reader     io.ReaderAt
 	offsetSize int
+
+	// sharedDatatype holds the shared message that replaces the datatype
+	// when the attribute uses a committed datatype.
+	sharedDatatype []byte
 }
 
+// attrFlagDatatypeShared marks an attribute whose datatype is a shared
+// message referencing a committed datatype.
+const attrFlagDatatypeShared = 0x01
+
 // AttributeInfoMessage represents the Attribute Info Message (0x000F).
 // This message contains information about dense attribute storage.
 // Reference: H5Adense.c in C library.
@@ -40,7 +51,7 @@ type AttributeInfoMessage struct {
 // ParseAttributeMessage parses an attribute message (type 0x000C).
 // Format according to HDF5 spec:
 // - Version (1 byte).
-// - Flags (1 byte) - reserved, always 0.
+// - Flags (1 byte) - bit 0: datatype shared, bit 1: dataspace shared.
 // - Name size (2 bytes).
 // - Datatype size (2 bytes).
 // - Dataspace size (2 bytes).
@@ -61,7 +72,8 @@ func ParseAttributeMessage(data []byte, endianness binary.ByteOrder) (*Attribute
 	version := data[offset]
 	offset++
 
-	// Flags (reserved).
+	// Flags.
+	flags := data[offset]
 	offset++
 
 	// Name size (2 bytes).
@@ -79,6 +91,7 @@ func ParseAttributeMessage(data []byte, endianness binary.ByteOrder) (*Attribute
 	// For version 3+, there's a name encoding byte.
 	if version >= 3 {
 		// Name encoding (1 byte) - 0 = ASCII, 1 = UTF-8.
+		attr.NameCharset = data[offset]
 		offset++
 	}
 
@@ -93,18 +106,18 @@ func ParseAttributeMessage(data []byte, endianness binary.ByteOrder) (*Attribute
 		attr.Name = string(data[offset : offset+int(nameSize)-1])
 	}
 
-	// For version 1 and 2, name/datatype/dataspace are padded to 8-byte boundaries.
-	// For version 3+, no padding (sizes are exact).
+	// For version 1, name/datatype/dataspace are padded to 8-byte boundaries.
+	// For version 2+, no padding (sizes are exact).
 	// Reference: H5Oattr.c - H5O_ALIGN_OLD macro: (8 * (((X) + 7) / 8))
 	alignTo8 := func(size uint16) int {
 		return int((size + 7) & ^uint16(7))
 	}
 
-	if version < 3 {
-		// V1/V2: Pad to 8-byte boundaries
+	if version < 2 {
+		// V1: Pad to 8-byte boundaries
 		offset += alignTo8(nameSize)
 	} else {
-		// V3+: Exact sizes
+		// V2+: Exact sizes
 		offset += int(nameSize)
 	}
 
@@ -119,8 +132,12 @@ func ParseAttributeMessage(data []byte, endianness binary.ByteOrder) (*Attribute
 	if err != nil {
 		return nil, utils.WrapError("datatype parse failed", err)
 	}
+	// A committed datatype is resolved by the caller, which has the reader.
+	if flags&attrFlagDatatypeShared != 0 {
+		attr.sharedDatatype = append([]byte(nil), datatypeData...)
+	}
 
-	if version < 3 {
+	if version < 2 {
 		offset += alignTo8(datatypeSize)
 	} else {
 		offset += int(datatypeSize)
@@ -137,7 +154,7 @@ func ParseAttributeMessage(data []byte, endianness binary.ByteOrder) (*Attribute
 		return nil, utils.WrapError("dataspace parse failed", err)
 	}
 
-	if version < 3 {
+	if version < 2 {
 		offset += alignTo8(dataspaceSize)
 	} else {
 		offset += int(dataspaceSize)
@@ -168,14 +185,19 @@ func (a *Attribute) ReadValue() (interface{}, error) {
 		return nil, fmt.Errorf("attribute missing datatype or dataspace")
 	}
 
+	if a.Dataspace.Type == DataspaceNull {
+		return nullAttributeValue(a.Datatype), nil
+	}
+
 	totalElements := a.Dataspace.TotalElements()
 	if totalElements == 0 || len(a.Data) == 0 {
 		// Empty attribute - return empty slice instead of nil.
 		return []interface{}{}, nil
 	}
 
-	// For scalar attributes, return single value.
-	isScalar := len(a.Dataspace.Dimensions) == 0 ||
+	// For scalar attributes, return single value. Besides true rank-0
+	// dataspaces this covers the [1] dataspaces our writer uses for scalars.
+	isScalar := a.Dataspace.IsScalar() || len(a.Dataspace.Dimensions) == 0 ||
 		(len(a.Dataspace.Dimensions) == 1 && a.Dataspace.Dimensions[0] == 1)
 
 	switch a.Datatype.Class {
@@ -283,6 +305,7 @@ func (a *Attribute) ReadValue() (interface{}, error) {
 
 		stringSize := uint64(a.Datatype.Size)
 		paddingType := a.Datatype.GetStringPadding()
+		charset := a.Datatype.GetStringCharset()
 		values := make([]string, totalElements)
 
 		for i := uint64(0); i < totalElements; i++ {
@@ -291,7 +314,7 @@ func (a *Attribute) ReadValue() (interface{}, error) {
 				return nil, fmt.Errorf("data too short for string element %d", i)
 			}
 			stringBytes := a.Data[offset : offset+stringSize]
-			values[i] = decodeFixedString(stringBytes, paddingType)
+			values[i] = decodeString(stringBytes, paddingType, charset)
 		}
 
 		if isScalar {
@@ -326,18 +349,85 @@ func (a *Attribute) ReadValue() (interface{}, error) {
 				totalBytes, len(a.Data))
 		}
 
+		heaps := newGlobalHeapCache(a.reader, a.offsetSize)
 		values := make([]string, totalElements)
 		for i := uint64(0); i < totalElements; i++ {
 			offset := i * refSize
 			elementData := a.Data[offset : offset+refSize]
 
-			str, err := a.readVariableLengthString(elementData)
+			str, err := a.readCachedVariableLengthString(heaps, elementData)
 			if err != nil {
 				return nil, fmt.Errorf("failed to read variable-length string element %d: %w", i, err)
 			}
 			values[i] = str
 		}
 
+		if isScalar {
+			return values[0], nil
+		}
+		return values, nil
+
+	case DatatypeEnum:
+		// Enumerated values are returned as their symbolic names.
+		enum, err := ParseEnumType(a.Datatype)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse enum type: %w", err)
+		}
+
+		elemSize := uint64(enum.Base.Size)
+		totalBytes, err := utils.SafeMultiply(totalElements, elemSize)
+		if err != nil {
+			return nil, fmt.Errorf("attribute size overflow (enum): %w", err)
+		}
+		if totalBytes > uint64(len(a.Data)) {
+			return nil, fmt.Errorf("attribute data size mismatch: need %d bytes, have %d",
+				totalBytes, len(a.Data))
+		}
+
+		values := make([]string, totalElements)
+		for i := uint64(0); i < totalElements; i++ {
+			raw := a.Data[i*elemSize : (i+1)*elemSize]
+			name, ok := enum.NameOf(raw)
+			if !ok {
+				return nil, fmt.Errorf("enum element %d: value %x is not a member", i, raw)
+			}
+			values[i] = name
+		}
+
+		if isScalar {
+			return values[0], nil
+		}
+		return values, nil
+
+	case DatatypeReference:
+		// Class bit field bits 0-3: 0 = object reference, 1 = region reference.
+		if a.Datatype.ClassBitField&0x0F != 1 {
+			break
+		}
+		// Each element is a global heap ID: heap address + object index (4 bytes).
+		values, err := ParseRegionReferences(a.Data, totalElements, int(a.Datatype.Size)-4)
+		if err != nil {
+			return nil, err
+		}
+		if isScalar {
+			return values[0], nil
+		}
+		return values, nil
+
+	case DatatypeCompound:
+		compound, err := ParseCompoundType(a.Datatype)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse compound type: %w", err)
+		}
+
+		// Only the offset size is consulted when resolving vlen string members.
+		//nolint:gosec // G115: offsetSize is bounded to 4 or 8 by HDF5 format specification
+		sb := &Superblock{OffsetSize: uint8(a.offsetSize)}
+		values, err := parseCompoundData(a.Data, compound, totalElements, a.reader, sb)
+		if err != nil {
+			return nil, err
+		}
+
 		if isScalar {
 			return values[0], nil
 		}
@@ -347,6 +437,106 @@ func (a *Attribute) ReadValue() (interface{}, error) {
 	return nil, fmt.Errorf("unsupported datatype class %d or size %d", a.Datatype.Class, a.Datatype.Size)
 }
 
+// nullAttributeValue returns the value of an attribute with a NULL
+// dataspace: an empty slice of the type ReadValue returns for the datatype,
+// or []interface{}{} if the datatype is not supported.
+func nullAttributeValue(dt *DatatypeMessage) interface{} {
+	switch dt.Class {
+	case DatatypeFixed:
+		switch dt.Size {
+		case 4:
+			return []int32{}
+		case 8:
+			return []int64{}
+		}
+	case DatatypeFloat:
+		switch dt.Size {
+		case 4:
+			return []float32{}
+		case 8:
+			return []float64{}
+		}
+	case DatatypeString, DatatypeVarLen, DatatypeEnum:
+		return []string{}
+	case DatatypeCompound:
+		return []CompoundValue{}
+	case DatatypeReference:
+		if dt.ClassBitField&0x0F == 1 {
+			return []RegionReference{}
+		}
+	}
+	return []interface{}{}
+}
+
+// ReadVarLenSequences reads a variable-length sequence attribute (for example
+// DIMENSION_LIST, a sequence of object references per dimension) and returns
+// the raw bytes of each sequence, fetched from the global heap. Each entry holds
+// length*baseSize bytes, where baseSize is the size of the sequence base type;
+// empty sequences are returned as nil.
+//
+// Reference: H5Tvlen.c - H5T__vlen_disk_read().
+func (a *Attribute) ReadVarLenSequences() ([][]byte, error) {
+	if a.Datatype == nil || a.Datatype.Class != DatatypeVarLen || a.Datatype.IsVariableString() {
+		return nil, fmt.Errorf("attribute %q is not a variable-length sequence", a.Name)
+	}
+	if a.reader == nil {
+		return nil, fmt.Errorf("variable-length attribute requires file reader (not available)")
+	}
+
+	base, err := ParseDatatypeMessage(a.Datatype.Properties)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse sequence base type: %w", err)
+	}
+
+	totalElements := uint64(1)
+	if a.Dataspace != nil {
+		totalElements = a.Dataspace.TotalElements()
+	}
+
+	// Each element is: length (4) + heap address (offsetSize) + object index (4).
+	refSize := uint64(a.offsetSize + 8) //nolint:gosec // G115: offsetSize is 4 or 8
+	totalBytes, err := utils.SafeMultiply(totalElements, refSize)
+	if err != nil {
+		return nil, fmt.Errorf("attribute size overflow (vlen sequence): %w", err)
+	}
+	if totalBytes > uint64(len(a.Data)) {
+		return nil, fmt.Errorf("attribute data size mismatch for vlen sequences: need %d bytes, have %d",
+			totalBytes, len(a.Data))
+	}
+
+	heaps := newGlobalHeapCache(a.reader, a.offsetSize)
+	sequences := make([][]byte, totalElements)
+	for i := uint64(0); i < totalElements; i++ {
+		element := a.Data[i*refSize : (i+1)*refSize]
+		length := binary.LittleEndian.Uint32(element[0:4])
+		if length == 0 {
+			continue
+		}
+
+		ref, err := ParseGlobalHeapReference(element[4:], a.offsetSize)
+		if err != nil {
+			return nil, fmt.Errorf("sequence %d: failed to parse global heap reference: %w", i, err)
+		}
+		if ref.HeapAddress == 0 {
+			continue
+		}
+
+		obj, err := heaps.object(ref)
+		if err != nil {
+			return nil, fmt.Errorf("sequence %d: %w", i, err)
+		}
+
+		size, err := utils.SafeMultiply(uint64(length), uint64(base.Size))
+		if err != nil || size > uint64(len(obj.Data)) {
+			return nil, fmt.Errorf("sequence %d: %d elements of %d bytes exceed heap object size %d",
+				i, length, base.Size, len(obj.Data))
+		}
+		sequences[i] = obj.Data[:size]
+	}
+
+	return sequences, nil
+}
+
 // readVariableLengthString reads a variable-length string from the Global Heap.
 //
 // For variable-length strings in attributes, the format is:
@@ -356,6 +546,12 @@ func (a *Attribute) ReadValue() (interface{}, error) {
 //
 // Reference: HDF5 Format Specification III.E (Global Heap), H5Tvlen.c.
 func (a *Attribute) readVariableLengthString(data []byte) (string, error) {
+	return a.readCachedVariableLengthString(newGlobalHeapCache(a.reader, a.offsetSize), data)
+}
+
+// readCachedVariableLengthString is readVariableLengthString resolving the
+// reference through heaps, so that elements of one attribute share collections.
+func (a *Attribute) readCachedVariableLengthString(heaps *globalHeapCache, data []byte) (string, error) {
 	// Expected size: 4 (length) + offsetSize (heap address) + 4 (object index)
 	expectedSize := 4 + a.offsetSize + 4
 	if len(data) < expectedSize {
@@ -377,16 +573,10 @@ func (a *Attribute) readVariableLengthString(data []byte) (string, error) {
 		return "", nil
 	}
 
-	// Read the global heap collection.
-	collection, err := ReadGlobalHeapCollection(a.reader, ref.HeapAddress, a.offsetSize)
-	if err != nil {
-		return "", fmt.Errorf("failed to read global heap collection at 0x%X: %w", ref.HeapAddress, err)
-	}
-
-	// Get the object from the collection.
-	obj, err := collection.GetObject(ref.ObjectIndex)
+	// Get the object from its collection.
+	obj, err := heaps.object(ref)
 	if err != nil {
-		return "", fmt.Errorf("failed to get object %d from heap collection: %w", ref.ObjectIndex, err)
+		return "", err
 	}
 
 	// Convert object data to string.
@@ -432,6 +622,9 @@ func ParseAttributesFromMessages(r io.ReaderAt, messages []*HeaderMessage, sb *S
 			// Log error but continue with other attributes
 			continue
 		}
+		if err := attr.resolveSharedDatatype(r, sb); err != nil {
+			continue
+		}
 		// Set reader for variable-length type resolution
 		attr.reader = r
 		attr.offsetSize = int(sb.OffsetSize)
@@ -450,6 +643,59 @@ func ParseAttributesFromMessages(r io.ReaderAt, messages []*HeaderMessage, sb *S
 	return attributes, nil
 }
 
+// CountAttributes returns the number of attributes of the object whose
+// header is at address, without decoding them: compact attribute messages are
+// counted and, for dense storage, the record count of the name index B-tree
+// is added.
+func CountAttributes(r io.ReaderAt, address uint64, sb *Superblock) (int, error) {
+	header, err := readObjectHeader(r, address, sb, false)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, msg := range header.Messages {
+		switch msg.Type {
+		case MsgAttribute:
+			count++
+		case MsgAttributeInfo:
+			info, err := ParseAttributeInfoMessage(msg.Data, sb)
+			if err != nil {
+				return 0, fmt.Errorf("failed to parse attribute info: %w", err)
+			}
+			if info.BTreeNameIndexAddr == 0 || isUndefinedAddress(info.BTreeNameIndexAddr, sb.OffsetSize) {
+				continue // No dense attributes yet
+			}
+			btree, err := readBTreeV2HeaderRaw(r, info.BTreeNameIndexAddr, sb)
+			if err != nil {
+				return 0, fmt.Errorf("failed to read attribute name index: %w", err)
+			}
+			count += int(btree.TotalRecords) //nolint:gosec // G115: attribute counts fit in int
+		}
+	}
+	return count, nil
+}
+
+// resolveSharedDatatype replaces the datatype of an attribute that uses a
+// committed datatype with the datatype stored in the committed object.
+//
+// Reference: H5Oattr.c - H5O__attr_decode() (H5O_MSG_FLAG_SHARED handling).
+func (a *Attribute) resolveSharedDatatype(r io.ReaderAt, sb *Superblock) error {
+	if a.sharedDatatype == nil {
+		return nil
+	}
+	address, err := ParseSharedMessageAddress(a.sharedDatatype, int(sb.OffsetSize))
+	if err != nil {
+		return err
+	}
+	datatype, err := ReadCommittedDatatype(r, address, sb)
+	if err != nil {
+		return err
+	}
+	a.Datatype = datatype
+	return nil
+}
+
 // Helper functions for float conversion (copied from dataset_reader.go to avoid circular import).
 func float32frombits(b uint32) float32 {
 	//nolint:gosec // G103: unsafe.Pointer required for IEEE 754 float32 bit representation
@@ -488,17 +734,17 @@ func readDenseAttributes(r io.ReaderAt, attrInfo *AttributeInfoMessage, sb *Supe
 		return nil, fmt.Errorf("failed to read B-tree header: %w", err)
 	}
 
-	// Step 2: Read B-tree leaf node to get all heap IDs
-	heapIDs, err := readBTreeV2LeafRecords(r, btreeHeader.RootNodeAddr, btreeHeader.NumRecordsRoot, sb)
+	// Step 2: Walk the B-tree nodes to get all heap IDs
+	heapIDs, err := readBTreeV2HeapIDs(r, btreeHeader, sb)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read B-tree leaf: %w", err)
+		return nil, fmt.Errorf("failed to read B-tree records: %w", err)
 	}
 
 	if len(heapIDs) == 0 {
 		return []*Attribute{}, nil
 	}
 
-	// Step 3: Read fractal heap header to find direct block
+	// Step 3: Read fractal heap header to find the direct blocks
 	heapHeader, err := readFractalHeapHeaderRaw(r, attrInfo.FractalHeapAddr, sb)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read heap header: %w", err)
@@ -513,8 +759,12 @@ func readDenseAttributes(r io.ReaderAt, attrInfo *AttributeInfoMessage, sb *Supe
 			return nil, fmt.Errorf("failed to parse heap ID %d: %w", i, err)
 		}
 
-		// Read object from direct block
-		objectData, err := readHeapObject(r, heapHeader.RootBlockAddress, offset, length, sb, heapHeader)
+		// Read object from the direct block holding it
+		blockAddr, err := locateDirectBlock(r, heapHeader, offset, sb)
+		if err != nil {
+			return nil, fmt.Errorf("failed to locate heap object %d: %w", i, err)
+		}
+		objectData, err := readHeapObject(r, blockAddr, offset, length, sb, heapHeader)
 		if err != nil {
 			return nil, fmt.Errorf("failed to read heap object %d: %w", i, err)
 		}
@@ -524,6 +774,9 @@ func readDenseAttributes(r io.ReaderAt, attrInfo *AttributeInfoMessage, sb *Supe
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse attribute %d: %w", i, err)
 		}
+		if err := attr.resolveSharedDatatype(r, sb); err != nil {
+			return nil, fmt.Errorf("failed to resolve datatype of attribute %q: %w", attr.Name, err)
+		}
 
 		// Set reader for variable-length type resolution
 		attr.reader = r
@@ -628,54 +881,6 @@ func readBTreeV2HeaderRaw(r io.ReaderAt, addr uint64, sb *Superblock) (*btreeV2H
 	return header, nil
 }
 
-// readBTreeV2LeafRecords reads heap IDs from a B-tree v2 leaf node.
-// Format (Section III.A.2 of HDF5 spec):
-//   - Signature "BTLF" (4 bytes)
-//   - Version (1 byte)
-//   - Type (1 byte)
-//   - Records (N × record size):
-//     Each record: Name Hash (4 bytes) + Heap ID (7 bytes)
-//   - Checksum (4 bytes)
-func readBTreeV2LeafRecords(r io.ReaderAt, addr uint64, numRecords uint16, _ *Superblock) ([][7]byte, error) {
-	// Each record: 4 (hash) + 7 (heap ID) = 11 bytes
-	// Header: 4 (sig) + 1 (ver) + 1 (type) = 6 bytes
-	// Checksum: 4 bytes
-	bufSize := 6 + int(numRecords)*11 + 4
-	buf := make([]byte, bufSize)
-
-	//nolint:gosec // G115: HDF5 addresses fit in int64 for io.ReaderAt interface
-	n, err := r.ReadAt(buf, int64(addr))
-	if err != nil && !errors.Is(err, io.EOF) {
-		return nil, fmt.Errorf("read failed at 0x%X: %w", addr, err)
-	}
-	if n < 10 {
-		return nil, fmt.Errorf("leaf node too short: %d bytes", n)
-	}
-
-	// Check signature
-	if string(buf[0:4]) != "BTLF" {
-		return nil, fmt.Errorf("invalid B-tree v2 leaf signature: %q", buf[0:4])
-	}
-
-	// Skip version (1) and type (1)
-	offset := 6
-
-	// Read records
-	heapIDs := make([][7]byte, numRecords)
-	for i := uint16(0); i < numRecords; i++ {
-		if offset+11 > len(buf) {
-			return nil, fmt.Errorf("buffer too short for record %d", i)
-		}
-
-		// Skip name hash (4 bytes), copy heap ID (7 bytes)
-		offset += 4
-		copy(heapIDs[i][:], buf[offset:offset+7])
-		offset += 7
-	}
-
-	return heapIDs, nil
-}
-
 // fractalHeapHeaderRaw represents a minimal fractal heap header.
 // Reference: H5HFhdr.c in C library.
 type fractalHeapHeaderRaw struct {
@@ -688,6 +893,10 @@ type fractalHeapHeaderRaw struct {
 	HeapOffsetSize     uint8  // Computed from MaxHeapSize
 	HeapLengthSize     uint8  // Computed from MaxDirectBlockSize and MaxManagedObjSize
 	ChecksumDirBlocks  bool   // Whether direct blocks have checksums
+	FiltersLen         uint16 // Size of the I/O filter information, 0 if unfiltered
+	TableWidth         uint16 // Columns of the doubling table
+	StartingBlockSize  uint64 // Size of the blocks in the first two rows
+	CurrentRootRows    uint16 // Rows of the root indirect block, 0 if the root is a direct block
 }
 
 // readFractalHeapHeaderRaw reads a fractal heap header directly from file.
@@ -702,10 +911,11 @@ type fractalHeapHeaderRaw struct {
 //   - Max Heap Size (2 bytes)
 //   - ... more fields ...
 //   - Root Block Address (offsetSize bytes) at offset 132
+//   - Current # of Rows in Root Indirect Block (2 bytes)
 //   - Checksum (4 bytes)
 func readFractalHeapHeaderRaw(r io.ReaderAt, addr uint64, sb *Superblock) (*fractalHeapHeaderRaw, error) {
-	// We need to read up to offset 132 + offsetSize + 4 (checksum)
-	// Max with 8-byte offsets: 132 + 8 + 4 = 144 bytes
+	// We need to read up to offset 132 + offsetSize + 2 (root rows)
+	// Max with 8-byte offsets: 132 + 8 + 2 = 142 bytes
 	buf := make([]byte, 144)
 	//nolint:gosec // G115: HDF5 addresses fit in int64 for io.ReaderAt interface
 	n, err := r.ReadAt(buf, int64(addr))
@@ -733,7 +943,7 @@ func readFractalHeapHeaderRaw(r io.ReaderAt, addr uint64, sb *Superblock) (*frac
 	offset += 2
 
 	// I/O Filters Encoded Length (2 bytes)
-	filtersLen := sb.Endianness.Uint16(buf[offset : offset+2])
+	header.FiltersLen = sb.Endianness.Uint16(buf[offset : offset+2])
 	offset += 2
 
 	// Flags (1 byte)
@@ -744,14 +954,17 @@ func readFractalHeapHeaderRaw(r io.ReaderAt, addr uint64, sb *Superblock) (*frac
 	// Max Managed Object Size (4 bytes)
 	header.MaxManagedObjSize = sb.Endianness.Uint32(buf[offset : offset+4])
 
-	// Skip to Table Width (offset 110 from start)
-	offset = 110
+	// Skip the huge object, free space and statistics fields to Table
+	// Width (offset 110 from start with 8-byte offsets and lengths)
+	sizeofSize := int(sb.LengthSize)
+	offset += 4 + 10*sizeofSize + 2*int(sb.OffsetSize)
 
 	// Table Width (2 bytes)
+	header.TableWidth = sb.Endianness.Uint16(buf[offset : offset+2])
 	offset += 2
 
 	// Starting Block Size (sizeofSize bytes)
-	sizeofSize := int(sb.LengthSize)
+	header.StartingBlockSize = readUint64(buf[offset:], sizeofSize, sb.Endianness)
 	offset += sizeofSize
 
 	// Max Direct Block Size (sizeofSize bytes)
@@ -783,18 +996,20 @@ func readFractalHeapHeaderRaw(r io.ReaderAt, addr uint64, sb *Superblock) (*frac
 		header.HeapLengthSize = maxManSizeEncoded
 	}
 
-	// Skip to Root Block Address at offset 132
-	offset = 132
+	// Skip Max Heap Size and Starting # of Rows to the Root Block Address
+	// (offset 132 with 8-byte offsets and lengths)
+	offset += 4
 
 	// Root Block Address (offsetSize bytes)
 	offsetSize := int(sb.OffsetSize)
-	if offset+offsetSize > len(buf) {
+	if offset+offsetSize+2 > len(buf) {
 		return nil, fmt.Errorf("buffer too short for root block address")
 	}
 	header.RootBlockAddress = readAddress(buf[offset:offset+offsetSize], offsetSize)
+	offset += offsetSize
 
-	// Note: We ignore filtersLen for now (assume no filters in dense attributes)
-	_ = filtersLen
+	// Current # of Rows in Root Indirect Block (2 bytes)
+	header.CurrentRootRows = sb.Endianness.Uint16(buf[offset : offset+2])
 
 	return header, nil
 }
@@ -462,9 +462,10 @@ func TestReadValue_NullDataspace(t *testing.T) {
 		Data: []byte{},
 	}
 
+	// A NULL dataspace reads as an empty slice of the datatype's Go type.
 	val, err := attr.ReadValue()
 	require.NoError(t, err)
-	require.Equal(t, []interface{}{}, val)
+	require.Equal(t, []int32{}, val)
 }
 
 func TestReadValue_UnsupportedFloatSize(t *testing.T) {
@@ -735,110 +736,6 @@ func TestReadBTreeV2HeaderRaw_4ByteOffsets(t *testing.T) {
 	require.Equal(t, uint16(3), header.NumRecordsRoot)
 }
 
-// ---------------------------------------------------------------------------
-// Binary parsing tests for readBTreeV2LeafRecords
-// ---------------------------------------------------------------------------
-
-func TestReadBTreeV2LeafRecords_Valid(t *testing.T) {
-	sb := &Superblock{
-		OffsetSize: 8,
-		LengthSize: 8,
-		Endianness: binary.LittleEndian,
-	}
-
-	numRecords := uint16(3)
-	// Each record: 4 bytes hash + 7 bytes heap ID = 11 bytes.
-	// Header: 4 (sig) + 1 (ver) + 1 (type) = 6 bytes.
-	// Checksum: 4 bytes.
-	bufSize := 6 + int(numRecords)*11 + 4
-	buf := make([]byte, bufSize)
-
-	offset := 0
-	copy(buf[offset:], "BTLF")
-	offset += 4
-	buf[offset] = 0 // version
-	offset++
-	buf[offset] = 8 // type
-	offset++
-
-	// Record 0
-	binary.LittleEndian.PutUint32(buf[offset:], 0x11111111) // hash
-	offset += 4
-	heapID0 := [7]byte{0x00, 0x10, 0x00, 0x20, 0x00, 0x00, 0x00}
-	copy(buf[offset:offset+7], heapID0[:])
-	offset += 7
-
-	// Record 1
-	binary.LittleEndian.PutUint32(buf[offset:], 0x22222222)
-	offset += 4
-	heapID1 := [7]byte{0x00, 0x30, 0x00, 0x40, 0x00, 0x00, 0x00}
-	copy(buf[offset:offset+7], heapID1[:])
-	offset += 7
-
-	// Record 2
-	binary.LittleEndian.PutUint32(buf[offset:], 0x33333333)
-	offset += 4
-	heapID2 := [7]byte{0x00, 0x50, 0x00, 0x60, 0x00, 0x00, 0x00}
-	copy(buf[offset:offset+7], heapID2[:])
-
-	reader := bytes.NewReader(buf)
-
-	heapIDs, err := readBTreeV2LeafRecords(reader, 0, numRecords, sb)
-	require.NoError(t, err)
-	require.Len(t, heapIDs, 3)
-	require.Equal(t, heapID0, heapIDs[0])
-	require.Equal(t, heapID1, heapIDs[1])
-	require.Equal(t, heapID2, heapIDs[2])
-}
-
-func TestReadBTreeV2LeafRecords_InvalidSignature(t *testing.T) {
-	sb := &Superblock{
-		OffsetSize: 8,
-		Endianness: binary.LittleEndian,
-	}
-
-	buf := make([]byte, 40)
-	copy(buf[0:4], "XXXX")
-
-	reader := bytes.NewReader(buf)
-
-	_, err := readBTreeV2LeafRecords(reader, 0, 1, sb)
-	require.Error(t, err)
-	require.Contains(t, err.Error(), "invalid B-tree v2 leaf signature")
-}
-
-func TestReadBTreeV2LeafRecords_ZeroRecords(t *testing.T) {
-	sb := &Superblock{
-		OffsetSize: 8,
-		Endianness: binary.LittleEndian,
-	}
-
-	bufSize := 6 + 4 // header + checksum, no records
-	buf := make([]byte, bufSize)
-	copy(buf[0:4], "BTLF")
-	buf[4] = 0 // version
-	buf[5] = 8 // type
-
-	reader := bytes.NewReader(buf)
-
-	heapIDs, err := readBTreeV2LeafRecords(reader, 0, 0, sb)
-	require.NoError(t, err)
-	require.Len(t, heapIDs, 0)
-}
-
-func TestReadBTreeV2LeafRecords_TooShort(t *testing.T) {
-	sb := &Superblock{
-		OffsetSize: 8,
-		Endianness: binary.LittleEndian,
-	}
-
-	buf := make([]byte, 5) // Too short
-	reader := bytes.NewReader(buf)
-
-	_, err := readBTreeV2LeafRecords(reader, 0, 1, sb)
-	require.Error(t, err)
-}
-
 // ---------------------------------------------------------------------------
 // Binary parsing tests for readFractalHeapHeaderRaw
 // ---------------------------------------------------------------------------
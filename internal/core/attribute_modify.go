@@ -241,7 +241,7 @@ func DeleteCompactAttribute(writer io.WriterAt, objectAddr uint64, name string,
 //  3. Encode new attribute value
 //  4. Check sizes:
 //     a. Same size → Overwrite in heap (in-place, fast path)
-//     b. Different size → Delete old, insert new, update B-tree
+//     b. Different size → Insert new, update B-tree, delete old
 //  5. Write updated heap and B-tree back to file
 //
 // Parameters:
@@ -295,15 +295,11 @@ func ModifyDenseAttribute(heap HeapWriter, btree BTreeWriter, name string, newAt
 		}
 		// B-tree unchanged (same heap ID)
 	} else {
-		// Different size → Delete old, insert new, update B-tree
+		// Different size → Insert new, update B-tree, delete old. Inserting
+		// first leaves the attribute intact if the heap has no room for the
+		// new value (the caller may compact the heap and retry).
 
-		// 4a. Delete old heap object
-		err = heap.DeleteObject(heapID)
-		if err != nil {
-			return fmt.Errorf("failed to delete old heap object: %w", err)
-		}
-
-		// 4b. Insert new attribute → get new heap ID
+		// 4a. Insert new attribute → get new heap ID
 		newHeapIDBytes, err := heap.InsertObject(newAttrData)
 		if err != nil {
 			return fmt.Errorf("failed to insert new attribute: %w", err)
@@ -315,11 +311,17 @@ func ModifyDenseAttribute(heap HeapWriter, btree BTreeWriter, name string, newAt
 		}
 		newHeapID := binary.LittleEndian.Uint64(newHeapIDBytes)
 
-		// 4c. Update B-tree record with new heap ID
+		// 4b. Update B-tree record with new heap ID
 		err = btree.UpdateRecord(name, newHeapID)
 		if err != nil {
 			return fmt.Errorf("failed to update B-tree record: %w", err)
 		}
+
+		// 4c. Delete old heap object
+		err = heap.DeleteObject(heapID)
+		if err != nil {
+			return fmt.Errorf("failed to delete old heap object: %w", err)
+		}
 	}
 
 	// Note: Heap and B-tree are written back to file by caller (attribute_write.go)
@@ -3,6 +3,7 @@ package core
 import (
 	"bytes"
 	"encoding/binary"
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -331,6 +332,35 @@ func TestParseAttributeMessage_Version3(t *testing.T) {
 	require.Equal(t, "test", attr.Name)
 }
 
+func TestParseAttributeMessage_RankZeroDataspace(t *testing.T) {
+	// Version 3 attribute with a 4-byte version 2 scalar dataspace, the
+	// layout h5py produces for attrs["x"] = 2.5 with the latest format.
+	data := []byte{
+		3, 0, // Version, flags
+		2, 0, // Name size ("x\0")
+		20, 0, // Datatype size
+		4, 0, // Dataspace size
+		0, // Name encoding (ASCII)
+		'x', 0,
+		0x11, 0x20, 0x3F, 0x00, 8, 0, 0, 0, // Float, 8 bytes
+		0, 0, 64, 0, 52, 11, 0, 52, // Bit offset, precision, exponent and mantissa fields
+		0xFF, 0x03, 0, 0, // Exponent bias 1023
+		2, 0, 0, 0, // Dataspace: version 2, rank 0, flags, type scalar
+	}
+	value := make([]byte, 8)
+	binary.LittleEndian.PutUint64(value, math.Float64bits(2.5))
+	data = append(data, value...)
+
+	attr, err := ParseAttributeMessage(data, binary.LittleEndian)
+	require.NoError(t, err)
+	require.True(t, attr.Dataspace.IsScalar())
+	require.Equal(t, uint64(1), attr.Dataspace.TotalElements())
+
+	v, err := attr.ReadValue()
+	require.NoError(t, err)
+	require.Equal(t, 2.5, v)
+}
+
 func TestAttributeReadValue_EmptyAttribute(t *testing.T) {
 	// Test empty attribute (0 elements)
 	attr := &Attribute{
@@ -451,6 +481,48 @@ func TestAttributeReadValue_ScalarTypes(t *testing.T) {
 	}
 }
 
+// TestAttributeReadValue_FixedStrings reads fixed-length string attributes
+// the way other tools store them: UTF-8 text, padded with nulls or spaces.
+func TestAttributeReadValue_FixedStrings(t *testing.T) {
+	const (
+		nullTerm = 0x00
+		nullPad  = 0x01
+		spacePad = 0x02
+		utf8Bits = uint32(CharsetUTF8) << 4
+	)
+	tests := []struct {
+		name     string
+		bitField uint32
+		data     []byte
+		want     string
+	}{
+		{"ascii null-terminated", nullTerm, []byte("unit\x00xx"), "unit"},
+		{"utf-8 null-padded", nullPad | utf8Bits, []byte("Größe\x00\x00\x00"), "Größe"},
+		{"utf-8 space-padded", spacePad | utf8Bits, []byte("Ω·m    "), "Ω·m"},
+		{"utf-8 space-padded with null", spacePad | utf8Bits, []byte("°C   \x00"), "°C"},
+		{"utf-8 cut inside character", nullPad | utf8Bits, []byte("ab\xC3"), "ab"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attr := &Attribute{
+				Name: "units",
+				Datatype: &DatatypeMessage{
+					Class:         DatatypeString,
+					Size:          uint32(len(tt.data)),
+					ClassBitField: tt.bitField,
+				},
+				Dataspace: &DataspaceMessage{Type: DataspaceScalar},
+				Data:      tt.data,
+			}
+
+			val, err := attr.ReadValue()
+			require.NoError(t, err)
+			require.Equal(t, tt.want, val)
+		})
+	}
+}
+
 func TestAttributeReadValue_ArrayTypes(t *testing.T) {
 	tests := []struct {
 		name       string
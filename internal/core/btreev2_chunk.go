@@ -0,0 +1,275 @@
+package core
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/bits"
+)
+
+// v2 B-tree signatures and the record types of chunk indexes.
+//
+// Reference: H5B2pkg.h, H5B2private.h - H5B2_CDSET_ID, H5B2_CDSET_FILT_ID.
+const (
+	btreeV2HeaderSignature   = "BTHD"
+	btreeV2InternalSignature = "BTIN"
+	btreeV2LeafSignature     = "BTLF"
+
+	BTreeV2ChunkRecord         = 10 // Unfiltered chunk: address and scaled offsets.
+	BTreeV2FilteredChunkRecord = 11 // Filtered chunk: address, size, filter mask and scaled offsets.
+)
+
+// Creation parameters of v2 B-tree chunk indexes, as chosen by the C library.
+//
+// Reference: H5Dpkg.h - H5D_BT2_NODE_SIZE, H5D_BT2_SPLIT_PERC, H5D_BT2_MERGE_PERC.
+const (
+	BTreeV2ChunkNodeSize = 2048
+	BTreeV2ChunkSplitPct = 100
+	BTreeV2ChunkMergePct = 40
+)
+
+// btreeV2MaxDepth bounds the depth accepted from v2 B-tree headers. A tree
+// of 2 KiB nodes this deep holds far more records than any file.
+const btreeV2MaxDepth = 16
+
+// btreeV2NodePrefix is the size of the signature, version, type and checksum
+// of every v2 B-tree node.
+const btreeV2NodePrefix = 4 + 1 + 1 + 4
+
+// BTreeV2Level describes the capacity of the nodes at one depth of a v2
+// B-tree (depth 0 holds the leaves).
+type BTreeV2Level struct {
+	MaxRecords    uint64 // Records a node at this depth holds at most.
+	CumMaxRecords uint64 // Records a subtree rooted at this depth holds at most.
+	CumSize       int    // Bytes encoding the record count of such a subtree.
+}
+
+// BTreeV2Geometry holds the node capacities of a v2 B-tree, which decide
+// the widths of the variable-size fields in internal nodes.
+//
+// Reference: H5B2hdr.c - H5B2__hdr_init(), H5B2pkg.h - H5B2_NUM_INT_REC().
+type BTreeV2Geometry struct {
+	NodeSize   int
+	RecordSize int
+	OffsetSize int
+	NrecSize   int            // Bytes encoding the record count of a child node.
+	Levels     []BTreeV2Level // Indexed by depth, 0 to the tree depth.
+}
+
+// NewBTreeV2Geometry computes the node capacities of a v2 B-tree of the
+// given depth.
+func NewBTreeV2Geometry(nodeSize uint32, recordSize int, offsetSize uint8, depth int) (*BTreeV2Geometry, error) {
+	if recordSize <= 0 {
+		return nil, fmt.Errorf("invalid v2 B-tree record size: %d", recordSize)
+	}
+	if depth < 0 || depth > btreeV2MaxDepth {
+		return nil, fmt.Errorf("invalid v2 B-tree depth: %d", depth)
+	}
+	g := &BTreeV2Geometry{
+		NodeSize:   int(nodeSize),
+		RecordSize: recordSize,
+		OffsetSize: int(offsetSize),
+		Levels:     make([]BTreeV2Level, depth+1),
+	}
+
+	leafRecords := (g.NodeSize - btreeV2NodePrefix) / recordSize
+	if leafRecords < 2 {
+		return nil, fmt.Errorf("v2 B-tree node size %d too small for %d-byte records", nodeSize, recordSize)
+	}
+	g.Levels[0] = BTreeV2Level{MaxRecords: uint64(leafRecords), CumMaxRecords: uint64(leafRecords)}
+	g.NrecSize = limitEncSize(uint64(leafRecords))
+
+	for d := 1; d <= depth; d++ {
+		pointer := g.PointerSize(d)
+		records := (g.NodeSize - (btreeV2NodePrefix + pointer)) / (recordSize + pointer)
+		if records < 1 {
+			return nil, fmt.Errorf("v2 B-tree node size %d too small for depth %d", nodeSize, d)
+		}
+		below := g.Levels[d-1].CumMaxRecords
+		cum := (uint64(records)+1)*below + uint64(records)
+		g.Levels[d] = BTreeV2Level{MaxRecords: uint64(records), CumMaxRecords: cum, CumSize: limitEncSize(cum)}
+	}
+	return g, nil
+}
+
+// PointerSize returns the size of one child pointer in an internal node at
+// the given depth: the child address, its record count and, for children
+// that are internal nodes themselves, the record count of their subtree.
+func (g *BTreeV2Geometry) PointerSize(depth int) int {
+	size := g.OffsetSize + g.NrecSize
+	if depth > 1 {
+		size += g.Levels[depth-1].CumSize
+	}
+	return size
+}
+
+// limitEncSize returns the number of bytes needed to encode values up to n.
+//
+// Reference: H5VMprivate.h - H5VM_limit_enc_size().
+func limitEncSize(n uint64) int {
+	if n == 0 {
+		return 1
+	}
+	return (bits.Len64(n)-1)/8 + 1
+}
+
+// ChunkSizeFieldWidth returns the width of the stored chunk size in filtered
+// chunk index records, allowing one extra byte for filters that make the
+// chunk larger.
+//
+// Reference: H5Dbtree2.c - H5D__bt2_idx_create(), H5Dfarray.c.
+func ChunkSizeFieldWidth(chunkBytes uint64) int {
+	log2 := 0
+	if chunkBytes > 0 {
+		log2 = bits.Len64(chunkBytes) - 1
+	}
+	return min(1+(log2+8)/8, 8)
+}
+
+// btreeV2Chunks reads a v2 B-tree chunk index, used by HDF5 1.10+ for chunked
+// datasets with more than one unlimited dimension. Each record holds the
+// scaled coordinates of its chunk; records are stored in internal nodes as
+// well as in leaves.
+//
+// Reference: H5Dbtree2.c, H5B2cache.c - H5B2__cache_hdr_deserialize(),
+// H5B2__cache_int_deserialize(), H5B2__cache_leaf_deserialize().
+func btreeV2Chunks(r io.ReaderAt, layout *DataLayoutMessage, sb *Superblock) ([]ChunkEntry, error) {
+	// Signature, version, type, node size, record size, depth, split and
+	// merge percents, root address, root record count, total record count,
+	// checksum.
+	size := 4 + 1 + 1 + 4 + 2 + 2 + 1 + 1 + int(sb.OffsetSize) + 2 + int(sb.LengthSize) + 4
+	buf, err := readChecksummedBlock(r, layout.DataAddress, size, btreeV2HeaderSignature)
+	if err != nil {
+		return nil, err
+	}
+	if buf[4] != 0 {
+		return nil, fmt.Errorf("unsupported v2 B-tree header version: %d", buf[4])
+	}
+	recordType := buf[5]
+	nodeSize := binary.LittleEndian.Uint32(buf[6:])
+	recordSize := int(binary.LittleEndian.Uint16(buf[10:]))
+	depth := int(binary.LittleEndian.Uint16(buf[12:]))
+	offset := 16
+	rootAddr := readUint64(buf[offset:], int(sb.OffsetSize), sb.Endianness)
+	offset += int(sb.OffsetSize)
+	rootRecords := uint64(binary.LittleEndian.Uint16(buf[offset:]))
+	offset += 2
+	total := readUint64(buf[offset:], int(sb.LengthSize), sb.Endianness)
+
+	ndims := len(layout.ChunkSize) - 1
+	sizeWidth := 0
+	switch recordType {
+	case BTreeV2ChunkRecord:
+		if recordSize != int(sb.OffsetSize)+8*ndims {
+			return nil, fmt.Errorf("invalid chunk record size: %d", recordSize)
+		}
+	case BTreeV2FilteredChunkRecord:
+		sizeWidth = recordSize - int(sb.OffsetSize) - 4 - 8*ndims
+		if sizeWidth < 1 || sizeWidth > 8 {
+			return nil, fmt.Errorf("invalid filtered chunk record size: %d", recordSize)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported v2 B-tree record type for chunk index: %d", recordType)
+	}
+	if total > maxIndexElements {
+		return nil, fmt.Errorf("v2 B-tree too large: %d records", total)
+	}
+	if total == 0 || isUndefinedAddress(rootAddr, sb.OffsetSize) {
+		return nil, nil
+	}
+
+	geom, err := NewBTreeV2Geometry(nodeSize, recordSize, sb.OffsetSize, depth)
+	if err != nil {
+		return nil, err
+	}
+	walker := &btreeV2ChunkWalker{
+		r:         r,
+		geom:      geom,
+		sb:        sb,
+		layout:    layout,
+		ndims:     ndims,
+		sizeWidth: sizeWidth,
+		chunks:    make([]ChunkEntry, 0, total),
+	}
+	if err := walker.walk(rootAddr, rootRecords, depth); err != nil {
+		return nil, err
+	}
+	if uint64(len(walker.chunks)) != total {
+		return nil, fmt.Errorf("v2 B-tree holds %d records, header says %d", len(walker.chunks), total)
+	}
+	return walker.chunks, nil
+}
+
+// btreeV2ChunkWalker collects the records of a v2 B-tree chunk index in key
+// order.
+type btreeV2ChunkWalker struct {
+	r         io.ReaderAt
+	geom      *BTreeV2Geometry
+	sb        *Superblock
+	layout    *DataLayoutMessage
+	ndims     int
+	sizeWidth int // Width of the chunk size field; 0 for unfiltered records.
+	chunks    []ChunkEntry
+}
+
+// walk visits the node at addr, holding nrec records, at the given depth.
+func (w *btreeV2ChunkWalker) walk(addr, nrec uint64, depth int) error {
+	if nrec > w.geom.Levels[depth].MaxRecords {
+		return fmt.Errorf("v2 B-tree node at 0x%x has %d records, at most %d fit", addr, nrec, w.geom.Levels[depth].MaxRecords)
+	}
+	n := int(nrec) //nolint:gosec // G115: bounded by the node capacity
+	recordsSize := n * w.geom.RecordSize
+
+	if depth == 0 {
+		buf, err := readChecksummedBlock(w.r, addr, 6+recordsSize+4, btreeV2LeafSignature)
+		if err != nil {
+			return fmt.Errorf("v2 B-tree leaf: %w", err)
+		}
+		for i := 0; i < n; i++ {
+			w.addRecord(buf[6+i*w.geom.RecordSize:])
+		}
+		return nil
+	}
+
+	pointerSize := w.geom.PointerSize(depth)
+	buf, err := readChecksummedBlock(w.r, addr, 6+recordsSize+(n+1)*pointerSize+4, btreeV2InternalSignature)
+	if err != nil {
+		return fmt.Errorf("v2 B-tree internal node: %w", err)
+	}
+	pointers := buf[6+recordsSize:]
+	for i := 0; i <= n; i++ {
+		p := pointers[i*pointerSize:]
+		childAddr := readUint64(p, w.geom.OffsetSize, w.sb.Endianness)
+		childRecords := readUint64(p[w.geom.OffsetSize:], w.geom.NrecSize, binary.LittleEndian)
+		if err := w.walk(childAddr, childRecords, depth-1); err != nil {
+			return err
+		}
+		if i < n {
+			w.addRecord(buf[6+i*w.geom.RecordSize:])
+		}
+	}
+	return nil
+}
+
+// addRecord decodes one chunk record.
+//
+// Reference: H5Dbtree2.c - H5D__bt2_unfilt_decode(), H5D__bt2_filt_decode().
+func (w *btreeV2ChunkWalker) addRecord(raw []byte) {
+	offsetSize := w.geom.OffsetSize
+	entry := ChunkEntry{Address: readUint64(raw, offsetSize, w.sb.Endianness)}
+	raw = raw[offsetSize:]
+	if w.sizeWidth > 0 {
+		entry.Key.Nbytes = uint32(readUint64(raw, w.sizeWidth, binary.LittleEndian)) //nolint:gosec // G115: chunk sizes are limited to 32 bits by HDF5
+		entry.Key.FilterMask = binary.LittleEndian.Uint32(raw[w.sizeWidth:])
+		raw = raw[w.sizeWidth+4:]
+	} else {
+		entry.Key.Nbytes = uint32(chunkBytes(w.layout)) //nolint:gosec // G115: chunk sizes are limited to 32 bits by HDF5
+	}
+
+	// Scaled coordinates, plus the trailing element-size dimension.
+	entry.Key.Scaled = make([]uint64, w.ndims+1)
+	for d := 0; d < w.ndims; d++ {
+		entry.Key.Scaled[d] = binary.LittleEndian.Uint64(raw[8*d:])
+	}
+	w.chunks = append(w.chunks, entry)
+}
@@ -0,0 +1,37 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestBTreeV2Geometry checks node capacities against the values the C
+// library derives for a 1-D unfiltered chunk index with 8-byte addresses.
+func TestBTreeV2Geometry(t *testing.T) {
+	g, err := NewBTreeV2Geometry(BTreeV2ChunkNodeSize, 16, 8, 2)
+	require.NoError(t, err)
+
+	require.Equal(t, uint64(127), g.Levels[0].MaxRecords)
+	require.Equal(t, 1, g.NrecSize)
+
+	// Depth 1: pointers hold an address and a child record count.
+	require.Equal(t, 9, g.PointerSize(1))
+	require.Equal(t, uint64(81), g.Levels[1].MaxRecords)
+	require.Equal(t, uint64(82*127+81), g.Levels[1].CumMaxRecords)
+	require.Equal(t, 2, g.Levels[1].CumSize)
+
+	// Depth 2: pointers also hold the record count of the child subtree.
+	require.Equal(t, 11, g.PointerSize(2))
+}
+
+// TestChunkSizeFieldWidth checks the width of the chunk size field of
+// filtered chunk records, one byte wider than the chunk size needs.
+func TestChunkSizeFieldWidth(t *testing.T) {
+	require.Equal(t, 2, ChunkSizeFieldWidth(1))
+	require.Equal(t, 2, ChunkSizeFieldWidth(2))
+	require.Equal(t, 2, ChunkSizeFieldWidth(255))
+	require.Equal(t, 3, ChunkSizeFieldWidth(256))
+	require.Equal(t, 5, ChunkSizeFieldWidth(1<<31))
+	require.Equal(t, 8, ChunkSizeFieldWidth(1<<62))
+}
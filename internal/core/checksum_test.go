@@ -175,3 +175,20 @@ func TestJenkinsChecksumVsCRC32(t *testing.T) {
 	// This test documents that Jenkins and CRC32 produce DIFFERENT results.
 	// DO NOT use CRC32 for HDF5 metadata!
 }
+
+func TestFletcher32Checksum(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want uint32
+	}{
+		{"empty", nil, 0},
+		{"big-endian words", []byte{0x01, 0x02, 0x03, 0x04}, 0x05080406},
+		{"odd length", []byte{0x01, 0x02, 0x03}, 0x05040402},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Fletcher32Checksum(tt.data))
+		})
+	}
+}
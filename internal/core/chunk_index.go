@@ -0,0 +1,266 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// undefinedAddress is the "undefined address" value (all bits set) used by
+// HDF5 for unallocated storage and missing chunks.
+const undefinedAddress = ^uint64(0)
+
+// unlimitedDim is the maximum dimension size of an unlimited dimension
+// (H5S_UNLIMITED).
+const unlimitedDim = ^uint64(0)
+
+// isUndefinedAddress reports whether addr is the undefined address for the
+// given offset size.
+func isUndefinedAddress(addr uint64, offsetSize uint8) bool {
+	if offsetSize >= 8 {
+		return addr == undefinedAddress
+	}
+	return addr == (uint64(1)<<(8*offsetSize))-1
+}
+
+// CollectChunks returns every allocated chunk of a chunked dataset, whatever
+// index the layout message uses. Chunks that were never written are omitted.
+//
+// Each ChunkKey.Scaled holds the chunk coordinates in chunk units, followed by
+// a trailing 0 for the element-size dimension, as in version 1 B-tree keys.
+//
+// Reference: H5Dchunk.c - H5D__chunk_iterate(), H5D_chunk_ops_t implementations
+// in H5Dbtree.c, H5Dsingle.c, H5Dnone.c, H5Dfarray.c, H5Dearray.c and
+// H5Dbtree2.c.
+//
+// Parameters:
+//   - r: file reader
+//   - layout: chunked data layout message
+//   - dataspace: dataset dataspace (current and maximum dimensions)
+//   - sb: superblock (offset and length sizes)
+//
+// Returns:
+//   - []ChunkEntry: allocated chunks with address, stored size and filter mask
+//   - error: if the index is corrupt or its type is not supported
+func CollectChunks(r io.ReaderAt, layout *DataLayoutMessage, dataspace *DataspaceMessage, sb *Superblock) ([]ChunkEntry, error) {
+	if !layout.IsChunked() {
+		return nil, errors.New("layout is not chunked")
+	}
+
+	ndims := len(dataspace.Dimensions)
+	if len(layout.ChunkSize) != ndims+1 {
+		return nil, fmt.Errorf("chunk rank %d does not match dataset rank %d", len(layout.ChunkSize)-1, ndims)
+	}
+
+	// Datasets without any written chunk have no index.
+	if isUndefinedAddress(layout.DataAddress, sb.OffsetSize) {
+		return nil, nil
+	}
+
+	var (
+		chunks []ChunkEntry
+		err    error
+	)
+	switch layout.ChunkIndex {
+	case ChunkIndexBTreeV1:
+		var root *BTreeV1Node
+		root, err = ParseBTreeV1Node(r, layout.DataAddress, sb.OffsetSize, len(layout.ChunkSize), layout.ChunkSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse B-tree: %w", err)
+		}
+		return root.CollectAllChunks(r, sb.OffsetSize, layout.ChunkSize)
+	case ChunkIndexSingle:
+		chunks = singleChunk(layout)
+	case ChunkIndexImplicit:
+		chunks, err = implicitChunks(layout, dataspace)
+	case ChunkIndexFixedArray:
+		chunks, err = fixedArrayChunks(r, layout, dataspace, sb)
+	case ChunkIndexExtensibleArray:
+		chunks, err = extensibleArrayChunks(r, layout, dataspace, sb)
+	case ChunkIndexBTreeV2:
+		chunks, err = btreeV2Chunks(r, layout, sb)
+	default:
+		return nil, fmt.Errorf("unsupported chunk index type: %s", layout.ChunkIndex)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s chunk index: %w", layout.ChunkIndex, err)
+	}
+
+	chunks = dropOutOfBoundsChunks(chunks, layout.ChunkSize[:ndims], dataspace.Dimensions)
+	if layout.Flags&LayoutFlagDontFilterPartialChunks != 0 {
+		markUnfilteredEdgeChunks(chunks, layout.ChunkSize[:ndims], dataspace.Dimensions)
+	}
+	return chunks, nil
+}
+
+// dropOutOfBoundsChunks removes chunks that start beyond the current dataset
+// dimensions. Array and implicit indexes cover the maximum dimensions, so a
+// dataset that was shrunk, or allocated early, can have such chunks.
+func dropOutOfBoundsChunks(chunks []ChunkEntry, chunkDims, dims []uint64) []ChunkEntry {
+	kept := chunks[:0]
+	for _, chunk := range chunks {
+		inside := true
+		for d, dim := range dims {
+			if chunk.Key.Scaled[d]*chunkDims[d] >= dim {
+				inside = false
+				break
+			}
+		}
+		if inside {
+			kept = append(kept, chunk)
+		}
+	}
+	return kept
+}
+
+// markUnfilteredEdgeChunks sets every filter mask bit on chunks that extend
+// past the dataset edge, since those are stored without filters when the
+// layout has LayoutFlagDontFilterPartialChunks.
+//
+// Reference: H5Dchunk.c - H5D__chunk_is_partial_edge_chunk().
+func markUnfilteredEdgeChunks(chunks []ChunkEntry, chunkDims, dims []uint64) {
+	for i := range chunks {
+		for d, dim := range dims {
+			if (chunks[i].Key.Scaled[d]+1)*chunkDims[d] > dim {
+				chunks[i].Key.FilterMask = ^uint32(0)
+				break
+			}
+		}
+	}
+}
+
+// chunkBytes returns the unfiltered size of one chunk in bytes; the last
+// layout dimension is the element size.
+func chunkBytes(layout *DataLayoutMessage) uint64 {
+	size := uint64(1)
+	for _, dim := range layout.ChunkSize {
+		size *= dim
+	}
+	return size
+}
+
+// singleChunk returns the only chunk of a dataset whose chunk covers the
+// whole dataset.
+//
+// Reference: H5Dsingle.c - H5D__single_idx_get_addr().
+func singleChunk(layout *DataLayoutMessage) []ChunkEntry {
+	key := ChunkKey{
+		Scaled: make([]uint64, len(layout.ChunkSize)),
+		Nbytes: uint32(chunkBytes(layout)), //nolint:gosec // G115: chunk sizes are limited to 32 bits by HDF5
+	}
+	if layout.Flags&LayoutFlagSingleIndexWithFilter != 0 {
+		key.Nbytes = uint32(layout.SingleChunk) //nolint:gosec // G115: chunk sizes are limited to 32 bits by HDF5
+		key.FilterMask = layout.SingleMask
+	}
+	return []ChunkEntry{{Key: key, Address: layout.DataAddress}}
+}
+
+// implicitChunks returns the chunks of a dataset using the implicit index:
+// all chunks are allocated up front and stored back to back in row-major
+// chunk order, starting at the index address. Filters are not allowed.
+//
+// Reference: H5Dnone.c - H5D__none_idx_get_addr().
+func implicitChunks(layout *DataLayoutMessage, dataspace *DataspaceMessage) ([]ChunkEntry, error) {
+	grid := newChunkGrid(layout.ChunkSize, maxDimensions(dataspace), -1)
+	total := grid.count()
+	if total > maxIndexElements {
+		return nil, fmt.Errorf("too many chunks: %d", total)
+	}
+
+	size := chunkBytes(layout)
+	chunks := make([]ChunkEntry, 0, total)
+	for idx := uint64(0); idx < total; idx++ {
+		chunks = append(chunks, ChunkEntry{
+			Key: ChunkKey{
+				Scaled: grid.scaled(idx),
+				Nbytes: uint32(size), //nolint:gosec // G115: chunk sizes are limited to 32 bits by HDF5
+			},
+			Address: layout.DataAddress + idx*size,
+		})
+	}
+	return chunks, nil
+}
+
+// maxIndexElements bounds the number of entries read from an array index,
+// protecting against corrupt element counts.
+const maxIndexElements = 1 << 28
+
+// chunkGrid maps linear array-index positions to scaled chunk coordinates.
+//
+// Fixed arrays and the implicit index number chunks in row-major order over
+// the maximum chunk counts. Extensible arrays first move the unlimited
+// dimension to the front ("swizzling") so the array grows at its end.
+//
+// Reference: H5Dchunk.c - H5D__chunk_set_info_real(), H5Dearray.c -
+// H5D__earray_idx_resize(), H5VM.c - H5VM_swizzle_coords().
+type chunkGrid struct {
+	order []int    // Dataset dimension stored at each index position.
+	down  []uint64 // Chunks spanned by one step in each position.
+	max   []uint64 // Chunk count in each position (0 when unlimited).
+}
+
+// newChunkGrid builds the grid over the chunks covering bounds (dataset
+// dimensions, unlimitedDim allowed). unlimDim is the dimension moved to the
+// front, or -1 to keep the dataset order.
+func newChunkGrid(chunkDims, bounds []uint64, unlimDim int) *chunkGrid {
+	ndims := len(bounds)
+	g := &chunkGrid{
+		order: make([]int, 0, ndims),
+		down:  make([]uint64, ndims),
+		max:   make([]uint64, ndims),
+	}
+	if unlimDim > 0 {
+		g.order = append(g.order, unlimDim)
+	}
+	for d := 0; d < ndims; d++ {
+		if d != unlimDim || unlimDim <= 0 {
+			g.order = append(g.order, d)
+		}
+	}
+
+	for pos, d := range g.order {
+		if chunk := chunkDims[d]; chunk > 0 && bounds[d] != unlimitedDim {
+			g.max[pos] = (bounds[d] + chunk - 1) / chunk
+		}
+	}
+
+	down := uint64(1)
+	for pos := ndims - 1; pos >= 0; pos-- {
+		g.down[pos] = down
+		down *= g.max[pos]
+	}
+	return g
+}
+
+// maxDimensions returns the maximum dimensions of a dataspace, which equal
+// the current dimensions when none are stored.
+func maxDimensions(dataspace *DataspaceMessage) []uint64 {
+	if len(dataspace.MaxDims) == len(dataspace.Dimensions) {
+		return dataspace.MaxDims
+	}
+	return dataspace.Dimensions
+}
+
+// count returns the number of chunks in the grid. Only meaningful when no
+// dimension is unlimited.
+func (g *chunkGrid) count() uint64 {
+	total := uint64(1)
+	for _, n := range g.max {
+		total *= n
+	}
+	return total
+}
+
+// scaled converts a linear index position to scaled chunk coordinates in
+// dataset order, with a trailing 0 for the element-size dimension.
+func (g *chunkGrid) scaled(idx uint64) []uint64 {
+	coords := make([]uint64, len(g.order)+1)
+	for pos, d := range g.order {
+		if g.down[pos] == 0 {
+			continue
+		}
+		coords[d] = idx / g.down[pos]
+		idx %= g.down[pos]
+	}
+	return coords
+}
@@ -0,0 +1,101 @@
+package core
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChunkGrid_RowMajor(t *testing.T) {
+	// 4x6 dataset with 2x3 chunks, maximum 20x10: 10x4 chunks.
+	g := newChunkGrid([]uint64{2, 3, 4}, []uint64{20, 10}, -1)
+	require.Equal(t, uint64(40), g.count())
+	require.Equal(t, []uint64{0, 1, 0}, g.scaled(1))
+	require.Equal(t, []uint64{1, 0, 0}, g.scaled(4))
+	require.Equal(t, []uint64{9, 3, 0}, g.scaled(39))
+}
+
+func TestChunkGrid_Swizzled(t *testing.T) {
+	// Unlimited second dimension is moved to the front, so the chunks along
+	// the first dimension are numbered consecutively.
+	g := newChunkGrid([]uint64{2, 3, 4}, []uint64{4, unlimitedDim}, 1)
+	require.Equal(t, []uint64{0, 0, 0}, g.scaled(0))
+	require.Equal(t, []uint64{1, 0, 0}, g.scaled(1))
+	require.Equal(t, []uint64{0, 1, 0}, g.scaled(2))
+	require.Equal(t, []uint64{1, 5, 0}, g.scaled(11))
+
+	// Unlimited first dimension needs no swizzling.
+	g = newChunkGrid([]uint64{2, 3, 4}, []uint64{unlimitedDim, 6}, 0)
+	require.Equal(t, []uint64{0, 1, 0}, g.scaled(1))
+	require.Equal(t, []uint64{3, 0, 0}, g.scaled(6))
+}
+
+func TestCollectChunks_Implicit(t *testing.T) {
+	sb := &Superblock{OffsetSize: 8, LengthSize: 8, Endianness: binary.LittleEndian}
+	layout := &DataLayoutMessage{
+		Class:       LayoutChunked,
+		ChunkIndex:  ChunkIndexImplicit,
+		ChunkSize:   []uint64{2, 4},
+		DataAddress: 1000,
+	}
+	// Chunks are allocated for the maximum size; only those overlapping the
+	// current 5 elements are returned.
+	space := &DataspaceMessage{Type: DataspaceSimple, Dimensions: []uint64{5}, MaxDims: []uint64{10}}
+
+	chunks, err := CollectChunks(nil, layout, space, sb)
+	require.NoError(t, err)
+	require.Len(t, chunks, 3)
+	for i, chunk := range chunks {
+		require.Equal(t, []uint64{uint64(i), 0}, chunk.Key.Scaled)
+		require.Equal(t, uint32(8), chunk.Key.Nbytes)
+		require.Equal(t, uint64(1000+8*i), chunk.Address)
+	}
+}
+
+func TestCollectChunks_SingleWithFilter(t *testing.T) {
+	sb := &Superblock{OffsetSize: 8, LengthSize: 8, Endianness: binary.LittleEndian}
+	layout := &DataLayoutMessage{
+		Class:       LayoutChunked,
+		Flags:       LayoutFlagSingleIndexWithFilter,
+		ChunkIndex:  ChunkIndexSingle,
+		ChunkSize:   []uint64{10, 10, 8},
+		DataAddress: 2048,
+		SingleChunk: 123,
+		SingleMask:  0x2,
+	}
+	space := &DataspaceMessage{Type: DataspaceSimple, Dimensions: []uint64{10, 10}}
+
+	chunks, err := CollectChunks(nil, layout, space, sb)
+	require.NoError(t, err)
+	require.Equal(t, []ChunkEntry{{
+		Key:     ChunkKey{Scaled: []uint64{0, 0, 0}, Nbytes: 123, FilterMask: 0x2},
+		Address: 2048,
+	}}, chunks)
+}
+
+func TestCollectChunks_UndefinedAddress(t *testing.T) {
+	sb := &Superblock{OffsetSize: 8, LengthSize: 8, Endianness: binary.LittleEndian}
+	for _, index := range []ChunkIndexType{ChunkIndexBTreeV1, ChunkIndexFixedArray, ChunkIndexExtensibleArray} {
+		layout := &DataLayoutMessage{
+			Class:       LayoutChunked,
+			ChunkIndex:  index,
+			ChunkSize:   []uint64{4, 4},
+			DataAddress: undefinedAddress,
+		}
+		space := &DataspaceMessage{Type: DataspaceSimple, Dimensions: []uint64{8}}
+		chunks, err := CollectChunks(nil, layout, space, sb)
+		require.NoError(t, err, index.String())
+		require.Empty(t, chunks, index.String())
+	}
+}
+
+func TestMarkUnfilteredEdgeChunks(t *testing.T) {
+	chunks := []ChunkEntry{
+		{Key: ChunkKey{Scaled: []uint64{0, 0, 0}}},
+		{Key: ChunkKey{Scaled: []uint64{0, 1, 0}}},
+	}
+	markUnfilteredEdgeChunks(chunks, []uint64{5, 5}, []uint64{5, 8})
+	require.Zero(t, chunks[0].Key.FilterMask)
+	require.Equal(t, ^uint32(0), chunks[1].Key.FilterMask)
+}
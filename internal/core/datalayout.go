@@ -19,6 +19,51 @@ const (
 	layoutUnknown = "unknown" // String representation for unknown layout class.
 )
 
+// ChunkIndexType identifies the structure that maps chunk coordinates to
+// file addresses.
+type ChunkIndexType uint8
+
+// Chunk index types. Layout messages before version 4 always use a version 1
+// B-tree; version 4 messages (HDF5 1.10+) store the index type explicitly.
+//
+// Reference: H5Dpublic.h - H5D_chunk_index_t.
+const (
+	ChunkIndexBTreeV1         ChunkIndexType = 0 // Version 1 B-tree (layout v1-v3).
+	ChunkIndexSingle          ChunkIndexType = 1 // Single chunk covering the whole dataset.
+	ChunkIndexImplicit        ChunkIndexType = 2 // Chunks stored contiguously, no index.
+	ChunkIndexFixedArray      ChunkIndexType = 3 // Fixed array (no unlimited dimensions).
+	ChunkIndexExtensibleArray ChunkIndexType = 4 // Extensible array (one unlimited dimension).
+	ChunkIndexBTreeV2         ChunkIndexType = 5 // Version 2 B-tree (several unlimited dimensions).
+)
+
+// Layout v4 chunked flags.
+const (
+	// LayoutFlagDontFilterPartialChunks means partial edge chunks are stored unfiltered.
+	LayoutFlagDontFilterPartialChunks uint8 = 0x01
+	// LayoutFlagSingleIndexWithFilter means a single chunk index records the filtered size.
+	LayoutFlagSingleIndexWithFilter uint8 = 0x02
+)
+
+// String returns the index type name.
+func (t ChunkIndexType) String() string {
+	switch t {
+	case ChunkIndexBTreeV1:
+		return "btree-v1"
+	case ChunkIndexSingle:
+		return "single"
+	case ChunkIndexImplicit:
+		return "implicit"
+	case ChunkIndexFixedArray:
+		return "fixed-array"
+	case ChunkIndexExtensibleArray:
+		return "extensible-array"
+	case ChunkIndexBTreeV2:
+		return "btree-v2"
+	default:
+		return layoutUnknown
+	}
+}
+
 // DataLayoutMessage represents HDF5 data layout message.
 type DataLayoutMessage struct {
 	Version      uint8
@@ -28,6 +73,20 @@ type DataLayoutMessage struct {
 	CompactData  []byte   // Data itself (for compact layout).
 	ChunkSize    []uint64 // Chunk dimensions (for chunked layout) - uint64 for HDF5 2.0.0+ support.
 	ChunkKeySize uint8    // Size of chunk keys in bytes: 4 (uint32) or 8 (uint64).
+
+	// Chunked layout v4 fields. DataAddress holds the index address.
+	Flags         uint8          // LayoutFlag* bits.
+	ChunkIndex    ChunkIndexType // How chunks are located (ChunkIndexBTreeV1 for v3).
+	SingleChunk   uint64         // Filtered size of a single chunk (LayoutFlagSingleIndexWithFilter).
+	SingleMask    uint32         // Filter mask of a single filtered chunk.
+	IndexPageBits uint8          // Fixed/extensible array: log2 of elements per data block page.
+	EAMaxBits     uint8          // Extensible array: log2 of the maximum number of elements.
+	EAIndexElmts  uint8          // Extensible array: elements stored in the index block.
+	EAMinPointers uint8          // Extensible array: minimum data block pointers in a super block.
+	EAMinElmts    uint8          // Extensible array: minimum elements per data block.
+	BT2NodeSize   uint32         // Version 2 B-tree node size.
+	BT2SplitPct   uint8          // Version 2 B-tree split percent.
+	BT2MergePct   uint8          // Version 2 B-tree merge percent.
 }
 
 // ParseDataLayoutMessage parses a data layout message from header message data.
@@ -163,10 +222,94 @@ func parseLayoutV3(data []byte, sb *Superblock, msg *DataLayoutMessage) (*DataLa
 	return msg, nil
 }
 
+// parseLayoutV4 parses HDF5 Data Layout Message version 4.
+// Compact and contiguous layouts are encoded as in version 3; chunked layouts
+// add flags, variable-width chunk dimensions and an explicit chunk index type.
+//
+// Reference: H5Olayout.c - H5O__layout_decode(), H5D_CHUNKED case.
 func parseLayoutV4(data []byte, sb *Superblock, msg *DataLayoutMessage) (*DataLayoutMessage, error) {
-	// Version 4 is similar to v3 but with some differences.
-	// For now, delegate to v3 parser (they're very similar for contiguous layout).
-	return parseLayoutV3(data, sb, msg)
+	if len(data) < 2 {
+		return nil, errors.New("layout v4 message too short")
+	}
+	if DataLayoutClass(data[1]) != LayoutChunked {
+		return parseLayoutV3(data, sb, msg)
+	}
+
+	msg.Class = LayoutChunked
+	if len(data) < 5 {
+		return nil, errors.New("chunked layout v4 message too short")
+	}
+	msg.Flags = data[2]
+	dimensionality := int(data[3])
+	encSize := int(data[4])
+	if encSize < 1 || encSize > 8 {
+		return nil, fmt.Errorf("invalid chunk dimension size encoding: %d", encSize)
+	}
+	offset := 5
+
+	if offset+dimensionality*encSize > len(data) {
+		return nil, errors.New("chunked layout v4 dimensions truncated")
+	}
+	msg.ChunkSize = make([]uint64, dimensionality)
+	for i := range msg.ChunkSize {
+		msg.ChunkSize[i] = readUint64(data[offset:], encSize, binary.LittleEndian)
+		offset += encSize
+	}
+
+	if offset >= len(data) {
+		return nil, errors.New("chunked layout v4 index type missing")
+	}
+	msg.ChunkIndex = ChunkIndexType(data[offset])
+	offset++
+
+	// Index-specific creation parameters.
+	switch msg.ChunkIndex {
+	case ChunkIndexSingle:
+		if msg.Flags&LayoutFlagSingleIndexWithFilter != 0 {
+			if offset+int(sb.LengthSize)+4 > len(data) {
+				return nil, errors.New("single chunk index info truncated")
+			}
+			msg.SingleChunk = readUint64(data[offset:], int(sb.LengthSize), sb.Endianness)
+			offset += int(sb.LengthSize)
+			msg.SingleMask = binary.LittleEndian.Uint32(data[offset:])
+			offset += 4
+		}
+	case ChunkIndexImplicit:
+		// No parameters.
+	case ChunkIndexFixedArray:
+		if offset+1 > len(data) {
+			return nil, errors.New("fixed array index info truncated")
+		}
+		msg.IndexPageBits = data[offset]
+		offset++
+	case ChunkIndexExtensibleArray:
+		if offset+5 > len(data) {
+			return nil, errors.New("extensible array index info truncated")
+		}
+		msg.EAMaxBits = data[offset]
+		msg.EAIndexElmts = data[offset+1]
+		msg.EAMinPointers = data[offset+2]
+		msg.EAMinElmts = data[offset+3]
+		msg.IndexPageBits = data[offset+4]
+		offset += 5
+	case ChunkIndexBTreeV2:
+		if offset+6 > len(data) {
+			return nil, errors.New("v2 B-tree index info truncated")
+		}
+		msg.BT2NodeSize = binary.LittleEndian.Uint32(data[offset:])
+		msg.BT2SplitPct = data[offset+4]
+		msg.BT2MergePct = data[offset+5]
+		offset += 6
+	default:
+		return nil, fmt.Errorf("unsupported chunk index type: %d", msg.ChunkIndex)
+	}
+
+	if offset+int(sb.OffsetSize) > len(data) {
+		return nil, errors.New("chunked layout v4 index address truncated")
+	}
+	msg.DataAddress = readUint64(data[offset:], int(sb.OffsetSize), sb.Endianness)
+
+	return msg, nil
 }
 
 // Helper function to read variable-sized unsigned integers.
@@ -215,6 +358,9 @@ func (dl *DataLayoutMessage) String() string {
 	case LayoutContiguous:
 		return fmt.Sprintf("contiguous (address=0x%X, size=%d)", dl.DataAddress, dl.DataSize)
 	case LayoutChunked:
+		if dl.ChunkIndex != ChunkIndexBTreeV1 {
+			return fmt.Sprintf("chunked (chunks=%v, index=%s)", dl.ChunkSize, dl.ChunkIndex)
+		}
 		return fmt.Sprintf("chunked (chunks=%v)", dl.ChunkSize)
 	case LayoutVirtual:
 		return "virtual"
@@ -234,3 +234,90 @@ func TestParseDataLayoutMessage_SmallOffsets(t *testing.T) {
 	require.Equal(t, uint64(0x1234), got.DataAddress)
 	require.Equal(t, uint64(0x5678), got.DataSize)
 }
+
+// TestParseDataLayoutMessage_V4Chunked tests the chunk index fields of
+// version 4 chunked layouts, using messages taken from HDF5 1.10 files.
+func TestParseDataLayoutMessage_V4Chunked(t *testing.T) {
+	sb := &Superblock{OffsetSize: 8, LengthSize: 8, Endianness: binary.LittleEndian}
+	addr := []byte{0x45, 0x0b, 0, 0, 0, 0, 0, 0}
+
+	t.Run("fixed array", func(t *testing.T) {
+		data := append([]byte{4, 2, 0, 3, 1, 2, 3, 4, 3, 10}, addr...)
+		msg, err := ParseDataLayoutMessage(data, sb)
+		require.NoError(t, err)
+		require.Equal(t, LayoutChunked, msg.Class)
+		require.Equal(t, ChunkIndexFixedArray, msg.ChunkIndex)
+		require.Equal(t, []uint64{2, 3, 4}, msg.ChunkSize)
+		require.Equal(t, uint8(10), msg.IndexPageBits)
+		require.Equal(t, uint64(0xb45), msg.DataAddress)
+	})
+
+	t.Run("extensible array", func(t *testing.T) {
+		data := append([]byte{4, 2, 0, 3, 1, 2, 3, 4, 4, 32, 4, 4, 16, 10}, addr...)
+		msg, err := ParseDataLayoutMessage(data, sb)
+		require.NoError(t, err)
+		require.Equal(t, ChunkIndexExtensibleArray, msg.ChunkIndex)
+		require.Equal(t, uint8(32), msg.EAMaxBits)
+		require.Equal(t, uint8(4), msg.EAIndexElmts)
+		require.Equal(t, uint8(4), msg.EAMinPointers)
+		require.Equal(t, uint8(16), msg.EAMinElmts)
+		require.Equal(t, uint8(10), msg.IndexPageBits)
+		require.Equal(t, uint64(0xb45), msg.DataAddress)
+	})
+
+	t.Run("filtered single chunk", func(t *testing.T) {
+		data := []byte{4, 2, LayoutFlagSingleIndexWithFilter, 2, 2, 0x10, 0x00, 0x08, 0x00, 1}
+		data = binary.LittleEndian.AppendUint64(data, 100) // filtered size
+		data = binary.LittleEndian.AppendUint32(data, 0)   // filter mask
+		data = append(data, addr...)
+		msg, err := ParseDataLayoutMessage(data, sb)
+		require.NoError(t, err)
+		require.Equal(t, ChunkIndexSingle, msg.ChunkIndex)
+		require.Equal(t, []uint64{16, 8}, msg.ChunkSize)
+		require.Equal(t, uint64(100), msg.SingleChunk)
+		require.Equal(t, uint64(0xb45), msg.DataAddress)
+	})
+
+	t.Run("unknown index type", func(t *testing.T) {
+		data := append([]byte{4, 2, 0, 2, 1, 4, 4, 9}, addr...)
+		_, err := ParseDataLayoutMessage(data, sb)
+		require.ErrorContains(t, err, "unsupported chunk index type")
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		_, err := ParseDataLayoutMessage([]byte{4, 2, 0, 3, 1, 2, 3, 4, 3, 10, 0x45}, sb)
+		require.ErrorContains(t, err, "index address truncated")
+	})
+}
+
+// TestParseDataLayoutMessage_CompactMaxSize checks that compact data up to
+// the largest size a layout message can hold is kept whole, in version 3 and
+// 4 messages.
+func TestParseDataLayoutMessage_CompactMaxSize(t *testing.T) {
+	sb := &Superblock{
+		OffsetSize: 8,
+		LengthSize: 8,
+		Endianness: binary.LittleEndian,
+	}
+
+	for _, version := range []uint8{3, 4} {
+		testData := make([]byte, MaxCompactDataSize)
+		for i := range testData {
+			testData[i] = byte(i * 7)
+		}
+		data := make([]byte, 4+len(testData))
+		data[0] = version
+		data[1] = byte(LayoutCompact)
+		binary.LittleEndian.PutUint16(data[2:4], uint16(len(testData)))
+		copy(data[4:], testData)
+
+		got, err := ParseDataLayoutMessage(data, sb)
+		require.NoError(t, err)
+		require.True(t, got.IsCompact())
+		require.Equal(t, uint64(MaxCompactDataSize), got.DataSize)
+		require.Equal(t, testData, got.CompactData)
+
+		_, err = ParseDataLayoutMessage(data[:len(data)-1], sb)
+		require.ErrorContains(t, err, "compact layout data truncated")
+	}
+}
@@ -11,7 +11,7 @@ import (
 
 // ReadDatasetFloat64 reads a dataset and returns values as float64 array.
 // This is the main entry point for reading numerical datasets.
-func ReadDatasetFloat64(r io.ReaderAt, header *ObjectHeader, sb *Superblock) ([]float64, error) {
+func ReadDatasetFloat64(r io.ReaderAt, header *ObjectHeader, sb *Superblock, opts ...ReadOption) ([]float64, error) {
 	// 1. Extract required messages from object header.
 	var datatypeMsg, dataspaceMsg, layoutMsg, filterPipelineMsg *HeaderMessage
 
@@ -44,6 +44,9 @@ func ReadDatasetFloat64(r io.ReaderAt, header *ObjectHeader, sb *Superblock) ([]
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse datatype: %w", err)
 	}
+	if datatype.IsString() || datatype.IsVariableString() {
+		return nil, fmt.Errorf("cannot convert %s to float64: use ReadStrings for string datasets", datatype)
+	}
 
 	// 3. Parse dataspace.
 	dataspace, err := ParseDataspaceMessage(dataspaceMsg.Data)
@@ -72,12 +75,24 @@ func ReadDatasetFloat64(r io.ReaderAt, header *ObjectHeader, sb *Superblock) ([]
 		return []float64{}, nil
 	}
 
+	cfg := newReadConfig(opts)
+	if err := cfg.checkAllocation(layout, totalElements, uint64(datatype.Size)); err != nil {
+		return nil, err
+	}
+	// The result holds one float64 per element.
+	if err := cfg.checkLimit(totalElements, 8); err != nil {
+		return nil, err
+	}
+
 	// 6. Read data based on layout type.
 	var rawData []byte
 
 	switch {
 	case layout.IsCompact():
 		// Data is stored directly in the layout message.
+		if size := totalElements * uint64(datatype.Size); uint64(len(layout.CompactData)) < size {
+			return nil, fmt.Errorf("compact data truncated: %d bytes, expected %d", len(layout.CompactData), size)
+		}
 		rawData = layout.CompactData
 
 	case layout.IsContiguous():
@@ -93,7 +108,7 @@ func ReadDatasetFloat64(r io.ReaderAt, header *ObjectHeader, sb *Superblock) ([]
 
 	case layout.IsChunked():
 		// Data is stored in chunks indexed by B-tree.
-		rawData, err = readChunkedData(r, layout, dataspace, datatype, sb, filterPipeline)
+		rawData, err = readChunkedData(r, layout, dataspace, datatype, sb, filterPipeline, cfg)
 		if err != nil {
 			return nil, fmt.Errorf("failed to read chunked data: %w", err)
 		}
@@ -118,6 +133,15 @@ func ConvertToFloat64(rawData []byte, datatype *DatatypeMessage, numElements uin
 // convertToFloat64 converts raw bytes to float64 array based on datatype.
 func convertToFloat64(rawData []byte, datatype *DatatypeMessage, numElements uint64) ([]float64, error) {
 	result := make([]float64, numElements)
+	if err := convertToFloat64Into(result, rawData, datatype, numElements); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// convertToFloat64Into converts numElements raw elements into result, which
+// must hold at least numElements values.
+func convertToFloat64Into(result []float64, rawData []byte, datatype *DatatypeMessage, numElements uint64) error {
 	byteOrder := datatype.GetByteOrder()
 
 	switch {
@@ -126,7 +150,7 @@ func convertToFloat64(rawData []byte, datatype *DatatypeMessage, numElements uin
 		for i := uint64(0); i < numElements; i++ {
 			offset := i * 8
 			if offset+8 > uint64(len(rawData)) {
-				return nil, errors.New("data truncated (float64)")
+				return errors.New("data truncated (float64)")
 			}
 
 			bits := byteOrder.Uint64(rawData[offset : offset+8])
@@ -138,7 +162,7 @@ func convertToFloat64(rawData []byte, datatype *DatatypeMessage, numElements uin
 		for i := uint64(0); i < numElements; i++ {
 			offset := i * 4
 			if offset+4 > uint64(len(rawData)) {
-				return nil, errors.New("data truncated (float32)")
+				return errors.New("data truncated (float32)")
 			}
 
 			bits := byteOrder.Uint32(rawData[offset : offset+4])
@@ -155,7 +179,7 @@ func convertToFloat64(rawData []byte, datatype *DatatypeMessage, numElements uin
 		switch datatype.Size {
 		case 1:
 			if numElements > uint64(len(rawData)) {
-				return nil, errors.New("data truncated (1-byte int)")
+				return errors.New("data truncated (1-byte int)")
 			}
 			if signed {
 				for i := uint64(0); i < numElements; i++ {
@@ -169,7 +193,7 @@ func convertToFloat64(rawData []byte, datatype *DatatypeMessage, numElements uin
 			}
 		case 2:
 			if numElements*2 > uint64(len(rawData)) {
-				return nil, errors.New("data truncated (2-byte int)")
+				return errors.New("data truncated (2-byte int)")
 			}
 			if signed {
 				for i := uint64(0); i < numElements; i++ {
@@ -183,7 +207,7 @@ func convertToFloat64(rawData []byte, datatype *DatatypeMessage, numElements uin
 			}
 		case 4:
 			if numElements*4 > uint64(len(rawData)) {
-				return nil, errors.New("data truncated (4-byte int)")
+				return errors.New("data truncated (4-byte int)")
 			}
 			if signed {
 				for i := uint64(0); i < numElements; i++ {
@@ -197,7 +221,7 @@ func convertToFloat64(rawData []byte, datatype *DatatypeMessage, numElements uin
 			}
 		case 8:
 			if numElements*8 > uint64(len(rawData)) {
-				return nil, errors.New("data truncated (8-byte int)")
+				return errors.New("data truncated (8-byte int)")
 			}
 			if signed {
 				for i := uint64(0); i < numElements; i++ {
@@ -214,14 +238,14 @@ func convertToFloat64(rawData []byte, datatype *DatatypeMessage, numElements uin
 				}
 			}
 		default:
-			return nil, fmt.Errorf("unsupported fixed-point width %d bytes", datatype.Size)
+			return fmt.Errorf("unsupported fixed-point width %d bytes (read wide integers with Dataset.ReadBigInts or ReadRaw)", datatype.Size)
 		}
 
 	default:
-		return nil, fmt.Errorf("unsupported datatype for conversion to float64: %s", datatype)
+		return fmt.Errorf("unsupported datatype for conversion to float64: %s", datatype)
 	}
 
-	return result, nil
+	return nil
 }
 
 // ReadDatasetInfo returns dataset metadata without reading actual data.
@@ -282,17 +306,86 @@ func (di *DatasetInfo) String() string {
 	)
 }
 
-// readChunkedData reads data from chunked layout.
-func readChunkedData(r io.ReaderAt, layout *DataLayoutMessage, dataspace *DataspaceMessage, datatype *DatatypeMessage, sb *Superblock, filterPipeline *FilterPipelineMessage) ([]byte, error) {
-	// Parse B-tree to get chunk index.
-	// Note: chunk dimensions may include an extra dimension for datatype size.
-	// (HDF5 stores "fastest-varying dimension" as bytes, see H5Dbtree.c comments).
-	ndims := len(layout.ChunkSize)
-	btree, err := ParseBTreeV1Node(r, layout.DataAddress, sb.OffsetSize, ndims, layout.ChunkSize)
+// ReadOption configures how dataset values are read.
+type ReadOption func(*readConfig)
+
+// readConfig holds the settings applied by ReadOption values.
+type readConfig struct {
+	skipChecksums bool
+	maxAllocation uint64 // Largest buffer a read may allocate, in bytes (0 = no limit).
+	fileSize      uint64 // Size of the file, for bounds checks (0 = unknown).
+}
+
+// WithoutChecksumVerification strips checksums (Fletcher32) from chunks without
+// verifying them. Use it to salvage data from files with known corruption.
+func WithoutChecksumVerification() ReadOption {
+	return func(cfg *readConfig) {
+		cfg.skipChecksums = true
+	}
+}
+
+// WithMaxAllocation limits the size of the buffers a read may allocate. A
+// dataset whose data (or converted result) would need more than maxBytes is
+// rejected with an error instead of being read. Zero means no limit.
+func WithMaxAllocation(maxBytes uint64) ReadOption {
+	return func(cfg *readConfig) {
+		cfg.maxAllocation = maxBytes
+	}
+}
+
+// WithFileSize tells the reader the size of the file so that contiguous data
+// extending beyond the end of the file is rejected before any allocation.
+func WithFileSize(size uint64) ReadOption {
+	return func(cfg *readConfig) {
+		cfg.fileSize = size
+	}
+}
+
+// checkLimit rejects numElements elements of elemSize bytes each when they
+// would exceed the allocation limit.
+func (cfg readConfig) checkLimit(numElements, elemSize uint64) error {
+	size, err := utils.SafeMultiply(numElements, elemSize)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse B-tree: %w", err)
+		return fmt.Errorf("dataset size overflow: %w", err)
+	}
+	if cfg.maxAllocation > 0 && size > cfg.maxAllocation {
+		return fmt.Errorf("dataset needs %d bytes, exceeding the allocation limit of %d bytes",
+			size, cfg.maxAllocation)
+	}
+	return nil
+}
+
+// checkAllocation validates the raw data size of a dataset before it is
+// allocated: it must fit the allocation limit and, for contiguous storage,
+// lie within the file. A malformed dataspace claiming far more elements than
+// the file can hold is caught here instead of failing in make().
+func (cfg readConfig) checkAllocation(layout *DataLayoutMessage, numElements, elemSize uint64) error {
+	if err := cfg.checkLimit(numElements, elemSize); err != nil {
+		return err
+	}
+	if cfg.fileSize == 0 || !layout.IsContiguous() || layout.DataAddress == undefinedAddress {
+		return nil
+	}
+	size := numElements * elemSize // Overflow already ruled out by checkLimit.
+	if layout.DataAddress > cfg.fileSize || size > cfg.fileSize-layout.DataAddress {
+		return fmt.Errorf("contiguous data of %d bytes at 0x%x extends beyond the end of the file (%d bytes)",
+			size, layout.DataAddress, cfg.fileSize)
+	}
+	return nil
+}
+
+// newReadConfig applies opts to the default configuration (checksums verified).
+func newReadConfig(opts []ReadOption) readConfig {
+	var cfg readConfig
+	for _, opt := range opts {
+		opt(&cfg)
 	}
+	return cfg
+}
 
+// readChunkedData reads data from chunked layout.
+// A checksum mismatch is returned as *ChecksumError carrying the chunk's scaled coordinates.
+func readChunkedData(r io.ReaderAt, layout *DataLayoutMessage, dataspace *DataspaceMessage, datatype *DatatypeMessage, sb *Superblock, filterPipeline *FilterPipelineMessage, cfg readConfig) ([]byte, error) {
 	// Calculate total data size.
 	totalElements := dataspace.TotalElements()
 	elementSize := uint64(datatype.Size)
@@ -310,11 +403,27 @@ func readChunkedData(r io.ReaderAt, layout *DataLayoutMessage, dataspace *Datasp
 
 	// Allocate output buffer.
 	rawData := make([]byte, totalBytes)
+	if err := readChunkedDataInto(r, layout, dataspace, datatype, sb, filterPipeline, cfg, rawData); err != nil {
+		return nil, err
+	}
+	return rawData, nil
+}
 
-	// Collect all chunks from B-tree (handles both leaf and non-leaf nodes).
-	chunks, err := btree.CollectAllChunks(r, sb.OffsetSize, layout.ChunkSize)
+// readChunkedDataInto reads a chunked dataset into rawData, which must hold
+// the whole dataset. Chunks that were never written are left zero-filled.
+func readChunkedDataInto(r io.ReaderAt, layout *DataLayoutMessage, dataspace *DataspaceMessage, datatype *DatatypeMessage, sb *Superblock, filterPipeline *FilterPipelineMessage, cfg readConfig, rawData []byte) error {
+	if filterPipeline != nil {
+		filterPipeline.SkipChecksums = cfg.skipChecksums
+	}
+	elementSize := uint64(datatype.Size)
+	clear(rawData)
+
+	// Collect all chunks from the chunk index (B-tree, fixed or extensible array, ...).
+	// Note: chunk dimensions include an extra dimension for datatype size.
+	// (HDF5 stores "fastest-varying dimension" as bytes, see H5Dbtree.c comments).
+	chunks, err := CollectChunks(r, layout, dataspace, sb)
 	if err != nil {
-		return nil, fmt.Errorf("failed to collect chunks: %w", err)
+		return fmt.Errorf("failed to collect chunks: %w", err)
 	}
 
 	// Read each chunk and copy to correct position.
@@ -324,7 +433,7 @@ func readChunkedData(r io.ReaderAt, layout *DataLayoutMessage, dataspace *Datasp
 
 		// CVE-2025-7067 fix: Validate chunk size before allocation to prevent buffer overflow.
 		if err := utils.ValidateBufferSize(uint64(chunkKey.Nbytes), utils.MaxChunkSize, "chunk data"); err != nil {
-			return nil, fmt.Errorf("invalid chunk size at 0x%x: %w", chunkAddr, err)
+			return fmt.Errorf("invalid chunk size at 0x%x: %w", chunkAddr, err)
 		}
 
 		// Read chunk data.
@@ -332,14 +441,20 @@ func readChunkedData(r io.ReaderAt, layout *DataLayoutMessage, dataspace *Datasp
 		//nolint:gosec // G115: HDF5 addresses fit in int64 for io.ReaderAt interface
 		_, err := r.ReadAt(chunkData, int64(chunkAddr))
 		if err != nil {
-			return nil, fmt.Errorf("failed to read chunk at 0x%x: %w", chunkAddr, err)
+			return fmt.Errorf("failed to read chunk at 0x%x: %w", chunkAddr, err)
 		}
 
 		// Apply filters (decompression, etc) if present.
 		if filterPipeline != nil {
-			chunkData, err = filterPipeline.ApplyFilters(chunkData)
+			chunkData, err = filterPipeline.ApplyFiltersMasked(chunkData, chunkKey.FilterMask)
 			if err != nil {
-				return nil, fmt.Errorf("failed to apply filters to chunk at 0x%x: %w", chunkAddr, err)
+				var csErr *ChecksumError
+				if errors.As(err, &csErr) {
+					csErr.Chunk = append([]uint64(nil), chunkKey.Scaled[:len(dataspace.Dimensions)]...)
+					csErr.Address = chunkAddr
+					return csErr
+				}
+				return fmt.Errorf("failed to apply filters to chunk at 0x%x: %w", chunkAddr, err)
 			}
 		}
 
@@ -355,11 +470,11 @@ func readChunkedData(r io.ReaderAt, layout *DataLayoutMessage, dataspace *Datasp
 
 		err = copyChunkToArray(chunkData, rawData, actualChunkCoords, actualChunkDims, dataDims, elementSize)
 		if err != nil {
-			return nil, fmt.Errorf("failed to copy chunk %v: %w", actualChunkCoords, err)
+			return fmt.Errorf("failed to copy chunk %v: %w", actualChunkCoords, err)
 		}
 	}
 
-	return rawData, nil
+	return nil
 }
 
 // copyChunkToArray copies chunk data to the correct position in full array.
@@ -13,7 +13,7 @@ import (
 type CompoundValue map[string]interface{}
 
 // ReadDatasetCompound reads a dataset with compound datatype and returns array of compound values.
-func ReadDatasetCompound(r io.ReaderAt, header *ObjectHeader, sb *Superblock) ([]CompoundValue, error) {
+func ReadDatasetCompound(r io.ReaderAt, header *ObjectHeader, sb *Superblock, opts ...ReadOption) ([]CompoundValue, error) {
 	// 1. Extract required messages.
 	var datatypeMsg, dataspaceMsg, layoutMsg, filterPipelineMsg *HeaderMessage
 
@@ -84,6 +84,11 @@ func ReadDatasetCompound(r io.ReaderAt, header *ObjectHeader, sb *Superblock) ([
 		return []CompoundValue{}, nil
 	}
 
+	cfg := newReadConfig(opts)
+	if err := cfg.checkAllocation(layout, totalElements, uint64(datatype.Size)); err != nil {
+		return nil, err
+	}
+
 	// 7. Read raw data based on layout.
 	var rawData []byte
 
@@ -101,7 +106,7 @@ func ReadDatasetCompound(r io.ReaderAt, header *ObjectHeader, sb *Superblock) ([
 		}
 
 	case layout.IsChunked():
-		rawData, err = readChunkedData(r, layout, dataspace, datatype, sb, filterPipeline)
+		rawData, err = readChunkedData(r, layout, dataspace, datatype, sb, filterPipeline, cfg)
 		if err != nil {
 			return nil, fmt.Errorf("failed to read chunked data: %w", err)
 		}
@@ -166,6 +171,9 @@ func parseMemberValue(data []byte, datatype *DatatypeMessage, r io.ReaderAt, sb
 		bits := byteOrder.Uint32(data[0:4])
 		return math.Float32frombits(bits), nil
 
+	case datatype.IsFixedPoint() && datatype.Size < 4:
+		return parseSmallIntMember(data, datatype)
+
 	case datatype.IsInt32():
 		if len(data) < 4 {
 			return nil, errors.New("insufficient data for int32")
@@ -192,7 +200,7 @@ func parseMemberValue(data []byte, datatype *DatatypeMessage, r io.ReaderAt, sb
 			return nil, errors.New("insufficient data for string")
 		}
 		// Extract string based on padding type.
-		str := extractString(data[0:datatype.Size], datatype.GetStringPadding())
+		str := applyCharset(extractString(data[0:datatype.Size], datatype.GetStringPadding()), datatype.GetStringCharset())
 		return str, nil
 
 	case datatype.IsVariableString():
@@ -212,11 +220,72 @@ func parseMemberValue(data []byte, datatype *DatatypeMessage, r io.ReaderAt, sb
 		}
 		return values[0], nil
 
+	case datatype.Class == DatatypeArray:
+		return parseArrayMember(data, datatype, r, sb)
+
+	case datatype.Class == DatatypeVarLen:
+		// Variable-length sequences need per-element global heap reads and a
+		// result type for each base type; not supported inside compounds yet.
+		return nil, errors.New("variable-length sequence members are not supported in compound types")
+
 	default:
 		return nil, fmt.Errorf("unsupported member datatype: %s", datatype)
 	}
 }
 
+// parseArrayMember decodes a fixed-size array member into a slice of its
+// elements, flattened in row-major order. Each element is decoded like a
+// scalar member, so arrays of compounds yield CompoundValue elements.
+func parseArrayMember(data []byte, datatype *DatatypeMessage, r io.ReaderAt, sb *Superblock) ([]interface{}, error) {
+	arr, err := ParseArrayType(datatype)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse array member: %w", err)
+	}
+	if uint64(len(data)) < uint64(datatype.Size) {
+		return nil, errors.New("insufficient data for array")
+	}
+
+	n := arr.Len()
+	elemSize := uint64(arr.Base.Size)
+	values := make([]interface{}, n)
+	for i := uint64(0); i < n; i++ {
+		v, err := parseMemberValue(data[i*elemSize:(i+1)*elemSize], arr.Base, r, sb)
+		if err != nil {
+			return nil, fmt.Errorf("array element %d: %w", i, err)
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// parseSmallIntMember decodes 8- and 16-bit integers into the Go type
+// matching their size and signedness.
+func parseSmallIntMember(data []byte, datatype *DatatypeMessage) (interface{}, error) {
+	if uint32(len(data)) < datatype.Size { //nolint:gosec // G115: Safe length comparison
+		return nil, fmt.Errorf("insufficient data for %d-byte integer", datatype.Size)
+	}
+
+	byteOrder := datatype.GetByteOrder()
+	signed := datatype.IsSignedFixedPoint()
+
+	//nolint:gosec // G115: HDF5 binary format requires unsigned to signed conversion
+	switch datatype.Size {
+	case 1:
+		if signed {
+			return int8(data[0]), nil
+		}
+		return data[0], nil
+	case 2:
+		v := byteOrder.Uint16(data[0:2])
+		if signed {
+			return int16(v), nil
+		}
+		return v, nil
+	default:
+		return nil, fmt.Errorf("unsupported integer size: %d", datatype.Size)
+	}
+}
+
 // extractString extracts a string from fixed-length byte array based on padding type.
 func extractString(data []byte, paddingType uint8) string {
 	switch paddingType {
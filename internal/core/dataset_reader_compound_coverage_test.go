@@ -298,6 +298,19 @@ func TestParseMemberValue_UnsupportedType(t *testing.T) {
 	require.Contains(t, err.Error(), "unsupported member datatype")
 }
 
+// TestParseMemberValue_VLenSequence tests that variable-length sequence
+// members are rejected instead of misread.
+func TestParseMemberValue_VLenSequence(t *testing.T) {
+	dt := &DatatypeMessage{
+		Class:         DatatypeVarLen,
+		Size:          16,
+		ClassBitField: 0x00, // type=0 (sequence)
+	}
+
+	_, err := parseMemberValue(make([]byte, 16), dt, nil, nil)
+	require.ErrorContains(t, err, "variable-length sequence members are not supported")
+}
+
 // TestParseMemberValue_VariableString_NullRef tests variable-string with zero heap address.
 func TestParseMemberValue_VariableString_NullRef(t *testing.T) {
 	dt := &DatatypeMessage{
@@ -143,6 +143,7 @@ func TestReadChunkedData_Synthetic(t *testing.T) {
 		bytes.NewReader(buf),
 		layout, dataspace, datatype, sb,
 		nil, // No filter pipeline
+		readConfig{},
 	)
 	require.NoError(t, err)
 	require.Len(t, rawData, 64, "expected 8 float64 = 64 bytes")
@@ -183,7 +184,7 @@ func TestReadChunkedData_ErrorMissingBTree(t *testing.T) {
 	// Small buffer that cannot contain valid B-tree.
 	_, err := readChunkedData(
 		bytes.NewReader(make([]byte, 100)),
-		layout, dataspace, datatype, sb, nil,
+		layout, dataspace, datatype, sb, nil, readConfig{},
 	)
 	require.Error(t, err)
 }
@@ -465,6 +466,60 @@ func TestReadDatasetStrings_NullPadded(t *testing.T) {
 	require.Equal(t, "cd", data[1])
 }
 
+// TestReadDatasetStrings_Charset tests that string bytes are decoded according
+// to the character set bits of the datatype.
+func TestReadDatasetStrings_Charset(t *testing.T) {
+	tests := []struct {
+		name    string
+		padding uint8
+		charset uint8
+		data    []byte
+		want    []string
+	}{
+		{
+			name:    "UTF-8 space-padded",
+			padding: StringPadSpacePad,
+			charset: CharsetUTF8,
+			data:    []byte("h\xc3\xa9  \xce\xb1\xce\xb2 "),
+			want:    []string{"hé", "αβ"},
+		},
+		{
+			name:    "UTF-8 truncated mid-character",
+			padding: StringPadNullPad,
+			charset: CharsetUTF8,
+			data:    []byte("abc\xe2\x82xyz\x00\x00"), // "abc€" cut to 5 bytes
+			want:    []string{"abc", "xyz"},
+		},
+		{
+			name:    "ASCII label with UTF-8 bytes",
+			padding: StringPadNullTerm,
+			charset: CharsetASCII,
+			data:    []byte("caf\xc3\xa9plain"),
+			want:    []string{"café", "plain"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dtMsg := buildCoverageFixedStringDatatypeMsg(5, tt.padding)
+			dtMsg[1] |= tt.charset << 4
+
+			header := &ObjectHeader{
+				Messages: []*HeaderMessage{
+					{Type: MsgDatatype, Data: dtMsg},
+					{Type: MsgDataspace, Data: buildCoverageSimpleDataspaceMsg([]uint64{2})},
+					{Type: MsgDataLayout, Data: buildCoverageCompactLayoutMsg(tt.data)},
+				},
+			}
+			sb := &Superblock{OffsetSize: 8, LengthSize: 8, Endianness: binary.LittleEndian}
+
+			data, err := ReadDatasetStrings(bytes.NewReader(nil), header, sb)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, data)
+		})
+	}
+}
+
 // ---------------------------------------------------------------------------
 // CollectAllChunks coverage
 // ---------------------------------------------------------------------------
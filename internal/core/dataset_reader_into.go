@@ -0,0 +1,113 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ReadDatasetRawInto reads the stored element bytes of a dataset into dst,
+// which must hold at least TotalElements * datatype size bytes. No
+// conversion is applied: the bytes are in the file's datatype byte order.
+// Contiguous storage that was never allocated and missing chunks read as
+// zeros.
+//
+// Returns the number of elements written and the dataset's datatype.
+func ReadDatasetRawInto(r io.ReaderAt, header *ObjectHeader, sb *Superblock, dst []byte, opts ...ReadOption) (uint64, *DatatypeMessage, error) {
+	var datatypeMsg, dataspaceMsg, layoutMsg, filterPipelineMsg *HeaderMessage
+	for _, msg := range header.Messages {
+		switch msg.Type {
+		case MsgDatatype:
+			datatypeMsg = msg
+		case MsgDataspace:
+			dataspaceMsg = msg
+		case MsgDataLayout:
+			layoutMsg = msg
+		case MsgFilterPipeline:
+			filterPipelineMsg = msg
+		}
+	}
+	if datatypeMsg == nil {
+		return 0, nil, errors.New("datatype message not found")
+	}
+	if dataspaceMsg == nil {
+		return 0, nil, errors.New("dataspace message not found")
+	}
+	if layoutMsg == nil {
+		return 0, nil, errors.New("data layout message not found")
+	}
+
+	datatype, err := ParseDatatypeMessage(datatypeMsg.Data)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to parse datatype: %w", err)
+	}
+	dataspace, err := ParseDataspaceMessage(dataspaceMsg.Data)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to parse dataspace: %w", err)
+	}
+	layout, err := ParseDataLayoutMessage(layoutMsg.Data, sb)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to parse layout: %w", err)
+	}
+	var filterPipeline *FilterPipelineMessage
+	if filterPipelineMsg != nil {
+		filterPipeline, err = ParseFilterPipelineMessage(filterPipelineMsg.Data)
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed to parse filter pipeline: %w", err)
+		}
+	}
+
+	totalElements := dataspace.TotalElements()
+	cfg := newReadConfig(opts)
+	if err := cfg.checkAllocation(layout, totalElements, uint64(datatype.Size)); err != nil {
+		return 0, nil, err
+	}
+	size := totalElements * uint64(datatype.Size)
+	if uint64(len(dst)) < size {
+		return 0, nil, fmt.Errorf("buffer too small: need %d bytes (%d elements), have %d",
+			size, totalElements, len(dst))
+	}
+	if totalElements == 0 {
+		return 0, datatype, nil
+	}
+	dst = dst[:size]
+
+	switch {
+	case layout.IsCompact():
+		if uint64(len(layout.CompactData)) < size {
+			return 0, nil, fmt.Errorf("compact data truncated: %d bytes, expected %d", len(layout.CompactData), size)
+		}
+		copy(dst, layout.CompactData)
+
+	case layout.IsContiguous():
+		if layout.DataAddress == undefinedAddress {
+			clear(dst)
+			break
+		}
+		//nolint:gosec // G115: HDF5 addresses fit in int64 for io.ReaderAt interface
+		if _, err := r.ReadAt(dst, int64(layout.DataAddress)); err != nil {
+			return 0, nil, fmt.Errorf("failed to read contiguous data: %w", err)
+		}
+
+	case layout.IsChunked():
+		err := readChunkedDataInto(r, layout, dataspace, datatype, sb, filterPipeline, cfg, dst)
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed to read chunked data: %w", err)
+		}
+
+	default:
+		return 0, nil, fmt.Errorf("unsupported layout class: %d", layout.Class)
+	}
+
+	return totalElements, datatype, nil
+}
+
+// ConvertToFloat64Into converts numElements raw elements into dst, which
+// must hold at least numElements values. It supports the same datatypes as
+// ConvertToFloat64 without allocating the result slice.
+func ConvertToFloat64Into(dst []float64, rawData []byte, datatype *DatatypeMessage, numElements uint64) error {
+	if uint64(len(dst)) < numElements {
+		return fmt.Errorf("buffer too small: need %d elements, have %d", numElements, len(dst))
+	}
+	return convertToFloat64Into(dst, rawData, datatype, numElements)
+}
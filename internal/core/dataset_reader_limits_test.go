@@ -0,0 +1,52 @@
+package core
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// contiguousHeader builds an in-memory object header for a 1-D float64
+// dataset of n elements stored contiguously at address.
+func contiguousHeader(t *testing.T, sb *Superblock, n, address uint64) *ObjectHeader {
+	t.Helper()
+
+	dtype, err := EncodeDatatypeMessage(&DatatypeMessage{Class: DatatypeFloat, Version: 1, Size: 8, ClassBitField: 0x20, Properties: []byte{0, 0, 64, 0, 52, 11, 63, 255, 3, 0, 0}})
+	require.NoError(t, err)
+	dspace, err := EncodeDataspaceMessage([]uint64{n}, nil)
+	require.NoError(t, err)
+	layout, err := EncodeLayoutMessage(LayoutContiguous, n*8, address, sb, nil, 8)
+	require.NoError(t, err)
+
+	return &ObjectHeader{Messages: []*HeaderMessage{
+		{Type: MsgDatatype, Data: dtype},
+		{Type: MsgDataspace, Data: dspace},
+		{Type: MsgDataLayout, Data: layout},
+	}}
+}
+
+func TestReadDatasetFloat64_AllocationChecks(t *testing.T) {
+	sb := &Superblock{OffsetSize: 8, LengthSize: 8, Endianness: binary.LittleEndian}
+	file := bytes.NewReader(make([]byte, 4096))
+
+	// A dataspace claiming 2^50 elements in a 4 KiB file.
+	huge := contiguousHeader(t, sb, 1<<50, 1024)
+	_, err := ReadDatasetFloat64(file, huge, sb, WithFileSize(4096))
+	require.ErrorContains(t, err, "beyond the end of the file")
+
+	_, err = ReadDatasetFloat64(file, contiguousHeader(t, sb, 1<<62, 1024), sb)
+	require.ErrorContains(t, err, "overflow")
+
+	small := contiguousHeader(t, sb, 100, 1024)
+	got, err := ReadDatasetFloat64(file, small, sb, WithFileSize(4096))
+	require.NoError(t, err)
+	require.Len(t, got, 100)
+
+	_, err = ReadDatasetFloat64(file, small, sb, WithFileSize(4096), WithMaxAllocation(799))
+	require.ErrorContains(t, err, "allocation limit")
+
+	_, _, err = ReadDatasetRawInto(file, huge, sb, nil, WithFileSize(4096))
+	require.ErrorContains(t, err, "beyond the end of the file")
+}
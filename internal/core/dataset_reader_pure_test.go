@@ -357,6 +357,12 @@ func TestDecodeFixedString(t *testing.T) {
 			paddingType: 2,
 			want:        "bar",
 		},
+		{
+			name:        "space-padded with null terminator",
+			data:        []byte{'b', 'a', 'r', ' ', ' ', 0},
+			paddingType: 2,
+			want:        "bar",
+		},
 		{
 			name:        "unknown padding",
 			data:        []byte{'t', 'e', 's', 't', 0, ' '},
@@ -5,13 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"unicode/utf8"
 
 	"github.com/scigolib/hdf5/internal/utils"
 )
 
 // ReadDatasetStrings reads a string dataset and returns values as string array.
 // Supports both fixed-length and variable-length strings.
-func ReadDatasetStrings(r io.ReaderAt, header *ObjectHeader, sb *Superblock) ([]string, error) {
+func ReadDatasetStrings(r io.ReaderAt, header *ObjectHeader, sb *Superblock, opts ...ReadOption) ([]string, error) {
 	// 1. Extract required messages from object header.
 	var datatypeMsg, dataspaceMsg, layoutMsg *HeaderMessage
 
@@ -43,6 +44,11 @@ func ReadDatasetStrings(r io.ReaderAt, header *ObjectHeader, sb *Superblock) ([]
 		return nil, fmt.Errorf("failed to parse datatype: %w", err)
 	}
 
+	// Variable-length strings live in the global heap; the dataset holds heap IDs.
+	if datatype.IsVariableString() {
+		return readVariableStrings(r, header, sb, datatype, opts)
+	}
+
 	// Verify it's a string type.
 	if !datatype.IsString() {
 		return nil, fmt.Errorf("datatype is not string: %s", datatype)
@@ -66,6 +72,11 @@ func ReadDatasetStrings(r io.ReaderAt, header *ObjectHeader, sb *Superblock) ([]
 		return []string{}, nil
 	}
 
+	cfg := newReadConfig(opts)
+	if err := cfg.checkAllocation(layout, totalElements, uint64(datatype.Size)); err != nil {
+		return nil, err
+	}
+
 	// 6. Read data based on layout type.
 	var rawData []byte
 
@@ -99,7 +110,7 @@ func ReadDatasetStrings(r io.ReaderAt, header *ObjectHeader, sb *Superblock) ([]
 			}
 		}
 
-		rawData, err = readChunkedData(r, layout, dataspace, datatype, sb, filterPipeline)
+		rawData, err = readChunkedData(r, layout, dataspace, datatype, sb, filterPipeline, cfg)
 		if err != nil {
 			return nil, fmt.Errorf("failed to read chunked data: %w", err)
 		}
@@ -112,6 +123,24 @@ func ReadDatasetStrings(r io.ReaderAt, header *ObjectHeader, sb *Superblock) ([]
 	return convertToStrings(rawData, datatype, totalElements)
 }
 
+// readVariableStrings reads a variable-length string dataset. The strings are
+// fetched from the global heap and decoded according to the padding type and
+// character set of the datatype.
+func readVariableStrings(r io.ReaderAt, header *ObjectHeader, sb *Superblock, datatype *DatatypeMessage, opts []ReadOption) ([]string, error) {
+	values, err := ReadDatasetVLenBytes(r, header, sb, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	paddingType := datatype.GetStringPadding()
+	charset := datatype.GetStringCharset()
+	result := make([]string, len(values))
+	for i, v := range values {
+		result[i] = decodeString(v, paddingType, charset)
+	}
+	return result, nil
+}
+
 // convertToStrings converts raw bytes to string array based on string datatype.
 func convertToStrings(rawData []byte, datatype *DatatypeMessage, numElements uint64) ([]string, error) {
 	result := make([]string, numElements)
@@ -121,6 +150,7 @@ func convertToStrings(rawData []byte, datatype *DatatypeMessage, numElements uin
 		// Fixed-length strings.
 		stringSize := uint64(datatype.Size)
 		paddingType := datatype.GetStringPadding()
+		charset := datatype.GetStringCharset()
 
 		// CVE-2025-2926 fix: Validate string size before processing.
 		if err := utils.ValidateBufferSize(stringSize, utils.MaxStringSize, "string element"); err != nil {
@@ -134,7 +164,7 @@ func convertToStrings(rawData []byte, datatype *DatatypeMessage, numElements uin
 			}
 
 			stringBytes := rawData[offset : offset+stringSize]
-			result[i] = decodeFixedString(stringBytes, paddingType)
+			result[i] = decodeString(stringBytes, paddingType, charset)
 		}
 	} else if datatype.IsVariableString() {
 		// Variable-length strings.
@@ -170,8 +200,9 @@ func decodeFixedString(data []byte, paddingType uint8) string {
 		return string(data)
 
 	case 2: // Space-padded.
-		// Trim trailing spaces.
-		data = bytes.TrimRight(data, " ")
+		// Trim trailing spaces. Some writers (MATLAB among them) also
+		// null-terminate space-padded strings, so trailing nulls go too.
+		data = bytes.TrimRight(data, " \x00")
 		return string(data)
 
 	default:
@@ -179,3 +210,44 @@ func decodeFixedString(data []byte, paddingType uint8) string {
 		return string(data)
 	}
 }
+
+// decodeString decodes a stored string: padding is removed as for
+// decodeFixedString, then the bytes are interpreted in the given character set.
+func decodeString(data []byte, paddingType, charset uint8) string {
+	return applyCharset(decodeFixedString(data, paddingType), charset)
+}
+
+// applyCharset makes s valid UTF-8 according to the character set it was stored in.
+//
+// ASCII strings are returned unchanged. h5py and other writers label
+// fixed-length strings ASCII by default even when they hold UTF-8 text, so
+// bytes above 0x7F are kept as stored rather than reinterpreted.
+//
+// UTF-8 strings are returned unchanged unless a multi-byte character was cut
+// off by the fixed string width, in which case the incomplete character is
+// dropped.
+func applyCharset(s string, charset uint8) string {
+	switch charset {
+	case CharsetASCII:
+		return s
+
+	case CharsetUTF8:
+		if utf8.ValidString(s) {
+			return s
+		}
+		// Drop an incomplete character at the end (at most utf8.UTFMax-1 bytes).
+		for n := 1; n < utf8.UTFMax && n <= len(s); n++ {
+			tail := s[len(s)-n:]
+			if utf8.RuneStart(tail[0]) {
+				if !utf8.FullRuneInString(tail) {
+					return s[:len(s)-n]
+				}
+				break
+			}
+		}
+		return s
+
+	default:
+		return s
+	}
+}
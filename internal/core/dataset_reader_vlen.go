@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strings"
 )
 
 // ReadDatasetVLenBytes reads a variable-length dataset and returns values as [][]byte.
@@ -14,7 +15,7 @@ import (
 // returned bytes are the raw UTF-8/ASCII characters without a null terminator.
 //
 // C Reference: H5Tvlen.c, H5HG.c (global heap object retrieval).
-func ReadDatasetVLenBytes(r io.ReaderAt, header *ObjectHeader, sb *Superblock) ([][]byte, error) {
+func ReadDatasetVLenBytes(r io.ReaderAt, header *ObjectHeader, sb *Superblock, opts ...ReadOption) ([][]byte, error) {
 	// 1. Extract required messages from object header.
 	var datatypeMsg, dataspaceMsg, layoutMsg *HeaderMessage
 
@@ -69,6 +70,11 @@ func ReadDatasetVLenBytes(r io.ReaderAt, header *ObjectHeader, sb *Superblock) (
 		return [][]byte{}, nil
 	}
 
+	cfg := newReadConfig(opts)
+	if err := cfg.checkAllocation(layout, totalElements, uint64(datatype.Size)); err != nil {
+		return nil, err
+	}
+
 	// 6. Read raw data (heap IDs) based on layout type.
 	// Each VLen element is stored as a global heap reference:
 	//   heap_address (OffsetSize bytes) + object_index (4 bytes) + padding to 16 bytes.
@@ -102,7 +108,7 @@ func ReadDatasetVLenBytes(r io.ReaderAt, header *ObjectHeader, sb *Superblock) (
 			}
 		}
 
-		rawData, err = readChunkedData(r, layout, dataspace, datatype, sb, filterPipeline)
+		rawData, err = readChunkedData(r, layout, dataspace, datatype, sb, filterPipeline, cfg)
 		if err != nil {
 			return nil, fmt.Errorf("failed to read chunked data: %w", err)
 		}
@@ -116,8 +122,8 @@ func ReadDatasetVLenBytes(r io.ReaderAt, header *ObjectHeader, sb *Superblock) (
 	heapIDSize := uint64(datatype.Size) // Typically 16 bytes.
 	result := make([][]byte, totalElements)
 
-	// Cache global heap collections to avoid re-reading the same collection.
-	heapCache := make(map[uint64]*GlobalHeapCollection)
+	// Elements may reference any number of collections; each is read once.
+	heaps := newGlobalHeapCache(r, offsetSize)
 
 	for i := uint64(0); i < totalElements; i++ {
 		idStart := i * heapIDSize
@@ -141,21 +147,9 @@ func ReadDatasetVLenBytes(r io.ReaderAt, header *ObjectHeader, sb *Superblock) (
 			continue
 		}
 
-		// Look up or read the global heap collection.
-		collection, ok := heapCache[heapRef.HeapAddress]
-		if !ok {
-			collection, err = ReadGlobalHeapCollection(r, heapRef.HeapAddress, offsetSize)
-			if err != nil {
-				return nil, fmt.Errorf("failed to read global heap at 0x%X for element %d: %w",
-					heapRef.HeapAddress, i, err)
-			}
-			heapCache[heapRef.HeapAddress] = collection
-		}
-
-		obj, err := collection.GetObject(heapRef.ObjectIndex)
+		obj, err := heaps.object(heapRef)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get heap object %d for element %d: %w",
-				heapRef.ObjectIndex, i, err)
+			return nil, fmt.Errorf("element %d: %w", i, err)
 		}
 
 		// Copy the data to avoid holding references to the heap collection buffer.
@@ -166,3 +160,72 @@ func ReadDatasetVLenBytes(r io.ReaderAt, header *ObjectHeader, sb *Superblock) (
 
 	return result, nil
 }
+
+// ReadDatasetVLenStringLists reads a dataset whose elements are variable-length
+// sequences of variable-length strings, returning one []string per element.
+//
+// Each element's sequence is a global heap object holding the heap IDs of its
+// strings (base type size bytes each, 16 for 8-byte offsets), which are
+// resolved in turn.
+//
+// Reference: H5Tvlen.c - H5T__vlen_disk_read() (nested VL types).
+func ReadDatasetVLenStringLists(r io.ReaderAt, header *ObjectHeader, sb *Superblock, opts ...ReadOption) ([][]string, error) {
+	var datatype *DatatypeMessage
+	for _, msg := range header.Messages {
+		if msg.Type == MsgDatatype {
+			var err error
+			datatype, err = ParseDatatypeMessage(msg.Data)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse datatype: %w", err)
+			}
+			break
+		}
+	}
+	if datatype == nil {
+		return nil, errors.New("datatype message not found")
+	}
+	if datatype.Class != DatatypeVarLen || datatype.IsVariableString() {
+		return nil, errors.New("datatype is not a variable-length sequence")
+	}
+	base, err := ParseDatatypeMessage(datatype.Properties)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse sequence base type: %w", err)
+	}
+	if !base.IsVariableString() {
+		return nil, fmt.Errorf("sequence base type is not a variable-length string: class=%d", base.Class)
+	}
+
+	sequences, err := ReadDatasetVLenBytes(r, header, sb, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	offsetSize := int(sb.OffsetSize)
+	idSize := int(base.Size)
+	heaps := newGlobalHeapCache(r, offsetSize)
+	result := make([][]string, len(sequences))
+
+	for i, seq := range sequences {
+		if len(seq)%idSize != 0 {
+			return nil, fmt.Errorf("element %d: sequence size %d is not a multiple of %d", i, len(seq), idSize)
+		}
+		strs := make([]string, len(seq)/idSize)
+		for j := range strs {
+			ref, err := ParseGlobalHeapReference(seq[j*idSize+4:(j+1)*idSize], offsetSize)
+			if err != nil {
+				return nil, fmt.Errorf("element %d string %d: %w", i, j, err)
+			}
+			if ref.HeapAddress == 0 {
+				continue // Null reference: empty string.
+			}
+			obj, err := heaps.object(ref)
+			if err != nil {
+				return nil, fmt.Errorf("element %d string %d: %w", i, j, err)
+			}
+			strs[j] = strings.TrimRight(string(obj.Data), "\x00")
+		}
+		result[i] = strs
+	}
+
+	return result, nil
+}
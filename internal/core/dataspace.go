@@ -33,8 +33,12 @@ type DataspaceMessage struct {
 }
 
 // ParseDataspaceMessage parses a dataspace message from header message data.
+//
+// A rank-0 message is a scalar dataspace, as written by h5py and the HDF5
+// library for single values; it is returned as DataspaceScalar with one
+// element so callers can size reads without special cases.
 func ParseDataspaceMessage(data []byte) (*DataspaceMessage, error) {
-	if len(data) < 2 {
+	if len(data) < 3 {
 		return nil, errors.New("dataspace message too short")
 	}
 
@@ -60,6 +64,12 @@ func ParseDataspaceMessage(data []byte) (*DataspaceMessage, error) {
 
 	// Determine dataspace type based on dimensionality.
 	if dimensionality == 0 {
+		// Version 2 distinguishes null from scalar in the type byte.
+		if version == 2 && len(data) > 3 && DataspaceType(data[3]) == DataspaceNull {
+			ds.Type = DataspaceNull
+			return ds, nil
+		}
+
 		// Scalar dataspace.
 		ds.Type = DataspaceScalar
 		ds.Dimensions = []uint64{1} // Treat scalar as 1-element array.
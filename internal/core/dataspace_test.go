@@ -131,3 +131,15 @@ func TestParseDataspaceMessage_Version2(t *testing.T) {
 	require.Equal(t, []uint64{5, 7}, ds.Dimensions)
 	require.Equal(t, uint64(35), ds.TotalElements())
 }
+
+func TestParseDataspaceMessage_Version2Null(t *testing.T) {
+	// Version 2 records the null type explicitly; it must not read as scalar.
+	data := []byte{2, 0, 0, byte(DataspaceNull)}
+
+	ds, err := ParseDataspaceMessage(data)
+	require.NoError(t, err)
+
+	require.Equal(t, DataspaceNull, ds.Type)
+	require.Empty(t, ds.Dimensions)
+	require.Equal(t, uint64(0), ds.TotalElements())
+}
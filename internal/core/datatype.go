@@ -34,33 +34,83 @@ type DatatypeMessage struct {
 	Properties    []byte
 }
 
-// calculateCompoundPropsLen calculates the exact length of compound datatype properties.
-// This is needed for inline parsing of nested compounds, where we can't just take "all remaining".
+// datatypePropsLen calculates the exact length of the properties of a
+// datatype of the given class. This is needed for inline parsing of compound
+// members, array and enum base types, where we can't just take "all remaining".
 //
-// Algorithm:
-//  1. Read member count (4 bytes for v3, 2 bytes embedded in header for v1)
-//  2. For each member:
-//     - Skip name (null-terminated, padded to 8-byte boundary for v1)
-//     - Skip offset field (4 bytes)
-//     - Skip array info (28 bytes for v1, not present in v3)
-//     - Recursively calculate member datatype size
-//  3. Return total properties length
-func calculateCompoundPropsLen(properties []byte, version uint8) (int, error) {
-	// Version 1 or 2: member count is embedded in ClassBitField (not in properties)
-	// This is complex, so for now return error to use fallback
-	if version != 3 {
-		return 0, errors.New("compound v1/v2 inline size calculation not yet implemented")
+// Compound, enum, array and variable-length properties embed further datatype
+// messages, whose lengths are calculated recursively.
+//
+// Reference: H5Odtype.c - H5O__dtype_decode_helper().
+func datatypePropsLen(class DatatypeClass, version uint8, classBitField uint32, properties []byte) (int, error) {
+	switch class {
+	case DatatypeFixed, DatatypeBitfield:
+		return 4, nil // Bit offset + precision.
+	case DatatypeFloat:
+		return 12, nil // Full IEEE 754 info.
+	case DatatypeTime:
+		return 2, nil
+	case DatatypeString, DatatypeReference:
+		// Padding, character set and reference type live in the class bit field.
+		return 0, nil
+	case DatatypeOpaque:
+		// Tag length (already a multiple of 8) is stored in bits 0-7.
+		return int(classBitField & 0xFF), nil
+	case DatatypeCompound:
+		return compoundPropsLen(version, classBitField, properties)
+	case DatatypeEnum:
+		return enumPropsLen(version, classBitField, properties)
+	case DatatypeArray:
+		return arrayPropsLen(version, properties)
+	case DatatypeVarLen, DatatypeComplex:
+		// Properties are the base type.
+		return encodedDatatypeLen(properties)
+	default:
+		return 0, fmt.Errorf("unknown datatype class: %d", class)
 	}
+}
 
-	// Version 3: member count is first 4 bytes
-	if len(properties) < 4 {
-		return 0, errors.New("compound v3 properties too short for member count")
+// encodedDatatypeLen returns the encoded length of the datatype message at
+// the start of data: the 8-byte header plus its properties.
+func encodedDatatypeLen(data []byte) (int, error) {
+	if len(data) < 8 {
+		return 0, errors.New("datatype header truncated")
 	}
-	numMembers := binary.LittleEndian.Uint32(properties[0:4])
-	offset := 4
+	classAndVersion := binary.LittleEndian.Uint32(data[0:4])
+	class := DatatypeClass(classAndVersion & 0x0F)
+	version := uint8((classAndVersion >> 4) & 0x0F)
+
+	propsLen, err := datatypePropsLen(class, version, classAndVersion>>8, data[8:])
+	if err != nil {
+		return 0, err
+	}
+	if 8+propsLen > len(data) {
+		return 0, fmt.Errorf("class %d properties truncated", class)
+	}
+	return 8 + propsLen, nil
+}
 
-	for i := uint32(0); i < numMembers; i++ {
-		// Skip member name (null-terminated, NOT padded in v3)
+// compoundPropsLen walks compound member definitions:
+//   - Member name: null-terminated, padded to a multiple of 8 bytes for
+//     versions 1 and 2, unpadded for version 3.
+//   - Member offset: 4 bytes.
+//   - Array info (version 1 only): 28 bytes.
+//   - Member datatype (recursive).
+//
+// Versions 1 and 2 store the member count in the class bit field, version 3
+// in the first 4 bytes of the properties.
+func compoundPropsLen(version uint8, classBitField uint32, properties []byte) (int, error) {
+	numMembers := int(classBitField & 0xFFFF)
+	offset := 0
+	if version >= 3 {
+		if len(properties) < 4 {
+			return 0, errors.New("compound v3 properties too short for member count")
+		}
+		numMembers = int(binary.LittleEndian.Uint32(properties[0:4]))
+		offset = 4
+	}
+
+	for i := 0; i < numMembers; i++ {
 		nameEnd := offset
 		for nameEnd < len(properties) && properties[nameEnd] != 0 {
 			nameEnd++
@@ -68,31 +118,80 @@ func calculateCompoundPropsLen(properties []byte, version uint8) (int, error) {
 		if nameEnd >= len(properties) {
 			return 0, fmt.Errorf("member %d: name not null-terminated", i)
 		}
-		offset = nameEnd + 1 // Skip past null terminator
-
-		// Skip member offset field (4 bytes)
-		if offset+4 > len(properties) {
-			return 0, fmt.Errorf("member %d: offset field truncated", i)
+		if version >= 3 {
+			offset = nameEnd + 1
+		} else {
+			offset += ((nameEnd - offset + 8) / 8) * 8
 		}
-		offset += 4
 
-		// Parse member datatype to calculate its size
-		if offset+8 > len(properties) {
-			return 0, fmt.Errorf("member %d: datatype header truncated", i)
+		offset += 4 // Member offset.
+		if version == 1 {
+			offset += 28 // Array info.
+		}
+		if offset > len(properties) {
+			return 0, fmt.Errorf("member %d: definition truncated", i)
 		}
 
-		// Recursively calculate member datatype size
-		memberDt, err := ParseDatatypeMessage(properties[offset:])
+		memberLen, err := encodedDatatypeLen(properties[offset:])
 		if err != nil {
-			return 0, fmt.Errorf("member %d: failed to parse datatype: %w", i, err)
+			return 0, fmt.Errorf("member %d: %w", i, err)
 		}
-		memberDtSize := 8 + len(memberDt.Properties)
-		offset += memberDtSize
+		offset += memberLen
 	}
 
 	return offset, nil
 }
 
+// enumPropsLen walks the enum base type, member names and member values.
+func enumPropsLen(version uint8, classBitField uint32, properties []byte) (int, error) {
+	baseLen, err := encodedDatatypeLen(properties)
+	if err != nil {
+		return 0, fmt.Errorf("enum base type: %w", err)
+	}
+	baseSize := int(binary.LittleEndian.Uint32(properties[4:8]))
+
+	numMembers := int(classBitField & 0xFFFF)
+	offset := baseLen
+	for i := 0; i < numMembers; i++ {
+		end := offset
+		for end < len(properties) && properties[end] != 0 {
+			end++
+		}
+		if end >= len(properties) {
+			return 0, fmt.Errorf("enum member %d name not null-terminated", i)
+		}
+		nameLen := end - offset + 1
+		if version < 3 {
+			nameLen = (nameLen + 7) / 8 * 8
+		}
+		offset += nameLen
+	}
+
+	return offset + numMembers*baseSize, nil
+}
+
+// arrayPropsLen walks the array dimensions and base type. See ParseArrayType
+// for the layout.
+func arrayPropsLen(version uint8, properties []byte) (int, error) {
+	if len(properties) < 1 {
+		return 0, errors.New("array properties too short")
+	}
+	ndims := int(properties[0])
+	offset := 1 + ndims*4
+	if version < 3 {
+		offset += 3 + ndims*4 // Reserved bytes and permutation indices.
+	}
+	if offset > len(properties) {
+		return 0, errors.New("array dimensions truncated")
+	}
+
+	baseLen, err := encodedDatatypeLen(properties[offset:])
+	if err != nil {
+		return 0, fmt.Errorf("array base type: %w", err)
+	}
+	return offset + baseLen, nil
+}
+
 // ParseDatatypeMessage parses a datatype message from header message data.
 func ParseDatatypeMessage(data []byte) (*DatatypeMessage, error) {
 	if len(data) < 8 {
@@ -109,39 +208,11 @@ func ParseDatatypeMessage(data []byte) (*DatatypeMessage, error) {
 	// Bytes 4-7: Size.
 	size := binary.LittleEndian.Uint32(data[4:8])
 
-	// Calculate property size based on class
-	// This is needed for inline parsing (e.g., compound members)
-	var propsLen int
-	switch class {
-	case DatatypeFixed: // Integer
-		propsLen = 4 // bit offset + precision
-	case DatatypeFloat:
-		propsLen = 12 // full IEEE 754 info
-	case DatatypeBitfield:
-		propsLen = 4
-	case DatatypeTime:
-		propsLen = 2
-	case DatatypeString:
-		// String properties are variable, but typically minimal
-		// For now, take all remaining (safe for top-level parsing)
-		propsLen = len(data) - 8
-	case DatatypeCompound:
-		// Compound types: properties are variable length and self-describing
-		// For inline parsing (nested compounds), we must calculate the exact size
-		// by walking through the member definitions
-		calculatedLen, err := calculateCompoundPropsLen(data[8:], version)
-		if err != nil {
-			// Fallback: take all remaining (for backward compatibility)
-			propsLen = len(data) - 8
-		} else {
-			propsLen = calculatedLen
-		}
-	case DatatypeArray, DatatypeEnum, DatatypeReference, DatatypeOpaque, DatatypeVarLen:
-		// Complex types: properties are variable length
-		// For inline parsing, take all remaining
-		propsLen = len(data) - 8
-	default:
-		// Unknown type: take all remaining
+	// Calculate property size based on class.
+	// This is needed for inline parsing (e.g., compound members).
+	propsLen, err := datatypePropsLen(class, version, classBitField, data[8:])
+	if err != nil {
+		// Fallback: take all remaining (for backward compatibility).
 		propsLen = len(data) - 8
 	}
 
@@ -228,12 +299,43 @@ func (dt *DatatypeMessage) IsCompound() bool {
 	return dt.Class == DatatypeCompound
 }
 
+// String padding types, stored in the class bit field of string datatypes.
+const (
+	StringPadNullTerm uint8 = 0 // Null-terminated (H5T_STR_NULLTERM)
+	StringPadNullPad  uint8 = 1 // Padded with nulls (H5T_STR_NULLPAD)
+	StringPadSpacePad uint8 = 2 // Padded with spaces, Fortran style (H5T_STR_SPACEPAD)
+)
+
+// Character sets, stored in the class bit field of string datatypes.
+const (
+	CharsetASCII uint8 = 0 // H5T_CSET_ASCII
+	CharsetUTF8  uint8 = 1 // H5T_CSET_UTF8
+)
+
 // GetStringPadding returns the string padding type.
 // 0 = null-terminated, 1 = null-padded, 2 = space-padded.
+//
+// Fixed-length strings keep it in bits 0-3 of the class bit field;
+// variable-length strings in bits 4-7 (bits 0-3 hold the sequence/string type).
 func (dt *DatatypeMessage) GetStringPadding() uint8 {
+	if dt.Class == DatatypeVarLen {
+		return uint8((dt.ClassBitField >> 4) & 0x0F)
+	}
 	return uint8(dt.ClassBitField & 0x0F)
 }
 
+// GetStringCharset returns the character set of a string datatype
+// (CharsetASCII or CharsetUTF8).
+//
+// Fixed-length strings keep it in bits 4-7 of the class bit field;
+// variable-length strings in bits 8-11.
+func (dt *DatatypeMessage) GetStringCharset() uint8 {
+	if dt.Class == DatatypeVarLen {
+		return uint8((dt.ClassBitField >> 8) & 0x0F)
+	}
+	return uint8((dt.ClassBitField >> 4) & 0x0F)
+}
+
 // String returns human-readable datatype description.
 func (dt *DatatypeMessage) String() string {
 	var className string
@@ -0,0 +1,78 @@
+package core
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// ArrayType represents a parsed array datatype: a fixed-shape array of a
+// base type per element.
+type ArrayType struct {
+	Dims []uint64         // Array dimensions (row-major).
+	Base *DatatypeMessage // Element type of the array.
+}
+
+// ParseArrayType parses array datatype properties.
+// Properties format:
+//   - Dimensionality: 1 byte.
+//   - Versions 1 and 2: 3 reserved bytes, then one 4-byte size per
+//     dimension followed by one 4-byte permutation index per dimension
+//     (unused by the library).
+//   - Version 3: one 4-byte size per dimension.
+//   - Base type (datatype message).
+//
+// Reference: H5Odtype.c - H5O__dtype_decode_helper() (H5T_ARRAY).
+func ParseArrayType(dt *DatatypeMessage) (*ArrayType, error) {
+	if dt.Class != DatatypeArray {
+		return nil, errors.New("not an array datatype")
+	}
+	props := dt.Properties
+	if len(props) < 1 {
+		return nil, errors.New("array properties too short")
+	}
+
+	ndims := int(props[0])
+	if ndims == 0 {
+		return nil, errors.New("array datatype has no dimensions")
+	}
+	offset := 1
+	if dt.Version < 3 {
+		offset += 3
+	}
+	if len(props) < offset+ndims*4 {
+		return nil, fmt.Errorf("array dimensions truncated: need %d bytes, have %d", ndims*4, len(props)-offset)
+	}
+
+	arr := &ArrayType{Dims: make([]uint64, ndims)}
+	for i := range arr.Dims {
+		arr.Dims[i] = uint64(binary.LittleEndian.Uint32(props[offset:]))
+		offset += 4
+	}
+	if dt.Version < 3 {
+		offset += ndims * 4 // Permutation indices.
+	}
+	if len(props) < offset+8 {
+		return nil, errors.New("array base type truncated")
+	}
+
+	base, err := ParseDatatypeMessage(props[offset:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse array base type: %w", err)
+	}
+	arr.Base = base
+
+	if n := arr.Len(); n*uint64(base.Size) != uint64(dt.Size) {
+		return nil, fmt.Errorf("array size %d does not match %d elements of %d bytes", dt.Size, n, base.Size)
+	}
+	return arr, nil
+}
+
+// Len returns the number of base elements in one array value.
+func (a *ArrayType) Len() uint64 {
+	n := uint64(1)
+	for _, d := range a.Dims {
+		n *= d
+	}
+	return n
+}
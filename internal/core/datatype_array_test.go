@@ -0,0 +1,47 @@
+package core
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseArrayType(t *testing.T) {
+	base, err := EncodeDatatypeMessage(&DatatypeMessage{Class: DatatypeFloat, Version: 1, Size: 8, ClassBitField: 0x20,
+		Properties: []byte{0, 0, 64, 0, 52, 11, 0, 0, 255, 3, 0, 0}})
+	require.NoError(t, err)
+	enc, err := EncodeArrayDatatypeMessage(base, []uint64{2, 3}, 48)
+	require.NoError(t, err)
+	dt, err := ParseDatatypeMessage(enc)
+	require.NoError(t, err)
+
+	arr, err := ParseArrayType(dt)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{2, 3}, arr.Dims)
+	require.Equal(t, uint64(6), arr.Len())
+	require.Equal(t, DatatypeFloat, arr.Base.Class)
+	require.Equal(t, uint32(8), arr.Base.Size)
+
+	_, err = ParseArrayType(&DatatypeMessage{Class: DatatypeFixed})
+	require.ErrorContains(t, err, "not an array")
+}
+
+// Versions 1 and 2 have reserved bytes and permutation indices.
+func TestParseArrayType_Version2(t *testing.T) {
+	base, err := EncodeDatatypeMessage(&DatatypeMessage{Class: DatatypeFixed, Version: 1, Size: 2})
+	require.NoError(t, err)
+
+	props := []byte{1, 0, 0, 0}
+	props = binary.LittleEndian.AppendUint32(props, 4)
+	props = binary.LittleEndian.AppendUint32(props, 0) // permutation
+	props = append(props, base...)
+
+	arr, err := ParseArrayType(&DatatypeMessage{Class: DatatypeArray, Version: 2, Size: 8, Properties: props})
+	require.NoError(t, err)
+	require.Equal(t, []uint64{4}, arr.Dims)
+	require.Equal(t, uint32(2), arr.Base.Size)
+
+	_, err = ParseArrayType(&DatatypeMessage{Class: DatatypeArray, Version: 2, Size: 6, Properties: props})
+	require.ErrorContains(t, err, "does not match")
+}
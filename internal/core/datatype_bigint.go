@@ -0,0 +1,46 @@
+package core
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// DecodeBigInts decodes n fixed-point values of any width (including 16-byte
+// int128/uint128) from raw element bytes. Byte order and signedness come
+// from the datatype; signed values are two's complement.
+func DecodeBigInts(raw []byte, dt *DatatypeMessage, n uint64) ([]*big.Int, error) {
+	if dt.Class != DatatypeFixed {
+		return nil, fmt.Errorf("datatype is not an integer: %s", dt)
+	}
+	size := uint64(dt.Size)
+	if size == 0 {
+		return nil, fmt.Errorf("invalid integer size 0")
+	}
+	if n*size > uint64(len(raw)) {
+		return nil, fmt.Errorf("data truncated: need %d bytes, have %d", n*size, len(raw))
+	}
+
+	bigEndian := dt.ClassBitField&0x01 != 0
+	signed := dt.IsSignedFixedPoint()
+	modulus := new(big.Int).Lsh(big.NewInt(1), uint(size*8))
+
+	values := make([]*big.Int, n)
+	elem := make([]byte, size)
+	for i := range values {
+		src := raw[uint64(i)*size : uint64(i+1)*size] //nolint:gosec // G115: element index is non-negative
+		// big.Int.SetBytes takes big-endian bytes.
+		if bigEndian {
+			copy(elem, src)
+		} else {
+			for j := range elem {
+				elem[j] = src[len(src)-1-j]
+			}
+		}
+		v := new(big.Int).SetBytes(elem)
+		if signed && elem[0]&0x80 != 0 {
+			v.Sub(v, modulus)
+		}
+		values[i] = v
+	}
+	return values, nil
+}
@@ -0,0 +1,49 @@
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeBigInts(t *testing.T) {
+	maxU128, _ := new(big.Int).SetString("340282366920938463463374607431768211455", 10)
+	minI128, _ := new(big.Int).SetString("-170141183460469231731687303715884105728", 10)
+
+	ones := make([]byte, 16)
+	for i := range ones {
+		ones[i] = 0xFF
+	}
+	minLE := make([]byte, 16)
+	minLE[15] = 0x80
+	two := make([]byte, 16)
+	two[0] = 2
+
+	le := append(append(append([]byte{}, ones...), minLE...), two...)
+
+	signed := &DatatypeMessage{Class: DatatypeFixed, Size: 16, ClassBitField: 0x08}
+	got, err := DecodeBigInts(le, signed, 3)
+	require.NoError(t, err)
+	require.Equal(t, "-1", got[0].String())
+	require.Equal(t, minI128.String(), got[1].String())
+	require.Equal(t, "2", got[2].String())
+
+	unsigned := &DatatypeMessage{Class: DatatypeFixed, Size: 16}
+	got, err = DecodeBigInts(le, unsigned, 3)
+	require.NoError(t, err)
+	require.Equal(t, maxU128.String(), got[0].String())
+	require.Equal(t, "2", got[2].String())
+
+	// Big-endian 4-byte signed.
+	be := &DatatypeMessage{Class: DatatypeFixed, Size: 4, ClassBitField: 0x09}
+	got, err = DecodeBigInts([]byte{0xFF, 0xFF, 0xFF, 0xFE, 0x00, 0x00, 0x01, 0x00}, be, 2)
+	require.NoError(t, err)
+	require.Equal(t, "-2", got[0].String())
+	require.Equal(t, "256", got[1].String())
+
+	_, err = DecodeBigInts(le, signed, 4)
+	require.ErrorContains(t, err, "truncated")
+	_, err = DecodeBigInts(le, &DatatypeMessage{Class: DatatypeFloat, Size: 8}, 1)
+	require.ErrorContains(t, err, "not an integer")
+}
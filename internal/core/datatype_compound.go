@@ -44,10 +44,10 @@ func ParseCompoundType(dt *DatatypeMessage) (*CompoundType, error) {
 
 	// Parse based on version.
 	switch dt.Version {
-	case 1:
-		// For version 1, number of members is in ClassBitField bits 0-15.
+	case 1, 2:
+		// For versions 1 and 2, number of members is in ClassBitField bits 0-15.
 		numMembers := uint16(dt.ClassBitField & 0xFFFF)
-		return parseCompoundV1(compound, dt.Properties, numMembers)
+		return parseCompoundV1(compound, dt.Properties, numMembers, dt.Version)
 	case 3:
 		return parseCompoundV3(compound, dt.Properties)
 	default:
@@ -55,18 +55,22 @@ func ParseCompoundType(dt *DatatypeMessage) (*CompoundType, error) {
 	}
 }
 
-// parseCompoundV1 parses version 1 compound datatype properties.
+// parseCompoundV1 parses version 1 and 2 compound datatype properties.
 // Format per member (H5Odtype.c:360-481):
 //  1. Name (null-terminated, padded to 8-byte boundary).
 //  2. Offset (uint32, 4 bytes).
-//  3. Array info (28 bytes total):
+//  3. Array info (version 1 only, 28 bytes total):
 //     - Dimensionality (1 byte).
 //     - Reserved (3 bytes).
 //     - Dimension permutation (4 bytes).
 //     - Reserved (4 bytes).
 //     - Dimension sizes (4 × uint32 = 16 bytes).
 //  4. Member datatype (recursive, NO padding between members).
-func parseCompoundV1(compound *CompoundType, properties []byte, numMembers uint16) (*CompoundType, error) {
+//
+// Version 1 members with a non-zero dimensionality are fixed-size arrays of
+// the member datatype; they are returned as array datatypes, the way version
+// 2 files store them.
+func parseCompoundV1(compound *CompoundType, properties []byte, numMembers uint16, version uint8) (*CompoundType, error) {
 	offset := 0
 
 	for i := uint16(0); i < numMembers; i++ {
@@ -97,11 +101,20 @@ func parseCompoundV1(compound *CompoundType, properties []byte, numMembers uint1
 		offset += 4
 
 		// 3. Array info (always 28 bytes for version 1, even for scalar members).
-		if offset+28 > len(properties) {
-			return nil, fmt.Errorf("member %d: array info truncated", i)
+		var dims []uint32
+		if version == 1 {
+			if offset+28 > len(properties) {
+				return nil, fmt.Errorf("member %d: array info truncated", i)
+			}
+			ndims := int(properties[offset])
+			if ndims > 4 {
+				return nil, fmt.Errorf("member %d (%s): invalid array dimensionality %d", i, member.Name, ndims)
+			}
+			for d := 0; d < ndims; d++ {
+				dims = append(dims, binary.LittleEndian.Uint32(properties[offset+12+d*4:]))
+			}
+			offset += 28
 		}
-		// Skip array info (we don't support array members yet).
-		offset += 28
 
 		// 4. Member datatype (recursive parse).
 		if offset+8 > len(properties) {
@@ -112,10 +125,14 @@ func parseCompoundV1(compound *CompoundType, properties []byte, numMembers uint1
 		if err != nil {
 			return nil, fmt.Errorf("member %d (%s): failed to parse datatype: %w", i, member.Name, err)
 		}
+		memberLen := memberType.GetEncodedSize()
+		if len(dims) > 0 {
+			memberType = arrayMemberType(dims, memberType, properties[offset:offset+memberLen])
+		}
 		member.Type = memberType
 
 		// Advance past member datatype (no padding between members).
-		offset += memberType.GetEncodedSize()
+		offset += memberLen
 
 		compound.Members = append(compound.Members, member)
 	}
@@ -123,6 +140,27 @@ func parseCompoundV1(compound *CompoundType, properties []byte, numMembers uint1
 	return compound, nil
 }
 
+// arrayMemberType wraps the base type of a version 1 array member into a
+// version 3 array datatype, so array members read the same way whatever
+// compound version stored them.
+func arrayMemberType(dims []uint32, base *DatatypeMessage, encodedBase []byte) *DatatypeMessage {
+	props := make([]byte, 0, 1+len(dims)*4+len(encodedBase))
+	props = append(props, byte(len(dims)))
+	size := base.Size
+	for _, d := range dims {
+		props = binary.LittleEndian.AppendUint32(props, d)
+		size *= d
+	}
+	props = append(props, encodedBase...)
+
+	return &DatatypeMessage{
+		Class:      DatatypeArray,
+		Version:    3,
+		Size:       size,
+		Properties: props,
+	}
+}
+
 // parseCompoundV3 parses version 3 compound datatype properties.
 func parseCompoundV3(compound *CompoundType, properties []byte) (*CompoundType, error) {
 	// Version 3 uses uint32 for member count.
@@ -36,7 +36,7 @@ func TestParseCompoundType(t *testing.T) {
 			name: "unsupported version",
 			dt: &DatatypeMessage{
 				Class:      DatatypeCompound,
-				Version:    2,
+				Version:    4,
 				Properties: []byte{0x00, 0x00},
 			},
 			wantErr:     true,
@@ -106,6 +106,46 @@ func TestParseCompoundType_Version1(t *testing.T) {
 	require.Equal(t, uint32(4), got.Members[0].Type.Size)
 }
 
+// TestParseCompoundType_Version1ArrayMember tests that version 1 members
+// with array info are returned as array datatypes.
+func TestParseCompoundType_Version1ArrayMember(t *testing.T) {
+	// Member "v": float32[2][3] at offset 0.
+	properties := []byte("v\x00\x00\x00\x00\x00\x00\x00")
+	properties = binary.LittleEndian.AppendUint32(properties, 0)
+
+	// Array info: dimensionality, reserved, permutation, reserved, 4 dimension sizes.
+	arrayInfo := make([]byte, 28)
+	arrayInfo[0] = 2
+	binary.LittleEndian.PutUint32(arrayInfo[12:], 2)
+	binary.LittleEndian.PutUint32(arrayInfo[16:], 3)
+	properties = append(properties, arrayInfo...)
+
+	float32Type, err := EncodeDatatypeMessage(&DatatypeMessage{Class: DatatypeFloat, Size: 4})
+	require.NoError(t, err)
+	properties = append(properties, float32Type...)
+
+	dt := &DatatypeMessage{
+		Class:         DatatypeCompound,
+		Version:       1,
+		Size:          24,
+		ClassBitField: 1,
+		Properties:    properties,
+	}
+
+	got, err := ParseCompoundType(dt)
+	require.NoError(t, err)
+	require.Len(t, got.Members, 1)
+
+	member := got.Members[0].Type
+	require.Equal(t, DatatypeArray, member.Class)
+	require.Equal(t, uint32(24), member.Size)
+
+	arr, err := ParseArrayType(member)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{2, 3}, arr.Dims)
+	require.True(t, arr.Base.IsFloat32())
+}
+
 // TestParseCompoundType_Version3 tests version 3 compound parsing.
 func TestParseCompoundType_Version3(t *testing.T) {
 	// Version 3 format: num members(4) + [name + offset(4) + datatype(8+)]*
@@ -167,3 +207,26 @@ func TestParseCompoundType_EmptyCompound(t *testing.T) {
 	require.Equal(t, 0, len(got.Members))
 	require.Equal(t, uint32(0), got.Size)
 }
+
+// TestParseCompoundType_StringMemberBeforeOthers checks that a fixed-length
+// string member, which has no datatype properties, does not swallow the
+// members that follow it.
+func TestParseCompoundType_StringMemberBeforeOthers(t *testing.T) {
+	int32Type := &DatatypeMessage{Class: DatatypeFixed, Version: 1, Size: 4, ClassBitField: 0x08, Properties: []byte{0, 0, 32, 0}}
+	strType := &DatatypeMessage{Class: DatatypeString, Version: 1, Size: 6}
+
+	dt, err := CreateCompoundTypeFromFields([]CompoundFieldDef{
+		{Name: "label", Offset: 0, Type: strType},
+		{Name: "id", Offset: 6, Type: int32Type},
+	})
+	require.NoError(t, err)
+
+	compound, err := ParseCompoundType(dt)
+	require.NoError(t, err)
+	require.Len(t, compound.Members, 2)
+	require.Equal(t, "label", compound.Members[0].Name)
+	require.Empty(t, compound.Members[0].Type.Properties)
+	require.Equal(t, "id", compound.Members[1].Name)
+	require.Equal(t, uint32(6), compound.Members[1].Offset)
+	require.Equal(t, uint32(4), compound.Members[1].Type.Size)
+}
@@ -340,3 +340,38 @@ func CreateBasicDatatypeMessage(class DatatypeClass, size uint32) (*DatatypeMess
 		Properties:    properties,
 	}, nil
 }
+
+// CompoundTypeV1 returns a compound datatype re-encoded in version 1, the
+// format readable by HDF5 1.6 and earlier. Nested compound members are
+// converted as well. Array members are not supported, as they would require
+// version 2; datatypes of other classes are returned unchanged.
+//
+// Reference: H5Odtype.c - H5O__dtype_encode_helper().
+func CompoundTypeV1(dt *DatatypeMessage) (*DatatypeMessage, error) {
+	if dt.Class != DatatypeCompound || dt.Version == 1 {
+		return dt, nil
+	}
+
+	compound, err := ParseCompoundType(dt)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make([]CompoundFieldDef, len(compound.Members))
+	for i, member := range compound.Members {
+		if member.Type.Class == DatatypeArray {
+			return nil, fmt.Errorf("compound member %q: array members require compound datatype version 2", member.Name)
+		}
+		memberType, err := CompoundTypeV1(member.Type)
+		if err != nil {
+			return nil, err
+		}
+		fields[i] = CompoundFieldDef{Name: member.Name, Offset: member.Offset, Type: memberType}
+	}
+
+	encoded, err := EncodeCompoundDatatypeV1(compound.Size, fields)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDatatypeMessage(encoded)
+}
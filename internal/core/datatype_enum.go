@@ -0,0 +1,95 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+// EnumType represents a parsed enumerated datatype: an integer base type and
+// a table of symbolic names with their values.
+type EnumType struct {
+	Base   *DatatypeMessage // Integer base type (any width and byte order).
+	Names  []string         // Member names.
+	Values [][]byte         // Member values, Base.Size bytes each, in file byte order.
+}
+
+// ParseEnumType parses enum datatype properties.
+// Properties format:
+//   - Base type (datatype message, 8+ bytes).
+//   - Member names: null-terminated, padded to a multiple of 8 bytes for
+//     versions 1 and 2, unpadded for version 3.
+//   - Member values: one base type element per member, in name order.
+//
+// The number of members is stored in ClassBitField bits 0-15.
+//
+// Reference: H5Odtype.c - H5O__dtype_decode_helper() (H5T_ENUM).
+func ParseEnumType(dt *DatatypeMessage) (*EnumType, error) {
+	if dt.Class != DatatypeEnum {
+		return nil, errors.New("not an enum datatype")
+	}
+	if len(dt.Properties) < 8 {
+		return nil, errors.New("enum properties too short")
+	}
+
+	base, err := ParseDatatypeMessage(dt.Properties)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse enum base type: %w", err)
+	}
+	if base.Class != DatatypeFixed {
+		return nil, fmt.Errorf("unsupported enum base type class: %d", base.Class)
+	}
+	if base.Size == 0 || base.Size > 8 {
+		return nil, fmt.Errorf("unsupported enum base type size: %d", base.Size)
+	}
+
+	numMembers := int(dt.ClassBitField & 0xFFFF)
+	enum := &EnumType{
+		Base:   base,
+		Names:  make([]string, numMembers),
+		Values: make([][]byte, numMembers),
+	}
+
+	props := dt.Properties
+	offset := base.GetEncodedSize()
+	for i := 0; i < numMembers; i++ {
+		end := offset
+		for end < len(props) && props[end] != 0 {
+			end++
+		}
+		if end >= len(props) {
+			return nil, fmt.Errorf("enum member %d name not null-terminated", i)
+		}
+		enum.Names[i] = string(props[offset:end])
+
+		nameLen := end - offset + 1
+		if dt.Version < 3 {
+			nameLen = (nameLen + 7) / 8 * 8
+		}
+		offset += nameLen
+	}
+
+	size := int(base.Size)
+	if offset+numMembers*size > len(props) {
+		return nil, fmt.Errorf("enum values truncated: need %d bytes, have %d", numMembers*size, len(props)-offset)
+	}
+	for i := range enum.Values {
+		enum.Values[i] = props[offset : offset+size]
+		offset += size
+	}
+
+	return enum, nil
+}
+
+// NameOf returns the name of the member with the given raw value (Base.Size
+// bytes in file byte order).
+//
+// Reference: H5Tenum.c - H5T__enum_nameof().
+func (e *EnumType) NameOf(value []byte) (string, bool) {
+	for i, v := range e.Values {
+		if bytes.Equal(v, value) {
+			return e.Names[i], true
+		}
+	}
+	return "", false
+}
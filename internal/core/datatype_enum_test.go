@@ -0,0 +1,131 @@
+package core
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// enumBase encodes an integer base type of the given size; bigEndian sets
+// byte order bit 0 of the class bit field.
+func enumBase(t *testing.T, size uint32, signed, bigEndian bool) []byte {
+	t.Helper()
+	var bits uint32
+	if bigEndian {
+		bits |= 0x01
+	}
+	if signed {
+		bits |= 0x08
+	}
+	base, err := EncodeDatatypeMessage(&DatatypeMessage{Class: DatatypeFixed, Version: 1, Size: size, ClassBitField: bits})
+	require.NoError(t, err)
+	return base
+}
+
+// enumAttribute builds an attribute holding data with the enum type enc.
+func enumAttribute(t *testing.T, enc []byte, dims []uint64, data []byte) *Attribute {
+	t.Helper()
+	dt, err := ParseDatatypeMessage(enc)
+	require.NoError(t, err)
+	return &Attribute{
+		Name:      "color",
+		Datatype:  dt,
+		Dataspace: &DataspaceMessage{Type: DataspaceSimple, Dimensions: dims},
+		Data:      data,
+	}
+}
+
+func TestParseEnumType(t *testing.T) {
+	tests := []struct {
+		name      string
+		size      uint32
+		signed    bool
+		bigEndian bool
+		values    []byte
+	}{
+		{"uint8", 1, false, false, []byte{0, 1, 200}},
+		{"int16 big-endian", 2, true, true, []byte{0xFF, 0xFF, 0x00, 0x00, 0x01, 0x00}},
+		{"int64", 8, true, false, []byte{
+			0, 0, 0, 0, 0, 0, 0, 0x80,
+			0, 0, 0, 0, 0, 0, 0, 0,
+			0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x7F,
+		}},
+	}
+
+	names := []string{"RED", "GREEN", "INFRARED_LONG"}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enc, err := EncodeEnumDatatypeMessage(enumBase(t, tt.size, tt.signed, tt.bigEndian), names, tt.values, tt.size)
+			require.NoError(t, err)
+			dt, err := ParseDatatypeMessage(enc)
+			require.NoError(t, err)
+
+			enum, err := ParseEnumType(dt)
+			require.NoError(t, err)
+			require.Equal(t, names, enum.Names)
+			require.Equal(t, tt.size, enum.Base.Size)
+			for i, name := range names {
+				size := int(tt.size)
+				got, ok := enum.NameOf(tt.values[i*size : (i+1)*size])
+				require.True(t, ok)
+				require.Equal(t, name, got)
+			}
+		})
+	}
+}
+
+// Versions 1 and 2 pad each member name to a multiple of 8 bytes.
+func TestParseEnumType_PaddedNames(t *testing.T) {
+	base := enumBase(t, 4, true, false)
+	props := append([]byte(nil), base...)
+	props = append(props, "ON\x00\x00\x00\x00\x00\x00"...)
+	props = append(props, "STANDBY\x00"...)
+	props = append(props, 1, 0, 0, 0, 2, 0, 0, 0)
+
+	enum, err := ParseEnumType(&DatatypeMessage{
+		Class:         DatatypeEnum,
+		Version:       1,
+		Size:          4,
+		ClassBitField: 2,
+		Properties:    props,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"ON", "STANDBY"}, enum.Names)
+	require.Equal(t, [][]byte{{1, 0, 0, 0}, {2, 0, 0, 0}}, enum.Values)
+}
+
+func TestParseEnumType_Invalid(t *testing.T) {
+	_, err := ParseEnumType(&DatatypeMessage{Class: DatatypeFixed})
+	require.ErrorContains(t, err, "not an enum")
+
+	enc, err := EncodeEnumDatatypeMessage(enumBase(t, 2, false, false), []string{"A", "B"}, []byte{0, 0, 1, 0}, 2)
+	require.NoError(t, err)
+	dt, err := ParseDatatypeMessage(enc[:len(enc)-1])
+	require.NoError(t, err)
+	_, err = ParseEnumType(dt)
+	require.ErrorContains(t, err, "truncated")
+}
+
+func TestAttributeReadValue_Enum(t *testing.T) {
+	values := make([]byte, 6)
+	binary.BigEndian.PutUint16(values[0:], 10)
+	binary.BigEndian.PutUint16(values[2:], 20)
+	binary.BigEndian.PutUint16(values[4:], 30)
+	enc, err := EncodeEnumDatatypeMessage(enumBase(t, 2, false, true), []string{"RED", "GREEN", "BLUE"}, values, 2)
+	require.NoError(t, err)
+
+	scalar := enumAttribute(t, enc, []uint64{1}, values[2:4])
+	got, err := scalar.ReadValue()
+	require.NoError(t, err)
+	require.Equal(t, "GREEN", got)
+
+	array := enumAttribute(t, enc, []uint64{3}, []byte{0, 30, 0, 10, 0, 30})
+	got, err = array.ReadValue()
+	require.NoError(t, err)
+	require.Equal(t, []string{"BLUE", "RED", "BLUE"}, got)
+
+	unknown := enumAttribute(t, enc, []uint64{1}, []byte{0, 99})
+	_, err = unknown.ReadValue()
+	require.ErrorContains(t, err, "not a member")
+}
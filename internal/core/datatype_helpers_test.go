@@ -385,6 +385,31 @@ func TestGetStringPadding(t *testing.T) {
 	}
 }
 
+// TestGetStringCharset tests character set extraction for fixed and
+// variable-length strings, and padding extraction for variable-length strings.
+func TestGetStringCharset(t *testing.T) {
+	tests := []struct {
+		name          string
+		class         DatatypeClass
+		classBitField uint32
+		wantCharset   uint8
+		wantPadding   uint8
+	}{
+		{"fixed ASCII", DatatypeString, 0x00, CharsetASCII, StringPadNullTerm},
+		{"fixed UTF-8 space-padded", DatatypeString, 0x12, CharsetUTF8, StringPadSpacePad},
+		{"vlen ASCII null-padded", DatatypeVarLen, 0x011, CharsetASCII, StringPadNullPad},
+		{"vlen UTF-8 null-terminated", DatatypeVarLen, 0x101, CharsetUTF8, StringPadNullTerm},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dt := &DatatypeMessage{Class: tt.class, ClassBitField: tt.classBitField}
+			require.Equal(t, tt.wantCharset, dt.GetStringCharset())
+			require.Equal(t, tt.wantPadding, dt.GetStringPadding())
+		})
+	}
+}
+
 // TestGetByteOrder tests byte order extraction.
 func TestGetByteOrder(t *testing.T) {
 	tests := []struct {
@@ -0,0 +1,81 @@
+package core
+
+import (
+	"fmt"
+	"math"
+)
+
+// DecodeInt64s decodes n fixed-point values of up to 8 bytes as int64,
+// exactly. Unsigned values above math.MaxInt64 are an error.
+func DecodeInt64s(raw []byte, dt *DatatypeMessage, n uint64) ([]int64, error) {
+	signed := dt.IsSignedFixedPoint()
+	values := make([]int64, n)
+	err := decodeIntegers(raw, dt, n, func(i uint64, bits uint64) error {
+		if !signed && bits > math.MaxInt64 {
+			return fmt.Errorf("element %d: unsigned value %d overflows int64", i, bits)
+		}
+		values[i] = int64(bits) //nolint:gosec // G115: sign-extended two's complement
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// DecodeUint64s decodes n fixed-point values of up to 8 bytes as uint64,
+// exactly. Negative signed values are an error.
+func DecodeUint64s(raw []byte, dt *DatatypeMessage, n uint64) ([]uint64, error) {
+	signed := dt.IsSignedFixedPoint()
+	values := make([]uint64, n)
+	err := decodeIntegers(raw, dt, n, func(i uint64, bits uint64) error {
+		if signed && int64(bits) < 0 { //nolint:gosec // G115: sign-extended two's complement
+			return fmt.Errorf("element %d: negative value %d cannot be stored as uint64", i, int64(bits)) //nolint:gosec // G115: as above
+		}
+		values[i] = bits
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// decodeIntegers calls store with the 64-bit pattern of each of the n
+// fixed-point values in raw: zero-extended for unsigned types and
+// sign-extended for signed ones.
+func decodeIntegers(raw []byte, dt *DatatypeMessage, n uint64, store func(i, bits uint64) error) error {
+	if dt.Class != DatatypeFixed {
+		return fmt.Errorf("datatype is not an integer: %s", dt)
+	}
+	size := uint64(dt.Size)
+	if size == 0 || size > 8 {
+		return fmt.Errorf("unsupported integer size %d (use big integers for wider types)", size)
+	}
+	if n*size > uint64(len(raw)) {
+		return fmt.Errorf("data truncated: need %d bytes, have %d", n*size, len(raw))
+	}
+
+	bigEndian := dt.ClassBitField&0x01 != 0
+	signed := dt.IsSignedFixedPoint()
+	shift := 64 - 8*size
+	for i := uint64(0); i < n; i++ {
+		elem := raw[i*size : (i+1)*size]
+		var bits uint64
+		for j := uint64(0); j < size; j++ {
+			b := elem[j]
+			if bigEndian {
+				bits = bits<<8 | uint64(b)
+			} else {
+				bits |= uint64(b) << (8 * j)
+			}
+		}
+		if signed && shift > 0 {
+			bits = uint64(int64(bits<<shift) >> shift) //nolint:gosec // G115: sign extension
+		}
+		if err := store(i, bits); err != nil {
+			return err
+		}
+	}
+	return nil
+}
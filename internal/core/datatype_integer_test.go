@@ -0,0 +1,52 @@
+package core
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeInt64s(t *testing.T) {
+	// Little-endian signed 3-byte integers: -2 and 0x123456.
+	i24 := &DatatypeMessage{Class: DatatypeFixed, Size: 3, ClassBitField: 0x08}
+	got, err := DecodeInt64s([]byte{0xFE, 0xFF, 0xFF, 0x56, 0x34, 0x12}, i24, 2)
+	require.NoError(t, err)
+	require.Equal(t, []int64{-2, 0x123456}, got)
+
+	// Big-endian signed 8-byte integers keep values above 2^53 exact.
+	i64be := &DatatypeMessage{Class: DatatypeFixed, Size: 8, ClassBitField: 0x09}
+	got, err = DecodeInt64s([]byte{0x00, 0x20, 0, 0, 0, 0, 0, 0x01, 0x80, 0, 0, 0, 0, 0, 0, 0}, i64be, 2)
+	require.NoError(t, err)
+	require.Equal(t, []int64{1<<53 + 1, math.MinInt64}, got)
+
+	u64 := &DatatypeMessage{Class: DatatypeFixed, Size: 8}
+	_, err = DecodeInt64s([]byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}, u64, 1)
+	require.ErrorContains(t, err, "overflows int64")
+
+	_, err = DecodeInt64s(make([]byte, 16), &DatatypeMessage{Class: DatatypeFixed, Size: 16}, 1)
+	require.ErrorContains(t, err, "unsupported integer size 16")
+	_, err = DecodeInt64s(make([]byte, 8), &DatatypeMessage{Class: DatatypeFloat, Size: 8}, 1)
+	require.ErrorContains(t, err, "not an integer")
+	_, err = DecodeInt64s(make([]byte, 4), u64, 1)
+	require.ErrorContains(t, err, "truncated")
+}
+
+func TestDecodeUint64s(t *testing.T) {
+	u64 := &DatatypeMessage{Class: DatatypeFixed, Size: 8}
+	got, err := DecodeUint64s([]byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 2, 0, 0, 0, 0, 0, 0, 0}, u64, 2)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{math.MaxUint64, 2}, got)
+
+	u16be := &DatatypeMessage{Class: DatatypeFixed, Size: 2, ClassBitField: 0x01}
+	got, err = DecodeUint64s([]byte{0xAB, 0xCD}, u16be, 1)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{0xABCD}, got)
+
+	i8 := &DatatypeMessage{Class: DatatypeFixed, Size: 1, ClassBitField: 0x08}
+	got, err = DecodeUint64s([]byte{0x7F}, i8, 1)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{127}, got)
+	_, err = DecodeUint64s([]byte{0xFF}, i8, 1)
+	require.ErrorContains(t, err, "negative value -1")
+}
@@ -1,39 +1,59 @@
 package core
 
 import (
+	"encoding/binary"
 	"fmt"
 	"io"
+	"math/bits"
 )
 
-// ReadDenseHeapObjects walks a v2 B-tree whose leaf records reference managed
-// objects in a fractal heap (the layout used by both dense attribute storage,
-// btree record type 8, and dense link storage, btree record type 5) and
-// returns the raw heap object bytes in btree leaf order.
+// v2 B-tree record types of dense link and attribute storage.
 //
-// The two record types share the same on-disk shape: 4 bytes of name hash
-// followed by a 7-byte heap ID. Differentiation between attribute and link
-// content happens at the heap-object decoding step (the caller passes the
-// bytes to ParseAttributeMessage or structures.ParseLinkMessage accordingly).
+// Reference: H5B2private.h - H5B2_subid_t.
+const (
+	BTreeV2LinkNameRecord  = 5 // Link name hash and heap ID
+	BTreeV2LinkOrderRecord = 6 // Link creation order and heap ID
+	BTreeV2AttrNameRecord  = 8 // Attribute heap ID, flags, creation order and name hash
+	BTreeV2AttrOrderRecord = 9 // Attribute heap ID, flags and creation order
+)
+
+// denseHeapIDSize is the size of the heap IDs stored in dense storage
+// records. Attribute records reserve 8 bytes, of which managed IDs use 7.
+const denseHeapIDSize = 7
+
+// fractalHeapMaxDepth bounds the nesting of indirect blocks followed when
+// locating a heap object. Each level at least doubles the block size, so a
+// 64-bit heap never needs more.
+const fractalHeapMaxDepth = 64
+
+// ReadDenseHeapObjects walks a v2 B-tree whose records reference managed
+// objects in a fractal heap (the layout used by both dense attribute storage
+// and dense link storage) and returns the raw heap object bytes in B-tree
+// order. Records are collected from internal nodes as well as leaves, and
+// objects are located through the indirect blocks of the heap's doubling
+// table when the heap has outgrown its root direct block.
 //
-// Current limitations (sufficient for the MET Norway NORDRAD layout and any
-// group / attribute set ≲30 records):
+// The heap ID is taken from each record according to the B-tree type
+// (BTreeV2LinkNameRecord, BTreeV2AttrNameRecord, ...). Differentiation
+// between attribute and link content happens at the heap-object decoding
+// step (the caller passes the bytes to ParseAttributeMessage or
+// structures.ParseLinkMessage accordingly).
 //
-//   - Only depth=0 btrees (root is a leaf). Deeper trees would require
-//     walking internal-node "BTIN" pointers and aren't on the critical path.
-//   - Only managed heap IDs (type bits 0 in heap-ID byte 0). Tiny/huge IDs
-//     aren't used for link/attribute records at the sizes we encounter.
+// Only managed heap IDs are supported; link and attribute messages are
+// stored as tiny or huge objects only when they are a few bytes or larger
+// than the heap's maximum managed object size.
+//
+// Reference: H5B2.c - H5B2_iterate(), H5HFman.c - H5HF__man_op_real(),
+// H5HFdtable.c - H5HF__dtable_lookup().
 func ReadDenseHeapObjects(r io.ReaderAt, btreeAddr, heapAddr uint64, sb *Superblock) ([][]byte, error) {
 	btreeHeader, err := readBTreeV2HeaderRaw(r, btreeAddr, sb)
 	if err != nil {
 		return nil, fmt.Errorf("btree v2 header: %w", err)
 	}
-	if btreeHeader.Depth != 0 {
-		return nil, fmt.Errorf("btree v2 depth %d unsupported (only depth=0 leaf-root)", btreeHeader.Depth)
-	}
 
-	heapIDs, err := readBTreeV2LeafRecords(r, btreeHeader.RootNodeAddr, btreeHeader.NumRecordsRoot, sb)
+	heapIDs, err := readBTreeV2HeapIDs(r, btreeHeader, sb)
 	if err != nil {
-		return nil, fmt.Errorf("btree v2 leaf: %w", err)
+		return nil, err
 	}
 	if len(heapIDs) == 0 {
 		return nil, nil
@@ -50,7 +70,11 @@ func ReadDenseHeapObjects(r io.ReaderAt, btreeAddr, heapAddr uint64, sb *Superbl
 		if err != nil {
 			return nil, fmt.Errorf("heap id %d: %w", i, err)
 		}
-		data, err := readHeapObject(r, heapHeader.RootBlockAddress, off, length, sb, heapHeader)
+		blockAddr, err := locateDirectBlock(r, heapHeader, off, sb)
+		if err != nil {
+			return nil, fmt.Errorf("heap object %d: %w", i, err)
+		}
+		data, err := readHeapObject(r, blockAddr, off, length, sb, heapHeader)
 		if err != nil {
 			return nil, fmt.Errorf("heap object %d: %w", i, err)
 		}
@@ -58,3 +82,186 @@ func ReadDenseHeapObjects(r io.ReaderAt, btreeAddr, heapAddr uint64, sb *Superbl
 	}
 	return out, nil
 }
+
+// readBTreeV2HeapIDs returns the heap IDs of all records of a dense storage
+// v2 B-tree, in key order.
+func readBTreeV2HeapIDs(r io.ReaderAt, header *btreeV2HeaderRaw, sb *Superblock) ([][denseHeapIDSize]byte, error) {
+	var idOffset int
+	switch header.Type {
+	case BTreeV2LinkNameRecord:
+		idOffset = 4
+	case BTreeV2LinkOrderRecord:
+		idOffset = 8
+	case BTreeV2AttrNameRecord, BTreeV2AttrOrderRecord:
+		idOffset = 0
+	default:
+		return nil, fmt.Errorf("unsupported v2 B-tree record type for dense storage: %d", header.Type)
+	}
+	if int(header.RecordSize) < idOffset+denseHeapIDSize {
+		return nil, fmt.Errorf("v2 B-tree record size %d too small for record type %d", header.RecordSize, header.Type)
+	}
+	if header.TotalRecords == 0 || isUndefinedAddress(header.RootNodeAddr, sb.OffsetSize) {
+		return nil, nil
+	}
+	if header.TotalRecords > maxIndexElements {
+		return nil, fmt.Errorf("v2 B-tree too large: %d records", header.TotalRecords)
+	}
+
+	geom, err := NewBTreeV2Geometry(header.NodeSize, int(header.RecordSize), sb.OffsetSize, int(header.Depth))
+	if err != nil {
+		return nil, err
+	}
+	ids := make([][denseHeapIDSize]byte, 0, header.TotalRecords)
+	add := func(record []byte) {
+		var id [denseHeapIDSize]byte
+		copy(id[:], record[idOffset:])
+		ids = append(ids, id)
+	}
+	if err := walkBTreeV2(r, geom, sb, header.RootNodeAddr, uint64(header.NumRecordsRoot), int(header.Depth), add); err != nil {
+		return nil, err
+	}
+	if uint64(len(ids)) != header.TotalRecords {
+		return nil, fmt.Errorf("v2 B-tree holds %d records, header says %d", len(ids), header.TotalRecords)
+	}
+	return ids, nil
+}
+
+// walkBTreeV2 calls add for each record of the v2 B-tree node at addr,
+// holding nrec records at the given depth, and of its subtrees, in key
+// order.
+//
+// Reference: H5B2int.c - H5B2__iterate_node().
+func walkBTreeV2(r io.ReaderAt, geom *BTreeV2Geometry, sb *Superblock, addr, nrec uint64, depth int, add func([]byte)) error {
+	if nrec > geom.Levels[depth].MaxRecords {
+		return fmt.Errorf("v2 B-tree node at 0x%x has %d records, at most %d fit", addr, nrec, geom.Levels[depth].MaxRecords)
+	}
+	n := int(nrec) //nolint:gosec // G115: bounded by the node capacity
+	recordsSize := n * geom.RecordSize
+
+	if depth == 0 {
+		buf, err := readChecksummedBlock(r, addr, 6+recordsSize+4, btreeV2LeafSignature)
+		if err != nil {
+			return fmt.Errorf("btree v2 leaf: %w", err)
+		}
+		for i := 0; i < n; i++ {
+			add(buf[6+i*geom.RecordSize:])
+		}
+		return nil
+	}
+
+	pointerSize := geom.PointerSize(depth)
+	buf, err := readChecksummedBlock(r, addr, 6+recordsSize+(n+1)*pointerSize+4, btreeV2InternalSignature)
+	if err != nil {
+		return fmt.Errorf("btree v2 internal node: %w", err)
+	}
+	pointers := buf[6+recordsSize:]
+	for i := 0; i <= n; i++ {
+		p := pointers[i*pointerSize:]
+		childAddr := readUint64(p, geom.OffsetSize, sb.Endianness)
+		childRecords := readUint64(p[geom.OffsetSize:], geom.NrecSize, binary.LittleEndian)
+		if err := walkBTreeV2(r, geom, sb, childAddr, childRecords, depth-1, add); err != nil {
+			return err
+		}
+		if i < n {
+			add(buf[6+i*geom.RecordSize:])
+		}
+	}
+	return nil
+}
+
+// locateDirectBlock returns the address of the direct block of the heap
+// holding the managed object at heap offset off. The root block is a direct
+// block until the heap outgrows it; it then becomes an indirect block whose
+// doubling table rows hold direct blocks, and for larger heaps, further
+// indirect blocks.
+//
+// Reference: H5HFman.c - H5HF__man_dblock_locate(), H5HFiblock.c.
+func locateDirectBlock(r io.ReaderAt, h *fractalHeapHeaderRaw, off uint64, sb *Superblock) (uint64, error) {
+	if h.CurrentRootRows == 0 {
+		return h.RootBlockAddress, nil
+	}
+	if h.FiltersLen != 0 {
+		return 0, fmt.Errorf("filtered fractal heaps with indirect blocks are not supported")
+	}
+	if h.TableWidth == 0 || h.StartingBlockSize == 0 || h.MaxDirectBlockSize < h.StartingBlockSize {
+		return 0, fmt.Errorf("invalid fractal heap doubling table (width %d, block sizes %d to %d)",
+			h.TableWidth, h.StartingBlockSize, h.MaxDirectBlockSize)
+	}
+
+	// Rows of direct blocks: the first two have the starting size, each
+	// following one doubles it, up to the maximum direct block size.
+	startBits := bits.Len64(h.StartingBlockSize) - 1
+	maxDirectRows := bits.Len64(h.MaxDirectBlockSize) - 1 - startBits + 2
+	width := uint64(h.TableWidth)
+	rowSize := func(row int) uint64 {
+		if row == 0 {
+			return h.StartingBlockSize
+		}
+		return h.StartingBlockSize << (row - 1)
+	}
+
+	addr, nrows, blockOffset := h.RootBlockAddress, int(h.CurrentRootRows), uint64(0)
+	for depth := 0; depth < fractalHeapMaxDepth; depth++ {
+		entries, err := readIndirectBlockEntries(r, h, addr, nrows, sb)
+		if err != nil {
+			return 0, err
+		}
+
+		start := blockOffset
+		found := false
+		for row := 0; row < nrows && !found; row++ {
+			size := rowSize(row)
+			if off >= start+size*width {
+				start += size * width
+				continue
+			}
+			col := (off - start) / size
+			child := entries[uint64(row)*width+col]
+			if isUndefinedAddress(child, sb.OffsetSize) || child == 0 {
+				return 0, fmt.Errorf("heap offset 0x%x falls in an unallocated block", off)
+			}
+			if row < maxDirectRows {
+				return child, nil
+			}
+			// A child indirect block covering this row's block size:
+			// enough rows to span it, starting from the starting size.
+			addr = child
+			blockOffset = start + col*size
+			nrows = bits.Len64(size) - 1 - startBits - (bits.Len64(width) - 1) + 1
+			found = true
+		}
+		if !found {
+			return 0, fmt.Errorf("heap offset 0x%x beyond indirect block at 0x%x", off, addr)
+		}
+	}
+	return 0, fmt.Errorf("fractal heap indirect blocks nested deeper than %d levels", fractalHeapMaxDepth)
+}
+
+// readIndirectBlockEntries reads the child block addresses of the indirect
+// block at addr, which has nrows rows. The addresses are returned row by
+// row; in an unfiltered heap, direct and indirect block entries are both
+// plain addresses.
+//
+// Format (Section III.G of HDF5 spec):
+//   - Signature "FHIB" (4 bytes)
+//   - Version (1 byte)
+//   - Heap Header Address (offsetSize bytes)
+//   - Block Offset (heapOffsetSize bytes)
+//   - Child Block Addresses (offsetSize bytes each, nrows × table width)
+//   - Checksum (4 bytes)
+func readIndirectBlockEntries(r io.ReaderAt, h *fractalHeapHeaderRaw, addr uint64, nrows int, sb *Superblock) ([]uint64, error) {
+	if nrows <= 0 || nrows > 64 {
+		return nil, fmt.Errorf("invalid fractal heap indirect block row count: %d", nrows)
+	}
+	count := nrows * int(h.TableWidth)
+	prefix := 5 + int(sb.OffsetSize) + int(h.HeapOffsetSize)
+	buf, err := readChecksummedBlock(r, addr, prefix+count*int(sb.OffsetSize)+4, "FHIB")
+	if err != nil {
+		return nil, fmt.Errorf("fractal heap indirect block: %w", err)
+	}
+	entries := make([]uint64, count)
+	for i := range entries {
+		entries[i] = readUint64(buf[prefix+i*int(sb.OffsetSize):], int(sb.OffsetSize), sb.Endianness)
+	}
+	return entries, nil
+}
@@ -0,0 +1,80 @@
+package core
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/scigolib/hdf5/internal/utils"
+)
+
+// Driver identifications of the file drivers that record driver
+// information in the superblock.
+const (
+	DriverFamily = "NCSAfami" // Family driver: one logical file split into members of fixed size
+	DriverMulti  = "NCSAmult" // Multi and split drivers: one member file per kind of data
+)
+
+// driverInfoHeaderSize is the size of the driver information block header:
+// version, reserved bytes, information size and driver identification.
+const driverInfoHeaderSize = 16
+
+// DriverInfo is the driver information block of a version 0 or 1
+// superblock. It names the file driver that created the file and holds the
+// driver-specific information needed to open it again.
+type DriverInfo struct {
+	Name string // Driver identification, e.g. DriverFamily
+	Data []byte // Driver-specific information
+}
+
+// ReadDriverInfo reads the driver information block of the superblock.
+// It returns nil when the file has none, which is the case for files
+// written by the default driver.
+//
+// Reference: H5Fsuper_cache.c - H5F__cache_drvrinfo_deserialize(),
+// HDF5 File Format Specification, section II.B "Driver Information Block".
+func ReadDriverInfo(r io.ReaderAt, sb *Superblock) (*DriverInfo, error) {
+	if sb.DriverInfo == 0 || sb.DriverInfo == ^uint64(0) {
+		return nil, nil
+	}
+
+	header := make([]byte, driverInfoHeaderSize)
+	if _, err := r.ReadAt(header, int64(sb.DriverInfo)); err != nil { //nolint:gosec // G115: file addresses fit in int64
+		return nil, utils.WrapError("driver info block read failed", err)
+	}
+	if header[0] != 0 {
+		return nil, fmt.Errorf("unsupported driver info block version: %d", header[0])
+	}
+	size := binary.LittleEndian.Uint32(header[4:8])
+	if size > 1<<16 {
+		return nil, fmt.Errorf("driver info size %d too large", size)
+	}
+
+	info := &DriverInfo{
+		Name: string(header[8:16]),
+		Data: make([]byte, size),
+	}
+	if _, err := r.ReadAt(info.Data, int64(sb.DriverInfo)+driverInfoHeaderSize); err != nil { //nolint:gosec // G115: file addresses fit in int64
+		return nil, utils.WrapError("driver info read failed", err)
+	}
+	return info, nil
+}
+
+// FamilyMemberSize returns the size of the member files of a file created
+// by the family driver.
+//
+// Reference: H5FDfamily.c - H5FD__family_sb_decode().
+func (d *DriverInfo) FamilyMemberSize() (uint64, error) {
+	if d.Name != DriverFamily {
+		return 0, fmt.Errorf("driver %q is not the family driver", d.Name)
+	}
+	if len(d.Data) < 8 {
+		return 0, errors.New("family driver info too short")
+	}
+	size := binary.LittleEndian.Uint64(d.Data[:8])
+	if size == 0 {
+		return 0, errors.New("family member size is zero")
+	}
+	return size, nil
+}
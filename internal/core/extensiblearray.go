@@ -0,0 +1,332 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+)
+
+// Extensible array signatures.
+//
+// Reference: H5EApkg.h.
+const (
+	extensibleArrayHeaderSignature = "EAHD"
+	extensibleArrayIndexSignature  = "EAIB"
+	extensibleArraySuperSignature  = "EASB"
+	extensibleArrayDataSignature   = "EADB"
+)
+
+// extensibleArrayHeader is the parsed header of an extensible array chunk index.
+type extensibleArrayHeader struct {
+	class         uint8  // arrayClassChunk or arrayClassFilteredChunk.
+	elemSize      int    // Encoded size of one element.
+	maxBits       uint8  // log2 of the maximum number of elements.
+	idxBlkElmts   int    // Elements stored directly in the index block.
+	dblkMinElmts  int    // Elements in the smallest data block.
+	sblkMinPtrs   int    // Data block pointers in the smallest super block.
+	pageBits      uint8  // log2 of elements per data block page.
+	maxIdxSet     uint64 // One past the highest element ever set.
+	idxBlkAddr    uint64 // Index block address.
+	chunkSize     int    // Width of the chunk size field of filtered elements.
+	arrOffSize    int    // Width of block offsets within the array.
+	superBlocks   []extensibleArraySuper
+	dblkPageElmts int // Elements per data block page.
+}
+
+// extensibleArraySuper describes the data blocks of one super block level.
+//
+// Reference: H5EAhdr.c - H5EA__hdr_init().
+type extensibleArraySuper struct {
+	ndblks     int    // Number of data blocks.
+	dblkElmts  int    // Elements per data block.
+	startIdx   uint64 // First element index (after the index block elements).
+	startDblk  int    // Index of the first data block among all data blocks.
+	dblkNpages int    // Pages per data block, 0 when not paged.
+}
+
+// extensibleArrayChunks reads an extensible array chunk index, used by HDF5
+// 1.10+ for chunked datasets with a single unlimited dimension. Element i
+// holds the chunk with row-major index i after moving the unlimited dimension
+// to the front.
+//
+// Reference: H5Dearray.c, H5EAcache.c - H5EA__cache_hdr_deserialize(),
+// H5EA__cache_iblock_deserialize(), H5EA__cache_sblock_deserialize(),
+// H5EA__cache_dblock_deserialize(), H5EA__cache_dblk_page_deserialize().
+func extensibleArrayChunks(r io.ReaderAt, layout *DataLayoutMessage, dataspace *DataspaceMessage, sb *Superblock) ([]ChunkEntry, error) {
+	unlimDim := -1
+	for d, maxDim := range maxDimensions(dataspace) {
+		if maxDim == unlimitedDim {
+			unlimDim = d
+			break
+		}
+	}
+	if unlimDim < 0 {
+		return nil, errors.New("extensible array index requires an unlimited dimension")
+	}
+
+	hdr, err := readExtensibleArrayHeader(r, layout.DataAddress, sb)
+	if err != nil {
+		return nil, err
+	}
+	if isUndefinedAddress(hdr.idxBlkAddr, sb.OffsetSize) || hdr.maxIdxSet == 0 {
+		return nil, nil
+	}
+
+	// The library numbers chunks over the current chunk counts of the fixed
+	// dimensions (unlike fixed arrays, which use the maximum counts).
+	grid := newChunkGrid(layout.ChunkSize, dataspace.Dimensions, unlimDim)
+	var chunks []ChunkEntry
+	visit := func(idx uint64, raw []byte) {
+		entry, ok := decodeChunkElement(raw, hdr.class, hdr.chunkSize, layout, sb)
+		if !ok {
+			return
+		}
+		entry.Key.Scaled = grid.scaled(idx)
+		chunks = append(chunks, entry)
+	}
+
+	if err := hdr.walk(r, sb, visit); err != nil {
+		return nil, err
+	}
+	return chunks, nil
+}
+
+// readExtensibleArrayHeader parses the extensible array header at addr.
+func readExtensibleArrayHeader(r io.ReaderAt, addr uint64, sb *Superblock) (*extensibleArrayHeader, error) {
+	// Signature, version, class, six creation parameters, six statistics,
+	// index block address, checksum.
+	size := 4 + 1 + 1 + 6 + 6*int(sb.LengthSize) + int(sb.OffsetSize) + 4
+	buf, err := readChecksummedBlock(r, addr, size, extensibleArrayHeaderSignature)
+	if err != nil {
+		return nil, err
+	}
+	if buf[4] != 0 {
+		return nil, fmt.Errorf("unsupported extensible array header version: %d", buf[4])
+	}
+
+	hdr := &extensibleArrayHeader{
+		class:        buf[5],
+		elemSize:     int(buf[6]),
+		maxBits:      buf[7],
+		idxBlkElmts:  int(buf[8]),
+		dblkMinElmts: int(buf[9]),
+		sblkMinPtrs:  int(buf[10]),
+		pageBits:     buf[11],
+	}
+
+	// Statistics: super blocks, super block size, data blocks, data block
+	// size, max index set, element count. Only max index set is needed.
+	offset := 12 + 4*int(sb.LengthSize)
+	hdr.maxIdxSet = readUint64(buf[offset:], int(sb.LengthSize), sb.Endianness)
+	offset += 2 * int(sb.LengthSize)
+	hdr.idxBlkAddr = readUint64(buf[offset:], int(sb.OffsetSize), sb.Endianness)
+
+	if hdr.chunkSize, err = chunkElementSizeField(hdr.class, hdr.elemSize, sb); err != nil {
+		return nil, err
+	}
+	if err := hdr.init(); err != nil {
+		return nil, err
+	}
+	return hdr, nil
+}
+
+// init validates the creation parameters and computes the super block table.
+//
+// Reference: H5EAhdr.c - H5EA__hdr_init().
+func (hdr *extensibleArrayHeader) init() error {
+	if hdr.maxBits == 0 || hdr.maxBits > 64 || hdr.pageBits >= 32 {
+		return fmt.Errorf("invalid extensible array parameters: max bits %d, page bits %d", hdr.maxBits, hdr.pageBits)
+	}
+	if !isPowerOfTwo(hdr.dblkMinElmts) || !isPowerOfTwo(hdr.sblkMinPtrs) {
+		return fmt.Errorf("invalid extensible array block sizes: %d elements, %d pointers", hdr.dblkMinElmts, hdr.sblkMinPtrs)
+	}
+	minBits := bits.TrailingZeros(uint(hdr.dblkMinElmts))
+	if int(hdr.maxBits) < minBits {
+		return fmt.Errorf("extensible array max bits %d below data block size", hdr.maxBits)
+	}
+	if hdr.maxIdxSet > maxIndexElements {
+		return fmt.Errorf("extensible array too large: %d elements", hdr.maxIdxSet)
+	}
+
+	hdr.arrOffSize = (int(hdr.maxBits) + 7) / 8
+	hdr.dblkPageElmts = 1 << hdr.pageBits
+
+	nsblks := 1 + int(hdr.maxBits) - minBits
+	hdr.superBlocks = make([]extensibleArraySuper, nsblks)
+	startIdx := uint64(0)
+	startDblk := 0
+	for u := range hdr.superBlocks {
+		s := &hdr.superBlocks[u]
+		s.ndblks = 1 << (u / 2)
+		s.dblkElmts = (1 << ((u + 1) / 2)) * hdr.dblkMinElmts
+		s.startIdx = startIdx
+		s.startDblk = startDblk
+		if s.dblkElmts > hdr.dblkPageElmts {
+			s.dblkNpages = s.dblkElmts / hdr.dblkPageElmts
+		}
+		startIdx += uint64(s.ndblks) * uint64(s.dblkElmts) //nolint:gosec // G115: bounded by maxBits
+		startDblk += s.ndblks
+	}
+	return nil
+}
+
+// walk visits every element below maxIdxSet stored in the index block and
+// the allocated data blocks, in index order.
+func (hdr *extensibleArrayHeader) walk(r io.ReaderAt, sb *Superblock, visit func(idx uint64, raw []byte)) error {
+	// The index block holds the first elements, the data block addresses of
+	// the first super block levels and the super block addresses of the rest.
+	iblkSblks := 2 * bits.TrailingZeros(uint(hdr.sblkMinPtrs))
+	iblkSblks = min(iblkSblks, len(hdr.superBlocks))
+	ndblkAddrs := 2 * (hdr.sblkMinPtrs - 1)
+	nsblkAddrs := len(hdr.superBlocks) - iblkSblks
+
+	offsetSize := int(sb.OffsetSize)
+	prefix := 4 + 1 + 1 + offsetSize
+	size := prefix + hdr.idxBlkElmts*hdr.elemSize + (ndblkAddrs+nsblkAddrs)*offsetSize + 4
+	buf, err := readChecksummedBlock(r, hdr.idxBlkAddr, size, extensibleArrayIndexSignature)
+	if err != nil {
+		return fmt.Errorf("index block: %w", err)
+	}
+
+	offset := prefix
+	for i := 0; i < hdr.idxBlkElmts; i++ {
+		if uint64(i) < hdr.maxIdxSet {
+			visit(uint64(i), buf[offset:offset+hdr.elemSize])
+		}
+		offset += hdr.elemSize
+	}
+	dblkAddrs := make([]uint64, ndblkAddrs)
+	for i := range dblkAddrs {
+		dblkAddrs[i] = readUint64(buf[offset:], offsetSize, sb.Endianness)
+		offset += offsetSize
+	}
+	sblkAddrs := make([]uint64, nsblkAddrs)
+	for i := range sblkAddrs {
+		sblkAddrs[i] = readUint64(buf[offset:], offsetSize, sb.Endianness)
+		offset += offsetSize
+	}
+
+	base := uint64(hdr.idxBlkElmts) //nolint:gosec // G115: single byte value
+	for u, s := range hdr.superBlocks {
+		first := base + s.startIdx
+		if first >= hdr.maxIdxSet {
+			break
+		}
+
+		var (
+			addrs    []uint64
+			pageInit []byte
+		)
+		if u < iblkSblks {
+			end := min(s.startDblk+s.ndblks, len(dblkAddrs))
+			addrs = dblkAddrs[s.startDblk:end]
+		} else {
+			sblkAddr := sblkAddrs[u-iblkSblks]
+			if isUndefinedAddress(sblkAddr, sb.OffsetSize) {
+				continue
+			}
+			if addrs, pageInit, err = hdr.readSuperBlock(r, sblkAddr, s, sb); err != nil {
+				return fmt.Errorf("super block %d: %w", u, err)
+			}
+		}
+
+		for d, dblkAddr := range addrs {
+			if isUndefinedAddress(dblkAddr, sb.OffsetSize) {
+				continue
+			}
+			start := first + uint64(d)*uint64(s.dblkElmts) //nolint:gosec // G115: bounded by maxBits
+			if start >= hdr.maxIdxSet {
+				break
+			}
+			if err := hdr.readDataBlock(r, dblkAddr, s, d, pageInit, start, sb, visit); err != nil {
+				return fmt.Errorf("data block at 0x%x: %w", dblkAddr, err)
+			}
+		}
+	}
+	return nil
+}
+
+// readSuperBlock returns the data block addresses of a super block and, for
+// paged data blocks, the page-initialized bitmap.
+func (hdr *extensibleArrayHeader) readSuperBlock(r io.ReaderAt, addr uint64, s extensibleArraySuper, sb *Superblock) ([]uint64, []byte, error) {
+	offsetSize := int(sb.OffsetSize)
+	bitmapSize := 0
+	if s.dblkNpages > 0 {
+		bitmapSize = s.ndblks * ((s.dblkNpages + 7) / 8)
+	}
+
+	prefix := 4 + 1 + 1 + offsetSize + hdr.arrOffSize
+	size := prefix + bitmapSize + s.ndblks*offsetSize + 4
+	buf, err := readChecksummedBlock(r, addr, size, extensibleArraySuperSignature)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pageInit := buf[prefix : prefix+bitmapSize]
+	offset := prefix + bitmapSize
+	addrs := make([]uint64, s.ndblks)
+	for i := range addrs {
+		addrs[i] = readUint64(buf[offset:], offsetSize, sb.Endianness)
+		offset += offsetSize
+	}
+	return addrs, pageInit, nil
+}
+
+// readDataBlock visits the elements of data block d of super block level s,
+// whose first element has index start. Unpaged blocks store the elements
+// inline; paged blocks are followed by one checksummed page per
+// dblkPageElmts elements, skipped when pageInit marks them uninitialized.
+func (hdr *extensibleArrayHeader) readDataBlock(r io.ReaderAt, addr uint64, s extensibleArraySuper, d int,
+	pageInit []byte, start uint64, sb *Superblock, visit func(idx uint64, raw []byte)) error {
+	prefix := 4 + 1 + 1 + int(sb.OffsetSize) + hdr.arrOffSize
+
+	if s.dblkNpages == 0 {
+		buf, err := readChecksummedBlock(r, addr, prefix+s.dblkElmts*hdr.elemSize+4, extensibleArrayDataSignature)
+		if err != nil {
+			return err
+		}
+		hdr.visitElements(buf[prefix:], s.dblkElmts, start, visit)
+		return nil
+	}
+
+	if _, err := readChecksummedBlock(r, addr, prefix+4, extensibleArrayDataSignature); err != nil {
+		return err
+	}
+	pageSize := hdr.dblkPageElmts*hdr.elemSize + 4
+	for page := 0; page < s.dblkNpages; page++ {
+		pageStart := start + uint64(page*hdr.dblkPageElmts) //nolint:gosec // G115: bounded by maxBits
+		if pageStart >= hdr.maxIdxSet {
+			break
+		}
+		// Data blocks addressed from the index block have no bitmap.
+		if len(pageInit) > 0 && !bitIsSet(pageInit, d*s.dblkNpages+page) {
+			continue
+		}
+
+		pageAddr := addr + uint64(prefix+4+page*pageSize) //nolint:gosec // G115: bounded by maxBits
+		buf, err := readChecksummedBlock(r, pageAddr, pageSize, "")
+		if err != nil {
+			return fmt.Errorf("page %d: %w", page, err)
+		}
+		hdr.visitElements(buf, hdr.dblkPageElmts, pageStart, visit)
+	}
+	return nil
+}
+
+// visitElements calls visit for each of the count elements in buf whose
+// index, counted from start, is below maxIdxSet.
+func (hdr *extensibleArrayHeader) visitElements(buf []byte, count int, start uint64, visit func(idx uint64, raw []byte)) {
+	for i := 0; i < count; i++ {
+		idx := start + uint64(i) //nolint:gosec // G115: non-negative loop index
+		if idx >= hdr.maxIdxSet {
+			return
+		}
+		visit(idx, buf[i*hdr.elemSize:(i+1)*hdr.elemSize])
+	}
+}
+
+// isPowerOfTwo reports whether n is a positive power of two.
+func isPowerOfTwo(n int) bool {
+	return n > 0 && n&(n-1) == 0
+}
@@ -0,0 +1,102 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ExternalFileEntry is one slot of an External Data Files message: a segment
+// of the dataset's raw data stored in another file.
+type ExternalFileEntry struct {
+	NameOffset uint64 // Offset of the file name in the local heap
+	Offset     uint64 // Byte offset of the segment within the external file
+	Size       uint64 // Size of the segment in bytes
+}
+
+// ExternalFileListMessage represents the External Data Files message
+// (HDF5 message type 0x0007). The dataset's raw data is stored in the listed
+// files, one segment after another, instead of in the HDF5 file.
+//
+// Format:
+//   - Version (1 byte): Always 1
+//   - Reserved (3 bytes)
+//   - Allocated Slots (2 bytes)
+//   - Used Slots (2 bytes)
+//   - Heap Address (offsetSize bytes): Local heap holding the file names
+//   - Slots (Used Slots entries): Name Offset, Offset and Size
+//     (lengthSize bytes each)
+//
+// Reference: HDF5 Format Spec Section IV.A.2.h (External Data Files Message).
+// C Reference: H5Oefl.c - H5O__efl_decode(), H5O__efl_encode().
+type ExternalFileListMessage struct {
+	HeapAddress uint64
+	Entries     []ExternalFileEntry
+}
+
+// ParseExternalFileListMessage parses an External Data Files message.
+//
+// Reference: H5Oefl.c - H5O__efl_decode().
+func ParseExternalFileListMessage(data []byte, sb *Superblock) (*ExternalFileListMessage, error) {
+	offsetSize := int(sb.OffsetSize)
+	lengthSize := int(sb.LengthSize)
+	if len(data) < 8+offsetSize {
+		return nil, errors.New("external file list message too short")
+	}
+	if data[0] != 1 {
+		return nil, fmt.Errorf("unsupported external file list version: %d", data[0])
+	}
+
+	allocated := int(sb.Endianness.Uint16(data[4:6]))
+	used := int(sb.Endianness.Uint16(data[6:8]))
+	if used > allocated {
+		return nil, fmt.Errorf("external file list uses %d of %d slots", used, allocated)
+	}
+
+	msg := &ExternalFileListMessage{
+		HeapAddress: readUint64(data[8:], offsetSize, sb.Endianness),
+		Entries:     make([]ExternalFileEntry, used),
+	}
+	offset := 8 + offsetSize
+	if len(data) < offset+used*3*lengthSize {
+		return nil, fmt.Errorf("external file list message truncated (%d slots)", used)
+	}
+	for i := range msg.Entries {
+		msg.Entries[i] = ExternalFileEntry{
+			NameOffset: readUint64(data[offset:], lengthSize, sb.Endianness),
+			Offset:     readUint64(data[offset+lengthSize:], lengthSize, sb.Endianness),
+			Size:       readUint64(data[offset+2*lengthSize:], lengthSize, sb.Endianness),
+		}
+		offset += 3 * lengthSize
+	}
+
+	return msg, nil
+}
+
+// EncodeExternalFileListMessage encodes an External Data Files message with
+// one allocated slot per entry.
+//
+// Reference: H5Oefl.c - H5O__efl_encode().
+func EncodeExternalFileListMessage(msg *ExternalFileListMessage, sb *Superblock) ([]byte, error) {
+	if len(msg.Entries) == 0 || len(msg.Entries) > 0xFFFF {
+		return nil, fmt.Errorf("external file list needs 1 to 65535 entries, got %d", len(msg.Entries))
+	}
+
+	offsetSize := int(sb.OffsetSize)
+	lengthSize := int(sb.LengthSize)
+	buf := make([]byte, 8+offsetSize+len(msg.Entries)*3*lengthSize)
+
+	buf[0] = 1                                                  // Version
+	sb.Endianness.PutUint16(buf[4:6], uint16(len(msg.Entries))) //nolint:gosec // G115: checked above
+	sb.Endianness.PutUint16(buf[6:8], uint16(len(msg.Entries))) //nolint:gosec // G115: checked above
+	writeUint64(buf[8:], msg.HeapAddress, offsetSize, sb.Endianness)
+
+	offset := 8 + offsetSize
+	for _, e := range msg.Entries {
+		writeUint64(buf[offset:], e.NameOffset, lengthSize, sb.Endianness)
+		writeUint64(buf[offset+lengthSize:], e.Offset, lengthSize, sb.Endianness)
+		writeUint64(buf[offset+2*lengthSize:], e.Size, lengthSize, sb.Endianness)
+		offset += 3 * lengthSize
+	}
+
+	return buf, nil
+}
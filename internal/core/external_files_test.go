@@ -0,0 +1,37 @@
+package core
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExternalFileListMessage_RoundTrip(t *testing.T) {
+	sb := &Superblock{OffsetSize: 8, LengthSize: 8, Endianness: binary.LittleEndian}
+	msg := &ExternalFileListMessage{
+		HeapAddress: 0x400,
+		Entries: []ExternalFileEntry{
+			{NameOffset: 8, Offset: 0, Size: 1000},
+			{NameOffset: 16, Offset: 512, Size: 24},
+		},
+	}
+
+	data, err := EncodeExternalFileListMessage(msg, sb)
+	require.NoError(t, err)
+	require.Len(t, data, 8+8+2*3*8)
+
+	parsed, err := ParseExternalFileListMessage(data, sb)
+	require.NoError(t, err)
+	require.Equal(t, msg, parsed)
+
+	_, err = ParseExternalFileListMessage(data[:len(data)-1], sb)
+	require.ErrorContains(t, err, "truncated")
+
+	data[0] = 2
+	_, err = ParseExternalFileListMessage(data, sb)
+	require.ErrorContains(t, err, "unsupported external file list version")
+
+	_, err = EncodeExternalFileListMessage(&ExternalFileListMessage{}, sb)
+	require.Error(t, err)
+}
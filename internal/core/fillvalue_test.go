@@ -0,0 +1,54 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFillValueMessage_RoundTrip(t *testing.T) {
+	enc, err := EncodeFillValueMessage(AllocTimeEarly, FillTimeNever, nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte{3, 0x05}, enc)
+
+	msg, err := ParseFillValueMessage(enc)
+	require.NoError(t, err)
+	require.Equal(t, AllocTimeEarly, msg.AllocTime)
+	require.Equal(t, FillTimeNever, msg.FillTime)
+	require.True(t, msg.Defined)
+	require.Empty(t, msg.Value)
+
+	enc, err = EncodeFillValueMessage(AllocTimeIncremental, FillTimeIfSet, []byte{1, 2, 3, 4})
+	require.NoError(t, err)
+	msg, err = ParseFillValueMessage(enc)
+	require.NoError(t, err)
+	require.Equal(t, AllocTimeIncremental, msg.AllocTime)
+	require.Equal(t, FillTimeIfSet, msg.FillTime)
+	require.Equal(t, []byte{1, 2, 3, 4}, msg.Value)
+}
+
+func TestParseFillValueMessage_Versions(t *testing.T) {
+	// Version 2, value defined: size 2, value 0x0102.
+	msg, err := ParseFillValueMessage([]byte{2, AllocTimeLate, FillTimeAlloc, 1, 2, 0, 0, 0, 0x02, 0x01})
+	require.NoError(t, err)
+	require.Equal(t, AllocTimeLate, msg.AllocTime)
+	require.Equal(t, FillTimeAlloc, msg.FillTime)
+	require.Equal(t, []byte{0x02, 0x01}, msg.Value)
+
+	// Version 2, undefined: no size field.
+	msg, err = ParseFillValueMessage([]byte{2, AllocTimeIncremental, FillTimeIfSet, 0})
+	require.NoError(t, err)
+	require.False(t, msg.Defined)
+
+	// Version 3 with the undefined flag.
+	msg, err = ParseFillValueMessage([]byte{3, 0x10 | AllocTimeLate})
+	require.NoError(t, err)
+	require.False(t, msg.Defined)
+
+	_, err = ParseFillValueMessage([]byte{3, 0x20, 8, 0, 0, 0, 1})
+	require.ErrorContains(t, err, "truncated")
+	_, err = ParseFillValueMessage([]byte{9, 0})
+	require.ErrorContains(t, err, "unsupported")
+	_, err = EncodeFillValueMessage(0, FillTimeIfSet, nil)
+	require.ErrorContains(t, err, "invalid allocation time")
+}
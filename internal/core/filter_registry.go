@@ -0,0 +1,55 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// FilterFunc transforms chunk data for a registered filter. clientData holds
+// the filter parameters (cd_values) stored in the filter pipeline message.
+type FilterFunc func(data []byte, clientData []uint32) ([]byte, error)
+
+// RegisteredFilter is a third-party filter added with RegisterFilter.
+type RegisteredFilter struct {
+	ID     FilterID
+	Name   string
+	Decode FilterFunc // Read path (decompression); nil if the filter is write-only.
+	Encode FilterFunc // Write path (compression); nil if the filter is read-only.
+}
+
+// filterRegistry holds the registered third-party filters by ID.
+var filterRegistry = struct {
+	sync.RWMutex
+	filters map[FilterID]*RegisteredFilter
+}{filters: make(map[FilterID]*RegisteredFilter)}
+
+// RegisterFilter adds or replaces a third-party filter. Filters implemented
+// by this package (deflate, shuffle, Fletcher32, BZIP2, LZF, Zstd) cannot be
+// replaced.
+//
+// Reference: H5Z.c - H5Zregister().
+func RegisterFilter(f RegisteredFilter) error {
+	if f.ID == 0 {
+		return errors.New("filter ID 0 is reserved")
+	}
+	if (Filter{ID: f.ID}).builtin() {
+		return fmt.Errorf("filter %d (%s) is built in and cannot be replaced", f.ID, filterName(f.ID))
+	}
+	if f.Decode == nil && f.Encode == nil {
+		return fmt.Errorf("filter %d: no decode or encode function", f.ID)
+	}
+
+	filterRegistry.Lock()
+	defer filterRegistry.Unlock()
+	filterRegistry.filters[f.ID] = &f
+	return nil
+}
+
+// LookupFilter returns the registered third-party filter with the given ID.
+func LookupFilter(id FilterID) (*RegisteredFilter, bool) {
+	filterRegistry.RLock()
+	defer filterRegistry.RUnlock()
+	f, ok := filterRegistry.filters[id]
+	return f, ok
+}
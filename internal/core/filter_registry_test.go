@@ -0,0 +1,41 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// xorFilter flips every byte with the first client data value.
+func xorFilter(data []byte, clientData []uint32) ([]byte, error) {
+	out := make([]byte, len(data))
+	for i, b := range data {
+		out[i] = b ^ byte(clientData[0])
+	}
+	return out, nil
+}
+
+func TestRegisterFilter(t *testing.T) {
+	const id = FilterID(40001)
+	t.Cleanup(func() {
+		filterRegistry.Lock()
+		delete(filterRegistry.filters, id)
+		filterRegistry.Unlock()
+	})
+
+	require.False(t, Filter{ID: id}.Supported())
+	require.ErrorContains(t, RegisterFilter(RegisteredFilter{ID: FilterDeflate, Decode: xorFilter}), "built in")
+	require.ErrorContains(t, RegisterFilter(RegisteredFilter{ID: 0, Decode: xorFilter}), "reserved")
+	require.ErrorContains(t, RegisterFilter(RegisteredFilter{ID: id}), "no decode or encode")
+
+	require.NoError(t, RegisterFilter(RegisteredFilter{ID: id, Name: "xor", Decode: xorFilter}))
+	require.True(t, Filter{ID: id}.Supported())
+	require.Equal(t, "xor", filterName(id))
+
+	pipeline := &FilterPipelineMessage{Filters: []Filter{
+		{ID: id, ClientData: []uint32{0x5A}},
+	}}
+	got, err := pipeline.ApplyFilters([]byte{0x5A ^ 'h', 0x5A ^ 'i'})
+	require.NoError(t, err)
+	require.Equal(t, []byte("hi"), got)
+}
@@ -1,7 +1,9 @@
 package core
 
 import (
+	"bytes"
 	"compress/bzip2"
+	"compress/flate"
 	"compress/zlib"
 	"encoding/binary"
 	"errors"
@@ -22,6 +24,7 @@ const (
 	FilterScaleOffset FilterID = 6     // Scale-offset filter.
 	FilterBZIP2       FilterID = 307   // BZIP2 compression.
 	FilterLZF         FilterID = 32000 // LZF compression (PyTables/h5py).
+	FilterZstd        FilterID = 32015 // Zstandard compression (hdf5plugin), decode only.
 )
 
 // Human-readable filter labels. Extracted as constants so goconst doesn't
@@ -36,8 +39,47 @@ type FilterPipelineMessage struct {
 	Version    uint8
 	NumFilters uint8
 	Filters    []Filter
+
+	// SkipChecksums disables checksum verification on read. Checksums are
+	// still stripped from the data; mismatches are silently ignored.
+	SkipChecksums bool
+}
+
+// ChecksumError reports a data chunk whose stored checksum does not match its contents.
+// The chunk location is filled in by the chunk reader (nil when unknown).
+type ChecksumError struct {
+	Filter   string   // Name of the checksum filter ("Fletcher32")
+	Chunk    []uint64 // Scaled chunk coordinates (chunk indices per dimension)
+	Address  uint64   // File address of the chunk
+	Stored   uint32   // Checksum stored with the data
+	Computed uint32   // Checksum computed from the data
+}
+
+// Error implements the error interface.
+func (e *ChecksumError) Error() string {
+	if e.Chunk != nil {
+		return fmt.Sprintf("%s checksum mismatch in chunk %v at 0x%x: stored=%08x, computed=%08x",
+			e.Filter, e.Chunk, e.Address, e.Stored, e.Computed)
+	}
+	return fmt.Sprintf("%s checksum mismatch: stored=%08x, computed=%08x", e.Filter, e.Stored, e.Computed)
+}
+
+// UnsupportedFilterError reports a mandatory filter that this package cannot
+// decode, such as a third-party compressor.
+type UnsupportedFilterError struct {
+	ID   FilterID // Filter identifier from the pipeline message
+	Name string   // Name stored in the pipeline message, or the built-in filter name
 }
 
+// Error implements the error interface.
+func (e *UnsupportedFilterError) Error() string {
+	return fmt.Sprintf("unsupported filter ID %d (%s)", e.ID, e.Name)
+}
+
+// filterFlagOptional marks a filter whose failure does not fail the I/O
+// (H5Z_FLAG_OPTIONAL).
+const filterFlagOptional = 0x0001
+
 // Filter represents a single filter in the pipeline.
 type Filter struct {
 	ID            FilterID
@@ -48,7 +90,47 @@ type Filter struct {
 	ClientData    []uint32
 }
 
+// Optional reports whether the filter is marked optional. Chunks are read
+// without an optional filter that cannot be applied.
+func (f Filter) Optional() bool {
+	return f.Flags&filterFlagOptional != 0
+}
+
+// Supported reports whether this package can decode the filter, either
+// natively or through a filter added with RegisterFilter.
+func (f Filter) Supported() bool {
+	if f.builtin() {
+		return true
+	}
+	rf, ok := LookupFilter(f.ID)
+	return ok && rf.Decode != nil
+}
+
+// builtin reports whether this package decodes the filter natively.
+func (f Filter) builtin() bool {
+	switch f.ID {
+	case FilterDeflate, FilterShuffle, FilterFletcher, FilterBZIP2, FilterLZF, FilterZstd:
+		return true
+	default:
+		return false
+	}
+}
+
+// DisplayName returns the name stored with the filter, or the built-in name.
+func (f Filter) DisplayName() string {
+	if f.Name != "" {
+		return f.Name
+	}
+	return filterName(f.ID)
+}
+
+// filterReservedID is the first filter ID outside the range reserved for
+// filters defined by the HDF5 library (H5Z_FILTER_RESERVED).
+const filterReservedID FilterID = 256
+
 // ParseFilterPipelineMessage parses filter pipeline message (type 0x000B).
+//
+// Reference: H5Opline.c - H5O__pline_decode().
 func ParseFilterPipelineMessage(data []byte) (*FilterPipelineMessage, error) {
 	if len(data) < 2 {
 		return nil, errors.New("filter pipeline message too short")
@@ -77,7 +159,7 @@ func ParseFilterPipelineMessage(data []byte) (*FilterPipelineMessage, error) {
 
 	// Parse each filter.
 	for i := uint8(0); i < numFilters; i++ {
-		if offset+8 > len(data) {
+		if offset+2 > len(data) {
 			return nil, fmt.Errorf("filter pipeline truncated at filter %d", i)
 		}
 
@@ -87,9 +169,19 @@ func ParseFilterPipelineMessage(data []byte) (*FilterPipelineMessage, error) {
 		filter.ID = FilterID(binary.LittleEndian.Uint16(data[offset : offset+2]))
 		offset += 2
 
-		// Name length (2 bytes) - for version 1, optional.
+		// Name length (2 bytes). Version 2 omits it for the library's own
+		// filters (IDs below 256); third-party filters still carry it.
+		hasNameLength := version == 1 || filter.ID >= filterReservedID
+		headerSize := 4
+		if hasNameLength {
+			headerSize += 2
+		}
+		if offset+headerSize > len(data) {
+			return nil, fmt.Errorf("filter pipeline truncated at filter %d", i)
+		}
+
 		var nameLength uint16
-		if version == 1 {
+		if hasNameLength {
 			nameLength = binary.LittleEndian.Uint16(data[offset : offset+2])
 			offset += 2
 		}
@@ -103,11 +195,11 @@ func ParseFilterPipelineMessage(data []byte) (*FilterPipelineMessage, error) {
 		filter.NumClientData = binary.LittleEndian.Uint16(data[offset : offset+2])
 		offset += 2
 
-		// Filter name (variable length, only in version 1).
-		if version == 1 && nameLength > 0 {
-			// Name is null-terminated and padded to 8-byte boundary.
+		// Filter name (variable length).
+		if nameLength > 0 {
+			// Name is null-terminated; version 1 pads it to an 8-byte boundary.
 			padded := nameLength
-			if padded%8 != 0 {
+			if version == 1 && padded%8 != 0 {
 				padded += 8 - (padded % 8)
 			}
 
@@ -159,6 +251,21 @@ func ParseFilterPipelineMessage(data []byte) (*FilterPipelineMessage, error) {
 
 // ApplyFilters applies filter pipeline to decompress/decode chunk data.
 func (fp *FilterPipelineMessage) ApplyFilters(data []byte) ([]byte, error) {
+	return fp.ApplyFiltersMasked(data, 0)
+}
+
+// ApplyFiltersMasked applies the filter pipeline to a chunk, skipping the
+// filters whose bit is set in the chunk's filter mask (bit i excludes
+// filter i). The mask is recorded in the chunk index for filters that were
+// not applied when the chunk was written.
+//
+// A filter marked optional that cannot be applied is skipped and the data is
+// passed on unmodified. A mandatory filter that this package does not
+// implement, and for which no decoder was registered with RegisterFilter,
+// fails with *UnsupportedFilterError.
+//
+// Reference: H5Z.c - H5Z_pipeline().
+func (fp *FilterPipelineMessage) ApplyFiltersMasked(data []byte, mask uint32) ([]byte, error) {
 	if fp == nil || len(fp.Filters) == 0 {
 		return data, nil
 	}
@@ -166,22 +273,29 @@ func (fp *FilterPipelineMessage) ApplyFilters(data []byte) ([]byte, error) {
 	// Filters are applied in REVERSE order during decompression.
 	// (they were applied forward during compression).
 	result := data
-	var err error
-
 	for i := len(fp.Filters) - 1; i >= 0; i-- {
 		filter := fp.Filters[i]
+		if i < 32 && mask&(1<<i) != 0 {
+			continue
+		}
 
-		// Skip optional filters if they fail.
-		isOptional := (filter.Flags & 0x0001) != 0
-
-		result, err = applyFilter(filter, result)
+		out, err := applyFilter(filter, result, !fp.SkipChecksums)
 		if err != nil {
-			if isOptional {
-				// Optional filter - log and continue.
+			var csErr *ChecksumError
+			if errors.As(err, &csErr) {
+				return nil, err
+			}
+			if filter.Optional() {
+				// Optional filter that cannot be applied: pass the data on unmodified.
 				continue
 			}
-			return nil, fmt.Errorf("filter %d (%s) failed: %w", filter.ID, filterName(filter.ID), err)
+			var unsupported *UnsupportedFilterError
+			if errors.As(err, &unsupported) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("filter %d (%s) failed: %w", filter.ID, filter.DisplayName(), err)
 		}
+		result = out
 
 		// LZF filter: ensure output matches expected size from cd_values[2].
 		// The HDF5 LZF filter stores the expected uncompressed chunk size in cd_values[2].
@@ -201,8 +315,15 @@ func (fp *FilterPipelineMessage) ApplyFilters(data []byte) ([]byte, error) {
 	return result, nil
 }
 
-// applyFilter applies a single filter.
-func applyFilter(filter Filter, data []byte) ([]byte, error) {
+// applyFilter applies a single filter. Checksum filters verify the data when verify is set.
+// Filters without a native implementation are looked up in the RegisterFilter registry.
+func applyFilter(filter Filter, data []byte, verify bool) ([]byte, error) {
+	if !filter.builtin() {
+		if rf, ok := LookupFilter(filter.ID); ok && rf.Decode != nil {
+			return rf.Decode(data, filter.ClientData)
+		}
+	}
+
 	switch filter.ID {
 	case FilterDeflate:
 		return applyDeflate(data)
@@ -211,8 +332,8 @@ func applyFilter(filter Filter, data []byte) ([]byte, error) {
 		return applyShuffle(data, filter.ClientData)
 
 	case FilterFletcher:
-		// Fletcher32 is a checksum - just verify and strip it.
-		return applyFletcher32(data)
+		// Fletcher32 is a checksum - verify and strip it.
+		return applyFletcher32(data, verify)
 
 	case FilterBZIP2:
 		return applyBZIP2(data)
@@ -230,17 +351,34 @@ func applyFilter(filter Filter, data []byte) ([]byte, error) {
 		}
 		return applyLZF(data)
 
+	case FilterZstd:
+		// cd_values[0] holds the compression level, which decoding does not need.
+		return applyZstd(data)
+
 	case FilterSZIP:
 		return applySZIP(data)
 
 	default:
-		return nil, fmt.Errorf("unsupported filter ID: %d", filter.ID)
+		return nil, &UnsupportedFilterError{ID: filter.ID, Name: filter.DisplayName()}
 	}
 }
 
 // applyDeflate decompresses GZIP/deflate compressed data.
-// HDF5 uses raw deflate (zlib), not gzip format.
+// HDF5 stores deflate data with a zlib header (RFC 1950), not gzip framing.
+// Some non-standard writers omit the header and store raw deflate streams
+// (RFC 1951); those are detected and decoded with compress/flate.
 func applyDeflate(data []byte) ([]byte, error) {
+	if !hasZlibHeader(data) {
+		reader := flate.NewReader(bytes.NewReader(data))
+		defer func() { _ = reader.Close() }()
+
+		decompressed, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, fmt.Errorf("raw deflate decompression failed: %w", err)
+		}
+		return decompressed, nil
+	}
+
 	reader, err := zlib.NewReader(io.NopCloser(io.NewSectionReader(
 		&bytesReaderAt{data}, 0, int64(len(data)))))
 	if err != nil {
@@ -257,6 +395,19 @@ func applyDeflate(data []byte) ([]byte, error) {
 	return decompressed, nil
 }
 
+// hasZlibHeader reports whether data starts with a zlib header: the deflate
+// compression method with a window of at most 32 KiB, and a check value
+// making the first two bytes a multiple of 31.
+//
+// Reference: RFC 1950 section 2.2.
+func hasZlibHeader(data []byte) bool {
+	if len(data) < 2 {
+		return false
+	}
+	cmf, flg := data[0], data[1]
+	return cmf&0x0F == 8 && cmf>>4 <= 7 && (uint16(cmf)<<8|uint16(flg))%31 == 0
+}
+
 // applyShuffle reverses shuffle filter.
 // Shuffle reorders bytes to improve compression.
 func applyShuffle(data []byte, clientData []uint32) ([]byte, error) {
@@ -289,20 +440,29 @@ func applyShuffle(data []byte, clientData []uint32) ([]byte, error) {
 	return result, nil
 }
 
-// applyFletcher32 verifies and strips Fletcher32 checksum.
-func applyFletcher32(data []byte) ([]byte, error) {
+// applyFletcher32 verifies and strips the Fletcher32 checksum appended to the data.
+//
+// The checksum is stored little-endian. Files written by HDF5 before 1.6.3 stored
+// it with the bytes of each 16-bit half swapped; that form is accepted too.
+//
+// Reference: H5Zfletcher32.c - H5Z__filter_fletcher32().
+func applyFletcher32(data []byte, verify bool) ([]byte, error) {
 	if len(data) < 4 {
 		return nil, errors.New("data too short for Fletcher32 checksum")
 	}
 
-	// Fletcher32 checksum is appended at the end (4 bytes).
-	// Checksum verification deferred to v0.11.0-RC (feature-complete release).
-	// Current implementation strips checksum without validation.
-	// In practice, file system and HDF5 library corruption is extremely rare.
-	// For production use, consider external file integrity checks (SHA256, etc.).
-	// Reference: https://docs.hdfgroup.org/hdf5/latest/group___h5_z.html
-	// Target version: v0.11.0-RC (comprehensive data integrity features)
-	return data[:len(data)-4], nil
+	payload := data[:len(data)-4]
+	if !verify {
+		return payload, nil
+	}
+
+	stored := binary.LittleEndian.Uint32(data[len(data)-4:])
+	computed := Fletcher32Checksum(payload)
+	reversed := (computed&0x00ff00ff)<<8 | (computed>>8)&0x00ff00ff
+	if stored != computed && stored != reversed {
+		return nil, &ChecksumError{Filter: "Fletcher32", Stored: stored, Computed: computed}
+	}
+	return payload, nil
 }
 
 // applyBZIP2 decompresses BZIP2-compressed data.
@@ -335,6 +495,16 @@ func applyLZF(data []byte) ([]byte, error) {
 	return decompressed, nil
 }
 
+// applyZstd decompresses Zstandard-compressed data.
+// Zstd is the default compressor of many recent pipelines (hdf5plugin, Blosc2).
+func applyZstd(data []byte) ([]byte, error) {
+	decompressed, err := zstdDecompress(data)
+	if err != nil {
+		return nil, fmt.Errorf("zstd decompression failed: %w", err)
+	}
+	return decompressed, nil
+}
+
 // applySZIP decompresses SZIP-compressed data.
 // SZIP uses extended Golomb-Rice coding (CCSDS 121.0-B-3 standard).
 // This algorithm is commonly used for satellite imagery and scientific data.
@@ -439,6 +609,8 @@ func filterName(id FilterID) string {
 		return "BZIP2"
 	case FilterLZF:
 		return "LZF"
+	case FilterZstd:
+		return "Zstd"
 	case FilterSZIP:
 		return filterSZIPName
 	case FilterNBit:
@@ -446,6 +618,9 @@ func filterName(id FilterID) string {
 	case FilterScaleOffset:
 		return "Scale-Offset"
 	default:
+		if rf, ok := LookupFilter(id); ok && rf.Name != "" {
+			return rf.Name
+		}
 		return fmt.Sprintf("Unknown-%d", id)
 	}
 }
@@ -215,3 +215,37 @@ func TestParseFilterPipelineMessage_Version1WithName(t *testing.T) {
 	require.Equal(t, uint16(10), filter.NameLength)
 	require.Equal(t, []uint32{999}, filter.ClientData)
 }
+
+// TestParseFilterPipelineMessage_Version2ThirdPartyName checks that version 2
+// keeps the name length field for filters with IDs of 256 and up, so the
+// client data that follows is read from the right offset.
+func TestParseFilterPipelineMessage_Version2ThirdPartyName(t *testing.T) {
+	data := []byte{2, 2} // version 2, 2 filters
+
+	// Shuffle (ID < 256): no name length field.
+	data = binary.LittleEndian.AppendUint16(data, uint16(FilterShuffle))
+	data = binary.LittleEndian.AppendUint16(data, 0) // flags
+	data = binary.LittleEndian.AppendUint16(data, 1) // 1 client data
+	data = binary.LittleEndian.AppendUint32(data, 8)
+
+	// LZF (ID 32000): name length and unpadded name.
+	data = binary.LittleEndian.AppendUint16(data, uint16(FilterLZF))
+	data = binary.LittleEndian.AppendUint16(data, 4) // name length
+	data = binary.LittleEndian.AppendUint16(data, 1) // flags: optional
+	data = binary.LittleEndian.AppendUint16(data, 3) // 3 client data
+	data = append(data, "lzf\x00"...)
+	for _, v := range []uint32{4, 261, 4096} {
+		data = binary.LittleEndian.AppendUint32(data, v)
+	}
+
+	got, err := ParseFilterPipelineMessage(data)
+	require.NoError(t, err)
+	require.Len(t, got.Filters, 2)
+	require.Equal(t, []uint32{8}, got.Filters[0].ClientData)
+	require.Equal(t, FilterLZF, got.Filters[1].ID)
+	require.Equal(t, "lzf", got.Filters[1].Name)
+	require.Equal(t, []uint32{4, 261, 4096}, got.Filters[1].ClientData)
+
+	_, err = ParseFilterPipelineMessage(data[:len(data)-1])
+	require.Error(t, err)
+}
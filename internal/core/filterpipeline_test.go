@@ -2,6 +2,7 @@ package core
 
 import (
 	"bytes"
+	"compress/flate"
 	"compress/zlib"
 	"testing"
 
@@ -34,6 +35,12 @@ func TestApplyDeflate(t *testing.T) {
 			want:    bytes.Repeat([]byte("test"), 1000),
 			wantErr: false,
 		},
+		{
+			name:    "raw deflate without zlib header",
+			input:   flateCompress(t, bytes.Repeat([]byte("raw"), 100)),
+			want:    bytes.Repeat([]byte("raw"), 100),
+			wantErr: false,
+		},
 		{
 			name:    "invalid compressed data",
 			input:   []byte{0x00, 0x01, 0x02, 0x03},
@@ -141,30 +148,56 @@ func TestApplyFletcher32(t *testing.T) {
 	tests := []struct {
 		name    string
 		data    []byte
+		verify  bool
 		want    []byte
 		wantErr bool
 	}{
 		{
 			name:    "valid data with checksum",
-			data:    []byte{0x01, 0x02, 0x03, 0x04, 0xAA, 0xBB, 0xCC, 0xDD},
+			data:    []byte{0x01, 0x02, 0x03, 0x04, 0x06, 0x04, 0x08, 0x05},
+			verify:  true,
+			want:    []byte{0x01, 0x02, 0x03, 0x04},
+			wantErr: false,
+		},
+		{
+			name:    "byte-swapped checksum (HDF5 < 1.6.3)",
+			data:    []byte{0x01, 0x02, 0x03, 0x04, 0x04, 0x06, 0x05, 0x08},
+			verify:  true,
 			want:    []byte{0x01, 0x02, 0x03, 0x04},
 			wantErr: false,
 		},
 		{
 			name:    "minimum size (4 bytes)",
-			data:    []byte{0xAA, 0xBB, 0xCC, 0xDD},
+			data:    []byte{0x00, 0x00, 0x00, 0x00},
+			verify:  true,
 			want:    []byte{},
 			wantErr: false,
 		},
+		{
+			name:    "checksum mismatch",
+			data:    []byte{0x01, 0x02, 0x03, 0x04, 0xAA, 0xBB, 0xCC, 0xDD},
+			verify:  true,
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "checksum mismatch without verification",
+			data:    []byte{0x01, 0x02, 0x03, 0x04, 0xAA, 0xBB, 0xCC, 0xDD},
+			verify:  false,
+			want:    []byte{0x01, 0x02, 0x03, 0x04},
+			wantErr: false,
+		},
 		{
 			name:    "data too short",
 			data:    []byte{0x01, 0x02, 0x03},
+			verify:  true,
 			want:    nil,
 			wantErr: true,
 		},
 		{
 			name:    "empty data",
 			data:    []byte{},
+			verify:  true,
 			want:    nil,
 			wantErr: true,
 		},
@@ -172,7 +205,7 @@ func TestApplyFletcher32(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := applyFletcher32(tt.data)
+			got, err := applyFletcher32(tt.data, tt.verify)
 			if tt.wantErr {
 				require.Error(t, err)
 				return
@@ -183,6 +216,22 @@ func TestApplyFletcher32(t *testing.T) {
 	}
 }
 
+// TestApplyFletcher32_ChecksumError verifies that mismatches are reported as *ChecksumError.
+func TestApplyFletcher32_ChecksumError(t *testing.T) {
+	pipeline := &FilterPipelineMessage{Filters: []Filter{{ID: FilterFletcher, Flags: 0x0001}}}
+
+	_, err := pipeline.ApplyFilters([]byte{0x01, 0x02, 0x03, 0x04, 0xAA, 0xBB, 0xCC, 0xDD})
+	var csErr *ChecksumError
+	require.ErrorAs(t, err, &csErr, "mismatch must not be skipped even for optional filters")
+	require.Equal(t, uint32(0xDDCCBBAA), csErr.Stored)
+	require.Equal(t, uint32(0x05080406), csErr.Computed)
+
+	pipeline.SkipChecksums = true
+	got, err := pipeline.ApplyFilters([]byte{0x01, 0x02, 0x03, 0x04, 0xAA, 0xBB, 0xCC, 0xDD})
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x01, 0x02, 0x03, 0x04}, got)
+}
+
 // TestApplyFilter tests individual filter application.
 func TestApplyFilter(t *testing.T) {
 	tests := []struct {
@@ -216,7 +265,7 @@ func TestApplyFilter(t *testing.T) {
 			filter: Filter{
 				ID: FilterFletcher,
 			},
-			data:    []byte{0x01, 0x02, 0x03, 0x04, 0xAA, 0xBB, 0xCC, 0xDD},
+			data:    []byte{0x01, 0x02, 0x03, 0x04, 0x06, 0x04, 0x08, 0x05},
 			want:    []byte{0x01, 0x02, 0x03, 0x04},
 			wantErr: false,
 		},
@@ -242,7 +291,7 @@ func TestApplyFilter(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := applyFilter(tt.filter, tt.data)
+			got, err := applyFilter(tt.filter, tt.data, true)
 			if tt.wantErr {
 				require.Error(t, err)
 				return
@@ -329,6 +378,27 @@ func TestFilterPipelineApplyFilters(t *testing.T) {
 	}
 }
 
+// TestFilterPipelineApplyFiltersMasked tests that filters excluded by a
+// chunk's filter mask are skipped.
+func TestFilterPipelineApplyFiltersMasked(t *testing.T) {
+	pipeline := &FilterPipelineMessage{
+		Filters: []Filter{
+			{ID: FilterShuffle, ClientData: []uint32{2}},
+			{ID: FilterDeflate},
+		},
+	}
+
+	// Deflate (filter 1) skipped: only unshuffle is applied.
+	got, err := pipeline.ApplyFiltersMasked([]byte{0x01, 0x02, 0xAA, 0xBB}, 0x2)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x01, 0xAA, 0x02, 0xBB}, got)
+
+	// All filters skipped: data is returned unchanged.
+	got, err = pipeline.ApplyFiltersMasked([]byte{0x01, 0x02, 0xAA, 0xBB}, ^uint32(0))
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x01, 0x02, 0xAA, 0xBB}, got)
+}
+
 // TestApplySZIP tests SZIP decompression error handling.
 func TestApplySZIP(t *testing.T) {
 	tests := []struct {
@@ -616,7 +686,7 @@ func TestApplyFilter_BZIP2Dispatch(t *testing.T) {
 		0x38, 0x50, 0x90, 0xE1, 0x6E, 0x65, 0x71,
 	}
 
-	got, err := applyFilter(Filter{ID: FilterBZIP2}, bzip2AAAA)
+	got, err := applyFilter(Filter{ID: FilterBZIP2}, bzip2AAAA, true)
 	require.NoError(t, err)
 	require.Equal(t, []byte("AAAA"), got)
 }
@@ -626,7 +696,7 @@ func TestApplyFilter_LZFDispatch(t *testing.T) {
 	// LZF literal: 0x04 = 5 bytes "hello".
 	lzfData := []byte{0x04, 'h', 'e', 'l', 'l', 'o'}
 
-	got, err := applyFilter(Filter{ID: FilterLZF}, lzfData)
+	got, err := applyFilter(Filter{ID: FilterLZF}, lzfData, true)
 	require.NoError(t, err)
 	require.Equal(t, []byte("hello"), got)
 }
@@ -640,18 +710,55 @@ func TestApplyFilter_LZFUncompressedPassthrough(t *testing.T) {
 		ClientData: []uint32{0, 0, 5}, // cd_values[2] = 5 = len(data)
 	}
 
-	got, err := applyFilter(filter, raw)
+	got, err := applyFilter(filter, raw, true)
 	require.NoError(t, err)
 	require.Equal(t, raw, got)
 }
 
 // TestApplyFilter_UnknownFilter tests that unknown filter IDs produce an error.
 func TestApplyFilter_UnknownFilter(t *testing.T) {
-	_, err := applyFilter(Filter{ID: FilterID(12345)}, []byte{0x01})
+	_, err := applyFilter(Filter{ID: FilterID(12345)}, []byte{0x01}, true)
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "unsupported filter ID")
 }
 
+// TestApplyFiltersUnsupported tests that optional filters that cannot be
+// applied are skipped and mandatory ones fail with *UnsupportedFilterError.
+func TestApplyFiltersUnsupported(t *testing.T) {
+	payload := []byte("unsupported filter payload")
+
+	optional := &FilterPipelineMessage{Filters: []Filter{
+		{ID: FilterDeflate, Flags: filterFlagOptional},
+		{ID: FilterID(32001), Flags: filterFlagOptional, Name: "blosc"},
+	}}
+	got, err := optional.ApplyFilters(zlibCompress(t, payload))
+	require.NoError(t, err)
+	require.Equal(t, payload, got)
+
+	mandatory := &FilterPipelineMessage{Filters: []Filter{
+		{ID: FilterDeflate, Flags: filterFlagOptional},
+		{ID: FilterID(32001), Name: "blosc"},
+	}}
+	_, err = mandatory.ApplyFilters(zlibCompress(t, payload))
+	var unsupported *UnsupportedFilterError
+	require.ErrorAs(t, err, &unsupported)
+	require.Equal(t, FilterID(32001), unsupported.ID)
+	require.Equal(t, "blosc", unsupported.Name)
+	require.Equal(t, "unsupported filter ID 32001 (blosc)", err.Error())
+}
+
+// TestFilterSupportedOptional tests the Filter support and flag helpers.
+func TestFilterSupportedOptional(t *testing.T) {
+	require.True(t, Filter{ID: FilterDeflate}.Supported())
+	require.True(t, Filter{ID: FilterLZF}.Supported())
+	require.False(t, Filter{ID: FilterSZIP}.Supported())
+	require.False(t, Filter{ID: FilterNBit}.Supported())
+	require.False(t, Filter{ID: FilterID(32001)}.Supported())
+
+	require.True(t, Filter{Flags: 0x0001}.Optional())
+	require.False(t, Filter{Flags: 0}.Optional())
+}
+
 // zlibCompress compresses data using zlib (for tests).
 func zlibCompress(t *testing.T, data []byte) []byte {
 	t.Helper()
@@ -663,3 +770,15 @@ func zlibCompress(t *testing.T, data []byte) []byte {
 	require.NoError(t, err)
 	return buf.Bytes()
 }
+
+// flateCompress compresses data as a raw deflate stream, without zlib framing.
+func flateCompress(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := flate.NewWriter(&buf, flate.DefaultCompression)
+	require.NoError(t, err)
+	_, err = w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
@@ -181,6 +181,46 @@ func (gc *GlobalHeapCollection) GetObject(index uint32) (*GlobalHeapObject, erro
 	return nil, fmt.Errorf("object with index %d not found in collection", index)
 }
 
+// globalHeapCache resolves global heap references, reading each collection
+// on first use. Variable-length data can span many collections once one
+// fills up, so references are resolved against the collection they name,
+// and each collection is read only once per dataset or attribute read.
+//
+// Reference: H5HG.c - H5HG_read() (H5HG__protect() per collection).
+type globalHeapCache struct {
+	r           io.ReaderAt
+	offsetSize  int
+	collections map[uint64]*GlobalHeapCollection
+}
+
+// newGlobalHeapCache creates an empty cache reading from r.
+func newGlobalHeapCache(r io.ReaderAt, offsetSize int) *globalHeapCache {
+	return &globalHeapCache{
+		r:           r,
+		offsetSize:  offsetSize,
+		collections: make(map[uint64]*GlobalHeapCollection),
+	}
+}
+
+// object returns the heap object ref points to.
+func (c *globalHeapCache) object(ref *GlobalHeapReference) (*GlobalHeapObject, error) {
+	collection, ok := c.collections[ref.HeapAddress]
+	if !ok {
+		var err error
+		collection, err = ReadGlobalHeapCollection(c.r, ref.HeapAddress, c.offsetSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read global heap collection at 0x%X: %w", ref.HeapAddress, err)
+		}
+		c.collections[ref.HeapAddress] = collection
+	}
+
+	obj, err := collection.GetObject(ref.ObjectIndex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object %d from heap collection at 0x%X: %w", ref.ObjectIndex, ref.HeapAddress, err)
+	}
+	return obj, nil
+}
+
 // ParseGlobalHeapReference parses a global heap reference from raw bytes.
 // Format: heap_address (offsetSize bytes) + object_index (4 bytes).
 func ParseGlobalHeapReference(data []byte, offsetSize int) (*GlobalHeapReference, error) {
@@ -0,0 +1,113 @@
+package core
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// GroupInfoMessage represents the Group Info message (HDF5 message type 0x000A).
+// It accompanies the Link Info message in new-style (link-info) groups and holds
+// the tuning parameters for link storage.
+//
+// Format:
+//   - Version (1 byte): Always 0
+//   - Flags (1 byte): Bit 0 = link phase change values stored, Bit 1 = entry estimates stored
+//   - Maximum Compact Value (2 bytes, optional): Present if bit 0 of flags is set
+//   - Minimum Dense Value (2 bytes, optional): Present if bit 0 of flags is set
+//   - Estimated Number of Entries (2 bytes, optional): Present if bit 1 of flags is set
+//   - Estimated Link Name Length (2 bytes, optional): Present if bit 1 of flags is set
+//
+// When a field is absent the library defaults apply (max compact 8, min dense 6,
+// 4 entries, 8-byte names).
+//
+// Reference: HDF5 Format Spec Section IV.A.2.k (Group Info Message).
+// C Reference: H5Oginfo.c - H5O_ginfo_t structure and encoding/decoding functions.
+type GroupInfoMessage struct {
+	Version uint8 // Message version (always 0)
+	Flags   uint8 // Bit 0: phase change values present, Bit 1: estimates present
+
+	MaxCompact    uint16 // Max links stored compactly before switching to dense storage
+	MinDense      uint16 // Min links in dense storage before switching back to compact
+	EstNumEntries uint16 // Estimated number of entries in the group
+	EstNameLen    uint16 // Estimated length of link names
+}
+
+// Flags for GroupInfoMessage.
+const (
+	GroupInfoStorePhaseChange uint8 = 0x01 // Bit 0: link phase change values stored
+	GroupInfoStoreEstimates   uint8 = 0x02 // Bit 1: entry/name length estimates stored
+)
+
+// ParseGroupInfoMessage parses Group Info message from header message data.
+//
+// Reference: H5Oginfo.c - H5O__ginfo_decode().
+func ParseGroupInfoMessage(data []byte) (*GroupInfoMessage, error) {
+	if len(data) < 2 {
+		return nil, errors.New("group info message too short (need at least 2 bytes for version and flags)")
+	}
+
+	gim := &GroupInfoMessage{Version: data[0], Flags: data[1]}
+	if gim.Version != 0 {
+		return nil, fmt.Errorf("unsupported group info version: %d (only version 0 is supported)", gim.Version)
+	}
+	if gim.Flags&^(GroupInfoStorePhaseChange|GroupInfoStoreEstimates) != 0 {
+		return nil, fmt.Errorf("invalid group info flags: 0x%02X (reserved bits set)", gim.Flags)
+	}
+
+	offset := 2
+	if gim.Flags&GroupInfoStorePhaseChange != 0 {
+		if len(data) < offset+4 {
+			return nil, errors.New("group info message truncated (missing phase change values)")
+		}
+		gim.MaxCompact = binary.LittleEndian.Uint16(data[offset:])
+		gim.MinDense = binary.LittleEndian.Uint16(data[offset+2:])
+		offset += 4
+	}
+	if gim.Flags&GroupInfoStoreEstimates != 0 {
+		if len(data) < offset+4 {
+			return nil, errors.New("group info message truncated (missing entry estimates)")
+		}
+		gim.EstNumEntries = binary.LittleEndian.Uint16(data[offset:])
+		gim.EstNameLen = binary.LittleEndian.Uint16(data[offset+2:])
+	}
+
+	return gim, nil
+}
+
+// EncodeGroupInfoMessage encodes Group Info message for writing.
+// Optional fields are written only when the corresponding flag bit is set.
+//
+// Reference: H5Oginfo.c - H5O__ginfo_encode().
+func EncodeGroupInfoMessage(gim *GroupInfoMessage) ([]byte, error) {
+	if gim == nil {
+		return nil, errors.New("group info message is nil")
+	}
+	if gim.Version != 0 {
+		return nil, fmt.Errorf("unsupported group info version: %d (only version 0 is supported)", gim.Version)
+	}
+
+	size := 2
+	if gim.Flags&GroupInfoStorePhaseChange != 0 {
+		size += 4
+	}
+	if gim.Flags&GroupInfoStoreEstimates != 0 {
+		size += 4
+	}
+
+	buf := make([]byte, size)
+	buf[0] = gim.Version
+	buf[1] = gim.Flags
+	offset := 2
+	if gim.Flags&GroupInfoStorePhaseChange != 0 {
+		binary.LittleEndian.PutUint16(buf[offset:], gim.MaxCompact)
+		binary.LittleEndian.PutUint16(buf[offset+2:], gim.MinDense)
+		offset += 4
+	}
+	if gim.Flags&GroupInfoStoreEstimates != 0 {
+		binary.LittleEndian.PutUint16(buf[offset:], gim.EstNumEntries)
+		binary.LittleEndian.PutUint16(buf[offset+2:], gim.EstNameLen)
+	}
+
+	return buf, nil
+}
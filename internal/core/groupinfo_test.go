@@ -0,0 +1,68 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupInfoMessageRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		msg      GroupInfoMessage
+		wantSize int
+	}{
+		{name: "defaults", msg: GroupInfoMessage{}, wantSize: 2},
+		{
+			name:     "phase change",
+			msg:      GroupInfoMessage{Flags: GroupInfoStorePhaseChange, MaxCompact: 16, MinDense: 12},
+			wantSize: 6,
+		},
+		{
+			name: "all fields",
+			msg: GroupInfoMessage{
+				Flags:      GroupInfoStorePhaseChange | GroupInfoStoreEstimates,
+				MaxCompact: 8, MinDense: 6, EstNumEntries: 100, EstNameLen: 20,
+			},
+			wantSize: 10,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := EncodeGroupInfoMessage(&tt.msg)
+			require.NoError(t, err)
+			assert.Len(t, data, tt.wantSize)
+
+			parsed, err := ParseGroupInfoMessage(data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.msg, *parsed)
+		})
+	}
+}
+
+func TestParseGroupInfoMessage_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr string
+	}{
+		{name: "too short", data: []byte{0}, wantErr: "too short"},
+		{name: "bad version", data: []byte{1, 0}, wantErr: "unsupported group info version"},
+		{name: "reserved flags", data: []byte{0, 0x04}, wantErr: "reserved bits set"},
+		{name: "truncated phase change", data: []byte{0, 0x01, 8}, wantErr: "phase change"},
+		{name: "truncated estimates", data: []byte{0, 0x02, 4, 0}, wantErr: "entry estimates"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseGroupInfoMessage(tt.data)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+
+	_, err := EncodeGroupInfoMessage(nil)
+	require.Error(t, err)
+}
@@ -162,6 +162,65 @@ func encodeChunkedLayout(chunkDims []uint64, btreeAddress uint64, sb *Superblock
 	return buf, nil
 }
 
+// EncodeChunkedLayoutBTreeV2 encodes a chunked layout message (version 4)
+// whose chunks are indexed by a v2 B-tree. HDF5 1.10 and later read it.
+//
+// The index address is the last field of the message, so it can be patched
+// in place at len(message) - OffsetSize once the index is written.
+//
+// Parameters:
+//   - chunkDims: Chunk dimensions (N dimensions)
+//   - elementSize: Size of one datatype element in bytes (stored as last dimension)
+//   - indexAddress: Address of the v2 B-tree header, or the undefined address
+//   - sb: Superblock for encoding parameters
+//
+// Format (version 4):
+//   - Version: 1 byte (4)
+//   - Class: 1 byte (2 for chunked)
+//   - Flags: 1 byte (0)
+//   - Dimensionality: 1 byte (ndims + 1)
+//   - Dimension size encoded length: 1 byte
+//   - Chunk Dimensions: (ndims+1) values of the encoded length
+//   - Chunk index type: 1 byte (5)
+//   - Node size (4), split percent (1), merge percent (1)
+//   - Index address: offsetSize bytes
+//
+// Reference: H5Olayout.c - H5O__layout_encode(), H5Dchunk.c - H5D__chunk_construct().
+func EncodeChunkedLayoutBTreeV2(chunkDims []uint64, elementSize uint32, indexAddress uint64, sb *Superblock) ([]byte, error) {
+	if len(chunkDims) == 0 {
+		return nil, fmt.Errorf("chunk dimensions cannot be empty")
+	}
+	dims := append(append([]uint64(nil), chunkDims...), uint64(elementSize))
+	if len(dims) > 255 {
+		return nil, fmt.Errorf("dimensionality %d exceeds maximum 255", len(dims))
+	}
+
+	// Bytes needed for the largest dimension.
+	maxDim := uint64(0)
+	for i, dim := range dims {
+		if dim > 0xFFFFFFFF {
+			return nil, fmt.Errorf("chunk dimension %d (%d) exceeds uint32 maximum", i, dim)
+		}
+		maxDim = max(maxDim, dim)
+	}
+	encSize := limitEncSize(maxDim)
+
+	buf := make([]byte, 0, 5+len(dims)*encSize+1+6+int(sb.OffsetSize))
+	buf = append(buf, 4, byte(LayoutChunked), 0, byte(len(dims)), byte(encSize))
+	for _, dim := range dims {
+		var enc [8]byte
+		binary.LittleEndian.PutUint64(enc[:], dim)
+		buf = append(buf, enc[:encSize]...)
+	}
+	buf = append(buf, byte(ChunkIndexBTreeV2))
+	buf = binary.LittleEndian.AppendUint32(buf, BTreeV2ChunkNodeSize)
+	buf = append(buf, BTreeV2ChunkSplitPct, BTreeV2ChunkMergePct)
+
+	addr := make([]byte, sb.OffsetSize)
+	writeUint64(addr, indexAddress, int(sb.OffsetSize), sb.Endianness)
+	return append(buf, addr...), nil
+}
+
 // EncodeDatatypeMessage encodes a Datatype message.
 // Supports primitive types: int8-64, uint8-64, float32, float64, and fixed-length strings.
 //
@@ -482,6 +541,20 @@ func EncodeDataspaceMessage(dims, maxDims []uint64) ([]byte, error) {
 	return buf, nil
 }
 
+// EncodeNullDataspaceMessage encodes a Dataspace message for the NULL
+// dataspace, which has no elements. Only version 2 can express it.
+//
+// Format (version 2):
+//   - Version: 1 byte (2)
+//   - Dimensionality: 1 byte (0)
+//   - Flags: 1 byte (0)
+//   - Type: 1 byte (2 = NULL)
+//
+// C Reference: H5Osdspace.c - H5O__sdspace_encode().
+func EncodeNullDataspaceMessage() []byte {
+	return []byte{2, 0, 0, byte(DataspaceNull)}
+}
+
 // EncodeSymbolTableMessage encodes a Symbol Table Message.
 // This message is used in group object headers to point to the symbol table structure.
 //
@@ -535,7 +608,7 @@ func EncodeSymbolTableMessage(btreeAddr, heapAddr uint64, offsetSize, _ int) []b
 //   - Name size: 2 bytes (includes null terminator)
 //   - Datatype size: 2 bytes
 //   - Dataspace size: 2 bytes
-//   - Name encoding: 1 byte (0=ASCII, 1=UTF-8)
+//   - Name encoding: 1 byte (0=ASCII, 1=UTF-8; UTF-8 for non-ASCII names)
 //   - Name: variable (null-terminated)
 //   - Datatype: variable (encoded datatype message)
 //   - Dataspace: variable (encoded dataspace message)
@@ -561,9 +634,14 @@ func EncodeAttributeMessage(name string, datatype *DatatypeMessage, dataspace *D
 		return nil, fmt.Errorf("encode datatype: %w", err)
 	}
 
-	dataspaceBytes, err := EncodeDataspaceMessage(dataspace.Dimensions, dataspace.MaxDims)
-	if err != nil {
-		return nil, fmt.Errorf("encode dataspace: %w", err)
+	var dataspaceBytes []byte
+	if dataspace.Type == DataspaceNull {
+		dataspaceBytes = EncodeNullDataspaceMessage()
+	} else {
+		dataspaceBytes, err = EncodeDataspaceMessage(dataspace.Dimensions, dataspace.MaxDims)
+		if err != nil {
+			return nil, fmt.Errorf("encode dataspace: %w", err)
+		}
 	}
 
 	// Calculate sizes
@@ -600,8 +678,8 @@ func EncodeAttributeMessage(name string, datatype *DatatypeMessage, dataspace *D
 	binary.LittleEndian.PutUint16(buf[offset:offset+2], dataspaceSize)
 	offset += 2
 
-	// Name encoding (0 = ASCII)
-	buf[offset] = 0
+	// Name encoding: UTF-8 when the name has non-ASCII bytes, ASCII otherwise
+	buf[offset] = nameCharset(name)
 	offset++
 
 	// Name (null-terminated)
@@ -626,6 +704,73 @@ func EncodeAttributeMessage(name string, datatype *DatatypeMessage, dataspace *D
 	return buf, nil
 }
 
+// EncodeAttributeMessageV1 encodes an Attribute message in version 1, the
+// format of HDF5 1.6 and earlier: the name, datatype and dataspace are each
+// padded to a multiple of 8 bytes, and the name must be ASCII, since
+// version 1 has no name encoding field. Null dataspaces are not allowed.
+//
+// Reference: H5Oattr.c - H5O__attr_encode().
+func EncodeAttributeMessageV1(name string, datatype *DatatypeMessage, dataspace *DataspaceMessage, data []byte) ([]byte, error) {
+	if name == "" {
+		return nil, fmt.Errorf("attribute name cannot be empty")
+	}
+	if nameCharset(name) != CharsetASCII {
+		return nil, fmt.Errorf("attribute name %q is not ASCII (version 1 attribute messages)", name)
+	}
+	if datatype == nil {
+		return nil, fmt.Errorf("datatype cannot be nil")
+	}
+	if dataspace == nil {
+		return nil, fmt.Errorf("dataspace cannot be nil")
+	}
+	if dataspace.Type == DataspaceNull {
+		return nil, fmt.Errorf("null dataspace requires a version 2 dataspace message")
+	}
+
+	datatypeBytes, err := EncodeDatatypeMessage(datatype)
+	if err != nil {
+		return nil, fmt.Errorf("encode datatype: %w", err)
+	}
+	dataspaceBytes, err := EncodeDataspaceMessage(dataspace.Dimensions, dataspace.MaxDims)
+	if err != nil {
+		return nil, fmt.Errorf("encode dataspace: %w", err)
+	}
+
+	pad8 := func(n int) int { return (n + 7) / 8 * 8 }
+	nameSize := len(name) + 1
+
+	// Header: version(1) + reserved(1) + name_size(2) + dtype_size(2) + dspace_size(2) = 8 bytes
+	buf := make([]byte, 8+pad8(nameSize)+pad8(len(datatypeBytes))+pad8(len(dataspaceBytes))+len(data))
+	buf[0] = 1
+	binary.LittleEndian.PutUint16(buf[2:4], uint16(nameSize))            //nolint:gosec // Safe: name length limited
+	binary.LittleEndian.PutUint16(buf[4:6], uint16(len(datatypeBytes)))  //nolint:gosec // Safe: datatype bytes limited
+	binary.LittleEndian.PutUint16(buf[6:8], uint16(len(dataspaceBytes))) //nolint:gosec // Safe: dataspace bytes limited
+	offset := 8
+
+	copy(buf[offset:], name) // Null terminator and padding already zero
+	offset += pad8(nameSize)
+	copy(buf[offset:], datatypeBytes)
+	offset += pad8(len(datatypeBytes))
+	copy(buf[offset:], dataspaceBytes)
+	offset += pad8(len(dataspaceBytes))
+	copy(buf[offset:], data)
+
+	return buf, nil
+}
+
+// nameCharset returns the character set recorded for an object or attribute
+// name: CharsetUTF8 if it has any non-ASCII byte, CharsetASCII otherwise.
+//
+// Reference: H5Pstrcpl.c - H5Pset_char_encoding().
+func nameCharset(name string) uint8 {
+	for i := 0; i < len(name); i++ {
+		if name[i] >= 0x80 {
+			return CharsetUTF8
+		}
+	}
+	return CharsetASCII
+}
+
 // writeUint64 writes a uint64 value to buffer using variable-sized encoding.
 // This is a helper for encoding addresses and sizes with different byte widths.
 func writeUint64(buf []byte, value uint64, size int, endianness binary.ByteOrder) {
@@ -805,9 +950,8 @@ func EncodeArrayDatatypeMessage(baseType []byte, dims []uint64, arraySize uint32
 //   - Bytes 0-3: Class (4 bits) | Version (4 bits) | NumMembers (16 bits, in classBitField)
 //   - Bytes 4-7: Size (base type size)
 //   - Following: Base type message
-//   - Following: For each member:
-//   - Name (null-terminated, padded to multiple of 8)
-//   - Value (size bytes)
+//   - Following: Member names (null-terminated, not padded in version 3)
+//   - Following: Member values (size bytes each, in name order)
 //
 // Reference: HDF5 spec III.C (Datatype Message - Enum class).
 // C Reference: H5Odtype.c - H5O__dtype_encode_helper() for H5T_ENUM.
@@ -830,19 +974,13 @@ func EncodeEnumDatatypeMessage(baseType []byte, names []string, values []byte, e
 	baseTypeSize := len(baseType)
 
 	// Calculate names + values size
-	namesValuesSize := 0
-	for i, name := range names {
-		// Name: null-terminated, padded to multiple of 8
-		nameLen := len(name) + 1 // include null terminator
-		paddedNameLen := ((nameLen + 7) / 8) * 8
-		namesValuesSize += paddedNameLen
-
-		// Value: enumSize bytes
-		valueOffset := i * int(enumSize)
-		if valueOffset+int(enumSize) > len(values) {
-			return nil, fmt.Errorf("not enough value bytes for member %d", i)
-		}
-		namesValuesSize += int(enumSize)
+	valuesSize := len(names) * int(enumSize)
+	if len(values) < valuesSize {
+		return nil, fmt.Errorf("not enough value bytes: need %d, have %d", valuesSize, len(values))
+	}
+	namesValuesSize := valuesSize
+	for _, name := range names {
+		namesValuesSize += len(name) + 1 // include null terminator
 	}
 
 	messageSize := headerSize + baseTypeSize + namesValuesSize
@@ -863,25 +1001,43 @@ func EncodeEnumDatatypeMessage(baseType []byte, names []string, values []byte, e
 	copy(buf[offset:], baseType)
 	offset += len(baseType)
 
-	// Names and values
-	for i, name := range names {
-		// Name (null-terminated, padded to multiple of 8)
-		nameLen := len(name) + 1
-		paddedNameLen := ((nameLen + 7) / 8) * 8
-
+	// Names (version 3: null-terminated, no padding), then all values
+	for _, name := range names {
 		copy(buf[offset:], name)
-		offset += len(name)
-		buf[offset] = 0 // null terminator
-		offset++
+		offset += len(name) + 1 // null terminator already zero
+	}
+	copy(buf[offset:], values[:valuesSize])
 
-		// Padding (zeros)
-		offset += paddedNameLen - nameLen
+	return buf, nil
+}
 
-		// Value
-		valueOffset := i * int(enumSize)
-		copy(buf[offset:], values[valueOffset:valueOffset+int(enumSize)])
-		offset += int(enumSize)
+// EncodeCompactLayoutMessage encodes a compact Data Layout message (version 3)
+// holding the raw data of the dataset inline.
+//
+// Format (version 3, compact):
+//   - Version: 1 byte (3)
+//   - Class: 1 byte (0 for compact)
+//   - Size: 2 bytes (raw data size)
+//   - Raw Data: Size bytes
+//
+// The whole message must fit in the 16-bit object header message size.
+//
+// Reference: H5Olayout.c - H5O__layout_encode() for compact case.
+func EncodeCompactLayoutMessage(data []byte) ([]byte, error) {
+	if len(data) > MaxCompactDataSize {
+		return nil, fmt.Errorf("compact data size %d exceeds maximum %d", len(data), MaxCompactDataSize)
 	}
 
+	buf := make([]byte, 4+len(data))
+	buf[0] = 3
+	buf[1] = byte(LayoutCompact)
+	binary.LittleEndian.PutUint16(buf[2:], uint16(len(data))) //nolint:gosec // G115: size checked above
+	copy(buf[4:], data)
 	return buf, nil
 }
+
+// MaxCompactDataSize is the largest raw data size of a compact dataset: the
+// 64KB object header message limit less the 4-byte layout message prefix.
+//
+// Reference: H5Dcompact.c - H5D__compact_construct().
+const MaxCompactDataSize = 0xFFFF - 4
@@ -0,0 +1,105 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestObjectHeaderModTime(t *testing.T) {
+	const stamp = 1700000000
+	want := time.Unix(stamp, 0).UTC()
+
+	newMsg := []byte{1, 0, 0, 0, 0x00, 0xF1, 0x53, 0x65} // version 1, 0x6553F100 = 1700000000
+	oldMsg := []byte("20231114221320\x00\x00")           // same instant in the old ASCII format
+
+	tests := []struct {
+		name   string
+		header *ObjectHeader
+		want   time.Time
+		ok     bool
+	}{
+		{
+			name:   "v2 header with stored times",
+			header: &ObjectHeader{Version: 2, Flags: OHDRStoreTimes, ModificationTime: stamp},
+			want:   want,
+			ok:     true,
+		},
+		{
+			name:   "v2 header without stored times",
+			header: &ObjectHeader{Version: 2, ModificationTime: stamp},
+		},
+		{
+			name: "modification time message",
+			header: &ObjectHeader{Version: 1, Messages: []*HeaderMessage{
+				{Type: MsgModTime, Data: newMsg},
+			}},
+			want: want,
+			ok:   true,
+		},
+		{
+			name: "old modification time message",
+			header: &ObjectHeader{Version: 1, Messages: []*HeaderMessage{
+				{Type: MsgModTimeOld, Data: oldMsg},
+			}},
+			want: want,
+			ok:   true,
+		},
+		{
+			name: "new message preferred over old",
+			header: &ObjectHeader{Version: 1, Messages: []*HeaderMessage{
+				{Type: MsgModTimeOld, Data: []byte("19990101000000\x00\x00")},
+				{Type: MsgModTime, Data: newMsg},
+			}},
+			want: want,
+			ok:   true,
+		},
+		{
+			name: "unsupported message version",
+			header: &ObjectHeader{Version: 1, Messages: []*HeaderMessage{
+				{Type: MsgModTime, Data: []byte{2, 0, 0, 0, 0, 0, 0, 0}},
+			}},
+		},
+		{
+			name:   "no times",
+			header: &ObjectHeader{Version: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.header.ModTime()
+			require.Equal(t, tt.ok, ok)
+			require.True(t, tt.want.Equal(got), "got %v, want %v", got, tt.want)
+		})
+	}
+}
+
+func TestParseModTimeMessages_Errors(t *testing.T) {
+	_, err := ParseModTimeMessage([]byte{1, 0, 0})
+	require.Error(t, err)
+
+	_, err = ParseModTimeOldMessage([]byte("2023"))
+	require.Error(t, err)
+
+	_, err = ParseModTimeOldMessage([]byte("2023XX14221320\x00\x00"))
+	require.Error(t, err)
+}
+
+func TestObjectHeaderCreationTime(t *testing.T) {
+	const birth, modified = 1600000000, 1700000000
+
+	header := &ObjectHeader{Version: 2, Flags: OHDRStoreTimes, ModificationTime: modified, BirthTime: birth}
+	got, ok := header.CreationTime()
+	require.True(t, ok)
+	require.True(t, time.Unix(birth, 0).Equal(got), "got %v", got)
+
+	// Without stored times, or in a version 1 header, there is none.
+	_, ok = (&ObjectHeader{Version: 2, BirthTime: birth}).CreationTime()
+	require.False(t, ok)
+	_, ok = (&ObjectHeader{Version: 1, Messages: []*HeaderMessage{
+		{Type: MsgModTime, Data: []byte{1, 0, 0, 0, 0x00, 0xF1, 0x53, 0x65}},
+	}}).CreationTime()
+	require.False(t, ok)
+}
@@ -19,6 +19,20 @@ const (
 	ObjectTypeUnknown
 )
 
+// String returns the name of the object type.
+func (t ObjectType) String() string {
+	switch t {
+	case ObjectTypeGroup:
+		return "group"
+	case ObjectTypeDataset:
+		return "dataset"
+	case ObjectTypeDatatype:
+		return "datatype"
+	default:
+		return "unknown"
+	}
+}
+
 // ObjectHeader represents an HDF5 object header containing metadata messages.
 type ObjectHeader struct {
 	Version    uint8
@@ -33,14 +47,45 @@ type ObjectHeader struct {
 	// For V2 headers: Stored in RefCount message (type 0x0016) if >1.
 	// Default value is 1 (single link). Incremented when hard links are created.
 	ReferenceCount uint32
+
+	// Optional v2 prefix fields. The times are seconds since the UNIX epoch and
+	// are only present when Flags has OHDRStoreTimes; the attribute storage
+	// thresholds are only present when Flags has OHDRAttrStorePhaseChange.
+	AccessTime           uint32
+	ModificationTime     uint32
+	ChangeTime           uint32
+	BirthTime            uint32
+	MaxCompactAttributes uint16
+	MinDenseAttributes   uint16
 }
 
+// Object header v2 status flags (H5Opkg.h).
+const (
+	OHDRChunk0SizeMask       uint8 = 0x03 // Width of the chunk #0 size field (1, 2, 4 or 8 bytes)
+	OHDRAttrCrtOrderTracked  uint8 = 0x04 // Attribute creation order tracked (messages carry a creation index)
+	OHDRAttrCrtOrderIndexed  uint8 = 0x08 // Attribute creation order indexed
+	OHDRAttrStorePhaseChange uint8 = 0x10 // Non-default attribute storage phase change values stored
+	OHDRStoreTimes           uint8 = 0x20 // Access, modification, change and birth times stored
+
+	// ohdrKnownFlags are all flag bits defined by the format.
+	ohdrKnownFlags = OHDRChunk0SizeMask | OHDRAttrCrtOrderTracked | OHDRAttrCrtOrderIndexed |
+		OHDRAttrStorePhaseChange | OHDRStoreTimes
+)
+
 // HeaderMessage represents a single message within an object header.
 type HeaderMessage struct {
 	Type   MessageType
 	Offset uint64
 	Data   []byte
 
+	// Flags are the message flags (constant, shared, ...), preserved so that
+	// rewriting a header does not change how the message data is interpreted.
+	Flags uint8
+
+	// CreationIndex is the message creation order, present only in v2 headers
+	// with OHDRAttrCrtOrderTracked set.
+	CreationIndex uint16
+
 	// FromContinuation is true if this message was read from an OCHK
 	// continuation block rather than the main OHDR chunk. Used by the
 	// write path to avoid rewriting continuation messages into the main header.
@@ -58,13 +103,17 @@ const (
 	MsgDatatype       MessageType = 3
 	MsgFillValueOld   MessageType = 4
 	MsgFillValue      MessageType = 5  // Alias for FillValueOld
+	MsgExternalFiles  MessageType = 7  // External Data Files (0x0007) - raw data stored outside the HDF5 file
 	MsgDataLayout     MessageType = 8  // Corrected: Data Layout is 0x0008
+	MsgGroupInfo      MessageType = 10 // Group Info (0x000A) - link storage thresholds for new-style groups
 	MsgFilterPipeline MessageType = 11 // Filter Pipeline (compression, etc)
 	MsgAttribute      MessageType = 12
 	MsgName           MessageType = 13 // Corrected: Name is 0x000D
 	MsgAttributeInfo  MessageType = 15 // Attribute Info (0x000F) - for dense attribute storage
 	MsgContinuation   MessageType = 16 // Object header continuation (0x0010)
 	MsgSymbolTable    MessageType = 17
+	MsgModTime        MessageType = 18 // Object Modification Time (0x0012) - seconds since the epoch
+	MsgModTimeOld     MessageType = 14 // Object Modification Time, old format (0x000E) - ASCII "YYYYMMDDhhmmss"
 	MsgLinkMessage    MessageType = 6
 	MsgRefCount       MessageType = 22 // Reference Count (0x0016) - for hard links (v2 only)
 )
@@ -72,6 +121,13 @@ const (
 // ReadObjectHeader reads and parses an HDF5 object header from the specified address.
 // It supports both version 1 and version 2 object header formats.
 func ReadObjectHeader(r io.ReaderAt, address uint64, sb *Superblock) (*ObjectHeader, error) {
+	return readObjectHeader(r, address, sb, true)
+}
+
+// readObjectHeader implements ReadObjectHeader. Attributes are parsed only if
+// withAttributes is set, so that resolving the committed datatype of an
+// attribute cannot recurse into the attributes of that datatype.
+func readObjectHeader(r io.ReaderAt, address uint64, sb *Superblock, withAttributes bool) (*ObjectHeader, error) {
 	//nolint:gosec // G115: HDF5 addresses fit in int64 for io.ReaderAt interface
 	offset := int64(address)
 	if offset < 0 {
@@ -121,6 +177,15 @@ func ReadObjectHeader(r io.ReaderAt, address uint64, sb *Superblock) (*ObjectHea
 			return nil, utils.WrapError("v1 header parse failed", err)
 		}
 	case 2:
+		// Unknown bits change the prefix layout in ways we cannot predict;
+		// refuse rather than misparse the messages that follow.
+		// Reference: H5Ocache.c H5O__prefix_deserialize().
+		if header.Flags&^ohdrKnownFlags != 0 {
+			return nil, fmt.Errorf("unknown object header flags 0x%02x", header.Flags&^ohdrKnownFlags)
+		}
+		if err = readV2PrefixFields(r, address, header, isBE); err != nil {
+			return nil, utils.WrapError("v2 header prefix read failed", err)
+		}
 		header.Messages, header.Name, err = parseV2Header(r, address, header.Flags, sb, isBE)
 		if err != nil {
 			return nil, utils.WrapError("v2 header parse failed", err)
@@ -144,6 +209,10 @@ func ReadObjectHeader(r io.ReaderAt, address uint64, sb *Superblock) (*ObjectHea
 		}
 	}
 
+	if !withAttributes {
+		return header, nil
+	}
+
 	// Parse attributes from messages (both compact and dense)
 	attributes, err := ParseAttributesFromMessages(r, header.Messages, sb)
 	if err != nil {
@@ -182,9 +251,6 @@ func determineObjectType(messages []*HeaderMessage) ObjectType {
 }
 
 func parseV2Header(r io.ReaderAt, headerAddr uint64, flags uint8, sb *Superblock, isBE bool) ([]*HeaderMessage, string, error) {
-	var messages []*HeaderMessage
-	var name string
-
 	// Start after signature (4) + version (1) + flags (1) = 6 bytes
 	current := headerAddr + 6
 
@@ -251,61 +317,9 @@ func parseV2Header(r io.ReaderAt, headerAddr uint64, flags uint8, sb *Superblock
 	// The 4-byte Jenkins lookup3 checksum follows immediately after the messages.
 	end := current + chunkSize
 
-	// Determine message header size based on flags.
-	// V2 message format: Type (1) + Size (2) + Flags (1) = 4 bytes
-	// If H5O_HDR_ATTR_CRT_ORDER_TRACKED (bit 2 = 0x04) is set,
-	// there's an additional 2-byte creation index, making it 6 bytes.
-	msgHeaderSize := uint64(4)
-	if flags&0x04 != 0 {
-		msgHeaderSize = 6
-	}
-
-	for current < end {
-		// Always read 6 bytes - enough for either 4-byte or 6-byte header
-		headerBuf := utils.GetBuffer(6)
-		//nolint:gosec // G115: HDF5 addresses fit in int64 for io.ReaderAt interface
-		if _, err := r.ReadAt(headerBuf, int64(current)); err != nil {
-			utils.ReleaseBuffer(headerBuf)
-			return nil, "", utils.WrapError("message header read failed", err)
-		}
-
-		// Type is 1 byte, size is 2 bytes, flags is 1 byte
-		// (optional: creation index is 2 bytes if tracked)
-		msgType := MessageType(headerBuf[0])
-		var msgSize uint16
-		if isBE {
-			msgSize = binary.BigEndian.Uint16(headerBuf[1:3])
-		} else {
-			msgSize = binary.LittleEndian.Uint16(headerBuf[1:3])
-		}
-		msgFlags := headerBuf[3]
-		_ = msgFlags // Unused for now
-		// Creation index at headerBuf[4:6] if tracked - not currently used
-		utils.ReleaseBuffer(headerBuf)
-
-		if msgSize == 0 {
-			current += msgHeaderSize
-			continue
-		}
-
-		data := utils.GetBuffer(int(msgSize))
-		//nolint:gosec // G115: HDF5 addresses fit in int64 for io.ReaderAt interface
-		if _, err := r.ReadAt(data, int64(current+msgHeaderSize)); err != nil {
-			utils.ReleaseBuffer(data)
-			return nil, "", utils.WrapError("message data read failed", err)
-		}
-
-		if msgType == MsgName && len(data) > 1 {
-			name = string(data[1:])
-		}
-
-		messages = append(messages, &HeaderMessage{
-			Type:   msgType,
-			Offset: current,
-			Data:   data,
-		})
-
-		current += msgHeaderSize + uint64(msgSize)
+	messages, name, err := parseV2Messages(r, current, end, flags, isBE)
+	if err != nil {
+		return nil, "", err
 	}
 
 	// Follow continuation messages (type 0x0010) to OCHK blocks.
@@ -370,43 +384,116 @@ func parseV2ContinuationBlock(r io.ReaderAt, blockAddr, blockSize uint64, flags
 	msgStart := blockAddr + 4
 	msgEnd := blockAddr + blockSize - 4
 
-	// Determine message header size (same as main OHDR).
+	return parseV2Messages(r, msgStart, msgEnd, flags, isBE)
+}
+
+// readV2PrefixFields reads the optional fields that follow the flags byte of a
+// v2 object header: the four timestamps and the attribute phase change values.
+func readV2PrefixFields(r io.ReaderAt, headerAddr uint64, header *ObjectHeader, isBE bool) error {
+	var order binary.ByteOrder = binary.LittleEndian
+	if isBE {
+		order = binary.BigEndian
+	}
+
+	// Signature (4) + version (1) + flags (1).
+	current := headerAddr + 6
+
+	if header.Flags&OHDRStoreTimes != 0 {
+		buf := utils.GetBuffer(16)
+		defer utils.ReleaseBuffer(buf)
+		//nolint:gosec // G115: HDF5 addresses fit in int64 for io.ReaderAt interface
+		if _, err := r.ReadAt(buf, int64(current)); err != nil {
+			return utils.WrapError("times read failed", err)
+		}
+		header.AccessTime = order.Uint32(buf[0:4])
+		header.ModificationTime = order.Uint32(buf[4:8])
+		header.ChangeTime = order.Uint32(buf[8:12])
+		header.BirthTime = order.Uint32(buf[12:16])
+		current += 16
+	}
+
+	if header.Flags&OHDRAttrStorePhaseChange != 0 {
+		buf := utils.GetBuffer(4)
+		defer utils.ReleaseBuffer(buf)
+		//nolint:gosec // G115: HDF5 addresses fit in int64 for io.ReaderAt interface
+		if _, err := r.ReadAt(buf, int64(current)); err != nil {
+			return utils.WrapError("attribute phase change read failed", err)
+		}
+		header.MaxCompactAttributes = order.Uint16(buf[0:2])
+		header.MinDenseAttributes = order.Uint16(buf[2:4])
+	}
+
+	return nil
+}
+
+// parseV2Messages parses the messages of a v2 header chunk occupying [start, end).
+//
+// Each message has a 4-byte header: type (1) + size (2) + flags (1), followed
+// by a 2-byte creation index when OHDRAttrCrtOrderTracked is set. Space at the
+// end of the chunk that is too small for a message header is a gap and is
+// skipped. Reference: H5Ocache.c H5O__chunk_deserialize().
+func parseV2Messages(r io.ReaderAt, start, end uint64, flags uint8, isBE bool) ([]*HeaderMessage, string, error) {
 	msgHeaderSize := uint64(4)
-	if flags&0x04 != 0 {
+	if flags&OHDRAttrCrtOrderTracked != 0 {
 		msgHeaderSize = 6
 	}
 
 	var messages []*HeaderMessage
 	var name string
-	current := msgStart
+	current := start
 
-	for current < msgEnd {
-		headerBuf := utils.GetBuffer(6)
+	for current < end && end-current >= msgHeaderSize {
+		headerBuf := utils.GetBuffer(int(msgHeaderSize))
 		//nolint:gosec // G115: HDF5 addresses fit in int64 for io.ReaderAt interface
 		if _, err := r.ReadAt(headerBuf, int64(current)); err != nil {
 			utils.ReleaseBuffer(headerBuf)
-			return nil, "", utils.WrapError("OCHK message header read failed", err)
+			return nil, "", utils.WrapError("message header read failed", err)
 		}
 
 		msgType := MessageType(headerBuf[0])
-		var msgSize uint16
+		var msgSize, creationIndex uint16
 		if isBE {
 			msgSize = binary.BigEndian.Uint16(headerBuf[1:3])
 		} else {
 			msgSize = binary.LittleEndian.Uint16(headerBuf[1:3])
 		}
+		msgFlags := headerBuf[3]
+		if msgHeaderSize == 6 {
+			if isBE {
+				creationIndex = binary.BigEndian.Uint16(headerBuf[4:6])
+			} else {
+				creationIndex = binary.LittleEndian.Uint16(headerBuf[4:6])
+			}
+		}
 		utils.ReleaseBuffer(headerBuf)
 
+		dataStart := current + msgHeaderSize
+		if uint64(msgSize) > end-dataStart {
+			return nil, "", fmt.Errorf("message type %d at 0x%x: size %d overruns chunk end 0x%x",
+				msgType, current, msgSize, end)
+		}
+
 		if msgSize == 0 {
-			current += msgHeaderSize
+			// Empty null messages are free space; other empty messages
+			// still carry meaning through their type and are kept.
+			if msgType != MsgNil {
+				messages = append(messages, &HeaderMessage{
+					Type:          msgType,
+					Offset:        current,
+					Data:          []byte{},
+					Flags:         msgFlags,
+					CreationIndex: creationIndex,
+				})
+			}
+			current = dataStart
 			continue
 		}
 
 		data := utils.GetBuffer(int(msgSize))
 		//nolint:gosec // G115: HDF5 addresses fit in int64 for io.ReaderAt interface
-		if _, err := r.ReadAt(data, int64(current+msgHeaderSize)); err != nil {
+		if _, err := r.ReadAt(data, int64(dataStart)); err != nil {
 			utils.ReleaseBuffer(data)
-			return nil, "", utils.WrapError("OCHK message data read failed", err)
+			return nil, "", utils.WrapError("message data read failed", err)
 		}
 
 		if msgType == MsgName && len(data) > 1 {
@@ -414,12 +501,14 @@ func parseV2ContinuationBlock(r io.ReaderAt, blockAddr, blockSize uint64, flags
 		}
 
 		messages = append(messages, &HeaderMessage{
-			Type:   msgType,
-			Offset: current,
-			Data:   data,
+			Type:          msgType,
+			Offset:        current,
+			Data:          data,
+			Flags:         msgFlags,
+			CreationIndex: creationIndex,
 		})
 
-		current += msgHeaderSize + uint64(msgSize)
+		current = dataStart + uint64(msgSize)
 	}
 
 	return messages, name, nil
@@ -78,10 +78,11 @@ func TestAddMessageToObjectHeader_NilHeader(t *testing.T) {
 	assert.Contains(t, err.Error(), "object header is nil")
 }
 
-// TestAddMessageToObjectHeader_V1NotSupported tests error with v1 header.
-func TestAddMessageToObjectHeader_V1NotSupported(t *testing.T) {
+// TestAddMessageToObjectHeader_UnsupportedVersion tests error with a header
+// version other than 1 or 2.
+func TestAddMessageToObjectHeader_UnsupportedVersion(t *testing.T) {
 	oh := &ObjectHeader{
-		Version:  1, // Version 1 not supported for modification
+		Version:  3,
 		Flags:    0,
 		Type:     ObjectTypeDataset,
 		Messages: []*HeaderMessage{},
@@ -89,7 +90,11 @@ func TestAddMessageToObjectHeader_V1NotSupported(t *testing.T) {
 
 	err := AddMessageToObjectHeader(oh, MsgAttribute, []byte{1, 2, 3})
 	require.Error(t, err)
-	assert.Contains(t, err.Error(), "only object header version 2 is supported")
+	assert.Contains(t, err.Error(), "only object header versions 1 and 2 are supported")
+
+	oh.Version = 1
+	require.NoError(t, AddMessageToObjectHeader(oh, MsgAttribute, []byte{1, 2, 3}))
+	assert.Len(t, oh.Messages, 1)
 }
 
 // TestWriteObjectHeader_Success tests writing an object header.
@@ -143,23 +148,41 @@ func TestWriteObjectHeader_NilHeader(t *testing.T) {
 	assert.Contains(t, err.Error(), "object header is nil")
 }
 
-// TestWriteObjectHeader_V1NotSupported tests error with v1 header.
-func TestWriteObjectHeader_V1NotSupported(t *testing.T) {
+// TestWriteObjectHeader_V1 tests writing a v1 header: the size field covers
+// the aligned messages and the reader parses them back.
+func TestWriteObjectHeader_V1(t *testing.T) {
 	oh := &ObjectHeader{
-		Version:  1,
-		Flags:    0,
-		Messages: []*HeaderMessage{},
+		Version:        1,
+		ReferenceCount: 1,
+		Messages: []*HeaderMessage{
+			{Type: MsgDatatype, Data: []byte{1, 2, 3}},
+			{Type: MsgAttribute, Data: make([]byte, 16), Flags: 0x01},
+		},
 	}
 
 	sb := &Superblock{
 		Endianness: binary.LittleEndian,
+		OffsetSize: 8,
+		LengthSize: 8,
 	}
 
 	writer := newMockWriterAtForModify()
 
 	err := WriteObjectHeader(writer, 48, oh, sb)
-	require.Error(t, err)
-	assert.Contains(t, err.Error(), "only object header version 2 is supported")
+	require.NoError(t, err)
+
+	data := writer.Bytes()
+	assert.Equal(t, byte(1), data[48])
+	assert.Equal(t, uint16(2), binary.LittleEndian.Uint16(data[50:52]))
+	assert.Equal(t, uint32(16+24), binary.LittleEndian.Uint32(data[56:60]))
+	// Message sizes include the padding to 8 bytes.
+	assert.Equal(t, uint16(8), binary.LittleEndian.Uint16(data[66:68]))
+
+	parsed, err := ReadObjectHeader(bytes.NewReader(data), 48, sb)
+	require.NoError(t, err)
+	require.Len(t, parsed.Messages, 2)
+	assert.Equal(t, MsgAttribute, parsed.Messages[1].Type)
+	assert.Equal(t, uint8(0x01), parsed.Messages[1].Flags)
 }
 
 // TestRewriteObjectHeaderV2_Success tests rewriting with new messages.
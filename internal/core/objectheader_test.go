@@ -3,6 +3,7 @@ package core
 import (
 	"bytes"
 	"encoding/binary"
+	"fmt"
 	"os"
 	"testing"
 
@@ -99,3 +100,143 @@ func TestObjectHeaderBoundaryCheck(t *testing.T) {
 	// The actual error is "short read" when buffer is too small
 	require.Contains(t, err.Error(), "read")
 }
+
+// v2TestMessage describes a message for buildV2Header.
+type v2TestMessage struct {
+	typ           MessageType
+	flags         uint8
+	creationIndex uint16
+	data          []byte
+}
+
+// buildV2Header encodes a v2 object header the way the C library lays it out:
+// prefix, optional times and phase change values, chunk #0 size (4 bytes),
+// messages, a trailing gap of gapSize bytes and the checksum.
+func buildV2Header(flags uint8, msgs []v2TestMessage, gapSize int) []byte {
+	flags = flags&^OHDRChunk0SizeMask | 0x02
+
+	var body []byte
+	for _, m := range msgs {
+		body = append(body, byte(m.typ))
+		body = binary.LittleEndian.AppendUint16(body, uint16(len(m.data)))
+		body = append(body, m.flags)
+		if flags&OHDRAttrCrtOrderTracked != 0 {
+			body = binary.LittleEndian.AppendUint16(body, m.creationIndex)
+		}
+		body = append(body, m.data...)
+	}
+	body = append(body, make([]byte, gapSize)...)
+
+	buf := []byte{'O', 'H', 'D', 'R', 2, flags}
+	if flags&OHDRStoreTimes != 0 {
+		for _, t := range []uint32{1700000001, 1700000002, 1700000003, 1700000004} {
+			buf = binary.LittleEndian.AppendUint32(buf, t)
+		}
+	}
+	if flags&OHDRAttrStorePhaseChange != 0 {
+		buf = binary.LittleEndian.AppendUint16(buf, 12)
+		buf = binary.LittleEndian.AppendUint16(buf, 10)
+	}
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(body)))
+	buf = append(buf, body...)
+	return binary.LittleEndian.AppendUint32(buf, JenkinsChecksum(buf))
+}
+
+func TestReadObjectHeader_V2Flags(t *testing.T) {
+	msgs := []v2TestMessage{
+		{typ: MsgDataspace, data: []byte{2, 1, 0, 0, 0, 0, 0, 0, 4, 0, 0, 0, 0, 0, 0, 0}},
+		{typ: MsgDatatype, flags: 0x01, data: []byte{0x10, 0x08, 0, 0, 4, 0, 0, 0, 0, 0, 32, 0}},
+		{typ: MsgFilterPipeline, data: []byte{}},
+		{typ: MsgAttribute, creationIndex: 0, data: []byte{3, 0, 2, 0, 8, 0, 8, 0, 0, 'a', 0, 0}},
+		{typ: MsgAttribute, creationIndex: 1, data: []byte{3, 0, 2, 0, 8, 0, 8, 0, 0, 'b', 0, 0}},
+		{typ: MsgName, data: []byte{0, 'd', 's'}},
+	}
+
+	// Flag combinations written by h5py >= 3 with libver="latest":
+	// track_times (default on for datasets), track_order, and non-default
+	// attribute phase change thresholds.
+	tests := []struct {
+		name  string
+		flags uint8
+	}{
+		{"no optional fields", 0x00},
+		{"times", OHDRStoreTimes},
+		{"creation order tracked", OHDRAttrCrtOrderTracked},
+		{"creation order tracked and indexed", OHDRAttrCrtOrderTracked | OHDRAttrCrtOrderIndexed},
+		{"phase change", OHDRAttrStorePhaseChange},
+		{"tracked, indexed and times", OHDRAttrCrtOrderTracked | OHDRAttrCrtOrderIndexed | OHDRStoreTimes},
+		{"all", OHDRAttrCrtOrderTracked | OHDRAttrCrtOrderIndexed | OHDRAttrStorePhaseChange | OHDRStoreTimes},
+	}
+
+	sb := &Superblock{Endianness: binary.LittleEndian, OffsetSize: 8, LengthSize: 8}
+
+	for _, tt := range tests {
+		for _, gap := range []int{0, 3} {
+			t.Run(fmt.Sprintf("%s/gap %d", tt.name, gap), func(t *testing.T) {
+				data := buildV2Header(tt.flags, msgs, gap)
+
+				header, err := ReadObjectHeader(bytes.NewReader(data), 0, sb)
+				require.NoError(t, err)
+				require.Equal(t, tt.flags, header.Flags&^OHDRChunk0SizeMask)
+				require.Equal(t, "ds", header.Name)
+				require.Len(t, header.Messages, len(msgs), "no message may be dropped")
+				for i, m := range msgs {
+					require.Equal(t, m.typ, header.Messages[i].Type)
+					require.Equal(t, m.data, header.Messages[i].Data)
+					require.Equal(t, m.flags, header.Messages[i].Flags)
+					if tt.flags&OHDRAttrCrtOrderTracked != 0 {
+						require.Equal(t, m.creationIndex, header.Messages[i].CreationIndex)
+					}
+				}
+
+				if tt.flags&OHDRStoreTimes != 0 {
+					require.Equal(t, uint32(1700000001), header.AccessTime)
+					require.Equal(t, uint32(1700000002), header.ModificationTime)
+					require.Equal(t, uint32(1700000003), header.ChangeTime)
+					require.Equal(t, uint32(1700000004), header.BirthTime)
+				} else {
+					require.Zero(t, header.ModificationTime)
+				}
+				if tt.flags&OHDRAttrStorePhaseChange != 0 {
+					require.Equal(t, uint16(12), header.MaxCompactAttributes)
+					require.Equal(t, uint16(10), header.MinDenseAttributes)
+				}
+
+				// Rewriting keeps the optional fields and message layout.
+				w := newMockWriterAt()
+				n, err := NewObjectHeaderWriterFromParsed(header).WriteTo(w, 0)
+				require.NoError(t, err)
+				require.Equal(t, n, ObjectHeaderSizeFromParsed(header))
+				reread, err := ReadObjectHeader(bytes.NewReader(w.buf.Bytes()), 0, sb)
+				require.NoError(t, err)
+				require.Equal(t, header.Flags&^OHDRChunk0SizeMask, reread.Flags&^OHDRChunk0SizeMask)
+				require.Equal(t, header.ModificationTime, reread.ModificationTime)
+				require.Equal(t, header.MinDenseAttributes, reread.MinDenseAttributes)
+				require.Len(t, reread.Messages, len(msgs))
+				for i := range msgs {
+					require.Equal(t, header.Messages[i].Data, reread.Messages[i].Data)
+					require.Equal(t, header.Messages[i].Flags, reread.Messages[i].Flags)
+					require.Equal(t, header.Messages[i].CreationIndex, reread.Messages[i].CreationIndex)
+				}
+			})
+		}
+	}
+}
+
+func TestReadObjectHeader_V2UnknownFlags(t *testing.T) {
+	data := buildV2Header(0, []v2TestMessage{{typ: MsgName, data: []byte{0, 'x'}}}, 0)
+	data[5] |= 0x40
+
+	_, err := ReadObjectHeader(bytes.NewReader(data), 0, &Superblock{Endianness: binary.LittleEndian})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unknown object header flags 0x40")
+}
+
+func TestReadObjectHeader_V2MessageOverrun(t *testing.T) {
+	data := buildV2Header(0, []v2TestMessage{{typ: MsgName, data: []byte{0, 'x'}}}, 0)
+	data[11] = 0xFF // Message size: prefix (6) + chunk size (4) + type (1)
+
+	_, err := ReadObjectHeader(bytes.NewReader(data), 0, &Superblock{Endianness: binary.LittleEndian})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "overruns chunk")
+}
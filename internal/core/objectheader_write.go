@@ -15,12 +15,79 @@ type ObjectHeaderWriter struct {
 
 	// V1-specific fields (used only when Version == 1)
 	RefCount uint32 // Reference count (always 1 for new files)
+
+	// ContinuationMessages is the number of messages stored in continuation
+	// chunks. V1 headers record the message count of all chunks.
+	ContinuationMessages uint16
+
+	// V2 optional prefix fields, written only when the matching flag is set
+	// (OHDRStoreTimes and OHDRAttrStorePhaseChange respectively).
+	AccessTime           uint32
+	ModificationTime     uint32
+	ChangeTime           uint32
+	BirthTime            uint32
+	MaxCompactAttributes uint16
+	MinDenseAttributes   uint16
 }
 
 // MessageWriter represents a message that can be written to an object header.
 type MessageWriter struct {
 	Type MessageType
 	Data []byte
+
+	// Flags and CreationIndex are written in v2 headers; CreationIndex only
+	// when the header has OHDRAttrCrtOrderTracked set.
+	Flags         uint8
+	CreationIndex uint16
+}
+
+// NewObjectHeaderWriterFromParsed builds a writer that re-encodes a parsed
+// header with the same flags, optional prefix fields and message flags, so
+// that headers written by other libraries (e.g. with tracked attribute
+// creation order or stored times) keep their layout when rewritten.
+func NewObjectHeaderWriterFromParsed(oh *ObjectHeader) *ObjectHeaderWriter {
+	ohw := &ObjectHeaderWriter{
+		Version:              oh.Version,
+		Flags:                oh.Flags,
+		RefCount:             oh.ReferenceCount,
+		AccessTime:           oh.AccessTime,
+		ModificationTime:     oh.ModificationTime,
+		ChangeTime:           oh.ChangeTime,
+		BirthTime:            oh.BirthTime,
+		MaxCompactAttributes: oh.MaxCompactAttributes,
+		MinDenseAttributes:   oh.MinDenseAttributes,
+		Messages:             make([]MessageWriter, len(oh.Messages)),
+	}
+	for i, msg := range oh.Messages {
+		ohw.Messages[i] = MessageWriter{
+			Type:          msg.Type,
+			Data:          msg.Data,
+			Flags:         msg.Flags,
+			CreationIndex: msg.CreationIndex,
+		}
+	}
+	return ohw
+}
+
+// v2MessageHeaderSize returns the size of a v2 message header: 4 bytes, plus
+// a 2-byte creation index when attribute creation order is tracked.
+func (ohw *ObjectHeaderWriter) v2MessageHeaderSize() uint64 {
+	if ohw.Flags&OHDRAttrCrtOrderTracked != 0 {
+		return 6
+	}
+	return 4
+}
+
+// v2PrefixExtraSize returns the size of the optional v2 prefix fields.
+func (ohw *ObjectHeaderWriter) v2PrefixExtraSize() uint64 {
+	var size uint64
+	if ohw.Flags&OHDRStoreTimes != 0 {
+		size += 16
+	}
+	if ohw.Flags&OHDRAttrStorePhaseChange != 0 {
+		size += 4
+	}
+	return size
 }
 
 // NewMinimalRootGroupHeader creates a minimal object header v2 for an empty root group.
@@ -90,7 +157,7 @@ func (ohw *ObjectHeaderWriter) PadToSize(minSize uint64) {
 	if ohw.Version == 1 {
 		msgOverhead = 8 // v1 message header
 	} else {
-		msgOverhead = 4 // v2 message header
+		msgOverhead = ohw.v2MessageHeaderSize()
 	}
 
 	if needed <= msgOverhead {
@@ -137,34 +204,10 @@ func (ohw *ObjectHeaderWriter) Size() uint64 {
 //   - Message headers (8 bytes each)
 //   - Message data (variable, 8-byte aligned)
 //
-// IMPORTANT: The "Object Header Size" field in v1 includes ONLY:
-//   - The 16-byte header
-//   - All message headers (8 bytes each)
-//   - It does NOT include message data!
-//
-// This function returns the TOTAL size (header + message headers + message data)
-// for allocation purposes, but writeToV1() calculates the "Object Header Size"
-// field separately.
+// The "Object Header Size" field written by writeToV1() is this size less
+// the 16-byte header.
 func (ohw *ObjectHeaderWriter) sizeV1() uint64 {
-	headerSize := uint64(16) // V1 header is always 16 bytes
-
-	// Calculate total message size with 8-byte alignment
-	var totalMessageSize uint64
-	for _, msg := range ohw.Messages {
-		// Each v1 message:
-		// - Header: Type (2) + Size (2) + Flags (1) + Reserved (3) = 8 bytes
-		// - Data: variable
-		// - Total aligned to 8-byte boundary
-		msgSize := 8 + uint64(len(msg.Data))
-		// Align to 8-byte boundary
-		if msgSize%8 != 0 {
-			msgSize += 8 - (msgSize % 8)
-		}
-		totalMessageSize += msgSize
-	}
-
-	// Return total size (header + all messages including data)
-	return headerSize + totalMessageSize
+	return 16 + ContinuationChunkSizeV1(ohw.Messages)
 }
 
 // sizeV2 calculates size for object header v2 (current implementation).
@@ -172,8 +215,8 @@ func (ohw *ObjectHeaderWriter) sizeV2() uint64 {
 	// Calculate message data size
 	var messageDataSize uint64
 	for _, msg := range ohw.Messages {
-		// Each message: Type (1) + Size (2) + Flags (1) + Data (variable)
-		messageDataSize += 1 + 2 + 1 + uint64(len(msg.Data))
+		// Each message: Type (1) + Size (2) + Flags (1) [+ Creation index (2)] + Data (variable)
+		messageDataSize += ohw.v2MessageHeaderSize() + uint64(len(msg.Data))
 	}
 
 	// Per HDF5 C reference (H5Ocache.c:1207, H5Opkg.h:85-107):
@@ -182,8 +225,9 @@ func (ohw *ObjectHeaderWriter) sizeV2() uint64 {
 	const checksumSize = 4
 	chunkSizeFieldWidth := chunkSizeFieldWidth(messageDataSize)
 
-	// Total on-disk size: Signature (4) + Version (1) + Flags (1) + ChunkSizeField + Messages + Checksum (4)
-	return 4 + 1 + 1 + chunkSizeFieldWidth + messageDataSize + checksumSize
+	// Total on-disk size: Signature (4) + Version (1) + Flags (1) + Optional fields +
+	// ChunkSizeField + Messages + Checksum (4)
+	return 4 + 1 + 1 + ohw.v2PrefixExtraSize() + chunkSizeFieldWidth + messageDataSize + checksumSize
 }
 
 // chunkSizeFieldWidth returns the number of bytes needed for the chunk size field
@@ -231,14 +275,9 @@ func writeChunkSize(buf []byte, chunkSize, width uint64) {
 //   - Signature: "OHDR" (4 bytes)
 //   - Version: 2 (1 byte)
 //   - Flags: (1 byte)
-//   - [Optional fields based on flags]
+//   - [Optional fields based on flags: times (16), attribute phase change (4)]
 //   - Size of Chunk 0: (1, 2, 4, or 8 bytes based on flags bits 0-1)
-//   - Messages: variable size
-//
-// For MVP v2:
-//   - No timestamp fields (flags bit 5 = 0)
-//   - No attribute phase change (flags bit 4 = 0)
-//   - Chunk size in 1 byte (flags bits 0-1 = 0)
+//   - Messages: variable size (with a creation index when order is tracked)
 func (ohw *ObjectHeaderWriter) WriteTo(w io.WriterAt, address uint64) (uint64, error) {
 	switch ohw.Version {
 	case 1:
@@ -269,10 +308,11 @@ func (ohw *ObjectHeaderWriter) writeToV1(w io.WriterAt, address uint64) (uint64,
 	totalSize := ohw.sizeV1()
 	buf := make([]byte, totalSize)
 
-	// Calculate "Object Header Size" field value
-	// This field includes ONLY: 16-byte header + message headers (8 bytes each)
-	// It does NOT include message data!
-	objectHeaderSize := uint32(16 + (len(ohw.Messages) * 8)) //nolint:gosec // G115: Safe - message count limited by HDF5 spec
+	// "Object Header Size" field value: the bytes of message headers and
+	// data that follow the 16-byte prefix in this chunk.
+	// Reference: H5Ocache.c - H5O__prefix_deserialize() (chunk0_size).
+	objectHeaderSize := uint32(totalSize - 16) //nolint:gosec // G115: Safe - header size limited by HDF5 spec
+	numMessages := len(ohw.Messages) + int(ohw.ContinuationMessages)
 
 	offset := 0
 
@@ -284,15 +324,14 @@ func (ohw *ObjectHeaderWriter) writeToV1(w io.WriterAt, address uint64) (uint64,
 	offset++
 
 	// Number of messages (2 bytes)
-	binary.LittleEndian.PutUint16(buf[offset:offset+2], uint16(len(ohw.Messages))) //nolint:gosec // G115: Safe - message count limited by HDF5 spec
+	binary.LittleEndian.PutUint16(buf[offset:offset+2], uint16(numMessages)) //nolint:gosec // G115: Safe - message count limited by HDF5 spec
 	offset += 2
 
 	// Object reference count (4 bytes) - always 1 for new files
 	binary.LittleEndian.PutUint32(buf[offset:offset+4], ohw.RefCount)
 	offset += 4
 
-	// Object header size (4 bytes) - header + message headers ONLY (no message data!)
-	// For 1 message: 16 (header) + 8 (message header) = 24 bytes
+	// Object header size (4 bytes)
 	binary.LittleEndian.PutUint32(buf[offset:offset+4], objectHeaderSize)
 	offset += 4
 
@@ -301,34 +340,7 @@ func (ohw *ObjectHeaderWriter) writeToV1(w io.WriterAt, address uint64) (uint64,
 	offset += 4
 
 	// Write messages
-	for _, msg := range ohw.Messages {
-		// Message type (2 bytes, little-endian)
-		binary.LittleEndian.PutUint16(buf[offset:offset+2], uint16(msg.Type))
-		offset += 2
-
-		// Message data size (2 bytes, little-endian)
-		binary.LittleEndian.PutUint16(buf[offset:offset+2], uint16(len(msg.Data))) //nolint:gosec // G115: Safe - message size validated
-		offset += 2
-
-		// Message flags (1 byte)
-		buf[offset] = 0 // For MVP: no flags
-		offset++
-
-		// Reserved (3 bytes) - already zero from make()
-		offset += 3
-
-		// Message data
-		copy(buf[offset:offset+len(msg.Data)], msg.Data)
-		offset += len(msg.Data)
-
-		// Pad to 8-byte boundary
-		msgSize := 8 + len(msg.Data) // Header (8 bytes) + Data
-		if msgSize%8 != 0 {
-			padding := 8 - (msgSize % 8)
-			// Padding bytes already zero from make()
-			offset += padding
-		}
-	}
+	encodeMessagesV1(buf[offset:], ohw.Messages)
 
 	// Write to file
 	n, err := w.WriteAt(buf, int64(address)) //nolint:gosec // Safe: address within file bounds
@@ -348,13 +360,15 @@ func (ohw *ObjectHeaderWriter) writeToV1(w io.WriterAt, address uint64) (uint64,
 func (ohw *ObjectHeaderWriter) writeToV2(w io.WriterAt, address uint64) (uint64, error) {
 	// Calculate message data size
 	var messageDataSize uint64
+	msgHeaderSize := ohw.v2MessageHeaderSize()
 	for _, msg := range ohw.Messages {
 		// Each message has:
 		// - Type (1 byte for v2)
 		// - Size (2 bytes for v2)
 		// - Flags (1 byte for v2)
+		// - Creation index (2 bytes, only if attribute creation order is tracked)
 		// - Data (variable)
-		messageDataSize += 1 + 2 + 1 + uint64(len(msg.Data))
+		messageDataSize += msgHeaderSize + uint64(len(msg.Data))
 	}
 
 	// Per HDF5 C reference (H5Ocache.c:1207): chunk_size in file = messages ONLY.
@@ -380,8 +394,9 @@ func (ohw *ObjectHeaderWriter) writeToV2(w io.WriterAt, address uint64) (uint64,
 	flags := (ohw.Flags & 0xFC) | flagsBits // Preserve other flag bits, set bits 0-1
 
 	// Build header buffer: prefix + messages + checksum
-	// Signature (4) + Version (1) + Flags (1) + Chunk Size field (variable) + Messages + Checksum (4)
-	headerSize := 4 + 1 + 1 + csWidth + messageDataSize + uint64(checksumSize)
+	// Signature (4) + Version (1) + Flags (1) + Optional fields + Chunk Size field (variable) +
+	// Messages + Checksum (4)
+	headerSize := 4 + 1 + 1 + ohw.v2PrefixExtraSize() + csWidth + messageDataSize + uint64(checksumSize)
 	buf := make([]byte, headerSize)
 
 	offset := 0
@@ -398,6 +413,21 @@ func (ohw *ObjectHeaderWriter) writeToV2(w io.WriterAt, address uint64) (uint64,
 	buf[offset] = flags
 	offset++
 
+	// Optional times (access, modification, change, birth).
+	if flags&OHDRStoreTimes != 0 {
+		for _, t := range []uint32{ohw.AccessTime, ohw.ModificationTime, ohw.ChangeTime, ohw.BirthTime} {
+			binary.LittleEndian.PutUint32(buf[offset:offset+4], t)
+			offset += 4
+		}
+	}
+
+	// Optional attribute storage phase change values.
+	if flags&OHDRAttrStorePhaseChange != 0 {
+		binary.LittleEndian.PutUint16(buf[offset:offset+2], ohw.MaxCompactAttributes)
+		binary.LittleEndian.PutUint16(buf[offset+2:offset+4], ohw.MinDenseAttributes)
+		offset += 4
+	}
+
 	// Chunk 0 size (variable width based on flags bits 0-1)
 	writeChunkSize(buf[offset:], chunkSize, csWidth)
 	offset += int(csWidth) //nolint:gosec // G115: csWidth is 1, 2, 4, or 8
@@ -413,10 +443,15 @@ func (ohw *ObjectHeaderWriter) writeToV2(w io.WriterAt, address uint64) (uint64,
 		offset += 2
 
 		// Message flags (1 byte)
-		// For MVP: flags = 0 (not shared, not constant, not shareable)
-		buf[offset] = 0
+		buf[offset] = msg.Flags
 		offset++
 
+		// Creation index (2 bytes, only if attribute creation order is tracked)
+		if msgHeaderSize == 6 {
+			binary.LittleEndian.PutUint16(buf[offset:offset+2], msg.CreationIndex)
+			offset += 2
+		}
+
 		// Message data
 		copy(buf[offset:offset+len(msg.Data)], msg.Data)
 		offset += len(msg.Data)
@@ -457,8 +492,8 @@ func AddMessageToObjectHeader(oh *ObjectHeader, msgType MessageType, msgData []b
 		return fmt.Errorf("object header is nil")
 	}
 
-	if oh.Version != 2 {
-		return fmt.Errorf("only object header version 2 is supported for modification, got version %d", oh.Version)
+	if oh.Version != 1 && oh.Version != 2 {
+		return fmt.Errorf("only object header versions 1 and 2 are supported for modification, got version %d", oh.Version)
 	}
 
 	// Create new message.
@@ -478,10 +513,9 @@ func AddMessageToObjectHeader(oh *ObjectHeader, msgType MessageType, msgData []b
 // WriteObjectHeader writes an object header back to disk at a given address.
 // This is used when modifying object headers (e.g., adding attributes).
 //
-// For MVP (v0.11.1-beta):
-//   - Only object header v2 supported
-//   - No continuation blocks
-//   - Overwrites existing header at the same address
+// Only the main chunk is written, over the existing header. Continuation
+// chunks are left untouched; for v1 headers their messages are read back to
+// keep the header's total message count.
 //
 // Parameters:
 //   - w: Writer with WriteAt capability
@@ -494,29 +528,27 @@ func AddMessageToObjectHeader(oh *ObjectHeader, msgType MessageType, msgData []b
 //
 // Reference: H5O.c - H5O_flush().
 func WriteObjectHeader(w io.WriterAt, addr uint64, oh *ObjectHeader, sb *Superblock) error {
-	_ = sb // Reserved for future use (v1 headers or encoding parameters)
-
 	if oh == nil {
 		return fmt.Errorf("object header is nil")
 	}
 
-	if oh.Version != 2 {
-		return fmt.Errorf("only object header version 2 is supported for writing, got version %d", oh.Version)
+	if oh.Version != 1 && oh.Version != 2 {
+		return fmt.Errorf("only object header versions 1 and 2 are supported for writing, got version %d", oh.Version)
 	}
 
 	// Build object header writer from the object header
-	ohw := &ObjectHeaderWriter{
-		Version:  oh.Version,
-		Flags:    oh.Flags,
-		Messages: make([]MessageWriter, len(oh.Messages)),
-	}
-
-	// Convert messages
-	for i, msg := range oh.Messages {
-		ohw.Messages[i] = MessageWriter{
-			Type: msg.Type,
-			Data: msg.Data,
+	ohw := NewObjectHeaderWriterFromParsed(oh)
+	if oh.Version == 1 {
+		main := mainChunkMessages(oh.Messages)
+		ohw.Messages = ohw.Messages[:0]
+		for _, msg := range main {
+			ohw.Messages = append(ohw.Messages, MessageWriter{Type: msg.Type, Data: msg.Data, Flags: msg.Flags})
 		}
+		count, err := countContinuationMessagesV1(w, main, sb)
+		if err != nil {
+			return err
+		}
+		ohw.ContinuationMessages = count
 	}
 
 	// Write the header
@@ -528,6 +560,47 @@ func WriteObjectHeader(w io.WriterAt, addr uint64, oh *ObjectHeader, sb *Superbl
 	return nil
 }
 
+// mainChunkMessages returns the messages not read from continuation chunks.
+func mainChunkMessages(messages []*HeaderMessage) []*HeaderMessage {
+	main := make([]*HeaderMessage, 0, len(messages))
+	for _, msg := range messages {
+		if !msg.FromContinuation {
+			main = append(main, msg)
+		}
+	}
+	return main
+}
+
+// countContinuationMessagesV1 counts the messages in the continuation chunks
+// the messages of a v1 header point to, following nested continuations.
+// The chunks are read back through w, which must also be an io.ReaderAt.
+func countContinuationMessagesV1(w io.WriterAt, messages []*HeaderMessage, sb *Superblock) (uint16, error) {
+	continuations := findContinuations(messages, sb)
+	if len(continuations) == 0 {
+		return 0, nil
+	}
+	r, ok := w.(io.ReaderAt)
+	if !ok {
+		return 0, fmt.Errorf("cannot read continuation chunks of v1 object header")
+	}
+
+	var count int
+	for len(continuations) > 0 {
+		cont := continuations[0]
+		continuations = continuations[1:]
+		chunk, _, err := parseV1ContinuationBlock(r, cont.Address, cont.Size, sb)
+		if err != nil {
+			return 0, fmt.Errorf("failed to read continuation chunk: %w", err)
+		}
+		count += len(chunk)
+		continuations = append(continuations, findContinuations(chunk, sb)...)
+	}
+	if count > 0xFFFF {
+		return 0, fmt.Errorf("too many object header messages: %d", count)
+	}
+	return uint16(count), nil //nolint:gosec // G115: checked above
+}
+
 // ObjectHeaderSizeFromParsed calculates the on-disk size of an ObjectHeader
 // (as returned by ReadObjectHeader). This is used to determine how much space
 // the header occupies after modification (e.g., adding attributes).
@@ -539,18 +612,7 @@ func ObjectHeaderSizeFromParsed(oh *ObjectHeader) uint64 {
 	if oh.Version != 1 && oh.Version != 2 {
 		return 0
 	}
-	ohw := &ObjectHeaderWriter{
-		Version:  oh.Version,
-		Flags:    oh.Flags,
-		Messages: make([]MessageWriter, len(oh.Messages)),
-	}
-	for i, msg := range oh.Messages {
-		ohw.Messages[i] = MessageWriter{
-			Type: msg.Type,
-			Data: msg.Data,
-		}
-	}
-	return ohw.Size()
+	return NewObjectHeaderWriterFromParsed(oh).Size()
 }
 
 // EncodeContinuationMessage creates a continuation message (type 0x0010) that points
@@ -603,26 +665,24 @@ func EncodeContinuationMessage(ochkAddr, ochkSize uint64, sb *Superblock) []byte
 //
 // OCHK block format (per H5Opkg.h and H5Ocache.c):
 //   - "OCHK" signature (4 bytes)
-//   - Messages (same format as main OHDR: type(1) + size(2) + flags(1) + data)
+//   - Messages (same format as main OHDR: type(1) + size(2) + flags(1) [+ creation index(2)] + data)
 //   - Jenkins lookup3 checksum (4 bytes) over "OCHK" + messages
 //
 // Parameters:
 //   - w: Writer to write the OCHK block
 //   - address: File address where the OCHK block will be written
+//   - hdrFlags: Flags of the owning object header (selects the message header size)
 //   - messages: Messages to include in the continuation chunk
 //
 // Returns:
 //   - uint64: Total size of the OCHK block written
 //   - error: Non-nil if write fails
-func WriteContinuationChunkV2(w io.WriterAt, address uint64, messages []MessageWriter) (uint64, error) {
-	// Calculate messages data size.
-	var messageDataSize uint64
-	for _, msg := range messages {
-		messageDataSize += 1 + 2 + 1 + uint64(len(msg.Data)) // Type(1) + Size(2) + Flags(1) + Data
-	}
+func WriteContinuationChunkV2(w io.WriterAt, address uint64, hdrFlags uint8, messages []MessageWriter) (uint64, error) {
+	ohw := &ObjectHeaderWriter{Version: 2, Flags: hdrFlags}
+	msgHeaderSize := ohw.v2MessageHeaderSize()
 
 	// OCHK total size: "OCHK"(4) + messages + checksum(4).
-	totalSize := 4 + messageDataSize + 4
+	totalSize := ContinuationChunkSizeV2(hdrFlags, messages)
 	buf := make([]byte, totalSize)
 	offset := 0
 
@@ -636,8 +696,12 @@ func WriteContinuationChunkV2(w io.WriterAt, address uint64, messages []MessageW
 		offset++
 		binary.LittleEndian.PutUint16(buf[offset:offset+2], uint16(len(msg.Data))) //nolint:gosec // Safe: message size validated
 		offset += 2
-		buf[offset] = 0 // Message flags
+		buf[offset] = msg.Flags
 		offset++
+		if msgHeaderSize == 6 {
+			binary.LittleEndian.PutUint16(buf[offset:offset+2], msg.CreationIndex)
+			offset += 2
+		}
 		copy(buf[offset:offset+len(msg.Data)], msg.Data)
 		offset += len(msg.Data)
 	}
@@ -661,15 +725,90 @@ func WriteContinuationChunkV2(w io.WriterAt, address uint64, messages []MessageW
 // ContinuationChunkSizeV2 calculates the on-disk size of an OCHK continuation block
 // for the given messages, without writing anything.
 //
-// Returns: "OCHK"(4) + sum(Type(1)+Size(2)+Flags(1)+Data) + Checksum(4).
-func ContinuationChunkSizeV2(messages []MessageWriter) uint64 {
+// Returns: "OCHK"(4) + sum(Type(1)+Size(2)+Flags(1)[+CreationIndex(2)]+Data) + Checksum(4).
+func ContinuationChunkSizeV2(hdrFlags uint8, messages []MessageWriter) uint64 {
+	ohw := &ObjectHeaderWriter{Version: 2, Flags: hdrFlags}
 	var messageDataSize uint64
 	for _, msg := range messages {
-		messageDataSize += 1 + 2 + 1 + uint64(len(msg.Data))
+		messageDataSize += ohw.v2MessageHeaderSize() + uint64(len(msg.Data))
 	}
 	return 4 + messageDataSize + 4
 }
 
+// encodeMessagesV1 encodes messages in the v1 layout into buf, each message
+// padded to a multiple of 8 bytes:
+//   - Type (2 bytes, little-endian)
+//   - Size (2 bytes, little-endian)
+//   - Flags (1 byte)
+//   - Reserved (3 bytes)
+//   - Data (variable, padded to 8-byte boundary)
+func encodeMessagesV1(buf []byte, messages []MessageWriter) {
+	offset := 0
+	for _, msg := range messages {
+		binary.LittleEndian.PutUint16(buf[offset:offset+2], uint16(msg.Type))
+		// The size field covers the padding: the C library rejects
+		// unaligned v1 messages.
+		size := messageSizeV1(msg)
+		binary.LittleEndian.PutUint16(buf[offset+2:offset+4], uint16(size-8)) //nolint:gosec // G115: Safe - message size validated
+		buf[offset+4] = msg.Flags
+		copy(buf[offset+8:], msg.Data)
+		offset += int(size) //nolint:gosec // G115: Safe - bounded by buffer size
+	}
+}
+
+// messageSizeV1 returns the size of a message in a v1 header: the 8-byte
+// message header and the data, padded to a multiple of 8 bytes.
+func messageSizeV1(msg MessageWriter) uint64 {
+	return (8 + uint64(len(msg.Data)) + 7) / 8 * 8
+}
+
+// ContinuationChunkSizeV1 returns the size of a v1 continuation chunk holding
+// messages. V1 continuation chunks are bare messages, without signature or
+// checksum.
+func ContinuationChunkSizeV1(messages []MessageWriter) uint64 {
+	var size uint64
+	for _, msg := range messages {
+		size += messageSizeV1(msg)
+	}
+	return size
+}
+
+// WriteContinuationChunkV1 writes messages as a v1 continuation chunk at
+// address and returns its size.
+//
+// Reference: H5Ocache.c - H5O__chunk_serialize().
+func WriteContinuationChunkV1(w io.WriterAt, address uint64, messages []MessageWriter) (uint64, error) {
+	buf := make([]byte, ContinuationChunkSizeV1(messages))
+	encodeMessagesV1(buf, messages)
+
+	n, err := w.WriteAt(buf, int64(address)) //nolint:gosec // Safe: address within file bounds
+	if err != nil {
+		return 0, fmt.Errorf("failed to write continuation chunk at address %d: %w", address, err)
+	}
+	if n != len(buf) {
+		return 0, fmt.Errorf("incomplete continuation chunk write: wrote %d bytes, expected %d", n, len(buf))
+	}
+	return uint64(len(buf)), nil
+}
+
+// ContinuationChunkSize returns the size of a continuation chunk holding
+// messages for an object header of the given version and flags.
+func ContinuationChunkSize(version, hdrFlags uint8, messages []MessageWriter) uint64 {
+	if version == 1 {
+		return ContinuationChunkSizeV1(messages)
+	}
+	return ContinuationChunkSizeV2(hdrFlags, messages)
+}
+
+// WriteContinuationChunk writes a continuation chunk (v1 or OCHK) for an
+// object header of the given version and flags.
+func WriteContinuationChunk(w io.WriterAt, address uint64, version, hdrFlags uint8, messages []MessageWriter) (uint64, error) {
+	if version == 1 {
+		return WriteContinuationChunkV1(w, address, messages)
+	}
+	return WriteContinuationChunkV2(w, address, hdrFlags, messages)
+}
+
 // RewriteObjectHeaderV2 rewrites an object header v2 with updated messages.
 // This handles the case where we need to modify an existing object header
 // by reading it, modifying it, and writing it back.
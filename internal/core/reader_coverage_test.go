@@ -252,7 +252,7 @@ func TestReadDenseAttributes_EndToEnd(t *testing.T) {
 	bthd := buf[bthdAddr:]
 	copy(bthd[0:4], "BTHD")
 	bthd[4] = 0                                          // version
-	bthd[5] = 8                                          // type (attribute name index)
+	bthd[5] = 5                                          // type (name hash + heap ID, as written here)
 	binary.LittleEndian.PutUint32(bthd[6:10], 4096)      // node size
 	binary.LittleEndian.PutUint16(bthd[10:12], 11)       // record size (4 hash + 7 heapID)
 	binary.LittleEndian.PutUint16(bthd[12:14], 0)        // depth (0 = leaf root)
@@ -266,7 +266,7 @@ func TestReadDenseAttributes_EndToEnd(t *testing.T) {
 	btlf := buf[btlfAddr:]
 	copy(btlf[0:4], "BTLF")
 	btlf[4] = 0 // version
-	btlf[5] = 8 // type
+	btlf[5] = 5 // type
 	// Record: hash(4) + heapID(7)
 	offset := 6
 	binary.LittleEndian.PutUint32(btlf[offset:offset+4], 0xAABBCCDD) // name hash
@@ -290,6 +290,9 @@ func TestReadDenseAttributes_EndToEnd(t *testing.T) {
 	heapIDBytes[5] = 0
 	heapIDBytes[6] = 0
 	copy(btlf[offset:offset+7], heapIDBytes[:])
+	offset += 7
+	// Checksum over the signature, version, type and record.
+	binary.LittleEndian.PutUint32(btlf[offset:offset+4], JenkinsChecksum(btlf[:offset]))
 
 	// --- FRHP at 0x0300 ---
 	frhp := buf[frhpAddr:]
@@ -374,6 +377,8 @@ func TestReadDenseAttributes_InvalidHeapHeader(t *testing.T) {
 	binary.LittleEndian.PutUint32(btlf[offset:offset+4], 0x11111111)
 	offset += 4
 	copy(btlf[offset:offset+7], make([]byte, 7))
+	offset += 7
+	binary.LittleEndian.PutUint32(btlf[offset:offset+4], JenkinsChecksum(btlf[:offset]))
 
 	// Invalid FRHP.
 	copy(buf[frhpAddr:frhpAddr+4], "XXXX")
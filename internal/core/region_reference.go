@@ -0,0 +1,385 @@
+package core
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/scigolib/hdf5/internal/utils"
+)
+
+// Selection types of a serialized dataspace selection (H5S_sel_type).
+const (
+	selectionNone      = 0
+	selectionPoints    = 1
+	selectionHyperslab = 2
+	selectionAll       = 3
+)
+
+// hyperslabRegular is the hyperslab selection flag marking a regular
+// (start/stride/count/block) encoding (H5S_HYPER_REGULAR).
+const hyperslabRegular = 0x01
+
+// maxSelectionRank is the largest dataspace rank HDF5 supports (H5S_MAX_RANK).
+const maxSelectionRank = 32
+
+// maxRegionBlocks bounds the number of blocks a regular hyperslab may expand
+// to, so a corrupt count cannot exhaust memory.
+const maxRegionBlocks = 1 << 24
+
+// RegionReference is a dataset region reference (hdset_reg_ref_t): the ID of
+// a global heap object holding the referenced dataset's address followed by
+// the serialized selection. The zero value is the null reference.
+type RegionReference struct {
+	HeapAddress uint64 // Address of the global heap collection.
+	ObjectIndex uint32 // Index of the object within the collection.
+}
+
+// IsNull reports whether r is the null region reference.
+func (r RegionReference) IsNull() bool {
+	return r.HeapAddress == 0
+}
+
+// RegionBlock is one block of a hyperslab selection. End is inclusive, so a
+// single element has Start equal to End.
+type RegionBlock struct {
+	Start []uint64
+	End   []uint64
+}
+
+// RegionSelection is the part of a dataset a region reference selects. All
+// marks the whole dataset; otherwise the elements are listed in Points or
+// covered by Blocks. A selection of nothing has all three empty.
+type RegionSelection struct {
+	All    bool
+	Points [][]uint64
+	Blocks []RegionBlock
+}
+
+// ParseRegionReferences decodes n region references of offsetSize+4 bytes
+// each from data.
+func ParseRegionReferences(data []byte, n uint64, offsetSize int) ([]RegionReference, error) {
+	if offsetSize != 4 && offsetSize != 8 {
+		return nil, fmt.Errorf("invalid region reference size %d", offsetSize+4)
+	}
+	size := uint64(offsetSize + 4) //nolint:gosec // G115: offsetSize is 4 or 8
+	totalBytes, err := utils.SafeMultiply(n, size)
+	if err != nil {
+		return nil, fmt.Errorf("region reference size overflow: %w", err)
+	}
+	if totalBytes > uint64(len(data)) {
+		return nil, fmt.Errorf("region reference data size mismatch: need %d bytes, have %d", totalBytes, len(data))
+	}
+	refs := make([]RegionReference, n)
+	for i := range refs {
+		ref, err := ParseGlobalHeapReference(data[uint64(i)*size:], offsetSize) //nolint:gosec // G115: index is non-negative
+		if err != nil {
+			return nil, err
+		}
+		refs[i] = RegionReference{HeapAddress: ref.HeapAddress, ObjectIndex: ref.ObjectIndex}
+	}
+	return refs, nil
+}
+
+// ReadRegion resolves a region reference: it reads the referenced global heap
+// object and returns the address of the dataset's object header and the
+// selection.
+//
+// Reference: H5Rint.c - H5R__decode_heap(), H5Sselect.c - H5S_select_deserialize().
+func ReadRegion(r io.ReaderAt, ref RegionReference, offsetSize int) (uint64, *RegionSelection, error) {
+	if ref.IsNull() {
+		return 0, nil, errors.New("null region reference")
+	}
+	collection, err := ReadGlobalHeapCollection(r, ref.HeapAddress, offsetSize)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to read global heap collection at 0x%X: %w", ref.HeapAddress, err)
+	}
+	obj, err := collection.GetObject(ref.ObjectIndex)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	data := obj.Data
+	if len(data) < offsetSize {
+		return 0, nil, fmt.Errorf("region reference object too short: %d bytes", len(data))
+	}
+	addr := readAddress(data, offsetSize)
+	sel, err := ParseRegionSelection(data[offsetSize:])
+	if err != nil {
+		return 0, nil, err
+	}
+	return addr, sel, nil
+}
+
+// ParseRegionSelection decodes a serialized dataspace selection, as stored
+// after the object address in a region reference's heap object. Point and
+// hyperslab selections of versions 1 to 3 are supported; regular hyperslabs
+// are expanded into their blocks.
+//
+// Reference: H5Spoint.c - H5S__point_deserialize(), H5Shyper.c - H5S__hyper_deserialize().
+func ParseRegionSelection(data []byte) (*RegionSelection, error) {
+	d := &selectionDecoder{data: data}
+	selType := d.uint32()
+	version := d.uint32()
+	if d.err != nil {
+		return nil, fmt.Errorf("selection too short: %w", d.err)
+	}
+
+	switch selType {
+	case selectionNone:
+		return &RegionSelection{}, nil
+	case selectionAll:
+		return &RegionSelection{All: true}, nil
+	case selectionPoints:
+		return d.points(version)
+	case selectionHyperslab:
+		return d.hyperslab(version)
+	default:
+		return nil, fmt.Errorf("unsupported selection type %d", selType)
+	}
+}
+
+// selectionDecoder reads little-endian fields from a serialized selection,
+// remembering the first short read.
+type selectionDecoder struct {
+	data []byte
+	pos  int
+	err  error
+}
+
+// next returns the next n bytes, or nil once the data runs out.
+func (d *selectionDecoder) next(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if d.pos+n > len(d.data) {
+		d.err = fmt.Errorf("need %d bytes at offset %d, have %d", n, d.pos, len(d.data))
+		return nil
+	}
+	b := d.data[d.pos : d.pos+n]
+	d.pos += n
+	return b
+}
+
+// remaining returns the number of unread bytes.
+func (d *selectionDecoder) remaining() uint64 {
+	return uint64(len(d.data) - d.pos) //nolint:gosec // G115: pos never passes len(data)
+}
+
+func (d *selectionDecoder) uint8() uint8 {
+	if b := d.next(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+func (d *selectionDecoder) uint32() uint32 {
+	if b := d.next(4); b != nil {
+		return binary.LittleEndian.Uint32(b)
+	}
+	return 0
+}
+
+// value reads an unsigned value of encSize bytes (2, 4 or 8).
+func (d *selectionDecoder) value(encSize int) uint64 {
+	b := d.next(encSize)
+	if b == nil {
+		return 0
+	}
+	switch encSize {
+	case 2:
+		return uint64(binary.LittleEndian.Uint16(b))
+	case 4:
+		return uint64(binary.LittleEndian.Uint32(b))
+	default:
+		return binary.LittleEndian.Uint64(b)
+	}
+}
+
+// coords reads rank values of encSize bytes.
+func (d *selectionDecoder) coords(rank uint32, encSize int) []uint64 {
+	c := make([]uint64, rank)
+	for i := range c {
+		c[i] = d.value(encSize)
+	}
+	return c
+}
+
+// encSize reads a version 2+ encoding size byte.
+func (d *selectionDecoder) encSize() (int, error) {
+	size := int(d.uint8())
+	if d.err == nil && size != 2 && size != 4 && size != 8 {
+		return 0, fmt.Errorf("invalid selection encoding size %d", size)
+	}
+	return size, nil
+}
+
+// points decodes a point selection.
+func (d *selectionDecoder) points(version uint32) (*RegionSelection, error) {
+	var encSize int
+	switch version {
+	case 1:
+		d.next(8) // reserved + length
+		encSize = 4
+	case 2:
+		var err error
+		if encSize, err = d.encSize(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported point selection version %d", version)
+	}
+
+	rank := d.uint32()
+	n := d.value(encSize)
+	if d.err != nil {
+		return nil, fmt.Errorf("point selection truncated: %w", d.err)
+	}
+	if rank == 0 || rank > maxSelectionRank {
+		return nil, fmt.Errorf("invalid point selection rank %d", rank)
+	}
+	if n > d.remaining()/(uint64(rank)*uint64(encSize)) { //nolint:gosec // G115: encSize is 2, 4 or 8
+		return nil, fmt.Errorf("point selection truncated: %d points of rank %d", n, rank)
+	}
+
+	sel := &RegionSelection{Points: make([][]uint64, n)}
+	for i := range sel.Points {
+		sel.Points[i] = d.coords(rank, encSize)
+	}
+	return sel, d.err
+}
+
+// hyperslab decodes a hyperslab selection, listed as blocks or regular.
+func (d *selectionDecoder) hyperslab(version uint32) (*RegionSelection, error) {
+	var flags uint8
+	var encSize int
+	switch version {
+	case 1:
+		d.next(8) // reserved + length
+		encSize = 4
+	case 2:
+		flags = d.uint8()
+		d.next(4) // length
+		encSize = 8
+	case 3:
+		flags = d.uint8()
+		var err error
+		if encSize, err = d.encSize(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported hyperslab selection version %d", version)
+	}
+
+	rank := d.uint32()
+	if d.err != nil {
+		return nil, fmt.Errorf("hyperslab selection truncated: %w", d.err)
+	}
+	if rank == 0 || rank > maxSelectionRank {
+		return nil, fmt.Errorf("invalid hyperslab selection rank %d", rank)
+	}
+
+	if flags&hyperslabRegular != 0 {
+		start := d.coords(rank, encSize)
+		stride := d.coords(rank, encSize)
+		count := d.coords(rank, encSize)
+		block := d.coords(rank, encSize)
+		if d.err != nil {
+			return nil, fmt.Errorf("hyperslab selection truncated: %w", d.err)
+		}
+		blocks, err := regularBlocks(start, stride, count, block)
+		if err != nil {
+			return nil, err
+		}
+		return &RegionSelection{Blocks: blocks}, nil
+	}
+
+	n := d.value(encSize)
+	if d.err != nil {
+		return nil, fmt.Errorf("hyperslab selection truncated: %w", d.err)
+	}
+	if n > d.remaining()/(2*uint64(rank)*uint64(encSize)) { //nolint:gosec // G115: encSize is 2, 4 or 8
+		return nil, fmt.Errorf("hyperslab selection truncated: %d blocks of rank %d", n, rank)
+	}
+
+	sel := &RegionSelection{Blocks: make([]RegionBlock, n)}
+	for i := range sel.Blocks {
+		sel.Blocks[i].Start = d.coords(rank, encSize)
+		sel.Blocks[i].End = d.coords(rank, encSize)
+	}
+	return sel, d.err
+}
+
+// regularBlocks expands a regular hyperslab into its blocks, in row-major
+// order.
+func regularBlocks(start, stride, count, block []uint64) ([]RegionBlock, error) {
+	total := uint64(1)
+	for i := range count {
+		if count[i] == 0 || block[i] == 0 {
+			return nil, nil
+		}
+		if count[i] > maxRegionBlocks || total*count[i] > maxRegionBlocks {
+			return nil, fmt.Errorf("regular hyperslab selection has too many blocks")
+		}
+		total *= count[i]
+	}
+
+	blocks := make([]RegionBlock, 0, total)
+	idx := make([]uint64, len(count))
+	for {
+		b := RegionBlock{Start: make([]uint64, len(count)), End: make([]uint64, len(count))}
+		for i := range idx {
+			b.Start[i] = start[i] + idx[i]*stride[i]
+			b.End[i] = b.Start[i] + block[i] - 1
+		}
+		blocks = append(blocks, b)
+
+		dim := len(idx) - 1
+		for ; dim >= 0; dim-- {
+			idx[dim]++
+			if idx[dim] < count[dim] {
+				break
+			}
+			idx[dim] = 0
+		}
+		if dim < 0 {
+			return blocks, nil
+		}
+	}
+}
+
+// EncodeHyperslabRegion serializes the object address and a single block
+// hyperslab selection as the heap object of a region reference, using the
+// version 1 selection encoding every HDF5 release reads. start and count
+// give the block; coordinates must fit in 32 bits.
+func EncodeHyperslabRegion(addr uint64, start, count []uint64, offsetSize int) ([]byte, error) {
+	rank := len(start)
+	if rank == 0 || rank != len(count) {
+		return nil, fmt.Errorf("start and count must have the same non-zero rank, got %d and %d", len(start), len(count))
+	}
+	for i := range start {
+		if count[i] == 0 {
+			return nil, fmt.Errorf("count[%d] must be positive", i)
+		}
+		if start[i]+count[i]-1 > 0xFFFFFFFF {
+			return nil, fmt.Errorf("region in dimension %d exceeds 32-bit coordinates", i)
+		}
+	}
+
+	// type, version, reserved, length, rank, nblocks, start and end coordinates.
+	length := 8 + 8*rank
+	buf := make([]byte, offsetSize+16+length)
+	writeAddress(buf, addr, offsetSize, binary.LittleEndian)
+	p := buf[offsetSize:]
+	binary.LittleEndian.PutUint32(p[0:], selectionHyperslab)
+	binary.LittleEndian.PutUint32(p[4:], 1)
+	binary.LittleEndian.PutUint32(p[12:], uint32(length)) //nolint:gosec // G115: rank is small
+	binary.LittleEndian.PutUint32(p[16:], uint32(rank))   //nolint:gosec // G115: rank is small
+	binary.LittleEndian.PutUint32(p[20:], 1)
+	for i := range start {
+		binary.LittleEndian.PutUint32(p[24+4*i:], uint32(start[i]))                   //nolint:gosec // G115: checked above
+		binary.LittleEndian.PutUint32(p[24+4*(rank+i):], uint32(start[i]+count[i]-1)) //nolint:gosec // G115: checked above
+	}
+	return buf, nil
+}
@@ -0,0 +1,130 @@
+package core
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// selectionBytes concatenates little-endian fields: uint8 for byte, uint32
+// for uint32 and uint64 for uint64 values.
+func selectionBytes(fields ...interface{}) []byte {
+	var buf []byte
+	for _, f := range fields {
+		switch v := f.(type) {
+		case byte:
+			buf = append(buf, v)
+		case uint32:
+			buf = binary.LittleEndian.AppendUint32(buf, v)
+		case uint64:
+			buf = binary.LittleEndian.AppendUint64(buf, v)
+		}
+	}
+	return buf
+}
+
+func TestParseRegionSelection(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want *RegionSelection
+	}{
+		{
+			name: "none",
+			data: selectionBytes(uint32(0), uint32(1), uint32(0), uint32(0)),
+			want: &RegionSelection{},
+		},
+		{
+			name: "all",
+			data: selectionBytes(uint32(3), uint32(1), uint32(0), uint32(0)),
+			want: &RegionSelection{All: true},
+		},
+		{
+			name: "points v2 with 2-byte coordinates",
+			data: selectionBytes(uint32(1), uint32(2), byte(2), uint32(1),
+				byte(2), byte(0), byte(5), byte(0), byte(9), byte(0)),
+			want: &RegionSelection{Points: [][]uint64{{5}, {9}}},
+		},
+		{
+			name: "hyperslab v3 regular",
+			data: selectionBytes(uint32(2), uint32(3), byte(1), byte(8), uint32(2),
+				uint64(0), uint64(1), // start
+				uint64(4), uint64(1), // stride
+				uint64(2), uint64(1), // count
+				uint64(2), uint64(3)), // block
+			want: &RegionSelection{Blocks: []RegionBlock{
+				{Start: []uint64{0, 1}, End: []uint64{1, 3}},
+				{Start: []uint64{4, 1}, End: []uint64{5, 3}},
+			}},
+		},
+		{
+			name: "hyperslab v2 regular",
+			data: selectionBytes(uint32(2), uint32(2), byte(1), uint32(36), uint32(1),
+				uint64(3), uint64(1), uint64(1), uint64(4)),
+			want: &RegionSelection{Blocks: []RegionBlock{{Start: []uint64{3}, End: []uint64{6}}}},
+		},
+		{
+			name: "hyperslab v3 irregular",
+			data: selectionBytes(uint32(2), uint32(3), byte(0), byte(4), uint32(1),
+				uint32(2), uint32(0), uint32(1), uint32(5), uint32(8)),
+			want: &RegionSelection{Blocks: []RegionBlock{
+				{Start: []uint64{0}, End: []uint64{1}},
+				{Start: []uint64{5}, End: []uint64{8}},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRegionSelection(tt.data)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseRegionSelection_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{"too short", selectionBytes(uint32(2)), "selection too short"},
+		{"unknown type", selectionBytes(uint32(7), uint32(1)), "unsupported selection type 7"},
+		{"hyperslab version", selectionBytes(uint32(2), uint32(9)), "unsupported hyperslab selection version 9"},
+		{"encoding size", selectionBytes(uint32(1), uint32(2), byte(3)), "invalid selection encoding size 3"},
+		{
+			"truncated points",
+			selectionBytes(uint32(1), uint32(1), uint32(0), uint32(0), uint32(2), uint32(1000), uint32(1)),
+			"point selection truncated",
+		},
+		{
+			"truncated blocks",
+			selectionBytes(uint32(2), uint32(1), uint32(0), uint32(0), uint32(1), uint32(1<<30)),
+			"hyperslab selection truncated",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseRegionSelection(tt.data)
+			require.ErrorContains(t, err, tt.want)
+		})
+	}
+}
+
+func TestEncodeHyperslabRegion(t *testing.T) {
+	data, err := EncodeHyperslabRegion(0x1234, []uint64{2, 3}, []uint64{4, 1}, 8)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0x1234), binary.LittleEndian.Uint64(data))
+
+	sel, err := ParseRegionSelection(data[8:])
+	require.NoError(t, err)
+	require.Equal(t, []RegionBlock{{Start: []uint64{2, 3}, End: []uint64{5, 3}}}, sel.Blocks)
+
+	_, err = EncodeHyperslabRegion(0, []uint64{0}, []uint64{0}, 8)
+	require.ErrorContains(t, err, "must be positive")
+	_, err = EncodeHyperslabRegion(0, []uint64{0, 0}, []uint64{1}, 8)
+	require.ErrorContains(t, err, "same non-zero rank")
+	_, err = EncodeHyperslabRegion(0, []uint64{1 << 32}, []uint64{1}, 8)
+	require.ErrorContains(t, err, "exceeds 32-bit")
+}
@@ -0,0 +1,66 @@
+package core
+
+import (
+	"fmt"
+	"io"
+)
+
+// Shared message types of version 3 shared messages.
+const (
+	sharedTypeSOHM      = 1 // Stored in the shared object header message heap.
+	sharedTypeCommitted = 2 // Stored in another object header (committed).
+)
+
+// ParseSharedMessageAddress returns the object header address referenced by
+// a shared message, as used for committed datatypes.
+//
+// Format:
+//   - Version 1: version(1), type(1), reserved(6), address
+//   - Version 2: version(1), type(1), address
+//   - Version 3: version(1), type(1), address (committed) or heap ID (SOHM)
+//
+// Messages shared through the shared object header message heap are not
+// supported.
+//
+// Reference: H5Oshared.c - H5O__shared_decode().
+func ParseSharedMessageAddress(data []byte, offsetSize int) (uint64, error) {
+	if len(data) < 2 {
+		return 0, fmt.Errorf("shared message too short: %d bytes", len(data))
+	}
+
+	offset := 2
+	switch version := data[0]; version {
+	case 1:
+		offset = 8
+	case 2:
+	case 3:
+		if data[1] == sharedTypeSOHM {
+			return 0, fmt.Errorf("messages in the shared object header message heap are not supported")
+		}
+		if data[1] != sharedTypeCommitted {
+			return 0, fmt.Errorf("unknown shared message type: %d", data[1])
+		}
+	default:
+		return 0, fmt.Errorf("unsupported shared message version: %d", version)
+	}
+
+	if offset+offsetSize > len(data) {
+		return 0, fmt.Errorf("shared message too short for address: %d bytes", len(data))
+	}
+	return readAddress(data[offset:offset+offsetSize], offsetSize), nil
+}
+
+// ReadCommittedDatatype reads the datatype stored in the committed datatype
+// object at address. The attributes of the object are not parsed.
+func ReadCommittedDatatype(r io.ReaderAt, address uint64, sb *Superblock) (*DatatypeMessage, error) {
+	header, err := readObjectHeader(r, address, sb, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read committed datatype header: %w", err)
+	}
+	for _, msg := range header.Messages {
+		if msg.Type == MsgDatatype {
+			return ParseDatatypeMessage(msg.Data)
+		}
+	}
+	return nil, fmt.Errorf("object at 0x%X is not a committed datatype", address)
+}
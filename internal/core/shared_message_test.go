@@ -0,0 +1,34 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSharedMessageAddress(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		want    uint64
+		wantErr string
+	}{
+		{"version 1", []byte{1, 0, 0, 0, 0, 0, 0, 0, 0x20, 0x03, 0, 0, 0, 0, 0, 0}, 0x320, ""},
+		{"version 2", []byte{2, 2, 0x20, 0x03, 0, 0, 0, 0, 0, 0}, 0x320, ""},
+		{"version 3 committed", []byte{3, 2, 0x00, 0x10, 0, 0, 0, 0, 0, 0}, 0x1000, ""},
+		{"version 3 heap", []byte{3, 1, 1, 2, 3, 4, 5, 6, 7, 8}, 0, "not supported"},
+		{"unknown version", []byte{4, 2, 0, 0, 0, 0, 0, 0, 0, 0}, 0, "unsupported shared message version"},
+		{"truncated", []byte{2, 2, 0x20, 0x03}, 0, "too short"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSharedMessageAddress(tt.data, 8)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
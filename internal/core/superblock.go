@@ -35,6 +35,34 @@ type Superblock struct {
 	// These are only used when Version == 0
 	RootBTreeAddr uint64 // B-tree address for root group (v0 only)
 	RootHeapAddr  uint64 // Local heap address for root group (v0 only)
+
+	// V0-specific: symbol table B-tree K values. Version 2 and 3 superblocks
+	// keep them in the superblock extension instead.
+	GroupLeafK     uint16 // Group leaf node K (v0 only)
+	GroupInternalK uint16 // Group internal node K (v0 only)
+}
+
+// LocateSuperblock returns the absolute file offset of the superblock. The
+// signature is searched at offset 0 and then at every power of two from 512
+// up to the file size, the possible sizes of a user block.
+//
+// Reference: H5FDint.c - H5FD_locate_signature().
+func LocateSuperblock(r io.ReaderAt, size int64) (uint64, error) {
+	buf := make([]byte, len(Signature))
+	for addr := int64(0); addr+int64(len(Signature)) <= size; {
+		if _, err := r.ReadAt(buf, addr); err != nil && !errors.Is(err, io.EOF) {
+			return 0, utils.WrapError("signature read failed", err)
+		}
+		if string(buf) == Signature {
+			return uint64(addr), nil
+		}
+		if addr == 0 {
+			addr = 512
+		} else {
+			addr *= 2
+		}
+	}
+	return 0, errors.New("not an HDF5 file")
 }
 
 // ReadSuperblock reads and parses the HDF5 superblock from the file.
@@ -65,10 +93,14 @@ func ReadSuperblock(r io.ReaderAt) (*Superblock, error) {
 	var offsetSize, lengthSize uint8
 
 	if version == Version0 {
-		// For v0: sizes in bytes 13-14, endianness presumably little-endian (check spec)
+		// For v0: sizes in bytes 13-14. The format has no byte order field:
+		// all file metadata is little-endian regardless of the platform that
+		// wrote it. Raw data byte order is recorded per datatype (class bit
+		// field bit 0) and handled by DatatypeMessage.GetByteOrder.
+		// Reference: HDF5 File Format Specification, section II.
 		offsetSize = buf[13]
 		lengthSize = buf[14]
-		endianness = binary.LittleEndian // v0 files are typically little-endian
+		endianness = binary.LittleEndian
 	} else {
 		// For v2 and v3: endianness in byte 9, packed sizes in byte 10
 		// Byte 9: flags byte - bit 0 is endianness (0=LE, 1=BE)
@@ -156,7 +188,10 @@ func ReadSuperblock(r io.ReaderAt) (*Superblock, error) {
 	}
 
 	if version == Version0 {
-		sb.BaseAddress = 0
+		sb.BaseAddress, err = readValue(24, offsetSize)
+		if err != nil {
+			return nil, utils.WrapError("base address read failed", err)
+		}
 		// Version 0 superblock structure:
 		// Offset 24-31: Base address
 		// Offset 32-39: Free space index
@@ -170,6 +205,15 @@ func ReadSuperblock(r io.ReaderAt) (*Superblock, error) {
 		//   80-87: B-tree address (8 bytes) - for cached symbol table
 		//   88-95: Local heap address (8 bytes) - for cached symbol table
 
+		// Offset 16-19: Group leaf node K and group internal node K
+		sb.GroupLeafK = binary.LittleEndian.Uint16(buf[16:18])
+		sb.GroupInternalK = binary.LittleEndian.Uint16(buf[18:20])
+
+		sb.DriverInfo, err = readValue(24+3*int(offsetSize), offsetSize)
+		if err != nil {
+			return nil, utils.WrapError("driver info address read failed", err)
+		}
+
 		// Read object header address at offset 64
 		sb.RootGroup, err = readValue(64, offsetSize)
 		if err != nil {
@@ -0,0 +1,110 @@
+package core
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuperblockExtensionMessages(t *testing.T) {
+	sb := &Superblock{OffsetSize: 8, LengthSize: 8}
+
+	t.Run("b-tree K values", func(t *testing.T) {
+		var ext SuperblockExtension
+		require.NoError(t, ext.parseBTreeK([]byte{0, 64, 0, 16, 0, 4, 0}))
+		require.True(t, ext.HasBTreeK)
+		require.Equal(t, uint16(64), ext.ChunkIndexK)
+		require.Equal(t, uint16(16), ext.GroupInternalK)
+		require.Equal(t, uint16(4), ext.GroupLeafK)
+
+		require.Error(t, ext.parseBTreeK([]byte{0, 64, 0}))
+		require.Error(t, ext.parseBTreeK([]byte{1, 64, 0, 16, 0, 4, 0}))
+	})
+
+	t.Run("file space info v1", func(t *testing.T) {
+		data := []byte{1, 1, 1}
+		data = append(data, 1, 0, 0, 0, 0, 0, 0, 0)    // threshold
+		data = append(data, 0, 0x20, 0, 0, 0, 0, 0, 0) // page size 8192
+		var ext SuperblockExtension
+		require.NoError(t, ext.parseFileSpaceInfo(data, sb))
+		require.True(t, ext.HasFileSpace)
+		require.Equal(t, FileSpaceStrategyPage, ext.FileSpaceStrategy)
+		require.True(t, ext.FreeSpacePersist)
+		require.Equal(t, uint64(1), ext.FreeSpaceThreshold)
+		require.Equal(t, uint64(8192), ext.PageSize)
+
+		data[1] = 4
+		require.Error(t, ext.parseFileSpaceInfo(data, sb))
+	})
+
+	t.Run("file space info v0", func(t *testing.T) {
+		data := []byte{0, 1, 10, 0, 0, 0, 0, 0, 0, 0}
+		var ext SuperblockExtension
+		require.NoError(t, ext.parseFileSpaceInfo(data, sb))
+		require.Equal(t, FileSpaceStrategyFSMAggr, ext.FileSpaceStrategy)
+		require.True(t, ext.FreeSpacePersist)
+		require.Equal(t, uint64(10), ext.FreeSpaceThreshold)
+		require.Zero(t, ext.PageSize)
+
+		require.Error(t, ext.parseFileSpaceInfo([]byte{2, 0}, sb))
+	})
+
+	t.Run("shared message table", func(t *testing.T) {
+		var ext SuperblockExtension
+		require.NoError(t, ext.parseSharedMessageTable([]byte{0, 0x61, 0, 0, 0, 0, 0, 0, 0, 4}, sb))
+		require.True(t, ext.HasSharedMessages)
+		require.Equal(t, uint64(0x61), ext.SharedMessageTable)
+		require.Equal(t, uint8(4), ext.SharedIndexes)
+	})
+
+	t.Run("driver info", func(t *testing.T) {
+		var ext SuperblockExtension
+		require.NoError(t, ext.parseDriverInfo([]byte{0, 'N', 'C', 'S', 'A', 'm', 'u', 'l', 't', 0, 0}))
+		require.Equal(t, DriverMulti, ext.Driver)
+	})
+}
+
+func TestReadSuperblockExtension(t *testing.T) {
+	tests := []struct {
+		file      string
+		istoreK   uint16
+		strategy  string
+		pageSize  uint64
+		sharedIdx uint8
+	}{
+		{"h5fc_ext1_i.h5", 64, "", 0, 0},
+		{"h5fc_ext1_s.h5", 0, "", 0, 4},
+		{"file_space.h5", 0, FileSpaceStrategyNone, 8192, 0},
+		{"h5fc_ext3_isf.h5", 64, FileSpaceStrategyNone, 4096, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			f, err := os.Open("../../testdata/hdf5_official/" + tt.file)
+			require.NoError(t, err)
+			defer func() { _ = f.Close() }()
+
+			sb, err := ReadSuperblock(f)
+			require.NoError(t, err)
+			ext, err := ReadSuperblockExtension(f, sb)
+			require.NoError(t, err)
+			require.NotNil(t, ext)
+			require.Equal(t, tt.istoreK, ext.ChunkIndexK)
+			require.Equal(t, tt.strategy, ext.FileSpaceStrategy)
+			require.Equal(t, tt.pageSize, ext.PageSize)
+			require.Equal(t, tt.sharedIdx, ext.SharedIndexes)
+		})
+	}
+
+	t.Run("no extension", func(t *testing.T) {
+		f, err := os.Open("../../testdata/hdf5_official/h5fc_ext_none.h5")
+		require.NoError(t, err)
+		defer func() { _ = f.Close() }()
+
+		sb, err := ReadSuperblock(f)
+		require.NoError(t, err)
+		ext, err := ReadSuperblockExtension(f, sb)
+		require.NoError(t, err)
+		require.Nil(t, ext)
+	})
+}
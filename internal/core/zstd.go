@@ -0,0 +1,969 @@
+package core
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/bits"
+)
+
+// Zstandard decompression (RFC 8878), decode only.
+//
+// HDF5 stores Zstd-filtered chunks (filter ID 32015, registered by the
+// hdf5plugin / HDF5 Zstd plugin) as one or more plain Zstandard frames. The
+// decoder below implements the full frame format except dictionaries, which
+// the HDF5 filter never uses.
+//
+// Reference: https://www.rfc-editor.org/rfc/rfc8878
+
+const (
+	zstdMagic             = 0xFD2FB528
+	zstdSkippableMagic    = 0x184D2A50 // Low 4 bits are user-defined.
+	zstdSkippableMask     = 0xFFFFFFF0
+	zstdMaxBlockSize      = 128 << 10
+	zstdMaxHuffmanBits    = 11
+	zstdMaxLLAccuracyLog  = 9
+	zstdMaxMLAccuracyLog  = 9
+	zstdMaxOFAccuracyLog  = 8
+	zstdMaxHufAccuracyLog = 6
+)
+
+// Block types (RFC 8878 section 3.1.1.2.2).
+const (
+	zstdBlockRaw        = 0
+	zstdBlockRLE        = 1
+	zstdBlockCompressed = 2
+)
+
+// Literals block types (RFC 8878 section 3.1.1.3.1.1).
+const (
+	zstdLiteralsRaw        = 0
+	zstdLiteralsRLE        = 1
+	zstdLiteralsCompressed = 2 // Type 3 (treeless) reuses the previous Huffman table.
+)
+
+// Sequence symbol compression modes (RFC 8878 section 3.1.1.3.2.1).
+const (
+	zstdModePredefined = 0
+	zstdModeRLE        = 1
+	zstdModeFSE        = 2 // Mode 3 (repeat) reuses the previous table.
+)
+
+// Predefined FSE distributions (RFC 8878 section 3.1.1.3.2.2).
+var (
+	zstdLLDefaultDist = []int16{4, 3, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1,
+		2, 2, 2, 2, 2, 2, 2, 2, 2, 3, 2, 1, 1, 1, 1, 1, -1, -1, -1, -1}
+	zstdMLDefaultDist = []int16{1, 4, 3, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1,
+		1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+		1, 1, 1, 1, 1, 1, -1, -1, -1, -1, -1, -1, -1}
+	zstdOFDefaultDist = []int16{1, 1, 1, 1, 1, 1, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1,
+		1, 1, 1, 1, 1, 1, 1, 1, -1, -1, -1, -1, -1}
+)
+
+// Literals length and match length codes: baseline and number of extra bits
+// (RFC 8878 section 3.1.1.3.2.1.1).
+var (
+	zstdLLBase = [36]uint32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15,
+		16, 18, 20, 22, 24, 28, 32, 40, 48, 64, 128, 256, 512, 1024, 2048, 4096,
+		8192, 16384, 32768, 65536}
+	zstdLLBits = [36]uint8{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		1, 1, 1, 1, 2, 2, 3, 3, 4, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	zstdMLBase = [53]uint32{3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18,
+		19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34,
+		35, 37, 39, 41, 43, 47, 51, 59, 67, 83, 99, 131, 259, 515, 1027, 2051,
+		4099, 8195, 16387, 32771, 65539}
+	zstdMLBits = [53]uint8{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		1, 1, 1, 1, 2, 2, 3, 3, 4, 4, 5, 7, 8, 9, 10, 11,
+		12, 13, 14, 15, 16}
+)
+
+var errZstdCorrupt = errors.New("zstd: corrupted data")
+
+// zstdDecompress decompresses all Zstandard frames in src. Skippable frames
+// are ignored.
+func zstdDecompress(src []byte) ([]byte, error) {
+	var out []byte
+	for len(src) > 0 {
+		if len(src) < 4 {
+			return nil, fmt.Errorf("zstd: truncated frame header")
+		}
+		magic := binary.LittleEndian.Uint32(src)
+		if magic&zstdSkippableMask == zstdSkippableMagic {
+			if len(src) < 8 {
+				return nil, fmt.Errorf("zstd: truncated skippable frame")
+			}
+			size := uint64(binary.LittleEndian.Uint32(src[4:]))
+			if size > uint64(len(src)-8) {
+				return nil, fmt.Errorf("zstd: truncated skippable frame")
+			}
+			src = src[8+size:]
+			continue
+		}
+		if magic != zstdMagic {
+			return nil, fmt.Errorf("zstd: invalid magic number 0x%08x", magic)
+		}
+
+		var (
+			n   int
+			err error
+		)
+		out, n, err = zstdDecodeFrame(out, src[4:])
+		if err != nil {
+			return nil, err
+		}
+		src = src[4+n:]
+	}
+	return out, nil
+}
+
+// zstdDecodeFrame decodes one frame (after the magic number), appending the
+// content to out. Returns the number of bytes of src consumed.
+func zstdDecodeFrame(out, src []byte) ([]byte, int, error) {
+	if len(src) < 1 {
+		return nil, 0, fmt.Errorf("zstd: truncated frame header")
+	}
+	desc := src[0]
+	fcsFlag := desc >> 6
+	singleSegment := desc&0x20 != 0
+	hasChecksum := desc&0x04 != 0
+	dictIDFlag := desc & 0x03
+	if desc&0x08 != 0 {
+		return nil, 0, fmt.Errorf("zstd: reserved frame header bit set")
+	}
+
+	pos := 1
+	if !singleSegment {
+		pos++ // Window descriptor: the whole frame is kept in memory.
+	}
+
+	dictIDSize := [4]int{0, 1, 2, 4}[dictIDFlag]
+	if len(src) < pos+dictIDSize {
+		return nil, 0, fmt.Errorf("zstd: truncated frame header")
+	}
+	var dictID uint32
+	for i := dictIDSize - 1; i >= 0; i-- {
+		dictID = dictID<<8 | uint32(src[pos+i])
+	}
+	if dictID != 0 {
+		return nil, 0, fmt.Errorf("zstd: dictionaries are not supported (dictionary ID %d)", dictID)
+	}
+	pos += dictIDSize
+
+	fcsSize := [4]int{0, 2, 4, 8}[fcsFlag]
+	if fcsFlag == 0 && singleSegment {
+		fcsSize = 1
+	}
+	if len(src) < pos+fcsSize {
+		return nil, 0, fmt.Errorf("zstd: truncated frame header")
+	}
+	var contentSize uint64
+	for i := fcsSize - 1; i >= 0; i-- {
+		contentSize = contentSize<<8 | uint64(src[pos+i])
+	}
+	if fcsSize == 2 {
+		contentSize += 256
+	}
+	pos += fcsSize
+
+	frameStart := len(out)
+	if fcsSize > 0 && contentSize <= uint64(len(src))*zstdMaxBlockSize {
+		// Reserve the declared size, bounded by what the input could produce.
+		out = append(make([]byte, 0, uint64(len(out))+contentSize), out...)
+	}
+
+	dec := &zstdDecoder{rep: [3]uint32{1, 4, 8}}
+	for {
+		if len(src) < pos+3 {
+			return nil, 0, fmt.Errorf("zstd: truncated block header")
+		}
+		header := uint32(src[pos]) | uint32(src[pos+1])<<8 | uint32(src[pos+2])<<16
+		pos += 3
+		last := header&1 != 0
+		blockType := (header >> 1) & 3
+		blockSize := int(header >> 3)
+
+		switch blockType {
+		case zstdBlockRaw:
+			if len(src) < pos+blockSize {
+				return nil, 0, fmt.Errorf("zstd: truncated raw block")
+			}
+			out = append(out, src[pos:pos+blockSize]...)
+			pos += blockSize
+		case zstdBlockRLE:
+			if len(src) < pos+1 {
+				return nil, 0, fmt.Errorf("zstd: truncated RLE block")
+			}
+			for i := 0; i < blockSize; i++ {
+				out = append(out, src[pos])
+			}
+			pos++
+		case zstdBlockCompressed:
+			if blockSize > zstdMaxBlockSize || len(src) < pos+blockSize {
+				return nil, 0, fmt.Errorf("zstd: truncated compressed block")
+			}
+			var err error
+			out, err = dec.decodeBlock(out, frameStart, src[pos:pos+blockSize])
+			if err != nil {
+				return nil, 0, err
+			}
+			pos += blockSize
+		default:
+			return nil, 0, fmt.Errorf("zstd: reserved block type")
+		}
+
+		if last {
+			break
+		}
+	}
+
+	content := out[frameStart:]
+	if fcsSize > 0 && uint64(len(content)) != contentSize {
+		return nil, 0, fmt.Errorf("zstd: frame content size %d, expected %d", len(content), contentSize)
+	}
+	if hasChecksum {
+		if len(src) < pos+4 {
+			return nil, 0, fmt.Errorf("zstd: truncated content checksum")
+		}
+		stored := binary.LittleEndian.Uint32(src[pos:])
+		computed := uint32(xxhash64(content)) //nolint:gosec // G115: checksum is the low 32 bits
+		if stored != computed {
+			return nil, 0, &ChecksumError{Filter: "Zstd", Stored: stored, Computed: computed}
+		}
+		pos += 4
+	}
+	return out, pos, nil
+}
+
+// zstdDecoder holds the state kept between the blocks of a frame.
+type zstdDecoder struct {
+	rep     [3]uint32 // Repeated offsets.
+	huffman *zstdHuffmanTable
+	ll      *zstdFSETable
+	of      *zstdFSETable
+	ml      *zstdFSETable
+	lits    []byte
+}
+
+// decodeBlock decodes a compressed block, appending to out. History for
+// matches starts at out[frameStart].
+func (d *zstdDecoder) decodeBlock(out []byte, frameStart int, src []byte) ([]byte, error) {
+	n, err := d.decodeLiterals(src)
+	if err != nil {
+		return nil, err
+	}
+	return d.decodeSequences(out, frameStart, src[n:])
+}
+
+// decodeLiterals decodes the literals section into d.lits and returns its
+// size in bytes.
+func (d *zstdDecoder) decodeLiterals(src []byte) (int, error) {
+	if len(src) < 1 {
+		return 0, errZstdCorrupt
+	}
+	litType := src[0] & 3
+	sizeFormat := (src[0] >> 2) & 3
+
+	if litType == zstdLiteralsRaw || litType == zstdLiteralsRLE {
+		var regen, hdr int
+		switch sizeFormat {
+		case 0, 2:
+			regen, hdr = int(src[0]>>3), 1
+		case 1:
+			if len(src) < 2 {
+				return 0, errZstdCorrupt
+			}
+			regen, hdr = int(src[0]>>4)+int(src[1])<<4, 2
+		case 3:
+			if len(src) < 3 {
+				return 0, errZstdCorrupt
+			}
+			regen, hdr = int(src[0]>>4)+int(src[1])<<4+int(src[2])<<12, 3
+		}
+		if regen > zstdMaxBlockSize {
+			return 0, errZstdCorrupt
+		}
+		if litType == zstdLiteralsRaw {
+			if len(src) < hdr+regen {
+				return 0, errZstdCorrupt
+			}
+			d.lits = src[hdr : hdr+regen]
+			return hdr + regen, nil
+		}
+		if len(src) < hdr+1 {
+			return 0, errZstdCorrupt
+		}
+		d.lits = make([]byte, regen)
+		for i := range d.lits {
+			d.lits[i] = src[hdr]
+		}
+		return hdr + 1, nil
+	}
+
+	// Compressed or treeless literals: sizes use 10, 14 or 18 bits each.
+	hdr := [4]int{3, 3, 4, 5}[sizeFormat]
+	sizeBits := [4]uint{10, 10, 14, 18}[sizeFormat]
+	streams := 4
+	if sizeFormat == 0 {
+		streams = 1
+	}
+	if len(src) < hdr {
+		return 0, errZstdCorrupt
+	}
+	var h uint64
+	for i := hdr - 1; i >= 0; i-- {
+		h = h<<8 | uint64(src[i])
+	}
+	mask := uint64(1)<<sizeBits - 1
+	regen := int((h >> 4) & mask)
+	compSize := int((h >> (4 + sizeBits)) & mask)
+	if regen > zstdMaxBlockSize || len(src) < hdr+compSize {
+		return 0, errZstdCorrupt
+	}
+	data := src[hdr : hdr+compSize]
+
+	if litType == zstdLiteralsCompressed {
+		table, n, err := zstdReadHuffmanTable(data)
+		if err != nil {
+			return 0, err
+		}
+		d.huffman = table
+		data = data[n:]
+	} else if d.huffman == nil {
+		return 0, fmt.Errorf("zstd: treeless literals without a previous Huffman table")
+	}
+
+	lits := make([]byte, regen)
+	if streams == 1 {
+		if err := d.huffman.decode(lits, data); err != nil {
+			return 0, err
+		}
+	} else {
+		if len(data) < 6 {
+			return 0, errZstdCorrupt
+		}
+		s1 := int(binary.LittleEndian.Uint16(data))
+		s2 := int(binary.LittleEndian.Uint16(data[2:]))
+		s3 := int(binary.LittleEndian.Uint16(data[4:]))
+		data = data[6:]
+		if s1+s2+s3 > len(data) {
+			return 0, errZstdCorrupt
+		}
+		sizes := [4]int{s1, s2, s3, len(data) - s1 - s2 - s3}
+		segment := (regen + 3) / 4
+		if 3*segment > regen {
+			return 0, errZstdCorrupt
+		}
+		for i, size := range sizes {
+			dst := lits[i*segment:]
+			if i < 3 {
+				dst = dst[:segment]
+			}
+			if err := d.huffman.decode(dst, data[:size]); err != nil {
+				return 0, err
+			}
+			data = data[size:]
+		}
+	}
+	d.lits = lits
+	return hdr + compSize, nil
+}
+
+// decodeSequences decodes the sequences section and executes the sequences,
+// appending the block content to out.
+func (d *zstdDecoder) decodeSequences(out []byte, frameStart int, src []byte) ([]byte, error) {
+	if len(src) < 1 {
+		return nil, errZstdCorrupt
+	}
+	var numSeq, pos int
+	switch b0 := int(src[0]); {
+	case b0 < 128:
+		numSeq, pos = b0, 1
+	case b0 < 255:
+		if len(src) < 2 {
+			return nil, errZstdCorrupt
+		}
+		numSeq, pos = (b0-128)<<8+int(src[1]), 2
+	default:
+		if len(src) < 3 {
+			return nil, errZstdCorrupt
+		}
+		numSeq, pos = int(src[1])+int(src[2])<<8+0x7F00, 3
+	}
+	if numSeq == 0 {
+		return append(out, d.lits...), nil
+	}
+
+	if len(src) < pos+1 {
+		return nil, errZstdCorrupt
+	}
+	modes := src[pos]
+	pos++
+	if modes&3 != 0 {
+		return nil, fmt.Errorf("zstd: reserved sequence mode bits set")
+	}
+
+	var err error
+	var n int
+	if d.ll, n, err = zstdSequenceTable(d.ll, modes>>6, src[pos:], zstdLLDefaultDist, 6, zstdMaxLLAccuracyLog, 35); err != nil {
+		return nil, fmt.Errorf("zstd: literals lengths table: %w", err)
+	}
+	pos += n
+	if d.of, n, err = zstdSequenceTable(d.of, (modes>>4)&3, src[pos:], zstdOFDefaultDist, 5, zstdMaxOFAccuracyLog, 31); err != nil {
+		return nil, fmt.Errorf("zstd: offsets table: %w", err)
+	}
+	pos += n
+	if d.ml, n, err = zstdSequenceTable(d.ml, (modes>>2)&3, src[pos:], zstdMLDefaultDist, 6, zstdMaxMLAccuracyLog, 52); err != nil {
+		return nil, fmt.Errorf("zstd: match lengths table: %w", err)
+	}
+	pos += n
+
+	br, err := newZstdBackwardReader(src[pos:])
+	if err != nil {
+		return nil, err
+	}
+	llState := br.read(d.ll.accuracyLog)
+	ofState := br.read(d.of.accuracyLog)
+	mlState := br.read(d.ml.accuracyLog)
+
+	lits := d.lits
+	for i := 0; i < numSeq; i++ {
+		llCode := d.ll.entries[llState].symbol
+		ofCode := d.of.entries[ofState].symbol
+		mlCode := d.ml.entries[mlState].symbol
+		if int(llCode) >= len(zstdLLBase) || int(mlCode) >= len(zstdMLBase) || ofCode > 31 {
+			return nil, errZstdCorrupt
+		}
+
+		// Extra bits are read in offset, match length, literals length order.
+		offsetValue := uint32(1)<<ofCode + uint32(br.read(ofCode)) //nolint:gosec // G115: at most 31 bits
+		matchLen := zstdMLBase[mlCode] + uint32(br.read(zstdMLBits[mlCode]))
+		litLen := zstdLLBase[llCode] + uint32(br.read(zstdLLBits[llCode]))
+
+		offset, err := d.offset(offsetValue, litLen)
+		if err != nil {
+			return nil, err
+		}
+
+		if uint64(litLen) > uint64(len(lits)) {
+			return nil, fmt.Errorf("zstd: literals length %d exceeds remaining literals", litLen)
+		}
+		out = append(out, lits[:litLen]...)
+		lits = lits[litLen:]
+
+		if uint64(offset) > uint64(len(out)-frameStart) {
+			return nil, fmt.Errorf("zstd: match offset %d beyond frame start", offset)
+		}
+		start := len(out) - int(offset)
+		for j := 0; j < int(matchLen); j++ { // Byte by byte: matches may overlap.
+			out = append(out, out[start+j])
+		}
+
+		if i < numSeq-1 {
+			// States are updated in literals length, match length, offset order.
+			llState = d.ll.next(llState, br)
+			mlState = d.ml.next(mlState, br)
+			ofState = d.of.next(ofState, br)
+		}
+	}
+	if br.overflow() {
+		return nil, fmt.Errorf("zstd: sequences bitstream overrun")
+	}
+	return append(out, lits...), nil
+}
+
+// offset resolves an offset value to a match offset, updating the repeated
+// offsets (RFC 8878 section 3.1.1.5).
+func (d *zstdDecoder) offset(value, litLen uint32) (uint32, error) {
+	if value > 3 {
+		d.rep[2], d.rep[1], d.rep[0] = d.rep[1], d.rep[0], value-3
+		return value - 3, nil
+	}
+
+	idx := value - 1
+	if litLen == 0 {
+		idx++
+	}
+	switch idx {
+	case 0:
+		return d.rep[0], nil
+	case 1:
+		d.rep[1], d.rep[0] = d.rep[0], d.rep[1]
+	case 2:
+		d.rep[2], d.rep[1], d.rep[0] = d.rep[1], d.rep[0], d.rep[2]
+	default:
+		off := d.rep[0] - 1
+		if off == 0 {
+			return 0, errZstdCorrupt
+		}
+		d.rep[2], d.rep[1], d.rep[0] = d.rep[1], d.rep[0], off
+	}
+	return d.rep[0], nil
+}
+
+// zstdSequenceTable returns the FSE table for one sequence symbol type and
+// the number of bytes of src its description used.
+func zstdSequenceTable(prev *zstdFSETable, mode uint8, src []byte, dist []int16, defaultLog, maxLog uint8, maxSymbol int) (*zstdFSETable, int, error) {
+	switch mode {
+	case zstdModePredefined:
+		table, err := newZstdFSETable(dist, defaultLog)
+		return table, 0, err
+	case zstdModeRLE:
+		if len(src) < 1 {
+			return nil, 0, errZstdCorrupt
+		}
+		if int(src[0]) > maxSymbol {
+			return nil, 0, errZstdCorrupt
+		}
+		return &zstdFSETable{entries: []zstdFSEEntry{{symbol: src[0]}}}, 1, nil
+	case zstdModeFSE:
+		counts, log, n, err := zstdReadFSECounts(src, maxSymbol, maxLog)
+		if err != nil {
+			return nil, 0, err
+		}
+		table, err := newZstdFSETable(counts, log)
+		return table, n, err
+	default:
+		if prev == nil {
+			return nil, 0, fmt.Errorf("repeat mode without a previous table")
+		}
+		return prev, 0, nil
+	}
+}
+
+// zstdFSEEntry is one state of an FSE decoding table.
+type zstdFSEEntry struct {
+	symbol   uint8
+	nbBits   uint8
+	baseline uint16
+}
+
+// zstdFSETable is an FSE decoding table.
+type zstdFSETable struct {
+	accuracyLog uint8
+	entries     []zstdFSEEntry
+}
+
+// next returns the state following state, reading its bits from br.
+func (t *zstdFSETable) next(state uint64, br *zstdBackwardReader) uint64 {
+	e := t.entries[state]
+	return uint64(e.baseline) + br.read(e.nbBits)
+}
+
+// newZstdFSETable builds a decoding table from normalized counts, where -1
+// marks a "less than 1" probability (RFC 8878 section 4.1.1).
+func newZstdFSETable(counts []int16, accuracyLog uint8) (*zstdFSETable, error) {
+	size := 1 << accuracyLog
+	entries := make([]zstdFSEEntry, size)
+	next := make([]uint32, len(counts))
+
+	high := size - 1
+	for s, c := range counts {
+		if c == -1 {
+			entries[high].symbol = uint8(s) //nolint:gosec // G115: at most 256 symbols
+			high--
+			next[s] = 1
+		} else {
+			next[s] = uint32(c) //nolint:gosec // G115: counts are non-negative here
+		}
+	}
+
+	step := size>>1 + size>>3 + 3
+	mask := size - 1
+	pos := 0
+	for s, c := range counts {
+		for i := 0; i < int(c); i++ {
+			entries[pos].symbol = uint8(s) //nolint:gosec // G115: at most 256 symbols
+			pos = (pos + step) & mask
+			for pos > high {
+				pos = (pos + step) & mask
+			}
+		}
+	}
+	if pos != 0 {
+		return nil, fmt.Errorf("invalid FSE distribution")
+	}
+
+	for i := range entries {
+		s := entries[i].symbol
+		state := next[s]
+		next[s]++
+		nb := int(accuracyLog) - (bits.Len32(state) - 1)
+		entries[i].nbBits = uint8(nb)                       //nolint:gosec // G115: nb <= accuracyLog
+		entries[i].baseline = uint16(int(state)<<nb - size) //nolint:gosec // G115: baseline < table size
+	}
+	return &zstdFSETable{accuracyLog: accuracyLog, entries: entries}, nil
+}
+
+// zstdReadFSECounts reads an FSE table description (RFC 8878 section
+// 4.1.1). Returns the normalized counts, the accuracy log and the number of
+// bytes used.
+func zstdReadFSECounts(src []byte, maxSymbol int, maxLog uint8) ([]int16, uint8, int, error) {
+	br := zstdForwardReader{data: src}
+	accuracyLog := uint8(br.read(4)) + 5 //nolint:gosec // G115: 4 bits
+	if accuracyLog > maxLog {
+		return nil, 0, 0, fmt.Errorf("FSE accuracy log %d exceeds %d", accuracyLog, maxLog)
+	}
+
+	counts := make([]int16, 0, maxSymbol+1)
+	remaining := 1<<accuracyLog + 1
+	threshold := 1 << accuracyLog
+	nbBits := uint8(accuracyLog + 1)
+	for remaining > 1 {
+		if len(counts) > maxSymbol {
+			return nil, 0, 0, fmt.Errorf("FSE table has too many symbols")
+		}
+		maxSmall := 2*threshold - 1 - remaining
+		var value int
+		if low := int(br.peek(nbBits - 1)); low < maxSmall {
+			value = low
+			br.skip(nbBits - 1)
+		} else {
+			value = int(br.peek(nbBits))
+			if value >= threshold {
+				value -= maxSmall
+			}
+			br.skip(nbBits)
+		}
+
+		count := value - 1
+		if count < 0 {
+			remaining--
+		} else {
+			remaining -= count
+		}
+		counts = append(counts, int16(count)) //nolint:gosec // G115: count bounded by table size
+
+		if count == 0 {
+			// Zero probability: a 2-bit repeat flag gives further zeros.
+			for {
+				repeat := int(br.read(2))
+				for i := 0; i < repeat; i++ {
+					counts = append(counts, 0)
+				}
+				if repeat != 3 {
+					break
+				}
+			}
+		}
+		for remaining < threshold {
+			nbBits--
+			threshold >>= 1
+		}
+		if br.overflow() {
+			return nil, 0, 0, errZstdCorrupt
+		}
+	}
+	if remaining != 1 || len(counts) > maxSymbol+1 {
+		return nil, 0, 0, fmt.Errorf("invalid FSE table description")
+	}
+	return counts, accuracyLog, br.bytesUsed(), nil
+}
+
+// zstdHuffmanTable is a Huffman decoding table indexed by the next maxBits
+// bits of the stream.
+type zstdHuffmanTable struct {
+	maxBits uint8
+	symbols []uint8
+	nbBits  []uint8
+}
+
+// zstdReadHuffmanTable reads a Huffman tree description (RFC 8878 section
+// 4.2.1) and returns the table and the number of bytes used.
+func zstdReadHuffmanTable(src []byte) (*zstdHuffmanTable, int, error) {
+	if len(src) < 1 {
+		return nil, 0, errZstdCorrupt
+	}
+
+	var weights []uint8
+	header := int(src[0])
+	used := 1
+	if header < 128 {
+		// FSE-compressed weights.
+		if len(src) < 1+header {
+			return nil, 0, errZstdCorrupt
+		}
+		data := src[1 : 1+header]
+		counts, log, n, err := zstdReadFSECounts(data, 255, zstdMaxHufAccuracyLog)
+		if err != nil {
+			return nil, 0, fmt.Errorf("zstd: Huffman weights table: %w", err)
+		}
+		table, err := newZstdFSETable(counts, log)
+		if err != nil {
+			return nil, 0, fmt.Errorf("zstd: Huffman weights table: %w", err)
+		}
+		br, err := newZstdBackwardReader(data[n:])
+		if err != nil {
+			return nil, 0, err
+		}
+
+		// Two interleaved states; decoding stops once the stream overruns.
+		state1 := br.read(log)
+		state2 := br.read(log)
+		for {
+			if len(weights) >= 255 {
+				return nil, 0, errZstdCorrupt
+			}
+			weights = append(weights, table.entries[state1].symbol)
+			state1 = table.next(state1, br)
+			if br.overflow() {
+				weights = append(weights, table.entries[state2].symbol)
+				break
+			}
+			weights = append(weights, table.entries[state2].symbol)
+			state2 = table.next(state2, br)
+			if br.overflow() {
+				weights = append(weights, table.entries[state1].symbol)
+				break
+			}
+		}
+		used += header
+	} else {
+		// Weights stored directly, 4 bits each.
+		count := header - 127
+		size := (count + 1) / 2
+		if len(src) < 1+size {
+			return nil, 0, errZstdCorrupt
+		}
+		weights = make([]uint8, count)
+		for i := range weights {
+			b := src[1+i/2]
+			if i%2 == 0 {
+				weights[i] = b >> 4
+			} else {
+				weights[i] = b & 0x0F
+			}
+		}
+		used += size
+	}
+
+	// The last weight is implied by the others summing to a power of two.
+	var total uint32
+	for _, w := range weights {
+		if w > zstdMaxHuffmanBits {
+			return nil, 0, errZstdCorrupt
+		}
+		if w > 0 {
+			total += 1 << (w - 1)
+		}
+	}
+	if total == 0 {
+		return nil, 0, errZstdCorrupt
+	}
+	maxBits := uint8(bits.Len32(total)) //nolint:gosec // G115: small value
+	rest := uint32(1)<<maxBits - total
+	if rest&(rest-1) != 0 || maxBits > zstdMaxHuffmanBits {
+		return nil, 0, errZstdCorrupt
+	}
+	weights = append(weights, uint8(bits.Len32(rest))) //nolint:gosec // G115: small value
+
+	// Symbols take 1<<(weight-1) entries, lowest weights first.
+	t := &zstdHuffmanTable{
+		maxBits: maxBits,
+		symbols: make([]uint8, 1<<maxBits),
+		nbBits:  make([]uint8, 1<<maxBits),
+	}
+	var rankStart [zstdMaxHuffmanBits + 2]uint32
+	for _, w := range weights {
+		if w > 0 {
+			rankStart[w+1] += 1 << (w - 1)
+		}
+	}
+	for w := 2; w < len(rankStart); w++ {
+		rankStart[w] += rankStart[w-1]
+	}
+	for s, w := range weights {
+		if w == 0 {
+			continue
+		}
+		start := rankStart[w]
+		n := uint32(1) << (w - 1)
+		for i := start; i < start+n; i++ {
+			t.symbols[i] = uint8(s) //nolint:gosec // G115: at most 256 symbols
+			t.nbBits[i] = maxBits + 1 - w
+		}
+		rankStart[w] += n
+	}
+	return t, used, nil
+}
+
+// decode fills dst from one Huffman-coded stream.
+func (t *zstdHuffmanTable) decode(dst, src []byte) error {
+	br, err := newZstdBackwardReader(src)
+	if err != nil {
+		return err
+	}
+	for i := range dst {
+		idx := br.peek(t.maxBits)
+		dst[i] = t.symbols[idx]
+		br.skip(t.nbBits[idx])
+	}
+	if br.remaining != 0 {
+		return fmt.Errorf("zstd: Huffman stream size mismatch")
+	}
+	return nil
+}
+
+// zstdBackwardReader reads a bitstream from its end (RFC 8878 section
+// 4.1). Reads past the start return zero bits and mark the stream as
+// overrun.
+type zstdBackwardReader struct {
+	data      []byte
+	remaining int // Unread bits; negative after an overrun.
+}
+
+// newZstdBackwardReader locates the end marker in the last byte of src.
+func newZstdBackwardReader(src []byte) (*zstdBackwardReader, error) {
+	if len(src) == 0 || src[len(src)-1] == 0 {
+		return nil, fmt.Errorf("zstd: missing bitstream end marker")
+	}
+	last := src[len(src)-1]
+	return &zstdBackwardReader{
+		data:      src,
+		remaining: (len(src)-1)*8 + bits.Len8(last) - 1,
+	}, nil
+}
+
+// peek returns the next n bits (n <= 32) without consuming them.
+func (br *zstdBackwardReader) peek(n uint8) uint64 {
+	if n == 0 {
+		return 0
+	}
+	if br.remaining <= 0 {
+		return 0
+	}
+	if br.remaining < int(n) {
+		return br.extract(0, uint8(br.remaining)) << (int(n) - br.remaining) //nolint:gosec // G115: remaining < n
+	}
+	return br.extract(br.remaining-int(n), n)
+}
+
+// skip consumes n bits.
+func (br *zstdBackwardReader) skip(n uint8) {
+	br.remaining -= int(n)
+}
+
+// read consumes and returns the next n bits.
+func (br *zstdBackwardReader) read(n uint8) uint64 {
+	v := br.peek(n)
+	br.skip(n)
+	return v
+}
+
+// overflow reports whether more bits were read than the stream holds.
+func (br *zstdBackwardReader) overflow() bool {
+	return br.remaining < 0
+}
+
+// extract returns n bits (n <= 32) starting at bit pos (LSB of data[0] is bit 0).
+func (br *zstdBackwardReader) extract(pos int, n uint8) uint64 {
+	var buf [8]byte
+	copy(buf[:], br.data[pos/8:])
+	v := binary.LittleEndian.Uint64(buf[:]) >> (pos % 8)
+	return v & (1<<n - 1)
+}
+
+// zstdForwardReader reads a little-endian bitstream from its start.
+type zstdForwardReader struct {
+	data []byte
+	pos  int // Bits consumed.
+}
+
+// peek returns the next n bits (n <= 32) without consuming them.
+func (br *zstdForwardReader) peek(n uint8) uint64 {
+	var buf [8]byte
+	if i := br.pos / 8; i < len(br.data) {
+		copy(buf[:], br.data[i:])
+	}
+	v := binary.LittleEndian.Uint64(buf[:]) >> (br.pos % 8)
+	return v & (1<<n - 1)
+}
+
+// skip consumes n bits.
+func (br *zstdForwardReader) skip(n uint8) {
+	br.pos += int(n)
+}
+
+// read consumes and returns the next n bits.
+func (br *zstdForwardReader) read(n uint8) uint64 {
+	v := br.peek(n)
+	br.skip(n)
+	return v
+}
+
+// overflow reports whether more bits were read than the stream holds.
+func (br *zstdForwardReader) overflow() bool {
+	return br.pos > len(br.data)*8
+}
+
+// bytesUsed returns the number of bytes touched by the bits read so far.
+func (br *zstdForwardReader) bytesUsed() int {
+	return (br.pos + 7) / 8
+}
+
+// xxhash64 computes the XXH64 hash of data with seed 0, used for the
+// Zstandard content checksum.
+//
+// Reference: https://github.com/Cyan4973/xxHash/blob/dev/doc/xxhash_spec.md
+func xxhash64(data []byte) uint64 {
+	// Variables, not constants: the seed setup relies on wrapping arithmetic.
+	var (
+		p1 uint64 = 11400714785074694791
+		p2 uint64 = 14029467366897019727
+		p3 uint64 = 1609587929392839161
+		p4 uint64 = 9650029242287828579
+		p5 uint64 = 2870177450012600261
+	)
+	round := func(acc, lane uint64) uint64 {
+		acc += lane * p2
+		return bits.RotateLeft64(acc, 31) * p1
+	}
+	merge := func(h, v uint64) uint64 {
+		h ^= round(0, v)
+		return h*p1 + p4
+	}
+
+	n := len(data)
+	var h uint64
+	if n >= 32 {
+		v1, v2, v3, v4 := p1+p2, p2, uint64(0), -p1
+		for len(data) >= 32 {
+			v1 = round(v1, binary.LittleEndian.Uint64(data))
+			v2 = round(v2, binary.LittleEndian.Uint64(data[8:]))
+			v3 = round(v3, binary.LittleEndian.Uint64(data[16:]))
+			v4 = round(v4, binary.LittleEndian.Uint64(data[24:]))
+			data = data[32:]
+		}
+		h = bits.RotateLeft64(v1, 1) + bits.RotateLeft64(v2, 7) +
+			bits.RotateLeft64(v3, 12) + bits.RotateLeft64(v4, 18)
+		h = merge(h, v1)
+		h = merge(h, v2)
+		h = merge(h, v3)
+		h = merge(h, v4)
+	} else {
+		h = p5
+	}
+	h += uint64(n)
+
+	for len(data) >= 8 {
+		h ^= round(0, binary.LittleEndian.Uint64(data))
+		h = bits.RotateLeft64(h, 27)*p1 + p4
+		data = data[8:]
+	}
+	if len(data) >= 4 {
+		h ^= uint64(binary.LittleEndian.Uint32(data)) * p1
+		h = bits.RotateLeft64(h, 23)*p2 + p3
+		data = data[4:]
+	}
+	for _, b := range data {
+		h ^= uint64(b) * p5
+		h = bits.RotateLeft64(h, 11) * p1
+	}
+
+	h ^= h >> 33
+	h *= p2
+	h ^= h >> 29
+	h *= p3
+	h ^= h >> 32
+	return h
+}
@@ -0,0 +1,139 @@
+package core
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// zstdTestInput returns 4 KiB of pseudo-random words, enough for the
+// compressor to use Huffman-coded literals and FSE-coded sequences.
+func zstdTestInput() []byte {
+	words := []string{"chunk", "dataset", "filter", "group", "attribute", "hdf5", "zstd", "float64", "pipeline", "layout"}
+	var out []byte
+	x := uint32(1)
+	for len(out) < 4096 {
+		x = x*1103515245 + 12345
+		out = append(out, words[(x>>16)%uint32(len(words))]...)
+		out = append(out, byte(' '+(x>>8)%3))
+	}
+	return out[:4096]
+}
+
+// zstdTestInput compressed with "zstd -19" (content size and checksum set).
+const zstdTestFrameHex = "" +
+	"28b52ffd64000f45190012061211a0ed4668e777bd9a9efef7b7164bfc0f40e7" +
+	"ddef99cfdff3bf1c4fb78596df478e1ee67c7a9215bbc7ee3273539503d1e335" +
+	"c41b9051dcf3d1d1be70be986e5ad61fb7da810b06817fa87157560a85610d21" +
+	"08014491a876900f11201408416a145245a549d201b4c5792167e68efe881981" +
+	"c2b6a9c5c3e55db7fc7a087697aff5c94cacd7432b954414c60ba3fa3aaddd59" +
+	"2450f9dc3b0574e35beee3a202508ba95af2468abbf7333d3ee940347ea0ec5e" +
+	"405e6211b71f1bbda81eb57df060139216dc0667e8a0deff5a8a1647228b09f8" +
+	"2b52d8aa17b4ff0a0ac34aef86923a2b9176cde18bb9a3402b2c7b11b9a387ed" +
+	"04d8884b929bd9d229c68819e12fe7017e2ed349b386acc7784c77e5d76721dc" +
+	"1da6a626249e9e5485c6d966667aa4913664100edbcd05b42356a7a450df0503" +
+	"79d98580af2297cae4610c3e3f2e51462a7e247c2c20496b6522b640fe0aead4" +
+	"71d021ea2590360e35c3bf04e8fa10fe1f511d5dc46c98ab8c4e62e420c5502f" +
+	"f495893ea0d8a1f88c0ea7744c5597e54b190b446e7c11a8d649060927541a94" +
+	"62687a10ac45befd0f929a5ab0e39b90caf1f9f3039fdff478e8fff50da5573d" +
+	"d34330bcb9e78e00064e3684271850a2a95a82c011e374ae7924bb1765ca727e" +
+	"27c135111c870cd78b9a7c0b4e5d5ff858d2540c48e5deccd3acb0b6d6923dfc" +
+	"ae5b465295097e4f866d881421182d7de50247aad52b5f9cd437b60dcb2c0638" +
+	"fd02474e091e2a34e3aafb9266d09bf0b2dd603f9d12b6d644400a6bb4b69c89" +
+	"ec43b30ee9ce187af7ddcd61875a85f824d8973e0227ab455d9466110675f032" +
+	"6c65aa7b83cdcbf4199019dd5073d04d30231a124f7584484f2d8c409404a95b" +
+	"e4d9c129fec90ca7f9d8465c6ab5fe440f4ef97e48485bc27d1a974421d69faa" +
+	"574fd3abecf0cb7d59b830f0665ba1dcc78d6d3fff174b66e40a1e531318df59" +
+	"3d2d76773c7378a21ce7af54534cbff099b8bd7f2c7d4b80414de1103f560b00" +
+	"c54e4c1d3e7d9d612337f5886aec227ad18f8d20921dc2d6247653934d94fdbc" +
+	"8902321842f48909035634cc921088afe620e04180231f81d8eae2de388fa993" +
+	"960983e81e4e6a71d686e90e6a1afb25b0aa3e878743"
+
+// TestZstdDecompress tests Zstandard frames produced by the reference zstd tool.
+func TestZstdDecompress(t *testing.T) {
+	tests := []struct {
+		name  string
+		frame string
+		want  []byte
+	}{
+		{
+			name: "raw literals with repeat matches",
+			frame: "28b52ffd2440150100e07a737464206669" +
+				"6c746572206368756e6b2030313233343536373839010016" +
+				"39c3d9c22259",
+			want: []byte("zstd filter chunk zstd filter chunk zstd filter chunk 0123456789"),
+		},
+		{
+			name:  "huffman literals and fse sequences",
+			frame: zstdTestFrameHex,
+			want:  zstdTestInput(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frame, err := hex.DecodeString(tt.frame)
+			require.NoError(t, err)
+
+			got, err := zstdDecompress(frame)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+
+			// Concatenated frames decode to the concatenated content,
+			// skippable frames are ignored.
+			skippable := []byte{0x50, 0x2A, 0x4D, 0x18, 0x02, 0x00, 0x00, 0x00, 0xAA, 0xBB}
+			multi := append(append(append([]byte{}, frame...), skippable...), frame...)
+			got, err = zstdDecompress(multi)
+			require.NoError(t, err)
+			require.Equal(t, append(append([]byte{}, tt.want...), tt.want...), got)
+		})
+	}
+}
+
+// TestZstdDecompress_Errors tests corrupted and unsupported input.
+func TestZstdDecompress_Errors(t *testing.T) {
+	frame, err := hex.DecodeString(zstdTestFrameHex)
+	require.NoError(t, err)
+
+	_, err = zstdDecompress([]byte{0x01, 0x02, 0x03, 0x04})
+	require.ErrorContains(t, err, "invalid magic number")
+
+	_, err = zstdDecompress(frame[:len(frame)/2])
+	require.Error(t, err)
+
+	corrupt := bytes.Clone(frame)
+	corrupt[len(corrupt)-1] ^= 0xFF
+	_, err = zstdDecompress(corrupt)
+	var checksumErr *ChecksumError
+	require.ErrorAs(t, err, &checksumErr)
+	require.Equal(t, "Zstd", checksumErr.Filter)
+
+	// Frame header with dictionary ID flag 1 and dictionary ID 7.
+	_, err = zstdDecompress([]byte{0x28, 0xB5, 0x2F, 0xFD, 0x21, 0x07, 0x00, 0x01, 0x00, 0x00})
+	require.ErrorContains(t, err, "dictionaries are not supported")
+}
+
+// TestApplyFilter_ZstdDispatch tests that filter 32015 is decoded natively.
+func TestApplyFilter_ZstdDispatch(t *testing.T) {
+	frame, err := hex.DecodeString(zstdTestFrameHex)
+	require.NoError(t, err)
+
+	require.True(t, Filter{ID: FilterZstd}.Supported())
+	require.Equal(t, "Zstd", filterName(FilterZstd))
+
+	pipeline := &FilterPipelineMessage{Filters: []Filter{
+		{ID: FilterZstd, ClientData: []uint32{19}},
+	}}
+	got, err := pipeline.ApplyFilters(frame)
+	require.NoError(t, err)
+	require.Equal(t, zstdTestInput(), got)
+}
+
+// TestXXHash64 tests the content checksum hash against reference values.
+func TestXXHash64(t *testing.T) {
+	require.Equal(t, uint64(0xEF46DB3751D8E999), xxhash64(nil))
+	require.Equal(t, uint64(0xD24EC4F1A98C6E5B), xxhash64([]byte("a")))
+	require.Equal(t, uint64(0x44BC2CF5AD770999), xxhash64([]byte("abc")))
+}
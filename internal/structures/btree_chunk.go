@@ -88,6 +88,7 @@ type ChunkBTreeEntry struct {
 	Coordinate []uint64 // Scaled chunk coordinate
 	Address    uint64   // File address of raw chunk data
 	Nbytes     uint32   // Chunk size in bytes (after filtering)
+	FilterMask uint32   // Filters skipped for this chunk (bit i set = filter i not applied)
 }
 
 // NewChunkBTreeWriter creates new chunk B-tree writer.
@@ -142,6 +143,20 @@ func (w *ChunkBTreeWriter) AddChunk(coord []uint64, address uint64) error {
 //   - address: File address where chunk data is written
 //   - nbytes: Size of chunk data in bytes (after filtering)
 func (w *ChunkBTreeWriter) AddChunkWithSize(coord []uint64, address uint64, nbytes uint32) error {
+	return w.AddChunkWithFilterMask(coord, address, nbytes, 0)
+}
+
+// AddChunkWithFilterMask adds chunk to index with explicit size and filter mask.
+//
+// The filter mask is non-zero only for chunks copied from another file where
+// some pipeline filters were skipped (e.g. data that did not compress).
+//
+// Parameters:
+//   - coord: Scaled chunk coordinate [dim0, dim1, ..., dimN]
+//   - address: File address where chunk data is written
+//   - nbytes: Size of chunk data in bytes (after filtering)
+//   - filterMask: Bit i set means filter i of the pipeline was not applied
+func (w *ChunkBTreeWriter) AddChunkWithFilterMask(coord []uint64, address uint64, nbytes, filterMask uint32) error {
 	if len(coord) != w.dimensionality {
 		return fmt.Errorf("coordinate dimensionality mismatch: expected %d, got %d",
 			w.dimensionality, len(coord))
@@ -155,6 +170,7 @@ func (w *ChunkBTreeWriter) AddChunkWithSize(coord []uint64, address uint64, nbyt
 		Coordinate: coordCopy,
 		Address:    address,
 		Nbytes:     nbytes,
+		FilterMask: filterMask,
 	})
 
 	return nil
@@ -237,7 +253,7 @@ func (w *ChunkBTreeWriter) buildLeafNode(entries []ChunkBTreeEntry, leftSibling,
 	for _, entry := range entries {
 		node.Keys = append(node.Keys, ChunkKey{
 			Coords:     entry.Coordinate,
-			FilterMask: 0,
+			FilterMask: entry.FilterMask,
 			Nbytes:     entry.Nbytes,
 		})
 		node.ChildAddrs = append(node.ChildAddrs, entry.Address)
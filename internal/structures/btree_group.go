@@ -2,7 +2,6 @@ package structures
 
 import (
 	"encoding/binary"
-	"errors"
 	"fmt"
 	"io"
 
@@ -18,7 +17,18 @@ import (
 // - Children: addresses of Symbol Table Nodes (SNODs)
 //
 // The function follows child pointers to SNODs and collects all entries from them.
+// Internal nodes (level > 0) are descended recursively, so groups with more
+// entries than a single node can index are read in full.
 func ReadGroupBTreeEntries(r io.ReaderAt, address uint64, sb *core.Superblock) ([]BTreeEntry, error) {
+	return readGroupBTreeNode(r, address, sb, -1)
+}
+
+// readGroupBTreeNode reads one group B-tree node. expectedLevel is the level the
+// parent node implies for this child (-1 for the root); a mismatch means the
+// tree is corrupt and would otherwise allow unbounded recursion.
+//
+//nolint:gocognit // Leaf and internal node handling share the same header parsing
+func readGroupBTreeNode(r io.ReaderAt, address uint64, sb *core.Superblock, expectedLevel int) ([]BTreeEntry, error) {
 	// Read B-tree node header.
 	// Format:
 	// - 4 bytes: Signature ("TREE").
@@ -49,10 +59,10 @@ func ReadGroupBTreeEntries(r io.ReaderAt, address uint64, sb *core.Superblock) (
 		return nil, fmt.Errorf("expected group B-tree (type 0), got type %d", nodeType)
 	}
 
-	// Check node level (we only support leaf nodes for now).
+	// Check node level against the level implied by the parent.
 	nodeLevel := header[5]
-	if nodeLevel != 0 {
-		return nil, errors.New("non-leaf B-tree nodes not supported yet")
+	if expectedLevel >= 0 && int(nodeLevel) != expectedLevel {
+		return nil, fmt.Errorf("group B-tree node at 0x%X has level %d, expected %d", address, nodeLevel, expectedLevel)
 	}
 
 	// Read number of entries (this is the number of keys used).
@@ -100,6 +110,19 @@ func ReadGroupBTreeEntries(r io.ReaderAt, address uint64, sb *core.Superblock) (
 		}
 	}
 
+	// Internal node: children are B-tree nodes one level down.
+	if nodeLevel > 0 {
+		var allEntries []BTreeEntry
+		for _, childAddr := range snodAddresses {
+			entries, err := readGroupBTreeNode(r, childAddr, sb, int(nodeLevel)-1)
+			if err != nil {
+				return nil, err
+			}
+			allEntries = append(allEntries, entries...)
+		}
+		return allEntries, nil
+	}
+
 	// Parse each SNOD to collect entries
 	var allEntries []BTreeEntry
 	for _, snodAddr := range snodAddresses {
@@ -118,6 +141,8 @@ func ReadGroupBTreeEntries(r io.ReaderAt, address uint64, sb *core.Superblock) (
 				Reserved:        0,
 				CachedBTreeAddr: entry.CachedBTreeAddr,
 				CachedHeapAddr:  entry.CachedHeapAddr,
+
+				CachedSoftLinkOffset: entry.CachedSoftLinkOffset,
 			})
 		}
 	}
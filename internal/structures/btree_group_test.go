@@ -1,6 +1,7 @@
 package structures
 
 import (
+	"bytes"
 	"encoding/binary"
 	"errors"
 	"testing"
@@ -293,19 +294,67 @@ func TestReadGroupBTreeEntries_InvalidNodeType(t *testing.T) {
 }
 
 func TestReadGroupBTreeEntries_NonLeafNode(t *testing.T) {
-	buf := make([]byte, 1024)
-	copy(buf[0:4], "TREE")
-	buf[4] = 0
-	buf[5] = 1 // Level 1 (non-leaf)
-	binary.LittleEndian.PutUint16(buf[6:8], 1)
-
-	reader := &mockReaderAt{data: buf}
+	var buf bytes.Buffer
+	w := newBytesWriterAt(&buf)
 	sb := createMockSuperblock()
 
-	entries, err := ReadGroupBTreeEntries(reader, 0, sb)
+	// Two leaves, each pointing to one SNOD with two entries.
+	const (
+		rootAddr  = 0
+		leaf1Addr = 1024
+		leaf2Addr = 2048
+		snod1Addr = 3072
+		snod2Addr = 3584
+	)
+	for i, snodAddr := range []uint64{snod1Addr, snod2Addr} {
+		sn := NewSymbolTableNode(8)
+		for j := 0; j < 2; j++ {
+			require.NoError(t, sn.AddEntry(SymbolTableEntry{
+				LinkNameOffset: uint64(8 * (i*2 + j + 1)),
+				ObjectAddress:  uint64(0x1000 + i*2 + j),
+			}))
+		}
+		require.NoError(t, sn.WriteAt(w, snodAddr, 8, 8, binary.LittleEndian))
+	}
+	for i, pair := range [][2]uint64{{leaf1Addr, snod1Addr}, {leaf2Addr, snod2Addr}} {
+		leaf := NewBTreeNodeV1(0, 16)
+		require.NoError(t, leaf.AddKey(uint64(16*i), pair[1]))
+		leaf.Keys = append(leaf.Keys, uint64(16*(i+1)))
+		require.NoError(t, leaf.WriteAt(w, pair[0], 8, 16, binary.LittleEndian))
+	}
+	root := NewBTreeNodeV1(0, 16)
+	root.NodeLevel = 1
+	require.NoError(t, root.AddKey(0, leaf1Addr))
+	require.NoError(t, root.AddKey(16, leaf2Addr))
+	root.Keys = append(root.Keys, 32)
+	require.NoError(t, root.WriteAt(w, rootAddr, 8, 16, binary.LittleEndian))
+
+	entries, err := ReadGroupBTreeEntries(&mockReaderAt{data: buf.Bytes()}, rootAddr, sb)
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+	for i, e := range entries {
+		require.Equal(t, uint64(0x1000+i), e.ObjectAddress)
+	}
+}
+
+func TestReadGroupBTreeEntries_LevelMismatch(t *testing.T) {
+	var buf bytes.Buffer
+	w := newBytesWriterAt(&buf)
+
+	// Root claims level 1 but its child is also level 1 (would recurse forever
+	// if the child pointed back at the root).
+	root := NewBTreeNodeV1(0, 16)
+	root.NodeLevel = 1
+	require.NoError(t, root.AddKey(0, 0)) // Points at itself.
+	root.Keys = append(root.Keys, 0)
+	require.NoError(t, root.WriteAt(w, 1024, 8, 16, binary.LittleEndian))
+	root.ChildPointers[0] = 1024
+	require.NoError(t, root.WriteAt(w, 1024, 8, 16, binary.LittleEndian))
+
+	entries, err := ReadGroupBTreeEntries(&mockReaderAt{data: buf.Bytes()}, 1024, createMockSuperblock())
 	require.Error(t, err)
 	require.Nil(t, entries)
-	require.Contains(t, err.Error(), "non-leaf B-tree nodes not supported")
+	require.Contains(t, err.Error(), "expected 0")
 }
 
 func TestReadGroupBTreeEntries_ReadErrors(t *testing.T) {
@@ -0,0 +1,312 @@
+package structures
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+
+	"github.com/scigolib/hdf5/internal/core"
+)
+
+// btreeV2InternalSignature is the signature of v2 B-tree internal nodes.
+const btreeV2InternalSignature = "BTIN"
+
+// ChunkBTreeV2Writer builds a v2 B-tree chunk index, the index HDF5 1.10+
+// uses for chunked datasets with several unlimited dimensions.
+//
+// Records hold the chunk address, for filtered datasets the stored size and
+// filter mask, and the scaled chunk coordinates (not byte offsets, unlike
+// B-tree v1 keys). They are sorted by coordinate and bulk-loaded into a tree
+// whose nodes are filled evenly, as deep as the chunk count requires.
+//
+// The writer remembers the space of the last tree it wrote. After Reset,
+// the next WriteToFile writes over it: nodes are reused, nodes no longer
+// needed are freed, and the header keeps its address.
+//
+// Usage:
+//
+//	writer := NewChunkBTreeV2Writer(2, 10*20*8, false) // 2D dataset, chunk 10x20, float64
+//	writer.AddChunk([]uint64{0, 0}, chunkAddr1, 1600, 0)
+//	writer.AddChunk([]uint64{0, 1}, chunkAddr2, 1600, 0)
+//	headerAddr, err := writer.WriteToFile(fileWriter, allocator, sb)
+//
+// Reference: H5Dbtree2.c - H5D__bt2_idx_create(), H5D__bt2_unfilt_encode(),
+// H5D__bt2_filt_encode(); H5B2cache.c - H5B2__cache_hdr_serialize(),
+// H5B2__cache_int_serialize(), H5B2__cache_leaf_serialize().
+type ChunkBTreeV2Writer struct {
+	dimensionality int
+	filtered       bool
+	recordType     uint8
+	sizeWidth      int // Width of the chunk size field of filtered records.
+	entries        []ChunkBTreeEntry
+
+	geom *core.BTreeV2Geometry // Node capacities, set by WriteToFile.
+
+	// Space of the last tree written, reused by the next WriteToFile.
+	headerAddr uint64
+	nodeAddrs  []uint64
+}
+
+// NewChunkBTreeV2Writer creates a v2 B-tree chunk index writer.
+//
+// Parameters:
+//   - dimensionality: Number of dimensions in dataset
+//   - chunkBytes: Unfiltered size of one chunk in bytes
+//   - filtered: Whether the dataset has a filter pipeline (record type 11)
+func NewChunkBTreeV2Writer(dimensionality int, chunkBytes uint64, filtered bool) *ChunkBTreeV2Writer {
+	w := &ChunkBTreeV2Writer{
+		dimensionality: dimensionality,
+		filtered:       filtered,
+		recordType:     core.BTreeV2ChunkRecord,
+	}
+	if filtered {
+		w.sizeWidth = core.ChunkSizeFieldWidth(chunkBytes)
+		w.recordType = core.BTreeV2FilteredChunkRecord
+	}
+	return w
+}
+
+// AddChunk adds a chunk to the index. Order does not matter.
+//
+// Parameters:
+//   - coord: Scaled chunk coordinate [dim0, dim1, ..., dimN]
+//   - address: File address where chunk data is written
+//   - nbytes: Size of chunk data in bytes (after filtering)
+//   - filterMask: Bit i set means filter i of the pipeline was not applied
+func (w *ChunkBTreeV2Writer) AddChunk(coord []uint64, address uint64, nbytes, filterMask uint32) error {
+	if len(coord) != w.dimensionality {
+		return fmt.Errorf("coordinate dimensionality mismatch: expected %d, got %d",
+			w.dimensionality, len(coord))
+	}
+	w.entries = append(w.entries, ChunkBTreeEntry{
+		Coordinate: append([]uint64(nil), coord...),
+		Address:    address,
+		Nbytes:     nbytes,
+		FilterMask: filterMask,
+	})
+	return nil
+}
+
+// Reset removes all chunks, keeping the file space of the last tree written
+// for the next WriteToFile.
+func (w *ChunkBTreeV2Writer) Reset() {
+	w.entries = w.entries[:0]
+}
+
+// recordSize returns the encoded size of one record.
+func (w *ChunkBTreeV2Writer) recordSize(sb *core.Superblock) int {
+	size := int(sb.OffsetSize) + 8*w.dimensionality
+	if w.filtered {
+		size += w.sizeWidth + 4
+	}
+	return size
+}
+
+// WriteToFile writes the tree and returns the address of its header, to be
+// stored in a version 4 chunked layout message. A tree written before by
+// this writer is overwritten: its header address is returned again, and
+// its nodes are reused, the ones left over being freed when allocator can
+// free space.
+//
+// Parameters:
+//   - writer: FileWriter for write operations
+//   - allocator: Space allocator
+//   - sb: Superblock for address and length sizes
+//
+// Returns:
+//   - uint64: File address of the v2 B-tree header
+//   - error: Non-nil if the tree is empty or a write fails
+func (w *ChunkBTreeV2Writer) WriteToFile(writer Writer, allocator Allocator, sb *core.Superblock) (uint64, error) {
+	if len(w.entries) == 0 {
+		return 0, fmt.Errorf("no chunks to write (empty B-tree)")
+	}
+	sort.Slice(w.entries, func(i, j int) bool {
+		return compareChunkCoords(w.entries[i].Coordinate, w.entries[j].Coordinate) < 0
+	})
+
+	// The shallowest tree holding every record.
+	recordSize := w.recordSize(sb)
+	total := uint64(len(w.entries))
+	for depth := 0; ; depth++ {
+		geom, err := core.NewBTreeV2Geometry(core.BTreeV2ChunkNodeSize, recordSize, sb.OffsetSize, depth)
+		if err != nil {
+			return 0, fmt.Errorf("too many chunks for v2 B-tree index: %w", err)
+		}
+		if geom.Levels[depth].CumMaxRecords >= total {
+			w.geom = geom
+			break
+		}
+	}
+
+	reuse := w.nodeAddrs
+	var used []uint64
+	next := func() (uint64, error) {
+		if len(reuse) > 0 {
+			addr := reuse[0]
+			reuse = reuse[1:]
+			used = append(used, addr)
+			return addr, nil
+		}
+		addr, err := allocator.Allocate(uint64(w.geom.NodeSize))
+		if err != nil {
+			return 0, fmt.Errorf("failed to allocate v2 B-tree node: %w", err)
+		}
+		used = append(used, addr)
+		return addr, nil
+	}
+
+	depth := len(w.geom.Levels) - 1
+	rootAddr, rootRecords, err := w.writeNode(writer, next, sb, w.entries, depth)
+	if err != nil {
+		return 0, err
+	}
+	if f, ok := allocator.(interface {
+		Free(offset, size uint64) error
+	}); ok {
+		for _, addr := range reuse {
+			if err := f.Free(addr, uint64(w.geom.NodeSize)); err != nil {
+				return 0, fmt.Errorf("failed to free v2 B-tree node at 0x%X: %w", addr, err)
+			}
+		}
+	}
+	w.nodeAddrs = used
+
+	// Header: signature, version, type, node size, record size, depth, split
+	// and merge percents, root address, root record count, total record
+	// count, checksum.
+	buf := make([]byte, 0, 16+int(sb.OffsetSize)+2+int(sb.LengthSize)+4)
+	buf = append(buf, BTreeV2HeaderSignature...)
+	buf = append(buf, 0, w.recordType)
+	buf = binary.LittleEndian.AppendUint32(buf, core.BTreeV2ChunkNodeSize)
+	buf = binary.LittleEndian.AppendUint16(buf, uint16(recordSize)) //nolint:gosec // G115: record size is small
+	buf = binary.LittleEndian.AppendUint16(buf, uint16(depth))      //nolint:gosec // G115: depth is bounded
+	buf = append(buf, core.BTreeV2ChunkSplitPct, core.BTreeV2ChunkMergePct)
+	buf = appendVarUint(buf, rootAddr, int(sb.OffsetSize))
+	buf = binary.LittleEndian.AppendUint16(buf, uint16(rootRecords)) //nolint:gosec // G115: bounded by the node capacity
+	buf = appendVarUint(buf, total, int(sb.LengthSize))
+	buf = binary.LittleEndian.AppendUint32(buf, core.JenkinsChecksum(buf))
+
+	// The header size only depends on the superblock, so it is rewritten in
+	// place.
+	if w.headerAddr == 0 {
+		headerAddr, err := allocator.Allocate(uint64(len(buf)))
+		if err != nil {
+			return 0, fmt.Errorf("failed to allocate v2 B-tree header: %w", err)
+		}
+		w.headerAddr = headerAddr
+	}
+	if err := writer.WriteAtAddress(buf, w.headerAddr); err != nil {
+		return 0, fmt.Errorf("failed to write v2 B-tree header: %w", err)
+	}
+	return w.headerAddr, nil
+}
+
+// writeNode writes the subtree holding entries with its root at the given
+// depth, and returns the root address and the number of records in it.
+// Node addresses are taken from next.
+//
+// An internal node with c children holds the c-1 records separating them.
+// The fewest children able to hold the entries are used, and the rest of
+// the entries are split evenly between them.
+func (w *ChunkBTreeV2Writer) writeNode(writer Writer, next func() (uint64, error), sb *core.Superblock,
+	entries []ChunkBTreeEntry, depth int) (uint64, int, error) {
+	geom := w.geom
+	buf := make([]byte, 0, geom.NodeSize)
+
+	if depth == 0 {
+		buf = append(buf, BTreeV2LeafSignature...)
+		buf = append(buf, 0, w.recordType)
+		for i := range entries {
+			buf = w.appendRecord(buf, &entries[i], sb)
+		}
+		addr, err := w.writeNodeBuffer(writer, next, buf)
+		return addr, len(entries), err
+	}
+
+	n := uint64(len(entries))
+	childCap := geom.Levels[depth-1].CumMaxRecords
+	children := max((n+1+childCap)/(childCap+1), 2)
+	per := (n - (children - 1)) / children
+	extra := (n - (children - 1)) % children
+
+	type childRef struct {
+		addr    uint64
+		records int
+		total   int
+	}
+	refs := make([]childRef, 0, children)
+	var separators []ChunkBTreeEntry
+	start := uint64(0)
+	for c := uint64(0); c < children; c++ {
+		count := per
+		if c < extra {
+			count++
+		}
+		addr, records, err := w.writeNode(writer, next, sb, entries[start:start+count], depth-1)
+		if err != nil {
+			return 0, 0, err
+		}
+		refs = append(refs, childRef{addr: addr, records: records, total: int(count)}) //nolint:gosec // G115: bounded by the tree capacity
+		start += count
+		if c < children-1 {
+			separators = append(separators, entries[start])
+			start++
+		}
+	}
+
+	buf = append(buf, btreeV2InternalSignature...)
+	buf = append(buf, 0, w.recordType)
+	for i := range separators {
+		buf = w.appendRecord(buf, &separators[i], sb)
+	}
+	for _, ref := range refs {
+		buf = appendVarUint(buf, ref.addr, int(sb.OffsetSize))
+		buf = appendVarUint(buf, uint64(ref.records), geom.NrecSize) //nolint:gosec // G115: record counts are non-negative
+		if depth > 1 {
+			buf = appendVarUint(buf, uint64(ref.total), geom.Levels[depth-1].CumSize) //nolint:gosec // G115: record counts are non-negative
+		}
+	}
+	addr, err := w.writeNodeBuffer(writer, next, buf)
+	return addr, len(separators), err
+}
+
+// writeNodeBuffer appends the checksum to an encoded node, pads it to the
+// node size and writes it at the address taken from next.
+func (w *ChunkBTreeV2Writer) writeNodeBuffer(writer Writer, next func() (uint64, error), buf []byte) (uint64, error) {
+	buf = binary.LittleEndian.AppendUint32(buf, core.JenkinsChecksum(buf))
+	if len(buf) > w.geom.NodeSize {
+		return 0, fmt.Errorf("v2 B-tree node of %d bytes exceeds node size %d", len(buf), w.geom.NodeSize)
+	}
+	node := make([]byte, w.geom.NodeSize)
+	copy(node, buf)
+
+	addr, err := next()
+	if err != nil {
+		return 0, err
+	}
+	if err := writer.WriteAtAddress(node, addr); err != nil {
+		return 0, fmt.Errorf("failed to write v2 B-tree node at address %d: %w", addr, err)
+	}
+	return addr, nil
+}
+
+// appendRecord encodes one chunk record.
+func (w *ChunkBTreeV2Writer) appendRecord(buf []byte, entry *ChunkBTreeEntry, sb *core.Superblock) []byte {
+	buf = appendVarUint(buf, entry.Address, int(sb.OffsetSize))
+	if w.filtered {
+		buf = appendVarUint(buf, uint64(entry.Nbytes), w.sizeWidth)
+		buf = binary.LittleEndian.AppendUint32(buf, entry.FilterMask)
+	}
+	for _, c := range entry.Coordinate {
+		buf = binary.LittleEndian.AppendUint64(buf, c)
+	}
+	return buf
+}
+
+// appendVarUint appends the low size bytes of v in little-endian order.
+func appendVarUint(buf []byte, v uint64, size int) []byte {
+	for i := 0; i < size; i++ {
+		buf = append(buf, byte(v>>(8*i)))
+	}
+	return buf
+}
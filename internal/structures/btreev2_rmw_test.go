@@ -294,24 +294,23 @@ func TestBTreeV2LoadFromFile_HeaderValidation(t *testing.T) {
 		assert.Contains(t, err.Error(), "unsupported B-tree version")
 	})
 
-	// Test non-zero depth (not supported in MVP)
-	t.Run("NonZeroDepth", func(t *testing.T) {
-		// Create valid header but with depth > 0
+	// Test an internal node with a wrong signature
+	t.Run("InvalidInternalSignature", func(t *testing.T) {
 		bt := NewWritableBTreeV2(4096)
 		bt.header.Depth = 2 // Multi-level tree
 
 		writer := &testBTreeWriter{buf: make([]byte, 100000)}
 
-		// Manually encode header with depth = 2
+		// Manually encode header with depth = 2 and a root that is no node
 		bt.header.RootNodeAddr = 1024
+		bt.header.NumRecordsRoot = 1
 		headerData, _ := bt.encodeHeader(sb)
 		_ = writer.WriteAtAddress(headerData, 2048)
 
-		// Try to load - should fail
 		bt2 := NewWritableBTreeV2(4096)
 		err := bt2.LoadFromFile(writer, 2048, sb)
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "single-leaf B-trees")
+		assert.Contains(t, err.Error(), "invalid B-tree internal node signature")
 	})
 }
 
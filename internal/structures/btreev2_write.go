@@ -119,11 +119,12 @@ type LinkNameRecord struct {
 
 // WritableBTreeV2 manages B-tree v2 construction for link name indexing.
 //
-// MVP Limitations:
-//   - Single leaf node only (no internal nodes)
-//   - No splits (error if node size exceeded)
-//   - Records sorted by name hash
-//   - Maximum records = (nodeSize - overhead) / recordSize
+// Records are kept in memory, sorted by name hash. When the tree is written,
+// they are laid out in a single leaf while they fit one node, otherwise in
+// the shallowest multi-level tree holding them, with nodes filled evenly (as
+// the chunk index writer does). Insertions and deletions therefore never
+// split or merge nodes in place: the tree is rebuilt on every write, reusing
+// the nodes it occupied before.
 type WritableBTreeV2 struct {
 	header   *BTreeV2Header
 	leaf     *BTreeV2LeafNode
@@ -134,6 +135,11 @@ type WritableBTreeV2 struct {
 	loadedHeaderAddress uint64
 	loadedLeafAddress   uint64
 
+	// Nodes occupied by the tree in the file (loaded or written), each
+	// nodeSize bytes, reused when the tree is written again.
+	nodeAddrs  []uint64
+	offsetSize uint8 // Address size of the file, for the node layout.
+
 	// Lazy rebalancing state (nil if disabled)
 	lazyState *LazyRebalancingState
 
@@ -173,8 +179,9 @@ func NewWritableBTreeV2(nodeSize uint32) *WritableBTreeV2 {
 			Type:      BTreeV2TypeLinkNameIndex,
 			Records:   make([]LinkNameRecord, 0),
 		},
-		records:  make([]LinkNameRecord, 0),
-		nodeSize: nodeSize,
+		records:    make([]LinkNameRecord, 0),
+		nodeSize:   nodeSize,
+		offsetSize: 8,
 	}
 }
 
@@ -184,10 +191,11 @@ func NewWritableBTreeV2(nodeSize uint32) *WritableBTreeV2 {
 //   - linkName: name of the link (for hash calculation)
 //   - heapID: 8-byte fractal heap object ID (we store 7 bytes)
 //
-// For MVP: stores in single leaf, sorted by name hash.
+// Records are kept sorted by name hash; the node layout is chosen when the
+// tree is written.
 //
 // Returns:
-//   - error if node is full or insertion fails
+//   - error if insertion fails
 func (bt *WritableBTreeV2) InsertRecord(linkName string, heapID uint64) error {
 	// Calculate Jenkins hash for link name
 	hash := jenkinsHash(linkName)
@@ -204,12 +212,6 @@ func (bt *WritableBTreeV2) InsertRecord(linkName string, heapID uint64) error {
 		HeapID:   heapIDBytes,
 	}
 
-	// Check if node will be full
-	maxRecords := bt.calculateMaxRecords()
-	if len(bt.records) >= maxRecords {
-		return ErrBTreeNodeFull
-	}
-
 	// Insert sorted by hash
 	bt.records = insertRecordSorted(bt.records, record)
 	bt.header.TotalRecords++
@@ -342,8 +344,9 @@ func (bt *WritableBTreeV2) DeleteRecord(name string) error {
 // WriteToFile writes B-tree v2 to file and returns header address.
 //
 // Writes:
-//  1. Leaf node at allocated address
-//  2. Header at allocated address (with leaf address)
+//  1. The nodes (a single leaf, or a multi-level tree when the records do
+//     not fit one node), each allocated at the full node size
+//  2. Header at allocated address (with root node address)
 //
 // Returns:
 //   - uint64: header address (store this in Link Info Message)
@@ -353,27 +356,8 @@ func (bt *WritableBTreeV2) WriteToFile(writer Writer, allocator Allocator, sb *c
 		return 0, errors.New("writer, allocator, or superblock is nil")
 	}
 
-	// Calculate leaf node size
-	// IMPORTANT: For RMW (Read-Modify-Write), allocate FULL node size,
-	// not just current content size. This allows leaf to grow without relocation.
-	// We'll only write actual data, but reserve full node size for future growth.
-	leafSize := uint64(bt.nodeSize) // Allocate FULL node size
-
-	// Allocate space for leaf node
-	leafAddr, err := allocator.Allocate(leafSize)
-	if err != nil {
-		return 0, fmt.Errorf("failed to allocate leaf node: %w", err)
-	}
-
-	// Encode leaf node
-	leafData, err := bt.encodeLeafNode(sb)
-	if err != nil {
-		return 0, fmt.Errorf("failed to encode leaf node: %w", err)
-	}
-
-	// Write leaf node
-	if err := writer.WriteAtAddress(leafData, leafAddr); err != nil {
-		return 0, fmt.Errorf("failed to write leaf node: %w", err)
+	if err := bt.writeNodes(writer, allocator, sb); err != nil {
+		return 0, err
 	}
 
 	// Calculate header size
@@ -385,9 +369,6 @@ func (bt *WritableBTreeV2) WriteToFile(writer Writer, allocator Allocator, sb *c
 		return 0, fmt.Errorf("failed to allocate header: %w", err)
 	}
 
-	// Set root node address in header
-	bt.header.RootNodeAddr = leafAddr
-
 	// Encode header
 	headerData, err := bt.encodeHeader(sb)
 	if err != nil {
@@ -406,18 +387,23 @@ func (bt *WritableBTreeV2) WriteToFile(writer Writer, allocator Allocator, sb *c
 //
 // This method is used for Read-Modify-Write (RMW) scenarios:
 // - B-tree was loaded via LoadFromFile()
-// - New records were inserted
-// - Write back to same addresses
+// - Records were inserted or deleted
+// - Write back to the same header address
+//
+// The nodes loaded with the tree are reused. When the tree needs more of
+// them, the rest are taken from allocator; nodes no longer needed are
+// returned to it if it can free space (as internal/writer.Allocator can).
 //
 // Parameters:
 //   - writer: File writer (must implement Writer interface)
+//   - allocator: Space allocator for nodes added to the tree
 //   - sb: Superblock for field sizes
 //
 // Returns:
 //   - error: if write fails or B-tree was not loaded from file
 //
 // Reference: Same as WriteToFile, but uses stored addresses.
-func (bt *WritableBTreeV2) WriteAt(writer Writer, sb *core.Superblock) error {
+func (bt *WritableBTreeV2) WriteAt(writer Writer, allocator Allocator, sb *core.Superblock) error {
 	if writer == nil || sb == nil {
 		return errors.New("writer or superblock is nil")
 	}
@@ -427,19 +413,10 @@ func (bt *WritableBTreeV2) WriteAt(writer Writer, sb *core.Superblock) error {
 		return errors.New("cannot use WriteAt: B-tree not loaded from file (use WriteToFile for new B-trees)")
 	}
 
-	// Encode leaf node
-	leafData, err := bt.encodeLeafNode(sb)
-	if err != nil {
-		return fmt.Errorf("failed to encode leaf node: %w", err)
-	}
-
-	// Write leaf node at loaded address
-	if err := writer.WriteAtAddress(leafData, bt.loadedLeafAddress); err != nil {
-		return fmt.Errorf("failed to write leaf node at 0x%X: %w", bt.loadedLeafAddress, err)
+	if err := bt.writeNodes(writer, allocator, sb); err != nil {
+		return err
 	}
-
-	// Update header with leaf address (in case it was cleared)
-	bt.header.RootNodeAddr = bt.loadedLeafAddress
+	bt.loadedLeafAddress = bt.header.RootNodeAddr
 
 	// Encode header
 	headerData, err := bt.encodeHeader(sb)
@@ -455,6 +432,180 @@ func (bt *WritableBTreeV2) WriteAt(writer Writer, sb *core.Superblock) error {
 	return nil
 }
 
+// btreeV2LayoutNode is a node of the tree built by layoutBTreeV2.
+type btreeV2LayoutNode struct {
+	records  []LinkNameRecord     // Leaf records, or the records separating the children
+	children []*btreeV2LayoutNode // Empty for leaves
+	total    uint64               // Records in the subtree
+}
+
+// geometry returns the node capacities of the shallowest tree holding all records.
+func (bt *WritableBTreeV2) geometry() (*core.BTreeV2Geometry, error) {
+	total := uint64(len(bt.records))
+	for depth := 0; ; depth++ {
+		geom, err := core.NewBTreeV2Geometry(bt.nodeSize, int(bt.header.RecordSize), bt.offsetSize, depth)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %d records: %w", ErrBTreeNodeFull, total, err)
+		}
+		if geom.Levels[depth].CumMaxRecords >= total {
+			return geom, nil
+		}
+	}
+}
+
+// layoutBTreeV2 lays out sorted records as a subtree with its root at the
+// given depth. An internal node with c children holds the c-1 records
+// separating them; the fewest children able to hold the records are used,
+// and the records are split evenly between them.
+//
+// Reference: H5B2int.c - H5B2__split_root(), H5B2__redistribute2().
+func layoutBTreeV2(records []LinkNameRecord, depth int, geom *core.BTreeV2Geometry) *btreeV2LayoutNode {
+	node := &btreeV2LayoutNode{total: uint64(len(records))}
+	if depth == 0 {
+		node.records = records
+		return node
+	}
+
+	n := uint64(len(records))
+	childCap := geom.Levels[depth-1].CumMaxRecords
+	children := max((n+1+childCap)/(childCap+1), 2)
+	per := (n - (children - 1)) / children
+	extra := (n - (children - 1)) % children
+
+	start := uint64(0)
+	for c := uint64(0); c < children; c++ {
+		count := per
+		if c < extra {
+			count++
+		}
+		node.children = append(node.children, layoutBTreeV2(records[start:start+count], depth-1, geom))
+		start += count
+		if c < children-1 {
+			node.records = append(node.records, records[start])
+			start++
+		}
+	}
+	return node
+}
+
+// countNodes returns the number of nodes in the subtree.
+func (n *btreeV2LayoutNode) countNodes() uint64 {
+	count := uint64(1)
+	for _, child := range n.children {
+		count += child.countNodes()
+	}
+	return count
+}
+
+// writeNodes lays the records out and writes every node, reusing the nodes
+// the tree occupied before, then updates the header fields describing the
+// root. Nodes left over are freed when allocator can free space.
+func (bt *WritableBTreeV2) writeNodes(writer Writer, allocator Allocator, sb *core.Superblock) error {
+	bt.offsetSize = sb.OffsetSize
+	geom, err := bt.geometry()
+	if err != nil {
+		return err
+	}
+	depth := len(geom.Levels) - 1
+	root := layoutBTreeV2(bt.records, depth, geom)
+
+	reuse := bt.nodeAddrs
+	var used []uint64
+	next := func() (uint64, error) {
+		if len(reuse) > 0 {
+			addr := reuse[0]
+			reuse = reuse[1:]
+			used = append(used, addr)
+			return addr, nil
+		}
+		if allocator == nil {
+			return 0, errors.New("B-tree needs more nodes but no allocator was given")
+		}
+		addr, err := allocator.Allocate(uint64(bt.nodeSize))
+		if err != nil {
+			return 0, fmt.Errorf("failed to allocate B-tree node: %w", err)
+		}
+		used = append(used, addr)
+		return addr, nil
+	}
+
+	rootAddr, err := bt.writeNode(writer, sb, geom, root, depth, next)
+	if err != nil {
+		return err
+	}
+	if f, ok := allocator.(interface {
+		Free(offset, size uint64) error
+	}); ok {
+		for _, addr := range reuse {
+			if err := f.Free(addr, uint64(bt.nodeSize)); err != nil {
+				return fmt.Errorf("failed to free B-tree node at 0x%X: %w", addr, err)
+			}
+		}
+	}
+	bt.nodeAddrs = used
+
+	bt.header.RootNodeAddr = rootAddr
+	bt.header.Depth = uint16(depth)                      //nolint:gosec // G115: depth is bounded by the geometry
+	bt.header.NumRecordsRoot = uint16(len(root.records)) //nolint:gosec // G115: bounded by the node capacity
+	bt.header.TotalRecords = uint64(len(bt.records))
+	return nil
+}
+
+// writeNode writes the subtree rooted at node, children first, and returns
+// the address of node. Nodes are padded to the node size.
+//
+// Reference: H5B2cache.c - H5B2__cache_int_serialize(), H5B2__cache_leaf_serialize().
+func (bt *WritableBTreeV2) writeNode(writer Writer, sb *core.Superblock, geom *core.BTreeV2Geometry,
+	node *btreeV2LayoutNode, depth int, next func() (uint64, error)) (uint64, error) {
+	childAddrs := make([]uint64, len(node.children))
+	for i, child := range node.children {
+		addr, err := bt.writeNode(writer, sb, geom, child, depth-1, next)
+		if err != nil {
+			return 0, err
+		}
+		childAddrs[i] = addr
+	}
+
+	buf := make([]byte, 0, bt.nodeSize)
+	if depth == 0 {
+		buf = append(buf, BTreeV2LeafSignature...)
+	} else {
+		buf = append(buf, btreeV2InternalSignature...)
+	}
+	buf = append(buf, 0, bt.header.Type)
+	buf = appendLinkNameRecords(buf, node.records)
+	for i, child := range node.children {
+		buf = appendVarUint(buf, childAddrs[i], int(sb.OffsetSize))
+		buf = appendVarUint(buf, uint64(len(child.records)), geom.NrecSize)
+		if depth > 1 {
+			buf = appendVarUint(buf, child.total, geom.Levels[depth-1].CumSize)
+		}
+	}
+	buf = binary.LittleEndian.AppendUint32(buf, core.JenkinsChecksum(buf))
+	if len(buf) > int(bt.nodeSize) {
+		return 0, fmt.Errorf("v2 B-tree node of %d bytes exceeds node size %d", len(buf), bt.nodeSize)
+	}
+	buf = buf[:bt.nodeSize]
+
+	addr, err := next()
+	if err != nil {
+		return 0, err
+	}
+	if err := writer.WriteAtAddress(buf, addr); err != nil {
+		return 0, fmt.Errorf("failed to write B-tree node at 0x%X: %w", addr, err)
+	}
+	return addr, nil
+}
+
+// appendLinkNameRecords encodes records: name hash (4 bytes) and heap ID (7 bytes).
+func appendLinkNameRecords(buf []byte, records []LinkNameRecord) []byte {
+	for _, record := range records {
+		buf = binary.LittleEndian.AppendUint32(buf, record.NameHash)
+		buf = append(buf, record.HeapID[:]...)
+	}
+	return buf
+}
+
 // encodeHeader encodes B-tree v2 header for writing.
 //
 // Format (from H5B2cache.c - H5B2__hdr_serialize):
@@ -652,8 +803,7 @@ func compareLinkNames(a, b string) int {
 // Returns:
 //   - error if read fails or validation fails
 //
-// MVP Limitations:
-//   - Single leaf node only (no internal nodes)
+// Limitations:
 //   - Assumes B-tree type 5 (Link Name Index)
 func (bt *WritableBTreeV2) LoadFromFile(r io.ReaderAt, headerAddr uint64, sb *core.Superblock) error {
 	if r == nil {
@@ -674,10 +824,6 @@ func (bt *WritableBTreeV2) LoadFromFile(r io.ReaderAt, headerAddr uint64, sb *co
 		return fmt.Errorf("%w: expected type %d, got %d", ErrInvalidBTreeType, BTreeV2TypeLinkNameIndex, header.Type)
 	}
 
-	if header.Depth != 0 {
-		return fmt.Errorf("only single-leaf B-trees are supported (depth 0), got depth %d", header.Depth)
-	}
-
 	// 3. Store loaded addresses for WriteAt() support (RMW)
 	bt.loadedHeaderAddress = headerAddr
 	bt.loadedLeafAddress = header.RootNodeAddr
@@ -685,27 +831,95 @@ func (bt *WritableBTreeV2) LoadFromFile(r io.ReaderAt, headerAddr uint64, sb *co
 	// 4. Store header
 	bt.header = header
 	bt.nodeSize = header.NodeSize
-
-	// 5. Read and decode leaf node (if not empty)
-	if header.NumRecordsRoot > 0 {
-		leaf, records, err := readBTreeV2LeafNode(r, header.RootNodeAddr, int(header.NumRecordsRoot), sb)
+	bt.offsetSize = sb.OffsetSize
+	bt.records = make([]LinkNameRecord, 0, header.TotalRecords)
+	bt.nodeAddrs = nil
+
+	// 5. Read the nodes (if not empty)
+	switch {
+	case header.Depth > 0:
+		geom, err := core.NewBTreeV2Geometry(header.NodeSize, int(header.RecordSize), sb.OffsetSize, int(header.Depth))
+		if err != nil {
+			return fmt.Errorf("invalid B-tree header: %w", err)
+		}
+		if err := bt.loadNode(r, geom, sb, header.RootNodeAddr, int(header.NumRecordsRoot), int(header.Depth)); err != nil {
+			return err
+		}
+	case header.NumRecordsRoot > 0:
+		_, records, err := readBTreeV2LeafNode(r, header.RootNodeAddr, int(header.NumRecordsRoot), sb)
 		if err != nil {
 			return fmt.Errorf("failed to read leaf node: %w", err)
 		}
-
-		bt.leaf = leaf
 		bt.records = records
-	} else {
-		// Empty tree
-		bt.leaf = &BTreeV2LeafNode{
-			Signature: [4]byte{'B', 'T', 'L', 'F'},
-			Version:   0,
-			Type:      BTreeV2TypeLinkNameIndex,
-			Records:   make([]LinkNameRecord, 0),
+		bt.nodeAddrs = append(bt.nodeAddrs, header.RootNodeAddr)
+	default:
+		// Empty tree: its leaf, if any, is reused by WriteAt.
+		if header.RootNodeAddr != 0 && header.RootNodeAddr != 0xFFFFFFFFFFFFFFFF {
+			bt.nodeAddrs = append(bt.nodeAddrs, header.RootNodeAddr)
+		}
+	}
+
+	bt.leaf = &BTreeV2LeafNode{
+		Signature: [4]byte{'B', 'T', 'L', 'F'},
+		Version:   0,
+		Type:      header.Type,
+		Records:   bt.records,
+	}
+	return nil
+}
+
+// loadNode reads the subtree rooted at the node at addr, holding nrec
+// records at the given depth, appending its records in key order and its
+// node addresses.
+//
+// Reference: H5B2cache.c - H5B2__cache_int_deserialize().
+func (bt *WritableBTreeV2) loadNode(r io.ReaderAt, geom *core.BTreeV2Geometry, sb *core.Superblock, addr uint64, nrec, depth int) error {
+	if uint64(nrec) > geom.Levels[depth].MaxRecords { //nolint:gosec // G115: nrec is a decoded uint16 or smaller
+		return fmt.Errorf("b-tree node at 0x%X has %d records, at most %d fit", addr, nrec, geom.Levels[depth].MaxRecords)
+	}
+	bt.nodeAddrs = append(bt.nodeAddrs, addr)
+	if depth == 0 {
+		_, records, err := readBTreeV2LeafNode(r, addr, nrec, sb)
+		if err != nil {
+			return fmt.Errorf("failed to read leaf node: %w", err)
 		}
-		bt.records = make([]LinkNameRecord, 0)
+		bt.records = append(bt.records, records...)
+		return nil
 	}
 
+	recordSize := int(bt.header.RecordSize)
+	pointerSize := geom.PointerSize(depth)
+	size := 6 + nrec*recordSize + (nrec+1)*pointerSize
+	buf := make([]byte, size+4)
+	//nolint:gosec // G115: address conversion, valid for file I/O
+	if _, err := r.ReadAt(buf, int64(addr)); err != nil {
+		return fmt.Errorf("failed to read internal node at 0x%X: %w", addr, err)
+	}
+	if string(buf[:4]) != btreeV2InternalSignature {
+		return fmt.Errorf("invalid B-tree internal node signature at 0x%X: %q", addr, buf[:4])
+	}
+	if stored, want := binary.LittleEndian.Uint32(buf[size:]), core.JenkinsChecksum(buf[:size]); stored != want {
+		return fmt.Errorf("b-tree internal node checksum mismatch: got 0x%X, want 0x%X", stored, want)
+	}
+
+	records := make([]LinkNameRecord, nrec)
+	for i := range records {
+		off := 6 + i*recordSize
+		records[i].NameHash = binary.LittleEndian.Uint32(buf[off:])
+		copy(records[i].HeapID[:], buf[off+4:off+recordSize])
+	}
+	ptr := 6 + nrec*recordSize
+	for i := 0; i <= nrec; i++ {
+		childAddr := readUint64(buf[ptr:], int(sb.OffsetSize), sb.Endianness)
+		childRecords := readUint64(buf[ptr+int(sb.OffsetSize):], geom.NrecSize, binary.LittleEndian)
+		ptr += pointerSize
+		if err := bt.loadNode(r, geom, sb, childAddr, int(childRecords), depth-1); err != nil { //nolint:gosec // G115: bounded by the node capacity check
+			return err
+		}
+		if i < nrec {
+			bt.records = append(bt.records, records[i])
+		}
+	}
 	return nil
 }
 
@@ -919,6 +1133,39 @@ func (bt *WritableBTreeV2) GetRecords() []LinkNameRecord {
 	return bt.records
 }
 
+// SetRecordHeapID replaces the heap ID of the record at index i of
+// GetRecords, for objects moved by WritableFractalHeap.Compact.
+//
+// Parameters:
+//   - i: index of the record in GetRecords
+//   - heapID: new 8-byte heap ID (7 bytes are stored)
+//
+// Returns:
+//   - error: if i is out of range
+func (bt *WritableBTreeV2) SetRecordHeapID(i int, heapID []byte) error {
+	if i < 0 || i >= len(bt.records) {
+		return fmt.Errorf("record index %d out of range [0, %d)", i, len(bt.records))
+	}
+	copy(bt.records[i].HeapID[:], heapID)
+	bt.leaf.Records = bt.records
+	return nil
+}
+
+// Stats returns the number of records and nodes in the B-tree, as it is
+// (or will be) laid out when written.
+//
+// Returns:
+//   - records: number of records in the tree
+//   - nodes: number of nodes (internal and leaf) in the tree
+func (bt *WritableBTreeV2) Stats() (records, nodes uint64) {
+	geom, err := bt.geometry()
+	if err != nil {
+		return uint64(len(bt.records)), uint64(len(bt.nodeAddrs))
+	}
+	root := layoutBTreeV2(bt.records, len(geom.Levels)-1, geom)
+	return uint64(len(bt.records)), root.countNodes()
+}
+
 // jenkinsHash computes Jenkins hash (lookup3) for a string.
 //
 // This is the hash function used by HDF5 for link name indexing.
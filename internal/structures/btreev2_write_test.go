@@ -143,24 +143,47 @@ func TestBTreeV2_LargeScale(t *testing.T) {
 	}
 }
 
-// TestBTreeV2_NodeSizeExceeded tests error handling for full nodes.
-func TestBTreeV2_NodeSizeExceeded(t *testing.T) {
-	bt := NewWritableBTreeV2(128) // Very small node
+// TestBTreeV2_MultiLevel tests trees with more records than fit one leaf.
+func TestBTreeV2_MultiLevel(t *testing.T) {
+	sb := createTestSuperblock()
 
-	// Calculate max records
-	maxRecords := bt.calculateMaxRecords()
-	require.Greater(t, maxRecords, 0)
+	for _, n := range []int{10, 500, 5000} {
+		t.Run(fmt.Sprintf("%d records", n), func(t *testing.T) {
+			bt := NewWritableBTreeV2(128) // Very small node: 10 records per leaf
+			for i := 0; i < n; i++ {
+				require.NoError(t, bt.InsertRecord(fmt.Sprintf("link%d", i), uint64(i)))
+			}
 
-	// Insert up to max
-	for i := 0; i < maxRecords; i++ {
-		err := bt.InsertRecord(fmt.Sprintf("link%d", i), uint64(i))
-		require.NoError(t, err)
-	}
+			writer := &testBTreeWriter{buf: make([]byte, 4<<20)}
+			allocator := &testBTreeAllocator{nextAddr: 1024}
+			headerAddr, err := bt.WriteToFile(writer, allocator, sb)
+			require.NoError(t, err)
+			if n > bt.calculateMaxRecords() {
+				require.Positive(t, bt.header.Depth)
+				require.Equal(t, "BTIN", string(writer.buf[bt.header.RootNodeAddr:bt.header.RootNodeAddr+4]))
+			}
+			_, nodes := bt.Stats()
+			require.Len(t, bt.nodeAddrs, int(nodes))
+
+			loaded := NewWritableBTreeV2(128)
+			require.NoError(t, loaded.LoadFromFile(writer, headerAddr, sb))
+			require.Equal(t, bt.header.Depth, loaded.header.Depth)
+			require.Equal(t, bt.records, loaded.records)
+			require.ElementsMatch(t, bt.nodeAddrs, loaded.nodeAddrs)
+
+			// Rewriting in place reuses the loaded nodes, and adds new ones as needed.
+			for i := n; i < 2*n; i++ {
+				require.NoError(t, loaded.InsertRecord(fmt.Sprintf("link%d", i), uint64(i)))
+			}
+			require.NoError(t, loaded.WriteAt(writer, allocator, sb))
+			require.Subset(t, loaded.nodeAddrs, bt.nodeAddrs)
 
-	// Next insertion should fail
-	err := bt.InsertRecord("overflow", 0xFFFF)
-	require.Error(t, err)
-	require.Equal(t, ErrBTreeNodeFull, err)
+			reloaded := NewWritableBTreeV2(128)
+			require.NoError(t, reloaded.LoadFromFile(writer, headerAddr, sb))
+			require.Equal(t, loaded.records, reloaded.records)
+			require.Len(t, reloaded.records, 2*n)
+		})
+	}
 }
 
 // TestBTreeV2_UTF8Names tests Unicode link names.
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"slices"
 
 	"github.com/scigolib/hdf5/internal/core"
 )
@@ -264,7 +265,7 @@ func (fh *WritableFractalHeap) needsTransition(objectSize uint64) bool {
 	}
 
 	// Check if object fits in current direct block
-	if fh.DirectBlock.FreeOffset+objectSize <= fh.DirectBlock.Size {
+	if fh.DirectBlock.FreeOffset+objectSize <= fh.directBlockCapacity(fh.DirectBlock) {
 		return false // Still fits
 	}
 
@@ -298,8 +299,14 @@ func (fh *WritableFractalHeap) InsertObject(data []byte) ([]byte, error) {
 			ErrObjectTooLarge, dataSize, fh.Header.MaxManagedObjectSize)
 	}
 
-	// Check if transition to indirect root is needed
+	// Check if transition to indirect root is needed. A heap loaded from
+	// file is written back in place by WriteAt, which only knows its direct
+	// block: report it full instead (see Compact).
 	if fh.needsTransition(dataSize) {
+		if fh.loadedHeaderAddress != 0 {
+			return nil, fmt.Errorf("%w: need %d bytes, have %d free in direct block", ErrHeapFull,
+				dataSize, fh.directBlockCapacity(fh.DirectBlock)-fh.DirectBlock.FreeOffset)
+		}
 		if err := fh.transitionToIndirectRoot(); err != nil {
 			return nil, fmt.Errorf("failed to transition to indirect root: %w", err)
 		}
@@ -324,9 +331,10 @@ func (fh *WritableFractalHeap) insertViaDirect(data []byte) ([]byte, error) {
 	dataSize := uint64(len(data))
 
 	// Check if enough space in direct block
-	if fh.DirectBlock.FreeOffset+dataSize > fh.DirectBlock.Size {
+	capacity := fh.directBlockCapacity(fh.DirectBlock)
+	if fh.DirectBlock.FreeOffset+dataSize > capacity {
 		return nil, fmt.Errorf("%w: need %d bytes, have %d free",
-			ErrHeapFull, dataSize, fh.DirectBlock.Size-fh.DirectBlock.FreeOffset)
+			ErrHeapFull, dataSize, capacity-fh.DirectBlock.FreeOffset)
 	}
 
 	// Current offset becomes the object's location
@@ -378,9 +386,16 @@ func (fh *WritableFractalHeap) insertViaIndirect(data []byte) ([]byte, error) {
 	var targetBlock *WritableDirectBlock
 	var targetOffset uint64
 
-	// Try each existing child block
-	for offset, block := range fh.DirectBlocks {
-		if block.FreeOffset+dataSize <= block.Size {
+	// Try each existing child block, lowest offset first, so that the
+	// layout does not depend on map iteration order.
+	offsets := make([]uint64, 0, len(fh.DirectBlocks))
+	for offset := range fh.DirectBlocks {
+		offsets = append(offsets, offset)
+	}
+	slices.Sort(offsets)
+	for _, offset := range offsets {
+		block := fh.DirectBlocks[offset]
+		if block.FreeOffset+dataSize <= fh.directBlockCapacity(block) {
 			targetBlock = block
 			targetOffset = offset
 			break
@@ -458,6 +473,64 @@ func (fh *WritableFractalHeap) insertViaIndirect(data []byte) ([]byte, error) {
 	return heapID, nil
 }
 
+// directBlockCapacity returns the bytes of a direct block available for
+// objects: its size less the block header and the checksum. Heaps are
+// written with 8-byte file addresses.
+//
+// Reference: H5HFpkg.h - H5HF_MAN_ABS_DIRECT_OVERHEAD.
+func (fh *WritableFractalHeap) directBlockCapacity(block *WritableDirectBlock) uint64 {
+	overhead := uint64(4+1+8+fh.Header.HeapOffsetSize) + 4
+	if overhead >= block.Size {
+		return 0
+	}
+	return block.Size - overhead
+}
+
+// Compact packs the objects identified by heapIDs at the start of the
+// direct block, in the given order, and returns their new heap IDs. All
+// other objects are dropped, which reclaims the space of deleted objects:
+// the heap has no free-space manager, so DeleteObject alone never makes
+// room for new objects. Callers must replace every stored copy of the old
+// IDs (e.g. B-tree records) with the returned ones.
+//
+// Only heaps whose root is a direct block can be compacted.
+//
+// Parameters:
+//   - heapIDs: IDs of all live objects
+//
+// Returns:
+//   - [][]byte: new heap IDs, in the order of heapIDs
+//   - error: if an ID is invalid or the heap has indirect blocks
+func (fh *WritableFractalHeap) Compact(heapIDs [][]byte) ([][]byte, error) {
+	if fh.RootIndirectBlock != nil {
+		return nil, errors.New("cannot compact a heap with indirect blocks")
+	}
+
+	objects := make([]byte, 0, len(fh.DirectBlock.Objects))
+	newIDs := make([][]byte, len(heapIDs))
+	for i, id := range heapIDs {
+		data, err := fh.GetObject(id)
+		if err != nil {
+			return nil, fmt.Errorf("object %d: %w", i, err)
+		}
+		newIDs[i] = fh.encodeHeapID(uint64(len(objects)), uint64(len(data)))
+		objects = append(objects, data...)
+	}
+
+	// Loaded heaps keep Objects at the full block capacity.
+	used := uint64(len(objects))
+	if n := len(fh.DirectBlock.Objects); n > len(objects) {
+		objects = append(objects, make([]byte, n-len(objects))...)
+	}
+	fh.DirectBlock.Objects = objects
+	fh.DirectBlock.FreeOffset = used
+	fh.Header.ManagedSpaceOffset = used
+	fh.Header.NumManagedObjects = uint64(len(heapIDs))
+	fh.Header.FreeSpace = fh.DirectBlock.Size - used
+
+	return newIDs, nil
+}
+
 // encodeHeapID creates a heap ID for a managed object.
 //
 // Format:
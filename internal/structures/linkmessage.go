@@ -1,6 +1,7 @@
 package structures
 
 import (
+	"bytes"
 	"encoding/binary"
 	"fmt"
 
@@ -32,6 +33,10 @@ type LinkMessage struct {
 
 	// For soft links.
 	TargetPath string
+
+	// For external links.
+	ExternalFile string
+	ExternalPath string
 }
 
 // Link message flag bits.
@@ -192,20 +197,57 @@ func ParseLinkMessage(data []byte, sb *core.Superblock) (*LinkMessage, error) {
 
 	default:
 		// External or user-defined links (type >= 64).
-		// For now, just skip the data.
 		// User-defined link data: 2 bytes length + data.
 		if current+2 > len(data) {
 			return nil, fmt.Errorf("unexpected end of data reading user-defined link length")
 		}
-		udLen := binary.LittleEndian.Uint16(data[current : current+2])
-		// Skip user-defined link data: current += 2 + int(udLen).
-		// Not implemented yet, so we don't need to track current.
-		_ = udLen // Acknowledge we read it but don't use it.
+		udLen := int(binary.LittleEndian.Uint16(data[current : current+2]))
+		current += 2
+		if current+udLen > len(data) {
+			return nil, fmt.Errorf("unexpected end of data reading user-defined link data")
+		}
+		if msg.Type == LinkTypeExternal {
+			if err := parseExternalLinkValue(msg, data[current:], udLen); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	return msg, nil
 }
 
+// parseExternalLinkValue parses the value of an external link: a version
+// and flags byte (both 0) followed by the null-terminated file name and
+// object path. data starts after the 2-byte length udLen.
+//
+// Earlier versions of this package wrote the file name and object path each
+// with a 2-byte length prefix instead; the first length is then udLen and
+// the version byte is the first character of the file name.
+//
+// Reference: H5Lexternal.c - H5L__extern_traverse().
+func parseExternalLinkValue(msg *LinkMessage, data []byte, udLen int) error {
+	if udLen > 0 && data[0] == 0 {
+		parts := bytes.SplitN(data[1:udLen], []byte{0}, 3)
+		if len(parts) < 2 {
+			return fmt.Errorf("malformed external link value")
+		}
+		msg.ExternalFile = string(parts[0])
+		msg.ExternalPath = string(parts[1])
+		return nil
+	}
+
+	if udLen+2 > len(data) {
+		return fmt.Errorf("unexpected end of data reading external link path length")
+	}
+	pathLen := int(binary.LittleEndian.Uint16(data[udLen : udLen+2]))
+	if udLen+2+pathLen > len(data) {
+		return fmt.Errorf("unexpected end of data reading external link path")
+	}
+	msg.ExternalFile = string(data[:udLen])
+	msg.ExternalPath = string(data[udLen+2 : udLen+2+pathLen])
+	return nil
+}
+
 // IsHardLink returns true if this is a hard link.
 func (lm *LinkMessage) IsHardLink() bool {
 	return lm.Type == LinkTypeHard
@@ -222,6 +222,30 @@ func TestParseLinkMessage_ExternalLink(t *testing.T) {
 	require.False(t, msg.IsSoftLink())
 }
 
+func TestParseLinkMessage_ExternalLinkValue(t *testing.T) {
+	sb := createMockSuperblock()
+	header := []byte{1, flagNameSize0 | flagStoreLinkType, byte(LinkTypeExternal), 3, 'e', 'x', 't'}
+
+	// HDF5 format: version/flags byte, then null-terminated file and path.
+	value := []byte("\x00other.h5\x00/data/x\x00")
+	buf := append(append([]byte{}, header...), byte(len(value)), 0)
+	buf = append(buf, value...)
+	msg, err := ParseLinkMessage(buf, sb)
+	require.NoError(t, err)
+	require.Equal(t, "other.h5", msg.ExternalFile)
+	require.Equal(t, "/data/x", msg.ExternalPath)
+
+	// Length-prefixed layout written by earlier versions of this package.
+	buf = append(append([]byte{}, header...), 8, 0)
+	buf = append(buf, "other.h5"...)
+	buf = append(buf, 7, 0)
+	buf = append(buf, "/data/x"...)
+	msg, err = ParseLinkMessage(buf, sb)
+	require.NoError(t, err)
+	require.Equal(t, "other.h5", msg.ExternalFile)
+	require.Equal(t, "/data/x", msg.ExternalPath)
+}
+
 func TestParseLinkMessage_NameSizeVariants(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -1106,6 +1106,52 @@ func TestDeleteObject(t *testing.T) {
 	}
 }
 
+// TestCompact tests that compaction reclaims the space of deleted objects.
+func TestCompact(t *testing.T) {
+	t.Parallel()
+
+	fh := NewWritableFractalHeap(256)
+
+	keep1, err := fh.InsertObject([]byte("first"))
+	if err != nil {
+		t.Fatalf("InsertObject failed: %v", err)
+	}
+	dropped, err := fh.InsertObject(bytes.Repeat([]byte{'x'}, 150))
+	if err != nil {
+		t.Fatalf("InsertObject failed: %v", err)
+	}
+	keep2, err := fh.InsertObject([]byte("second"))
+	if err != nil {
+		t.Fatalf("InsertObject failed: %v", err)
+	}
+	if err := fh.DeleteObject(dropped); err != nil {
+		t.Fatalf("DeleteObject failed: %v", err)
+	}
+
+	newIDs, err := fh.Compact([][]byte{keep2, keep1})
+	if err != nil {
+		t.Fatalf("Compact failed: %v", err)
+	}
+	for i, want := range []string{"second", "first"} {
+		got, err := fh.GetObject(newIDs[i])
+		if err != nil {
+			t.Fatalf("GetObject(%d) failed: %v", i, err)
+		}
+		if string(got) != want {
+			t.Errorf("object %d = %q, want %q", i, got, want)
+		}
+	}
+	if fh.Header.NumManagedObjects != 2 || fh.DirectBlock.FreeOffset != 11 {
+		t.Errorf("after compaction: %d objects, free offset %d; want 2, 11",
+			fh.Header.NumManagedObjects, fh.DirectBlock.FreeOffset)
+	}
+
+	// The reclaimed space is usable again.
+	if _, err := fh.InsertObject(bytes.Repeat([]byte{'y'}, 150)); err != nil {
+		t.Fatalf("InsertObject after compaction failed: %v", err)
+	}
+}
+
 // TestDeleteObject_InvalidID tests deletion with invalid heap IDs.
 func TestDeleteObject_InvalidID(t *testing.T) {
 	t.Parallel()
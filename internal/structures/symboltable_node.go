@@ -110,10 +110,15 @@ func ParseSymbolTableNode(r io.ReaderAt, address uint64, sb *core.Superblock) (*
 
 		// Read scratch-pad (16 bytes).
 		// For CacheType == 1 (H5G_CACHED_STAB), this contains cached B-tree and heap addresses.
+		// For CacheType == 2 (H5G_CACHED_SLINK), the soft link value offset in the local heap.
 		var cachedBTree, cachedHeap uint64
-		if cacheType == 1 {
+		var softLinkOffset uint32
+		switch cacheType {
+		case CacheTypeSymbolTable:
 			cachedBTree = readAddressFromBytes(data[offset:], int(sb.OffsetSize), sb.Endianness)
 			cachedHeap = readAddressFromBytes(data[offset+int(sb.OffsetSize):], int(sb.OffsetSize), sb.Endianness)
+		case CacheTypeSoftLink:
+			softLinkOffset = sb.Endianness.Uint32(data[offset : offset+4])
 		}
 		offset += 16
 
@@ -124,6 +129,8 @@ func ParseSymbolTableNode(r io.ReaderAt, address uint64, sb *core.Superblock) (*
 			Reserved:        reserved,
 			CachedBTreeAddr: cachedBTree,
 			CachedHeapAddr:  cachedHeap,
+
+			CachedSoftLinkOffset: softLinkOffset,
 		})
 	}
 
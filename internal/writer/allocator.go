@@ -57,6 +57,12 @@ type Allocator struct {
 	blocks     []AllocatedBlock // All allocated blocks
 	freeList   []FreeBlock      // Free blocks sorted by offset
 	nextOffset uint64           // Next available address (end-of-file)
+
+	alignThreshold uint64 // Minimum size of aligned allocations
+	alignment      uint64 // Address multiple for aligned allocations (0 or 1: none)
+
+	metaBlockSize uint64    // Size of metadata aggregation blocks (0: no aggregation)
+	metaBlock     FreeBlock // Unused part of the current metadata block
 }
 
 // NewAllocator creates a space allocator.
@@ -96,7 +102,8 @@ func NewAllocator(initialOffset uint64) *Allocator {
 //   - Allocates at current end-of-file (sequential allocation)
 //   - Updates end-of-file pointer to addr + size
 //   - Tracks allocation in internal block list
-//   - No alignment enforcement (deferred to RC)
+//   - Allocations of at least the alignment threshold start at a multiple
+//     of the alignment (see SetAlignment)
 //   - No size limit validation (OS will reject impossible sizes)
 //
 // Parameters:
@@ -121,10 +128,20 @@ func NewAllocator(initialOffset uint64) *Allocator {
 //	// Use addr to write data to file
 //	file.WriteAt(data, int64(addr))
 func (a *Allocator) Allocate(size uint64) (uint64, error) {
+	return a.allocate(size, true)
+}
+
+// allocate reserves size bytes; metadata allocations may be taken from a
+// metadata block.
+func (a *Allocator) allocate(size uint64, metadata bool) (uint64, error) {
 	if size == 0 {
 		return 0, fmt.Errorf("cannot allocate zero bytes")
 	}
 
+	if a.alignment > 1 && size >= a.alignThreshold {
+		return a.allocateAligned(size), nil
+	}
+
 	// Try to reuse freed space (best-fit strategy).
 	// Best-fit minimizes wasted space by choosing the smallest free block
 	// that can satisfy the request.
@@ -139,6 +156,10 @@ func (a *Allocator) Allocate(size uint64) (uint64, error) {
 		}
 	}
 
+	if bestIdx < 0 && metadata && a.metaBlockSize > 0 && size < a.metaBlockSize {
+		return a.allocateFromMetadataBlock(size), nil
+	}
+
 	if bestIdx >= 0 {
 		fb := a.freeList[bestIdx]
 		addr := fb.Offset
@@ -169,6 +190,155 @@ func (a *Allocator) Allocate(size uint64) (uint64, error) {
 	return addr, nil
 }
 
+// AllocateRaw reserves a block of space for raw data: contiguous dataset
+// storage, chunks and global heap collections. It behaves like Allocate,
+// but never takes space from a metadata block (see SetMetadataBlockSize),
+// so raw data does not split the metadata aggregated there.
+//
+// Reference: H5MFaggr.c - H5MF__aggr_alloc() (small data aggregator).
+func (a *Allocator) AllocateRaw(size uint64) (uint64, error) {
+	return a.allocate(size, false)
+}
+
+// SetMetadataBlockSize makes metadata allocations smaller than size share
+// blocks of size bytes, like H5Pset_meta_block_size. When a request does not
+// fit in the current block, a new block is reserved at the end of the file
+// and the rest of the old one is added to the free list. Allocations of size
+// bytes or more, and raw data allocated with AllocateRaw, are placed as
+// usual. A size of 0 disables aggregation.
+//
+// Grouping metadata keeps object headers, heaps and B-tree nodes close
+// together instead of interleaving them with dataset data, so files with
+// many small objects read faster.
+//
+// Example:
+//
+//	alloc.SetMetadataBlockSize(64 * 1024) // Aggregate metadata in 64KB blocks
+func (a *Allocator) SetMetadataBlockSize(size uint64) {
+	a.ReleaseMetadataBlock()
+	a.metaBlockSize = size
+}
+
+// ReleaseMetadataBlock returns the unused part of the current metadata block
+// to the allocator. If it is at the end of the file, the file shrinks. Call
+// it before the end-of-file address is recorded, so the file does not end
+// with space that was never written.
+//
+// Reference: H5MFaggr.c - H5MF_free_aggrs().
+func (a *Allocator) ReleaseMetadataBlock() {
+	rest := a.metaBlock
+	a.metaBlock = FreeBlock{}
+	if rest.Size == 0 {
+		return
+	}
+	if rest.Offset+rest.Size == a.nextOffset {
+		a.nextOffset = rest.Offset
+		a.shrinkTrailingFreeBlocks()
+		return
+	}
+	a.addToFreeList(rest.Offset, rest.Size)
+}
+
+// allocateFromMetadataBlock reserves size bytes from the current metadata
+// block, first reserving a new block at the end of file if it is too small.
+//
+// Reference: H5MFaggr.c - H5MF__aggr_alloc().
+func (a *Allocator) allocateFromMetadataBlock(size uint64) uint64 {
+	if a.metaBlock.Size < size {
+		a.ReleaseMetadataBlock()
+		a.metaBlock = FreeBlock{Offset: a.nextOffset, Size: a.metaBlockSize}
+		a.nextOffset += a.metaBlockSize
+	}
+
+	addr := a.metaBlock.Offset
+	a.metaBlock.Offset += size
+	a.metaBlock.Size -= size
+	a.blocks = append(a.blocks, AllocatedBlock{Offset: addr, Size: size})
+	return addr
+}
+
+// AdvanceEndOfFile moves the end of file to end if it is below it, marking
+// the space in between as allocated. It is used when an object at the end of
+// the file grew in place.
+func (a *Allocator) AdvanceEndOfFile(end uint64) {
+	if end <= a.nextOffset {
+		return
+	}
+	a.blocks = append(a.blocks, AllocatedBlock{Offset: a.nextOffset, Size: end - a.nextOffset})
+	a.nextOffset = end
+}
+
+// SetAlignment makes allocations of at least threshold bytes start at a
+// multiple of alignment, like H5Pset_alignment. Smaller allocations are
+// unaffected. An alignment of 0 or 1 disables alignment.
+//
+// The bytes skipped to reach an aligned address are added to the free list,
+// so later small allocations can use them.
+//
+// Parameters:
+//   - threshold: Minimum allocation size that is aligned
+//   - alignment: Address multiple for aligned allocations
+//
+// Example:
+//
+//	alloc.SetAlignment(64*1024, 4096) // Align blocks of 64KB or more to 4KB
+func (a *Allocator) SetAlignment(threshold, alignment uint64) {
+	a.alignThreshold = threshold
+	a.alignment = alignment
+}
+
+// allocateAligned reserves size bytes at a multiple of the alignment, from
+// the best-fitting free block that can hold the aligned range, or else at
+// the end of file. Unused parts of the chosen space stay free.
+//
+// Reference: H5FDspace.c - H5FD__alloc_real() (alignment fragments),
+// H5FS.c - H5FS_sect_find().
+func (a *Allocator) allocateAligned(size uint64) uint64 {
+	bestIdx := -1
+	var bestSize, bestAddr uint64
+	for i, fb := range a.freeList {
+		addr := alignUp(fb.Offset, a.alignment)
+		if addr+size > fb.Offset+fb.Size {
+			continue
+		}
+		if bestIdx == -1 || fb.Size < bestSize {
+			bestIdx = i
+			bestSize = fb.Size
+			bestAddr = addr
+		}
+	}
+
+	var addr uint64
+	if bestIdx >= 0 {
+		fb := a.freeList[bestIdx]
+		a.freeList = append(a.freeList[:bestIdx], a.freeList[bestIdx+1:]...)
+		addr = bestAddr
+		if addr > fb.Offset {
+			a.addToFreeList(fb.Offset, addr-fb.Offset)
+		}
+		if end := addr + size; end < fb.Offset+fb.Size {
+			a.addToFreeList(end, fb.Offset+fb.Size-end)
+		}
+	} else {
+		addr = alignUp(a.nextOffset, a.alignment)
+		if addr > a.nextOffset {
+			a.addToFreeList(a.nextOffset, addr-a.nextOffset)
+		}
+		a.nextOffset = addr + size
+	}
+
+	a.blocks = append(a.blocks, AllocatedBlock{Offset: addr, Size: size})
+	return addr
+}
+
+// alignUp rounds addr up to a multiple of alignment.
+func alignUp(addr, alignment uint64) uint64 {
+	if rem := addr % alignment; rem != 0 {
+		return addr + alignment - rem
+	}
+	return addr
+}
+
 // IsAllocated checks if an address range overlaps with any allocated blocks.
 //
 // This method is useful for validation and debugging to ensure no
@@ -656,3 +656,140 @@ func BenchmarkValidateNoOverlaps(b *testing.B) {
 		_ = alloc.ValidateNoOverlaps()
 	}
 }
+
+func TestAllocator_Alignment(t *testing.T) {
+	t.Run("aligns large allocations at end of file", func(t *testing.T) {
+		alloc := NewAllocator(48)
+		alloc.SetAlignment(1024, 4096)
+
+		addr, err := alloc.Allocate(2000)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(4096), addr)
+		assert.Equal(t, uint64(6096), alloc.EndOfFile())
+		assert.Equal(t, []FreeBlock{{Offset: 48, Size: 4048}}, alloc.FreeBlocks())
+
+		// Small allocations are not aligned and reuse the gap.
+		small, err := alloc.Allocate(100)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(48), small)
+
+		addr, err = alloc.Allocate(1024)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(8192), addr)
+		require.NoError(t, alloc.ValidateNoOverlaps())
+	})
+
+	t.Run("aligns within free blocks", func(t *testing.T) {
+		alloc := NewAllocator(0)
+		alloc.SetAlignment(1, 1024)
+
+		hole, err := alloc.Allocate(3000)
+		require.NoError(t, err)
+		_, err = alloc.Allocate(10) // Keeps the freed block off the end of file
+		require.NoError(t, err)
+		require.NoError(t, alloc.Free(hole, 3000))
+		require.Equal(t, []FreeBlock{{Offset: 0, Size: 3072}}, alloc.FreeBlocks())
+
+		addr, err := alloc.Allocate(1000)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(0), addr)
+
+		// The rest of the hole starts at 1000; the aligned block skips to 1024
+		// and both leftovers stay free.
+		addr, err = alloc.Allocate(1000)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1024), addr)
+		assert.Equal(t, []FreeBlock{{Offset: 1000, Size: 24}, {Offset: 2024, Size: 1048}}, alloc.FreeBlocks())
+		require.NoError(t, alloc.ValidateNoOverlaps())
+	})
+
+	t.Run("alignment of one is a no-op", func(t *testing.T) {
+		alloc := NewAllocator(48)
+		alloc.SetAlignment(1, 1)
+
+		addr, err := alloc.Allocate(10)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(48), addr)
+	})
+}
+
+func TestAllocator_MetadataBlock(t *testing.T) {
+	t.Run("aggregates small metadata and skips raw data", func(t *testing.T) {
+		alloc := NewAllocator(48)
+		alloc.SetMetadataBlockSize(1024)
+
+		first, err := alloc.Allocate(100)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(48), first)
+		assert.Equal(t, uint64(48+1024), alloc.EndOfFile())
+
+		raw, err := alloc.AllocateRaw(500)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(48+1024), raw)
+
+		// Metadata continues in the block, before the raw data.
+		second, err := alloc.Allocate(200)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(148), second)
+		require.NoError(t, alloc.ValidateNoOverlaps())
+	})
+
+	t.Run("starts a new block when the current one is full", func(t *testing.T) {
+		alloc := NewAllocator(0)
+		alloc.SetMetadataBlockSize(256)
+
+		_, err := alloc.Allocate(200)
+		require.NoError(t, err)
+		_, err = alloc.AllocateRaw(1000)
+		require.NoError(t, err)
+		addr, err := alloc.Allocate(100)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1256), addr)
+		assert.Equal(t, []FreeBlock{{Offset: 200, Size: 56}}, alloc.FreeBlocks())
+
+		// Allocations of a block or more bypass the block.
+		big, err := alloc.Allocate(256)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1512), big)
+		require.NoError(t, alloc.ValidateNoOverlaps())
+	})
+
+	t.Run("release shrinks the end of file", func(t *testing.T) {
+		alloc := NewAllocator(0)
+		alloc.SetMetadataBlockSize(4096)
+
+		_, err := alloc.Allocate(100)
+		require.NoError(t, err)
+		alloc.ReleaseMetadataBlock()
+		assert.Equal(t, uint64(100), alloc.EndOfFile())
+		assert.Empty(t, alloc.FreeBlocks())
+
+		addr, err := alloc.Allocate(10)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(100), addr)
+	})
+
+	t.Run("release keeps an inner block free", func(t *testing.T) {
+		alloc := NewAllocator(0)
+		alloc.SetMetadataBlockSize(1024)
+
+		_, err := alloc.Allocate(24)
+		require.NoError(t, err)
+		_, err = alloc.AllocateRaw(4096)
+		require.NoError(t, err)
+		alloc.ReleaseMetadataBlock()
+		assert.Equal(t, uint64(1024+4096), alloc.EndOfFile())
+		assert.Equal(t, []FreeBlock{{Offset: 24, Size: 1000}}, alloc.FreeBlocks())
+	})
+}
+
+func TestAllocator_AdvanceEndOfFile(t *testing.T) {
+	alloc := NewAllocator(48)
+
+	alloc.AdvanceEndOfFile(40)
+	assert.Equal(t, uint64(48), alloc.EndOfFile())
+
+	alloc.AdvanceEndOfFile(100)
+	assert.Equal(t, uint64(100), alloc.EndOfFile())
+	assert.True(t, alloc.IsAllocated(48, 52))
+}
@@ -201,13 +201,16 @@ func (cc *ChunkCoordinator) GetChunkSize(coord []uint64) []uint64 {
 // The dataset is laid out in row-major order (C order), and the chunk
 // data is extracted maintaining this layout.
 //
+// The result always has the full chunk dimensions: HDF5 stores edge chunks
+// whole, so the part of an edge chunk outside the dataset is zero-padded.
+//
 // Parameters:
 //   - data: Full dataset buffer (row-major layout)
 //   - coord: Chunk coordinate to extract
 //   - elemSize: Size of each element in bytes
 //
 // Returns:
-//   - []byte: Extracted chunk data (contiguous buffer)
+//   - []byte: Extracted chunk data (contiguous buffer of the full chunk size)
 //
 // Example (2D, dataset 20x30 uint32, chunks 10x10):
 //
@@ -215,79 +218,128 @@ func (cc *ChunkCoordinator) GetChunkSize(coord []uint64) []uint64 {
 //	chunk [0,1]: extract data[0:10, 10:20]
 //	chunk [1,0]: extract data[10:20, 0:10]
 //
-// Algorithm:
-//
-//	For each element in chunk:
-//	  1. Calculate position in dataset coordinates
-//	  2. Calculate linear offset in dataset buffer
-//	  3. Copy element to chunk buffer
+// Reference: H5Dchunk.c - H5D__chunk_write() (edge chunks are full size).
 func (cc *ChunkCoordinator) ExtractChunkData(data []byte, coord []uint64, elemSize uint32) []byte {
-	chunkSize := cc.GetChunkSize(coord)
+	chunkData := make([]byte, cc.chunkBytes(elemSize))
 
-	// Calculate total number of elements in chunk
-	numElements := uint64(1)
-	for _, dim := range chunkSize {
-		numElements *= dim
+	start := make([]uint64, len(coord))
+	for i := range coord {
+		start[i] = coord[i] * cc.chunkDims[i]
 	}
+	copyRegion(chunkData, cc.chunkDims, data, cc.datasetDims, start, cc.GetChunkSize(coord), elemSize)
+
+	return chunkData
+}
 
-	// Allocate buffer for chunk data
-	chunkData := make([]byte, numElements*uint64(elemSize))
+// PadChunkData places the data of the part of a chunk inside the dataset,
+// as returned by GetChunkSize and laid out in row-major order, into a
+// zero-padded buffer of the full chunk size. Data of interior chunks is
+// returned unchanged.
+func (cc *ChunkCoordinator) PadChunkData(data []byte, coord []uint64, elemSize uint32) []byte {
+	full := cc.chunkBytes(elemSize)
+	if uint64(len(data)) == full {
+		return data
+	}
 
-	// Extract data recursively
-	cc.extractRecursive(data, chunkData, coord, 0, 0, 0, elemSize)
+	chunkData := make([]byte, full)
+	size := cc.GetChunkSize(coord)
+	copyRegion(chunkData, cc.chunkDims, data, size, make([]uint64, len(size)), size, elemSize)
 
 	return chunkData
 }
 
-// extractRecursive extracts chunk data recursively for each dimension.
-//
-// This recursive method traverses the dataset and chunk buffers dimension
-// by dimension, copying elements from the dataset to the chunk buffer.
-//
-// Parameters:
-//   - src: Source dataset buffer
-//   - dst: Destination chunk buffer
-//   - coord: Chunk coordinate
-//   - dim: Current dimension being processed
-//   - srcOff: Current offset in source buffer
-//   - dstOff: Current offset in destination buffer
-//   - elemSize: Size of each element in bytes
-//
-// Base case: dim == len(datasetDims) → copy single element
-// Recursive case: iterate over chunk size in current dimension.
-func (cc *ChunkCoordinator) extractRecursive(src, dst []byte, coord []uint64, dim int, srcOff, dstOff uint64, elemSize uint32) {
-	// Base case: reached innermost dimension, copy element
-	if dim == len(cc.datasetDims) {
-		copy(dst[dstOff:dstOff+uint64(elemSize)], src[srcOff:srcOff+uint64(elemSize)])
+// MergeRows copies into chunk the part of data that falls inside the chunk
+// at coord. data holds whole dataset rows (all dimensions but the first) in
+// row-major order, starting at dataset row firstRow; chunk is a full-size
+// chunk buffer, whose other elements are left as they are. It is used to
+// append rows to a dataset whose last chunks are partly filled.
+//
+// Example (1D dataset of 7 elements, chunks of 4, data = rows 5 and 6):
+//
+//	chunk [1] covers rows 4-7: data is copied to chunk elements 1 and 2
+func (cc *ChunkCoordinator) MergeRows(chunk []byte, coord []uint64, data []byte, firstRow uint64, elemSize uint32) {
+	rank := len(cc.datasetDims)
+	rowBytes := uint64(elemSize)
+	chunkRowBytes := uint64(elemSize)
+	for i := 1; i < rank; i++ {
+		rowBytes *= cc.datasetDims[i]
+		chunkRowBytes *= cc.chunkDims[i]
+	}
+	rows := uint64(len(data)) / rowBytes
+
+	start := coord[0] * cc.chunkDims[0]
+	from := max(start, firstRow)
+	end := min(start+cc.chunkDims[0], firstRow+rows, cc.datasetDims[0])
+	if from >= end {
 		return
 	}
 
-	// Get actual chunk size in this dimension (may be partial)
-	chunkSize := cc.GetChunkSize(coord)
+	srcDims := append([]uint64{rows}, cc.datasetDims[1:]...)
+	srcStart := make([]uint64, rank)
+	srcStart[0] = from - firstRow
+	for i := 1; i < rank; i++ {
+		srcStart[i] = coord[i] * cc.chunkDims[i]
+	}
+	size := cc.GetChunkSize(coord)
+	size[0] = end - from
+	copyRegion(chunk[(from-start)*chunkRowBytes:], cc.chunkDims, data, srcDims, srcStart, size, elemSize)
+}
+
+// chunkBytes returns the size in bytes of a full chunk.
+func (cc *ChunkCoordinator) chunkBytes(elemSize uint32) uint64 {
+	n := uint64(elemSize)
+	for _, dim := range cc.chunkDims {
+		n *= dim
+	}
+	return n
+}
 
-	// Calculate strides for dataset and chunk
-	// Dataset stride: number of bytes to skip to move one step in this dimension
-	dsStride := uint64(1)
-	for i := dim + 1; i < len(cc.datasetDims); i++ {
-		dsStride *= cc.datasetDims[i]
+// copyRegion copies a block of size elements starting at srcStart of the
+// row-major array src (shape srcDims) to the origin of the row-major array
+// dst (shape dstDims).
+//
+// Algorithm: walk every row of the block (all dimensions but the last) and
+// copy it with one copy call.
+func copyRegion(dst []byte, dstDims []uint64, src []byte, srcDims, srcStart, size []uint64, elemSize uint32) {
+	rank := len(size)
+	for _, n := range size {
+		if n == 0 {
+			return
+		}
 	}
-	dsStride *= uint64(elemSize)
 
-	// Chunk stride: number of bytes to skip in chunk buffer
-	chunkStride := uint64(1)
-	for i := dim + 1; i < len(chunkSize); i++ {
-		chunkStride *= chunkSize[i]
+	// Strides in bytes of each dimension.
+	srcStrides := make([]uint64, rank)
+	dstStrides := make([]uint64, rank)
+	srcStride, dstStride := uint64(elemSize), uint64(elemSize)
+	for i := rank - 1; i >= 0; i-- {
+		srcStrides[i], dstStrides[i] = srcStride, dstStride
+		srcStride *= srcDims[i]
+		dstStride *= dstDims[i]
 	}
-	chunkStride *= uint64(elemSize)
 
-	// Calculate starting position in dataset for this chunk
-	start := coord[dim] * cc.chunkDims[dim]
+	rowBytes := size[rank-1] * uint64(elemSize)
+	idx := make([]uint64, rank)
+	for {
+		var srcOff, dstOff uint64
+		for i := 0; i < rank; i++ {
+			srcOff += (srcStart[i] + idx[i]) * srcStrides[i]
+			dstOff += idx[i] * dstStrides[i]
+		}
+		copy(dst[dstOff:dstOff+rowBytes], src[srcOff:srcOff+rowBytes])
 
-	// Iterate over chunk size in this dimension
-	for i := uint64(0); i < chunkSize[dim]; i++ {
-		newSrc := srcOff + (start+i)*dsStride
-		newDst := dstOff + i*chunkStride
-		cc.extractRecursive(src, dst, coord, dim+1, newSrc, newDst, elemSize)
+		// Advance to the next row, last dimensions first.
+		i := rank - 2
+		for ; i >= 0; i-- {
+			idx[i]++
+			if idx[i] < size[i] {
+				break
+			}
+			idx[i] = 0
+		}
+		if i < 0 {
+			return
+		}
 	}
 }
 
@@ -327,13 +327,14 @@ func TestExtractChunkData(t *testing.T) {
 			require.Equal(t, i+4, val, "chunk1 element %d", i)
 		}
 
-		// Extract chunk 2 [8-9] (edge chunk, 2 elements)
+		// Extract chunk 2 [8-9] (edge chunk, 2 elements zero-padded to 4)
 		chunk2 := cc.ExtractChunkData(data, []uint64{2}, elemSize)
-		require.Equal(t, 2*elemSize, uint32(len(chunk2)))
+		require.Equal(t, 4*elemSize, uint32(len(chunk2)))
 		for i := uint32(0); i < 2; i++ {
 			val := binary.LittleEndian.Uint32(chunk2[i*elemSize:])
 			require.Equal(t, i+8, val, "chunk2 element %d", i)
 		}
+		require.Equal(t, make([]byte, 2*elemSize), chunk2[2*elemSize:])
 	})
 
 	t.Run("2D extraction", func(t *testing.T) {
@@ -423,13 +424,24 @@ func TestExtractChunkData(t *testing.T) {
 		//   Row 3: 21-27
 		//   Row 4: 28-34
 		// Elements: row3 col6 = 3*7+6=27, row4 col6 = 4*7+6=34
+		// The chunk keeps its full 3x3 shape, zero-padded:
+		//   27 0 0
+		//   34 0 0
+		//    0 0 0
 		chunk := cc.ExtractChunkData(data, []uint64{1, 2}, elemSize)
-		require.Equal(t, 2*elemSize, uint32(len(chunk)))
+		require.Equal(t, 9*elemSize, uint32(len(chunk)))
 
-		val0 := binary.LittleEndian.Uint32(chunk[0:4])
-		val1 := binary.LittleEndian.Uint32(chunk[4:8])
-		require.Equal(t, uint32(27), val0)
-		require.Equal(t, uint32(34), val1)
+		for i := 0; i < 9; i++ {
+			val := binary.LittleEndian.Uint32(chunk[i*4:])
+			switch i {
+			case 0:
+				require.Equal(t, uint32(27), val)
+			case 3:
+				require.Equal(t, uint32(34), val)
+			default:
+				require.Zero(t, val, "padding element %d", i)
+			}
+		}
 	})
 
 	t.Run("3D extraction", func(t *testing.T) {
@@ -471,6 +483,44 @@ func TestExtractChunkData(t *testing.T) {
 	})
 }
 
+// TestPadChunkData tests padding partial edge chunk data to the chunk size.
+func TestPadChunkData(t *testing.T) {
+	// Dataset 5x7, chunks 3x3: chunk [1,2] holds 2x1 elements.
+	cc, err := NewChunkCoordinator([]uint64{5, 7}, []uint64{3, 3})
+	require.NoError(t, err)
+
+	padded := cc.PadChunkData([]byte{1, 2}, []uint64{1, 2}, 1)
+	require.Equal(t, []byte{1, 0, 0, 2, 0, 0, 0, 0, 0}, padded)
+
+	// Interior chunks are returned as is.
+	full := []byte{1, 2, 3, 4, 5, 6, 7, 8, 9}
+	require.Equal(t, full, cc.PadChunkData(full, []uint64{0, 0}, 1))
+}
+
+// TestMergeRows tests copying appended rows into partly filled chunks.
+func TestMergeRows(t *testing.T) {
+	// Dataset 5x3, chunks 3x2; data holds rows 2-4.
+	cc, err := NewChunkCoordinator([]uint64{5, 3}, []uint64{3, 2})
+	require.NoError(t, err)
+	data := []byte{1, 2, 3, 4, 5, 6, 7, 8, 9}
+
+	// Rows 0-1 of chunk [0,0] were written before and are kept.
+	chunk := []byte{9, 9, 9, 9, 0, 0}
+	cc.MergeRows(chunk, []uint64{0, 0}, data, 2, 1)
+	require.Equal(t, []byte{9, 9, 9, 9, 1, 2}, chunk)
+
+	chunk = make([]byte, 6)
+	cc.MergeRows(chunk, []uint64{1, 1}, data, 2, 1)
+	require.Equal(t, []byte{6, 0, 9, 0, 0, 0}, chunk)
+
+	// Chunks before the first appended row are untouched.
+	cc2, err := NewChunkCoordinator([]uint64{8}, []uint64{4})
+	require.NoError(t, err)
+	chunk = []byte{1, 1, 1, 1}
+	cc2.MergeRows(chunk, []uint64{0}, []byte{5, 6}, 6, 1)
+	require.Equal(t, []byte{1, 1, 1, 1}, chunk)
+}
+
 // TestChunkCoordinator_Getters tests read-only getters.
 func TestChunkCoordinator_Getters(t *testing.T) {
 	datasetDims := []uint64{10, 20, 30}
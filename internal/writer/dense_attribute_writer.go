@@ -90,6 +90,47 @@ func (daw *DenseAttributeWriter) AddAttribute(attr *core.Attribute, sb *core.Sup
 		return fmt.Errorf("failed to encode attribute: %w", err)
 	}
 
+	if err := daw.insert(attr.Name, attrMsg); err != nil {
+		return err
+	}
+
+	// Track for duplicate detection
+	daw.attributes[attr.Name] = attr
+
+	return nil
+}
+
+// AddEncodedAttribute adds an already encoded attribute message to dense storage.
+//
+// The message is stored byte for byte, so attributes copied from another file keep
+// their original datatype and dataspace encoding (including types that
+// core.EncodeAttributeFromStruct cannot produce, such as enums and arrays).
+//
+// Parameters:
+//   - name: Attribute name (B-tree key; must match the name inside attrMsg)
+//   - attrMsg: Encoded attribute message (type 0x000C body)
+//
+// Returns:
+//   - error: Non-nil if add fails or duplicate name
+func (daw *DenseAttributeWriter) AddEncodedAttribute(name string, attrMsg []byte) error {
+	if name == "" {
+		return fmt.Errorf("attribute name cannot be empty")
+	}
+
+	if _, exists := daw.attributes[name]; exists {
+		return fmt.Errorf("attribute %q already exists", name)
+	}
+
+	if err := daw.insert(name, attrMsg); err != nil {
+		return err
+	}
+
+	daw.attributes[name] = nil
+	return nil
+}
+
+// insert stores an encoded attribute message in the fractal heap and indexes it by name.
+func (daw *DenseAttributeWriter) insert(name string, attrMsg []byte) error {
 	// 2. Insert into fractal heap (REUSE from dense groups!)
 	heapIDBytes, err := daw.fractalHeap.InsertObject(attrMsg)
 	if err != nil {
@@ -105,14 +146,10 @@ func (daw *DenseAttributeWriter) AddAttribute(attr *core.Attribute, sb *core.Sup
 
 	// 3. Insert into B-tree v2 (REUSE from dense groups!)
 	// For attributes, we use attribute name directly (not link name)
-	err = daw.btree.InsertRecord(attr.Name, heapID)
-	if err != nil {
+	if err := daw.btree.InsertRecord(name, heapID); err != nil {
 		return fmt.Errorf("failed to insert into B-tree: %w", err)
 	}
 
-	// Track for duplicate detection
-	daw.attributes[attr.Name] = attr
-
 	return nil
 }
 
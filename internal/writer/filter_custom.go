@@ -0,0 +1,64 @@
+package writer
+
+import (
+	"fmt"
+
+	"github.com/scigolib/hdf5/internal/core"
+)
+
+// CustomFilter applies a third-party filter registered with
+// core.RegisterFilter. The filter functions are looked up on each call, so
+// the filter may be registered after the pipeline is built.
+type CustomFilter struct {
+	id         FilterID
+	clientData []uint32
+}
+
+// NewCustomFilter creates a filter for the registered filter id with the
+// given client data (cd_values).
+func NewCustomFilter(id FilterID, clientData []uint32) *CustomFilter {
+	return &CustomFilter{id: id, clientData: clientData}
+}
+
+// ID returns the HDF5 filter identifier.
+func (f *CustomFilter) ID() FilterID {
+	return f.id
+}
+
+// Name returns the registered filter name, or an empty name if the filter
+// is not registered.
+func (f *CustomFilter) Name() string {
+	if rf, ok := core.LookupFilter(core.FilterID(f.id)); ok {
+		return rf.Name
+	}
+	return ""
+}
+
+// Apply runs the registered encode function (write path).
+func (f *CustomFilter) Apply(data []byte) ([]byte, error) {
+	rf, ok := core.LookupFilter(core.FilterID(f.id))
+	if !ok {
+		return nil, fmt.Errorf("filter %d is not registered", f.id)
+	}
+	if rf.Encode == nil {
+		return nil, fmt.Errorf("filter %d (%s) has no encode function", f.id, rf.Name)
+	}
+	return rf.Encode(data, f.clientData)
+}
+
+// Remove runs the registered decode function (read path).
+func (f *CustomFilter) Remove(data []byte) ([]byte, error) {
+	rf, ok := core.LookupFilter(core.FilterID(f.id))
+	if !ok {
+		return nil, fmt.Errorf("filter %d is not registered", f.id)
+	}
+	if rf.Decode == nil {
+		return nil, fmt.Errorf("filter %d (%s) has no decode function", f.id, rf.Name)
+	}
+	return rf.Decode(data, f.clientData)
+}
+
+// Encode returns the filter parameters for the Pipeline message.
+func (f *CustomFilter) Encode() (flags uint16, cdValues []uint32) {
+	return 0, f.clientData
+}
@@ -2,11 +2,30 @@ package writer
 
 import (
 	"bytes"
-	"compress/gzip"
+	"compress/zlib"
 	"fmt"
 	"io"
 )
 
+// DeflateStrategy selects how the GZIP filter encodes data. It only affects
+// compression: every strategy produces standard zlib streams.
+type DeflateStrategy int
+
+// Deflate strategies supported by compress/flate. zlib's Z_FILTERED and
+// Z_RLE have no equivalent there.
+const (
+	// DeflateDefault uses LZ77 matching and Huffman coding at the filter level.
+	DeflateDefault DeflateStrategy = iota
+
+	// DeflateHuffmanOnly skips match searching and only Huffman-codes bytes.
+	// Much faster; suits noisy data such as float mantissas.
+	DeflateHuffmanOnly
+
+	// DeflateStored writes stored (uncompressed) blocks, for data that is
+	// already compressed.
+	DeflateStored
+)
+
 // HDF5 filter label for DEFLATE / GZIP compression. Extracted as a
 // constant so goconst doesn't flag the duplicate string across source +
 // helper tests.
@@ -22,7 +41,8 @@ const filterDeflateName = "deflate"
 //	6 = balanced (default)
 //	9 = best compression, slower
 type GZIPFilter struct {
-	level int // Compression level (1-9)
+	level    int             // Compression level (1-9)
+	strategy DeflateStrategy // Encoding strategy (DeflateDefault unless set)
 }
 
 // NewGZIPFilter creates a GZIP filter with the specified compression level.
@@ -41,6 +61,24 @@ func NewGZIPFilter(level int) *GZIPFilter {
 	return &GZIPFilter{level: level}
 }
 
+// SetStrategy sets the encoding strategy. The level is still recorded in
+// the filter parameters.
+func (f *GZIPFilter) SetStrategy(strategy DeflateStrategy) {
+	f.strategy = strategy
+}
+
+// zlibLevel returns the compress/zlib level for the level and strategy.
+func (f *GZIPFilter) zlibLevel() int {
+	switch f.strategy {
+	case DeflateHuffmanOnly:
+		return zlib.HuffmanOnly
+	case DeflateStored:
+		return zlib.NoCompression
+	default:
+		return f.level
+	}
+}
+
 // ID returns the HDF5 filter identifier for GZIP.
 func (f *GZIPFilter) ID() FilterID {
 	return FilterGZIP
@@ -55,12 +93,16 @@ func (f *GZIPFilter) Name() string {
 // Apply compresses data using GZIP/DEFLATE algorithm.
 // Returns compressed data suitable for storage.
 //
-// The compressed data includes GZIP headers and CRC32 checksum.
+// The compressed data is a zlib stream (RFC 1950: 2-byte header, DEFLATE
+// data, Adler-32 checksum), which is what the HDF5 deflate filter stores.
+// Despite the filter's name it is not gzip (RFC 1952) framed.
+//
+// Reference: H5Zdeflate.c - H5Z__filter_deflate() (compress2/uncompress).
 func (f *GZIPFilter) Apply(data []byte) ([]byte, error) {
 	var buf bytes.Buffer
 
-	// Create gzip writer with specified compression level
-	w, err := gzip.NewWriterLevel(&buf, f.level)
+	// Create zlib writer with specified compression level and strategy
+	w, err := zlib.NewWriterLevel(&buf, f.zlibLevel())
 	if err != nil {
 		return nil, fmt.Errorf("gzip writer creation failed: %w", err)
 	}
@@ -79,15 +121,15 @@ func (f *GZIPFilter) Apply(data []byte) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-// Remove decompresses GZIP-compressed data.
+// Remove decompresses zlib-framed DEFLATE data.
 // Returns the original uncompressed data.
 //
 // This method reverses the Apply operation, restoring the original data.
 func (f *GZIPFilter) Remove(data []byte) ([]byte, error) {
 	buf := bytes.NewReader(data)
 
-	// Create gzip reader
-	r, err := gzip.NewReader(buf)
+	// Create zlib reader
+	r, err := zlib.NewReader(buf)
 	if err != nil {
 		return nil, fmt.Errorf("gzip reader creation failed: %w", err)
 	}
@@ -244,6 +244,33 @@ func TestGZIPFilter_CompressionRatio_Comparison(t *testing.T) {
 	require.Equal(t, data, decompressed9)
 }
 
+func TestGZIPFilter_Strategies(t *testing.T) {
+	data := bytes.Repeat([]byte("Lorem ipsum dolor sit amet. "), 1000)
+
+	sizes := make(map[DeflateStrategy]int)
+	for _, strategy := range []DeflateStrategy{DeflateDefault, DeflateHuffmanOnly, DeflateStored} {
+		filter := NewGZIPFilter(6)
+		filter.SetStrategy(strategy)
+
+		compressed, err := filter.Apply(data)
+		require.NoError(t, err)
+		sizes[strategy] = len(compressed)
+
+		// Decoding is standard deflate whatever the strategy.
+		decompressed, err := NewGZIPFilter(6).Remove(compressed)
+		require.NoError(t, err)
+		require.Equal(t, data, decompressed)
+
+		// The level is still recorded.
+		_, cd := filter.Encode()
+		require.Equal(t, []uint32{6}, cd)
+	}
+
+	require.Less(t, sizes[DeflateDefault], sizes[DeflateHuffmanOnly])
+	require.Less(t, sizes[DeflateHuffmanOnly], sizes[DeflateStored])
+	require.Greater(t, sizes[DeflateStored], len(data), "stored blocks add framing")
+}
+
 func TestGZIPFilter_Remove_InvalidData(t *testing.T) {
 	filter := NewGZIPFilter(6)
 
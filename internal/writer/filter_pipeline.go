@@ -23,6 +23,10 @@ const (
 	FilterLZF         FilterID = 32000 // LZF compression (PyTables/h5py)
 )
 
+// filterFlagOptional marks a filter whose failure leaves the chunk unfiltered
+// instead of failing the write (H5Z_FLAG_OPTIONAL).
+const filterFlagOptional uint16 = 0x0001
+
 // Filter interface for data transformation.
 // Filters are applied in sequence during write (e.g., Shuffle → GZIP → Fletcher32)
 // and reversed during read (Fletcher32 → GZIP → Shuffle).
@@ -118,6 +122,11 @@ func (fp *FilterPipeline) IsEmpty() bool {
 	return len(fp.filters) == 0
 }
 
+// Filters returns the filters in pipeline order.
+func (fp *FilterPipeline) Filters() []Filter {
+	return fp.filters
+}
+
 // Count returns the number of filters in the pipeline.
 func (fp *FilterPipeline) Count() int {
 	return len(fp.filters)
@@ -134,22 +143,25 @@ func (fp *FilterPipeline) EncodePipelineMessage() ([]byte, error) {
 		return nil, errors.New("empty filter pipeline")
 	}
 
-	// Pipeline message format (version 2):
-	// Bytes 0:    Version (1 byte) = 2
+	// Pipeline message format (version 1):
+	// Bytes 0:    Version (1 byte) = 1
 	// Bytes 1:    Number of filters (1 byte)
 	// Bytes 2-7:  Reserved (6 bytes, must be 0)
 	//
 	// For each filter:
 	//   Filter ID (2 bytes)
-	//   Name length (2 bytes) - may be 0
+	//   Name length (2 bytes) - including null terminator, may be 0
 	//   Flags (2 bytes)
 	//   Number of CD values (2 bytes)
-	//   Name (variable, padded to 8-byte boundary) - only if name length > 0
-	//   CD values (4 bytes each)
+	//   Name (variable, null-terminated, padded to 8-byte boundary) - only if name length > 0
+	//   CD values (4 bytes each), padded to 8-byte boundary
+	//
+	// Version 1 is the layout written by the C library for default file-format bounds.
+	// Reference: H5Opline.c - H5O__pline_encode().
 
 	buf := make([]byte, 0, 8+len(fp.filters)*32) // Pre-allocate for header + filters
 	header := make([]byte, 8)
-	header[0] = 2                     // Version 2
+	header[0] = 1                     // Version 1
 	header[1] = byte(len(fp.filters)) //nolint:gosec // G115: filter count bounded by HDF5 format
 	// Reserved bytes 2-7 are already zero
 	buf = append(buf, header...)
@@ -162,20 +174,24 @@ func (fp *FilterPipeline) EncodePipelineMessage() ([]byte, error) {
 	return buf, nil
 }
 
-// encodeFilter encodes a single filter for the pipeline message.
+// encodeFilter encodes a single filter for a version 1 pipeline message.
 func encodeFilter(f Filter) []byte {
 	flags, cdValues := f.Encode()
 	name := f.Name()
-	nameLen := uint16(len(name)) //nolint:gosec // G115: Filter names are short (<256), always fit in uint16
 
-	// Calculate padded name length (align to 8-byte boundary)
-	var paddedNameLen uint16
-	if nameLen > 0 {
+	// Name length includes the null terminator (H5Opline.c rejects unterminated names).
+	var nameLen, paddedNameLen uint16
+	if name != "" {
+		nameLen = uint16(len(name) + 1) //nolint:gosec // G115: Filter names are short (<256), always fit in uint16
 		paddedNameLen = ((nameLen + 7) / 8) * 8
 	}
 
+	// Client data is padded to a multiple of 8 bytes (odd number of values).
+	cdSize := len(cdValues) * 4
+	paddedCDSize := (cdSize + 7) / 8 * 8
+
 	// Calculate buffer size
-	bufSize := 8 + int(paddedNameLen) + len(cdValues)*4
+	bufSize := 8 + int(paddedNameLen) + paddedCDSize
 	buf := make([]byte, bufSize)
 
 	// Filter header (8 bytes)
@@ -186,7 +202,7 @@ func encodeFilter(f Filter) []byte {
 
 	offset := 8
 
-	// Name (padded to 8-byte boundary)
+	// Name (null-terminated, padded to 8-byte boundary)
 	if nameLen > 0 {
 		copy(buf[offset:], name)
 		offset += int(paddedNameLen)
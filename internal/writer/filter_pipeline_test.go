@@ -242,7 +242,7 @@ func TestFilterPipeline_EncodePipelineMessage_SingleFilter(t *testing.T) {
 	require.NoError(t, err)
 
 	// Check header
-	require.Equal(t, byte(2), msg[0])           // Version 2
+	require.Equal(t, byte(1), msg[0])           // Version 1
 	require.Equal(t, byte(1), msg[1])           // 1 filter
 	require.Equal(t, make([]byte, 6), msg[2:8]) // Reserved
 
@@ -252,7 +252,7 @@ func TestFilterPipeline_EncodePipelineMessage_SingleFilter(t *testing.T) {
 	require.Equal(t, uint16(FilterGZIP), filterID)
 
 	nameLen := binary.LittleEndian.Uint16(msg[offset+2:])
-	require.Equal(t, uint16(7), nameLen) // "deflate"
+	require.Equal(t, uint16(8), nameLen) // "deflate" + null terminator
 
 	flags := binary.LittleEndian.Uint16(msg[offset+4:])
 	require.Equal(t, uint16(0), flags)
@@ -290,12 +290,12 @@ func TestFilterPipeline_EncodePipelineMessage_MultipleFilters(t *testing.T) {
 	require.NoError(t, err)
 
 	// Check header
-	require.Equal(t, byte(2), msg[0]) // Version 2
+	require.Equal(t, byte(1), msg[0]) // Version 1
 	require.Equal(t, byte(2), msg[1]) // 2 filters
 
 	// Verify message is valid length
-	// Header (8) + Filter1 (8 + 8 (padded name) + 4 (1 CD)) + Filter2 (8 + 8 (padded name) + 4 (1 CD)) = 48
-	require.Equal(t, 48, len(msg))
+	// Header (8) + Filter1 (8 + 8 (padded name) + 8 (1 CD, padded)) + Filter2 (8 + 8 (padded name) + 8 (1 CD, padded)) = 56
+	require.Equal(t, 56, len(msg))
 
 	// Verify both filters are present in message
 	offset := 8
@@ -304,14 +304,14 @@ func TestFilterPipeline_EncodePipelineMessage_MultipleFilters(t *testing.T) {
 	filterID1 := binary.LittleEndian.Uint16(msg[offset:])
 	require.Equal(t, uint16(FilterShuffle), filterID1)
 	nameLen1 := binary.LittleEndian.Uint16(msg[offset+2:])
-	require.Equal(t, uint16(7), nameLen1) // "shuffle"
+	require.Equal(t, uint16(8), nameLen1) // "shuffle" + null terminator
 
-	// Second filter (offset = 8 + 8 + 8 + 4 = 28)
-	offset2 := 28
+	// Second filter (offset = 8 + 8 + 8 + 8 = 32)
+	offset2 := 32
 	filterID2 := binary.LittleEndian.Uint16(msg[offset2:])
 	require.Equal(t, uint16(FilterGZIP), filterID2)
 	nameLen2 := binary.LittleEndian.Uint16(msg[offset2+2:])
-	require.Equal(t, uint16(7), nameLen2) // "deflate"
+	require.Equal(t, uint16(8), nameLen2) // "deflate" + null terminator
 }
 
 func TestFilterPipeline_EncodePipelineMessage_NoName(t *testing.T) {
@@ -328,7 +328,7 @@ func TestFilterPipeline_EncodePipelineMessage_NoName(t *testing.T) {
 	require.NoError(t, err)
 
 	// Check header
-	require.Equal(t, byte(2), msg[0]) // Version 2
+	require.Equal(t, byte(1), msg[0]) // Version 1
 	require.Equal(t, byte(1), msg[1]) // 1 filter
 
 	// Check filter encoding
@@ -350,7 +350,7 @@ func TestFilterPipeline_EncodePipelineMessage_LongName(t *testing.T) {
 	pipeline := NewFilterPipeline()
 	filter := &mockFilter{
 		id:       FilterGZIP,
-		name:     "very-long-filter-name", // 21 bytes -> padded to 24
+		name:     "very-long-filter-name", // 21 bytes + null -> padded to 24
 		flags:    42,
 		cdValues: []uint32{1, 2, 3},
 	}
@@ -361,7 +361,7 @@ func TestFilterPipeline_EncodePipelineMessage_LongName(t *testing.T) {
 
 	offset := 8
 	nameLen := binary.LittleEndian.Uint16(msg[offset+2:])
-	require.Equal(t, uint16(21), nameLen)
+	require.Equal(t, uint16(22), nameLen)
 
 	// Name should be padded to 24 bytes (next multiple of 8)
 	name := string(msg[offset+8 : offset+8+21])
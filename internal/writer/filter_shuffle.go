@@ -40,7 +40,10 @@ type ShuffleFilter struct {
 //   - int16: elementSize = 2
 //   - int8: elementSize = 1
 //
-// For compound or array types, use the size of the base element.
+// For compound, array and string types, use the full datatype size: HDF5
+// shuffles whole dataset elements, so readers unshuffle with the type size.
+//
+// Reference: H5Zshuffle.c - H5Z__set_local_shuffle().
 func NewShuffleFilter(elementSize uint32) *ShuffleFilter {
 	return &ShuffleFilter{elementSize: elementSize}
 }
@@ -130,7 +133,9 @@ func (f *ShuffleFilter) Remove(data []byte) ([]byte, error) {
 // Encode returns the filter parameters for the Pipeline message.
 //
 // For shuffle, the client data contains a single value: the element size.
-// Flags are always 0 for shuffle.
+// The filter is marked optional (H5Z_FLAG_OPTIONAL), as H5Pset_shuffle does.
+//
+// Reference: H5Pocpl.c - H5Pset_shuffle(), H5Zshuffle.c - H5Z__set_local_shuffle().
 func (f *ShuffleFilter) Encode() (flags uint16, cdValues []uint32) {
-	return 0, []uint32{f.elementSize}
+	return filterFlagOptional, []uint32{f.elementSize}
 }
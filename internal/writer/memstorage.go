@@ -0,0 +1,85 @@
+package writer
+
+import (
+	"fmt"
+	"io"
+)
+
+// MemStorage is an in-memory Storage. The buffer grows as data is written
+// past its end; gaps left by allocations that are never written read as zero.
+//
+// Thread-safety: Not thread-safe. Caller must synchronize access.
+type MemStorage struct {
+	buf []byte
+}
+
+// NewMemStorage creates an empty in-memory storage.
+func NewMemStorage() *MemStorage {
+	return &MemStorage{}
+}
+
+// WriteAt writes p at offset off, growing the buffer as needed.
+func (m *MemStorage) WriteAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, fmt.Errorf("negative offset %d", off)
+	}
+
+	end := off + int64(len(p))
+	if end > int64(len(m.buf)) {
+		if end > int64(cap(m.buf)) {
+			// Grow geometrically so appends stay amortized O(1).
+			newCap := 2 * int64(cap(m.buf))
+			if newCap < end {
+				newCap = end
+			}
+			grown := make([]byte, end, newCap)
+			copy(grown, m.buf)
+			m.buf = grown
+		} else {
+			m.buf = m.buf[:end]
+		}
+	}
+
+	return copy(m.buf[off:], p), nil
+}
+
+// ReadAt reads len(p) bytes at offset off. Like os.File, it returns io.EOF
+// when fewer bytes are available.
+func (m *MemStorage) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, fmt.Errorf("negative offset %d", off)
+	}
+	if off >= int64(len(m.buf)) {
+		return 0, io.EOF
+	}
+
+	n := copy(p, m.buf[off:])
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// Sync is a no-op; memory needs no flushing.
+func (m *MemStorage) Sync() error {
+	return nil
+}
+
+// Close is a no-op; the contents stay available through Bytes.
+func (m *MemStorage) Close() error {
+	return nil
+}
+
+// Size returns the current size of the stored file in bytes.
+func (m *MemStorage) Size() int64 {
+	return int64(len(m.buf))
+}
+
+// Bytes returns the stored file. The slice aliases the internal buffer and
+// is only valid until the next write.
+func (m *MemStorage) Bytes() []byte {
+	return m.buf
+}
+
+// Ensure MemStorage implements Storage.
+var _ Storage = (*MemStorage)(nil)
@@ -0,0 +1,97 @@
+package writer
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemStorage_WriteRead(t *testing.T) {
+	m := NewMemStorage()
+
+	// Writing past the end zero-fills the gap.
+	n, err := m.WriteAt([]byte{1, 2, 3}, 5)
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, int64(8), m.Size())
+	assert.Equal(t, []byte{0, 0, 0, 0, 0, 1, 2, 3}, m.Bytes())
+
+	// Overwrite in place, then extend.
+	_, err = m.WriteAt([]byte{9, 9}, 6)
+	require.NoError(t, err)
+	_, err = m.WriteAt([]byte{7}, 8)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0, 0, 0, 0, 0, 1, 9, 9, 7}, m.Bytes())
+
+	buf := make([]byte, 4)
+	n, err = m.ReadAt(buf, 5)
+	require.NoError(t, err)
+	assert.Equal(t, 4, n)
+	assert.Equal(t, []byte{1, 9, 9, 7}, buf)
+
+	// Short reads report io.EOF, like os.File.
+	n, err = m.ReadAt(buf, 7)
+	assert.ErrorIs(t, err, io.EOF)
+	assert.Equal(t, 2, n)
+	_, err = m.ReadAt(buf, 100)
+	assert.ErrorIs(t, err, io.EOF)
+
+	_, err = m.WriteAt([]byte{1}, -1)
+	assert.Error(t, err)
+}
+
+func TestNewMemFileWriter(t *testing.T) {
+	w := NewMemFileWriter(48)
+	defer func() { _ = w.Close() }()
+
+	assert.Nil(t, w.File())
+	assert.Equal(t, uint64(48), w.EndOfFile())
+
+	addr, err := w.WriteAtWithAllocation([]byte("HDF5 data"))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(48), addr)
+	require.NoError(t, w.Flush())
+
+	buf := make([]byte, 9)
+	_, err = w.ReadAt(buf, int64(addr))
+	require.NoError(t, err)
+	assert.Equal(t, "HDF5 data", string(buf))
+
+	mem, ok := w.Storage().(*MemStorage)
+	require.True(t, ok)
+	assert.Equal(t, int64(57), mem.Size())
+
+	_, err = w.Seek(0, io.SeekStart)
+	assert.Error(t, err)
+}
+
+func TestSparseStorage_WriteRead(t *testing.T) {
+	s := NewSparseStorage()
+
+	// A write across a page boundary, far from the start.
+	off := int64(3*sparsePageSize - 2)
+	n, err := s.WriteAt([]byte{1, 2, 3, 4}, off)
+	require.NoError(t, err)
+	assert.Equal(t, 4, n)
+	assert.Equal(t, off+4, s.Size())
+	assert.Len(t, s.pages, 2, "only written pages are kept")
+
+	buf := make([]byte, 6)
+	n, err = s.ReadAt(buf, off-1)
+	assert.ErrorIs(t, err, io.EOF)
+	assert.Equal(t, 5, n)
+	assert.Equal(t, []byte{0, 1, 2, 3, 4}, buf[:n])
+
+	// Unwritten space reads as zero.
+	buf = []byte{9, 9, 9}
+	_, err = s.ReadAt(buf, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0, 0, 0}, buf)
+
+	_, err = s.ReadAt(buf, off+4)
+	assert.ErrorIs(t, err, io.EOF)
+	_, err = s.WriteAt([]byte{1}, -1)
+	assert.Error(t, err)
+}
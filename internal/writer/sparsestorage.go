@@ -0,0 +1,91 @@
+package writer
+
+import (
+	"fmt"
+	"io"
+)
+
+// sparsePageSize is the size of the pages a SparseStorage keeps.
+const sparsePageSize = 4096
+
+// SparseStorage is an in-memory Storage that only keeps the pages that were
+// written. Space that is allocated but never written, such as the raw data
+// of a dataset sized without being filled, costs no memory, so a file of any
+// size can be laid out to learn its metadata and final size.
+//
+// Thread-safety: Not thread-safe. Caller must synchronize access.
+type SparseStorage struct {
+	pages map[int64][]byte
+	size  int64
+}
+
+// NewSparseStorage creates an empty sparse storage.
+func NewSparseStorage() *SparseStorage {
+	return &SparseStorage{pages: make(map[int64][]byte)}
+}
+
+// WriteAt writes p at offset off.
+func (s *SparseStorage) WriteAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, fmt.Errorf("negative offset %d", off)
+	}
+
+	n := 0
+	for n < len(p) {
+		pos := off + int64(n)
+		page, ok := s.pages[pos/sparsePageSize]
+		if !ok {
+			page = make([]byte, sparsePageSize)
+			s.pages[pos/sparsePageSize] = page
+		}
+		n += copy(page[pos%sparsePageSize:], p[n:])
+	}
+	s.size = max(s.size, off+int64(len(p)))
+	return n, nil
+}
+
+// ReadAt reads len(p) bytes at offset off; pages never written read as
+// zero. Like os.File, it returns io.EOF when fewer bytes are available.
+func (s *SparseStorage) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, fmt.Errorf("negative offset %d", off)
+	}
+	if off >= s.size {
+		return 0, io.EOF
+	}
+
+	want := min(int64(len(p)), s.size-off)
+	n := 0
+	for int64(n) < want {
+		pos := off + int64(n)
+		end := min(int64(n)+sparsePageSize-pos%sparsePageSize, want)
+		if page, ok := s.pages[pos/sparsePageSize]; ok {
+			copy(p[n:end], page[pos%sparsePageSize:])
+		} else {
+			clear(p[n:end])
+		}
+		n = int(end)
+	}
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// Sync is a no-op; memory needs no flushing.
+func (s *SparseStorage) Sync() error {
+	return nil
+}
+
+// Close is a no-op.
+func (s *SparseStorage) Close() error {
+	return nil
+}
+
+// Size returns the end of the last write in bytes.
+func (s *SparseStorage) Size() int64 {
+	return s.size
+}
+
+// Ensure SparseStorage implements Storage.
+var _ Storage = (*SparseStorage)(nil)
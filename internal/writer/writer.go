@@ -6,7 +6,20 @@ import (
 	"os"
 )
 
-// FileWriter wraps an os.File for writing HDF5 files.
+// Storage is the backing store of a FileWriter. *os.File implements it;
+// MemStorage keeps the file in memory.
+type Storage interface {
+	io.ReaderAt
+	io.WriterAt
+
+	// Sync commits written data to stable storage.
+	Sync() error
+
+	// Close releases the storage.
+	Close() error
+}
+
+// FileWriter wraps a Storage (usually an os.File) for writing HDF5 files.
 // It provides:
 // - Space allocation tracking (via Allocator)
 // - Write-at-address operations
@@ -15,8 +28,10 @@ import (
 //
 // Thread-safety: Not thread-safe. Caller must synchronize access.
 type FileWriter struct {
-	file      *os.File   // Underlying OS file
+	file      Storage    // Underlying file or memory buffer
 	allocator *Allocator // Space allocation tracker
+	base      uint64     // Absolute offset of address 0 (user block size)
+	existing  bool       // Opened on an existing file (end of file taken from its size)
 }
 
 // CreateMode specifies the file creation/opening behavior.
@@ -82,6 +97,28 @@ func NewFileWriter(filename string, mode CreateMode, initialOffset uint64) (*Fil
 	}, nil
 }
 
+// NewMemFileWriter creates a writer for a new HDF5 file held in memory.
+// The finished file image is available from Storage().(*MemStorage).Bytes().
+//
+// Parameters:
+//   - initialOffset: Starting address for allocations (typically superblock size)
+func NewMemFileWriter(initialOffset uint64) *FileWriter {
+	return NewStorageFileWriter(NewMemStorage(), initialOffset)
+}
+
+// NewStorageFileWriter creates a writer for a new HDF5 file held in the
+// given storage.
+//
+// Parameters:
+//   - storage: Empty backing store
+//   - initialOffset: Starting address for allocations (typically superblock size)
+func NewStorageFileWriter(storage Storage, initialOffset uint64) *FileWriter {
+	return &FileWriter{
+		file:      storage,
+		allocator: NewAllocator(initialOffset),
+	}
+}
+
 // OpenFileWriter opens an existing HDF5 file for read-modify-write operations.
 // Unlike NewFileWriter which creates a new file, this opens an existing file.
 //
@@ -151,9 +188,30 @@ func OpenFileWriter(filename string, mode CreateMode, initialOffset uint64) (*Fi
 	return &FileWriter{
 		file:      osFile,
 		allocator: NewAllocator(allocatorOffset),
+		existing:  true,
 	}, nil
 }
 
+// SetBaseAddress makes all addresses relative to base, the absolute offset of
+// the superblock in the file. The bytes before base form the user block and
+// are never written. Call it before the first allocation or write.
+//
+// For writers opened with OpenFileWriter, whose end of file was taken from
+// the file size, the end of file is rebased as well.
+//
+// Reference: H5FD.c - H5FD_set_base_addr().
+func (w *FileWriter) SetBaseAddress(base uint64) {
+	if w.existing && w.allocator.nextOffset >= base {
+		w.allocator.nextOffset -= base
+	}
+	w.base = base
+}
+
+// BaseAddress returns the absolute offset of address 0 (see SetBaseAddress).
+func (w *FileWriter) BaseAddress() uint64 {
+	return w.base
+}
+
 // Allocate reserves a block of space in the file.
 // Returns the address where the block was allocated.
 // The space is not zeroed - caller must write data to the allocated block.
@@ -178,6 +236,17 @@ func (w *FileWriter) Allocate(size uint64) (uint64, error) {
 	return w.allocator.Allocate(size)
 }
 
+// AllocateRaw reserves space for raw data (dataset storage, chunks, global
+// heap collections), which is kept out of metadata blocks.
+// See Allocator.AllocateRaw.
+func (w *FileWriter) AllocateRaw(size uint64) (uint64, error) {
+	if w.file == nil {
+		return 0, fmt.Errorf("writer is closed")
+	}
+
+	return w.allocator.AllocateRaw(size)
+}
+
 // WriteAt writes data at a specific address in the file.
 // Implements io.WriterAt interface.
 //
@@ -199,8 +268,8 @@ func (w *FileWriter) WriteAt(data []byte, offset int64) (int, error) {
 		return 0, nil // Nothing to write
 	}
 
-	// Use os.File.WriteAt which handles seeking internally
-	n, err := w.file.WriteAt(data, offset)
+	// WriteAt on the storage handles positioning internally
+	n, err := w.file.WriteAt(data, offset+int64(w.base)) //nolint:gosec // G115: user block size fits in int64
 	if err != nil {
 		return n, fmt.Errorf("write at address %d failed: %w", offset, err)
 	}
@@ -226,7 +295,7 @@ func (w *FileWriter) ReadAt(buf []byte, addr int64) (int, error) {
 		return 0, fmt.Errorf("writer is closed")
 	}
 
-	return w.file.ReadAt(buf, addr)
+	return w.file.ReadAt(buf, addr+int64(w.base)) //nolint:gosec // G115: user block size fits in int64
 }
 
 // EndOfFile returns the current end-of-file address.
@@ -258,14 +327,21 @@ func (w *FileWriter) Close() error {
 	return err
 }
 
-// File returns the underlying *os.File.
+// File returns the underlying *os.File, or nil for in-memory writers.
 // Use with caution - direct file operations may break allocation tracking.
 // Primarily for reading operations or advanced use cases.
 func (w *FileWriter) File() *os.File {
+	f, _ := w.file.(*os.File)
+	return f
+}
+
+// Storage returns the backing store of the writer.
+func (w *FileWriter) Storage() Storage {
 	return w.file
 }
 
 // Reader returns an io.ReaderAt interface for reading from the file.
+// Offsets are addresses, relative to the base address.
 // This is the preferred method for reading operations as it returns an interface
 // rather than a concrete type, improving testability and following Go best practices.
 //
@@ -279,6 +355,9 @@ func (w *FileWriter) File() *os.File {
 //	reader := fw.Reader()
 //	oh, err := core.ReadObjectHeader(reader, addr, sb)
 func (w *FileWriter) Reader() io.ReaderAt {
+	if w.base != 0 {
+		return w
+	}
 	return w.file
 }
 
@@ -321,7 +400,11 @@ func (w *FileWriter) Seek(offset int64, whence int) (int64, error) {
 		return 0, fmt.Errorf("writer is closed")
 	}
 
-	return w.file.Seek(offset, whence)
+	seeker, ok := w.file.(io.Seeker)
+	if !ok {
+		return 0, fmt.Errorf("storage does not support seeking")
+	}
+	return seeker.Seek(offset, whence)
 }
 
 // Ensure FileWriter implements io.ReaderAt and io.WriterAt.
@@ -0,0 +1,162 @@
+package hdf5
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// updateInterop rewrites the golden files of TestInterop from the output of
+// the installed h5dump: go test -run TestInterop -update-interop.
+var updateInterop = flag.Bool("update-interop", false, "rewrite testdata/interop golden files with h5dump output")
+
+// interopCases are files written by this package whose h5dump output is
+// compared with testdata/interop/<name>.ddl.
+var interopCases = []struct {
+	name  string
+	write func(t *testing.T, fw *FileWriter)
+}{
+	{"basic", func(t *testing.T, fw *FileWriter) {
+		ds, err := fw.CreateDataset("/temperature", Float64, []uint64{4})
+		require.NoError(t, err)
+		require.NoError(t, ds.Write([]float64{0.5, 1.25, -2, 3.75}))
+		require.NoError(t, ds.WriteAttribute("valid_range", []float64{-50, 50}))
+
+		counts, err := fw.CreateDataset("/counts", Int32, []uint64{2, 3})
+		require.NoError(t, err)
+		require.NoError(t, counts.Write([]int32{0, 1, 2, 3, 4, 5}))
+
+		_, err = fw.CreateGroup("/sensors")
+		require.NoError(t, err)
+		ids, err := fw.CreateDataset("/sensors/ids", Uint16, []uint64{3})
+		require.NoError(t, err)
+		require.NoError(t, ids.Write([]uint16{7, 8, 9}))
+	}},
+	{"chunked", func(t *testing.T, fw *FileWriter) {
+		ds, err := fw.CreateDataset("/grid", Int16, []uint64{4, 4},
+			WithChunkDims([]uint64{2, 2}),
+			WithMaxDims([]uint64{Unlimited, 4}),
+			WithGZIPCompression(6))
+		require.NoError(t, err)
+		values := make([]int16, 16)
+		for i := range values {
+			values[i] = int16(i)
+		}
+		require.NoError(t, ds.Write(values))
+	}},
+}
+
+// writeInteropFile writes an interop case to dir/<name>.h5 with a
+// deterministic layout and returns the path.
+func writeInteropFile(t *testing.T, dir, name string, write func(*testing.T, *FileWriter)) string {
+	t.Helper()
+	path := filepath.Join(dir, name+".h5")
+	fw, err := CreateForWrite(path, CreateTruncate, WithDeterministicLayout(), WithTrackTimes(true))
+	require.NoError(t, err)
+	write(t, fw)
+	require.NoError(t, fw.Close())
+	return path
+}
+
+// TestInterop checks files against the HDF5 C tools: files written by this
+// package must dump as their golden files with h5dump, and files written by
+// h5py must read back with this package. Each part is skipped when its tool
+// is not installed; the files must also be byte-reproducible, which needs no
+// tool.
+func TestInterop(t *testing.T) {
+	t.Run("Reproducible", func(t *testing.T) {
+		for _, tc := range interopCases {
+			first, err := os.ReadFile(writeInteropFile(t, t.TempDir(), tc.name, tc.write))
+			require.NoError(t, err)
+			second, err := os.ReadFile(writeInteropFile(t, t.TempDir(), tc.name, tc.write))
+			require.NoError(t, err)
+			require.True(t, bytes.Equal(first, second), "%s: files differ between runs", tc.name)
+		}
+
+		// WithDeterministicLayout overrides WithTrackTimes.
+		f, err := Open(writeInteropFile(t, t.TempDir(), "basic", interopCases[0].write))
+		require.NoError(t, err)
+		defer func() { _ = f.Close() }()
+		_, tracked := findDatasetByPath(t, f, "/temperature").ModTime()
+		require.False(t, tracked)
+	})
+
+	t.Run("H5dump", func(t *testing.T) {
+		h5dump, err := exec.LookPath("h5dump")
+		if err != nil {
+			t.Skip("h5dump not available")
+		}
+		for _, tc := range interopCases {
+			t.Run(tc.name, func(t *testing.T) {
+				dir := t.TempDir()
+				writeInteropFile(t, dir, tc.name, tc.write)
+
+				cmd := exec.Command(h5dump, tc.name+".h5")
+				cmd.Dir = dir
+				out, err := cmd.CombinedOutput()
+				require.NoError(t, err, "h5dump failed: %s", out)
+
+				golden := filepath.Join("testdata", "interop", tc.name+".ddl")
+				if *updateInterop {
+					require.NoError(t, os.WriteFile(golden, out, 0o600))
+					return
+				}
+				want, err := os.ReadFile(golden)
+				require.NoError(t, err)
+				require.Equal(t, string(want), string(out))
+			})
+		}
+	})
+
+	t.Run("H5py", func(t *testing.T) {
+		python, err := exec.LookPath("python3")
+		if err != nil {
+			t.Skip("python3 not available")
+		}
+		if err := exec.Command(python, "-c", "import h5py").Run(); err != nil {
+			t.Skip("h5py not available")
+		}
+
+		path := filepath.Join(t.TempDir(), "h5py.h5")
+		const script = `
+import sys, h5py, numpy as np
+with h5py.File(sys.argv[1], "w") as f:
+    f["temperature"] = np.array([0.5, 1.25, -2, 3.75])
+    f["temperature"].attrs["valid_range"] = np.array([-50.0, 50.0])
+    f.create_dataset("grid", data=np.arange(16, dtype="<i2").reshape(4, 4),
+                     chunks=(2, 2), maxshape=(None, 4), compression="gzip")
+    f.create_group("sensors")["ids"] = np.array([7, 8, 9], dtype="<u2")
+`
+		out, err := exec.Command(python, "-c", script, path).CombinedOutput()
+		require.NoError(t, err, "h5py failed: %s", out)
+
+		f, err := Open(path)
+		require.NoError(t, err)
+		defer func() { _ = f.Close() }()
+
+		temperature := findDatasetByPath(t, f, "/temperature")
+		data, err := temperature.Read()
+		require.NoError(t, err)
+		require.Equal(t, []float64{0.5, 1.25, -2, 3.75}, data)
+		attrs, err := temperature.Attributes()
+		require.NoError(t, err)
+		require.Len(t, attrs, 1)
+		value, err := attrs[0].ReadValue()
+		require.NoError(t, err)
+		require.Equal(t, []float64{-50, 50}, value)
+
+		data, err = findDatasetByPath(t, f, "/grid").Read()
+		require.NoError(t, err)
+		require.Len(t, data, 16)
+		require.Equal(t, 15.0, data[15])
+
+		data, err = findDatasetByPath(t, f, "/sensors/ids").Read()
+		require.NoError(t, err)
+		require.Equal(t, []float64{7, 8, 9}, data)
+	})
+}
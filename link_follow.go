@@ -0,0 +1,147 @@
+package hdf5
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxSoftLinkTraversals is the number of soft links followed while resolving
+// one path, as the default of the C library's link access property list.
+//
+// Reference: H5Lprivate.h - H5L_NUM_LINKS.
+const maxSoftLinkTraversals = 16
+
+// LinkCycleError reports a link that leads back to where it started: a link
+// to a group that encloses it, or a chain of soft links that returns to one
+// of its own links.
+type LinkCycleError struct {
+	Path   string // Path of the link that closes the cycle
+	Target string // Path the link leads back to
+}
+
+// Error implements the error interface.
+func (e *LinkCycleError) Error() string {
+	return fmt.Sprintf("link cycle: %s -> %s", e.Path, e.Target)
+}
+
+// linkedMember is an object reached through a link of a group, or the error
+// that prevented resolving the link.
+type linkedMember struct {
+	name string
+	obj  Object
+	err  error
+}
+
+// groupMembers returns the objects linked from the group g at groupPath, in
+// link order. Soft links are resolved to their targets; external and
+// user-defined links are left out. chain holds the soft links being
+// resolved, to detect cycles.
+func (f *File) groupMembers(g *Group, groupPath string, chain []string) ([]linkedMember, error) {
+	links, err := g.Links()
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]linkedMember, 0, len(links))
+	for _, link := range links {
+		if link.Type != LinkHard && link.Type != LinkSoft {
+			continue
+		}
+		obj, err := f.resolveLink(g, groupPath, link, chain)
+		// Objects of unsupported kinds are not loaded.
+		if obj == nil && err == nil {
+			continue
+		}
+		members = append(members, linkedMember{name: link.Name, obj: obj, err: err})
+	}
+	return members, nil
+}
+
+// resolveLink returns the object a hard or soft link of the group g at
+// groupPath points to. It returns nil, nil for a hard link to an object that
+// was not loaded.
+func (f *File) resolveLink(g *Group, groupPath string, link LinkInfo, chain []string) (Object, error) {
+	switch link.Type {
+	case LinkHard:
+		return childByName(g, link.Name), nil
+	case LinkSoft:
+		return f.followSoftLink(joinPath(groupPath, link.Name), groupPath, link.Target, chain)
+	default:
+		return nil, fmt.Errorf("%s link %s is not followed", link.Type, joinPath(groupPath, link.Name))
+	}
+}
+
+// followSoftLink returns the object the soft link at linkPath points to.
+// Relative targets are resolved from dir, the group holding the link.
+func (f *File) followSoftLink(linkPath, dir, target string, chain []string) (Object, error) {
+	for _, p := range chain {
+		if p == linkPath {
+			return nil, &LinkCycleError{Path: linkPath, Target: target}
+		}
+	}
+	if len(chain) >= maxSoftLinkTraversals {
+		return nil, fmt.Errorf("soft link %s: more than %d soft links to follow", linkPath, maxSoftLinkTraversals)
+	}
+	chain = append(chain, linkPath)
+
+	if !strings.HasPrefix(target, "/") {
+		target = joinPath(dir, target)
+	}
+
+	var obj Object = f.root
+	current := "/"
+	for _, name := range strings.Split(target, "/") {
+		if name == "" || name == "." {
+			continue
+		}
+		g, ok := obj.AsGroup()
+		if !ok {
+			return nil, fmt.Errorf("soft link %s: target %q not found: %q is not a group", linkPath, target, current)
+		}
+		next, err := f.linkedMember(g, current, name, chain)
+		if err != nil {
+			return nil, err
+		}
+		obj = next
+		current = joinPath(current, name)
+	}
+	return obj, nil
+}
+
+// linkedMember returns the object linked as name from the group g at
+// groupPath, following a soft link.
+func (f *File) linkedMember(g *Group, groupPath, name string, chain []string) (Object, error) {
+	links, err := g.Links()
+	if err != nil {
+		return nil, err
+	}
+	for _, link := range links {
+		if link.Name != name {
+			continue
+		}
+		obj, err := f.resolveLink(g, groupPath, link, chain)
+		if obj == nil && err == nil {
+			break
+		}
+		return obj, err
+	}
+	return nil, fmt.Errorf("object %q not found", joinPath(groupPath, name))
+}
+
+// childByName returns the loaded child of g with the given link name.
+func childByName(g *Group, name string) Object {
+	for _, child := range g.Children() {
+		if child.Name() == name {
+			return child
+		}
+	}
+	return nil
+}
+
+// pathOrRoot returns path, or "/" for the empty path of the root group.
+func pathOrRoot(path string) string {
+	if path == "" {
+		return "/"
+	}
+	return path
+}
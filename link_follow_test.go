@@ -0,0 +1,125 @@
+package hdf5
+
+import (
+	"errors"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestWalk_HardLinkCycle verifies that a hard link to an enclosing group
+// neither makes Open recurse forever nor Walk loop, and is reported as a
+// link cycle.
+func TestWalk_HardLinkCycle(t *testing.T) {
+	for _, modern := range []bool{false, true} {
+		filename := filepath.Join(t.TempDir(), "cycle.h5")
+
+		var opts []interface{}
+		if modern {
+			opts = append(opts, WithModernGroups())
+		}
+		fw, err := CreateForWrite(filename, CreateTruncate, opts...)
+		require.NoError(t, err)
+		_, err = fw.CreateGroup("/a")
+		require.NoError(t, err)
+		_, err = fw.CreateGroup("/a/b")
+		require.NoError(t, err)
+		require.NoError(t, fw.CreateHardLink("/a/b/up", "/a"))
+		require.NoError(t, fw.Close())
+
+		f, err := Open(filename)
+		require.NoError(t, err)
+
+		var paths []string
+		var cycles []*LinkCycleError
+		f.Walk(func(path string, _ Object) {
+			paths = append(paths, path)
+		}, WithLinkErrors(func(_ string, err error) {
+			var cycle *LinkCycleError
+			require.True(t, errors.As(err, &cycle))
+			cycles = append(cycles, cycle)
+		}))
+		require.NoError(t, f.Close())
+
+		require.Equal(t, []string{"/", "/a/", "/a/b/"}, paths)
+		require.Equal(t, []*LinkCycleError{{Path: "/a/b/up", Target: "/a"}}, cycles)
+	}
+}
+
+// TestWalk_FollowSoftLinks verifies that soft links are followed, and that
+// dangling links, soft link loops and links to enclosing groups are
+// reported rather than followed forever.
+func TestWalk_FollowSoftLinks(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "soft.h5")
+
+	fw, err := CreateForWrite(filename, CreateTruncate)
+	require.NoError(t, err)
+	_, err = fw.CreateGroup("/g")
+	require.NoError(t, err)
+	ds, err := fw.CreateDataset("/g/data", Int32, []uint64{2})
+	require.NoError(t, err)
+	require.NoError(t, ds.Write([]int32{1, 2}))
+	require.NoError(t, fw.CreateSoftLink("/g/soft", "/g/data"))
+	require.NoError(t, fw.CreateSoftLink("/view", "/g"))
+	require.NoError(t, fw.CreateSoftLink("/g/up", "/g"))
+	require.NoError(t, fw.CreateSoftLink("/ping", "/pong"))
+	require.NoError(t, fw.CreateSoftLink("/pong", "/ping"))
+	require.NoError(t, fw.CreateSoftLink("/dangling", "/missing"))
+	require.NoError(t, fw.Close())
+
+	f, err := Open(filename)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	visited := make(map[string]Object)
+	linkErrors := make(map[string]error)
+	f.Walk(func(path string, obj Object) {
+		visited[path] = obj
+	}, WithFollowSoftLinks(), WithLinkErrors(func(path string, err error) {
+		linkErrors[path] = err
+	}))
+
+	var paths []string
+	for p := range visited {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	require.Equal(t, []string{"/", "/g/", "/g/data", "/g/soft", "/view/", "/view/data", "/view/soft"}, paths)
+	require.Equal(t, visited["/g/data"].Address(), visited["/g/soft"].Address())
+	require.Equal(t, visited["/g/data"].Address(), visited["/view/soft"].Address())
+
+	require.Len(t, linkErrors, 5)
+	var cycle *LinkCycleError
+	require.ErrorAs(t, linkErrors["/g/up"], &cycle)
+	require.Equal(t, "/g", cycle.Target)
+	require.ErrorAs(t, linkErrors["/view/up"], &cycle)
+	require.ErrorAs(t, linkErrors["/ping"], &cycle)
+	require.ErrorAs(t, linkErrors["/pong"], &cycle)
+	require.ErrorContains(t, linkErrors["/dangling"], "not found")
+}
+
+// TestWalk_MaxDepth verifies that WithMaxDepth stops Walk below the given
+// level.
+func TestWalk_MaxDepth(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "deep.h5")
+
+	fw, err := CreateForWrite(filename, CreateTruncate)
+	require.NoError(t, err)
+	for _, p := range []string{"/a", "/a/b", "/a/b/c"} {
+		_, err = fw.CreateGroup(p)
+		require.NoError(t, err)
+	}
+	require.NoError(t, fw.Close())
+
+	f, err := Open(filename)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	var paths []string
+	f.Walk(func(path string, _ Object) {
+		paths = append(paths, path)
+	}, WithMaxDepth(2))
+	require.Equal(t, []string{"/", "/a/", "/a/b/"}, paths)
+}
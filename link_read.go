@@ -0,0 +1,271 @@
+package hdf5
+
+import (
+	"fmt"
+
+	"github.com/scigolib/hdf5/internal/core"
+	"github.com/scigolib/hdf5/internal/structures"
+)
+
+// LinkType identifies the kind of a link in a group.
+type LinkType int
+
+// Link types reported by Group.Links.
+const (
+	// LinkHard points directly at an object header. Several hard links to
+	// the same address are aliases of one object.
+	LinkHard LinkType = iota
+
+	// LinkSoft stores the path of its target, which may not exist.
+	LinkSoft
+
+	// LinkExternal stores a file name and an object path in that file.
+	LinkExternal
+
+	// LinkUserDefined is a link of a user-defined class (type 65 and up).
+	LinkUserDefined
+)
+
+// String returns the link type name.
+func (t LinkType) String() string {
+	switch t {
+	case LinkHard:
+		return "hard"
+	case LinkSoft:
+		return "soft"
+	case LinkExternal:
+		return "external"
+	case LinkUserDefined:
+		return "user-defined"
+	default:
+		return fmt.Sprintf("LinkType(%d)", int(t))
+	}
+}
+
+// LinkInfo describes one link of a group.
+type LinkInfo struct {
+	Name             string
+	Type             LinkType
+	Address          uint64 // Hard links: object header address of the target
+	Target           string // Soft links: target path; external links: object path in ExternalFile
+	ExternalFile     string // External links: name of the target file
+	UTF8             bool   // Name is UTF-8 encoded (otherwise ASCII)
+	CreationOrder    int64  // Creation order index, if HasCreationOrder
+	HasCreationOrder bool   // The group tracks link creation order
+}
+
+// Links returns every link of the group with its type and target, in the
+// order they are stored. Unlike Children it includes soft and external
+// links, and reports each hard link separately, so aliases of one object
+// can be told apart by comparing addresses.
+//
+// Links of old-style (symbol table) groups have no character set or
+// creation order.
+//
+// Example:
+//
+//	links, _ := group.Links()
+//	for _, l := range links {
+//	    switch l.Type {
+//	    case hdf5.LinkSoft:
+//	        fmt.Printf("%s -> %s\n", l.Name, l.Target)
+//	    case hdf5.LinkExternal:
+//	        fmt.Printf("%s -> %s:%s\n", l.Name, l.ExternalFile, l.Target)
+//	    }
+//	}
+func (g *Group) Links() ([]LinkInfo, error) {
+	g.ensureLoaded()
+	// Root groups loaded from a symbol table node have no object header.
+	if g.symbolTable == nil && g.localHeap != nil {
+		node, err := structures.ParseSymbolTableNode(g.file.reader, g.address, g.file.sb)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse symbol table node: %w", err)
+		}
+		return g.symbolTableLinks(btreeEntries(node.Entries), g.localHeap)
+	}
+
+	if g.symbolTable != nil {
+		return g.readSymbolTableLinks()
+	}
+
+	header, err := core.ReadObjectHeader(g.file.reader, g.address, g.file.sb)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object header: %w", err)
+	}
+	raw, err := groupLinkMessages(g.file, header)
+	if err != nil {
+		return nil, err
+	}
+
+	links := make([]LinkInfo, 0, len(raw))
+	for _, data := range raw {
+		msg, err := structures.ParseLinkMessage(data, g.file.sb)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse link message: %w", err)
+		}
+		info := g.file.linkInfo(msg)
+		info.UTF8 = msg.CharacterSet == core.CharsetUTF8
+		info.CreationOrder = msg.CreationOrder
+		info.HasCreationOrder = msg.CreationOrderValid
+		links = append(links, info)
+	}
+	return links, nil
+}
+
+// groupLinkMessages returns the raw Link messages of a link-info group, from
+// its object header or from dense storage.
+func groupLinkMessages(file *File, header *core.ObjectHeader) ([][]byte, error) {
+	var raw [][]byte
+	for _, msg := range header.Messages {
+		switch msg.Type {
+		case core.MsgLinkMessage:
+			raw = append(raw, msg.Data)
+		case core.MsgLinkInfo:
+			linkInfo, err := core.ParseLinkInfoMessage(msg.Data, file.sb)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse link info: %w", err)
+			}
+			if !linkInfo.HasFractalHeap() || !linkInfo.HasNameBTree() {
+				continue
+			}
+			dense, err := core.ReadDenseHeapObjects(file.reader,
+				linkInfo.NameBTreeAddress, linkInfo.FractalHeapAddress, file.sb)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read dense links: %w", err)
+			}
+			raw = append(raw, dense...)
+		}
+	}
+	return raw, nil
+}
+
+// readSymbolTableLinks lists the links of an old-style group from its
+// symbol table B-tree and local heap.
+func (g *Group) readSymbolTableLinks() ([]LinkInfo, error) {
+	r, sb := g.file.reader, g.file.sb
+	heap, err := structures.LoadLocalHeap(r, g.symbolTable.HeapAddress, sb)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load local heap: %w", err)
+	}
+
+	var entries []structures.BTreeEntry
+	btreeAddr := g.symbolTable.BTreeAddress
+	switch sig := readSignature(r, btreeAddr); sig {
+	case "TREE":
+		entries, err = structures.ReadGroupBTreeEntries(r, btreeAddr, sb)
+	case "BTRE":
+		entries, err = structures.ReadBTreeEntries(r, btreeAddr, sb)
+	default:
+		return nil, fmt.Errorf("unknown B-tree signature: %q at address 0x%X", sig, btreeAddr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read B-tree: %w", err)
+	}
+
+	// Unnamed symbol table nodes hold further entries (see loadChildren).
+	flat := make([]structures.BTreeEntry, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsSoftLink() && entry.LinkNameOffset == 0 && readSignature(r, entry.ObjectAddress) == SignatureSNOD {
+			node, err := structures.ParseSymbolTableNode(r, entry.ObjectAddress, sb)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse symbol table node: %w", err)
+			}
+			flat = append(flat, btreeEntries(node.Entries)...)
+			continue
+		}
+		flat = append(flat, entry)
+	}
+	return g.symbolTableLinks(flat, heap)
+}
+
+// symbolTableLinks converts symbol table entries to links. Soft links keep
+// their target path in the local heap.
+func (g *Group) symbolTableLinks(entries []structures.BTreeEntry, heap *structures.LocalHeap) ([]LinkInfo, error) {
+	links := make([]LinkInfo, 0, len(entries))
+	for _, entry := range entries {
+		name, err := heap.GetString(entry.LinkNameOffset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read link name: %w", err)
+		}
+		if entry.IsSoftLink() {
+			target, err := heap.GetString(uint64(entry.CachedSoftLinkOffset))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read soft link %q target: %w", name, err)
+			}
+			links = append(links, LinkInfo{Name: name, Type: LinkSoft, Target: target})
+			continue
+		}
+		info := g.file.linkInfo(&structures.LinkMessage{
+			Type:          structures.LinkTypeHard,
+			Name:          name,
+			ObjectAddress: entry.ObjectAddress,
+		})
+		links = append(links, info)
+	}
+	return links, nil
+}
+
+// btreeEntries converts symbol table node entries to B-tree entries, which
+// have the same fields.
+func btreeEntries(entries []structures.SymbolTableEntry) []structures.BTreeEntry {
+	out := make([]structures.BTreeEntry, len(entries))
+	for i, e := range entries {
+		out[i] = structures.BTreeEntry(e)
+	}
+	return out
+}
+
+// linkInfo converts a parsed link message to a LinkInfo.
+//
+// FileWriter.CreateSoftLink and CreateExternalLink store the link in an
+// object header of its own, reached through a hard link; such hard links
+// are reported as the soft or external link they hold.
+func (f *File) linkInfo(msg *structures.LinkMessage) LinkInfo {
+	if msg.IsHardLink() {
+		if stored := f.linkObject(msg.ObjectAddress); stored != nil {
+			info := f.linkInfo(stored)
+			info.Name = msg.Name
+			return info
+		}
+	}
+
+	info := LinkInfo{Name: msg.Name}
+	switch msg.Type {
+	case structures.LinkTypeHard:
+		info.Type = LinkHard
+		info.Address = msg.ObjectAddress
+	case structures.LinkTypeSoft:
+		info.Type = LinkSoft
+		info.Target = msg.TargetPath
+	case structures.LinkTypeExternal:
+		info.Type = LinkExternal
+		info.ExternalFile = msg.ExternalFile
+		info.Target = msg.ExternalPath
+	default:
+		info.Type = LinkUserDefined
+	}
+	return info
+}
+
+// linkObject returns the soft or external link held by the object header at
+// address, or nil if it is a regular object.
+func (f *File) linkObject(address uint64) *structures.LinkMessage {
+	header, err := core.ReadObjectHeader(f.reader, address, f.sb)
+	if err != nil {
+		return nil
+	}
+	var link *structures.LinkMessage
+	for _, msg := range header.Messages {
+		switch msg.Type {
+		case core.MsgLinkMessage:
+			parsed, err := structures.ParseLinkMessage(msg.Data, f.sb)
+			if link != nil || err != nil || parsed.IsHardLink() {
+				return nil
+			}
+			link = parsed
+		case core.MsgLinkInfo, core.MsgSymbolTable, core.MsgDatatype, core.MsgDataLayout:
+			return nil
+		}
+	}
+	return link
+}
@@ -0,0 +1,86 @@
+package hdf5
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestGroupLinks_Written verifies Links on groups written with hard, soft
+// and external links, in both group formats.
+func TestGroupLinks_Written(t *testing.T) {
+	for _, modern := range []bool{false, true} {
+		filename := filepath.Join(t.TempDir(), "links.h5")
+
+		var opts []interface{}
+		if modern {
+			opts = append(opts, WithModernGroups())
+		}
+		fw, err := CreateForWrite(filename, CreateTruncate, opts...)
+		require.NoError(t, err)
+		_, err = fw.CreateGroup("/g")
+		require.NoError(t, err)
+		ds, err := fw.CreateDataset("/g/data", Int32, []uint64{2})
+		require.NoError(t, err)
+		require.NoError(t, ds.Write([]int32{1, 2}))
+		require.NoError(t, fw.CreateHardLink("/g/alias", "/g/data"))
+		require.NoError(t, fw.CreateSoftLink("/g/soft", "/g/data"))
+		require.NoError(t, fw.CreateExternalLink("/g/ext", "other.h5", "/x"))
+		require.NoError(t, fw.Close())
+
+		f, err := Open(filename)
+		require.NoError(t, err)
+		obj, err := f.lookup("/g")
+		require.NoError(t, err)
+		links, err := obj.(*Group).Links()
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+
+		byName := make(map[string]LinkInfo)
+		for _, l := range links {
+			byName[l.Name] = l
+		}
+		require.Len(t, byName, 4)
+		require.Equal(t, LinkHard, byName["data"].Type)
+		require.Equal(t, LinkHard, byName["alias"].Type)
+		require.Equal(t, byName["data"].Address, byName["alias"].Address, "aliases share an address")
+		require.Equal(t, LinkSoft, byName["soft"].Type)
+		require.Equal(t, "/g/data", byName["soft"].Target)
+		require.Equal(t, LinkExternal, byName["ext"].Type)
+		require.Equal(t, "other.h5", byName["ext"].ExternalFile)
+		require.Equal(t, "/x", byName["ext"].Target)
+	}
+}
+
+// TestGroupLinks_Reference verifies Links on files written by the HDF5
+// library: symbol table soft links and link message external links.
+func TestGroupLinks_Reference(t *testing.T) {
+	f, err := Open("testdata/hdf5_official/h5diff_ext2softlink_trg.h5")
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	links, err := f.Root().Links()
+	require.NoError(t, err)
+	soft := make(map[string]string)
+	for _, l := range links {
+		if l.Type == LinkSoft {
+			soft[l.Name] = l.Target
+		}
+	}
+	require.Equal(t, map[string]string{"softlink_to_dset1": "/dset1", "softlink_to_dset2": "/dset2"}, soft)
+
+	ext, err := Open("testdata/hdf5_official/be_extlink1.h5")
+	require.NoError(t, err)
+	defer func() { _ = ext.Close() }()
+
+	links, err = ext.Root().Links()
+	require.NoError(t, err)
+	require.Equal(t, []LinkInfo{{
+		Name:         "ext_link",
+		Type:         LinkExternal,
+		Target:       "group",
+		ExternalFile: "be_extlink2.h5",
+	}}, links)
+	require.Equal(t, "external", LinkExternal.String())
+}
@@ -2,6 +2,7 @@ package hdf5
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/scigolib/hdf5/internal/core"
@@ -46,8 +47,8 @@ import (
 // Limitations (MVP v0.11.5-beta):
 //   - Target must exist before creating link
 //   - Parent group must exist before creating link
-//   - Reference count stored in object header (v1) or RefCount message (v2)
-//   - No link deletion support yet (DeleteLink not implemented)
+//   - Reference count stored in object header (v1) or RefCount message (v2);
+//     Delete decrements it and frees the object when it reaches zero
 //   - No circular link detection
 //
 // Reference: H5L.c - H5Lcreate_hard().
@@ -66,6 +67,16 @@ func (fw *FileWriter) CreateHardLink(linkPath, targetPath string) error {
 		return fmt.Errorf("failed to resolve target %q: %w", targetPath, err)
 	}
 
+	// Parse link path to find parent and link name
+	parent, linkName := parsePath(linkPath)
+
+	// Validate parent exists before touching the reference count
+	if parent != "" && parent != "/" {
+		if _, exists := fw.groups[parent]; !exists {
+			return fmt.Errorf("parent group %q does not exist (create it first)", parent)
+		}
+	}
+
 	// Read target object header to increment reference count
 	targetHeader, err := core.ReadObjectHeader(fw.writer, targetAddr, fw.file.sb)
 	if err != nil {
@@ -73,23 +84,13 @@ func (fw *FileWriter) CreateHardLink(linkPath, targetPath string) error {
 	}
 
 	// Increment reference count
-	newRefCount := targetHeader.IncrementReferenceCount()
+	targetHeader.IncrementReferenceCount()
 
 	// Write updated object header back to file
 	if err := writeObjectHeaderWithRefCount(fw, targetAddr, targetHeader); err != nil {
 		return fmt.Errorf("failed to update reference count: %w", err)
 	}
 
-	// Parse link path to find parent and link name
-	parent, linkName := parsePath(linkPath)
-
-	// Validate parent exists
-	if parent != "" && parent != "/" {
-		if _, exists := fw.groups[parent]; !exists {
-			return fmt.Errorf("parent group %q does not exist (create it first)", parent)
-		}
-	}
-
 	// Create link in parent group
 	if err := fw.linkToParent(parent, linkName, targetAddr); err != nil {
 		// Rollback: decrement reference count
@@ -98,8 +99,6 @@ func (fw *FileWriter) CreateHardLink(linkPath, targetPath string) error {
 		return fmt.Errorf("failed to create link in parent group: %w", err)
 	}
 
-	// Success
-	_ = newRefCount // Used for debugging if needed
 	return nil
 }
 
@@ -160,12 +159,19 @@ func writeV1RefCount(fw *FileWriter, addr uint64, oh *core.ObjectHeader) error {
 
 // writeV2RefCount writes reference count for v2 object header.
 func writeV2RefCount(fw *FileWriter, addr uint64, oh *core.ObjectHeader) error {
-	// V2: Reference count stored in RefCount message (type 0x0016)
-	// If refcount > 1, we need to add/update RefCount message
+	// V2: Reference count stored in RefCount message (type 0x0016), which is
+	// present only while the object has more than one hard link; a header
+	// without it has a count of 1.
+	// Null padding is dropped so the new message takes its place instead of
+	// growing the header past its allocation; messages in continuation chunks
+	// stay where they are.
+	oh.Messages = filterMainChunkMessages(oh.Messages)
 	if oh.ReferenceCount > 1 {
 		if err := ensureRefCountMessage(fw, oh); err != nil {
 			return err
 		}
+	} else {
+		removeRefCountMessage(oh)
 	}
 
 	// Write entire object header back to disk
@@ -199,6 +205,16 @@ func ensureRefCountMessage(fw *FileWriter, oh *core.ObjectHeader) error {
 	return nil
 }
 
+// removeRefCountMessage drops the RefCount message once the object is back
+// to a single hard link, as the C library does.
+//
+// Reference: H5Oint.c - H5O__link_oh().
+func removeRefCountMessage(oh *core.ObjectHeader) {
+	oh.Messages = slices.DeleteFunc(oh.Messages, func(msg *core.HeaderMessage) bool {
+		return msg.Type == core.MsgRefCount
+	})
+}
+
 // CreateSoftLink creates a symbolic link to a path within the HDF5 file.
 //
 // Soft links (symbolic links) store a path string that is resolved when accessed.
@@ -240,12 +256,15 @@ func ensureRefCountMessage(fw *FileWriter, oh *core.ObjectHeader) error {
 //
 // Limitations:
 //   - Symbol table format only (dense groups not yet supported)
-//   - No soft link resolution yet (reading soft links not implemented)
+//   - Soft links are followed on read only by File.Walk with WithFollowSoftLinks
 //   - No circular link detection
 //
 // HDF5 Spec: Section IV.A.2.f "Link Message" - Type 1 (Soft Link)
 // Reference: H5L.c - H5Lcreate_soft().
 func (fw *FileWriter) CreateSoftLink(linkPath, targetPath string) error {
+	if err := fw.requireNewFormat("soft link"); err != nil {
+		return err
+	}
 	// Validate paths
 	if err := validateLinkPath(linkPath); err != nil {
 		return fmt.Errorf("invalid link path: %w", err)
@@ -409,6 +428,9 @@ func (fw *FileWriter) resolveSoftLink(linkAddr uint64, visitedPaths map[string]b
 // HDF5 Spec: Section IV.A.2.f "Link Message" - Type 64 (External Link)
 // Reference: H5Lcreate_external() in H5L.c.
 func (fw *FileWriter) CreateExternalLink(linkPath, fileName, objectPath string) error {
+	if err := fw.requireNewFormat("external link"); err != nil {
+		return err
+	}
 	// Validate link path
 	if err := validateLinkPath(linkPath); err != nil {
 		return fmt.Errorf("invalid link path: %w", err)
@@ -383,3 +383,118 @@ func TestCreateHardLink_MultipleLinks(t *testing.T) {
 		assert.Equal(t, expectedRefCount, oh.GetReferenceCount(), "Refcount should be %d after link %d", expectedRefCount, i)
 	}
 }
+
+func TestWalk_VisitOnce(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "walk_aliases.h5")
+
+	fw, err := CreateForWrite(filename, CreateTruncate)
+	require.NoError(t, err)
+	_, err = fw.CreateGroup("/data")
+	require.NoError(t, err)
+	ds, err := fw.CreateDataset("/data/original", Float64, []uint64{3})
+	require.NoError(t, err)
+	require.NoError(t, ds.Write([]float64{1, 2, 3}))
+	require.NoError(t, fw.CreateHardLink("/data/alias", "/data/original"))
+	require.NoError(t, fw.CreateHardLink("/mirror", "/data"))
+	require.NoError(t, fw.Close())
+
+	f, err := Open(filename)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	var all []string
+	f.Walk(func(path string, _ Object) { all = append(all, path) })
+	assert.Subset(t, all, []string{"/data/original", "/data/alias", "/mirror/"})
+
+	var visited []string
+	aliases := map[string]string{}
+	addresses := map[uint64]bool{}
+	f.Walk(func(path string, obj Object) {
+		visited = append(visited, path)
+		require.False(t, addresses[obj.Address()], "object at 0x%x visited twice", obj.Address())
+		addresses[obj.Address()] = true
+	}, WithVisitOnce(func(path string, first Object) {
+		aliases[path] = first.Path()
+	}))
+
+	require.Equal(t, []string{"/", "/data/", "/data/alias"}, visited)
+	require.Equal(t, map[string]string{
+		"/data/original": "/data/alias",
+		"/mirror/":       "/data",
+	}, aliases)
+
+	// A nil alias callback just skips repeated objects.
+	count := 0
+	f.Walk(func(string, Object) { count++ }, WithVisitOnce(nil))
+	require.Equal(t, len(visited), count)
+}
+
+// refCountState returns the reference count of the object at path and
+// whether its header carries a RefCount message.
+func refCountState(t *testing.T, filename, path string) (uint32, bool) {
+	t.Helper()
+	f, err := Open(filename)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	obj, err := f.lookup(path)
+	require.NoError(t, err)
+	var addr uint64
+	switch o := obj.(type) {
+	case *Dataset:
+		addr = o.Address()
+	case *Group:
+		addr = o.Address()
+	}
+	oh, err := core.ReadObjectHeader(f.reader, addr, f.sb)
+	require.NoError(t, err)
+	hasMsg := false
+	for _, msg := range oh.Messages {
+		hasMsg = hasMsg || msg.Type == core.MsgRefCount
+	}
+	return oh.GetReferenceCount(), hasMsg
+}
+
+func TestHardLink_ReferenceCountAcrossDelete(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "refcount_delete.h5")
+
+	fw, err := CreateForWrite(filename, CreateTruncate)
+	require.NoError(t, err)
+	ds, err := fw.CreateDataset("/data", Int32, []uint64{3})
+	require.NoError(t, err)
+	require.NoError(t, ds.Write([]int32{1, 2, 3}))
+	require.NoError(t, fw.CreateHardLink("/alias1", "/data"))
+	require.NoError(t, fw.CreateHardLink("/alias2", "/data"))
+
+	// A failed link creation leaves the count unchanged.
+	require.Error(t, fw.CreateHardLink("/missing/alias", "/data"))
+	require.NoError(t, fw.Close())
+
+	count, hasMsg := refCountState(t, filename, "/data")
+	require.Equal(t, uint32(3), count)
+	require.True(t, hasMsg)
+
+	fw, err = OpenForWrite(filename, OpenReadWrite)
+	require.NoError(t, err)
+	require.NoError(t, fw.Delete("/alias1"))
+	require.NoError(t, fw.Close())
+	count, _ = refCountState(t, filename, "/alias2")
+	require.Equal(t, uint32(2), count)
+
+	// Back to one link: the RefCount message is removed, as the C library does.
+	fw, err = OpenForWrite(filename, OpenReadWrite)
+	require.NoError(t, err)
+	require.NoError(t, fw.Delete("/data"))
+	require.NoError(t, fw.Close())
+	count, hasMsg = refCountState(t, filename, "/alias2")
+	require.Equal(t, uint32(1), count)
+	require.False(t, hasMsg)
+
+	f, err := Open(filename)
+	require.NoError(t, err)
+	values, err := findDatasetByPath(t, f, "/alias2").Read()
+	require.NoError(t, err)
+	require.Equal(t, []float64{1, 2, 3}, values)
+	require.False(t, f.Exists("/data"))
+	require.NoError(t, f.Close())
+}
@@ -0,0 +1,278 @@
+package hdf5
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ObjectKind identifies the kind of an HDF5 object.
+type ObjectKind int
+
+// Object kinds returned by Object.Kind.
+const (
+	KindGroup         ObjectKind = iota + 1 // Group (*Group).
+	KindDataset                             // Dataset (*Dataset).
+	KindNamedDatatype                       // Committed datatype (*NamedDatatype).
+)
+
+// String returns the kind name ("group", "dataset" or "datatype").
+func (k ObjectKind) String() string {
+	switch k {
+	case KindGroup:
+		return "group"
+	case KindDataset:
+		return "dataset"
+	case KindNamedDatatype:
+		return "datatype"
+	default:
+		return "unknown"
+	}
+}
+
+// assignPaths records the absolute path of every object below g.
+// Objects reachable through several hard links are loaded once per link, so
+// each instance keeps the path it was reached by.
+func assignPaths(g *Group, path string) {
+	g.path = path
+	for _, child := range g.children {
+		childPath := joinPath(path, child.Name())
+		switch c := child.(type) {
+		case *Group:
+			assignPaths(c, childPath)
+		case *Dataset:
+			c.path = childPath
+		case *NamedDatatype:
+			c.path = childPath
+		}
+	}
+}
+
+// joinPath appends a link name to a group path.
+func joinPath(parent, name string) string {
+	if parent == "/" {
+		return "/" + name
+	}
+	return parent + "/" + name
+}
+
+// Path returns the absolute path of the group ("/" for the root group).
+func (g *Group) Path() string { return g.path }
+
+// Address returns the object header address of the group.
+func (g *Group) Address() uint64 { return g.address }
+
+// Kind returns KindGroup.
+func (g *Group) Kind() ObjectKind { return KindGroup }
+
+// IsGroup returns true.
+func (g *Group) IsGroup() bool { return true }
+
+// IsDataset returns false.
+func (g *Group) IsDataset() bool { return false }
+
+// AsGroup returns the group itself.
+func (g *Group) AsGroup() (*Group, bool) { return g, true }
+
+// AsDataset returns nil, false.
+func (g *Group) AsDataset() (*Dataset, bool) { return nil, false }
+
+// Path returns the absolute path of the dataset, e.g. "/group/data".
+func (d *Dataset) Path() string { return d.path }
+
+// Kind returns KindDataset.
+func (d *Dataset) Kind() ObjectKind { return KindDataset }
+
+// IsGroup returns false.
+func (d *Dataset) IsGroup() bool { return false }
+
+// IsDataset returns true.
+func (d *Dataset) IsDataset() bool { return true }
+
+// AsGroup returns nil, false.
+func (d *Dataset) AsGroup() (*Group, bool) { return nil, false }
+
+// AsDataset returns the dataset itself.
+func (d *Dataset) AsDataset() (*Dataset, bool) { return d, true }
+
+// Path returns the absolute path of the named datatype.
+func (n *NamedDatatype) Path() string { return n.path }
+
+// Address returns the object header address of the named datatype.
+func (n *NamedDatatype) Address() uint64 { return n.address }
+
+// Kind returns KindNamedDatatype.
+func (n *NamedDatatype) Kind() ObjectKind { return KindNamedDatatype }
+
+// IsGroup returns false.
+func (n *NamedDatatype) IsGroup() bool { return false }
+
+// IsDataset returns false.
+func (n *NamedDatatype) IsDataset() bool { return false }
+
+// AsGroup returns nil, false.
+func (n *NamedDatatype) AsGroup() (*Group, bool) { return nil, false }
+
+// AsDataset returns nil, false.
+func (n *NamedDatatype) AsDataset() (*Dataset, bool) { return nil, false }
+
+// Exists reports whether an object exists at path. Paths are absolute
+// ("/group/data"); a path without a leading slash is taken relative to the
+// root group.
+//
+// The lookup follows links in the hierarchy loaded by Open, so no object
+// headers are read and no dataset is opened.
+//
+// Example:
+//
+//	if f.Exists("/results/run1") {
+//	    ...
+//	}
+func (f *File) Exists(path string) bool {
+	_, err := f.lookup(path)
+	return err == nil
+}
+
+// ObjectType returns the kind of the object at path without opening it.
+// Like Exists, it only follows links in the loaded hierarchy.
+//
+// Returns:
+//   - ObjectKind: KindGroup, KindDataset or KindNamedDatatype
+//   - error: If no object exists at path
+//
+// Example:
+//
+//	kind, err := f.ObjectType("/data")
+//	if err == nil && kind == hdf5.KindDataset {
+//	    ...
+//	}
+func (f *File) ObjectType(path string) (ObjectKind, error) {
+	obj, err := f.lookup(path)
+	if err != nil {
+		return 0, err
+	}
+	return obj.Kind(), nil
+}
+
+// DatasetPaths returns the absolute paths of all datasets in the file,
+// sorted. A dataset reachable through several hard links is listed once per
+// link; links back to an enclosing group are not followed.
+//
+// Only the hierarchy loaded by Open is walked: no dataset object header is
+// read, so this is much cheaper than a Walk that opens every dataset.
+//
+// Example:
+//
+//	paths, err := f.DatasetPaths()
+//	// paths: ["/group/data", "/temperature", ...]
+func (f *File) DatasetPaths() ([]string, error) {
+	var paths []string
+	f.Walk(func(_ string, obj Object) {
+		if obj.IsDataset() {
+			paths = append(paths, obj.Path())
+		}
+	})
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// lookup returns the object at path, following one link per path component.
+func (f *File) lookup(path string) (Object, error) {
+	return lookupFrom(f.root, path)
+}
+
+// lookupFrom returns the object at path below start, following one link per
+// path component.
+func lookupFrom(start *Group, path string) (Object, error) {
+	var obj Object = start
+	for _, name := range strings.Split(path, "/") {
+		if name == "" || name == "." {
+			continue
+		}
+		g, ok := obj.AsGroup()
+		if !ok {
+			return nil, fmt.Errorf("object %q not found: %q is not a group", path, obj.Path())
+		}
+		obj = nil
+		for _, child := range g.Children() {
+			if child.Name() == name {
+				obj = child
+				break
+			}
+		}
+		if obj == nil {
+			return nil, fmt.Errorf("object %q not found", path)
+		}
+	}
+	return obj, nil
+}
+
+// Open returns the object at relPath below the group, descending one group
+// per path component ("run1/data" is the child "data" of the child group
+// "run1"). Like File.Exists, it only follows links in the hierarchy loaded by
+// Open.
+//
+// Example:
+//
+//	obj, err := grp.Open("run1/data")
+//	ds, ok := obj.AsDataset()
+func (g *Group) Open(relPath string) (Object, error) {
+	if strings.HasPrefix(relPath, "/") {
+		return nil, fmt.Errorf("path %q is not relative to group %q", relPath, g.path)
+	}
+	obj, err := lookupFrom(g, relPath)
+	if err != nil {
+		return nil, fmt.Errorf("group %q: %w", g.path, err)
+	}
+	return obj, nil
+}
+
+// Group returns the child group called name.
+//
+// Example:
+//
+//	exp, err := f.Root().Group("experiment")
+//	run, err := exp.Group("run1")
+func (g *Group) Group(name string) (*Group, error) {
+	obj, err := g.child(name)
+	if err != nil {
+		return nil, err
+	}
+	child, ok := obj.AsGroup()
+	if !ok {
+		return nil, fmt.Errorf("%q in group %q is a %s, not a group", name, g.path, obj.Kind())
+	}
+	return child, nil
+}
+
+// Dataset returns the child dataset called name.
+//
+// Example:
+//
+//	exp, err := f.Root().Group("experiment")
+//	ds, err := exp.Dataset("time")
+//	times, err := ds.Read()
+func (g *Group) Dataset(name string) (*Dataset, error) {
+	obj, err := g.child(name)
+	if err != nil {
+		return nil, err
+	}
+	ds, ok := obj.AsDataset()
+	if !ok {
+		return nil, fmt.Errorf("%q in group %q is a %s, not a dataset", name, g.path, obj.Kind())
+	}
+	return ds, nil
+}
+
+// child returns the direct child called name.
+func (g *Group) child(name string) (Object, error) {
+	if name == "" || strings.Contains(name, "/") {
+		return nil, fmt.Errorf("invalid child name %q (use Open for paths)", name)
+	}
+	for _, child := range g.Children() {
+		if child.Name() == name {
+			return child, nil
+		}
+	}
+	return nil, fmt.Errorf("no object %q in group %q", name, g.path)
+}
@@ -0,0 +1,152 @@
+package hdf5
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestObjectRef_RoundTrip(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "refs.h5")
+
+	fw, err := CreateForWrite(filename, CreateTruncate)
+	require.NoError(t, err)
+	_, err = fw.CreateGroup("/runs")
+	require.NoError(t, err)
+	for _, path := range []string{"/runs/a", "/runs/b", "/top"} {
+		ds, err := fw.CreateDataset(path, Float64, []uint64{2})
+		require.NoError(t, err)
+		require.NoError(t, ds.Write([]float64{1, 2}))
+	}
+
+	paths := []string{"/runs/b", "/top", "/runs", "/runs/a"}
+	refs := make([]ObjectRef, len(paths))
+	for i, path := range paths {
+		refs[i], err = fw.MakeObjectRef(path)
+		require.NoError(t, err)
+	}
+	_, err = fw.MakeObjectRef("/runs/missing")
+	require.Error(t, err)
+
+	index, err := fw.CreateDataset("/index", ObjectReference, []uint64{uint64(len(refs))})
+	require.NoError(t, err)
+	require.NoError(t, index.Write(refs))
+	require.NoError(t, fw.Close())
+
+	f, err := Open(filename)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	got, err := findDatasetByPath(t, f, "/index").ReadObjectRefs()
+	require.NoError(t, err)
+	require.Equal(t, refs, got)
+
+	for i, ref := range got {
+		obj, err := f.Dereference(ref)
+		require.NoError(t, err)
+		require.Equal(t, paths[i], obj.Path())
+	}
+
+	_, err = f.Dereference(0)
+	require.ErrorContains(t, err, "null object reference")
+	_, err = findDatasetByPath(t, f, "/top").ReadObjectRefs()
+	require.ErrorContains(t, err, "not an object reference dataset")
+}
+
+func TestRegionRef_Official(t *testing.T) {
+	f, err := Open("testdata/hdf5_official/tattrreg.h5")
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	value, err := findDatasetByPath(t, f, "/Dataset1").ReadAttribute("Attribute1")
+	require.NoError(t, err)
+	refs, ok := value.([]RegionRef)
+	require.True(t, ok, "got %T", value)
+	require.Len(t, refs, 4)
+	require.True(t, refs[2].IsNull())
+	require.True(t, refs[3].IsNull())
+
+	ds, sel, err := f.DereferenceRegion(refs[0])
+	require.NoError(t, err)
+	require.Equal(t, "/Dataset2", ds.Path())
+	require.Equal(t, []RegionBlock{{Start: []uint64{2, 2}, End: []uint64{7, 7}}}, sel.Blocks)
+
+	ds, sel, err = f.DereferenceRegion(refs[1])
+	require.NoError(t, err)
+	require.Equal(t, "/Dataset2", ds.Path())
+	require.Len(t, sel.Points, 10)
+	require.Equal(t, []uint64{6, 9}, sel.Points[0])
+	require.Equal(t, []uint64{3, 3}, sel.Points[9])
+
+	_, _, err = f.DereferenceRegion(refs[2])
+	require.ErrorContains(t, err, "null region reference")
+}
+
+func TestRegionRef_AttributeRoundTrip(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "regions.h5")
+
+	fw, err := CreateForWrite(filename, CreateTruncate)
+	require.NoError(t, err)
+	src, err := fw.CreateDataset("/input", Float64, []uint64{4, 5})
+	require.NoError(t, err)
+	input := make([]float64, 20)
+	for i := range input {
+		input[i] = float64(i)
+	}
+	require.NoError(t, src.Write(input))
+	out, err := fw.CreateDataset("/output", Float64, []uint64{2})
+	require.NoError(t, err)
+	require.NoError(t, out.Write([]float64{1, 2}))
+
+	slab, err := fw.MakeRegionRef("/input", []uint64{1, 2}, []uint64{2, 3})
+	require.NoError(t, err)
+	row, err := fw.MakeRegionRef("/input", []uint64{3, 0}, []uint64{1, 5})
+	require.NoError(t, err)
+	_, err = fw.MakeRegionRef("/missing", []uint64{0}, []uint64{1})
+	require.Error(t, err)
+	_, err = fw.MakeRegionRef("/input", []uint64{0, 0}, []uint64{1})
+	require.Error(t, err)
+
+	require.NoError(t, out.WriteAttribute("source_region", slab))
+	require.NoError(t, out.WriteAttribute("all_regions", []RegionRef{slab, row, {}}))
+
+	index, err := fw.CreateDataset("/index", RegionReference, []uint64{2})
+	require.NoError(t, err)
+	require.NoError(t, index.Write([]RegionRef{row, slab}))
+	require.NoError(t, fw.Close())
+
+	f, err := Open(filename)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	output := findDatasetByPath(t, f, "/output")
+	value, err := output.ReadAttribute("source_region")
+	require.NoError(t, err)
+	ref, ok := value.(RegionRef)
+	require.True(t, ok, "got %T", value)
+
+	ds, sel, err := f.DereferenceRegion(ref)
+	require.NoError(t, err)
+	require.Equal(t, "/input", ds.Path())
+	require.Equal(t, []RegionBlock{{Start: []uint64{1, 2}, End: []uint64{2, 4}}}, sel.Blocks)
+
+	b := sel.Blocks[0]
+	data, err := ds.ReadSlice(b.Start, []uint64{b.End[0] - b.Start[0] + 1, b.End[1] - b.Start[1] + 1})
+	require.NoError(t, err)
+	require.Equal(t, []float64{7, 8, 9, 12, 13, 14}, data)
+
+	value, err = output.ReadAttribute("all_regions")
+	require.NoError(t, err)
+	refs, ok := value.([]RegionRef)
+	require.True(t, ok, "got %T", value)
+	require.Equal(t, []RegionRef{slab, row, {}}, refs)
+
+	_, sel, err = f.DereferenceRegion(refs[1])
+	require.NoError(t, err)
+	require.Equal(t, []RegionBlock{{Start: []uint64{3, 0}, End: []uint64{3, 4}}}, sel.Blocks)
+
+	raw, _, _, err := findDatasetByPath(t, f, "/index").ReadRaw()
+	require.NoError(t, err)
+	require.Equal(t, encodeRegionRefs([]RegionRef{row, slab}), raw)
+}
@@ -0,0 +1,170 @@
+package hdf5
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObject_PathMatchesWalk(t *testing.T) {
+	for _, name := range []string{"testdata/with_groups.h5", "testdata/v0.h5", "testdata/hdf5_official/h5copytst_new.h5"} {
+		t.Run(name, func(t *testing.T) {
+			f, err := Open(name)
+			require.NoError(t, err)
+			defer f.Close()
+
+			count := 0
+			f.Walk(func(path string, obj Object) {
+				count++
+				want := path
+				if want != "/" {
+					want = strings.TrimSuffix(want, "/")
+				}
+				assert.Equal(t, want, obj.Path())
+			})
+			assert.Greater(t, count, 1)
+			assert.Equal(t, "/", f.Root().Path())
+		})
+	}
+}
+
+func TestObject_KindAndAccessors(t *testing.T) {
+	f, err := Open("testdata/hdf5_official/h5copytst_new.h5")
+	require.NoError(t, err)
+	defer f.Close()
+
+	kinds := make(map[ObjectKind]int)
+	f.Walk(func(_ string, obj Object) {
+		kinds[obj.Kind()]++
+
+		g, isGroup := obj.AsGroup()
+		ds, isDataset := obj.AsDataset()
+		assert.Equal(t, isGroup, obj.IsGroup())
+		assert.Equal(t, isDataset, obj.IsDataset())
+
+		switch obj.Kind() {
+		case KindGroup:
+			require.True(t, isGroup)
+			assert.Same(t, obj, g)
+			assert.Nil(t, ds)
+		case KindDataset:
+			require.True(t, isDataset)
+			assert.Same(t, obj, ds)
+			assert.Nil(t, g)
+		case KindNamedDatatype:
+			assert.False(t, isGroup)
+			assert.False(t, isDataset)
+			_, ok := obj.(*NamedDatatype)
+			assert.True(t, ok)
+		default:
+			t.Errorf("unexpected kind %v for %s", obj.Kind(), obj.Path())
+		}
+	})
+
+	assert.NotZero(t, kinds[KindGroup])
+	assert.NotZero(t, kinds[KindDataset])
+	assert.NotZero(t, kinds[KindNamedDatatype])
+}
+
+func TestObjectKind_String(t *testing.T) {
+	assert.Equal(t, "group", KindGroup.String())
+	assert.Equal(t, "dataset", KindDataset.String())
+	assert.Equal(t, "datatype", KindNamedDatatype.String())
+	assert.Equal(t, "unknown", ObjectKind(0).String())
+}
+
+func TestFile_ExistsAndObjectType(t *testing.T) {
+	f, err := Open("testdata/hdf5_official/h5copytst_new.h5")
+	require.NoError(t, err)
+	defer f.Close()
+
+	tests := []struct {
+		path string
+		kind ObjectKind
+	}{
+		{"/", KindGroup},
+		{"/simple", KindDataset},
+		{"simple", KindDataset},
+		{"/grp_dsets/", KindGroup},
+		{"/vl", KindNamedDatatype},
+	}
+	for _, tt := range tests {
+		assert.True(t, f.Exists(tt.path), tt.path)
+		kind, err := f.ObjectType(tt.path)
+		require.NoError(t, err, tt.path)
+		assert.Equal(t, tt.kind, kind, tt.path)
+	}
+
+	assert.False(t, f.Exists("/missing"))
+	assert.False(t, f.Exists("/simple/child"))
+	_, err = f.ObjectType("/missing")
+	require.ErrorContains(t, err, `object "/missing" not found`)
+	_, err = f.ObjectType("/simple/child")
+	require.ErrorContains(t, err, "is not a group")
+}
+
+func TestFile_DatasetPaths(t *testing.T) {
+	f, err := Open("testdata/hdf5_official/h5copytst_new.h5")
+	require.NoError(t, err)
+
+	paths, err := f.DatasetPaths()
+	require.NoError(t, err)
+	assert.True(t, sort.StringsAreSorted(paths))
+	assert.Contains(t, paths, "/simple")
+	assert.Contains(t, paths, "/grp_dsets/simple")
+	assert.NotContains(t, paths, "/grp_dsets")
+	assert.NotContains(t, paths, "/vl")
+
+	var walked []string
+	f.Walk(func(_ string, obj Object) {
+		if ds, ok := obj.AsDataset(); ok {
+			walked = append(walked, ds.Path())
+		}
+	})
+	assert.ElementsMatch(t, walked, paths)
+
+	// The hierarchy stays listed after Close; only reads fail.
+	require.NoError(t, f.Close())
+	closed, err := f.DatasetPaths()
+	require.NoError(t, err)
+	assert.Equal(t, paths, closed)
+}
+
+func TestGroup_RelativeNavigation(t *testing.T) {
+	f, err := Open("testdata/hdf5_official/h5copytst_new.h5")
+	require.NoError(t, err)
+	defer f.Close()
+
+	grp, err := f.Root().Group("grp_dsets")
+	require.NoError(t, err)
+	assert.Equal(t, "/grp_dsets", grp.Path())
+
+	ds, err := grp.Dataset("simple")
+	require.NoError(t, err)
+	assert.Equal(t, "/grp_dsets/simple", ds.Path())
+
+	obj, err := f.Root().Open("grp_dsets/simple")
+	require.NoError(t, err)
+	assert.Same(t, ds, obj)
+	obj, err = grp.Open(".")
+	require.NoError(t, err)
+	assert.Same(t, grp, obj)
+
+	_, err = f.Root().Group("simple")
+	require.ErrorContains(t, err, `"simple" in group "/" is a dataset, not a group`)
+	_, err = f.Root().Dataset("grp_dsets")
+	require.ErrorContains(t, err, "is a group, not a dataset")
+	_, err = f.Root().Dataset("vl")
+	require.ErrorContains(t, err, "is a datatype, not a dataset")
+	_, err = grp.Dataset("missing")
+	require.ErrorContains(t, err, `no object "missing" in group "/grp_dsets"`)
+	_, err = f.Root().Dataset("grp_dsets/simple")
+	require.ErrorContains(t, err, "use Open for paths")
+	_, err = grp.Open("/simple")
+	require.ErrorContains(t, err, "not relative")
+	_, err = grp.Open("simple/child")
+	require.ErrorContains(t, err, "is not a group")
+}
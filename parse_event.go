@@ -0,0 +1,64 @@
+package hdf5
+
+import (
+	"fmt"
+)
+
+// ParseEvent describes one step Open takes while loading the file structure:
+// a structure it read (superblock, object header, B-tree, local heap, link
+// message, ...) or a decision it made, such as skipping a link. Events are
+// reported in the order they happen, so the last events before a group shows
+// up empty tell where traversal stopped finding children.
+type ParseEvent struct {
+	Path      string // Path of the object being loaded ("/" for the root group)
+	Address   uint64 // File address of the structure
+	Structure string // Kind of structure, e.g. "B-tree" or "object header"
+	Message   string // What was found or decided, e.g. "symbol table empty"
+	Err       error  // Why a link or structure was skipped, if it was
+}
+
+// String formats the event as a single log line.
+func (e ParseEvent) String() string {
+	s := fmt.Sprintf("%s: %s at 0x%X: %s", e.Path, e.Structure, e.Address, e.Message)
+	if e.Err != nil {
+		s += ": " + e.Err.Error()
+	}
+	return s
+}
+
+// WithLogger sets a function called with a ParseEvent for each step Open
+// takes while walking the superblock, groups, B-trees, heaps and object
+// headers. It is meant for diagnosing files whose structure is not read as
+// expected; fn is called synchronously and only while Open runs.
+//
+// Example:
+//
+//	f, err := hdf5.Open("flux.h5", hdf5.WithLogger(func(e hdf5.ParseEvent) {
+//	    log.Println(e)
+//	}))
+func WithLogger(fn func(event ParseEvent)) OpenOption {
+	return func(cfg *openConfig) {
+		cfg.logger = fn
+	}
+}
+
+// trace reports a parse event for the object being loaded, if a logger is
+// set.
+func (f *File) trace(structure string, address uint64, format string, args ...interface{}) {
+	f.traceError(structure, address, nil, format, args...)
+}
+
+// traceError reports a parse event carrying the error that made Open skip
+// something, if a logger is set.
+func (f *File) traceError(structure string, address uint64, err error, format string, args ...interface{}) {
+	if f.logger == nil {
+		return
+	}
+	f.logger(ParseEvent{
+		Path:      f.loadingPath(),
+		Address:   address,
+		Structure: structure,
+		Message:   fmt.Sprintf(format, args...),
+		Err:       err,
+	})
+}
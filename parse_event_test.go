@@ -0,0 +1,93 @@
+package hdf5
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// traceOpen opens filename with a logger and returns the events reported.
+func traceOpen(t *testing.T, filename string) []ParseEvent {
+	t.Helper()
+	var events []ParseEvent
+	f, err := Open(filename, WithLogger(func(e ParseEvent) {
+		events = append(events, e)
+	}))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	return events
+}
+
+// requireEvent fails unless an event for path and structure has a message
+// containing substr.
+func requireEvent(t *testing.T, events []ParseEvent, path, structure, substr string) {
+	t.Helper()
+	for _, e := range events {
+		if e.Path == path && e.Structure == structure && strings.Contains(e.Message, substr) {
+			return
+		}
+	}
+	t.Fatalf("no %s event for %s containing %q in:\n%s", structure, path, substr, formatEvents(events))
+}
+
+func formatEvents(events []ParseEvent) string {
+	lines := make([]string, len(events))
+	for i, e := range events {
+		lines[i] = e.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestOpen_WithLogger_SymbolTables(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "trace.h5")
+
+	fw, err := CreateForWrite(filename, CreateTruncate)
+	require.NoError(t, err)
+	_, err = fw.CreateGroup("/empty")
+	require.NoError(t, err)
+	ds, err := fw.CreateDataset("/data", Float64, []uint64{2})
+	require.NoError(t, err)
+	require.NoError(t, ds.Write([]float64{1, 2}))
+	require.NoError(t, fw.Close())
+
+	events := traceOpen(t, filename)
+	require.Equal(t, "superblock", events[0].Structure)
+	require.Contains(t, events[0].Message, "version 2")
+
+	requireEvent(t, events, "/", "object header", "type group")
+	requireEvent(t, events, "/", "symbol table", "B-tree at")
+	requireEvent(t, events, "/", "B-tree", "2 entries")
+	requireEvent(t, events, "/data", "object header", "type dataset")
+	requireEvent(t, events, "/empty", "B-tree", "symbol table empty")
+}
+
+func TestOpen_WithLogger_LinkMessages(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "trace_links.h5")
+
+	fw, err := CreateForWrite(filename, CreateTruncate, WithModernGroups())
+	require.NoError(t, err)
+	_, err = fw.CreateGroup("/runs")
+	require.NoError(t, err)
+	ds, err := fw.CreateDataset("/runs/a", Int32, []uint64{1})
+	require.NoError(t, err)
+	require.NoError(t, ds.Write([]int32{7}))
+	require.NoError(t, fw.CreateSoftLink("/latest", "/runs/a"))
+	require.NoError(t, fw.Close())
+
+	events := traceOpen(t, filename)
+	requireEvent(t, events, "/runs", "link message", `hard link "a"`)
+	requireEvent(t, events, "/runs/a", "object header", "type dataset")
+	requireEvent(t, events, "/latest", "link message", `soft link "latest" not loaded`)
+
+	// Nothing is reported once Open has returned.
+	count := 0
+	f, err := Open(filename, WithLogger(func(ParseEvent) { count++ }))
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+	opened := count
+	_, err = findDatasetByPath(t, f, "/runs/a").Read()
+	require.NoError(t, err)
+	require.Equal(t, opened, count)
+}
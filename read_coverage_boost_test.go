@@ -21,7 +21,7 @@ func TestReadSignature_AtEndOfFile(t *testing.T) {
 	defer func() { _ = f.Close() }()
 
 	// Reading signature at an absurdly large offset should return empty string.
-	sig := readSignature(f.osFile, 0xFFFFFFFFFFFF)
+	sig := readSignature(f.reader, 0xFFFFFFFFFFFF)
 	assert.Equal(t, "", sig, "readSignature past EOF should return empty string")
 }
 
@@ -31,7 +31,7 @@ func TestReadSignature_AtZero(t *testing.T) {
 	require.NoError(t, err)
 	defer func() { _ = f.Close() }()
 
-	sig := readSignature(f.osFile, 0)
+	sig := readSignature(f.reader, 0)
 	// HDF5 magic bytes: \x89HDF
 	assert.Equal(t, "\x89HDF", sig, "readSignature at offset 0 should be HDF5 magic prefix")
 }
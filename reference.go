@@ -0,0 +1,196 @@
+package hdf5
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/scigolib/hdf5/internal/core"
+)
+
+// ObjectRef is an HDF5 object reference (hobj_ref_t): the address of the
+// referenced object's header. It is the element type of ObjectReference
+// datasets.
+type ObjectRef uint64
+
+// MakeObjectRef returns a reference to the group or dataset at path, for
+// writing into an ObjectReference dataset.
+//
+// Parameters:
+//   - path: Absolute path of the referenced object (e.g., "/data/run1")
+//
+// Returns:
+//   - ObjectRef: Reference to the object
+//   - error: If the object does not exist
+//
+// Example:
+//
+//	a, _ := fw.MakeObjectRef("/runs/a")
+//	b, _ := fw.MakeObjectRef("/runs/b")
+//	index, _ := fw.CreateDataset("/index", hdf5.ObjectReference, []uint64{2})
+//	err := index.Write([]hdf5.ObjectRef{a, b})
+func (fw *FileWriter) MakeObjectRef(path string) (ObjectRef, error) {
+	addr, err := fw.resolveObjectAddress(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to resolve %q: %w", path, err)
+	}
+	return ObjectRef(addr), nil
+}
+
+// ReadObjectRefs reads an object reference dataset. Use Dereference to get
+// the referenced objects.
+//
+// Returns:
+//   - []ObjectRef: One reference per element
+//   - error: If the dataset is not an object reference dataset
+func (d *Dataset) ReadObjectRefs() ([]ObjectRef, error) {
+	header, err := core.ReadObjectHeader(d.file.reader, d.address, d.file.sb)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object header: %w", err)
+	}
+	messages, err := extractHyperslabMessages(header)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := parseHyperslabMessages(messages, d.file.sb)
+	if err != nil {
+		return nil, err
+	}
+
+	// Class bit field bits 0-3: 0 = object reference, 1 = region reference.
+	dt := parsed.datatype
+	if dt.Class != core.DatatypeReference || dt.ClassBitField&0x0F != 0 {
+		return nil, fmt.Errorf("dataset is not an object reference dataset (class %d)", dt.Class)
+	}
+	size := uint64(dt.Size)
+	if size != 4 && size != 8 {
+		return nil, fmt.Errorf("unsupported object reference size: %d", size)
+	}
+
+	buf := make([]byte, parsed.dataspace.TotalElements()*size)
+	n, _, err := core.ReadDatasetRawInto(d.file.reader, header, d.file.sb, buf, d.file.readOptions()...)
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make([]ObjectRef, n)
+	for i := range refs {
+		raw := buf[uint64(i)*size:] //nolint:gosec // G115: element index is non-negative
+		if size == 4 {
+			refs[i] = ObjectRef(binary.LittleEndian.Uint32(raw))
+		} else {
+			refs[i] = ObjectRef(binary.LittleEndian.Uint64(raw))
+		}
+	}
+	return refs, nil
+}
+
+// Dereference returns the group or dataset an object reference points to.
+//
+// Example:
+//
+//	refs, _ := index.ReadObjectRefs()
+//	obj, err := f.Dereference(refs[0])
+//	fmt.Println(obj.Path())
+func (f *File) Dereference(ref ObjectRef) (Object, error) {
+	if ref == 0 || uint64(ref) == undefinedAddress {
+		return nil, fmt.Errorf("null object reference")
+	}
+
+	var found Object
+	f.Walk(func(_ string, obj Object) {
+		if found == nil && obj.Address() == uint64(ref) {
+			found = obj
+		}
+	})
+	if found == nil {
+		return nil, fmt.Errorf("no object at address 0x%x", uint64(ref))
+	}
+	return found, nil
+}
+
+// RegionRef is an HDF5 dataset region reference (hdset_reg_ref_t): it names
+// a dataset and a selection within it. It is the element type of
+// RegionReference datasets and the value of region reference attributes.
+// The zero value is the null reference.
+type RegionRef = core.RegionReference
+
+// RegionSelection is the selection a region reference points at: the whole
+// dataset (All), a list of element coordinates (Points), or hyperslab blocks
+// with inclusive corners (Blocks). Regular hyperslabs are expanded into
+// their blocks.
+type RegionSelection = core.RegionSelection
+
+// RegionBlock is one block of a region selection; End is inclusive.
+type RegionBlock = core.RegionBlock
+
+// MakeRegionRef returns a reference to the block of the dataset at path that
+// starts at start and spans count elements per dimension, for writing into
+// a RegionReference dataset or attribute. The selection is stored in the
+// file's global heap.
+//
+// Parameters:
+//   - path: Absolute path of the referenced dataset (e.g., "/raw/frames")
+//   - start: First selected element in each dimension
+//   - count: Number of selected elements in each dimension
+//
+// Returns:
+//   - RegionRef: Reference to the region
+//   - error: If the dataset does not exist or the block is invalid
+//
+// Example:
+//
+//	ref, _ := fw.MakeRegionRef("/raw/frames", []uint64{100, 0}, []uint64{50, 640})
+//	err := result.WriteAttribute("source_region", ref)
+func (fw *FileWriter) MakeRegionRef(path string, start, count []uint64) (RegionRef, error) {
+	addr, err := fw.resolveObjectAddress(path)
+	if err != nil {
+		return RegionRef{}, fmt.Errorf("failed to resolve %q: %w", path, err)
+	}
+	obj, err := core.EncodeHyperslabRegion(addr, start, count, 8)
+	if err != nil {
+		return RegionRef{}, fmt.Errorf("invalid region of %q: %w", path, err)
+	}
+
+	ensureGlobalHeapWriter(fw)
+	id, err := fw.globalHeapWriter.WriteToGlobalHeap(obj)
+	if err != nil {
+		return RegionRef{}, fmt.Errorf("failed to write region to global heap: %w", err)
+	}
+	return RegionRef{HeapAddress: id.CollectionAddress, ObjectIndex: uint32(id.ObjectIndex)}, nil
+}
+
+// DereferenceRegion returns the dataset a region reference points to and
+// the selection within it.
+//
+// Example:
+//
+//	v, _ := ds.ReadAttribute("source_region")
+//	src, sel, err := f.DereferenceRegion(v.(hdf5.RegionRef))
+//	for _, b := range sel.Blocks {
+//		fmt.Println(src.Name(), b.Start, b.End)
+//	}
+func (f *File) DereferenceRegion(ref RegionRef) (*Dataset, *RegionSelection, error) {
+	addr, sel, err := core.ReadRegion(f.reader, ref, int(f.sb.OffsetSize))
+	if err != nil {
+		return nil, nil, err
+	}
+	obj, err := f.Dereference(ObjectRef(addr))
+	if err != nil {
+		return nil, nil, err
+	}
+	ds, ok := obj.(*Dataset)
+	if !ok {
+		return nil, nil, fmt.Errorf("region reference points to %s, not a dataset", obj.Path())
+	}
+	return ds, sel, nil
+}
+
+// encodeRegionRefs encodes region references as 12-byte global heap IDs.
+func encodeRegionRefs(refs []RegionRef) []byte {
+	buf := make([]byte, len(refs)*12)
+	for i, ref := range refs {
+		binary.LittleEndian.PutUint64(buf[i*12:], ref.HeapAddress)
+		binary.LittleEndian.PutUint32(buf[i*12+8:], ref.ObjectIndex)
+	}
+	return buf
+}
@@ -0,0 +1,897 @@
+package hdf5
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/scigolib/hdf5/internal/core"
+	"github.com/scigolib/hdf5/internal/structures"
+	"github.com/scigolib/hdf5/internal/writer"
+)
+
+// repackCopyBlockSize is the amount of contiguous raw data copied per read/write.
+const repackCopyBlockSize = 1 << 20
+
+// Repack copies all live objects of the HDF5 file src into a freshly allocated file dst,
+// dropping the space lost to deleted objects, removed attributes and relocated heaps.
+//
+// This is the equivalent of h5repack without filter or layout changes: object headers
+// are rewritten compactly, raw data is copied byte for byte (compressed chunks are not
+// decompressed), variable-length data is moved to new global heap collections and
+// object references are updated to the new object addresses. An object reachable
+// through several hard links is copied once and linked again.
+//
+// dst is created (or truncated) with the superblock version of src (v0 for v0/v1
+// sources, v2 otherwise). opts are applied after that, so WithSuperblockVersion or
+// WithModernGroups change the output format. If repacking fails, dst is removed.
+//
+// Parameters:
+//   - src: Path of the file to compact
+//   - dst: Path of the file to create (must differ from src)
+//   - opts: Write options for the new file
+//
+// Returns:
+//   - error: If src cannot be read or contains structures Repack cannot copy
+//
+// Example:
+//
+//	// After many DeleteAttribute/Delete calls:
+//	if err := hdf5.Repack("churned.h5", "compact.h5"); err != nil {
+//	    log.Fatal(err)
+//	}
+//	os.Rename("compact.h5", "churned.h5")
+//
+// Soft and external links are copied with their target paths unchanged; they
+// are not followed, so external files are neither read nor copied.
+//
+// Limitations:
+//   - User-defined links are rejected
+//   - Chunked datasets must use a version 1 B-tree chunk index (layout message version 3)
+//   - Datasets with external raw data files and dataset region references are rejected
+//   - Only hard links reachable from the root group are copied (like h5repack)
+//   - WithCompatibility(Earliest) is rejected: messages are copied in their source versions
+//
+// Reference: tools/src/h5repack/h5repack_copy.c - copy_objects(), H5Ocopy.c - H5O__copy_header_real().
+func Repack(src, dst string, opts ...WriteOption) error {
+	srcAbs, err := filepath.Abs(src)
+	if err != nil {
+		return fmt.Errorf("repack: %w", err)
+	}
+	dstAbs, err := filepath.Abs(dst)
+	if err != nil {
+		return fmt.Errorf("repack: %w", err)
+	}
+	if srcAbs == dstAbs {
+		return fmt.Errorf("repack: source and destination are the same file: %s", src)
+	}
+
+	cfg := &FileWriteConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.Compatibility == Earliest {
+		return fmt.Errorf("repack: WithCompatibility(Earliest) is not supported")
+	}
+
+	in, err := Open(src)
+	if err != nil {
+		return fmt.Errorf("repack: open source: %w", err)
+	}
+	defer func() { _ = in.Close() }()
+
+	version := uint8(SuperblockV2)
+	if in.SuperblockVersion() < core.Version2 {
+		version = SuperblockV0
+	}
+	createOpts := make([]interface{}, 0, len(opts)+1)
+	createOpts = append(createOpts, WithSuperblockVersion(version))
+	for _, opt := range opts {
+		createOpts = append(createOpts, opt)
+	}
+
+	fw, err := CreateForWrite(dst, CreateTruncate, createOpts...)
+	if err != nil {
+		return fmt.Errorf("repack: create destination: %w", err)
+	}
+
+	rp := newRepacker(in, fw)
+	if err := rp.run(); err != nil {
+		_ = fw.Close()
+		_ = os.Remove(dst)
+		return fmt.Errorf("repack %s: %w", src, err)
+	}
+
+	if err := fw.Close(); err != nil {
+		_ = os.Remove(dst)
+		return fmt.Errorf("repack: close destination: %w", err)
+	}
+	return nil
+}
+
+// repackedObject is an object header copied to the destination file.
+type repackedObject struct {
+	path    string
+	srcAddr uint64
+	dstAddr uint64
+	allocSz uint64 // Allocated size of the destination header (0 = look up)
+}
+
+// pendingLink is a hard link whose target is copied after the main pass.
+type pendingLink struct {
+	path    string
+	srcAddr uint64
+}
+
+// relocField is a part of a datatype element that holds a file address:
+// an object reference or a variable-length global heap ID.
+type relocField struct {
+	offset uint32
+	dt     *core.DatatypeMessage
+}
+
+// repacker copies the object hierarchy of one file into another.
+//
+// Objects are copied in two passes. The first pass recreates groups, datasets and
+// named datatypes (datasets holding object references are deferred to the end of
+// the pass so their targets exist). The second pass copies attributes, which may
+// reference any object in the file.
+type repacker struct {
+	src *File
+	fw  *FileWriter
+
+	paths   map[uint64]string // Source header address → destination path
+	addrs   map[uint64]uint64 // Source header address → destination header address
+	objects []*repackedObject
+
+	deferred     []pendingLink // Datasets with object references
+	deferredRefs map[uint64]bool
+	pendingLinks []pendingLink // Extra hard links to deferred datasets
+
+	heaps     map[uint64]*core.GlobalHeapCollection  // Source global heap collections
+	vlenBases map[*core.DatatypeMessage][]relocField // Relocatable parts of vlen base types
+}
+
+func newRepacker(src *File, fw *FileWriter) *repacker {
+	return &repacker{
+		src:          src,
+		fw:           fw,
+		paths:        make(map[uint64]string),
+		addrs:        make(map[uint64]uint64),
+		deferredRefs: make(map[uint64]bool),
+		heaps:        make(map[uint64]*core.GlobalHeapCollection),
+		vlenBases:    make(map[*core.DatatypeMessage][]relocField),
+	}
+}
+
+// run copies the whole hierarchy.
+func (rp *repacker) run() error {
+	rootAddr := rp.src.sb.RootGroup
+	rp.record(rootAddr, rp.fw.rootGroupAddr, "/", 0)
+
+	if err := rp.copyChildren(rp.src.Root(), ""); err != nil {
+		return err
+	}
+
+	for _, d := range rp.deferred {
+		if err := rp.copyDataset(d.srcAddr, d.path, true); err != nil {
+			return err
+		}
+	}
+	for _, l := range rp.pendingLinks {
+		if err := rp.fw.CreateHardLink(l.path, rp.paths[l.srcAddr]); err != nil {
+			return fmt.Errorf("link %q: %w", l.path, err)
+		}
+	}
+
+	for _, obj := range rp.objects {
+		if err := rp.copyAttributes(obj); err != nil {
+			return fmt.Errorf("copy attributes of %q: %w", obj.path, err)
+		}
+	}
+	return nil
+}
+
+// record registers a copied object.
+func (rp *repacker) record(srcAddr, dstAddr uint64, path string, allocSz uint64) {
+	rp.paths[srcAddr] = path
+	rp.addrs[srcAddr] = dstAddr
+	rp.objects = append(rp.objects, &repackedObject{
+		path:    path,
+		srcAddr: srcAddr,
+		dstAddr: dstAddr,
+		allocSz: allocSz,
+	})
+}
+
+// copyChildren copies the members of a source group below prefix ("" for the root).
+func (rp *repacker) copyChildren(g *Group, prefix string) error {
+	links, err := g.Links()
+	if err != nil {
+		return fmt.Errorf("read links of %q: %w", g.Path(), err)
+	}
+	if err := rp.copyLinks(links, prefix); err != nil {
+		return err
+	}
+	hard := make(map[string]bool, len(links))
+	for _, l := range links {
+		hard[l.Name] = l.Type == LinkHard
+	}
+
+	for _, child := range g.Children() {
+		// Soft and external links stored as link objects were copied above.
+		if isHard, ok := hard[child.Name()]; ok && !isHard {
+			continue
+		}
+		path := prefix + "/" + child.Name()
+
+		var srcAddr uint64
+		switch obj := child.(type) {
+		case *Group:
+			srcAddr = obj.address
+		case *Dataset:
+			srcAddr = obj.address
+		case *NamedDatatype:
+			srcAddr = obj.address
+		}
+
+		// Another hard link to an object that was already copied.
+		if srcAddr != 0 {
+			if target, ok := rp.paths[srcAddr]; ok {
+				if err := rp.fw.CreateHardLink(path, target); err != nil {
+					return fmt.Errorf("link %q: %w", path, err)
+				}
+				continue
+			}
+			if rp.deferredRefs[srcAddr] {
+				rp.pendingLinks = append(rp.pendingLinks, pendingLink{path: path, srcAddr: srcAddr})
+				continue
+			}
+		}
+
+		switch obj := child.(type) {
+		case *Group:
+			gw, err := rp.fw.CreateGroup(path)
+			if err != nil {
+				return fmt.Errorf("create group %q: %w", path, err)
+			}
+			if srcAddr != 0 {
+				rp.record(srcAddr, gw.headerAddr, path, 0)
+			}
+			if err := rp.copyChildren(obj, path); err != nil {
+				return err
+			}
+		case *Dataset:
+			if err := rp.copyDataset(srcAddr, path, false); err != nil {
+				return err
+			}
+		case *NamedDatatype:
+			if err := rp.copyNamedDatatype(srcAddr, path); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// copyLinks recreates the soft and external links of a source group below prefix.
+// Their targets are stored as paths, so they are copied unchanged.
+func (rp *repacker) copyLinks(links []LinkInfo, prefix string) error {
+	for _, l := range links {
+		path := prefix + "/" + l.Name
+		switch l.Type {
+		case LinkHard:
+			continue
+		case LinkSoft:
+			if err := rp.fw.CreateSoftLink(path, l.Target); err != nil {
+				return fmt.Errorf("soft link %q: %w", path, err)
+			}
+		case LinkExternal:
+			if err := rp.fw.CreateExternalLink(path, l.ExternalFile, l.Target); err != nil {
+				return fmt.Errorf("external link %q: %w", path, err)
+			}
+		default:
+			return fmt.Errorf("link %q: %s links are not supported", path, l.Type)
+		}
+	}
+	return nil
+}
+
+// copyDataset copies a dataset's header and raw data. Datasets whose elements hold
+// object references are deferred until all other objects exist, unless final is set.
+func (rp *repacker) copyDataset(srcAddr uint64, path string, final bool) error {
+	oh, err := core.ReadObjectHeader(rp.src.reader, srcAddr, rp.src.sb)
+	if err != nil {
+		return fmt.Errorf("read dataset %q: %w", path, err)
+	}
+
+	var dt *core.DatatypeMessage
+	hasFilters := false
+	for _, msg := range oh.Messages {
+		switch msg.Type {
+		case core.MsgDatatype:
+			dt, err = core.ParseDatatypeMessage(msg.Data)
+			if err != nil {
+				return fmt.Errorf("dataset %q: parse datatype: %w", path, err)
+			}
+		case core.MsgFilterPipeline:
+			hasFilters = true
+		case core.MsgExternalFiles:
+			return fmt.Errorf("dataset %q: external raw data files are not supported", path)
+		}
+	}
+	if dt == nil {
+		return fmt.Errorf("dataset %q: missing datatype message", path)
+	}
+
+	fields, err := relocFields(dt, 0, rp.src.sb.OffsetSize, nil)
+	if err != nil {
+		return fmt.Errorf("dataset %q: %w", path, err)
+	}
+	if !final && hasReference(fields) {
+		rp.deferred = append(rp.deferred, pendingLink{path: path, srcAddr: srcAddr})
+		rp.deferredRefs[srcAddr] = true
+		return nil
+	}
+
+	msgs := make([]core.MessageWriter, 0, len(oh.Messages))
+	for _, msg := range oh.Messages {
+		if skipRepackMessage(msg.Type) {
+			continue
+		}
+		data := msg.Data
+		if msg.Type == core.MsgDataLayout {
+			data, err = rp.copyLayout(msg.Data, dt, fields, hasFilters)
+			if err != nil {
+				return fmt.Errorf("dataset %q: %w", path, err)
+			}
+		}
+		msgs = append(msgs, core.MessageWriter{Type: msg.Type, Data: data})
+	}
+
+	return rp.createObject(srcAddr, path, msgs)
+}
+
+// copyNamedDatatype copies a committed datatype.
+func (rp *repacker) copyNamedDatatype(srcAddr uint64, path string) error {
+	oh, err := core.ReadObjectHeader(rp.src.reader, srcAddr, rp.src.sb)
+	if err != nil {
+		return fmt.Errorf("read datatype %q: %w", path, err)
+	}
+
+	msgs := make([]core.MessageWriter, 0, len(oh.Messages))
+	for _, msg := range oh.Messages {
+		if !skipRepackMessage(msg.Type) {
+			msgs = append(msgs, core.MessageWriter{Type: msg.Type, Data: msg.Data})
+		}
+	}
+	return rp.createObject(srcAddr, path, msgs)
+}
+
+// skipRepackMessage reports whether a source header message is not copied as-is:
+// padding and continuations (the header is rewritten in one chunk), attributes
+// (copied in the second pass) and reference counts (rebuilt by CreateHardLink).
+func skipRepackMessage(t core.MessageType) bool {
+	switch t {
+	case core.MsgNil, core.MsgContinuation, core.MsgAttribute, core.MsgAttributeInfo, core.MsgRefCount:
+		return true
+	}
+	return false
+}
+
+// createObject writes a new object header with the given messages and links it at path.
+func (rp *repacker) createObject(srcAddr uint64, path string, msgs []core.MessageWriter) error {
+	fw := rp.fw
+
+	ohw := &core.ObjectHeaderWriter{
+		Version:  2,
+		Flags:    0,
+		Messages: msgs,
+	}
+	fw.stampAttributePhaseChange(ohw)
+	ohw.PadToSize(core.MinOHDRAllocSize)
+	headerSize := ohw.Size()
+
+	headerAddr, err := fw.writer.Allocate(headerSize)
+	if err != nil {
+		return fmt.Errorf("allocate header for %q: %w", path, err)
+	}
+	writtenSize, err := ohw.WriteTo(fw.writer, headerAddr)
+	if err != nil {
+		return fmt.Errorf("write header for %q: %w", path, err)
+	}
+	if writtenSize != headerSize {
+		return fmt.Errorf("header size mismatch for %q: expected %d, wrote %d", path, headerSize, writtenSize)
+	}
+
+	parent, name := parsePath(path)
+	if err := fw.linkToParent(parent, name, headerAddr); err != nil {
+		return fmt.Errorf("link %q: %w", path, err)
+	}
+
+	rp.record(srcAddr, headerAddr, path, headerSize)
+	return nil
+}
+
+// copyLayout copies a dataset's raw data and returns the Data Layout message for the copy.
+func (rp *repacker) copyLayout(data []byte, dt *core.DatatypeMessage, fields []relocField, hasFilters bool) ([]byte, error) {
+	srcSB := rp.src.sb
+	dstSB := rp.fw.file.sb
+
+	layout, err := core.ParseDataLayoutMessage(data, srcSB)
+	if err != nil {
+		return nil, fmt.Errorf("parse layout: %w", err)
+	}
+
+	switch layout.Class {
+	case core.LayoutCompact:
+		// Layout v3/v4 compact: version(1) + class(1) + size(2) + data.
+		out := append([]byte(nil), data...)
+		if err := rp.relocateElements(fields, dt.Size, out[4:4+len(layout.CompactData)]); err != nil {
+			return nil, err
+		}
+		return out, nil
+
+	case core.LayoutContiguous:
+		dstAddr := undefinedAddress
+		if layout.DataAddress != undefinedAddress && layout.DataSize > 0 {
+			dstAddr, err = rp.copyContiguous(layout.DataAddress, layout.DataSize, dt.Size, fields)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return core.EncodeLayoutMessage(core.LayoutContiguous, layout.DataSize, dstAddr, dstSB, nil, 0)
+
+	case core.LayoutChunked:
+		if layout.Version != 3 {
+			return nil, fmt.Errorf("chunked layout version %d (non-B-tree chunk index) is not supported", layout.Version)
+		}
+		if len(fields) > 0 && hasFilters {
+			return nil, errors.New("filtered chunks with variable-length data or references are not supported")
+		}
+		ndims := len(layout.ChunkSize) - 1
+		if ndims < 1 {
+			return nil, fmt.Errorf("invalid chunked layout dimensionality %d", len(layout.ChunkSize))
+		}
+		//nolint:gosec // G115: chunk element size is a uint32 on disk
+		elemSize := uint32(layout.ChunkSize[ndims])
+
+		btreeAddr := undefinedAddress
+		if layout.DataAddress != 0 && layout.DataAddress != undefinedAddress {
+			btreeAddr, err = rp.copyChunks(layout, ndims, elemSize, dt.Size, fields)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return core.EncodeLayoutMessage(core.LayoutChunked, 0, btreeAddr, dstSB, layout.ChunkSize[:ndims], elemSize)
+
+	default:
+		return nil, fmt.Errorf("layout class %d is not supported", layout.Class)
+	}
+}
+
+// copyContiguous copies size bytes of contiguous raw data and returns the new address.
+func (rp *repacker) copyContiguous(srcAddr, size uint64, elemSize uint32, fields []relocField) (uint64, error) {
+	fw := rp.fw
+
+	dstAddr, err := fw.writer.AllocateRaw(size)
+	if err != nil {
+		return 0, fmt.Errorf("allocate raw data: %w", err)
+	}
+
+	// Copy whole elements per block so references and heap IDs are never split.
+	block := uint64(repackCopyBlockSize)
+	if elemSize > 0 && block > uint64(elemSize) {
+		block -= block % uint64(elemSize)
+	}
+	buf := make([]byte, min(block, size))
+	for off := uint64(0); off < size; off += uint64(len(buf)) {
+		if remaining := size - off; remaining < uint64(len(buf)) {
+			buf = buf[:remaining]
+		}
+		//nolint:gosec // G115: HDF5 addresses fit in int64 for io.ReaderAt interface
+		if _, err := rp.src.reader.ReadAt(buf, int64(srcAddr+off)); err != nil {
+			return 0, fmt.Errorf("read raw data at 0x%X: %w", srcAddr+off, err)
+		}
+		if err := rp.relocateElements(fields, elemSize, buf); err != nil {
+			return 0, err
+		}
+		if err := fw.writer.WriteAtAddress(buf, dstAddr+off); err != nil {
+			return 0, fmt.Errorf("write raw data: %w", err)
+		}
+	}
+	return dstAddr, nil
+}
+
+// copyChunks copies every chunk of a B-tree indexed dataset (still filtered) and
+// writes a new chunk index. Returns the address of the new B-tree.
+func (rp *repacker) copyChunks(layout *core.DataLayoutMessage, ndims int, chunkElemSize, dtSize uint32,
+	fields []relocField) (uint64, error) {
+	fw := rp.fw
+	sb := rp.src.sb
+
+	root, err := core.ParseBTreeV1Node(rp.src.reader, layout.DataAddress, sb.OffsetSize, len(layout.ChunkSize), layout.ChunkSize)
+	if err != nil {
+		return 0, fmt.Errorf("parse chunk index: %w", err)
+	}
+	chunks, err := root.CollectAllChunks(rp.src.reader, sb.OffsetSize, layout.ChunkSize)
+	if err != nil {
+		return 0, fmt.Errorf("collect chunks: %w", err)
+	}
+
+	btreeWriter := structures.NewChunkBTreeWriter(ndims, layout.ChunkSize[:ndims], chunkElemSize)
+	for _, chunk := range chunks {
+		buf := make([]byte, chunk.Key.Nbytes)
+		//nolint:gosec // G115: HDF5 addresses fit in int64 for io.ReaderAt interface
+		if _, err := rp.src.reader.ReadAt(buf, int64(chunk.Address)); err != nil {
+			return 0, fmt.Errorf("read chunk at 0x%X: %w", chunk.Address, err)
+		}
+		if err := rp.relocateElements(fields, dtSize, buf); err != nil {
+			return 0, err
+		}
+
+		addr, err := fw.writer.AllocateRaw(uint64(len(buf)))
+		if err != nil {
+			return 0, fmt.Errorf("allocate chunk: %w", err)
+		}
+		if err := fw.writer.WriteAtAddress(buf, addr); err != nil {
+			return 0, fmt.Errorf("write chunk: %w", err)
+		}
+		if err := btreeWriter.AddChunkWithFilterMask(chunk.Key.Scaled[:ndims], addr, chunk.Key.Nbytes, chunk.Key.FilterMask); err != nil {
+			return 0, fmt.Errorf("index chunk %v: %w", chunk.Key.Scaled[:ndims], err)
+		}
+	}
+
+	if len(chunks) == 0 {
+		return undefinedAddress, nil
+	}
+	btreeAddr, err := btreeWriter.WriteToFile(fw.writer, fw.writer.Allocator())
+	if err != nil {
+		return 0, fmt.Errorf("write chunk index: %w", err)
+	}
+	return btreeAddr, nil
+}
+
+// copyAttributes copies the attributes of a source object (compact or dense) to its copy.
+// Like WriteAttributes, it uses dense storage when there are more attributes than the
+// destination's WithMaxCompactAttributes threshold.
+func (rp *repacker) copyAttributes(obj *repackedObject) error {
+	sb := rp.src.sb
+
+	oh, err := core.ReadObjectHeader(rp.src.reader, obj.srcAddr, sb)
+	if err != nil {
+		return fmt.Errorf("read header: %w", err)
+	}
+
+	var raws [][]byte
+	for _, msg := range oh.Messages {
+		switch msg.Type {
+		case core.MsgAttribute:
+			raws = append(raws, msg.Data)
+		case core.MsgAttributeInfo:
+			info, parseErr := core.ParseAttributeInfoMessage(msg.Data, sb)
+			if parseErr != nil {
+				return fmt.Errorf("parse attribute info: %w", parseErr)
+			}
+			if info.FractalHeapAddr == 0 || info.FractalHeapAddr == undefinedAddress {
+				continue
+			}
+			dense, readErr := core.ReadDenseHeapObjects(rp.src.reader, info.BTreeNameIndexAddr, info.FractalHeapAddr, sb)
+			if readErr != nil {
+				return fmt.Errorf("read dense attributes: %w", readErr)
+			}
+			raws = append(raws, dense...)
+		}
+	}
+	if len(raws) == 0 {
+		return nil
+	}
+
+	names := make([]string, len(raws))
+	encoded := make([][]byte, len(raws))
+	for i, raw := range raws {
+		attr, err := core.ParseAttributeMessage(raw, sb.Endianness)
+		if err != nil {
+			return fmt.Errorf("parse attribute: %w", err)
+		}
+		fields, err := relocFields(attr.Datatype, 0, sb.OffsetSize, nil)
+		if err != nil {
+			return fmt.Errorf("attribute %q: %w", attr.Name, err)
+		}
+		out := append([]byte(nil), raw...)
+		// The attribute value is the tail of the message.
+		if err := rp.relocateElements(fields, attr.Datatype.Size, out[len(out)-len(attr.Data):]); err != nil {
+			return fmt.Errorf("attribute %q: %w", attr.Name, err)
+		}
+		names[i] = attr.Name
+		encoded[i] = out
+	}
+
+	var msgs []core.MessageWriter
+	if maxCompact, _ := rp.fw.attributePhaseChange(nil); len(encoded) <= maxCompact {
+		for _, data := range encoded {
+			msgs = append(msgs, core.MessageWriter{Type: core.MsgAttribute, Data: data})
+		}
+	} else {
+		dstSB := rp.fw.file.sb
+		daw := writer.NewDenseAttributeWriter(obj.dstAddr)
+		for i, data := range encoded {
+			if err := daw.AddEncodedAttribute(names[i], data); err != nil {
+				return err
+			}
+		}
+		info, err := daw.WriteToFile(rp.fw.writer, rp.fw.writer.Allocator(), dstSB)
+		if err != nil {
+			return fmt.Errorf("write dense attributes: %w", err)
+		}
+		infoMsg, err := core.EncodeAttributeInfoMessage(info, dstSB)
+		if err != nil {
+			return fmt.Errorf("encode attribute info: %w", err)
+		}
+		msgs = append(msgs, core.MessageWriter{Type: core.MsgAttributeInfo, Data: infoMsg})
+	}
+
+	return rp.appendMessages(obj, msgs)
+}
+
+// appendMessages adds messages to a destination object header. They are written in
+// place when they fit the header's allocation, otherwise into a continuation chunk.
+// A version 1 root group header (superblock v0) has no room for a continuation
+// message and is moved to a larger allocation instead.
+func (rp *repacker) appendMessages(obj *repackedObject, msgs []core.MessageWriter) error {
+	fw := rp.fw
+	sb := fw.file.sb
+
+	oh, err := core.ReadObjectHeader(fw.writer.Reader(), obj.dstAddr, sb)
+	if err != nil {
+		return fmt.Errorf("read destination header: %w", err)
+	}
+	oh.Messages = filterMainChunkMessages(oh.Messages)
+	if oh.Version != 2 {
+		return rp.moveRootHeader(obj, oh, msgs)
+	}
+	base := len(oh.Messages)
+	for _, msg := range msgs {
+		if err := core.AddMessageToObjectHeader(oh, msg.Type, msg.Data); err != nil {
+			return fmt.Errorf("add message: %w", err)
+		}
+	}
+
+	allocSz := obj.allocSz
+	if allocSz == 0 {
+		allocSz = fw.lookupHeaderAllocSize(obj.dstAddr)
+	}
+	if core.ObjectHeaderSizeFromParsed(oh) <= allocSz {
+		return writeOHDRWithBoundsCheck(fw, obj.dstAddr, oh, sb)
+	}
+
+	oh.Messages = oh.Messages[:base]
+	ochkSize := core.ContinuationChunkSizeV2(oh.Flags, msgs)
+	ochkAddr, err := fw.writer.Allocate(ochkSize)
+	if err != nil {
+		return fmt.Errorf("allocate continuation chunk: %w", err)
+	}
+	if _, err := core.WriteContinuationChunkV2(fw.writer, ochkAddr, oh.Flags, msgs); err != nil {
+		return fmt.Errorf("write continuation chunk: %w", err)
+	}
+	contMsg := core.EncodeContinuationMessage(ochkAddr, ochkSize, sb)
+	if err := core.AddMessageToObjectHeader(oh, core.MsgContinuation, contMsg); err != nil {
+		return fmt.Errorf("add continuation message: %w", err)
+	}
+	if core.ObjectHeaderSizeFromParsed(oh) > allocSz {
+		return fmt.Errorf("object header at 0x%X has no room for a continuation message", obj.dstAddr)
+	}
+	return writeOHDRWithBoundsCheck(fw, obj.dstAddr, oh, sb)
+}
+
+// moveRootHeader rewrites the (version 1) root group header with msgs appended
+// at a new address. The old header stays allocated: it is part of the block
+// reserved for the root group's symbol table when the file was created.
+func (rp *repacker) moveRootHeader(obj *repackedObject, oh *core.ObjectHeader, msgs []core.MessageWriter) error {
+	fw := rp.fw
+	if obj.dstAddr != fw.rootGroupAddr {
+		return fmt.Errorf("object header version %d at 0x%X is too small", oh.Version, obj.dstAddr)
+	}
+
+	ohw := core.NewObjectHeaderWriterFromParsed(oh)
+	ohw.RefCount = 1
+	ohw.Messages = append(ohw.Messages, msgs...)
+	size := ohw.Size()
+
+	addr, err := fw.writer.Allocate(size)
+	if err != nil {
+		return fmt.Errorf("allocate root group header: %w", err)
+	}
+	if _, err := ohw.WriteTo(fw.writer, addr); err != nil {
+		return fmt.Errorf("write root group header: %w", err)
+	}
+
+	fw.rootGroupAddr = addr
+	fw.rootHeaderAllocSz = size
+	fw.file.sb.RootGroup = addr
+	obj.dstAddr = addr
+	rp.addrs[obj.srcAddr] = addr
+	return nil
+}
+
+// relocFields appends the parts of one element of dt (placed at offset) that hold
+// file addresses: object references and variable-length heap IDs.
+func relocFields(dt *core.DatatypeMessage, offset uint32, offsetSize uint8, fields []relocField) ([]relocField, error) {
+	switch dt.Class {
+	case core.DatatypeReference:
+		// Class bits 0-3: 0 = object reference, 1 = dataset region reference.
+		if dt.ClassBitField&0x0F != 0 || dt.Size != uint32(offsetSize) {
+			return nil, errors.New("dataset region references are not supported")
+		}
+		return append(fields, relocField{offset: offset, dt: dt}), nil
+
+	case core.DatatypeVarLen:
+		if offsetSize != 8 {
+			return nil, fmt.Errorf("variable-length data with %d-byte offsets is not supported", offsetSize)
+		}
+		return append(fields, relocField{offset: offset, dt: dt}), nil
+
+	case core.DatatypeCompound:
+		ct, err := core.ParseCompoundType(dt)
+		if err != nil {
+			return nil, fmt.Errorf("parse compound datatype: %w", err)
+		}
+		for _, m := range ct.Members {
+			fields, err = relocFields(m.Type, offset+m.Offset, offsetSize, fields)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return fields, nil
+
+	case core.DatatypeArray:
+		baseType, count, err := parseArrayDatatype(dt)
+		if err != nil {
+			return nil, err
+		}
+		inner, err := relocFields(baseType, 0, offsetSize, nil)
+		if err != nil || len(inner) == 0 {
+			return fields, err
+		}
+		for i := uint32(0); i < count; i++ {
+			for _, f := range inner {
+				fields = append(fields, relocField{offset: offset + i*baseType.Size + f.offset, dt: f.dt})
+			}
+		}
+		return fields, nil
+	}
+	return fields, nil
+}
+
+// hasReference reports whether any relocatable field is an object reference.
+func hasReference(fields []relocField) bool {
+	for _, f := range fields {
+		if f.dt.Class == core.DatatypeReference {
+			return true
+		}
+	}
+	return false
+}
+
+// parseArrayDatatype returns the base type and element count of an array datatype.
+//
+// Array properties (H5Odtype.c): dimensionality (1 byte), 3 reserved bytes in
+// version 2 only, dimension sizes (4 bytes each), permutation indices (version 2
+// only, 4 bytes each), then the base type.
+func parseArrayDatatype(dt *core.DatatypeMessage) (*core.DatatypeMessage, uint32, error) {
+	props := dt.Properties
+	if len(props) < 1 {
+		return nil, 0, errors.New("array datatype properties too short")
+	}
+	ndims := int(props[0])
+	pos := 1
+	if dt.Version < 3 {
+		pos = 4
+	}
+	if len(props) < pos+4*ndims {
+		return nil, 0, errors.New("array datatype dimensions truncated")
+	}
+	count := uint32(1)
+	for i := 0; i < ndims; i++ {
+		count *= binary.LittleEndian.Uint32(props[pos:])
+		pos += 4
+	}
+	if dt.Version < 3 {
+		pos += 4 * ndims // Permutation indices (unused).
+	}
+	if pos > len(props) {
+		return nil, 0, errors.New("array datatype base type missing")
+	}
+	baseType, err := core.ParseDatatypeMessage(props[pos:])
+	if err != nil {
+		return nil, 0, fmt.Errorf("parse array base type: %w", err)
+	}
+	return baseType, count, nil
+}
+
+// relocateElements rewrites the addresses held by every element of buf in place.
+func (rp *repacker) relocateElements(fields []relocField, elemSize uint32, buf []byte) error {
+	if len(fields) == 0 || elemSize == 0 {
+		return nil
+	}
+	for off := 0; off+int(elemSize) <= len(buf); off += int(elemSize) {
+		for _, f := range fields {
+			if err := rp.relocateField(f.dt, buf[off+int(f.offset):]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// relocateField rewrites one object reference or variable-length heap ID at the start of b.
+func (rp *repacker) relocateField(dt *core.DatatypeMessage, b []byte) error {
+	if dt.Class == core.DatatypeReference {
+		addr := binary.LittleEndian.Uint64(b)
+		if addr == 0 || addr == undefinedAddress {
+			return nil
+		}
+		dstAddr, ok := rp.addrs[addr]
+		if !ok {
+			return fmt.Errorf("reference to object at 0x%X that is not reachable from the root group", addr)
+		}
+		binary.LittleEndian.PutUint64(b, dstAddr)
+		return nil
+	}
+
+	// Variable-length heap ID: sequence length (4) + collection address (8) + object index (4).
+	seqLen := binary.LittleEndian.Uint32(b[0:4])
+	heapAddr := binary.LittleEndian.Uint64(b[4:12])
+	index := binary.LittleEndian.Uint32(b[12:16])
+	if heapAddr == 0 || heapAddr == undefinedAddress {
+		return nil
+	}
+
+	collection, ok := rp.heaps[heapAddr]
+	if !ok {
+		var err error
+		collection, err = core.ReadGlobalHeapCollection(rp.src.reader, heapAddr, int(rp.src.sb.OffsetSize))
+		if err != nil {
+			return fmt.Errorf("read global heap at 0x%X: %w", heapAddr, err)
+		}
+		rp.heaps[heapAddr] = collection
+	}
+	obj, err := collection.GetObject(index)
+	if err != nil {
+		return fmt.Errorf("global heap at 0x%X: %w", heapAddr, err)
+	}
+	data := append([]byte(nil), obj.Data...)
+
+	// Sequences of references or nested variable-length data.
+	baseFields, ok := rp.vlenBases[dt]
+	if !ok {
+		baseType, parseErr := core.ParseDatatypeMessage(dt.Properties)
+		if parseErr == nil {
+			baseFields, err = relocFields(baseType, 0, rp.src.sb.OffsetSize, nil)
+			if err != nil {
+				return err
+			}
+			if len(baseFields) > 0 {
+				baseFields = append(baseFields, relocField{offset: baseType.Size, dt: nil}) // Element size marker.
+			}
+		}
+		rp.vlenBases[dt] = baseFields
+	}
+	if len(baseFields) > 0 {
+		last := len(baseFields) - 1
+		if err := rp.relocateElements(baseFields[:last], baseFields[last].offset, data); err != nil {
+			return err
+		}
+	}
+
+	ensureGlobalHeapWriter(rp.fw)
+	hid, err := rp.fw.globalHeapWriter.WriteToGlobalHeap(data)
+	if err != nil {
+		return fmt.Errorf("write global heap object: %w", err)
+	}
+	hid.SeqLen = seqLen
+	copy(b[:16], hid.Encode())
+	return nil
+}
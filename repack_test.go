@@ -0,0 +1,273 @@
+package hdf5
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/scigolib/hdf5/internal/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeChurnedFile creates a file with live data plus objects and attributes
+// that are deleted again, leaving free space behind.
+func writeChurnedFile(t *testing.T, filename string, opts ...interface{}) {
+	t.Helper()
+
+	fw, err := CreateForWrite(filename, CreateTruncate, opts...)
+	require.NoError(t, err)
+
+	g, err := fw.CreateGroup("/data")
+	require.NoError(t, err)
+	require.NoError(t, g.WriteAttribute("units", "kelvin"))
+
+	plain, err := fw.CreateDataset("/data/plain", Float64, []uint64{4})
+	require.NoError(t, err)
+	require.NoError(t, plain.Write([]float64{1, 2, 3, 4}))
+	for i := 0; i < 10; i++ {
+		require.NoError(t, plain.WriteAttribute(fmt.Sprintf("attr_%02d", i), int32(i)))
+	}
+	require.NoError(t, plain.DeleteAttribute("attr_05"))
+
+	values := make([]float64, 100)
+	for i := range values {
+		values[i] = float64(i) * 0.5
+	}
+	chunked, err := fw.CreateDataset("/data/chunked", Float64, []uint64{100}, WithChunkDims([]uint64{10}))
+	require.NoError(t, err)
+	require.NoError(t, chunked.Write(values))
+	packed, err := fw.CreateDataset("/data/packed", Float64, []uint64{100},
+		WithChunkDims([]uint64{10}), WithGZIPCompression(6))
+	require.NoError(t, err)
+	require.NoError(t, packed.Write(values))
+
+	blobs, err := fw.CreateDataset("/data/blobs", VLenUint8, []uint64{3})
+	require.NoError(t, err)
+	require.NoError(t, blobs.Write([][]byte{{1, 2, 3}, {}, {0xAB}}))
+
+	require.NoError(t, fw.CreateHardLink("/alias", "/data/plain"))
+
+	for i := 0; i < 5; i++ {
+		path := fmt.Sprintf("/data/scratch_%d", i)
+		ds, err := fw.CreateDataset(path, Float64, []uint64{4096})
+		require.NoError(t, err)
+		require.NoError(t, ds.Write(make([]float64, 4096)))
+		require.NoError(t, fw.Delete(path))
+	}
+
+	require.NoError(t, fw.Close())
+}
+
+// datasetMessage returns the raw data of the first message of type msgType in a dataset header.
+func datasetMessage(t *testing.T, f *File, path string, msgType core.MessageType) []byte {
+	t.Helper()
+	oh, err := core.ReadObjectHeader(f.reader, findDatasetByPath(t, f, path).address, f.sb)
+	require.NoError(t, err)
+	for _, msg := range oh.Messages {
+		if msg.Type == msgType {
+			return msg.Data
+		}
+	}
+	return nil
+}
+
+// findDatasetByPath returns the dataset at path in f, failing the test if absent.
+func findDatasetByPath(t *testing.T, f *File, path string) *Dataset {
+	t.Helper()
+	var found *Dataset
+	f.Walk(func(p string, obj Object) {
+		if ds, ok := obj.(*Dataset); ok && p == path {
+			found = ds
+		}
+	})
+	require.NotNil(t, found, "dataset %s not found", path)
+	return found
+}
+
+func TestRepack(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []interface{}
+	}{
+		{"superblock v2", nil},
+		{"superblock v0", []interface{}{WithSuperblockVersion(SuperblockV0)}},
+		{"modern groups", []interface{}{WithModernGroups()}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			src := filepath.Join(dir, "churned.h5")
+			dst := filepath.Join(dir, "compact.h5")
+
+			writeChurnedFile(t, src, tt.opts...)
+			require.NoError(t, Repack(src, dst))
+
+			srcInfo, err := os.Stat(src)
+			require.NoError(t, err)
+			dstInfo, err := os.Stat(dst)
+			require.NoError(t, err)
+			assert.Less(t, dstInfo.Size(), srcInfo.Size(), "repacked file should be smaller")
+
+			f, err := Open(dst)
+			require.NoError(t, err)
+			defer func() { _ = f.Close() }()
+
+			plain, err := findDatasetByPath(t, f, "/data/plain").Read()
+			require.NoError(t, err)
+			assert.Equal(t, []float64{1, 2, 3, 4}, plain)
+
+			alias, err := findDatasetByPath(t, f, "/alias").Read()
+			require.NoError(t, err)
+			assert.Equal(t, plain, alias)
+
+			attrs, err := findDatasetByPath(t, f, "/data/plain").Attributes()
+			require.NoError(t, err)
+			attrNames := make([]string, 0, len(attrs))
+			for _, a := range attrs {
+				attrNames = append(attrNames, a.Name)
+			}
+			assert.Len(t, attrNames, 9)
+			assert.NotContains(t, attrNames, "attr_05")
+			value, err := findDatasetByPath(t, f, "/data/plain").ReadAttribute("attr_09")
+			require.NoError(t, err)
+			assert.EqualValues(t, 9, value)
+
+			chunked, err := findDatasetByPath(t, f, "/data/chunked").Read()
+			require.NoError(t, err)
+			require.Len(t, chunked, 100)
+			assert.InDelta(t, 49.5, chunked[99], 1e-12)
+
+			// Compressed chunks are copied as stored, with the same filter pipeline.
+			orig, err := Open(src)
+			require.NoError(t, err)
+			defer func() { _ = orig.Close() }()
+			pipeline := datasetMessage(t, f, "/data/packed", core.MsgFilterPipeline)
+			require.NotNil(t, pipeline)
+			assert.Equal(t, datasetMessage(t, orig, "/data/packed", core.MsgFilterPipeline), pipeline)
+
+			blobs, err := findDatasetByPath(t, f, "/data/blobs").ReadVLenBytes()
+			require.NoError(t, err)
+			require.Len(t, blobs, 3)
+			assert.Equal(t, []byte{1, 2, 3}, blobs[0])
+			assert.Empty(t, blobs[1])
+			assert.Equal(t, []byte{0xAB}, blobs[2])
+
+			var groupAttrs int
+			f.Walk(func(p string, obj Object) {
+				if g, ok := obj.(*Group); ok && p == "/data/" {
+					a, attrErr := g.Attributes()
+					require.NoError(t, attrErr)
+					groupAttrs = len(a)
+				}
+				assert.NotContains(t, p, "scratch")
+			})
+			assert.Equal(t, 1, groupAttrs)
+		})
+	}
+}
+
+func TestRepack_SameFile(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "same.h5")
+	writeChurnedFile(t, src)
+
+	err := Repack(src, src)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "same file")
+}
+
+func TestRepack_MissingSource(t *testing.T) {
+	dir := t.TempDir()
+	dst := filepath.Join(dir, "out.h5")
+
+	require.Error(t, Repack(filepath.Join(dir, "missing.h5"), dst))
+	_, err := os.Stat(dst)
+	assert.True(t, os.IsNotExist(err), "destination should not be created")
+}
+
+func TestRepack_Links(t *testing.T) {
+	for _, version := range []uint8{SuperblockV0, SuperblockV2} {
+		t.Run(fmt.Sprintf("superblock v%d", version), func(t *testing.T) {
+			dir := t.TempDir()
+			src := filepath.Join(dir, "links.h5")
+			dst := filepath.Join(dir, "compact.h5")
+
+			fw, err := CreateForWrite(src, CreateTruncate, WithSuperblockVersion(version))
+			require.NoError(t, err)
+			_, err = fw.CreateGroup("/g")
+			require.NoError(t, err)
+			ds, err := fw.CreateDataset("/g/data", Int32, []uint64{3})
+			require.NoError(t, err)
+			require.NoError(t, ds.Write([]int32{1, 2, 3}))
+			require.NoError(t, fw.CreateSoftLink("/g/soft", "/g/data"))
+			require.NoError(t, fw.CreateSoftLink("/dangling", "/missing"))
+			require.NoError(t, fw.CreateExternalLink("/g/ext", "other.h5", "/x"))
+			require.NoError(t, fw.Close())
+
+			require.NoError(t, Repack(src, dst))
+
+			f, err := Open(dst)
+			require.NoError(t, err)
+			defer func() { _ = f.Close() }()
+
+			obj, err := f.Root().Open("g")
+			require.NoError(t, err)
+			g, ok := obj.AsGroup()
+			require.True(t, ok)
+			links := map[string]LinkInfo{}
+			for _, g := range []*Group{f.Root(), g} {
+				gl, err := g.Links()
+				require.NoError(t, err)
+				for _, l := range gl {
+					links[l.Name] = l
+				}
+			}
+			assert.Equal(t, LinkHard, links["data"].Type)
+			assert.Equal(t, LinkSoft, links["soft"].Type)
+			assert.Equal(t, "/g/data", links["soft"].Target)
+			assert.Equal(t, LinkSoft, links["dangling"].Type)
+			assert.Equal(t, "/missing", links["dangling"].Target)
+			assert.Equal(t, LinkExternal, links["ext"].Type)
+			assert.Equal(t, "other.h5", links["ext"].ExternalFile)
+			assert.Equal(t, "/x", links["ext"].Target)
+
+			values, err := findDatasetByPath(t, f, "/g/data").Read()
+			require.NoError(t, err)
+			assert.Equal(t, []float64{1, 2, 3}, values)
+		})
+	}
+}
+
+func TestRepack_MaxCompactAttributes(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "churned.h5")
+	writeChurnedFile(t, src)
+
+	// /data/plain keeps 9 attributes: dense by default, compact with a threshold of 16.
+	tests := []struct {
+		name  string
+		opts  []WriteOption
+		dense bool
+	}{
+		{"default", nil, true},
+		{"max compact 16", []WriteOption{WithMaxCompactAttributes(16)}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dst := filepath.Join(dir, "compact.h5")
+			require.NoError(t, Repack(src, dst, tt.opts...))
+
+			f, err := Open(dst)
+			require.NoError(t, err)
+			defer func() { _ = f.Close() }()
+
+			info := datasetMessage(t, f, "/data/plain", core.MsgAttributeInfo)
+			assert.Equal(t, tt.dense, info != nil)
+			attrs, err := findDatasetByPath(t, f, "/data/plain").Attributes()
+			require.NoError(t, err)
+			assert.Len(t, attrs, 9)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package hdf5
+
+import (
+	"fmt"
+	"strings"
+)
+
+// StructureError is returned by Open when a file structure (object header,
+// local heap, B-tree, symbol table node, ...) cannot be parsed. It names the
+// object that was being loaded and the address of the damaged structure.
+// Use errors.As to get it; Cause holds the underlying parse error.
+//
+// Example:
+//
+//	_, err := hdf5.Open("damaged.h5")
+//	var se *hdf5.StructureError
+//	if errors.As(err, &se) {
+//	    fmt.Printf("%s: bad %s at 0x%X\n", se.Path, se.Structure, se.Address)
+//	}
+type StructureError struct {
+	Path      string // Path of the object being loaded ("/" for the root group)
+	Address   uint64 // File address of the structure that failed to parse
+	Structure string // Kind of structure, e.g. "local heap" or "object header"
+	Cause     error  // Underlying parse error
+}
+
+// Error implements the error interface.
+func (e *StructureError) Error() string {
+	return fmt.Sprintf("%s: %s at 0x%X: %v", e.Path, e.Structure, e.Address, e.Cause)
+}
+
+// Unwrap returns the underlying parse error.
+func (e *StructureError) Unwrap() error {
+	return e.Cause
+}
+
+// structureError returns a *StructureError for the object currently being
+// loaded. Returns nil if cause is nil.
+func (f *File) structureError(structure string, address uint64, cause error) error {
+	if cause == nil {
+		return nil
+	}
+	return &StructureError{
+		Path:      f.loadingPath(),
+		Address:   address,
+		Structure: structure,
+		Cause:     cause,
+	}
+}
+
+// loadingPath returns the path of the object being loaded by Open.
+func (f *File) loadingPath() string {
+	return "/" + strings.Join(f.loading, "/")
+}
+
+// enterObject records that the object name at address is being loaded below
+// the current one. The returned function must be called once it is loaded.
+func (f *File) enterObject(name string, address uint64) func() {
+	f.loading = append(f.loading, name)
+	f.loadingAddrs = append(f.loadingAddrs, address)
+	return func() {
+		f.loading = f.loading[:len(f.loading)-1]
+		f.loadingAddrs = f.loadingAddrs[:len(f.loadingAddrs)-1]
+	}
+}
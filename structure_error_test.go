@@ -0,0 +1,53 @@
+package hdf5
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestOpen_StructureError verifies that a damaged local heap is reported as
+// a *StructureError naming the group and the heap address.
+func TestOpen_StructureError(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "damaged.h5")
+
+	fw, err := CreateForWrite(filename, CreateTruncate)
+	require.NoError(t, err)
+	_, err = fw.CreateGroup("/metadata")
+	require.NoError(t, err)
+	ds, err := fw.CreateDataset("/metadata/values", Int32, []uint64{4})
+	require.NoError(t, err)
+	require.NoError(t, ds.Write([]int32{1, 2, 3, 4}))
+	require.NoError(t, fw.Close())
+
+	f, err := Open(filename)
+	require.NoError(t, err)
+	obj, err := f.lookup("/metadata")
+	require.NoError(t, err)
+	group, ok := obj.(*Group)
+	require.True(t, ok)
+	require.NotNil(t, group.symbolTable)
+	heapAddr := group.symbolTable.HeapAddress
+	require.NoError(t, f.Close())
+
+	// Overwrite the "HEAP" signature.
+	file, err := os.OpenFile(filename, os.O_RDWR, 0)
+	require.NoError(t, err)
+	_, err = file.WriteAt([]byte("XXXX"), int64(heapAddr)) //nolint:gosec // G115: test file address
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	_, err = Open(filename)
+	require.Error(t, err)
+
+	var se *StructureError
+	require.True(t, errors.As(err, &se), "error %v is not a StructureError", err)
+	require.Equal(t, "/metadata", se.Path)
+	require.Equal(t, heapAddr, se.Address)
+	require.Equal(t, "local heap", se.Structure)
+	require.ErrorContains(t, se.Cause, "invalid local heap signature")
+	require.ErrorContains(t, err, "/metadata: local heap at 0x")
+}
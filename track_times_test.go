@@ -0,0 +1,150 @@
+package hdf5_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/scigolib/hdf5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTrackTimesFile creates a file with a contiguous dataset, a chunked
+// dataset, a symbol-table group and a link-info group.
+func writeTrackTimesFile(t *testing.T, file string, opts ...interface{}) {
+	t.Helper()
+
+	fw, err := hdf5.CreateForWrite(file, hdf5.CreateTruncate, opts...)
+	require.NoError(t, err)
+
+	ds, err := fw.CreateDataset("/contiguous", hdf5.Int32, []uint64{3})
+	require.NoError(t, err)
+	require.NoError(t, ds.Write([]int32{1, 2, 3}))
+
+	ds, err = fw.CreateDataset("/chunked", hdf5.Int32, []uint64{4}, hdf5.WithChunkDims([]uint64{2}))
+	require.NoError(t, err)
+	require.NoError(t, ds.Write([]int32{1, 2, 3, 4}))
+
+	_, err = fw.CreateGroup("/group")
+	require.NoError(t, err)
+	require.NoError(t, fw.Close())
+}
+
+// modTimes returns the ModTime result of every non-root object in the file.
+func modTimes(t *testing.T, file string) map[string]time.Time {
+	t.Helper()
+
+	f, err := hdf5.Open(file)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	times := make(map[string]time.Time)
+	f.Walk(func(path string, obj hdf5.Object) {
+		var (
+			mt time.Time
+			ok bool
+		)
+		switch o := obj.(type) {
+		case *hdf5.Dataset:
+			mt, ok = o.ModTime()
+		case *hdf5.Group:
+			if path == "/" {
+				return
+			}
+			mt, ok = o.ModTime()
+		default:
+			return
+		}
+		if ok {
+			times[path] = mt
+		}
+	})
+	return times
+}
+
+func TestWithTrackTimes(t *testing.T) {
+	for _, modern := range []bool{false, true} {
+		name := "symbol table groups"
+		opts := []interface{}{hdf5.WithTrackTimes(true)}
+		if modern {
+			name = "link info groups"
+			opts = append(opts, hdf5.WithModernGroups())
+		}
+
+		t.Run(name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "track_times.h5")
+
+			before := time.Now().Add(-time.Second).Truncate(time.Second)
+			writeTrackTimesFile(t, file, opts...)
+			after := time.Now().Add(time.Second)
+
+			times := modTimes(t, file)
+			require.Len(t, times, 3, "got %v", times)
+			for path, mt := range times {
+				assert.False(t, mt.Before(before), "%s: %v before %v", path, mt, before)
+				assert.False(t, mt.After(after), "%s: %v after %v", path, mt, after)
+			}
+		})
+	}
+}
+
+func TestWithTrackTimes_Disabled(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "no_track_times.h5")
+	writeTrackTimesFile(t, file)
+
+	assert.Empty(t, modTimes(t, file))
+}
+
+// creationTimes returns the CreationTime result of every non-root object in
+// the file.
+func creationTimes(t *testing.T, file string) map[string]time.Time {
+	t.Helper()
+
+	f, err := hdf5.Open(file)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	times := make(map[string]time.Time)
+	f.Walk(func(path string, obj hdf5.Object) {
+		var (
+			ct time.Time
+			ok bool
+		)
+		switch o := obj.(type) {
+		case *hdf5.Dataset:
+			ct, ok = o.CreationTime()
+		case *hdf5.Group:
+			if path == "/" {
+				return
+			}
+			ct, ok = o.CreationTime()
+		default:
+			return
+		}
+		if ok {
+			times[path] = ct
+		}
+	})
+	return times
+}
+
+func TestWithTrackTimes_CreationTime(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "creation_times.h5")
+
+	before := time.Now().Add(-time.Second).Truncate(time.Second)
+	writeTrackTimesFile(t, file, hdf5.WithTrackTimes(true), hdf5.WithModernGroups())
+	after := time.Now().Add(time.Second)
+
+	times := creationTimes(t, file)
+	require.Len(t, times, 3, "got %v", times)
+	for path, ct := range times {
+		assert.False(t, ct.Before(before), "%s: %v before %v", path, ct, before)
+		assert.False(t, ct.After(after), "%s: %v after %v", path, ct, after)
+	}
+
+	// Without time tracking no creation time is recorded.
+	untracked := filepath.Join(t.TempDir(), "no_creation_times.h5")
+	writeTrackTimesFile(t, untracked, hdf5.WithModernGroups())
+	assert.Empty(t, creationTimes(t, untracked))
+}
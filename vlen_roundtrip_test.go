@@ -2,10 +2,13 @@ package hdf5
 
 import (
 	"bytes"
+	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/scigolib/hdf5/internal/core"
+	"github.com/stretchr/testify/require"
 )
 
 // TestVLenUint8_RoundTrip writes VLenUint8 data, closes the file, reopens it,
@@ -211,3 +214,55 @@ func assertVLenBytesEqual(t *testing.T, expected, got [][]byte) {
 		}
 	}
 }
+
+// TestVLenStringList_RoundTrip writes lists of strings per element, including
+// empty lists and strings, and enough data to span several heap collections.
+func TestVLenStringList_RoundTrip(t *testing.T) {
+	expected := [][]string{
+		{"alpha", "beta"},
+		{},
+		{""},
+		{"a single, somewhat longer string"},
+	}
+	for i := 0; i < 100; i++ {
+		list := make([]string, i%7)
+		for j := range list {
+			list[j] = fmt.Sprintf("item-%03d-%02d-%s", i, j, "padding to make heap objects larger")
+		}
+		expected = append(expected, list)
+	}
+
+	path := filepath.Join(t.TempDir(), "vlen_string_list.h5")
+	fw, err := CreateForWrite(path, CreateTruncate)
+	require.NoError(t, err)
+	dw, err := fw.CreateDataset("/tags", VLenStringList, []uint64{uint64(len(expected))})
+	require.NoError(t, err)
+	require.NoError(t, dw.Write(expected))
+	require.NoError(t, fw.Close())
+
+	f, err := Open(path)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	ds := findDatasetByPath(t, f, "/tags")
+	got, err := ds.ReadStringLists()
+	require.NoError(t, err)
+	require.Equal(t, expected, got)
+
+	header, err := core.ReadObjectHeader(f.reader, ds.Address(), f.sb)
+	require.NoError(t, err)
+	for _, msg := range header.Messages {
+		if msg.Type != core.MsgDatatype {
+			continue
+		}
+		dt, err := core.ParseDatatypeMessage(msg.Data)
+		require.NoError(t, err)
+		require.False(t, dt.IsVariableString())
+		base, err := core.ParseDatatypeMessage(dt.Properties)
+		require.NoError(t, err)
+		require.True(t, base.IsVariableString())
+	}
+
+	_, err = findDatasetByPath(t, f, "/tags").ReadStrings()
+	require.Error(t, err)
+}
@@ -3,9 +3,11 @@ package hdf5
 import (
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/scigolib/hdf5/internal/core"
 	"github.com/stretchr/testify/require"
 )
 
@@ -219,6 +221,33 @@ func TestVLenStringAttribute_WithEmptyStrings(t *testing.T) {
 	require.Equal(t, input, got)
 }
 
+// TestVLenStringAttribute_Multibyte verifies that non-ASCII strings are
+// stored as UTF-8 and read back unchanged.
+func TestVLenStringAttribute_Multibyte(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "vlen_string_attr_utf8.h5")
+	input := []string{"héllo", "", "日本語"}
+
+	fw, err := CreateForWrite(testFile, CreateTruncate)
+	require.NoError(t, err)
+	group, err := fw.CreateGroup("/grp")
+	require.NoError(t, err)
+	require.NoError(t, group.WriteAttribute("labels", input))
+	require.NoError(t, fw.Close())
+
+	f, err := Open(testFile)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	attrs, err := f.ReadAttributes("/grp")
+	require.NoError(t, err)
+	require.Len(t, attrs, 1)
+	require.Equal(t, uint8(core.CharsetUTF8), attrs[0].Datatype.GetStringCharset())
+
+	val, err := attrs[0].ReadValue()
+	require.NoError(t, err)
+	require.Equal(t, input, val)
+}
+
 // TestVLenStringAttribute_MixedWithOtherAttrs verifies []string alongside scalar attributes.
 func TestVLenStringAttribute_MixedWithOtherAttrs(t *testing.T) {
 	testFile := "test_vlen_string_attr_mixed.h5"
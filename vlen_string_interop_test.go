@@ -0,0 +1,132 @@
+package hdf5
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/scigolib/hdf5/internal/core"
+	"github.com/stretchr/testify/require"
+)
+
+// vlenStringCases covers the strings most likely to expose off-by-one and
+// terminator bugs: empty strings (also first and last), single characters,
+// multibyte UTF-8 and embedded tabs and newlines.
+var vlenStringCases = []string{
+	"",
+	"a",
+	"hello",
+	"héllo",
+	"日本語",
+	"emoji 🚀",
+	"tab\tand\nnewline",
+	"",
+}
+
+// writeVLenStringFile writes vlenStringCases to the dataset /strings of a new
+// file and returns its path.
+func writeVLenStringFile(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "vlen_strings.h5")
+
+	fw, err := CreateForWrite(path, CreateTruncate)
+	require.NoError(t, err)
+	ds, err := fw.CreateDataset("/strings", VLenString, []uint64{uint64(len(vlenStringCases))})
+	require.NoError(t, err)
+	require.NoError(t, ds.Write(vlenStringCases))
+	require.NoError(t, fw.Close())
+	return path
+}
+
+// TestVLenStringDataset_EmptyAndMultibyte checks that empty and multibyte
+// strings read back unchanged, and that the datatype declares UTF-8.
+func TestVLenStringDataset_EmptyAndMultibyte(t *testing.T) {
+	path := writeVLenStringFile(t)
+
+	f, err := Open(path)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	ds := findDatasetByPath(t, f, "/strings")
+	got, err := ds.ReadStrings()
+	require.NoError(t, err)
+	require.Equal(t, vlenStringCases, got)
+
+	info, err := ds.readInfo()
+	require.NoError(t, err)
+	require.True(t, info.Datatype.IsVariableString())
+	require.Equal(t, uint8(core.CharsetUTF8), info.Datatype.GetStringCharset())
+}
+
+// TestVLenStringDataset_HeapLayout checks the on-disk form of each element
+// against the C library: the sequence length is the byte length of the
+// string, and the heap object holds exactly those bytes, with no terminator.
+func TestVLenStringDataset_HeapLayout(t *testing.T) {
+	path := writeVLenStringFile(t)
+
+	f, err := Open(path)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	ds := findDatasetByPath(t, f, "/strings")
+	raw, _, _, err := ds.ReadRaw()
+	require.NoError(t, err)
+	require.Len(t, raw, len(vlenStringCases)*16)
+
+	offsetSize := int(f.sb.OffsetSize)
+	for i, want := range vlenStringCases {
+		elem := raw[i*16 : (i+1)*16]
+		seqLen := binary.LittleEndian.Uint32(elem[0:4])
+		require.Equal(t, uint32(len(want)), seqLen, "element %d: sequence length", i)
+
+		ref, err := core.ParseGlobalHeapReference(elem[4:], offsetSize)
+		require.NoError(t, err)
+		collection, err := core.ReadGlobalHeapCollection(f.reader, ref.HeapAddress, offsetSize)
+		require.NoError(t, err)
+		obj, err := collection.GetObject(ref.ObjectIndex)
+		require.NoError(t, err)
+		require.Equal(t, uint64(len(want)), obj.Size, "element %d: heap object size", i)
+		require.Equal(t, want, string(obj.Data[:obj.Size]), "element %d: heap object data", i)
+	}
+}
+
+// TestVLenStringDataset_H5py reads the strings back with h5py.
+// This test is skipped if python3 or h5py is not available.
+func TestVLenStringDataset_H5py(t *testing.T) {
+	python, err := exec.LookPath("python3")
+	if err != nil {
+		t.Skip("python3 not available")
+	}
+	if err := exec.Command(python, "-c", "import h5py").Run(); err != nil {
+		t.Skip("h5py not available")
+	}
+
+	path := writeVLenStringFile(t)
+
+	const script = `
+import json, sys, h5py
+with h5py.File(sys.argv[1], "r") as f:
+    ds = f["strings"]
+    info = h5py.check_string_dtype(ds.dtype)
+    print(json.dumps({
+        "encoding": info.encoding if info else None,
+        "length": info.length if info else -1,
+        "values": list(ds.asstr()[...]),
+    }))
+`
+	out, err := exec.Command(python, "-c", script, path).CombinedOutput()
+	require.NoError(t, err, "h5py failed: %s", out)
+
+	var result struct {
+		Encoding *string  `json:"encoding"`
+		Length   *int     `json:"length"`
+		Values   []string `json:"values"`
+	}
+	require.NoError(t, json.Unmarshal(out, &result), "h5py output: %s", out)
+	require.NotNil(t, result.Encoding, "h5py does not see a string datatype")
+	require.Equal(t, "utf-8", *result.Encoding)
+	require.Nil(t, result.Length, "h5py sees a fixed-length string")
+	require.Equal(t, vlenStringCases, result.Values)
+}
@@ -46,18 +46,24 @@ func TestWriteCov_InitializeFileWriter_InvalidMode(t *testing.T) {
 	require.Contains(t, err.Error(), "invalid create mode")
 }
 
-// TestWriteCov_CalculateObjectHeaderSize_V1Error tests calculateObjectHeaderSize with v1 header.
-func TestWriteCov_CalculateObjectHeaderSize_V1Error(t *testing.T) {
+// TestWriteCov_CalculateObjectHeaderSize_V1 tests calculateObjectHeaderSize with
+// v1 headers, and that other versions are rejected.
+func TestWriteCov_CalculateObjectHeaderSize_V1(t *testing.T) {
 	ohw := &core.ObjectHeaderWriter{
-		Version: 1, // Not supported
+		Version: 1,
 		Flags:   0,
 		Messages: []core.MessageWriter{
 			{Type: core.MsgDatatype, Data: []byte{1, 2, 3, 4}},
 		},
 	}
-	_, err := calculateObjectHeaderSize(ohw)
+	size, err := calculateObjectHeaderSize(ohw)
+	require.NoError(t, err)
+	require.Equal(t, uint64(16+16), size)
+
+	ohw.Version = 3
+	_, err = calculateObjectHeaderSize(ohw)
 	require.Error(t, err)
-	require.Contains(t, err.Error(), "only object header version 2 supported")
+	require.Contains(t, err.Error(), "unsupported object header version")
 }
 
 // TestWriteCov_FileWriterClose_DoubleClose tests that calling Close twice is safe.
@@ -0,0 +1,189 @@
+package hdf5
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/scigolib/hdf5/internal/core"
+)
+
+// Library defaults for the settings kept in the superblock extension, used
+// when the file does not record them (H5Fprivate.h - H5F_CRT_*_DEF).
+const (
+	defaultChunkIndexK        = 32
+	defaultGroupInternalK     = 16
+	defaultGroupLeafK         = 4
+	defaultFreeSpaceThreshold = 1
+	defaultFileSpacePageSize  = 4096
+)
+
+// ncPropertiesAttr is the root attribute in which netCDF-4 records the
+// netCDF and HDF5 library versions that created the file.
+const ncPropertiesAttr = "_NCProperties"
+
+// WriterInfo describes what the file records about the library that wrote
+// it: the superblock format, the file creation settings kept in the
+// superblock extension, and the library versions noted by netCDF-4. Settings
+// the file does not record hold the library defaults, as h5dump reports them.
+type WriterInfo struct {
+	SuperblockVersion uint8  // Superblock format version (0, 2 or 3)
+	MinLibraryVersion string // Oldest HDF5 release that can read the superblock ("1.0", "1.8" or "1.10")
+	HasExtension      bool   // The file has a superblock extension
+
+	ChunkIndexK    int // Chunk B-tree internal node K (ISTORE_K)
+	GroupInternalK int // Symbol table B-tree internal node K (BTREE_RANK)
+	GroupLeafK     int // Symbol table B-tree leaf node K (BTREE_LEAF)
+
+	FileSpaceStrategy  string // File space strategy, e.g. "H5F_FSPACE_STRATEGY_FSM_AGGR"
+	FreeSpacePersist   bool   // Free-space managers are saved in the file
+	FreeSpaceThreshold uint64 // Smallest free-space section tracked, in bytes
+	PageSize           uint64 // File space page size in bytes
+
+	SharedMessageIndexes int    // Number of shared object header message indexes, 0 if none
+	Driver               string // File driver recorded in the file, "" for the default driver
+
+	NetCDFVersion string // netCDF library version from _NCProperties, "" if absent
+	HDF5Version   string // HDF5 library version from _NCProperties, "" if absent
+
+	Library  string            // Library recorded by WithFileMetadata, "" if absent
+	Metadata map[string]string // String attributes of the superblock extension (see WithFileMetadata)
+}
+
+// WriterInfo returns the library version and file creation settings
+// recorded in the file. The HDF5 library does not store its own version in
+// the file; the superblock format only bounds it from below, and files
+// written through netCDF-4 name both library versions in the root
+// _NCProperties attribute.
+//
+// Example:
+//
+//	info, _ := f.WriterInfo()
+//	fmt.Printf("superblock v%d (HDF5 >= %s), ISTORE_K %d\n",
+//	    info.SuperblockVersion, info.MinLibraryVersion, info.ChunkIndexK)
+//
+// Reference: H5Fsuper.c - H5F__super_read(), H5Pfcpl.c.
+func (f *File) WriterInfo() (WriterInfo, error) {
+	info := WriterInfo{
+		SuperblockVersion:  f.sb.Version,
+		ChunkIndexK:        defaultChunkIndexK,
+		GroupInternalK:     defaultGroupInternalK,
+		GroupLeafK:         defaultGroupLeafK,
+		FileSpaceStrategy:  core.FileSpaceStrategyFSMAggr,
+		FreeSpaceThreshold: defaultFreeSpaceThreshold,
+		PageSize:           defaultFileSpacePageSize,
+	}
+
+	switch f.sb.Version {
+	case core.Version0:
+		info.MinLibraryVersion = "1.0"
+		if f.sb.GroupLeafK != 0 && f.sb.GroupInternalK != 0 {
+			info.GroupLeafK, info.GroupInternalK = int(f.sb.GroupLeafK), int(f.sb.GroupInternalK)
+		}
+		driver, err := core.ReadDriverInfo(f.reader, f.sb)
+		if err != nil {
+			return WriterInfo{}, err
+		}
+		if driver != nil {
+			info.Driver = strings.TrimRight(driver.Name, "\x00")
+		}
+	case core.Version2:
+		info.MinLibraryVersion = "1.8"
+	default:
+		info.MinLibraryVersion = "1.10"
+	}
+
+	ext, err := core.ReadSuperblockExtension(f.reader, f.sb)
+	if err != nil {
+		return WriterInfo{}, err
+	}
+	if ext != nil {
+		info.HasExtension = true
+		if ext.HasBTreeK {
+			info.ChunkIndexK = int(ext.ChunkIndexK)
+			info.GroupInternalK = int(ext.GroupInternalK)
+			info.GroupLeafK = int(ext.GroupLeafK)
+		}
+		if ext.HasFileSpace {
+			info.FileSpaceStrategy = ext.FileSpaceStrategy
+			info.FreeSpacePersist = ext.FreeSpacePersist
+			info.FreeSpaceThreshold = ext.FreeSpaceThreshold
+			if ext.PageSize != 0 {
+				info.PageSize = ext.PageSize
+			}
+		}
+		if ext.HasSharedMessages {
+			info.SharedMessageIndexes = int(ext.SharedIndexes)
+		}
+		info.Driver = ext.Driver
+		if err := info.readExtensionAttributes(ext.Attributes); err != nil {
+			return WriterInfo{}, err
+		}
+	}
+
+	attrs, err := f.root.Attributes()
+	if err != nil {
+		return WriterInfo{}, fmt.Errorf("failed to read root attributes: %w", err)
+	}
+	for _, attr := range attrs {
+		if attr.Name != ncPropertiesAttr {
+			continue
+		}
+		value, err := attr.ReadValue()
+		if err != nil {
+			return WriterInfo{}, fmt.Errorf("failed to read %s: %w", ncPropertiesAttr, err)
+		}
+		if s, ok := value.(string); ok {
+			info.NetCDFVersion, info.HDF5Version = parseNCProperties(s)
+		}
+	}
+	return info, nil
+}
+
+// readExtensionAttributes stores the string attributes of the superblock
+// extension in Metadata, except the library written by WithFileMetadata.
+// Attributes of other types are skipped.
+func (info *WriterInfo) readExtensionAttributes(attrs []*core.Attribute) error {
+	for _, attr := range attrs {
+		value, err := attr.ReadValue()
+		if err != nil {
+			return fmt.Errorf("failed to read superblock extension attribute %q: %w", attr.Name, err)
+		}
+		s, ok := value.(string)
+		if !ok {
+			continue
+		}
+		if attr.Name == libraryAttr {
+			info.Library = s
+			continue
+		}
+		if info.Metadata == nil {
+			info.Metadata = make(map[string]string)
+		}
+		info.Metadata[attr.Name] = s
+	}
+	return nil
+}
+
+// parseNCProperties returns the netCDF and HDF5 versions from an
+// _NCProperties value. Version 1 separates the fields with '|'
+// ("version=1|netcdflibversion=4.6.1|hdf5libversion=1.10.2"), version 2
+// with ',' ("version=2,netcdf=4.9.2,hdf5=1.14.3").
+func parseNCProperties(s string) (netcdf, hdf5 string) {
+	sep := ","
+	if strings.Contains(s, "|") {
+		sep = "|"
+	}
+	for _, field := range strings.Split(s, sep) {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			continue
+		}
+		switch strings.TrimSpace(key) {
+		case "netcdf", "netcdflibversion":
+			netcdf = strings.TrimSpace(value)
+		case "hdf5", "hdf5libversion":
+			hdf5 = strings.TrimSpace(value)
+		}
+	}
+	return netcdf, hdf5
+}
@@ -0,0 +1,82 @@
+package hdf5
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFile_WriterInfo(t *testing.T) {
+	t.Run("b-tree K values", func(t *testing.T) {
+		f, err := Open("testdata/hdf5_official/h5fc_ext1_i.h5")
+		require.NoError(t, err)
+		defer func() { _ = f.Close() }()
+
+		// Matches SUPER_BLOCK in testdata/hdf5_official/ddl/h5fc_ext1_i.ddl.
+		info, err := f.WriterInfo()
+		require.NoError(t, err)
+		require.Equal(t, uint8(2), info.SuperblockVersion)
+		require.Equal(t, "1.8", info.MinLibraryVersion)
+		require.True(t, info.HasExtension)
+		require.Equal(t, 64, info.ChunkIndexK)
+		require.Equal(t, 16, info.GroupInternalK)
+		require.Equal(t, 4, info.GroupLeafK)
+		require.Equal(t, "H5F_FSPACE_STRATEGY_FSM_AGGR", info.FileSpaceStrategy)
+		require.False(t, info.FreeSpacePersist)
+		require.Equal(t, uint64(1), info.FreeSpaceThreshold)
+		require.Equal(t, uint64(4096), info.PageSize)
+	})
+
+	t.Run("file space info", func(t *testing.T) {
+		f, err := Open("testdata/hdf5_official/file_space.h5")
+		require.NoError(t, err)
+		defer func() { _ = f.Close() }()
+
+		// Matches SUPER_BLOCK in testdata/hdf5_official/ddl/file_space.ddl.
+		info, err := f.WriterInfo()
+		require.NoError(t, err)
+		require.Equal(t, 32, info.ChunkIndexK)
+		require.Equal(t, "H5F_FSPACE_STRATEGY_NONE", info.FileSpaceStrategy)
+		require.Equal(t, uint64(8192), info.PageSize)
+		require.Zero(t, info.SharedMessageIndexes)
+	})
+
+	t.Run("version 0 superblock", func(t *testing.T) {
+		f, err := Open("testdata/v0.h5")
+		require.NoError(t, err)
+		defer func() { _ = f.Close() }()
+
+		info, err := f.WriterInfo()
+		require.NoError(t, err)
+		require.Equal(t, "1.0", info.MinLibraryVersion)
+		require.False(t, info.HasExtension)
+		require.Equal(t, 16, info.GroupInternalK)
+		require.Equal(t, 4, info.GroupLeafK)
+		require.Empty(t, info.HDF5Version)
+	})
+
+	t.Run("netCDF-4 properties", func(t *testing.T) {
+		f, err := Open("testdata/dense_links.h5")
+		require.NoError(t, err)
+		defer func() { _ = f.Close() }()
+
+		info, err := f.WriterInfo()
+		require.NoError(t, err)
+		require.Equal(t, "4.10.0", info.NetCDFVersion)
+		require.Equal(t, "2.1.1", info.HDF5Version)
+	})
+}
+
+func TestParseNCProperties(t *testing.T) {
+	netcdf, hdf5 := parseNCProperties("version=1|netcdflibversion=4.6.1|hdf5libversion=1.10.2")
+	require.Equal(t, "4.6.1", netcdf)
+	require.Equal(t, "1.10.2", hdf5)
+
+	netcdf, hdf5 = parseNCProperties("version=2,netcdf=4.9.2,hdf5=1.14.3")
+	require.Equal(t, "4.9.2", netcdf)
+	require.Equal(t, "1.14.3", hdf5)
+
+	netcdf, hdf5 = parseNCProperties("garbage")
+	require.Empty(t, netcdf)
+	require.Empty(t, hdf5)
+}